@@ -0,0 +1,36 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// HcloudAction is a Hetzner Cloud action started by the operator that has not completed yet
+type HcloudAction struct {
+	// id of the action in Hetzner Cloud
+	Id int64 `json:"id"`
+	// command performed by the action, e.g. change_ip_range
+	Command string `json:"command"`
+	// progress of the action in percent
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	Progress int `json:"progress"`
+	// started is the time at which Hetzner Cloud started the action
+	// +optional
+	Started metav1.Time `json:"started,omitzero"`
+}
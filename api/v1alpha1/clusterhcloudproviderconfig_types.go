@@ -0,0 +1,70 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:validation:XValidation:rule="has(self.spec.credentialsRef.__namespace__)",message="spec.credentialsRef.namespace is required for ClusterHcloudProviderConfig"
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`,description="Whether the API token was accepted"
+// +kubebuilder:printcolumn:name="Networks",type=integer,JSONPath=`.status.usage.hcloudNetworks`,description="Number of HcloudNetworks using this provider config"
+// +kubebuilder:printcolumn:name="DnsZones",type=integer,JSONPath=`.status.usage.hcloudDnsZones`,description="Number of HcloudDnsZones using this provider config"
+// +kubebuilder:printcolumn:name="Firewalls",type=integer,JSONPath=`.status.usage.hcloudFirewalls`,description="Number of HcloudFirewalls using this provider config",priority=1
+// +kubebuilder:printcolumn:name="Servers",type=integer,JSONPath=`.status.usage.hcloudServers`,description="Number of HcloudServers using this provider config",priority=1
+// +kubebuilder:printcolumn:name="ServerOperations",type=integer,JSONPath=`.status.usage.hcloudServerOperations`,description="Number of HcloudServerOperations using this provider config",priority=1
+// +kubebuilder:printcolumn:name="Volumes",type=integer,JSONPath=`.status.usage.hcloudVolumes`,description="Number of HcloudVolumes using this provider config",priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`,description="Age of the resource"
+
+// ClusterHcloudProviderConfig is the Schema for the clusterhcloudproviderconfigs API.
+// It holds the same settings as HcloudProviderConfig and can be referenced from any namespace.
+type ClusterHcloudProviderConfig struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitzero"`
+
+	// spec defines the desired state of ClusterHcloudProviderConfig
+	// +required
+	Spec HcloudProviderConfigSpec `json:"spec"`
+
+	// status defines the observed state of ClusterHcloudProviderConfig
+	// +optional
+	Status HcloudProviderConfigStatus `json:"status,omitzero"`
+}
+
+// GetConditions returns the conditions of the ClusterHcloudProviderConfig
+func (in *ClusterHcloudProviderConfig) GetConditions() []metav1.Condition {
+	return in.Status.Conditions
+}
+
+// +kubebuilder:object:root=true
+
+// ClusterHcloudProviderConfigList contains a list of ClusterHcloudProviderConfig
+type ClusterHcloudProviderConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitzero"`
+	Items           []ClusterHcloudProviderConfig `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterHcloudProviderConfig{}, &ClusterHcloudProviderConfigList{})
+}
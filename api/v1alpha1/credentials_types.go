@@ -0,0 +1,30 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// HcloudCredentialsReference selects the Hetzner Cloud API token from a Secret in the
+// namespace of the referencing resource
+type HcloudCredentialsReference struct {
+	// name of the Secret holding the API token
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// key of the API token within the Secret
+	// +optional
+	// +kubebuilder:default=token
+	Key string `json:"key,omitempty"`
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// HcloudDnsZoneReference points at the zone an RRSet belongs to, either through an
+// HcloudDnsZone object in the same namespace or directly through a Hetzner Cloud zone ID.
+// +kubebuilder:validation:XValidation:rule="has(self.name) != has(self.zoneId)",message="Exactly one of name or zoneId must be set"
+type HcloudDnsZoneReference struct {
+	// name of an HcloudDnsZone object in the same namespace
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// zoneId of an existing Hetzner Cloud DNS zone
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	ZoneId int `json:"zoneId,omitempty"`
+}
+
+// HcloudDnsRecord is a single record value of an RRSet
+type HcloudDnsRecord struct {
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	Value string `json:"value"`
+
+	// +optional
+	Comment string `json:"comment,omitempty"`
+}
+
+// HcloudDnsRecordSetSpec defines the desired state of HcloudDnsRecordSet
+type HcloudDnsRecordSetSpec struct {
+	// +required
+	ZoneRef HcloudDnsZoneReference `json:"zoneRef"`
+
+	// name of the RRSet relative to the zone, "@" for the zone apex
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Field name is immutable"
+	Name string `json:"name"`
+
+	// +required
+	// +kubebuilder:validation:Enum=A;AAAA;CNAME;MX;TXT;SRV;CAA;NS
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Field type is immutable"
+	Type string `json:"type"`
+
+	// ttl of the RRSet, the zone default TTL is used when unset
+	// +optional
+	TTL *int `json:"ttl,omitempty"`
+
+	// +required
+	// +kubebuilder:validation:MinItems=1
+	Records []HcloudDnsRecord `json:"records"`
+
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// syncPolicy selects which changes the operator makes to the cloud resource
+	// +optional
+	SyncPolicy *HcloudSyncPolicy `json:"syncPolicy,omitempty"`
+}
+
+// HcloudDnsRecordSetStatus defines the observed state of HcloudDnsRecordSet.
+type HcloudDnsRecordSetStatus struct {
+	// For Kubernetes API conventions, see:
+	// https://github.com/kubernetes/community/blob/master/contributors/devel/sig-architecture/api-conventions.md#typical-status-properties
+	RRSetId string `json:"rrsetId,omitempty"`
+
+	ZoneId int `json:"zoneId,omitempty"`
+
+	TTL *int `json:"ttl,omitempty"`
+
+	Records []HcloudDnsRecord `json:"records,omitempty"`
+
+	Labels map[string]string `json:"labels,omitempty"`
+
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// conditions represent the current state of the HcloudDnsRecordSet resource.
+	// Each condition has a unique type and reflects the status of a specific aspect of the resource.
+	//
+	// Standard condition types include:
+	// - "Available": the resource is fully functional
+	// - "Progressing": the resource is being created or updated
+	// - "Degraded": the resource failed to reach or maintain its desired state
+	//
+	// The status of each condition is one of True, False, or Unknown.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="RRSetId",type=string,JSONPath=`.status.rrsetId`,description="Hetzner Cloud RRSet ID"
+// +kubebuilder:printcolumn:name="ZoneId",type=integer,JSONPath=`.status.zoneId`,description="Hetzner Cloud DNS Zone ID"
+// +kubebuilder:printcolumn:name="ProvisioningState",type=string,JSONPath=`.status.conditions[?(@.type=="Available")].reason`,description="Provisioning state of the record set"
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`,description="Age of the resource"
+
+// HcloudDnsRecordSet is the Schema for the hclouddnsrecordsets API
+type HcloudDnsRecordSet struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitzero"`
+
+	// spec defines the desired state of HcloudDnsRecordSet
+	// +required
+	Spec HcloudDnsRecordSetSpec `json:"spec"`
+
+	// status defines the observed state of HcloudDnsRecordSet
+	// +optional
+	Status HcloudDnsRecordSetStatus `json:"status,omitzero"`
+}
+
+// GetConditions returns the conditions of the HcloudDnsRecordSet
+func (in *HcloudDnsRecordSet) GetConditions() []metav1.Condition {
+	return in.Status.Conditions
+}
+
+// +kubebuilder:object:root=true
+
+// HcloudDnsRecordSetList contains a list of HcloudDnsRecordSet
+type HcloudDnsRecordSetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitzero"`
+	Items           []HcloudDnsRecordSet `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&HcloudDnsRecordSet{}, &HcloudDnsRecordSetList{})
+}
@@ -40,8 +40,39 @@ type HcloudDnsZoneSpec struct {
 	// +optional
 	TTL *int `json:"ttl,omitempty"`
 
+	// primaryNameservers are the nameservers a SECONDARY zone is transferred from.
+	// They are required for SECONDARY zones and not allowed for PRIMARY zones.
+	// +optional
+	PrimaryNameservers []HcloudDnsZonePrimaryNameserver `json:"primaryNameservers,omitempty"`
+
 	// +optional
 	Labels map[string]string `json:"labels,omitempty"`
+
+	// syncPolicy selects which changes the operator makes to the cloud resource
+	// +optional
+	SyncPolicy *HcloudSyncPolicy `json:"syncPolicy,omitempty"`
+	// credentialsRef selects the Secret holding the Hetzner Cloud API token for this resource.
+	// It takes precedence over the token of the provider config.
+	// +optional
+	CredentialsRef *HcloudCredentialsReference `json:"credentialsRef,omitempty"`
+
+	// providerConfigRef selects the provider config used for this resource. The default provider
+	// config of the manager, or the token configured for the manager, is used when unset.
+	// +optional
+	ProviderConfigRef *HcloudProviderConfigReference `json:"providerConfigRef,omitempty"`
+}
+
+// HcloudDnsZonePrimaryNameserver is a nameserver a SECONDARY zone is transferred from
+type HcloudDnsZonePrimaryNameserver struct {
+	// address is the IPv4 or IPv6 address of the nameserver
+	// +required
+	Address string `json:"address"`
+
+	// port of the nameserver, 53 when unset
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int `json:"port,omitempty"`
 }
 
 // HcloudDnsZoneStatus defines the observed state of HcloudDnsZone.
@@ -59,6 +90,10 @@ type HcloudDnsZoneStatus struct {
 
 	Labels map[string]string `json:"labels,omitempty"`
 
+	// pendingActions are the Hetzner Cloud actions started by the operator that are still running
+	// +optional
+	PendingActions []HcloudAction `json:"pendingActions,omitempty"`
+
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 
 	// conditions represent the current state of the HcloudDnsZone resource.
@@ -99,6 +134,11 @@ type HcloudDnsZone struct {
 	Status HcloudDnsZoneStatus `json:"status,omitzero"`
 }
 
+// GetConditions returns the conditions of the HcloudDnsZone
+func (in *HcloudDnsZone) GetConditions() []metav1.Condition {
+	return in.Status.Conditions
+}
+
 // +kubebuilder:object:root=true
 
 // HcloudDnsZoneList contains a list of HcloudDnsZone
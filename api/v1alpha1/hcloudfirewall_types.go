@@ -0,0 +1,252 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// HcloudNetworkReference points at an HcloudNetwork object in the same namespace
+type HcloudNetworkReference struct {
+	// name of the HcloudNetwork object
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+}
+
+// HcloudConfigMapKeyReference selects CIDRs from a ConfigMap in the same namespace
+type HcloudConfigMapKeyReference struct {
+	// name of the ConfigMap
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// key holding the CIDRs, separated by commas or whitespace. The CIDRs of all keys are used when unset.
+	// +optional
+	Key string `json:"key,omitempty"`
+}
+
+// HcloudFirewallIPSource resolves the CIDRs of a rule from Kubernetes objects, so that the rule
+// follows the cluster instead of listing addresses that go stale when nodes are replaced.
+// +kubebuilder:validation:XValidation:rule="(has(self.nodeSelector) ? 1 : 0) + (has(self.networkRef) ? 1 : 0) + (has(self.configMapRef) ? 1 : 0) == 1",message="Exactly one of nodeSelector, networkRef or configMapRef must be set"
+type HcloudFirewallIPSource struct {
+	// nodeSelector selects Kubernetes Nodes whose ExternalIP addresses are used, an empty selector selects all Nodes
+	// +optional
+	NodeSelector *metav1.LabelSelector `json:"nodeSelector,omitempty"`
+	// networkRef selects an HcloudNetwork whose status.ipRange is used
+	// +optional
+	NetworkRef *HcloudNetworkReference `json:"networkRef,omitempty"`
+	// configMapRef selects a ConfigMap listing CIDRs
+	// +optional
+	ConfigMapRef *HcloudConfigMapKeyReference `json:"configMapRef,omitempty"`
+}
+
+// HcloudFirewallRule describes a rule of a Hetzner Cloud firewall. Inbound rules match traffic
+// from sourceIPs and sourceRefs, outbound rules match traffic to destinationIPs and destinationRefs.
+// A rule whose CIDRs all come from references that currently resolve to none, such as a node
+// selector matching no Nodes, is left out of the firewall until they resolve to some.
+// +kubebuilder:validation:XValidation:rule="self.direction != 'in' || ((has(self.sourceIPs) || has(self.sourceRefs)) && !has(self.destinationIPs) && !has(self.destinationRefs))",message="inbound rules require sourceIPs or sourceRefs and do not allow destinationIPs or destinationRefs"
+// +kubebuilder:validation:XValidation:rule="self.direction != 'out' || ((has(self.destinationIPs) || has(self.destinationRefs)) && !has(self.sourceIPs) && !has(self.sourceRefs))",message="outbound rules require destinationIPs or destinationRefs and do not allow sourceIPs or sourceRefs"
+// +kubebuilder:validation:XValidation:rule="(self.protocol == 'tcp' || self.protocol == 'udp') == has(self.port)",message="port is required for tcp and udp rules and not allowed otherwise"
+type HcloudFirewallRule struct {
+	// direction of the traffic the rule matches
+	// +required
+	// +kubebuilder:validation:Enum=in;out
+	Direction string `json:"direction"`
+	// +required
+	// +kubebuilder:validation:Enum=tcp;udp;icmp;esp;gre
+	Protocol string `json:"protocol"`
+	// port or port range such as 80 or 1024-2048, "any" matches all ports
+	// +optional
+	// +kubebuilder:validation:Pattern=`^(any|[0-9]{1,5}(-[0-9]{1,5})?)$`
+	Port string `json:"port,omitempty"`
+	// sourceIPs are the CIDRs inbound traffic is allowed from
+	// +optional
+	// +kubebuilder:validation:MinItems=1
+	SourceIPs []string `json:"sourceIPs,omitempty"`
+	// destinationIPs are the CIDRs outbound traffic is allowed to
+	// +optional
+	// +kubebuilder:validation:MinItems=1
+	DestinationIPs []string `json:"destinationIPs,omitempty"`
+	// sourceRefs resolve further CIDRs inbound traffic is allowed from, the firewall rules are
+	// rewritten whenever the resolved CIDRs change
+	// +optional
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	SourceRefs []HcloudFirewallIPSource `json:"sourceRefs,omitempty"`
+	// destinationRefs resolve further CIDRs outbound traffic is allowed to, the firewall rules are
+	// rewritten whenever the resolved CIDRs change
+	// +optional
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	DestinationRefs []HcloudFirewallIPSource `json:"destinationRefs,omitempty"`
+	// +optional
+	// +kubebuilder:validation:MaxLength=255
+	Description string `json:"description,omitempty"`
+}
+
+// HcloudFirewallApplyTo selects the resources a Hetzner Cloud firewall is applied to
+type HcloudFirewallApplyTo struct {
+	// serverIds of Hetzner Cloud servers the firewall is applied to
+	// +optional
+	// +listType=set
+	ServerIds []int `json:"serverIds,omitempty"`
+	// labelSelectors in Hetzner Cloud syntax, such as role=web, the firewall is applied to all
+	// servers matching any of them, including servers created later
+	// +optional
+	// +listType=set
+	LabelSelectors []string `json:"labelSelectors,omitempty"`
+}
+
+// HcloudFirewallSpec defines the desired state of HcloudFirewall
+type HcloudFirewallSpec struct {
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Field name is immutable"
+	Name string `json:"name"`
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+	// rules of the firewall. Traffic not matched by any rule is dropped, a firewall without
+	// inbound rules blocks all inbound traffic.
+	// +optional
+	// +kubebuilder:validation:MaxItems=50
+	Rules []HcloudFirewallRule `json:"rules,omitempty"`
+	// applyTo selects the resources the firewall is applied to. Resources the firewall is applied
+	// to in Hetzner Cloud that are not listed here are removed from it.
+	// +optional
+	ApplyTo HcloudFirewallApplyTo `json:"applyTo,omitzero"`
+	// syncPolicy selects which changes the operator makes to the cloud resource
+	// +optional
+	SyncPolicy *HcloudSyncPolicy `json:"syncPolicy,omitempty"`
+	// credentialsRef selects the Secret holding the Hetzner Cloud API token for this resource.
+	// It takes precedence over the token of the provider config.
+	// +optional
+	CredentialsRef *HcloudCredentialsReference `json:"credentialsRef,omitempty"`
+	// providerConfigRef selects the provider config used for this resource. The default provider
+	// config of the manager, or the token configured for the manager, is used when unset.
+	// +optional
+	ProviderConfigRef *HcloudProviderConfigReference `json:"providerConfigRef,omitempty"`
+}
+
+// HcloudFirewallResourceState is the state of applying a firewall to a resource
+// +kubebuilder:validation:Enum=Applied;Pending;Failed
+type HcloudFirewallResourceState string
+
+const (
+	// HcloudFirewallResourceApplied means the firewall is in effect on the resource
+	HcloudFirewallResourceApplied HcloudFirewallResourceState = "Applied"
+	// HcloudFirewallResourcePending means the action applying the firewall is still running
+	HcloudFirewallResourcePending HcloudFirewallResourceState = "Pending"
+	// HcloudFirewallResourceFailed means the firewall could not be applied, see the message
+	HcloudFirewallResourceFailed HcloudFirewallResourceState = "Failed"
+)
+
+// HcloudFirewallResourceStatus describes a resource of spec.applyTo and whether the firewall is applied to it
+type HcloudFirewallResourceStatus struct {
+	// type of the resource, server or label_selector
+	Type string `json:"type"`
+
+	ServerId int `json:"serverId,omitempty"`
+
+	LabelSelector string `json:"labelSelector,omitempty"`
+
+	// servers currently matched by the label selector
+	// +optional
+	Servers []int `json:"servers,omitempty"`
+
+	State HcloudFirewallResourceState `json:"state"`
+
+	// actionId of the running action applying the firewall while the state is Pending
+	// +optional
+	ActionId int64 `json:"actionId,omitempty"`
+
+	// message explains why the firewall could not be applied
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// HcloudFirewallStatus defines the observed state of HcloudFirewall.
+type HcloudFirewallStatus struct {
+	// For Kubernetes API conventions, see:
+	// https://github.com/kubernetes/community/blob/master/contributors/devel/sig-architecture/api-conventions.md#typical-status-properties
+	FirewallId int `json:"firewallId,omitempty"`
+
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// rules in effect in Hetzner Cloud, with the references of the spec resolved to CIDRs
+	Rules []HcloudFirewallRule `json:"rules,omitempty"`
+
+	// appliedTo lists the resources of spec.applyTo with the state of applying the firewall to each
+	// +optional
+	AppliedTo []HcloudFirewallResourceStatus `json:"appliedTo,omitempty"`
+
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// conditions represent the current state of the HcloudFirewall resource.
+	// Each condition has a unique type and reflects the status of a specific aspect of the resource.
+	//
+	// Standard condition types include:
+	// - "Available": the resource is fully functional
+	// - "Progressing": the resource is being created or updated
+	// - "Degraded": the resource failed to reach or maintain its desired state
+	//
+	// The status of each condition is one of True, False, or Unknown.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="FirewallId",type=integer,JSONPath=`.status.firewallId`,description="Hetzner Cloud Firewall ID"
+// +kubebuilder:printcolumn:name="ProvisioningState",type=string,JSONPath=`.status.conditions[?(@.type=="Available")].reason`,description="Provisioning state of the firewall"
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`,description="Age of the resource"
+
+// HcloudFirewall is the Schema for the hcloudfirewalls API
+type HcloudFirewall struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitzero"`
+
+	// spec defines the desired state of HcloudFirewall
+	// +required
+	Spec HcloudFirewallSpec `json:"spec"`
+
+	// status defines the observed state of HcloudFirewall
+	// +optional
+	Status HcloudFirewallStatus `json:"status,omitzero"`
+}
+
+// GetConditions returns the conditions of the HcloudFirewall
+func (in *HcloudFirewall) GetConditions() []metav1.Condition {
+	return in.Status.Conditions
+}
+
+// +kubebuilder:object:root=true
+
+// HcloudFirewallList contains a list of HcloudFirewall
+type HcloudFirewallList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitzero"`
+	Items           []HcloudFirewall `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&HcloudFirewall{}, &HcloudFirewallList{})
+}
@@ -23,6 +23,48 @@ import (
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
+// HcloudNetworkSubnet describes a subnet of a Hetzner Cloud network
+// +kubebuilder:validation:XValidation:rule="self.type != 'vswitch' || has(self.vSwitchId)",message="vSwitchId is required for vswitch subnets"
+// +kubebuilder:validation:XValidation:rule="self.type == 'vswitch' || !has(self.vSwitchId)",message="vSwitchId is only allowed for vswitch subnets"
+type HcloudNetworkSubnet struct {
+	// +required
+	// +kubebuilder:validation:Enum=cloud;server;vswitch
+	Type string `json:"type"`
+	// +required
+	// +kubebuilder:validation:Enum=eu-central;us-east;us-west;ap-southeast
+	NetworkZone string `json:"networkZone"`
+	// +required
+	// +kubebuilder:validation:Pattern=`^([0-9]{1,3}\.){3}[0-9]{1,3}\/([0-9]{1,2})$`
+	IpRange string `json:"ipRange"`
+	// +optional
+	VSwitchId int `json:"vSwitchId,omitempty"`
+}
+
+// HcloudNetworkSubnetStatus describes an observed subnet of a Hetzner Cloud network
+type HcloudNetworkSubnetStatus struct {
+	Type string `json:"type"`
+
+	NetworkZone string `json:"networkZone"`
+
+	IpRange string `json:"ipRange"`
+
+	Gateway string `json:"gateway,omitempty"`
+
+	VSwitchId int `json:"vSwitchId,omitempty"`
+}
+
+// HcloudNetworkRoute describes a static route of a Hetzner Cloud network
+type HcloudNetworkRoute struct {
+	// destination network the route applies to
+	// +required
+	// +kubebuilder:validation:Pattern=`^([0-9]{1,3}\.){3}[0-9]{1,3}\/([0-9]{1,2})$`
+	Destination string `json:"destination"`
+	// gateway the traffic is routed through, must lie inside spec.ipRange
+	// +required
+	// +kubebuilder:validation:Pattern=`^([0-9]{1,3}\.){3}[0-9]{1,3}$`
+	Gateway string `json:"gateway"`
+}
+
 // HcloudNetworkSpec defines the desired state of HcloudNetwork
 type HcloudNetworkSpec struct {
 	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
@@ -38,6 +80,31 @@ type HcloudNetworkSpec struct {
 	IpRange string `json:"ipRange"`
 	// +optional
 	Labels map[string]string `json:"labels,omitempty"`
+	// subnets of the network. Subnets are only reconciled when the list is set, in which case
+	// subnets missing from this list are removed from the network. An empty list removes all
+	// subnets, leaving the field unset leaves the subnets of the network alone.
+	// +optional
+	// +listType=map
+	// +listMapKey=ipRange
+	Subnets []HcloudNetworkSubnet `json:"subnets"`
+	// routes of the network. Routes are only reconciled when the list is set, in which case
+	// routes missing from this list are removed from the network. An empty list removes all
+	// routes, leaving the field unset leaves the routes of the network alone.
+	// +optional
+	// +listType=map
+	// +listMapKey=destination
+	Routes []HcloudNetworkRoute `json:"routes"`
+	// syncPolicy selects which changes the operator makes to the cloud resource
+	// +optional
+	SyncPolicy *HcloudSyncPolicy `json:"syncPolicy,omitempty"`
+	// credentialsRef selects the Secret holding the Hetzner Cloud API token for this resource.
+	// It takes precedence over the token of the provider config.
+	// +optional
+	CredentialsRef *HcloudCredentialsReference `json:"credentialsRef,omitempty"`
+	// providerConfigRef selects the provider config used for this resource. The default provider
+	// config of the manager, or the token configured for the manager, is used when unset.
+	// +optional
+	ProviderConfigRef *HcloudProviderConfigReference `json:"providerConfigRef,omitempty"`
 }
 
 // HcloudNetworkStatus defines the observed state of HcloudNetwork.
@@ -54,6 +121,14 @@ type HcloudNetworkStatus struct {
 
 	Labels map[string]string `json:"labels,omitempty"`
 
+	Subnets []HcloudNetworkSubnetStatus `json:"subnets,omitempty"`
+
+	Routes []HcloudNetworkRoute `json:"routes,omitempty"`
+
+	// pendingActions are the Hetzner Cloud actions started by the operator that are still running
+	// +optional
+	PendingActions []HcloudAction `json:"pendingActions,omitempty"`
+
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 
 	// conditions represent the current state of the HcloudNetwork resource.
@@ -94,6 +169,11 @@ type HcloudNetwork struct {
 	Status HcloudNetworkStatus `json:"status,omitzero"`
 }
 
+// GetConditions returns the conditions of the HcloudNetwork
+func (in *HcloudNetwork) GetConditions() []metav1.Condition {
+	return in.Status.Conditions
+}
+
 // +kubebuilder:object:root=true
 
 // HcloudNetworkList contains a list of HcloudNetwork
@@ -0,0 +1,151 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// HcloudSecretKeyReference selects the Hetzner Cloud API token from a Secret
+type HcloudSecretKeyReference struct {
+	// name of the Secret holding the API token
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// namespace of the Secret. Required for ClusterHcloudProviderConfig, ignored for
+	// HcloudProviderConfig which always reads the Secret from its own namespace.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// key of the API token within the Secret
+	// +optional
+	// +kubebuilder:default=token
+	Key string `json:"key,omitempty"`
+}
+
+// HcloudProviderConfigSpec defines the desired state of HcloudProviderConfig and ClusterHcloudProviderConfig
+type HcloudProviderConfigSpec struct {
+	// credentialsRef selects the Secret holding the Hetzner Cloud API token
+	// +required
+	CredentialsRef HcloudSecretKeyReference `json:"credentialsRef"`
+
+	// endpoint overrides the Hetzner Cloud API endpoint
+	// +optional
+	// +kubebuilder:validation:Pattern=`^https?://`
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// pollInterval is the interval used to poll the state of Hetzner Cloud actions
+	// +optional
+	PollInterval *metav1.Duration `json:"pollInterval,omitempty"`
+
+	// defaultLabels are added to every cloud resource managed through this provider config.
+	// Labels set on the resource itself take precedence.
+	// +optional
+	DefaultLabels map[string]string `json:"defaultLabels,omitempty"`
+
+	// defaultSyncPolicy is used for resources that set neither spec.syncPolicy.mode nor the
+	// sync-policy annotation
+	// +optional
+	DefaultSyncPolicy SyncPolicyMode `json:"defaultSyncPolicy,omitempty"`
+}
+
+// HcloudProviderConfigUsage counts the resources using a provider config
+type HcloudProviderConfigUsage struct {
+	HcloudNetworks int `json:"hcloudNetworks"`
+
+	HcloudDnsZones int `json:"hcloudDnsZones"`
+
+	HcloudFirewalls int `json:"hcloudFirewalls"`
+
+	HcloudServers int `json:"hcloudServers"`
+
+	HcloudServerOperations int `json:"hcloudServerOperations"`
+
+	HcloudVolumes int `json:"hcloudVolumes"`
+}
+
+// HcloudProviderConfigStatus defines the observed state of HcloudProviderConfig and ClusterHcloudProviderConfig
+type HcloudProviderConfigStatus struct {
+	Usage HcloudProviderConfigUsage `json:"usage,omitzero"`
+
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// conditions represent the current state of the provider config.
+	// The "Ready" condition reports whether the API token was accepted by Hetzner Cloud.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// HcloudProviderConfigReference references the provider config used to reconcile a resource
+type HcloudProviderConfigReference struct {
+	// kind of the provider config
+	// +optional
+	// +kubebuilder:validation:Enum=HcloudProviderConfig;ClusterHcloudProviderConfig
+	// +kubebuilder:default=HcloudProviderConfig
+	Kind string `json:"kind,omitempty"`
+	// name of the provider config. HcloudProviderConfigs are looked up in the namespace of the resource.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`,description="Whether the API token was accepted"
+// +kubebuilder:printcolumn:name="Networks",type=integer,JSONPath=`.status.usage.hcloudNetworks`,description="Number of HcloudNetworks using this provider config"
+// +kubebuilder:printcolumn:name="DnsZones",type=integer,JSONPath=`.status.usage.hcloudDnsZones`,description="Number of HcloudDnsZones using this provider config"
+// +kubebuilder:printcolumn:name="Firewalls",type=integer,JSONPath=`.status.usage.hcloudFirewalls`,description="Number of HcloudFirewalls using this provider config",priority=1
+// +kubebuilder:printcolumn:name="Servers",type=integer,JSONPath=`.status.usage.hcloudServers`,description="Number of HcloudServers using this provider config",priority=1
+// +kubebuilder:printcolumn:name="ServerOperations",type=integer,JSONPath=`.status.usage.hcloudServerOperations`,description="Number of HcloudServerOperations using this provider config",priority=1
+// +kubebuilder:printcolumn:name="Volumes",type=integer,JSONPath=`.status.usage.hcloudVolumes`,description="Number of HcloudVolumes using this provider config",priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`,description="Age of the resource"
+
+// HcloudProviderConfig is the Schema for the hcloudproviderconfigs API
+type HcloudProviderConfig struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitzero"`
+
+	// spec defines the desired state of HcloudProviderConfig
+	// +required
+	Spec HcloudProviderConfigSpec `json:"spec"`
+
+	// status defines the observed state of HcloudProviderConfig
+	// +optional
+	Status HcloudProviderConfigStatus `json:"status,omitzero"`
+}
+
+// GetConditions returns the conditions of the HcloudProviderConfig
+func (in *HcloudProviderConfig) GetConditions() []metav1.Condition {
+	return in.Status.Conditions
+}
+
+// +kubebuilder:object:root=true
+
+// HcloudProviderConfigList contains a list of HcloudProviderConfig
+type HcloudProviderConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitzero"`
+	Items           []HcloudProviderConfig `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&HcloudProviderConfig{}, &HcloudProviderConfigList{})
+}
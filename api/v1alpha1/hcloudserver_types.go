@@ -0,0 +1,246 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// HcloudObjectKeyReference selects a key of a Secret or ConfigMap in the same namespace
+type HcloudObjectKeyReference struct {
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	Key string `json:"key"`
+}
+
+// HcloudServerUserData selects the cloud-init user data of a server from a Secret or a ConfigMap
+// +kubebuilder:validation:XValidation:rule="has(self.secretKeyRef) != has(self.configMapKeyRef)",message="Exactly one of secretKeyRef or configMapKeyRef must be set"
+type HcloudServerUserData struct {
+	// +optional
+	SecretKeyRef *HcloudObjectKeyReference `json:"secretKeyRef,omitempty"`
+	// +optional
+	ConfigMapKeyRef *HcloudObjectKeyReference `json:"configMapKeyRef,omitempty"`
+}
+
+// HcloudServerNetwork attaches a server to the network of an HcloudNetwork
+type HcloudServerNetwork struct {
+	// name of an HcloudNetwork object in the same namespace
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// ip is the fixed private IP of the server in the network, Hetzner Cloud picks one when unset.
+	// Changing it detaches the server from the network and attaches it again.
+	// +optional
+	// +kubebuilder:validation:Format=ipv4
+	IP string `json:"ip,omitempty"`
+}
+
+// HcloudServerFirewall applies a firewall to a server, either through an HcloudFirewall object in
+// the same namespace or directly through a Hetzner Cloud firewall ID. An HcloudFirewall removes
+// servers it does not list in its applyTo, unless applyTo is ignored by its sync policy.
+// +kubebuilder:validation:XValidation:rule="has(self.name) != has(self.firewallId)",message="Exactly one of name or firewallId must be set"
+type HcloudServerFirewall struct {
+	// name of an HcloudFirewall object in the same namespace
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// firewallId of an existing Hetzner Cloud firewall
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	FirewallId int `json:"firewallId,omitempty"`
+}
+
+// HcloudServerPublicNet selects the public addresses of a server
+type HcloudServerPublicNet struct {
+	// enableIPv4 assigns a public IPv4 address, defaults to true
+	// +optional
+	EnableIPv4 *bool `json:"enableIPv4,omitempty"`
+	// enableIPv6 assigns a public IPv6 network, defaults to true
+	// +optional
+	EnableIPv6 *bool `json:"enableIPv6,omitempty"`
+}
+
+// HcloudServerSpec defines the desired state of HcloudServer. Labels, networks and firewalls are
+// updated in place. The image, SSH keys and user data are only used when the server is created,
+// while changes to the server type, location, placement group and public addresses need the server
+// to be shut down or replaced; they are reported on the RequiresReplacement condition instead.
+type HcloudServerSpec struct {
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Field name is immutable"
+	Name string `json:"name"`
+	// serverType such as cx22
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	ServerType string `json:"serverType"`
+	// image name such as ubuntu-24.04, or the ID of an image or snapshot
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	Image string `json:"image"`
+	// location such as fsn1, Hetzner Cloud picks one when unset
+	// +optional
+	Location string `json:"location,omitempty"`
+	// placementGroupId of an existing Hetzner Cloud placement group
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	PlacementGroupId int `json:"placementGroupId,omitempty"`
+	// sshKeys are the names of Hetzner Cloud SSH keys allowed to log in as root
+	// +optional
+	// +listType=set
+	SSHKeys []string `json:"sshKeys,omitempty"`
+	// userData selects the cloud-init configuration of the server
+	// +optional
+	UserData *HcloudServerUserData `json:"userData,omitempty"`
+	// networks the server is attached to
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Networks []HcloudServerNetwork `json:"networks,omitempty"`
+	// firewalls applied to the server
+	// +optional
+	Firewalls []HcloudServerFirewall `json:"firewalls,omitempty"`
+	// +optional
+	PublicNet HcloudServerPublicNet `json:"publicNet,omitzero"`
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+	// syncPolicy selects which changes the operator makes to the cloud resource
+	// +optional
+	SyncPolicy *HcloudSyncPolicy `json:"syncPolicy,omitempty"`
+	// credentialsRef selects the Secret holding the Hetzner Cloud API token for this resource.
+	// It takes precedence over the token of the provider config.
+	// +optional
+	CredentialsRef *HcloudCredentialsReference `json:"credentialsRef,omitempty"`
+	// providerConfigRef selects the provider config used for this resource. The default provider
+	// config of the manager, or the token configured for the manager, is used when unset.
+	// +optional
+	ProviderConfigRef *HcloudProviderConfigReference `json:"providerConfigRef,omitempty"`
+}
+
+// HcloudServerPrivateNet describes a network the server is attached to
+type HcloudServerPrivateNet struct {
+	// name of the HcloudNetwork of spec.networks, empty for networks attached by other tools
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	NetworkId int `json:"networkId"`
+
+	IP string `json:"ip,omitempty"`
+
+	// +optional
+	AliasIPs []string `json:"aliasIPs,omitempty"`
+}
+
+// HcloudServerFirewallStatus describes a firewall applied to the server
+type HcloudServerFirewallStatus struct {
+	FirewallId int `json:"firewallId"`
+
+	// status of the firewall on the server, applied or pending
+	Status string `json:"status"`
+}
+
+// HcloudServerStatus defines the observed state of HcloudServer.
+type HcloudServerStatus struct {
+	// For Kubernetes API conventions, see:
+	// https://github.com/kubernetes/community/blob/master/contributors/devel/sig-architecture/api-conventions.md#typical-status-properties
+	ServerId int `json:"serverId,omitempty"`
+
+	// status of the server in Hetzner Cloud, such as running, off or initializing
+	Status string `json:"status,omitempty"`
+
+	ServerType string `json:"serverType,omitempty"`
+
+	Location string `json:"location,omitempty"`
+
+	Image string `json:"image,omitempty"`
+
+	PublicIPv4 string `json:"publicIPv4,omitempty"`
+
+	// publicIPv6 is the IPv6 network assigned to the server
+	PublicIPv6 string `json:"publicIPv6,omitempty"`
+
+	PrivateNets []HcloudServerPrivateNet `json:"privateNets,omitempty"`
+
+	Firewalls []HcloudServerFirewallStatus `json:"firewalls,omitempty"`
+
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// pendingActions are the Hetzner Cloud actions started by the operator that are still running
+	// +optional
+	PendingActions []HcloudAction `json:"pendingActions,omitempty"`
+
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// conditions represent the current state of the HcloudServer resource.
+	// Each condition has a unique type and reflects the status of a specific aspect of the resource.
+	//
+	// Standard condition types include:
+	// - "Available": the resource is fully functional
+	// - "Progressing": the resource is being created or updated
+	// - "RequiresReplacement": the spec differs in fields that cannot be changed in place
+	//
+	// The status of each condition is one of True, False, or Unknown.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="ServerId",type=integer,JSONPath=`.status.serverId`,description="Hetzner Cloud Server ID"
+// +kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.status`,description="Status of the server in Hetzner Cloud"
+// +kubebuilder:printcolumn:name="IPv4",type=string,JSONPath=`.status.publicIPv4`,description="Public IPv4 address of the server"
+// +kubebuilder:printcolumn:name="ProvisioningState",type=string,JSONPath=`.status.conditions[?(@.type=="Available")].reason`,description="Provisioning state of the server"
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`,description="Age of the resource"
+
+// HcloudServer is the Schema for the hcloudservers API
+type HcloudServer struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitzero"`
+
+	// spec defines the desired state of HcloudServer
+	// +required
+	Spec HcloudServerSpec `json:"spec"`
+
+	// status defines the observed state of HcloudServer
+	// +optional
+	Status HcloudServerStatus `json:"status,omitzero"`
+}
+
+// GetConditions returns the conditions of the HcloudServer
+func (in *HcloudServer) GetConditions() []metav1.Condition {
+	return in.Status.Conditions
+}
+
+// +kubebuilder:object:root=true
+
+// HcloudServerList contains a list of HcloudServer
+type HcloudServerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitzero"`
+	Items           []HcloudServer `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&HcloudServer{}, &HcloudServerList{})
+}
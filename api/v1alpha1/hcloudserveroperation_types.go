@@ -0,0 +1,252 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// HcloudServerOperationType is the day-2 operation performed on a server
+// +kubebuilder:validation:Enum=PowerOn;PowerOff;Shutdown;Reboot;Reset;EnableRescue;Rebuild;ChangeType;CreateSnapshot
+type HcloudServerOperationType string
+
+const (
+	// ServerOperationPowerOn starts a stopped server
+	ServerOperationPowerOn HcloudServerOperationType = "PowerOn"
+	// ServerOperationPowerOff cuts the power of a server, like pulling the plug
+	ServerOperationPowerOff HcloudServerOperationType = "PowerOff"
+	// ServerOperationShutdown sends an ACPI shutdown request to a server
+	ServerOperationShutdown HcloudServerOperationType = "Shutdown"
+	// ServerOperationReboot sends an ACPI reboot request to a server
+	ServerOperationReboot HcloudServerOperationType = "Reboot"
+	// ServerOperationReset cuts the power of a server and starts it again
+	ServerOperationReset HcloudServerOperationType = "Reset"
+	// ServerOperationEnableRescue enables the rescue system and resets the server to boot into it
+	ServerOperationEnableRescue HcloudServerOperationType = "EnableRescue"
+	// ServerOperationRebuild reinstalls a server from an image, destroying all data on its disk
+	ServerOperationRebuild HcloudServerOperationType = "Rebuild"
+	// ServerOperationChangeType shuts a server down, changes its server type and starts it again
+	ServerOperationChangeType HcloudServerOperationType = "ChangeType"
+	// ServerOperationCreateSnapshot creates a snapshot image of the disk of a server
+	ServerOperationCreateSnapshot HcloudServerOperationType = "CreateSnapshot"
+)
+
+// HcloudServerOperationPhase is the phase of an operation or of one of its steps
+type HcloudServerOperationPhase string
+
+const (
+	// ServerOperationPending has not started yet
+	ServerOperationPending HcloudServerOperationPhase = "Pending"
+	// ServerOperationRunning waits for a Hetzner Cloud action to complete
+	ServerOperationRunning HcloudServerOperationPhase = "Running"
+	// ServerOperationSucceeded completed successfully
+	ServerOperationSucceeded HcloudServerOperationPhase = "Succeeded"
+	// ServerOperationFailed stopped with an error, the operation is not retried
+	ServerOperationFailed HcloudServerOperationPhase = "Failed"
+)
+
+// HcloudServerOperationTarget selects the server of an operation by its Hetzner Cloud ID or name
+// +kubebuilder:validation:XValidation:rule="has(self.serverId) != has(self.name)",message="Exactly one of serverId or name must be set"
+type HcloudServerOperationTarget struct {
+	// serverId of the Hetzner Cloud server
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	ServerId int `json:"serverId,omitempty"`
+	// name of the server in Hetzner Cloud
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name,omitempty"`
+}
+
+// HcloudServerRescue configures the rescue system enabled by EnableRescue
+type HcloudServerRescue struct {
+	// type of the rescue system
+	// +optional
+	// +kubebuilder:default=linux64
+	// +kubebuilder:validation:Enum=linux64
+	Type string `json:"type,omitempty"`
+	// sshKeys are the names of Hetzner Cloud SSH keys allowed to log in to the rescue system
+	// +optional
+	// +listType=set
+	SSHKeys []string `json:"sshKeys,omitempty"`
+}
+
+// HcloudServerRebuild configures the image installed by Rebuild
+type HcloudServerRebuild struct {
+	// image name such as ubuntu-24.04, or the ID of an image or snapshot
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	Image string `json:"image"`
+}
+
+// HcloudServerChangeType configures the server type set by ChangeType
+type HcloudServerChangeType struct {
+	// serverType such as cx32
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	ServerType string `json:"serverType"`
+	// upgradeDisk grows the disk to the size of the new server type. The server type cannot be
+	// downgraded again afterwards.
+	// +optional
+	UpgradeDisk bool `json:"upgradeDisk,omitempty"`
+	// shutdownTimeout is how long to wait for the server to shut down before the operation fails,
+	// defaults to 5 minutes
+	// +optional
+	ShutdownTimeout *metav1.Duration `json:"shutdownTimeout,omitempty"`
+}
+
+// HcloudServerSnapshot configures the snapshot image created by CreateSnapshot
+type HcloudServerSnapshot struct {
+	// description of the snapshot image
+	// +optional
+	Description string `json:"description,omitempty"`
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// HcloudServerOperationSpec defines the desired state of HcloudServerOperation. Like a Job, an
+// operation runs once; the spec cannot be changed once the operation has started.
+// +kubebuilder:validation:XValidation:rule="self.operation == 'EnableRescue' || !has(self.rescue)",message="rescue is only allowed for the EnableRescue operation"
+// +kubebuilder:validation:XValidation:rule="self.operation == 'Rebuild' ? has(self.rebuild) : !has(self.rebuild)",message="rebuild must be set for the Rebuild operation only"
+// +kubebuilder:validation:XValidation:rule="self.operation == 'ChangeType' ? has(self.changeType) : !has(self.changeType)",message="changeType must be set for the ChangeType operation only"
+// +kubebuilder:validation:XValidation:rule="self.operation == 'CreateSnapshot' || !has(self.snapshot)",message="snapshot is only allowed for the CreateSnapshot operation"
+type HcloudServerOperationSpec struct {
+	// server the operation is performed on
+	// +required
+	Server HcloudServerOperationTarget `json:"server"`
+	// operation performed on the server
+	// +required
+	Operation HcloudServerOperationType `json:"operation"`
+	// +optional
+	Rescue *HcloudServerRescue `json:"rescue,omitempty"`
+	// +optional
+	Rebuild *HcloudServerRebuild `json:"rebuild,omitempty"`
+	// +optional
+	ChangeType *HcloudServerChangeType `json:"changeType,omitempty"`
+	// +optional
+	Snapshot *HcloudServerSnapshot `json:"snapshot,omitempty"`
+	// credentialsRef selects the Secret holding the Hetzner Cloud API token for this resource.
+	// It takes precedence over the token of the provider config.
+	// +optional
+	CredentialsRef *HcloudCredentialsReference `json:"credentialsRef,omitempty"`
+	// providerConfigRef selects the provider config used for this resource. The default provider
+	// config of the manager, or the token configured for the manager, is used when unset.
+	// +optional
+	ProviderConfigRef *HcloudProviderConfigReference `json:"providerConfigRef,omitempty"`
+}
+
+// HcloudServerOperationStep is a step of an operation, backed by one Hetzner Cloud action
+type HcloudServerOperationStep struct {
+	// name of the step, the command of its Hetzner Cloud action such as shutdown_server
+	Name string `json:"name"`
+
+	Phase HcloudServerOperationPhase `json:"phase"`
+
+	// actionId of the Hetzner Cloud action performing the step
+	// +optional
+	ActionId int64 `json:"actionId,omitempty"`
+
+	// progress of the action in percent
+	// +optional
+	Progress int `json:"progress,omitempty"`
+
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// message describes the result of the step or why it failed
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// HcloudServerOperationStatus defines the observed state of HcloudServerOperation.
+type HcloudServerOperationStatus struct {
+	// For Kubernetes API conventions, see:
+	// https://github.com/kubernetes/community/blob/master/contributors/devel/sig-architecture/api-conventions.md#typical-status-properties
+	Phase HcloudServerOperationPhase `json:"phase,omitempty"`
+
+	// serverId of the server the operation is performed on
+	ServerId int `json:"serverId,omitempty"`
+
+	// steps of the operation in the order they are performed
+	Steps []HcloudServerOperationStep `json:"steps,omitempty"`
+
+	// imageId of the snapshot image created by CreateSnapshot
+	ImageId int `json:"imageId,omitempty"`
+
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// conditions represent the current state of the HcloudServerOperation resource.
+	// Each condition has a unique type and reflects the status of a specific aspect of the resource.
+	//
+	// Standard condition types include:
+	// - "Available": the operation succeeded
+	// - "Progressing": a step of the operation is running
+	//
+	// The status of each condition is one of True, False, or Unknown.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:validation:XValidation:rule="!has(oldSelf.status) || !has(oldSelf.status.startTime) || self.spec == oldSelf.spec",message="spec is immutable once the operation has started"
+// +kubebuilder:printcolumn:name="Operation",type=string,JSONPath=`.spec.operation`,description="Operation performed on the server"
+// +kubebuilder:printcolumn:name="ServerId",type=integer,JSONPath=`.status.serverId`,description="Hetzner Cloud Server ID"
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`,description="Phase of the operation"
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`,description="Age of the resource"
+
+// HcloudServerOperation is the Schema for the hcloudserveroperations API
+type HcloudServerOperation struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitzero"`
+
+	// spec defines the desired state of HcloudServerOperation
+	// +required
+	Spec HcloudServerOperationSpec `json:"spec"`
+
+	// status defines the observed state of HcloudServerOperation
+	// +optional
+	Status HcloudServerOperationStatus `json:"status,omitzero"`
+}
+
+// GetConditions returns the conditions of the HcloudServerOperation
+func (in *HcloudServerOperation) GetConditions() []metav1.Condition {
+	return in.Status.Conditions
+}
+
+// +kubebuilder:object:root=true
+
+// HcloudServerOperationList contains a list of HcloudServerOperation
+type HcloudServerOperationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitzero"`
+	Items           []HcloudServerOperation `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&HcloudServerOperation{}, &HcloudServerOperationList{})
+}
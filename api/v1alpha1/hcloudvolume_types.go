@@ -0,0 +1,172 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// HcloudVolumeServerReference selects the server a volume is attached to, either through an
+// HcloudServer object in the same namespace or directly through a Hetzner Cloud server ID
+// +kubebuilder:validation:XValidation:rule="has(self.name) != has(self.serverId)",message="Exactly one of name or serverId must be set"
+type HcloudVolumeServerReference struct {
+	// name of an HcloudServer object in the same namespace
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// serverId of an existing Hetzner Cloud server
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	ServerId int `json:"serverId,omitempty"`
+}
+
+// HcloudVolumeSpec defines the desired state of HcloudVolume. Labels and the size are updated in
+// place and changing the server reference detaches the volume and attaches it to the new server.
+// The format is only used when the volume is created, while a change of the location is reported
+// on the RequiresReplacement condition.
+// +kubebuilder:validation:XValidation:rule="has(self.location) || has(self.serverRef)",message="location must be set when serverRef is not set"
+type HcloudVolumeSpec struct {
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Field name is immutable"
+	Name string `json:"name"`
+	// size of the volume in GB. Volumes can only grow, the file system on the volume has to be
+	// grown on the server after the volume was resized.
+	// +required
+	// +kubebuilder:validation:Minimum=10
+	// +kubebuilder:validation:Maximum=10240
+	// +kubebuilder:validation:XValidation:rule="self >= oldSelf",message="Volumes cannot be shrunk"
+	Size int `json:"size"`
+	// location such as fsn1, defaults to the location of the server of serverRef
+	// +optional
+	Location string `json:"location,omitempty"`
+	// format is the file system the volume is formatted with when it is created, it is left
+	// unformatted when unset
+	// +optional
+	// +kubebuilder:validation:Enum=ext4;xfs
+	Format string `json:"format,omitempty"`
+	// serverRef selects the server the volume is attached to, the volume is detached when unset
+	// +optional
+	ServerRef *HcloudVolumeServerReference `json:"serverRef,omitempty"`
+	// automount mounts the volume on the server when it is attached
+	// +optional
+	Automount bool `json:"automount,omitempty"`
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+	// syncPolicy selects which changes the operator makes to the cloud resource. Volumes holding
+	// data that has to survive the deletion of the resource use the orphan mode.
+	// +optional
+	SyncPolicy *HcloudSyncPolicy `json:"syncPolicy,omitempty"`
+	// credentialsRef selects the Secret holding the Hetzner Cloud API token for this resource.
+	// It takes precedence over the token of the provider config.
+	// +optional
+	CredentialsRef *HcloudCredentialsReference `json:"credentialsRef,omitempty"`
+	// providerConfigRef selects the provider config used for this resource. The default provider
+	// config of the manager, or the token configured for the manager, is used when unset.
+	// +optional
+	ProviderConfigRef *HcloudProviderConfigReference `json:"providerConfigRef,omitempty"`
+}
+
+// HcloudVolumeStatus defines the observed state of HcloudVolume.
+type HcloudVolumeStatus struct {
+	// For Kubernetes API conventions, see:
+	// https://github.com/kubernetes/community/blob/master/contributors/devel/sig-architecture/api-conventions.md#typical-status-properties
+	VolumeId int `json:"volumeId,omitempty"`
+
+	// status of the volume in Hetzner Cloud, creating or available
+	Status string `json:"status,omitempty"`
+
+	// size of the volume in GB
+	Size int `json:"size,omitempty"`
+
+	Location string `json:"location,omitempty"`
+
+	Format string `json:"format,omitempty"`
+
+	// serverId of the server the volume is attached to
+	ServerId int `json:"serverId,omitempty"`
+
+	// linuxDevice is the path of the volume on the server it is attached to, such as
+	// /dev/disk/by-id/scsi-0HC_Volume_12345
+	LinuxDevice string `json:"linuxDevice,omitempty"`
+
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// pendingActions are the Hetzner Cloud actions started by the operator that are still running
+	// +optional
+	PendingActions []HcloudAction `json:"pendingActions,omitempty"`
+
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// conditions represent the current state of the HcloudVolume resource.
+	// Each condition has a unique type and reflects the status of a specific aspect of the resource.
+	//
+	// Standard condition types include:
+	// - "Available": the resource is fully functional
+	// - "Progressing": the volume is being created, resized, attached or detached
+	// - "RequiresReplacement": the spec differs in fields that cannot be changed in place
+	//
+	// The status of each condition is one of True, False, or Unknown.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="VolumeId",type=integer,JSONPath=`.status.volumeId`,description="Hetzner Cloud Volume ID"
+// +kubebuilder:printcolumn:name="Size",type=integer,JSONPath=`.status.size`,description="Size of the volume in GB"
+// +kubebuilder:printcolumn:name="ServerId",type=integer,JSONPath=`.status.serverId`,description="Hetzner Cloud Server ID the volume is attached to"
+// +kubebuilder:printcolumn:name="Device",type=string,JSONPath=`.status.linuxDevice`,description="Linux device path of the volume",priority=1
+// +kubebuilder:printcolumn:name="ProvisioningState",type=string,JSONPath=`.status.conditions[?(@.type=="Available")].reason`,description="Provisioning state of the volume"
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`,description="Age of the resource"
+
+// HcloudVolume is the Schema for the hcloudvolumes API
+type HcloudVolume struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitzero"`
+
+	// spec defines the desired state of HcloudVolume
+	// +required
+	Spec HcloudVolumeSpec `json:"spec"`
+
+	// status defines the observed state of HcloudVolume
+	// +optional
+	Status HcloudVolumeStatus `json:"status,omitzero"`
+}
+
+// GetConditions returns the conditions of the HcloudVolume
+func (in *HcloudVolume) GetConditions() []metav1.Condition {
+	return in.Status.Conditions
+}
+
+// +kubebuilder:object:root=true
+
+// HcloudVolumeList contains a list of HcloudVolume
+type HcloudVolumeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitzero"`
+	Items           []HcloudVolume `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&HcloudVolume{}, &HcloudVolumeList{})
+}
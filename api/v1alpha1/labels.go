@@ -0,0 +1,35 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+const (
+	// ExternalIDAnnotation is the annotation key pinning a resource to the ID of an existing cloud resource
+	ExternalIDAnnotation = "hcloud.bunskin.com/external-id"
+	// TakeoverAnnotation is the annotation key allowing a resource to adopt a cloud resource claimed by another resource
+	TakeoverAnnotation = "hcloud.bunskin.com/takeover"
+
+	// ReservedLabelPrefix is the prefix of the ownership labels the controllers write onto cloud resources
+	ReservedLabelPrefix = "hcloud.bunskin.com/"
+	// ClusterIDLabel is the label written onto cloud resources recording the cluster managing them
+	ClusterIDLabel = ReservedLabelPrefix + "cluster-id"
+	// NamespaceLabel is the label written onto cloud resources recording the namespace of the resource managing them
+	NamespaceLabel = ReservedLabelPrefix + "namespace"
+	// NameLabel is the label written onto cloud resources recording the name of the resource managing them
+	NameLabel = ReservedLabelPrefix + "name"
+	// OwnerUIDLabel is the label written onto cloud resources recording the UID of the resource managing them
+	OwnerUIDLabel = ReservedLabelPrefix + "owner-uid"
+)
@@ -0,0 +1,75 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// SyncPolicyMode selects which changes the operator makes to the cloud resource of a resource
+// +kubebuilder:validation:Enum=manage;read-only;observe;create-only;update-only;orphan
+type SyncPolicyMode string
+
+const (
+	// SyncPolicyManage creates, updates and deletes the cloud resource
+	SyncPolicyManage SyncPolicyMode = "manage"
+	// SyncPolicyReadOnly never changes the cloud resource and reports where it drifted from the spec
+	SyncPolicyReadOnly SyncPolicyMode = "read-only"
+	// SyncPolicyObserve never changes the cloud resource and only mirrors it into the status
+	SyncPolicyObserve SyncPolicyMode = "observe"
+	// SyncPolicyCreateOnly creates a missing cloud resource but never updates an existing one
+	SyncPolicyCreateOnly SyncPolicyMode = "create-only"
+	// SyncPolicyUpdateOnly updates an existing cloud resource but never creates or deletes it
+	SyncPolicyUpdateOnly SyncPolicyMode = "update-only"
+	// SyncPolicyOrphan creates and updates the cloud resource but leaves it behind on deletion
+	SyncPolicyOrphan SyncPolicyMode = "orphan"
+)
+
+// SyncPolicyModes are all sync policy modes, in the order they are documented
+var SyncPolicyModes = []SyncPolicyMode{
+	SyncPolicyManage, SyncPolicyReadOnly, SyncPolicyObserve, SyncPolicyCreateOnly, SyncPolicyUpdateOnly, SyncPolicyOrphan,
+}
+
+var (
+	// HcloudNetworkIgnorableFields are the spec fields of an HcloudNetwork that can be left to other tools
+	HcloudNetworkIgnorableFields = []string{"labels", "ipRange", "subnets", "routes"}
+	// HcloudDnsZoneIgnorableFields are the spec fields of an HcloudDnsZone that can be left to other tools
+	HcloudDnsZoneIgnorableFields = []string{"labels", "ttl", "primaryNameservers"}
+	// HcloudDnsRecordSetIgnorableFields are the spec fields of an HcloudDnsRecordSet that can be left to other tools
+	HcloudDnsRecordSetIgnorableFields = []string{"labels", "ttl", "records"}
+	// HcloudFirewallIgnorableFields are the spec fields of an HcloudFirewall that can be left to other tools
+	HcloudFirewallIgnorableFields = []string{"labels", "rules", "applyTo"}
+	// HcloudServerIgnorableFields are the spec fields of an HcloudServer that can be left to other tools
+	HcloudServerIgnorableFields = []string{"labels", "networks", "firewalls"}
+	// HcloudVolumeIgnorableFields are the spec fields of an HcloudVolume that can be left to other tools
+	HcloudVolumeIgnorableFields = []string{"labels", "size", "serverRef"}
+)
+
+// HcloudSyncPolicy selects how the operator treats the cloud resource of a resource
+type HcloudSyncPolicy struct {
+	// mode of the sync policy. It takes precedence over the hcloud.bunskin.com/sync-policy
+	// annotation, the default sync policy of the provider config applies when neither is set.
+	// +optional
+	Mode SyncPolicyMode `json:"mode,omitempty"`
+
+	// ignoreFields lists spec fields that are left to other tools. They are used when the cloud
+	// resource is created, but are never updated or reported as drift afterwards.
+	// Networks support labels, ipRange, subnets and routes, zones support labels, ttl and
+	// primaryNameservers, record sets support labels, ttl and records, firewalls support labels,
+	// rules and applyTo, servers support labels, networks and firewalls, and volumes support labels,
+	// size and serverRef.
+	// +optional
+	// +listType=set
+	// +kubebuilder:validation:items:Enum=labels;ipRange;subnets;routes;ttl;primaryNameservers;records;rules;applyTo;networks;firewalls;size;serverRef
+	IgnoreFields []string `json:"ignoreFields,omitempty"`
+}
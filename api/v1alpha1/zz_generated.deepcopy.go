@@ -26,7 +26,7 @@ import (
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HcloudDnsZone) DeepCopyInto(out *HcloudDnsZone) {
+func (in *ClusterHcloudProviderConfig) DeepCopyInto(out *ClusterHcloudProviderConfig) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -34,18 +34,18 @@ func (in *HcloudDnsZone) DeepCopyInto(out *HcloudDnsZone) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HcloudDnsZone.
-func (in *HcloudDnsZone) DeepCopy() *HcloudDnsZone {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterHcloudProviderConfig.
+func (in *ClusterHcloudProviderConfig) DeepCopy() *ClusterHcloudProviderConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(HcloudDnsZone)
+	out := new(ClusterHcloudProviderConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *HcloudDnsZone) DeepCopyObject() runtime.Object {
+func (in *ClusterHcloudProviderConfig) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -53,31 +53,31 @@ func (in *HcloudDnsZone) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HcloudDnsZoneList) DeepCopyInto(out *HcloudDnsZoneList) {
+func (in *ClusterHcloudProviderConfigList) DeepCopyInto(out *ClusterHcloudProviderConfigList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]HcloudDnsZone, len(*in))
+		*out = make([]ClusterHcloudProviderConfig, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HcloudDnsZoneList.
-func (in *HcloudDnsZoneList) DeepCopy() *HcloudDnsZoneList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterHcloudProviderConfigList.
+func (in *ClusterHcloudProviderConfigList) DeepCopy() *ClusterHcloudProviderConfigList {
 	if in == nil {
 		return nil
 	}
-	out := new(HcloudDnsZoneList)
+	out := new(ClusterHcloudProviderConfigList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *HcloudDnsZoneList) DeepCopyObject() runtime.Object {
+func (in *ClusterHcloudProviderConfigList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -85,13 +85,139 @@ func (in *HcloudDnsZoneList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HcloudDnsZoneSpec) DeepCopyInto(out *HcloudDnsZoneSpec) {
+func (in *HcloudAction) DeepCopyInto(out *HcloudAction) {
+	*out = *in
+	in.Started.DeepCopyInto(&out.Started)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HcloudAction.
+func (in *HcloudAction) DeepCopy() *HcloudAction {
+	if in == nil {
+		return nil
+	}
+	out := new(HcloudAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HcloudConfigMapKeyReference) DeepCopyInto(out *HcloudConfigMapKeyReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HcloudConfigMapKeyReference.
+func (in *HcloudConfigMapKeyReference) DeepCopy() *HcloudConfigMapKeyReference {
+	if in == nil {
+		return nil
+	}
+	out := new(HcloudConfigMapKeyReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HcloudCredentialsReference) DeepCopyInto(out *HcloudCredentialsReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HcloudCredentialsReference.
+func (in *HcloudCredentialsReference) DeepCopy() *HcloudCredentialsReference {
+	if in == nil {
+		return nil
+	}
+	out := new(HcloudCredentialsReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HcloudDnsRecord) DeepCopyInto(out *HcloudDnsRecord) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HcloudDnsRecord.
+func (in *HcloudDnsRecord) DeepCopy() *HcloudDnsRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(HcloudDnsRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HcloudDnsRecordSet) DeepCopyInto(out *HcloudDnsRecordSet) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HcloudDnsRecordSet.
+func (in *HcloudDnsRecordSet) DeepCopy() *HcloudDnsRecordSet {
+	if in == nil {
+		return nil
+	}
+	out := new(HcloudDnsRecordSet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HcloudDnsRecordSet) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HcloudDnsRecordSetList) DeepCopyInto(out *HcloudDnsRecordSetList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]HcloudDnsRecordSet, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HcloudDnsRecordSetList.
+func (in *HcloudDnsRecordSetList) DeepCopy() *HcloudDnsRecordSetList {
+	if in == nil {
+		return nil
+	}
+	out := new(HcloudDnsRecordSetList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HcloudDnsRecordSetList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HcloudDnsRecordSetSpec) DeepCopyInto(out *HcloudDnsRecordSetSpec) {
 	*out = *in
+	out.ZoneRef = in.ZoneRef
 	if in.TTL != nil {
 		in, out := &in.TTL, &out.TTL
 		*out = new(int)
 		**out = **in
 	}
+	if in.Records != nil {
+		in, out := &in.Records, &out.Records
+		*out = make([]HcloudDnsRecord, len(*in))
+		copy(*out, *in)
+	}
 	if in.Labels != nil {
 		in, out := &in.Labels, &out.Labels
 		*out = make(map[string]string, len(*in))
@@ -99,21 +225,36 @@ func (in *HcloudDnsZoneSpec) DeepCopyInto(out *HcloudDnsZoneSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.SyncPolicy != nil {
+		in, out := &in.SyncPolicy, &out.SyncPolicy
+		*out = new(HcloudSyncPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HcloudDnsZoneSpec.
-func (in *HcloudDnsZoneSpec) DeepCopy() *HcloudDnsZoneSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HcloudDnsRecordSetSpec.
+func (in *HcloudDnsRecordSetSpec) DeepCopy() *HcloudDnsRecordSetSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(HcloudDnsZoneSpec)
+	out := new(HcloudDnsRecordSetSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HcloudDnsZoneStatus) DeepCopyInto(out *HcloudDnsZoneStatus) {
+func (in *HcloudDnsRecordSetStatus) DeepCopyInto(out *HcloudDnsRecordSetStatus) {
 	*out = *in
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(int)
+		**out = **in
+	}
+	if in.Records != nil {
+		in, out := &in.Records, &out.Records
+		*out = make([]HcloudDnsRecord, len(*in))
+		copy(*out, *in)
+	}
 	if in.Labels != nil {
 		in, out := &in.Labels, &out.Labels
 		*out = make(map[string]string, len(*in))
@@ -130,18 +271,18 @@ func (in *HcloudDnsZoneStatus) DeepCopyInto(out *HcloudDnsZoneStatus) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HcloudDnsZoneStatus.
-func (in *HcloudDnsZoneStatus) DeepCopy() *HcloudDnsZoneStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HcloudDnsRecordSetStatus.
+func (in *HcloudDnsRecordSetStatus) DeepCopy() *HcloudDnsRecordSetStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(HcloudDnsZoneStatus)
+	out := new(HcloudDnsRecordSetStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HcloudNetwork) DeepCopyInto(out *HcloudNetwork) {
+func (in *HcloudDnsZone) DeepCopyInto(out *HcloudDnsZone) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -149,18 +290,18 @@ func (in *HcloudNetwork) DeepCopyInto(out *HcloudNetwork) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HcloudNetwork.
-func (in *HcloudNetwork) DeepCopy() *HcloudNetwork {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HcloudDnsZone.
+func (in *HcloudDnsZone) DeepCopy() *HcloudDnsZone {
 	if in == nil {
 		return nil
 	}
-	out := new(HcloudNetwork)
+	out := new(HcloudDnsZone)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *HcloudNetwork) DeepCopyObject() runtime.Object {
+func (in *HcloudDnsZone) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -168,31 +309,31 @@ func (in *HcloudNetwork) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HcloudNetworkList) DeepCopyInto(out *HcloudNetworkList) {
+func (in *HcloudDnsZoneList) DeepCopyInto(out *HcloudDnsZoneList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]HcloudNetwork, len(*in))
+		*out = make([]HcloudDnsZone, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HcloudNetworkList.
-func (in *HcloudNetworkList) DeepCopy() *HcloudNetworkList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HcloudDnsZoneList.
+func (in *HcloudDnsZoneList) DeepCopy() *HcloudDnsZoneList {
 	if in == nil {
 		return nil
 	}
-	out := new(HcloudNetworkList)
+	out := new(HcloudDnsZoneList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *HcloudNetworkList) DeepCopyObject() runtime.Object {
+func (in *HcloudDnsZoneList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -200,8 +341,48 @@ func (in *HcloudNetworkList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HcloudNetworkSpec) DeepCopyInto(out *HcloudNetworkSpec) {
+func (in *HcloudDnsZonePrimaryNameserver) DeepCopyInto(out *HcloudDnsZonePrimaryNameserver) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HcloudDnsZonePrimaryNameserver.
+func (in *HcloudDnsZonePrimaryNameserver) DeepCopy() *HcloudDnsZonePrimaryNameserver {
+	if in == nil {
+		return nil
+	}
+	out := new(HcloudDnsZonePrimaryNameserver)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HcloudDnsZoneReference) DeepCopyInto(out *HcloudDnsZoneReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HcloudDnsZoneReference.
+func (in *HcloudDnsZoneReference) DeepCopy() *HcloudDnsZoneReference {
+	if in == nil {
+		return nil
+	}
+	out := new(HcloudDnsZoneReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HcloudDnsZoneSpec) DeepCopyInto(out *HcloudDnsZoneSpec) {
 	*out = *in
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(int)
+		**out = **in
+	}
+	if in.PrimaryNameservers != nil {
+		in, out := &in.PrimaryNameservers, &out.PrimaryNameservers
+		*out = make([]HcloudDnsZonePrimaryNameserver, len(*in))
+		copy(*out, *in)
+	}
 	if in.Labels != nil {
 		in, out := &in.Labels, &out.Labels
 		*out = make(map[string]string, len(*in))
@@ -209,20 +390,35 @@ func (in *HcloudNetworkSpec) DeepCopyInto(out *HcloudNetworkSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.SyncPolicy != nil {
+		in, out := &in.SyncPolicy, &out.SyncPolicy
+		*out = new(HcloudSyncPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CredentialsRef != nil {
+		in, out := &in.CredentialsRef, &out.CredentialsRef
+		*out = new(HcloudCredentialsReference)
+		**out = **in
+	}
+	if in.ProviderConfigRef != nil {
+		in, out := &in.ProviderConfigRef, &out.ProviderConfigRef
+		*out = new(HcloudProviderConfigReference)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HcloudNetworkSpec.
-func (in *HcloudNetworkSpec) DeepCopy() *HcloudNetworkSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HcloudDnsZoneSpec.
+func (in *HcloudDnsZoneSpec) DeepCopy() *HcloudDnsZoneSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(HcloudNetworkSpec)
+	out := new(HcloudDnsZoneSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HcloudNetworkStatus) DeepCopyInto(out *HcloudNetworkStatus) {
+func (in *HcloudDnsZoneStatus) DeepCopyInto(out *HcloudDnsZoneStatus) {
 	*out = *in
 	if in.Labels != nil {
 		in, out := &in.Labels, &out.Labels
@@ -231,6 +427,13 @@ func (in *HcloudNetworkStatus) DeepCopyInto(out *HcloudNetworkStatus) {
 			(*out)[key] = val
 		}
 	}
+	if in.PendingActions != nil {
+		in, out := &in.PendingActions, &out.PendingActions
+		*out = make([]HcloudAction, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]v1.Condition, len(*in))
@@ -240,12 +443,1364 @@ func (in *HcloudNetworkStatus) DeepCopyInto(out *HcloudNetworkStatus) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HcloudNetworkStatus.
-func (in *HcloudNetworkStatus) DeepCopy() *HcloudNetworkStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HcloudDnsZoneStatus.
+func (in *HcloudDnsZoneStatus) DeepCopy() *HcloudDnsZoneStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(HcloudNetworkStatus)
+	out := new(HcloudDnsZoneStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HcloudFirewall) DeepCopyInto(out *HcloudFirewall) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HcloudFirewall.
+func (in *HcloudFirewall) DeepCopy() *HcloudFirewall {
+	if in == nil {
+		return nil
+	}
+	out := new(HcloudFirewall)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HcloudFirewall) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HcloudFirewallApplyTo) DeepCopyInto(out *HcloudFirewallApplyTo) {
+	*out = *in
+	if in.ServerIds != nil {
+		in, out := &in.ServerIds, &out.ServerIds
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+	if in.LabelSelectors != nil {
+		in, out := &in.LabelSelectors, &out.LabelSelectors
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HcloudFirewallApplyTo.
+func (in *HcloudFirewallApplyTo) DeepCopy() *HcloudFirewallApplyTo {
+	if in == nil {
+		return nil
+	}
+	out := new(HcloudFirewallApplyTo)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HcloudFirewallIPSource) DeepCopyInto(out *HcloudFirewallIPSource) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NetworkRef != nil {
+		in, out := &in.NetworkRef, &out.NetworkRef
+		*out = new(HcloudNetworkReference)
+		**out = **in
+	}
+	if in.ConfigMapRef != nil {
+		in, out := &in.ConfigMapRef, &out.ConfigMapRef
+		*out = new(HcloudConfigMapKeyReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HcloudFirewallIPSource.
+func (in *HcloudFirewallIPSource) DeepCopy() *HcloudFirewallIPSource {
+	if in == nil {
+		return nil
+	}
+	out := new(HcloudFirewallIPSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HcloudFirewallList) DeepCopyInto(out *HcloudFirewallList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]HcloudFirewall, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HcloudFirewallList.
+func (in *HcloudFirewallList) DeepCopy() *HcloudFirewallList {
+	if in == nil {
+		return nil
+	}
+	out := new(HcloudFirewallList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HcloudFirewallList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HcloudFirewallResourceStatus) DeepCopyInto(out *HcloudFirewallResourceStatus) {
+	*out = *in
+	if in.Servers != nil {
+		in, out := &in.Servers, &out.Servers
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HcloudFirewallResourceStatus.
+func (in *HcloudFirewallResourceStatus) DeepCopy() *HcloudFirewallResourceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HcloudFirewallResourceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HcloudFirewallRule) DeepCopyInto(out *HcloudFirewallRule) {
+	*out = *in
+	if in.SourceIPs != nil {
+		in, out := &in.SourceIPs, &out.SourceIPs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DestinationIPs != nil {
+		in, out := &in.DestinationIPs, &out.DestinationIPs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SourceRefs != nil {
+		in, out := &in.SourceRefs, &out.SourceRefs
+		*out = make([]HcloudFirewallIPSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DestinationRefs != nil {
+		in, out := &in.DestinationRefs, &out.DestinationRefs
+		*out = make([]HcloudFirewallIPSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HcloudFirewallRule.
+func (in *HcloudFirewallRule) DeepCopy() *HcloudFirewallRule {
+	if in == nil {
+		return nil
+	}
+	out := new(HcloudFirewallRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HcloudFirewallSpec) DeepCopyInto(out *HcloudFirewallSpec) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]HcloudFirewallRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.ApplyTo.DeepCopyInto(&out.ApplyTo)
+	if in.SyncPolicy != nil {
+		in, out := &in.SyncPolicy, &out.SyncPolicy
+		*out = new(HcloudSyncPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CredentialsRef != nil {
+		in, out := &in.CredentialsRef, &out.CredentialsRef
+		*out = new(HcloudCredentialsReference)
+		**out = **in
+	}
+	if in.ProviderConfigRef != nil {
+		in, out := &in.ProviderConfigRef, &out.ProviderConfigRef
+		*out = new(HcloudProviderConfigReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HcloudFirewallSpec.
+func (in *HcloudFirewallSpec) DeepCopy() *HcloudFirewallSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HcloudFirewallSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HcloudFirewallStatus) DeepCopyInto(out *HcloudFirewallStatus) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]HcloudFirewallRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AppliedTo != nil {
+		in, out := &in.AppliedTo, &out.AppliedTo
+		*out = make([]HcloudFirewallResourceStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HcloudFirewallStatus.
+func (in *HcloudFirewallStatus) DeepCopy() *HcloudFirewallStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HcloudFirewallStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HcloudNetwork) DeepCopyInto(out *HcloudNetwork) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HcloudNetwork.
+func (in *HcloudNetwork) DeepCopy() *HcloudNetwork {
+	if in == nil {
+		return nil
+	}
+	out := new(HcloudNetwork)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HcloudNetwork) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HcloudNetworkList) DeepCopyInto(out *HcloudNetworkList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]HcloudNetwork, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HcloudNetworkList.
+func (in *HcloudNetworkList) DeepCopy() *HcloudNetworkList {
+	if in == nil {
+		return nil
+	}
+	out := new(HcloudNetworkList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HcloudNetworkList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HcloudNetworkReference) DeepCopyInto(out *HcloudNetworkReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HcloudNetworkReference.
+func (in *HcloudNetworkReference) DeepCopy() *HcloudNetworkReference {
+	if in == nil {
+		return nil
+	}
+	out := new(HcloudNetworkReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HcloudNetworkRoute) DeepCopyInto(out *HcloudNetworkRoute) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HcloudNetworkRoute.
+func (in *HcloudNetworkRoute) DeepCopy() *HcloudNetworkRoute {
+	if in == nil {
+		return nil
+	}
+	out := new(HcloudNetworkRoute)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HcloudNetworkSpec) DeepCopyInto(out *HcloudNetworkSpec) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Subnets != nil {
+		in, out := &in.Subnets, &out.Subnets
+		*out = make([]HcloudNetworkSubnet, len(*in))
+		copy(*out, *in)
+	}
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]HcloudNetworkRoute, len(*in))
+		copy(*out, *in)
+	}
+	if in.SyncPolicy != nil {
+		in, out := &in.SyncPolicy, &out.SyncPolicy
+		*out = new(HcloudSyncPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CredentialsRef != nil {
+		in, out := &in.CredentialsRef, &out.CredentialsRef
+		*out = new(HcloudCredentialsReference)
+		**out = **in
+	}
+	if in.ProviderConfigRef != nil {
+		in, out := &in.ProviderConfigRef, &out.ProviderConfigRef
+		*out = new(HcloudProviderConfigReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HcloudNetworkSpec.
+func (in *HcloudNetworkSpec) DeepCopy() *HcloudNetworkSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HcloudNetworkSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HcloudNetworkStatus) DeepCopyInto(out *HcloudNetworkStatus) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Subnets != nil {
+		in, out := &in.Subnets, &out.Subnets
+		*out = make([]HcloudNetworkSubnetStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]HcloudNetworkRoute, len(*in))
+		copy(*out, *in)
+	}
+	if in.PendingActions != nil {
+		in, out := &in.PendingActions, &out.PendingActions
+		*out = make([]HcloudAction, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HcloudNetworkStatus.
+func (in *HcloudNetworkStatus) DeepCopy() *HcloudNetworkStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HcloudNetworkStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HcloudNetworkSubnet) DeepCopyInto(out *HcloudNetworkSubnet) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HcloudNetworkSubnet.
+func (in *HcloudNetworkSubnet) DeepCopy() *HcloudNetworkSubnet {
+	if in == nil {
+		return nil
+	}
+	out := new(HcloudNetworkSubnet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HcloudNetworkSubnetStatus) DeepCopyInto(out *HcloudNetworkSubnetStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HcloudNetworkSubnetStatus.
+func (in *HcloudNetworkSubnetStatus) DeepCopy() *HcloudNetworkSubnetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HcloudNetworkSubnetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HcloudObjectKeyReference) DeepCopyInto(out *HcloudObjectKeyReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HcloudObjectKeyReference.
+func (in *HcloudObjectKeyReference) DeepCopy() *HcloudObjectKeyReference {
+	if in == nil {
+		return nil
+	}
+	out := new(HcloudObjectKeyReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HcloudProviderConfig) DeepCopyInto(out *HcloudProviderConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HcloudProviderConfig.
+func (in *HcloudProviderConfig) DeepCopy() *HcloudProviderConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(HcloudProviderConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HcloudProviderConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HcloudProviderConfigList) DeepCopyInto(out *HcloudProviderConfigList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]HcloudProviderConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HcloudProviderConfigList.
+func (in *HcloudProviderConfigList) DeepCopy() *HcloudProviderConfigList {
+	if in == nil {
+		return nil
+	}
+	out := new(HcloudProviderConfigList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HcloudProviderConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HcloudProviderConfigReference) DeepCopyInto(out *HcloudProviderConfigReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HcloudProviderConfigReference.
+func (in *HcloudProviderConfigReference) DeepCopy() *HcloudProviderConfigReference {
+	if in == nil {
+		return nil
+	}
+	out := new(HcloudProviderConfigReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HcloudProviderConfigSpec) DeepCopyInto(out *HcloudProviderConfigSpec) {
+	*out = *in
+	out.CredentialsRef = in.CredentialsRef
+	if in.PollInterval != nil {
+		in, out := &in.PollInterval, &out.PollInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.DefaultLabels != nil {
+		in, out := &in.DefaultLabels, &out.DefaultLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HcloudProviderConfigSpec.
+func (in *HcloudProviderConfigSpec) DeepCopy() *HcloudProviderConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HcloudProviderConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HcloudProviderConfigStatus) DeepCopyInto(out *HcloudProviderConfigStatus) {
+	*out = *in
+	out.Usage = in.Usage
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HcloudProviderConfigStatus.
+func (in *HcloudProviderConfigStatus) DeepCopy() *HcloudProviderConfigStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HcloudProviderConfigStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HcloudProviderConfigUsage) DeepCopyInto(out *HcloudProviderConfigUsage) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HcloudProviderConfigUsage.
+func (in *HcloudProviderConfigUsage) DeepCopy() *HcloudProviderConfigUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(HcloudProviderConfigUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HcloudSecretKeyReference) DeepCopyInto(out *HcloudSecretKeyReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HcloudSecretKeyReference.
+func (in *HcloudSecretKeyReference) DeepCopy() *HcloudSecretKeyReference {
+	if in == nil {
+		return nil
+	}
+	out := new(HcloudSecretKeyReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HcloudServer) DeepCopyInto(out *HcloudServer) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HcloudServer.
+func (in *HcloudServer) DeepCopy() *HcloudServer {
+	if in == nil {
+		return nil
+	}
+	out := new(HcloudServer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HcloudServer) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HcloudServerChangeType) DeepCopyInto(out *HcloudServerChangeType) {
+	*out = *in
+	if in.ShutdownTimeout != nil {
+		in, out := &in.ShutdownTimeout, &out.ShutdownTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HcloudServerChangeType.
+func (in *HcloudServerChangeType) DeepCopy() *HcloudServerChangeType {
+	if in == nil {
+		return nil
+	}
+	out := new(HcloudServerChangeType)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HcloudServerFirewall) DeepCopyInto(out *HcloudServerFirewall) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HcloudServerFirewall.
+func (in *HcloudServerFirewall) DeepCopy() *HcloudServerFirewall {
+	if in == nil {
+		return nil
+	}
+	out := new(HcloudServerFirewall)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HcloudServerFirewallStatus) DeepCopyInto(out *HcloudServerFirewallStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HcloudServerFirewallStatus.
+func (in *HcloudServerFirewallStatus) DeepCopy() *HcloudServerFirewallStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HcloudServerFirewallStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HcloudServerList) DeepCopyInto(out *HcloudServerList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]HcloudServer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HcloudServerList.
+func (in *HcloudServerList) DeepCopy() *HcloudServerList {
+	if in == nil {
+		return nil
+	}
+	out := new(HcloudServerList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HcloudServerList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HcloudServerNetwork) DeepCopyInto(out *HcloudServerNetwork) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HcloudServerNetwork.
+func (in *HcloudServerNetwork) DeepCopy() *HcloudServerNetwork {
+	if in == nil {
+		return nil
+	}
+	out := new(HcloudServerNetwork)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HcloudServerOperation) DeepCopyInto(out *HcloudServerOperation) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HcloudServerOperation.
+func (in *HcloudServerOperation) DeepCopy() *HcloudServerOperation {
+	if in == nil {
+		return nil
+	}
+	out := new(HcloudServerOperation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HcloudServerOperation) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HcloudServerOperationList) DeepCopyInto(out *HcloudServerOperationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]HcloudServerOperation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HcloudServerOperationList.
+func (in *HcloudServerOperationList) DeepCopy() *HcloudServerOperationList {
+	if in == nil {
+		return nil
+	}
+	out := new(HcloudServerOperationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HcloudServerOperationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HcloudServerOperationSpec) DeepCopyInto(out *HcloudServerOperationSpec) {
+	*out = *in
+	out.Server = in.Server
+	if in.Rescue != nil {
+		in, out := &in.Rescue, &out.Rescue
+		*out = new(HcloudServerRescue)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Rebuild != nil {
+		in, out := &in.Rebuild, &out.Rebuild
+		*out = new(HcloudServerRebuild)
+		**out = **in
+	}
+	if in.ChangeType != nil {
+		in, out := &in.ChangeType, &out.ChangeType
+		*out = new(HcloudServerChangeType)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Snapshot != nil {
+		in, out := &in.Snapshot, &out.Snapshot
+		*out = new(HcloudServerSnapshot)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CredentialsRef != nil {
+		in, out := &in.CredentialsRef, &out.CredentialsRef
+		*out = new(HcloudCredentialsReference)
+		**out = **in
+	}
+	if in.ProviderConfigRef != nil {
+		in, out := &in.ProviderConfigRef, &out.ProviderConfigRef
+		*out = new(HcloudProviderConfigReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HcloudServerOperationSpec.
+func (in *HcloudServerOperationSpec) DeepCopy() *HcloudServerOperationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HcloudServerOperationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HcloudServerOperationStatus) DeepCopyInto(out *HcloudServerOperationStatus) {
+	*out = *in
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]HcloudServerOperationStep, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HcloudServerOperationStatus.
+func (in *HcloudServerOperationStatus) DeepCopy() *HcloudServerOperationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HcloudServerOperationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HcloudServerOperationStep) DeepCopyInto(out *HcloudServerOperationStep) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HcloudServerOperationStep.
+func (in *HcloudServerOperationStep) DeepCopy() *HcloudServerOperationStep {
+	if in == nil {
+		return nil
+	}
+	out := new(HcloudServerOperationStep)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HcloudServerOperationTarget) DeepCopyInto(out *HcloudServerOperationTarget) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HcloudServerOperationTarget.
+func (in *HcloudServerOperationTarget) DeepCopy() *HcloudServerOperationTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(HcloudServerOperationTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HcloudServerPrivateNet) DeepCopyInto(out *HcloudServerPrivateNet) {
+	*out = *in
+	if in.AliasIPs != nil {
+		in, out := &in.AliasIPs, &out.AliasIPs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HcloudServerPrivateNet.
+func (in *HcloudServerPrivateNet) DeepCopy() *HcloudServerPrivateNet {
+	if in == nil {
+		return nil
+	}
+	out := new(HcloudServerPrivateNet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HcloudServerPublicNet) DeepCopyInto(out *HcloudServerPublicNet) {
+	*out = *in
+	if in.EnableIPv4 != nil {
+		in, out := &in.EnableIPv4, &out.EnableIPv4
+		*out = new(bool)
+		**out = **in
+	}
+	if in.EnableIPv6 != nil {
+		in, out := &in.EnableIPv6, &out.EnableIPv6
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HcloudServerPublicNet.
+func (in *HcloudServerPublicNet) DeepCopy() *HcloudServerPublicNet {
+	if in == nil {
+		return nil
+	}
+	out := new(HcloudServerPublicNet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HcloudServerRebuild) DeepCopyInto(out *HcloudServerRebuild) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HcloudServerRebuild.
+func (in *HcloudServerRebuild) DeepCopy() *HcloudServerRebuild {
+	if in == nil {
+		return nil
+	}
+	out := new(HcloudServerRebuild)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HcloudServerRescue) DeepCopyInto(out *HcloudServerRescue) {
+	*out = *in
+	if in.SSHKeys != nil {
+		in, out := &in.SSHKeys, &out.SSHKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HcloudServerRescue.
+func (in *HcloudServerRescue) DeepCopy() *HcloudServerRescue {
+	if in == nil {
+		return nil
+	}
+	out := new(HcloudServerRescue)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HcloudServerSnapshot) DeepCopyInto(out *HcloudServerSnapshot) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HcloudServerSnapshot.
+func (in *HcloudServerSnapshot) DeepCopy() *HcloudServerSnapshot {
+	if in == nil {
+		return nil
+	}
+	out := new(HcloudServerSnapshot)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HcloudServerSpec) DeepCopyInto(out *HcloudServerSpec) {
+	*out = *in
+	if in.SSHKeys != nil {
+		in, out := &in.SSHKeys, &out.SSHKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.UserData != nil {
+		in, out := &in.UserData, &out.UserData
+		*out = new(HcloudServerUserData)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Networks != nil {
+		in, out := &in.Networks, &out.Networks
+		*out = make([]HcloudServerNetwork, len(*in))
+		copy(*out, *in)
+	}
+	if in.Firewalls != nil {
+		in, out := &in.Firewalls, &out.Firewalls
+		*out = make([]HcloudServerFirewall, len(*in))
+		copy(*out, *in)
+	}
+	in.PublicNet.DeepCopyInto(&out.PublicNet)
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.SyncPolicy != nil {
+		in, out := &in.SyncPolicy, &out.SyncPolicy
+		*out = new(HcloudSyncPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CredentialsRef != nil {
+		in, out := &in.CredentialsRef, &out.CredentialsRef
+		*out = new(HcloudCredentialsReference)
+		**out = **in
+	}
+	if in.ProviderConfigRef != nil {
+		in, out := &in.ProviderConfigRef, &out.ProviderConfigRef
+		*out = new(HcloudProviderConfigReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HcloudServerSpec.
+func (in *HcloudServerSpec) DeepCopy() *HcloudServerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HcloudServerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HcloudServerStatus) DeepCopyInto(out *HcloudServerStatus) {
+	*out = *in
+	if in.PrivateNets != nil {
+		in, out := &in.PrivateNets, &out.PrivateNets
+		*out = make([]HcloudServerPrivateNet, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Firewalls != nil {
+		in, out := &in.Firewalls, &out.Firewalls
+		*out = make([]HcloudServerFirewallStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PendingActions != nil {
+		in, out := &in.PendingActions, &out.PendingActions
+		*out = make([]HcloudAction, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HcloudServerStatus.
+func (in *HcloudServerStatus) DeepCopy() *HcloudServerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HcloudServerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HcloudServerUserData) DeepCopyInto(out *HcloudServerUserData) {
+	*out = *in
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(HcloudObjectKeyReference)
+		**out = **in
+	}
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		*out = new(HcloudObjectKeyReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HcloudServerUserData.
+func (in *HcloudServerUserData) DeepCopy() *HcloudServerUserData {
+	if in == nil {
+		return nil
+	}
+	out := new(HcloudServerUserData)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HcloudSyncPolicy) DeepCopyInto(out *HcloudSyncPolicy) {
+	*out = *in
+	if in.IgnoreFields != nil {
+		in, out := &in.IgnoreFields, &out.IgnoreFields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HcloudSyncPolicy.
+func (in *HcloudSyncPolicy) DeepCopy() *HcloudSyncPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(HcloudSyncPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HcloudVolume) DeepCopyInto(out *HcloudVolume) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HcloudVolume.
+func (in *HcloudVolume) DeepCopy() *HcloudVolume {
+	if in == nil {
+		return nil
+	}
+	out := new(HcloudVolume)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HcloudVolume) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HcloudVolumeList) DeepCopyInto(out *HcloudVolumeList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]HcloudVolume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HcloudVolumeList.
+func (in *HcloudVolumeList) DeepCopy() *HcloudVolumeList {
+	if in == nil {
+		return nil
+	}
+	out := new(HcloudVolumeList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HcloudVolumeList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HcloudVolumeServerReference) DeepCopyInto(out *HcloudVolumeServerReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HcloudVolumeServerReference.
+func (in *HcloudVolumeServerReference) DeepCopy() *HcloudVolumeServerReference {
+	if in == nil {
+		return nil
+	}
+	out := new(HcloudVolumeServerReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HcloudVolumeSpec) DeepCopyInto(out *HcloudVolumeSpec) {
+	*out = *in
+	if in.ServerRef != nil {
+		in, out := &in.ServerRef, &out.ServerRef
+		*out = new(HcloudVolumeServerReference)
+		**out = **in
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.SyncPolicy != nil {
+		in, out := &in.SyncPolicy, &out.SyncPolicy
+		*out = new(HcloudSyncPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CredentialsRef != nil {
+		in, out := &in.CredentialsRef, &out.CredentialsRef
+		*out = new(HcloudCredentialsReference)
+		**out = **in
+	}
+	if in.ProviderConfigRef != nil {
+		in, out := &in.ProviderConfigRef, &out.ProviderConfigRef
+		*out = new(HcloudProviderConfigReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HcloudVolumeSpec.
+func (in *HcloudVolumeSpec) DeepCopy() *HcloudVolumeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HcloudVolumeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HcloudVolumeStatus) DeepCopyInto(out *HcloudVolumeStatus) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PendingActions != nil {
+		in, out := &in.PendingActions, &out.PendingActions
+		*out = make([]HcloudAction, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HcloudVolumeStatus.
+func (in *HcloudVolumeStatus) DeepCopy() *HcloudVolumeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HcloudVolumeStatus)
 	in.DeepCopyInto(out)
 	return out
 }
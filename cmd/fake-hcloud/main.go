@@ -0,0 +1,56 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// fake-hcloud serves the in-process fake Hetzner Cloud API on a fixed address, so that a manager
+// started with --hcloud-endpoint can run against it without access to the real API.
+package main
+
+import (
+	"flag"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"bunskin.com/hcrm/pkg/hcloud/fake"
+)
+
+func main() {
+	var addr string
+	var actionPolls int
+	flag.StringVar(&addr, "bind-address", ":8090", "The address the fake Hetzner Cloud API binds to.")
+	flag.IntVar(&actionPolls, "action-polls", 0, "The number of reads an action is reported as running before it completes.")
+	flag.Parse()
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		log.Fatalf("unable to listen on %s: %v", addr, err)
+	}
+
+	server := fake.NewUnstartedServer()
+	_ = server.Listener.Close()
+	server.Listener = listener
+	server.SetActionPolls(actionPolls)
+	server.Start()
+	defer server.Close()
+
+	log.Printf("serving the fake Hetzner Cloud API on %s, start the manager with --hcloud-endpoint=%s", server.URL, server.URL)
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	<-signals
+}
@@ -0,0 +1,454 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	hcloudgo "github.com/hetznercloud/hcloud-go/v2/hcloud"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/yaml"
+
+	hcloudv1alpha1 "bunskin.com/hcrm/api/v1alpha1"
+	"bunskin.com/hcrm/pkg/hcloud"
+)
+
+const (
+	// kustomizationFile is the name of the kustomization written next to the resources
+	kustomizationFile = "kustomization.yaml"
+)
+
+// invalidNameChars matches the characters that cannot appear in the name of a resource
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9.-]+`)
+
+// stringList is a flag that can be given multiple times or as a comma separated list. The first
+// value given replaces the default.
+type stringList struct {
+	values []string
+	set    bool
+}
+
+// String implements flag.Value
+func (l *stringList) String() string {
+	return strings.Join(l.values, ",")
+}
+
+// Set implements flag.Value
+func (l *stringList) Set(value string) error {
+	if !l.set {
+		l.values, l.set = nil, true
+	}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			l.values = append(l.values, item)
+		}
+	}
+	return nil
+}
+
+// importOptions are the flags of the import command
+type importOptions struct {
+	token          string
+	endpoint       string
+	namespace      string
+	syncPolicy     string
+	selector       string
+	names          stringList
+	kinds          stringList
+	providerConfig string
+	outputDir      string
+	kustomization  bool
+	includeManaged bool
+}
+
+// importedResource is a generated resource together with the file it is written to
+type importedResource struct {
+	file   string
+	object any
+}
+
+// runImport lists the networks and zones of a Hetzner Cloud project and writes a resource for each
+// of them. The resources carry the external-id annotation, so that the operator adopts exactly the
+// listed cloud resource even if it is renamed, and the sync policy chosen on the command line.
+func runImport(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	options := importOptions{kinds: stringList{values: []string{"networks", "zones"}}}
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.StringVar(&options.token, "token", os.Getenv("HCLOUD_TOKEN"), "The Hetzner Cloud API token. Defaults to HCLOUD_TOKEN.")
+	flags.StringVar(&options.endpoint, "endpoint", os.Getenv("HCLOUD_ENDPOINT"), "The Hetzner Cloud API endpoint. Defaults to HCLOUD_ENDPOINT or the public API.")
+	flags.StringVar(&options.namespace, "namespace", "default", "The namespace of the generated resources.")
+	flags.StringVar(&options.syncPolicy, "sync-policy", "read-only",
+		"The sync policy mode of the generated resources, for example read-only to only observe the cloud resources or manage to reconcile them.")
+	flags.StringVar(&options.selector, "selector", "", "Only import cloud resources whose labels match this label selector, for example env=prod,team!=dev.")
+	flags.Var(&options.names, "name", "Only import cloud resources whose name matches one of these glob patterns, for example prod-*. Can be repeated.")
+	flags.Var(&options.kinds, "kinds", "The kinds of cloud resources to import: networks, zones or both.")
+	flags.StringVar(&options.providerConfig, "provider-config", "", "The name of the ClusterHcloudProviderConfig referenced by the generated resources.")
+	flags.StringVar(&options.outputDir, "output-dir", "", "The directory to write one file per resource to. Resources are written to stdout when unset.")
+	flags.BoolVar(&options.kustomization, "kustomization", false, "Also write a kustomization.yaml listing the resources to the output directory.")
+	flags.BoolVar(&options.includeManaged, "include-managed", false,
+		"Also import cloud resources that carry the ownership labels of a resource, they are skipped by default.")
+	flags.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: hcrm import [flags]\n\n"+
+			"Generates HcloudNetwork and HcloudDnsZone resources for the networks and zones of a Hetzner Cloud project.\n\n")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments %v", flags.Args())
+	}
+
+	if err := options.validate(); err != nil {
+		return err
+	}
+	selector, err := labels.Parse(options.selector)
+	if err != nil {
+		return fmt.Errorf("invalid selector: %w", err)
+	}
+
+	var clientOptions []hcloudgo.ClientOption
+	if options.endpoint != "" {
+		clientOptions = append(clientOptions, hcloudgo.WithEndpoint(options.endpoint))
+	}
+	importer := &importer{
+		options:  options,
+		selector: selector,
+		stderr:   stderr,
+		names:    make(map[string]bool),
+	}
+
+	var resources []importedResource
+	for _, kind := range options.kinds.values {
+		var imported []importedResource
+		switch kind {
+		case "networks":
+			imported, err = importer.importNetworks(ctx, hcloud.NewNetworkClient(options.token, clientOptions...))
+		case "zones":
+			imported, err = importer.importZones(ctx, hcloud.NewDnsZoneClient(options.token, clientOptions...))
+		}
+		if err != nil {
+			return err
+		}
+		resources = append(resources, imported...)
+	}
+
+	if options.outputDir == "" {
+		return writeResources(stdout, resources)
+	}
+	return writeResourceFiles(options.outputDir, options.kustomization, resources, stderr)
+}
+
+// validate checks the flags that do not depend on each other
+func (o *importOptions) validate() error {
+	if o.token == "" {
+		return fmt.Errorf("a Hetzner Cloud API token is required, set --token or HCLOUD_TOKEN")
+	}
+	if !slices.Contains(hcloudv1alpha1.SyncPolicyModes, hcloudv1alpha1.SyncPolicyMode(o.syncPolicy)) {
+		return fmt.Errorf("--sync-policy must be one of %v, got %q", hcloudv1alpha1.SyncPolicyModes, o.syncPolicy)
+	}
+	if errs := validation.IsDNS1123Label(o.namespace); len(errs) > 0 {
+		return fmt.Errorf("invalid namespace %q: %s", o.namespace, strings.Join(errs, "; "))
+	}
+	for _, kind := range o.kinds.values {
+		if kind != "networks" && kind != "zones" {
+			return fmt.Errorf("--kinds must list networks or zones, got %q", kind)
+		}
+	}
+	for _, pattern := range o.names.values {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid name pattern %q: %w", pattern, err)
+		}
+	}
+	if o.kustomization && o.outputDir == "" {
+		return fmt.Errorf("--kustomization requires --output-dir")
+	}
+	return nil
+}
+
+// importer turns cloud resources into resources of the operator
+type importer struct {
+	options  importOptions
+	selector labels.Selector
+	stderr   io.Writer
+	// names are the file names already taken, so that cloud resources whose names only differ in
+	// characters not allowed in resource names still get their own resource
+	names map[string]bool
+}
+
+// importNetworks generates an HcloudNetwork for every network passing the filters
+func (i *importer) importNetworks(ctx context.Context, client hcloud.NetworkClient) ([]importedResource, error) {
+	networks, err := client.ListNetworks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list networks: %w", err)
+	}
+
+	var resources []importedResource
+	for _, network := range networks {
+		if !i.selected("network", network.ID, network.Name, network.Labels) {
+			continue
+		}
+
+		spec := hcloudv1alpha1.HcloudNetworkSpec{
+			Name:              network.Name,
+			IpRange:           network.IPRange.String(),
+			Labels:            unreservedLabels(network.Labels),
+			SyncPolicy:        i.syncPolicy(),
+			ProviderConfigRef: i.providerConfigRef(),
+		}
+		for _, subnet := range network.Subnets {
+			spec.Subnets = append(spec.Subnets, hcloudv1alpha1.HcloudNetworkSubnet{
+				Type:        string(subnet.Type),
+				NetworkZone: string(subnet.NetworkZone),
+				IpRange:     subnet.IPRange.String(),
+				VSwitchId:   int(subnet.VSwitchID),
+			})
+		}
+		for _, route := range network.Routes {
+			spec.Routes = append(spec.Routes, hcloudv1alpha1.HcloudNetworkRoute{
+				Destination: route.Destination.String(),
+				Gateway:     route.Gateway.String(),
+			})
+		}
+
+		name := i.resourceName("hcloudnetwork", network.Name, network.ID)
+		resources = append(resources, importedResource{
+			file: "hcloudnetwork-" + name + ".yaml",
+			object: &hcloudv1alpha1.HcloudNetwork{
+				TypeMeta:   metav1.TypeMeta{APIVersion: hcloudv1alpha1.GroupVersion.String(), Kind: "HcloudNetwork"},
+				ObjectMeta: i.objectMeta(name, network.ID),
+				Spec:       spec,
+			},
+		})
+	}
+	return resources, nil
+}
+
+// importZones generates an HcloudDnsZone for every zone passing the filters
+func (i *importer) importZones(ctx context.Context, client hcloud.DnsZoneClient) ([]importedResource, error) {
+	zones, err := client.ListZones(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list zones: %w", err)
+	}
+
+	var resources []importedResource
+	for _, zone := range zones {
+		if !i.selected("zone", zone.ID, zone.Name, zone.Labels) {
+			continue
+		}
+
+		ttl := zone.TTL
+		spec := hcloudv1alpha1.HcloudDnsZoneSpec{
+			Name:              zone.Name,
+			Mode:              strings.ToUpper(string(zone.Mode)),
+			TTL:               &ttl,
+			Labels:            unreservedLabels(zone.Labels),
+			SyncPolicy:        i.syncPolicy(),
+			ProviderConfigRef: i.providerConfigRef(),
+		}
+		for _, nameserver := range zone.PrimaryNameservers {
+			if nameserver.TSIGKey != "" {
+				fmt.Fprintf(i.stderr, "zone %s (%d): the TSIG key of primary nameserver %s cannot be imported\n", zone.Name, zone.ID, nameserver.Address)
+			}
+			spec.PrimaryNameservers = append(spec.PrimaryNameservers, hcloudv1alpha1.HcloudDnsZonePrimaryNameserver{
+				Address: nameserver.Address,
+				Port:    nameserver.Port,
+			})
+		}
+
+		name := i.resourceName("hclouddnszone", zone.Name, zone.ID)
+		resources = append(resources, importedResource{
+			file: "hclouddnszone-" + name + ".yaml",
+			object: &hcloudv1alpha1.HcloudDnsZone{
+				TypeMeta:   metav1.TypeMeta{APIVersion: hcloudv1alpha1.GroupVersion.String(), Kind: "HcloudDnsZone"},
+				ObjectMeta: i.objectMeta(name, zone.ID),
+				Spec:       spec,
+			},
+		})
+	}
+	return resources, nil
+}
+
+// selected reports whether a cloud resource passes the selector and name filters. Cloud resources
+// already managed by a resource are skipped unless --include-managed is set, as the operator would
+// refuse to adopt them anyway.
+func (i *importer) selected(kind string, id int64, name string, cloudLabels map[string]string) bool {
+	if !i.selector.Matches(labels.Set(cloudLabels)) {
+		return false
+	}
+	if len(i.options.names.values) > 0 {
+		matched := false
+		for _, pattern := range i.options.names.values {
+			if ok, _ := path.Match(pattern, name); ok {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	if owner := cloudLabels[hcloudv1alpha1.OwnerUIDLabel]; owner != "" && !i.options.includeManaged {
+		fmt.Fprintf(i.stderr, "skipping %s %s (%d), it is already managed by the resource with UID %s\n", kind, name, id, owner)
+		return false
+	}
+	return true
+}
+
+// resourceName derives the name of the resource from the name of the cloud resource, falling back
+// to the ID for names that are taken or cannot be turned into a resource name
+func (i *importer) resourceName(kind, name string, id int64) string {
+	candidate := invalidNameChars.ReplaceAllString(strings.ToLower(name), "-")
+	candidate = strings.Trim(candidate, "-.")
+	if len(candidate) > validation.DNS1123SubdomainMaxLength-20 {
+		candidate = strings.Trim(candidate[:validation.DNS1123SubdomainMaxLength-20], "-.")
+	}
+	if candidate == "" || len(validation.IsDNS1123Subdomain(candidate)) > 0 || i.names[kind+"/"+candidate] {
+		candidate = strings.Trim(candidate+"-"+strconv.FormatInt(id, 10), "-")
+	}
+	i.names[kind+"/"+candidate] = true
+	return candidate
+}
+
+// objectMeta returns the metadata of a generated resource
+func (i *importer) objectMeta(name string, id int64) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:      name,
+		Namespace: i.options.namespace,
+		Annotations: map[string]string{
+			hcloudv1alpha1.ExternalIDAnnotation: strconv.FormatInt(id, 10),
+		},
+	}
+}
+
+// syncPolicy returns the sync policy of the generated resources
+func (i *importer) syncPolicy() *hcloudv1alpha1.HcloudSyncPolicy {
+	return &hcloudv1alpha1.HcloudSyncPolicy{Mode: hcloudv1alpha1.SyncPolicyMode(i.options.syncPolicy)}
+}
+
+// providerConfigRef returns the provider config reference of the generated resources, if any
+func (i *importer) providerConfigRef() *hcloudv1alpha1.HcloudProviderConfigReference {
+	if i.options.providerConfig == "" {
+		return nil
+	}
+	return &hcloudv1alpha1.HcloudProviderConfigReference{Kind: "ClusterHcloudProviderConfig", Name: i.options.providerConfig}
+}
+
+// unreservedLabels returns the labels of a cloud resource without the ownership labels of the
+// operator, which the webhooks reject in the spec
+func unreservedLabels(cloudLabels map[string]string) map[string]string {
+	var result map[string]string
+	for key, value := range cloudLabels {
+		if strings.HasPrefix(key, hcloudv1alpha1.ReservedLabelPrefix) {
+			continue
+		}
+		if result == nil {
+			result = make(map[string]string, len(cloudLabels))
+		}
+		result[key] = value
+	}
+	return result
+}
+
+// marshalResource renders a resource as YAML without the empty status, creation timestamp and
+// unset lists the API types always carry
+func marshalResource(object any) ([]byte, error) {
+	data, err := yaml.Marshal(object)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]any
+	if err := yaml.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	delete(fields, "status")
+	if metadata, ok := fields["metadata"].(map[string]any); ok {
+		delete(metadata, "creationTimestamp")
+	}
+	if spec, ok := fields["spec"].(map[string]any); ok {
+		for key, value := range spec {
+			if value == nil {
+				delete(spec, key)
+			}
+		}
+	}
+	return yaml.Marshal(fields)
+}
+
+// writeResources writes all resources as a single YAML stream
+func writeResources(out io.Writer, resources []importedResource) error {
+	var buf bytes.Buffer
+	for n, resource := range resources {
+		data, err := marshalResource(resource.object)
+		if err != nil {
+			return err
+		}
+		if n > 0 {
+			buf.WriteString("---\n")
+		}
+		buf.Write(data)
+	}
+	_, err := out.Write(buf.Bytes())
+	return err
+}
+
+// writeResourceFiles writes one file per resource to the directory, together with a kustomization
+// listing them when requested
+func writeResourceFiles(dir string, kustomization bool, resources []importedResource, stderr io.Writer) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	files := make([]string, 0, len(resources))
+	for _, resource := range resources {
+		data, err := marshalResource(resource.object)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(dir, resource.file), data, 0o644); err != nil {
+			return err
+		}
+		files = append(files, resource.file)
+	}
+	fmt.Fprintf(stderr, "wrote %d resources to %s\n", len(files), dir)
+
+	if !kustomization {
+		return nil
+	}
+	data, err := yaml.Marshal(map[string]any{
+		"apiVersion": "kustomize.config.k8s.io/v1beta1",
+		"kind":       "Kustomization",
+		"resources":  files,
+	})
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, kustomizationFile), data, 0o644)
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud/schema"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/yaml"
+
+	hcloudv1alpha1 "bunskin.com/hcrm/api/v1alpha1"
+	"bunskin.com/hcrm/pkg/hcloud/fake"
+)
+
+var _ = Describe("import", func() {
+	var (
+		server         *fake.Server
+		stdout, stderr *bytes.Buffer
+		networkID      int64
+		zoneID         int64
+	)
+
+	runCommand := func(args ...string) int {
+		return run(context.Background(), append([]string{"import", "--token", "test-token", "--endpoint", server.URL}, args...), stdout, stderr)
+	}
+
+	BeforeEach(func() {
+		server = fake.NewServer()
+		DeferCleanup(server.Close)
+		stdout, stderr = &bytes.Buffer{}, &bytes.Buffer{}
+
+		networkID = server.AddNetwork(schema.Network{
+			Name:    "Prod_Network",
+			IPRange: "10.0.0.0/16",
+			Labels:  map[string]string{"env": "prod"},
+			Subnets: []schema.NetworkSubnet{{Type: "cloud", IPRange: "10.0.1.0/24", NetworkZone: "eu-central", Gateway: "10.0.0.1"}},
+			Routes:  []schema.NetworkRoute{{Destination: "10.100.0.0/24", Gateway: "10.0.1.2"}},
+		})
+		server.AddNetwork(schema.Network{Name: "dev-network", IPRange: "10.1.0.0/16", Labels: map[string]string{"env": "dev"}})
+		server.AddNetwork(schema.Network{
+			Name:    "managed-network",
+			IPRange: "10.2.0.0/16",
+			Labels:  map[string]string{"env": "prod", "hcloud.bunskin.com/owner-uid": "1234"},
+		})
+		zoneID = server.AddZone(schema.Zone{Name: "example.com", Mode: "primary", TTL: 3600, Labels: map[string]string{"env": "prod"}})
+	})
+
+	It("should write the selected networks and zones to stdout", func() {
+		Expect(runCommand("--selector", "env=prod")).To(Equal(0), stderr.String())
+		Expect(stderr.String()).To(ContainSubstring("skipping network managed-network"))
+
+		documents := bytes.Split(stdout.Bytes(), []byte("---\n"))
+		Expect(documents).To(HaveLen(2))
+
+		var network hcloudv1alpha1.HcloudNetwork
+		Expect(yaml.UnmarshalStrict(documents[0], &network)).To(Succeed())
+		Expect(network.Kind).To(Equal("HcloudNetwork"))
+		Expect(network.Name).To(Equal("prod-network"))
+		Expect(network.Namespace).To(Equal("default"))
+		Expect(network.Annotations).To(HaveKeyWithValue(hcloudv1alpha1.ExternalIDAnnotation, formatID(networkID)))
+		Expect(network.Spec).To(Equal(hcloudv1alpha1.HcloudNetworkSpec{
+			Name:       "Prod_Network",
+			IpRange:    "10.0.0.0/16",
+			Labels:     map[string]string{"env": "prod"},
+			Subnets:    []hcloudv1alpha1.HcloudNetworkSubnet{{Type: "cloud", NetworkZone: "eu-central", IpRange: "10.0.1.0/24"}},
+			Routes:     []hcloudv1alpha1.HcloudNetworkRoute{{Destination: "10.100.0.0/24", Gateway: "10.0.1.2"}},
+			SyncPolicy: &hcloudv1alpha1.HcloudSyncPolicy{Mode: hcloudv1alpha1.SyncPolicyReadOnly},
+		}))
+		Expect(string(documents[0])).NotTo(ContainSubstring("status"))
+		Expect(string(documents[0])).NotTo(ContainSubstring("creationTimestamp"))
+
+		var zone hcloudv1alpha1.HcloudDnsZone
+		Expect(yaml.UnmarshalStrict(documents[1], &zone)).To(Succeed())
+		Expect(zone.Name).To(Equal("example.com"))
+		Expect(zone.Annotations).To(HaveKeyWithValue(hcloudv1alpha1.ExternalIDAnnotation, formatID(zoneID)))
+		Expect(zone.Spec.Mode).To(Equal("PRIMARY"))
+		Expect(zone.Spec.TTL).To(HaveValue(Equal(3600)))
+	})
+
+	It("should filter by name", func() {
+		Expect(runCommand("--kinds", "networks", "--name", "dev-*", "--sync-policy", "manage")).To(Equal(0), stderr.String())
+
+		var network hcloudv1alpha1.HcloudNetwork
+		Expect(yaml.UnmarshalStrict(stdout.Bytes(), &network)).To(Succeed())
+		Expect(network.Spec.Name).To(Equal("dev-network"))
+		Expect(network.Spec.SyncPolicy).To(Equal(&hcloudv1alpha1.HcloudSyncPolicy{Mode: hcloudv1alpha1.SyncPolicyManage}))
+	})
+
+	It("should write a kustomization directory", func() {
+		dir := filepath.Join(GinkgoT().TempDir(), "imported")
+		Expect(runCommand("--selector", "env=prod", "--output-dir", dir, "--kustomization", "--namespace", "hcloud")).To(Equal(0), stderr.String())
+
+		kustomization, err := os.ReadFile(filepath.Join(dir, kustomizationFile))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(kustomization)).To(Equal(`apiVersion: kustomize.config.k8s.io/v1beta1
+kind: Kustomization
+resources:
+- hcloudnetwork-prod-network.yaml
+- hclouddnszone-example.com.yaml
+`))
+
+		data, err := os.ReadFile(filepath.Join(dir, "hclouddnszone-example.com.yaml"))
+		Expect(err).NotTo(HaveOccurred())
+		var zone hcloudv1alpha1.HcloudDnsZone
+		Expect(yaml.UnmarshalStrict(data, &zone)).To(Succeed())
+		Expect(zone.Namespace).To(Equal("hcloud"))
+	})
+
+	It("should give cloud resources with clashing names their own resource", func() {
+		server.AddNetwork(schema.Network{Name: "prod-network", IPRange: "10.3.0.0/16", Labels: map[string]string{"env": "prod"}})
+		Expect(runCommand("--kinds", "networks", "--name", "*rod*", "--output-dir", GinkgoT().TempDir())).To(Equal(0), stderr.String())
+		Expect(stderr.String()).To(ContainSubstring("wrote 2 resources"))
+	})
+
+	It("should reject invalid flags", func() {
+		Expect(runCommand("--sync-policy", "delete")).To(Equal(1))
+		Expect(stderr.String()).To(ContainSubstring("--sync-policy must be one of"))
+
+		stderr.Reset()
+		Expect(runCommand("--kustomization")).To(Equal(1))
+		Expect(stderr.String()).To(ContainSubstring("--kustomization requires --output-dir"))
+	})
+})
+
+// formatID formats a cloud resource ID as written into the external-id annotation
+func formatID(id int64) string {
+	return strconv.FormatInt(id, 10)
+}
@@ -20,24 +20,31 @@ import (
 	"crypto/tls"
 	"flag"
 	"os"
+	"strings"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	hcloudv1alpha1 "bunskin.com/hcrm/api/v1alpha1"
 	"bunskin.com/hcrm/internal/controller"
+	webhookv1alpha1 "bunskin.com/hcrm/internal/webhook/v1alpha1"
 	"bunskin.com/hcrm/pkg/hcloud"
+	hcloudgo "github.com/hetznercloud/hcloud-go/v2/hcloud"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -51,6 +58,8 @@ func init() {
 
 	utilruntime.Must(hcloudv1alpha1.AddToScheme(scheme))
 	// +kubebuilder:scaffold:scheme
+
+	hcloud.RegisterMetrics(metrics.Registry)
 }
 
 // nolint:gocyclo
@@ -62,6 +71,13 @@ func main() {
 	var probeAddr string
 	var secureMetrics bool
 	var enableHTTP2 bool
+	var defaultProviderConfig string
+	var resyncInterval time.Duration
+	var hcloudEndpoint string
+	var clusterID string
+	var orphanGCInterval, orphanGCGracePeriod time.Duration
+	var orphanGCDryRun bool
+	var pausedKinds, pausedNamespaces, pauseConfigMap string
 	var tlsOpts []func(*tls.Config)
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
@@ -78,6 +94,32 @@ func main() {
 		"The directory that contains the metrics server certificate.")
 	flag.StringVar(&metricsCertName, "metrics-cert-name", "tls.crt", "The name of the metrics server certificate file.")
 	flag.StringVar(&metricsCertKey, "metrics-cert-key", "tls.key", "The name of the metrics server key file.")
+	flag.StringVar(&defaultProviderConfig, "default-provider-config", "",
+		"The name of the ClusterHcloudProviderConfig used for resources without a providerConfigRef.")
+	flag.DurationVar(&resyncInterval, "resync-interval", 10*time.Minute,
+		"The interval after which reconciled resources are compared with Hetzner Cloud to detect drift. "+
+			"Use 0 to disable the periodic resync.")
+	flag.StringVar(&hcloudEndpoint, "hcloud-endpoint", os.Getenv("HCLOUD_ENDPOINT"),
+		"The Hetzner Cloud API endpoint, for example a fake API for offline tests. "+
+			"Defaults to HCLOUD_ENDPOINT or the public API. The endpoint of a provider config takes precedence.")
+	flag.StringVar(&clusterID, "cluster-id", "",
+		"The identifier of this cluster written into the ownership labels of Hetzner Cloud resources. "+
+			"Resources labelled by another cluster are only adopted with the takeover annotation.")
+	flag.DurationVar(&orphanGCInterval, "orphan-gc-interval", time.Hour,
+		"The interval at which Hetzner Cloud is searched for networks and zones whose resource no longer exists. "+
+			"Only the project of HCLOUD_TOKEN is searched, firewalls, servers and volumes are never collected. "+
+			"Use 0 to disable the orphan collector.")
+	flag.DurationVar(&orphanGCGracePeriod, "orphan-gc-grace-period", 24*time.Hour,
+		"The time a Hetzner Cloud network or zone has to be orphaned before the orphan collector deletes it.")
+	flag.BoolVar(&orphanGCDryRun, "orphan-gc-dry-run", true,
+		"If set, orphaned Hetzner Cloud networks and zones are only reported. Deleting them also requires --cluster-id.")
+	flag.StringVar(&pausedKinds, "paused-kinds", "",
+		"A comma separated list of kinds, for example HcloudNetwork, whose resources are not reconciled.")
+	flag.StringVar(&pausedNamespaces, "paused-namespaces", "",
+		"A comma separated list of namespaces whose resources are not reconciled.")
+	flag.StringVar(&pauseConfigMap, "pause-configmap", "",
+		"The namespace/name of a ConfigMap whose kinds and namespaces keys list further paused kinds and namespaces. "+
+			"It is read on every reconcile, so reconciliation can be paused and resumed without a restart.")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
 	opts := zap.Options{
@@ -88,6 +130,25 @@ func main() {
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
+	// The cluster ID is a label value, which Hetzner Cloud restricts like Kubernetes does
+	if errs := validation.IsValidLabelValue(clusterID); len(errs) > 0 {
+		setupLog.Error(nil, "invalid cluster ID", "cluster-id", clusterID, "reason", strings.Join(errs, "; "))
+		os.Exit(1)
+	}
+
+	pause := &controller.PauseSwitch{
+		Kinds:      controller.SplitList(pausedKinds),
+		Namespaces: controller.SplitList(pausedNamespaces),
+	}
+	if pauseConfigMap != "" {
+		namespace, name, ok := strings.Cut(pauseConfigMap, "/")
+		if !ok || namespace == "" || name == "" {
+			setupLog.Error(nil, "invalid pause ConfigMap, must be namespace/name", "pause-configmap", pauseConfigMap)
+			os.Exit(1)
+		}
+		pause.ConfigMap = types.NamespacedName{Namespace: namespace, Name: name}
+	}
+
 	// if the enable-http2 flag is false (the default), http/2 should be disabled
 	// due to its vulnerabilities. More specifically, disabling http/2 will
 	// prevent from being vulnerable to the HTTP/2 Stream Cancellation and
@@ -178,40 +239,189 @@ func main() {
 		setupLog.Error(err, "unable to start manager")
 		os.Exit(1)
 	}
+	// The pause ConfigMap is read directly, the cache would watch every ConfigMap of the cluster
+	pause.Reader = mgr.GetAPIReader()
+
+	var hcloudOptions []hcloudgo.ClientOption
+	if hcloudEndpoint != "" {
+		setupLog.Info("using custom Hetzner Cloud API endpoint", "endpoint", hcloudEndpoint)
+		hcloudOptions = append(hcloudOptions, hcloudgo.WithEndpoint(hcloudEndpoint))
+	}
 
-	// Initialize Hetzner Cloud manager from environment token (optional)
+	// Initialize Hetzner Cloud clients from environment token (optional)
 	var client hcloud.NetworkClient
+	var dnsZoneClient hcloud.DnsZoneClient
+	var firewallClient hcloud.FirewallClient
+	var serverClient hcloud.ServerClient
+	var serverActionClient hcloud.ServerActionClient
+	var volumeClient hcloud.VolumeClient
 	token := os.Getenv("HCLOUD_TOKEN")
 	if token != "" {
-		setupLog.Info("initializing Hetzner Cloud client")
-		newClient := hcloud.NewNetworkClient(token)
-		if err != nil {
-			setupLog.Error(err, "unable to create Hetzner Cloud client")
-			os.Exit(1)
-		}
-		client = newClient
+		setupLog.Info("initializing Hetzner Cloud clients")
+		client = hcloud.NewNetworkClient(token, hcloudOptions...)
+		dnsZoneClient = hcloud.NewDnsZoneClient(token, hcloudOptions...)
+		firewallClient = hcloud.NewFirewallClient(token, hcloudOptions...)
+		serverClient = hcloud.NewServerClient(token, hcloudOptions...)
+		serverActionClient = hcloud.NewServerActionClient(token, hcloudOptions...)
+		volumeClient = hcloud.NewVolumeClient(token, hcloudOptions...)
 	} else {
-		setupLog.Info("HCLOUD_TOKEN not provided; HCloud operations will be disabled")
+		setupLog.Info("HCLOUD_TOKEN not provided; only resources with a credentialsRef will be reconciled")
 	}
+	// Clients for resources using their own credentials or a provider config
+	clientCache := hcloud.NewClientCache()
+	clientCache.Options = hcloudOptions
 
 	if err := (&controller.HcloudNetworkReconciler{
-		Client:        mgr.GetClient(),
-		Scheme:        mgr.GetScheme(),
-		NetworkClient: client,
-		Recorder:      mgr.GetEventRecorderFor("hcloudnetwork-controller"),
+		Client:                mgr.GetClient(),
+		Scheme:                mgr.GetScheme(),
+		NetworkClient:         client,
+		ClientCache:           clientCache,
+		DefaultProviderConfig: defaultProviderConfig,
+		ResyncInterval:        resyncInterval,
+		ClusterID:             clusterID,
+		Pause:                 pause,
+		Recorder:              mgr.GetEventRecorderFor("hcloudnetwork-controller"),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "HcloudNetwork")
 		os.Exit(1)
 	}
 	if err := (&controller.HcloudDnsZoneReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:                mgr.GetClient(),
+		Scheme:                mgr.GetScheme(),
+		DnsZoneClient:         dnsZoneClient,
+		ClientCache:           clientCache,
+		DefaultProviderConfig: defaultProviderConfig,
+		ResyncInterval:        resyncInterval,
+		ClusterID:             clusterID,
+		Pause:                 pause,
+		Recorder:              mgr.GetEventRecorderFor("hclouddnszone-controller"),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "HcloudDnsZone")
 		os.Exit(1)
 	}
+	if err := (&controller.HcloudDnsRecordSetReconciler{
+		Client:                mgr.GetClient(),
+		Scheme:                mgr.GetScheme(),
+		DnsZoneClient:         dnsZoneClient,
+		ClientCache:           clientCache,
+		DefaultProviderConfig: defaultProviderConfig,
+		ResyncInterval:        resyncInterval,
+		Pause:                 pause,
+		Recorder:              mgr.GetEventRecorderFor("hclouddnsrecordset-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "HcloudDnsRecordSet")
+		os.Exit(1)
+	}
+	if err := (&controller.HcloudFirewallReconciler{
+		Client:                mgr.GetClient(),
+		Scheme:                mgr.GetScheme(),
+		FirewallClient:        firewallClient,
+		ClientCache:           clientCache,
+		DefaultProviderConfig: defaultProviderConfig,
+		ResyncInterval:        resyncInterval,
+		ClusterID:             clusterID,
+		Pause:                 pause,
+		APIReader:             mgr.GetAPIReader(),
+		Recorder:              mgr.GetEventRecorderFor("hcloudfirewall-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "HcloudFirewall")
+		os.Exit(1)
+	}
+	if err := (&controller.HcloudServerReconciler{
+		Client:                mgr.GetClient(),
+		Scheme:                mgr.GetScheme(),
+		ServerClient:          serverClient,
+		ClientCache:           clientCache,
+		DefaultProviderConfig: defaultProviderConfig,
+		ResyncInterval:        resyncInterval,
+		ClusterID:             clusterID,
+		Pause:                 pause,
+		APIReader:             mgr.GetAPIReader(),
+		Recorder:              mgr.GetEventRecorderFor("hcloudserver-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "HcloudServer")
+		os.Exit(1)
+	}
+	if err := (&controller.HcloudServerOperationReconciler{
+		Client:                mgr.GetClient(),
+		Scheme:                mgr.GetScheme(),
+		ServerActionClient:    serverActionClient,
+		ClientCache:           clientCache,
+		DefaultProviderConfig: defaultProviderConfig,
+		Pause:                 pause,
+		Recorder:              mgr.GetEventRecorderFor("hcloudserveroperation-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "HcloudServerOperation")
+		os.Exit(1)
+	}
+	if err := (&controller.HcloudVolumeReconciler{
+		Client:                mgr.GetClient(),
+		Scheme:                mgr.GetScheme(),
+		VolumeClient:          volumeClient,
+		ClientCache:           clientCache,
+		DefaultProviderConfig: defaultProviderConfig,
+		ResyncInterval:        resyncInterval,
+		ClusterID:             clusterID,
+		Pause:                 pause,
+		Recorder:              mgr.GetEventRecorderFor("hcloudvolume-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "HcloudVolume")
+		os.Exit(1)
+	}
+	if err := (&controller.HcloudProviderConfigReconciler{
+		Client:      mgr.GetClient(),
+		Scheme:      mgr.GetScheme(),
+		ClientCache: clientCache,
+		Recorder:    mgr.GetEventRecorderFor("hcloudproviderconfig-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "HcloudProviderConfig")
+		os.Exit(1)
+	}
+	if err := (&controller.ClusterHcloudProviderConfigReconciler{
+		Client:                mgr.GetClient(),
+		Scheme:                mgr.GetScheme(),
+		ClientCache:           clientCache,
+		Recorder:              mgr.GetEventRecorderFor("clusterhcloudproviderconfig-controller"),
+		DefaultProviderConfig: defaultProviderConfig,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ClusterHcloudProviderConfig")
+		os.Exit(1)
+	}
+	// nolint:goconst
+	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+		if err := webhookv1alpha1.SetupHcloudNetworkWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "HcloudNetwork")
+			os.Exit(1)
+		}
+		if err := webhookv1alpha1.SetupHcloudDnsZoneWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "HcloudDnsZone")
+			os.Exit(1)
+		}
+	}
 	// +kubebuilder:scaffold:builder
 
+	// Resources by condition reason, counted from the cache on every scrape
+	metrics.Registry.MustRegister(controller.NewResourceConditionCollector(mgr.GetClient()))
+
+	// Cloud resources left behind by resources deleted while the operator was down
+	controller.RegisterOrphanMetrics(metrics.Registry)
+	if orphanGCInterval > 0 && token != "" {
+		if err := mgr.Add(&controller.OrphanCollector{
+			Reader:        mgr.GetAPIReader(),
+			NetworkClient: client,
+			DnsZoneClient: dnsZoneClient,
+			Recorder:      mgr.GetEventRecorderFor("orphan-collector"),
+			ClusterID:     clusterID,
+			Interval:      orphanGCInterval,
+			GracePeriod:   orphanGCGracePeriod,
+			DryRun:        orphanGCDryRun,
+			Pause:         pause,
+		}); err != nil {
+			setupLog.Error(err, "unable to add orphan collector")
+			os.Exit(1)
+		}
+	}
+
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		setupLog.Error(err, "unable to set up health check")
 		os.Exit(1)
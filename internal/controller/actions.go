@@ -0,0 +1,168 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	hcloudv1alpha1 "bunskin.com/hcrm/api/v1alpha1"
+	hcloudgo "github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+const (
+	// progressingCondition is the condition type reporting the Hetzner Cloud actions still running for a resource
+	progressingCondition = "Progressing"
+	// actionFailedReason is the condition reason used when a Hetzner Cloud action failed
+	actionFailedReason = "ActionFailed"
+	// defaultActionPollInterval is the interval at which pending actions are polled without a provider config poll interval
+	defaultActionPollInterval = 2 * time.Second
+)
+
+// getActionFunc retrieves a Hetzner Cloud action by ID
+type getActionFunc func(ctx context.Context, id int64) (*hcloudgo.Action, *hcloudgo.Response, error)
+
+// actionPending reports whether the action has to be followed in a later reconcile
+func actionPending(action *hcloudgo.Action) bool {
+	return action != nil && action.Status == hcloudgo.ActionStatusRunning
+}
+
+// recordPendingAction adds a started action to the pending actions of a resource and reports it
+// on the Progressing condition
+func recordPendingAction(pending *[]hcloudv1alpha1.HcloudAction, conditions *[]metav1.Condition, generation int64, action *hcloudgo.Action) {
+	*pending = append(*pending, hcloudv1alpha1.HcloudAction{
+		Id:       action.ID,
+		Command:  action.Command,
+		Progress: action.Progress,
+		Started:  metav1.NewTime(action.Started),
+	})
+	setProgressingCondition(conditions, generation, *pending)
+}
+
+// setProgressingCondition reports the progress of the pending actions, the condition is false once
+// no action is pending anymore
+func setProgressingCondition(conditions *[]metav1.Condition, generation int64, pending []hcloudv1alpha1.HcloudAction) {
+	if len(pending) == 0 {
+		meta.SetStatusCondition(conditions, metav1.Condition{
+			Type:               progressingCondition,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: generation,
+			Reason:             "ActionsCompleted",
+			Message:            "All Hetzner Cloud actions completed",
+		})
+		return
+	}
+
+	progress := make([]string, 0, len(pending))
+	for _, action := range pending {
+		progress = append(progress, fmt.Sprintf("%s (action %d) %d%%", action.Command, action.Id, action.Progress))
+	}
+	meta.SetStatusCondition(conditions, metav1.Condition{
+		Type:               progressingCondition,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: generation,
+		Reason:             "ActionRunning",
+		Message:            strings.Join(progress, ", "),
+	})
+}
+
+// awaitPendingActions refreshes the pending actions recorded on a resource. While actions are running
+// their progress is reported on the Progressing condition and the resource is requeued after the poll
+// interval. A failed action is removed and reported with its Hetzner Cloud error code and message on
+// the Available condition. done is true once no action is pending anymore, the status is not updated
+// in that case so that the caller can continue with the reconcile.
+func awaitPendingActions(ctx context.Context, c client.Client, recorder record.EventRecorder, obj client.Object, pending *[]hcloudv1alpha1.HcloudAction, conditions *[]metav1.Condition, getAction getActionFunc, pollInterval time.Duration) (done bool, result ctrl.Result, err error) {
+	log := logf.Log.WithName("actions")
+
+	var running []hcloudv1alpha1.HcloudAction
+	var failed *hcloudgo.Action
+	for _, pendingAction := range *pending {
+		action, response, err := getAction(ctx, pendingAction.Id)
+		if err != nil {
+			log.Error(err, "Failed to get action from Hetzner Cloud", "name", obj.GetName(), "actionId", pendingAction.Id)
+			meta.SetStatusCondition(conditions, metav1.Condition{
+				Type:               "Available",
+				Status:             metav1.ConditionFalse,
+				ObservedGeneration: obj.GetGeneration(),
+				Reason:             "Failed",
+				Message:            fmt.Sprintf("Failed to get action %d from Hetzner Cloud: %v. %v", pendingAction.Id, err, response),
+			})
+			if err := c.Status().Update(ctx, obj); err != nil {
+				log.Error(err, "Failed to update status", "name", obj.GetName())
+			}
+			return false, ctrl.Result{}, err
+		}
+
+		switch {
+		case action == nil || action.Status == hcloudgo.ActionStatusSuccess:
+			log.Info("Hetzner Cloud action completed", "name", obj.GetName(), "actionId", pendingAction.Id, "command", pendingAction.Command)
+		case action.Status == hcloudgo.ActionStatusError:
+			failed = action
+		default:
+			pendingAction.Progress = action.Progress
+			running = append(running, pendingAction)
+		}
+	}
+	*pending = running
+
+	if failed != nil {
+		err := fmt.Errorf("action %d (%s) failed: %s (%s)", failed.ID, failed.Command, failed.ErrorMessage, failed.ErrorCode)
+		log.Error(err, "Hetzner Cloud action failed", "name", obj.GetName())
+		setProgressingCondition(conditions, obj.GetGeneration(), running)
+		meta.SetStatusCondition(conditions, metav1.Condition{
+			Type:               "Available",
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: obj.GetGeneration(),
+			Reason:             actionFailedReason,
+			Message:            fmt.Sprintf("Hetzner Cloud action %d (%s) failed with %s: %s", failed.ID, failed.Command, failed.ErrorCode, failed.ErrorMessage),
+		})
+		if err := c.Status().Update(ctx, obj); err != nil {
+			log.Error(err, "Failed to update status", "name", obj.GetName())
+		}
+		recorder.Eventf(obj, "Warning", actionFailedReason, "Hetzner Cloud action %s failed with %s: %s", failed.Command, failed.ErrorCode, failed.ErrorMessage)
+
+		return false, ctrl.Result{}, err
+	}
+
+	setProgressingCondition(conditions, obj.GetGeneration(), running)
+	if len(running) == 0 {
+		return true, ctrl.Result{}, nil
+	}
+
+	if err := c.Status().Update(ctx, obj); err != nil {
+		log.Error(err, "Failed to update status", "name", obj.GetName())
+		return false, ctrl.Result{}, err
+	}
+	return false, ctrl.Result{RequeueAfter: pollInterval}, nil
+}
+
+// actionPollInterval returns the interval at which pending actions of resources using the provider are polled
+func (p *hcloudProvider) actionPollInterval() time.Duration {
+	if p != nil && p.config != nil && p.config.PollInterval != nil && p.config.PollInterval.Duration > 0 {
+		return p.config.PollInterval.Duration
+	}
+	return defaultActionPollInterval
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hcloudv1alpha1 "bunskin.com/hcrm/api/v1alpha1"
+)
+
+const (
+	// invalidExternalIDReason is the condition reason for an external-id annotation that is not a cloud resource ID
+	invalidExternalIDReason = "InvalidExternalID"
+	// externalResourceNotFoundReason is the condition reason for an external-id naming a missing cloud resource
+	externalResourceNotFoundReason = "ExternalResourceNotFound"
+	// alreadyClaimedReason is the condition reason for a cloud resource managed by another resource
+	alreadyClaimedReason = "AlreadyClaimed"
+	// takenOverReason is the event reason for a cloud resource adopted from another resource
+	takenOverReason = "TakenOver"
+	// renamedReason is the event reason for a cloud resource whose name differs from the spec
+	renamedReason = "Renamed"
+)
+
+// ownershipLabels are the reserved label keys the controllers manage on cloud resources
+var ownershipLabels = []string{
+	hcloudv1alpha1.ClusterIDLabel, hcloudv1alpha1.NamespaceLabel, hcloudv1alpha1.NameLabel, hcloudv1alpha1.OwnerUIDLabel,
+}
+
+// externalID returns the cloud resource ID pinned by the external-id annotation, zero if the
+// annotation is not set
+func externalID(obj client.Object) (int64, error) {
+	value, ok := obj.GetAnnotations()[hcloudv1alpha1.ExternalIDAnnotation]
+	if !ok {
+		return 0, nil
+	}
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("annotation %s must be the ID of a Hetzner Cloud resource, got %q", hcloudv1alpha1.ExternalIDAnnotation, value)
+	}
+	return id, nil
+}
+
+// takeover reports whether the resource may adopt a cloud resource claimed by another resource
+func takeover(obj client.Object) bool {
+	return obj.GetAnnotations()[hcloudv1alpha1.TakeoverAnnotation] == "true"
+}
+
+// claimant describes the resource recorded by the ownership labels of a cloud resource, empty when
+// the cloud resource is unclaimed or claimed by obj. A cloud resource labelled by another cluster
+// counts as claimed even without an owner UID.
+func claimant(labels map[string]string, obj client.Object, clusterID string) string {
+	uid, cluster := labels[hcloudv1alpha1.OwnerUIDLabel], labels[hcloudv1alpha1.ClusterIDLabel]
+	if uid == string(obj.GetUID()) || (uid == "" && (cluster == "" || cluster == clusterID)) {
+		return ""
+	}
+
+	owner := "another resource"
+	if name := labels[hcloudv1alpha1.NameLabel]; name != "" {
+		owner = labels[hcloudv1alpha1.NamespaceLabel] + "/" + name
+	}
+	if uid != "" {
+		owner += fmt.Sprintf(" (UID %s)", uid)
+	}
+	if cluster != "" && cluster != clusterID {
+		owner += fmt.Sprintf(" of cluster %s", cluster)
+	}
+	return owner
+}
+
+// withOwnershipLabels returns the labels to write onto a cloud resource managed by obj. These are
+// the desired labels, or the live labels when the spec does not manage labels, together with the
+// ownership labels. The cluster ID label is left out when no cluster ID is configured.
+func withOwnershipLabels(desired, live map[string]string, obj client.Object, clusterID string) map[string]string {
+	base := desired
+	if base == nil {
+		base = withoutOwnershipLabels(live)
+	}
+	labels := make(map[string]string, len(base)+len(ownershipLabels))
+	for key, value := range base {
+		labels[key] = value
+	}
+	if clusterID != "" {
+		labels[hcloudv1alpha1.ClusterIDLabel] = clusterID
+	}
+	labels[hcloudv1alpha1.NamespaceLabel] = obj.GetNamespace()
+	labels[hcloudv1alpha1.NameLabel] = labelValue(obj.GetName())
+	labels[hcloudv1alpha1.OwnerUIDLabel] = string(obj.GetUID())
+	return labels
+}
+
+// withoutOwnershipLabels returns the labels of a cloud resource without the ownership labels, as
+// they are compared with the spec and reported in the status
+func withoutOwnershipLabels(labels map[string]string) map[string]string {
+	owned := false
+	for _, key := range ownershipLabels {
+		if _, ok := labels[key]; ok {
+			owned = true
+			break
+		}
+	}
+	if !owned {
+		return labels
+	}
+
+	var stripped map[string]string
+	for key, value := range labels {
+		if !slices.Contains(ownershipLabels, key) {
+			if stripped == nil {
+				stripped = make(map[string]string, len(labels))
+			}
+			stripped[key] = value
+		}
+	}
+	return stripped
+}
+
+// labelValue shortens a resource name to the 63 characters Hetzner Cloud allows for label values.
+// Label values have to end with an alphanumeric character, like names do.
+func labelValue(name string) string {
+	if len(name) <= validation.LabelValueMaxLength {
+		return name
+	}
+	return strings.TrimRight(name[:validation.LabelValueMaxLength], "-_.")
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hcloudv1alpha1 "bunskin.com/hcrm/api/v1alpha1"
+	hcloudgo "github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+const (
+	// credentialsUnavailableReason is the condition reason used when no Hetzner Cloud token can be resolved
+	credentialsUnavailableReason = "CredentialsUnavailable"
+	// defaultCredentialsKey is the Secret key holding the API token when credentialsRef.key is unset
+	defaultCredentialsKey = "token"
+	// clusterProviderConfigKind is the kind of the cluster scoped provider config
+	clusterProviderConfigKind = "ClusterHcloudProviderConfig"
+)
+
+// hcloudProvider is the resolved configuration used to talk to Hetzner Cloud for a resource
+type hcloudProvider struct {
+	// token is empty when the client configured for the manager has to be used
+	token string
+	// cacheKey and cacheVersion identify the client built for the token in the ClientCache
+	cacheKey     string
+	cacheVersion string
+	// options are passed on to the hcloud-go client
+	options []hcloudgo.ClientOption
+	// config is the provider config used for the resource, nil when none applies
+	config *hcloudv1alpha1.HcloudProviderConfigSpec
+}
+
+// resolveProvider determines the credentials and provider config used for a resource. A credentialsRef
+// on the resource takes precedence over the token of its provider config and the default cluster
+// provider config is used when the resource does not reference one.
+func resolveProvider(ctx context.Context, c client.Client, defaultProviderConfig string, namespace string, credentialsRef *hcloudv1alpha1.HcloudCredentialsReference, providerConfigRef *hcloudv1alpha1.HcloudProviderConfigReference) (*hcloudProvider, error) {
+	provider := &hcloudProvider{}
+	var secretRef *hcloudv1alpha1.HcloudSecretKeyReference
+	var configId, configVersion string
+
+	if providerConfigRef == nil && defaultProviderConfig != "" {
+		providerConfigRef = &hcloudv1alpha1.HcloudProviderConfigReference{Kind: clusterProviderConfigKind, Name: defaultProviderConfig}
+	}
+	if providerConfigRef != nil {
+		var configMeta metav1.ObjectMeta
+		if providerConfigRef.Kind == clusterProviderConfigKind {
+			var config hcloudv1alpha1.ClusterHcloudProviderConfig
+			if err := c.Get(ctx, types.NamespacedName{Name: providerConfigRef.Name}, &config); err != nil {
+				return nil, fmt.Errorf("failed to get ClusterHcloudProviderConfig %s: %w", providerConfigRef.Name, err)
+			}
+			configMeta = config.ObjectMeta
+			provider.config = &config.Spec
+			secretRef = config.Spec.CredentialsRef.DeepCopy()
+		} else {
+			var config hcloudv1alpha1.HcloudProviderConfig
+			if err := c.Get(ctx, types.NamespacedName{Name: providerConfigRef.Name, Namespace: namespace}, &config); err != nil {
+				return nil, fmt.Errorf("failed to get HcloudProviderConfig %s/%s: %w", namespace, providerConfigRef.Name, err)
+			}
+			configMeta = config.ObjectMeta
+			provider.config = &config.Spec
+			secretRef = config.Spec.CredentialsRef.DeepCopy()
+			secretRef.Namespace = namespace
+		}
+		configId = string(configMeta.UID)
+		configVersion = strconv.FormatInt(configMeta.Generation, 10)
+		provider.options = providerConfigOptions(provider.config)
+	}
+	if credentialsRef != nil {
+		secretRef = &hcloudv1alpha1.HcloudSecretKeyReference{Name: credentialsRef.Name, Namespace: namespace, Key: credentialsRef.Key}
+	}
+	if secretRef == nil {
+		return provider, nil
+	}
+
+	secret, token, err := readCredentialsSecret(ctx, c, secretRef)
+	if err != nil {
+		return nil, err
+	}
+	provider.token = token
+	provider.cacheKey = string(secret.UID) + "/" + configId
+	provider.cacheVersion = secret.ResourceVersion + "/" + configVersion
+	return provider, nil
+}
+
+// providerConfigOptions converts the API settings of a provider config into hcloud-go client options
+func providerConfigOptions(config *hcloudv1alpha1.HcloudProviderConfigSpec) []hcloudgo.ClientOption {
+	var options []hcloudgo.ClientOption
+	if config.Endpoint != "" {
+		options = append(options, hcloudgo.WithEndpoint(config.Endpoint))
+	}
+	if config.PollInterval != nil && config.PollInterval.Duration > 0 {
+		options = append(options, hcloudgo.WithPollOpts(hcloudgo.PollOpts{
+			BackoffFunc: hcloudgo.ConstantBackoff(config.PollInterval.Duration),
+		}))
+	}
+	return options
+}
+
+// labels merges the default labels of the provider config with the labels of the resource
+func (p *hcloudProvider) labels(labels map[string]string) map[string]string {
+	if p.config == nil || len(p.config.DefaultLabels) == 0 {
+		return labels
+	}
+	merged := make(map[string]string, len(p.config.DefaultLabels)+len(labels))
+	for key, value := range p.config.DefaultLabels {
+		merged[key] = value
+	}
+	for key, value := range labels {
+		merged[key] = value
+	}
+	return merged
+}
+
+// syncPolicy returns the sync policy for resources that set neither a sync policy mode nor a
+// sync-policy annotation
+func (p *hcloudProvider) syncPolicy() hcloudv1alpha1.SyncPolicyMode {
+	if p.config == nil || p.config.DefaultSyncPolicy == "" {
+		return hcloudv1alpha1.SyncPolicyManage
+	}
+	return p.config.DefaultSyncPolicy
+}
+
+// readCredentialsSecret fetches the referenced Secret and returns it together with the API token
+func readCredentialsSecret(ctx context.Context, c client.Client, ref *hcloudv1alpha1.HcloudSecretKeyReference) (*corev1.Secret, string, error) {
+	if ref.Namespace == "" {
+		return nil, "", fmt.Errorf("no namespace set for credentials secret %s", ref.Name)
+	}
+	key := ref.Key
+	if key == "" {
+		key = defaultCredentialsKey
+	}
+
+	var secret corev1.Secret
+	if err := c.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: ref.Namespace}, &secret); err != nil {
+		return nil, "", fmt.Errorf("failed to get credentials secret %s/%s: %w", ref.Namespace, ref.Name, err)
+	}
+	token, ok := secret.Data[key]
+	if !ok || len(token) == 0 {
+		return nil, "", fmt.Errorf("credentials secret %s/%s has no token under key %q", ref.Namespace, ref.Name, key)
+	}
+	return &secret, string(token), nil
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// resyncIntervalAnnotation is the annotation key overriding the resync interval of a single resource
+	resyncIntervalAnnotation = "hcloud.bunskin.com/resync-interval"
+	// driftedCondition is the condition type reporting changes made to the cloud resource outside of the operator
+	driftedCondition = "Drifted"
+	// absentValue is shown in drift entries for values missing on one side
+	absentValue = "<absent>"
+)
+
+// resyncAfter returns the interval after which a reconciled resource is checked for drift again.
+// The resync-interval annotation overrides the interval configured for the manager, a zero
+// interval disables the periodic resync.
+func resyncAfter(obj client.Object, defaultInterval time.Duration) time.Duration {
+	value, ok := obj.GetAnnotations()[resyncIntervalAnnotation]
+	if !ok {
+		return defaultInterval
+	}
+	interval, err := time.ParseDuration(value)
+	if err != nil || interval < 0 {
+		logf.Log.WithName("drift").Info("Ignoring invalid resync interval annotation", "name", obj.GetName(), "value", value)
+		return defaultInterval
+	}
+	return interval
+}
+
+// driftEntry formats the difference of a single field as "field: live -> desired"
+func driftEntry(field, live, desired string) string {
+	if live == "" {
+		live = absentValue
+	}
+	if desired == "" {
+		desired = absentValue
+	}
+	return fmt.Sprintf("%s: %s -> %s", field, live, desired)
+}
+
+// labelsDrift returns one drift entry per label that differs between the cloud resource and the spec
+func labelsDrift(live, desired map[string]string) []string {
+	keys := make([]string, 0, len(live)+len(desired))
+	for key := range desired {
+		keys = append(keys, key)
+	}
+	for key := range live {
+		if _, ok := desired[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var drift []string
+	for _, key := range keys {
+		if live[key] != desired[key] {
+			drift = append(drift, driftEntry("labels."+key, live[key], desired[key]))
+		}
+	}
+	return drift
+}
+
+// setDriftCondition records the outcome of the drift detection in the Drifted condition.
+// Drift that was corrected is reported once with reason DriftCorrected, drift that is left
+// in place because of the sync policy keeps the condition true.
+func setDriftCondition(conditions *[]metav1.Condition, generation int64, drift []string, corrected bool) {
+	condition := metav1.Condition{
+		Type:               driftedCondition,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: generation,
+		Reason:             "InSync",
+		Message:            "Cloud resource matches the spec",
+	}
+	if len(drift) > 0 && corrected {
+		condition.Reason = "DriftCorrected"
+		condition.Message = "Corrected drift: " + strings.Join(drift, "; ")
+	} else if len(drift) > 0 {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "DriftDetected"
+		condition.Message = strings.Join(drift, "; ")
+	}
+	meta.SetStatusCondition(conditions, condition)
+}
@@ -0,0 +1,355 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hcloudv1alpha1 "bunskin.com/hcrm/api/v1alpha1"
+	"bunskin.com/hcrm/pkg/hcloud"
+	"bunskin.com/hcrm/pkg/hcloud/fake"
+	hcloudgo "github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+// convergeTimeout bounds the reconciles of a scenario, the faults are seeded so a scenario either
+// converges quickly or not at all
+const convergeTimeout = 30 * time.Second
+
+// faultyHcloudOptions returns the hcloud-go options for clients of the server whose requests pass
+// the fault transport. Retries and polls are not delayed, so that the scenarios run quickly.
+func faultyHcloudOptions(server *fake.Server, faults *fake.FaultTransport) []hcloudgo.ClientOption {
+	return []hcloudgo.ClientOption{
+		hcloudgo.WithEndpoint(server.URL),
+		hcloudgo.WithHTTPClient(&http.Client{Transport: faults}),
+		hcloudgo.WithRetryOpts(hcloudgo.RetryOpts{BackoffFunc: hcloudgo.ConstantBackoff(time.Millisecond), MaxRetries: 2}),
+		hcloudgo.WithPollOpts(hcloudgo.PollOpts{BackoffFunc: hcloudgo.ConstantBackoff(time.Millisecond)}),
+	}
+}
+
+// injectTransientFaults makes a share of all requests fail the way the API fails intermittently
+func injectTransientFaults(faults *fake.FaultTransport) {
+	faults.Sometimes("", 0.1, fake.Status(http.StatusBadGateway, "", ""))
+	faults.Sometimes("", 0.1, fake.Status(http.StatusServiceUnavailable, hcloudgo.ErrorCodeServiceError, "service temporarily unavailable"))
+	faults.Sometimes("", 0.05, fake.Timeout())
+	faults.Sometimes("", 0.05, fake.ConnectionReset())
+	faults.Sometimes("", 0.1, fake.Latency(20*time.Millisecond))
+}
+
+// reconcileUntil reconciles the resource the way the controller manager does, retrying after
+// errors and requeues without waiting, until the assertion succeeds
+func reconcileUntil(ctx context.Context, reconciler reconcile.Reconciler, name types.NamespacedName, assertion func(g Gomega)) {
+	GinkgoHelper()
+	Eventually(func(g Gomega) {
+		_, _ = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: name})
+		assertion(g)
+	}).WithTimeout(convergeTimeout).WithPolling(time.Millisecond).Should(Succeed())
+}
+
+// expectDeleted asserts that the resource is gone, so its finalizer has been removed
+func expectDeleted(g Gomega, ctx context.Context, name types.NamespacedName, obj client.Object) {
+	err := k8sClient.Get(ctx, name, obj)
+	g.Expect(errors.IsNotFound(err)).To(BeTrue(), "resource still exists with finalizers %v", obj.GetFinalizers())
+}
+
+var _ = Describe("Reconcile against a faulty Hetzner Cloud API", func() {
+	const namespace = "default"
+
+	ctx := context.Background()
+
+	var server *fake.Server
+	var faults *fake.FaultTransport
+
+	BeforeEach(func() {
+		// Each scenario has its own API, the injected faults and failing actions must not leak into other tests
+		server = fake.NewServer()
+		faults = fake.NewFaultTransport(nil, uint64(GinkgoRandomSeed()))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Context("HcloudNetwork", func() {
+		var reconciler *HcloudNetworkReconciler
+
+		BeforeEach(func() {
+			reconciler = &HcloudNetworkReconciler{
+				Client:        k8sClient,
+				Scheme:        k8sClient.Scheme(),
+				NetworkClient: hcloud.NewNetworkClient("faulty-api-token", faultyHcloudOptions(server, faults)...),
+				Recorder:      &record.FakeRecorder{},
+			}
+		})
+
+		newNetwork := func(name string) *hcloudv1alpha1.HcloudNetwork {
+			resource := &hcloudv1alpha1.HcloudNetwork{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: namespace,
+				},
+				Spec: hcloudv1alpha1.HcloudNetworkSpec{
+					Name:    name,
+					IpRange: "10.0.0.0/16",
+					Labels:  map[string]string{"env": "test"},
+					Subnets: []hcloudv1alpha1.HcloudNetworkSubnet{
+						{Type: "cloud", NetworkZone: "eu-central", IpRange: "10.0.1.0/24"},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+			return resource
+		}
+
+		// networkConverged asserts that the resource is ready and matches the single network of the API
+		networkConverged := func(name types.NamespacedName, ipRange string) func(g Gomega) {
+			return func(g Gomega) {
+				resource := &hcloudv1alpha1.HcloudNetwork{}
+				g.Expect(k8sClient.Get(ctx, name, resource)).To(Succeed())
+				g.Expect(resource.Status.ObservedGeneration).To(Equal(resource.Generation))
+				g.Expect(resource.Status.PendingActions).To(BeEmpty())
+				g.Expect(meta.IsStatusConditionTrue(resource.Status.Conditions, "Available")).To(BeTrue())
+
+				networks := server.Networks()
+				g.Expect(networks).To(HaveLen(1))
+				g.Expect(int(networks[0].ID)).To(Equal(resource.Status.NetworkId))
+				g.Expect(networks[0].IPRange).To(Equal(ipRange))
+				g.Expect(networks[0].Labels).To(HaveKeyWithValue("env", "test"))
+				g.Expect(networks[0].Subnets).To(HaveLen(1))
+			}
+		}
+
+		It("should converge and release its finalizer under intermittent errors and rate limiting", func() {
+			const resourceName = "test-faulty-network"
+			typeNamespacedName := types.NamespacedName{Name: resourceName, Namespace: namespace}
+			newNetwork(resourceName)
+
+			// Enough rate limited responses to exhaust the retries of the client
+			faults.Sequence("POST /networks", fake.RateLimited(time.Second), fake.RateLimited(time.Second), fake.RateLimited(time.Second))
+			injectTransientFaults(faults)
+
+			By("creating the network")
+			reconcileUntil(ctx, reconciler, typeNamespacedName, networkConverged(typeNamespacedName, "10.0.0.0/16"))
+			Expect(faults.Injected("POST /networks")).To(Equal(3))
+
+			By("changing the IP range")
+			resource := &hcloudv1alpha1.HcloudNetwork{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			resource.Spec.IpRange = "10.0.0.0/15"
+			Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+			reconcileUntil(ctx, reconciler, typeNamespacedName, networkConverged(typeNamespacedName, "10.0.0.0/15"))
+
+			By("deleting the network")
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+			reconcileUntil(ctx, reconciler, typeNamespacedName, func(g Gomega) {
+				expectDeleted(g, ctx, typeNamespacedName, &hcloudv1alpha1.HcloudNetwork{})
+			})
+			Expect(server.Networks()).To(BeEmpty())
+		})
+
+		It("should converge when IP range changes run long or fail", func() {
+			const resourceName = "test-faulty-network-actions"
+			typeNamespacedName := types.NamespacedName{Name: resourceName, Namespace: namespace}
+			newNetwork(resourceName)
+			reconcileUntil(ctx, reconciler, typeNamespacedName, networkConverged(typeNamespacedName, "10.0.0.0/16"))
+
+			By("running the IP range change for many polls before it fails")
+			server.FailActions("change_ip_range", 1, "action_failed", "IP range change failed")
+			server.SetActionPolls(20)
+			injectTransientFaults(faults)
+
+			resource := &hcloudv1alpha1.HcloudNetwork{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			resource.Spec.IpRange = "10.0.0.0/15"
+			Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+
+			reconcileUntil(ctx, reconciler, typeNamespacedName, func(g Gomega) {
+				resource := &hcloudv1alpha1.HcloudNetwork{}
+				g.Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+				condition := meta.FindStatusCondition(resource.Status.Conditions, "Available")
+				g.Expect(condition).NotTo(BeNil())
+				g.Expect(condition.Reason).To(Equal(actionFailedReason))
+			})
+			reconcileUntil(ctx, reconciler, typeNamespacedName, networkConverged(typeNamespacedName, "10.0.0.0/15"))
+
+			By("deleting the network")
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+			reconcileUntil(ctx, reconciler, typeNamespacedName, func(g Gomega) {
+				expectDeleted(g, ctx, typeNamespacedName, &hcloudv1alpha1.HcloudNetwork{})
+			})
+		})
+
+		It("should release its finalizer when the network disappears between GET and DELETE", func() {
+			const resourceName = "test-vanishing-network"
+			typeNamespacedName := types.NamespacedName{Name: resourceName, Namespace: namespace}
+			newNetwork(resourceName)
+			reconcileUntil(ctx, reconciler, typeNamespacedName, networkConverged(typeNamespacedName, "10.0.0.0/16"))
+
+			resource := &hcloudv1alpha1.HcloudNetwork{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			networkID := int64(resource.Status.NetworkId)
+			faults.Sequence("DELETE /networks/{id}", fake.Before(func(*http.Request) {
+				server.RemoveNetwork(networkID)
+			}))
+
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(faults.Injected("DELETE /networks/{id}")).To(Equal(1))
+			expectDeleted(Default, ctx, typeNamespacedName, &hcloudv1alpha1.HcloudNetwork{})
+		})
+	})
+
+	Context("HcloudDnsZone", func() {
+		var reconciler *HcloudDnsZoneReconciler
+
+		BeforeEach(func() {
+			reconciler = &HcloudDnsZoneReconciler{
+				Client:        k8sClient,
+				Scheme:        k8sClient.Scheme(),
+				DnsZoneClient: hcloud.NewDnsZoneClient("faulty-api-token", faultyHcloudOptions(server, faults)...),
+				Recorder:      &record.FakeRecorder{},
+			}
+		})
+
+		newDnsZone := func(name, zoneName string) *hcloudv1alpha1.HcloudDnsZone {
+			ttl := 3600
+			resource := &hcloudv1alpha1.HcloudDnsZone{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: namespace,
+				},
+				Spec: hcloudv1alpha1.HcloudDnsZoneSpec{
+					Name:   zoneName,
+					Mode:   "PRIMARY",
+					TTL:    &ttl,
+					Labels: map[string]string{"env": "test"},
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+			return resource
+		}
+
+		// dnsZoneConverged asserts that the resource is ready and matches the single zone of the API
+		dnsZoneConverged := func(name types.NamespacedName, ttl int) func(g Gomega) {
+			return func(g Gomega) {
+				resource := &hcloudv1alpha1.HcloudDnsZone{}
+				g.Expect(k8sClient.Get(ctx, name, resource)).To(Succeed())
+				g.Expect(resource.Status.ObservedGeneration).To(Equal(resource.Generation))
+				g.Expect(resource.Status.PendingActions).To(BeEmpty())
+				g.Expect(meta.IsStatusConditionTrue(resource.Status.Conditions, "Available")).To(BeTrue())
+
+				zones := server.Zones()
+				g.Expect(zones).To(HaveLen(1))
+				g.Expect(int(zones[0].ID)).To(Equal(resource.Status.ZoneId))
+				g.Expect(zones[0].TTL).To(Equal(ttl))
+				g.Expect(zones[0].Labels).To(HaveKeyWithValue("env", "test"))
+			}
+		}
+
+		It("should converge and release its finalizer under intermittent errors and long-running actions", func() {
+			const resourceName = "test-faulty-zone"
+			typeNamespacedName := types.NamespacedName{Name: resourceName, Namespace: namespace}
+			newDnsZone(resourceName, "faulty.example.com")
+
+			server.SetActionPolls(10)
+			faults.Sequence("GET /zones/{id}", fake.RateLimited(time.Second), fake.RateLimited(time.Second), fake.RateLimited(time.Second))
+			injectTransientFaults(faults)
+
+			By("creating the zone with a long-running create action")
+			reconcileUntil(ctx, reconciler, typeNamespacedName, dnsZoneConverged(typeNamespacedName, 3600))
+
+			By("changing the TTL")
+			resource := &hcloudv1alpha1.HcloudDnsZone{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			ttl := 600
+			resource.Spec.TTL = &ttl
+			Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+			reconcileUntil(ctx, reconciler, typeNamespacedName, dnsZoneConverged(typeNamespacedName, 600))
+
+			By("deleting the zone with a long-running delete action")
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+			reconcileUntil(ctx, reconciler, typeNamespacedName, func(g Gomega) {
+				expectDeleted(g, ctx, typeNamespacedName, &hcloudv1alpha1.HcloudDnsZone{})
+			})
+			Expect(server.Zones()).To(BeEmpty())
+		})
+
+		It("should converge when zone actions fail", func() {
+			const resourceName = "test-failing-zone-actions"
+			typeNamespacedName := types.NamespacedName{Name: resourceName, Namespace: namespace}
+			newDnsZone(resourceName, "failing.example.com")
+
+			server.FailActions("create_zone", 1, "action_failed", "zone creation failed")
+			server.FailActions("change_ttl", 1, "action_failed", "TTL change failed")
+			server.FailActions("delete_zone", 1, "action_failed", "zone deletion failed")
+			server.SetActionPolls(3)
+			injectTransientFaults(faults)
+
+			By("creating the zone")
+			reconcileUntil(ctx, reconciler, typeNamespacedName, dnsZoneConverged(typeNamespacedName, 3600))
+
+			By("changing the TTL")
+			resource := &hcloudv1alpha1.HcloudDnsZone{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			ttl := 600
+			resource.Spec.TTL = &ttl
+			Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+			reconcileUntil(ctx, reconciler, typeNamespacedName, dnsZoneConverged(typeNamespacedName, 600))
+
+			By("deleting the zone")
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+			reconcileUntil(ctx, reconciler, typeNamespacedName, func(g Gomega) {
+				expectDeleted(g, ctx, typeNamespacedName, &hcloudv1alpha1.HcloudDnsZone{})
+			})
+			Expect(server.Zones()).To(BeEmpty())
+		})
+
+		It("should release its finalizer when the zone disappears between GET and DELETE", func() {
+			const resourceName = "test-vanishing-zone"
+			typeNamespacedName := types.NamespacedName{Name: resourceName, Namespace: namespace}
+			newDnsZone(resourceName, "vanishing.example.com")
+			reconcileUntil(ctx, reconciler, typeNamespacedName, dnsZoneConverged(typeNamespacedName, 3600))
+
+			resource := &hcloudv1alpha1.HcloudDnsZone{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			zoneID := int64(resource.Status.ZoneId)
+			faults.Sequence("DELETE /zones/{id}", fake.Before(func(*http.Request) {
+				server.RemoveZone(zoneID)
+			}))
+
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(faults.Injected("DELETE /zones/{id}")).To(Equal(1))
+			expectDeleted(Default, ctx, typeNamespacedName, &hcloudv1alpha1.HcloudDnsZone{})
+		})
+	})
+})
@@ -0,0 +1,245 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hcloudv1alpha1 "bunskin.com/hcrm/api/v1alpha1"
+)
+
+// unresolvedSourcesReason is the condition reason for rules whose sourceRefs or destinationRefs cannot be resolved
+const unresolvedSourcesReason = "UnresolvedSources"
+
+// resolveFirewallRules returns the rules of the spec with their sourceRefs and destinationRefs
+// resolved into sourceIPs and destinationIPs. Rules that end up without any CIDR are left out, as
+// Hetzner Cloud does not accept them and a rule matching no address allows no traffic anyway.
+// ConfigMaps are read through configMaps, so that they do not have to be cached.
+func resolveFirewallRules(ctx context.Context, c client.Reader, configMaps client.Reader, namespace string, rules []hcloudv1alpha1.HcloudFirewallRule) ([]hcloudv1alpha1.HcloudFirewallRule, error) {
+	resolved := make([]hcloudv1alpha1.HcloudFirewallRule, 0, len(rules))
+	for i, rule := range rules {
+		if len(rule.SourceRefs) == 0 && len(rule.DestinationRefs) == 0 {
+			resolved = append(resolved, rule)
+			continue
+		}
+
+		sourceIPs, err := resolveIPSources(ctx, c, configMaps, namespace, rule.SourceIPs, rule.SourceRefs)
+		if err != nil {
+			return nil, fmt.Errorf("rules[%d].sourceRefs: %w", i, err)
+		}
+		destinationIPs, err := resolveIPSources(ctx, c, configMaps, namespace, rule.DestinationIPs, rule.DestinationRefs)
+		if err != nil {
+			return nil, fmt.Errorf("rules[%d].destinationRefs: %w", i, err)
+		}
+		if len(sourceIPs) == 0 && len(destinationIPs) == 0 {
+			continue
+		}
+
+		rule.SourceIPs = sourceIPs
+		rule.DestinationIPs = destinationIPs
+		rule.SourceRefs = nil
+		rule.DestinationRefs = nil
+		resolved = append(resolved, rule)
+	}
+	return resolved, nil
+}
+
+// resolveIPSources appends the CIDRs resolved from sources to cidrs
+func resolveIPSources(ctx context.Context, c client.Reader, configMaps client.Reader, namespace string, cidrs []string, sources []hcloudv1alpha1.HcloudFirewallIPSource) ([]string, error) {
+	resolved := slices.Clone(cidrs)
+	for _, source := range sources {
+		switch {
+		case source.NodeSelector != nil:
+			nodeCIDRs, err := nodeExternalCIDRs(ctx, c, source.NodeSelector)
+			if err != nil {
+				return nil, err
+			}
+			resolved = append(resolved, nodeCIDRs...)
+		case source.NetworkRef != nil:
+			var hcloudNetwork hcloudv1alpha1.HcloudNetwork
+			if err := c.Get(ctx, types.NamespacedName{Name: source.NetworkRef.Name, Namespace: namespace}, &hcloudNetwork); err != nil {
+				return nil, fmt.Errorf("failed to get HcloudNetwork %s: %w", source.NetworkRef.Name, err)
+			}
+			if hcloudNetwork.Status.IpRange == "" {
+				return nil, fmt.Errorf("HcloudNetwork %s has no IP range yet", source.NetworkRef.Name)
+			}
+			resolved = append(resolved, hcloudNetwork.Status.IpRange)
+		case source.ConfigMapRef != nil:
+			var configMap corev1.ConfigMap
+			if err := configMaps.Get(ctx, types.NamespacedName{Name: source.ConfigMapRef.Name, Namespace: namespace}, &configMap); err != nil {
+				return nil, fmt.Errorf("failed to get ConfigMap %s: %w", source.ConfigMapRef.Name, err)
+			}
+			if source.ConfigMapRef.Key != "" {
+				value, ok := configMap.Data[source.ConfigMapRef.Key]
+				if !ok {
+					return nil, fmt.Errorf("ConfigMap %s has no key %s", source.ConfigMapRef.Name, source.ConfigMapRef.Key)
+				}
+				resolved = append(resolved, splitCIDRs(value)...)
+				continue
+			}
+			keys := make([]string, 0, len(configMap.Data))
+			for key := range configMap.Data {
+				keys = append(keys, key)
+			}
+			slices.Sort(keys)
+			for _, key := range keys {
+				resolved = append(resolved, splitCIDRs(configMap.Data[key])...)
+			}
+		}
+	}
+	return resolved, nil
+}
+
+// nodeExternalCIDRs returns the ExternalIP addresses of the Nodes matching selector as single-address CIDRs
+func nodeExternalCIDRs(ctx context.Context, c client.Reader, selector *metav1.LabelSelector) ([]string, error) {
+	nodeSelector, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid node selector: %w", err)
+	}
+	var nodes corev1.NodeList
+	if err := c.List(ctx, &nodes, client.MatchingLabelsSelector{Selector: nodeSelector}); err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	var cidrs []string
+	for _, node := range nodes.Items {
+		for _, ip := range externalIPs(&node) {
+			if ip.To4() != nil {
+				cidrs = append(cidrs, ip.String()+"/32")
+			} else {
+				cidrs = append(cidrs, ip.String()+"/128")
+			}
+		}
+	}
+	return cidrs, nil
+}
+
+// externalIPs returns the parsable ExternalIP addresses of a Node
+func externalIPs(node *corev1.Node) []net.IP {
+	var ips []net.IP
+	for _, address := range node.Status.Addresses {
+		if address.Type != corev1.NodeExternalIP {
+			continue
+		}
+		if ip := net.ParseIP(address.Address); ip != nil {
+			ips = append(ips, ip)
+		}
+	}
+	return ips
+}
+
+// splitCIDRs splits a list of CIDRs separated by commas or whitespace, so that ConfigMaps can list one per line
+func splitCIDRs(value string) []string {
+	return strings.FieldsFunc(value, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t' || r == '\n' || r == '\r'
+	})
+}
+
+// hasIPSources reports whether any rule of the firewall resolves CIDRs from source
+func hasIPSources(hcloudFirewall *hcloudv1alpha1.HcloudFirewall, matches func(source hcloudv1alpha1.HcloudFirewallIPSource) bool) bool {
+	for _, rule := range hcloudFirewall.Spec.Rules {
+		if slices.ContainsFunc(rule.SourceRefs, matches) || slices.ContainsFunc(rule.DestinationRefs, matches) {
+			return true
+		}
+	}
+	return false
+}
+
+// firewallsReferencing returns requests for the HcloudFirewalls in namespace, or in all namespaces
+// when it is empty, with a rule resolving CIDRs from a matching source
+func firewallsReferencing(ctx context.Context, c client.Reader, namespace string, matches func(source hcloudv1alpha1.HcloudFirewallIPSource) bool) []reconcile.Request {
+	log := logf.Log.WithName("hcloudfirewall-controller")
+
+	var list hcloudv1alpha1.HcloudFirewallList
+	if err := c.List(ctx, &list, client.InNamespace(namespace)); err != nil {
+		log.Error(err, "Failed to list HcloudFirewalls referencing a changed object")
+		return nil
+	}
+	var requests []reconcile.Request
+	for i := range list.Items {
+		if hasIPSources(&list.Items[i], matches) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&list.Items[i])})
+		}
+	}
+	return requests
+}
+
+// firewallsForNode maps a Node to the HcloudFirewalls whose node selectors match it. Updates are
+// mapped with both the old and the new Node, so firewalls of a selector the Node left are included.
+func (r *HcloudFirewallReconciler) firewallsForNode(ctx context.Context, obj client.Object) []reconcile.Request {
+	nodeLabels := labels.Set(obj.GetLabels())
+	return firewallsReferencing(ctx, r.Client, "", func(source hcloudv1alpha1.HcloudFirewallIPSource) bool {
+		if source.NodeSelector == nil {
+			return false
+		}
+		selector, err := metav1.LabelSelectorAsSelector(source.NodeSelector)
+		return err == nil && selector.Matches(nodeLabels)
+	})
+}
+
+// firewallsForNetwork maps an HcloudNetwork to the HcloudFirewalls of its namespace referencing it
+func (r *HcloudFirewallReconciler) firewallsForNetwork(ctx context.Context, obj client.Object) []reconcile.Request {
+	return firewallsReferencing(ctx, r.Client, obj.GetNamespace(), func(source hcloudv1alpha1.HcloudFirewallIPSource) bool {
+		return source.NetworkRef != nil && source.NetworkRef.Name == obj.GetName()
+	})
+}
+
+// firewallsForConfigMap maps a ConfigMap to the HcloudFirewalls of its namespace referencing it
+func (r *HcloudFirewallReconciler) firewallsForConfigMap(ctx context.Context, obj client.Object) []reconcile.Request {
+	return firewallsReferencing(ctx, r.Client, obj.GetNamespace(), func(source hcloudv1alpha1.HcloudFirewallIPSource) bool {
+		return source.ConfigMapRef != nil && source.ConfigMapRef.Name == obj.GetName()
+	})
+}
+
+// nodeAddressesChanged lets through updates of Nodes that change their labels or ExternalIP
+// addresses, the frequent status updates of Nodes leave the resolved CIDRs unchanged.
+var nodeAddressesChanged = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldNode, okOld := e.ObjectOld.(*corev1.Node)
+		newNode, okNew := e.ObjectNew.(*corev1.Node)
+		if !okOld || !okNew {
+			return true
+		}
+		return !labels.Equals(oldNode.Labels, newNode.Labels) || !slices.EqualFunc(externalIPs(oldNode), externalIPs(newNode), net.IP.Equal)
+	},
+}
+
+// networkIPRangeChanged lets through updates of HcloudNetworks that change their IP range
+var networkIPRangeChanged = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldNetwork, okOld := e.ObjectOld.(*hcloudv1alpha1.HcloudNetwork)
+		newNetwork, okNew := e.ObjectNew.(*hcloudv1alpha1.HcloudNetwork)
+		if !okOld || !okNew {
+			return true
+		}
+		return oldNetwork.Status.IpRange != newNetwork.Status.IpRange
+	},
+}
@@ -0,0 +1,629 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	hcloudv1alpha1 "bunskin.com/hcrm/api/v1alpha1"
+	"bunskin.com/hcrm/pkg/hcloud"
+	hcloudgo "github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+const (
+	// zoneNotReadyRequeueAfter is how long to wait before retrying when the referenced zone has no ID yet
+	zoneNotReadyRequeueAfter = 30 * time.Second
+)
+
+// HcloudDnsRecordSetReconciler reconciles a HcloudDnsRecordSet object
+type HcloudDnsRecordSetReconciler struct {
+	client.Client
+	Scheme        *runtime.Scheme
+	DnsZoneClient hcloud.DnsZoneClient
+	ClientCache   *hcloud.ClientCache
+	Recorder      record.EventRecorder
+	// DefaultProviderConfig is the name of the ClusterHcloudProviderConfig used for resources without a providerConfigRef
+	DefaultProviderConfig string
+	// ResyncInterval is the interval after which reconciled resources are checked for drift, zero disables it
+	ResyncInterval time.Duration
+	// Pause pauses the reconciliation of whole kinds or namespaces, nil if it cannot be paused by the manager
+	Pause *PauseSwitch
+}
+
+// +kubebuilder:rbac:groups=hcloud.bunskin.com,resources=hclouddnsrecordsets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=hcloud.bunskin.com,resources=hclouddnsrecordsets/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=hcloud.bunskin.com,resources=hclouddnsrecordsets/finalizers,verbs=update
+// +kubebuilder:rbac:groups=hcloud.bunskin.com,resources=hclouddnszones,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=hcloud.bunskin.com,resources=hcloudproviderconfigs;clusterhcloudproviderconfigs,verbs=get;list;watch
+
+// Reconcile resolves the zone referenced by the HcloudDnsRecordSet and creates, updates
+// or deletes the matching RRSet according to the sync policy of the resource.
+func (r *HcloudDnsRecordSetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	result, err := r.reconcile(ctx, req)
+	if reset, ok := hcloud.RateLimitReset(err); ok {
+		var recordSet hcloudv1alpha1.HcloudDnsRecordSet
+		if err := r.Get(ctx, req.NamespacedName, &recordSet); err != nil {
+			return ctrl.Result{}, client.IgnoreNotFound(err)
+		}
+		return requeueRateLimited(ctx, r.Client, r.Recorder, &recordSet, &recordSet.Status.Conditions, reset, err)
+	}
+	return result, err
+}
+
+// reconcile implements Reconcile, errors caused by the Hetzner Cloud rate limit are handled by the caller
+func (r *HcloudDnsRecordSetReconciler) reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := logf.Log.WithName("hclouddnsrecordset-controller")
+
+	// Fetch the HcloudDnsRecordSet resource
+	var recordSet hcloudv1alpha1.HcloudDnsRecordSet
+	if err := r.Get(ctx, req.NamespacedName, &recordSet); err != nil {
+		// object does not exist, nothing to do
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	// A paused resource is left alone, including its deletion
+	if paused, result, err := reconcilePaused(ctx, r.Client, r.Recorder, r.Pause, "HcloudDnsRecordSet", &recordSet, &recordSet.Status.Conditions); paused {
+		return result, err
+	}
+
+	log.Info("Reconciling HcloudDnsRecordSet", "name", recordSet.Name, "namespace", recordSet.Namespace)
+	meta.SetStatusCondition(&recordSet.Status.Conditions, metav1.Condition{
+		Type:               "Available",
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: recordSet.Generation,
+		Reason:             "Progressing",
+		Message:            "HcloudDnsRecordSet resource reconciliation in progress",
+	})
+	if err := r.Status().Update(ctx, &recordSet); err != nil {
+		log.Error(err, "Failed to update HcloudDnsRecordSet status", "name", recordSet.Name)
+		return ctrl.Result{}, err
+	}
+
+	// Handle deletion with finalizer
+	if recordSet.DeletionTimestamp != nil {
+		log.Info("HcloudDnsRecordSet resource is being deleted", "name", recordSet.Name)
+		meta.SetStatusCondition(&recordSet.Status.Conditions, metav1.Condition{
+			Type:               "Available",
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: recordSet.Generation,
+			Reason:             "Deleting",
+			Message:            "HcloudDnsRecordSet resource is being deleted",
+		})
+		if err := r.Status().Update(ctx, &recordSet); err != nil {
+			log.Error(err, "Failed to update HcloudDnsRecordSet status", "name", recordSet.Name)
+			return ctrl.Result{}, err
+		}
+
+		// Check if finalizer exists
+		if controllerutil.ContainsFinalizer(&recordSet, finalizerName) {
+			policy, policyErr := resolveSyncPolicy(&recordSet, recordSet.Spec.SyncPolicy, hcloudv1alpha1.SyncPolicyManage, hcloudv1alpha1.HcloudDnsRecordSetIgnorableFields)
+			if policyErr != nil {
+				// A sync policy that cannot be understood never deletes the RRSet
+				log.Error(policyErr, "Invalid sync policy, will not remove cloud resource", "name", recordSet.Name)
+				r.Recorder.Eventf(&recordSet, "Warning", invalidSyncPolicyReason, "RRSet %s/%s is left in Hetzner cloud: %v", recordSet.Spec.Name, recordSet.Spec.Type, policyErr)
+			} else if recordSet.Status.ZoneId != 0 && policy.deletes() {
+				// Delete the RRSet from Hetzner Cloud if it exists
+				dnsZoneClient, _, err := r.dnsZoneClientFor(ctx, &recordSet)
+				if apierrors.IsNotFound(err) {
+					// Without the referenced HcloudDnsZone there are no credentials for its zone, the RRSet is left to the zone
+					log.Info("Referenced HcloudDnsZone not found, will not remove the RRSet", "zoneRef", recordSet.Spec.ZoneRef.Name)
+				} else if err != nil {
+					log.Error(err, "Failed to resolve Hetzner Cloud credentials", "name", recordSet.Name)
+					meta.SetStatusCondition(&recordSet.Status.Conditions, metav1.Condition{
+						Type:               "Available",
+						Status:             metav1.ConditionFalse,
+						ObservedGeneration: recordSet.Generation,
+						Reason:             credentialsUnavailableReason,
+						Message:            fmt.Sprintf("Failed to resolve Hetzner Cloud credentials: %v", err),
+					})
+					if err := r.Status().Update(ctx, &recordSet); err != nil {
+						log.Error(err, "Failed to update HcloudDnsRecordSet status", "name", recordSet.Name)
+					}
+					r.Recorder.Eventf(&recordSet, "Warning", credentialsUnavailableReason, "Failed to resolve Hetzner Cloud credentials: %v", err)
+
+					return ctrl.Result{}, err
+				} else {
+					log.Info("Fetching Hetzner Cloud RRSet for deletion", "zoneId", recordSet.Status.ZoneId, "rrset", recordSet.Spec.Name, "type", recordSet.Spec.Type)
+					zone := &hcloudgo.Zone{ID: int64(recordSet.Status.ZoneId)}
+					rrset, response, err := dnsZoneClient.GetRRSet(ctx, zone, recordSet.Spec.Name, recordSet.Spec.Type)
+					if err != nil {
+						log.Error(err, "Failed to get RRSet from Hetzner Cloud", "zoneId", recordSet.Status.ZoneId)
+						meta.SetStatusCondition(&recordSet.Status.Conditions, metav1.Condition{
+							Type:               "Available",
+							Status:             metav1.ConditionFalse,
+							ObservedGeneration: recordSet.Generation,
+							Reason:             "DeletionFailed",
+							Message:            fmt.Sprintf("Failed to get RRSet for deletion: %v. %v", err, response),
+						})
+						if err := r.Status().Update(ctx, &recordSet); err != nil {
+							log.Error(err, "Failed to update HcloudDnsRecordSet status", "name", recordSet.Name)
+						}
+
+						r.Recorder.Eventf(&recordSet, "Warning", "DeletionFailed", "Failed to get RRSet %s/%s for deletion", recordSet.Spec.Name, recordSet.Spec.Type)
+
+						return ctrl.Result{}, err
+					}
+
+					if rrset != nil {
+						// Delete the RRSet
+						log.Info("Deleting Hetzner Cloud RRSet", "rrsetId", rrset.ID)
+						response, err := dnsZoneClient.DeleteRRSet(ctx, rrset)
+						// An RRSet deleted by someone else since it was fetched is gone all the same
+						if err != nil && !hcloudgo.IsError(err, hcloudgo.ErrorCodeNotFound) {
+							log.Error(err, "Failed to delete RRSet from Hetzner Cloud", "rrsetId", rrset.ID)
+							meta.SetStatusCondition(&recordSet.Status.Conditions, metav1.Condition{
+								Type:               "Available",
+								Status:             metav1.ConditionFalse,
+								ObservedGeneration: recordSet.Generation,
+								Reason:             "DeletionFailed",
+								Message:            fmt.Sprintf("Failed to delete RRSet from Hetzner Cloud: %v. %v", err, response),
+							})
+							if err := r.Status().Update(ctx, &recordSet); err != nil {
+								log.Error(err, "Failed to update HcloudDnsRecordSet status", "name", recordSet.Name)
+							}
+
+							r.Recorder.Eventf(&recordSet, "Warning", "DeletionFailed", "Failed to delete RRSet %s/%s from Hetzner cloud", recordSet.Spec.Name, recordSet.Spec.Type)
+
+							return ctrl.Result{}, err
+						}
+
+						log.Info("Successfully deleted Hetzner Cloud RRSet", "rrsetId", rrset.ID)
+						r.Recorder.Eventf(&recordSet, "Normal", "Deleted", "HcloudDnsRecordSet %s/%s deleted successfully", recordSet.Spec.Name, recordSet.Spec.Type)
+					} else {
+						log.Info("RRSet not found in Hetzner Cloud, nothing to delete", "zoneId", recordSet.Status.ZoneId)
+					}
+				}
+			} else if recordSet.Status.ZoneId != 0 {
+				log.Info("Sync policy does not delete the cloud resource, will not remove it", "syncPolicy", policy.mode)
+			}
+
+			// Remove finalizer
+			controllerutil.RemoveFinalizer(&recordSet, finalizerName)
+			if err := r.Update(ctx, &recordSet); err != nil {
+				log.Error(err, "Failed to remove finalizer", "name", recordSet.Name)
+				return ctrl.Result{}, err
+			}
+			log.Info("Finalizer removed, resource deletion complete", "name", recordSet.Name)
+		}
+		return ctrl.Result{}, nil
+	}
+
+	// Initialize annotations if not present
+	if recordSet.Annotations == nil {
+		recordSet.Annotations = make(map[string]string)
+	}
+
+	// Add sync policy annotation if neither it nor the sync policy mode is present
+	if recordSet.Annotations[syncPolicy] == "" && (recordSet.Spec.SyncPolicy == nil || recordSet.Spec.SyncPolicy.Mode == "") {
+		log.Info("Adding sync policy annotation", "name", recordSet.Name)
+		recordSet.Annotations[syncPolicy] = string(hcloudv1alpha1.SyncPolicyManage)
+		if err := r.Update(ctx, &recordSet); err != nil {
+			log.Error(err, "Failed to add sync policy annotation", "name", recordSet.Name)
+			return ctrl.Result{}, err
+		}
+	}
+
+	policy, err := resolveSyncPolicy(&recordSet, recordSet.Spec.SyncPolicy, hcloudv1alpha1.SyncPolicyManage, hcloudv1alpha1.HcloudDnsRecordSetIgnorableFields)
+	if err != nil {
+		log.Error(err, "Invalid sync policy", "name", recordSet.Name)
+		meta.SetStatusCondition(&recordSet.Status.Conditions, metav1.Condition{
+			Type:               "Available",
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: recordSet.Generation,
+			Reason:             invalidSyncPolicyReason,
+			Message:            err.Error(),
+		})
+		if err := r.Status().Update(ctx, &recordSet); err != nil {
+			log.Error(err, "Failed to update HcloudDnsRecordSet status", "name", recordSet.Name)
+			return ctrl.Result{}, err
+		}
+		r.Recorder.Eventf(&recordSet, "Warning", invalidSyncPolicyReason, "Sync policy of RRSet %s/%s is invalid: %v", recordSet.Spec.Name, recordSet.Spec.Type, err)
+
+		// The sync policy has to change before the RRSet can be reconciled
+		return ctrl.Result{}, nil
+	}
+
+	// Add finalizer if not present and sync policy supports it
+	if !controllerutil.ContainsFinalizer(&recordSet, finalizerName) && policy.claims() {
+		log.Info("Adding finalizer", "name", recordSet.Name)
+		controllerutil.AddFinalizer(&recordSet, finalizerName)
+		if err := r.Update(ctx, &recordSet); err != nil {
+			log.Error(err, "Failed to add finalizer", "name", recordSet.Name)
+			return ctrl.Result{}, err
+		}
+	}
+
+	// Resolve the Hetzner Cloud client for this resource
+	dnsZoneClient, _, err := r.dnsZoneClientFor(ctx, &recordSet)
+	if err != nil {
+		log.Error(err, "Failed to resolve Hetzner Cloud credentials", "name", recordSet.Name)
+		meta.SetStatusCondition(&recordSet.Status.Conditions, metav1.Condition{
+			Type:               "Available",
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: recordSet.Generation,
+			Reason:             credentialsUnavailableReason,
+			Message:            fmt.Sprintf("Failed to resolve Hetzner Cloud credentials: %v", err),
+		})
+		if err := r.Status().Update(ctx, &recordSet); err != nil {
+			log.Error(err, "Failed to update HcloudDnsRecordSet status", "name", recordSet.Name)
+		}
+		r.Recorder.Eventf(&recordSet, "Warning", credentialsUnavailableReason, "Failed to resolve Hetzner Cloud credentials: %v", err)
+
+		return ctrl.Result{}, err
+	}
+
+	// Resolve the zone the RRSet belongs to
+	zoneId, err := r.resolveZoneId(ctx, &recordSet)
+	if err != nil {
+		log.Error(err, "Failed to resolve zone reference", "name", recordSet.Name)
+		meta.SetStatusCondition(&recordSet.Status.Conditions, metav1.Condition{
+			Type:               "Available",
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: recordSet.Generation,
+			Reason:             "Failed",
+			Message:            fmt.Sprintf("Failed to resolve zone reference: %v", err),
+		})
+		if err := r.Status().Update(ctx, &recordSet); err != nil {
+			log.Error(err, "Failed to update HcloudDnsRecordSet status", "name", recordSet.Name)
+		}
+		return ctrl.Result{}, err
+	}
+	if zoneId == 0 {
+		log.Info("Referenced HcloudDnsZone has no zone ID yet, requeueing", "zoneRef", recordSet.Spec.ZoneRef.Name)
+		meta.SetStatusCondition(&recordSet.Status.Conditions, metav1.Condition{
+			Type:               "Available",
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: recordSet.Generation,
+			Reason:             "ZoneNotReady",
+			Message:            fmt.Sprintf("HcloudDnsZone %s is not ready", recordSet.Spec.ZoneRef.Name),
+		})
+		if err := r.Status().Update(ctx, &recordSet); err != nil {
+			log.Error(err, "Failed to update HcloudDnsRecordSet status", "name", recordSet.Name)
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{RequeueAfter: zoneNotReadyRequeueAfter}, nil
+	}
+
+	zone, response, err := dnsZoneClient.GetZoneById(ctx, int64(zoneId))
+	if err == nil && zone == nil {
+		err = fmt.Errorf("dns zone %d not found in Hetzner Cloud", zoneId)
+	}
+	if err != nil {
+		log.Error(err, "Failed to get dns zone from Hetzner Cloud", "zoneId", zoneId)
+		meta.SetStatusCondition(&recordSet.Status.Conditions, metav1.Condition{
+			Type:               "Available",
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: recordSet.Generation,
+			Reason:             "Failed",
+			Message:            fmt.Sprintf("Failed to get dns zone %d from Hetzner Cloud: %v. %v", zoneId, err, response),
+		})
+		if err := r.Status().Update(ctx, &recordSet); err != nil {
+			log.Error(err, "Failed to update HcloudDnsRecordSet status", "name", recordSet.Name)
+		}
+		r.Recorder.Eventf(&recordSet, "Warning", "UpdateFailed", "Failed to get dns zone %d from Hetzner cloud", zoneId)
+
+		return ctrl.Result{}, err
+	}
+
+	// Adopt existing RRSet if it exists
+	log.Info("Checking for existing RRSet in Hetzner Cloud", "zoneId", zone.ID, "rrset", recordSet.Spec.Name, "type", recordSet.Spec.Type)
+	rrset, response, err := dnsZoneClient.GetRRSet(ctx, zone, recordSet.Spec.Name, recordSet.Spec.Type)
+	if err != nil {
+		log.Error(err, "Failed to get RRSet from Hetzner Cloud", "zoneId", zone.ID)
+		meta.SetStatusCondition(&recordSet.Status.Conditions, metav1.Condition{
+			Type:               "Available",
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: recordSet.Generation,
+			Reason:             "Failed",
+			Message:            fmt.Sprintf("Failed to get RRSet from Hetzner Cloud: %v. %v", err, response),
+		})
+		if err := r.Status().Update(ctx, &recordSet); err != nil {
+			log.Error(err, "Failed to update HcloudDnsRecordSet status", "name", recordSet.Name)
+		}
+		r.Recorder.Eventf(&recordSet, "Warning", "UpdateFailed", "Failed to get RRSet %s/%s from Hetzner cloud", recordSet.Spec.Name, recordSet.Spec.Type)
+
+		return ctrl.Result{}, err
+	}
+
+	desiredRecords := toZoneRRSetRecords(recordSet.Spec.Records)
+
+	if rrset != nil {
+		log.Info("Found existing RRSet in Hetzner Cloud", "rrsetId", rrset.ID)
+
+		// Differences found while the spec is unchanged were made outside of the operator
+		var drift []string
+		if policy.reportsDrift() && (!policy.updates() || recordSet.Status.ObservedGeneration == recordSet.Generation) {
+			drift = recordSetDrift(recordSet.Spec, policy, desiredRecords, rrset)
+		}
+		if len(drift) > 0 {
+			log.Info("RRSet drifted from its spec", "rrsetId", rrset.ID, "drift", drift)
+			r.Recorder.Eventf(&recordSet, "Warning", driftedCondition, "RRSet %s/%s drifted from its spec: %s", recordSet.Spec.Name, recordSet.Spec.Type, strings.Join(drift, "; "))
+		}
+
+		// Update the existing RRSet if sync policy allows it, fields left to other tools are never updated
+		if policy.updates() {
+			needsRecordsUpdate := !policy.ignores("records") && !rrsetRecordsEqual(desiredRecords, rrset.Records)
+			needsTTLUpdate := !policy.ignores("ttl") && !equality.Semantic.DeepEqual(recordSet.Spec.TTL, rrset.TTL)
+			needsLabelsUpdate := !policy.ignores("labels") && recordSet.Spec.Labels != nil && !equality.Semantic.DeepEqual(recordSet.Spec.Labels, rrset.Labels)
+
+			if needsRecordsUpdate {
+				log.Info("RRSet records differ, updating", "current", rrset.Records, "desired", desiredRecords)
+				rrset, response, err = dnsZoneClient.UpdateRRSetRecords(ctx, rrset, desiredRecords)
+			}
+			if err == nil && needsTTLUpdate {
+				log.Info("RRSet TTL differs, updating", "desired", recordSet.Spec.TTL)
+				rrset, response, err = dnsZoneClient.UpdateRRSetTTL(ctx, rrset, recordSet.Spec.TTL)
+			}
+			if err == nil && needsLabelsUpdate {
+				log.Info("RRSet labels differ, updating", "current", rrset.Labels, "desired", recordSet.Spec.Labels)
+				rrset, response, err = dnsZoneClient.UpdateRRSetLabels(ctx, rrset, recordSet.Spec.Labels)
+			}
+			if err != nil {
+				log.Error(err, "Failed to update RRSet in Hetzner Cloud", "zoneId", zone.ID)
+				meta.SetStatusCondition(&recordSet.Status.Conditions, metav1.Condition{
+					Type:               "Available",
+					Status:             metav1.ConditionFalse,
+					ObservedGeneration: recordSet.Generation,
+					Reason:             "Failed",
+					Message:            fmt.Sprintf("Failed to update RRSet in Hetzner Cloud: %v. %v", err, response),
+				})
+				if err := r.Status().Update(ctx, &recordSet); err != nil {
+					log.Error(err, "Failed to update HcloudDnsRecordSet status", "name", recordSet.Name)
+				}
+				r.Recorder.Eventf(&recordSet, "Warning", "UpdateFailed", "Failed to update RRSet %s/%s in Hetzner cloud", recordSet.Spec.Name, recordSet.Spec.Type)
+
+				return ctrl.Result{}, err
+			}
+			if !needsRecordsUpdate && !needsTTLUpdate && !needsLabelsUpdate {
+				log.Info("No updates required for existing RRSet", "rrsetId", rrset.ID)
+			}
+		} else {
+			log.Info("Sync policy does not update existing RRSets; skipping updates", "rrsetId", rrset.ID, "syncPolicy", policy.mode)
+		}
+
+		// Update the resource status with the RRSet details and conditions
+		setRecordSetStatus(&recordSet, zone, rrset)
+		if policy.reportsDrift() {
+			setDriftCondition(&recordSet.Status.Conditions, recordSet.Generation, drift, policy.updates())
+		} else {
+			meta.RemoveStatusCondition(&recordSet.Status.Conditions, driftedCondition)
+		}
+		meta.SetStatusCondition(&recordSet.Status.Conditions, metav1.Condition{
+			Type:               "Available",
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: recordSet.Generation,
+			Reason:             "Ready",
+			Message:            fmt.Sprintf("RRSet %s reconciled successfully", rrset.ID),
+		})
+		recordSet.Status.ObservedGeneration = recordSet.Generation
+
+		if err := r.Status().Update(ctx, &recordSet); err != nil {
+			log.Error(err, "Failed to update HcloudDnsRecordSet status", "name", recordSet.Name)
+			return ctrl.Result{}, err
+		}
+
+		r.Recorder.Eventf(&recordSet, "Normal", "Ready", "HcloudDnsRecordSet updated %s", recordSet.Status.RRSetId)
+
+	} else if policy.creates() {
+		log.Info("RRSet not found in Hetzner Cloud, creating new RRSet", "zoneId", zone.ID, "rrset", recordSet.Spec.Name, "type", recordSet.Spec.Type)
+
+		rrset, response, err := dnsZoneClient.CreateRRSet(ctx, zone, recordSet.Spec.Name, recordSet.Spec.Type, recordSet.Spec.TTL, desiredRecords, recordSet.Spec.Labels)
+		if err != nil {
+			log.Error(err, "Failed to create RRSet in Hetzner Cloud", "zoneId", zone.ID)
+			meta.SetStatusCondition(&recordSet.Status.Conditions, metav1.Condition{
+				Type:               "Available",
+				Status:             metav1.ConditionFalse,
+				ObservedGeneration: recordSet.Generation,
+				Reason:             "Failed",
+				Message:            fmt.Sprintf("Failed to create RRSet in Hetzner Cloud: %v. %v", err, response),
+			})
+			if err := r.Status().Update(ctx, &recordSet); err != nil {
+				log.Error(err, "Failed to update HcloudDnsRecordSet status", "name", recordSet.Name)
+			}
+			r.Recorder.Eventf(&recordSet, "Warning", "CreateFailed", "Failed to create RRSet %s/%s in Hetzner cloud", recordSet.Spec.Name, recordSet.Spec.Type)
+
+			return ctrl.Result{}, err
+		}
+
+		log.Info("Successfully created RRSet in Hetzner Cloud", "rrsetId", rrset.ID)
+
+		setRecordSetStatus(&recordSet, zone, rrset)
+		meta.SetStatusCondition(&recordSet.Status.Conditions, metav1.Condition{
+			Type:               "Available",
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: recordSet.Generation,
+			Reason:             "Ready",
+			Message:            fmt.Sprintf("RRSet created in Hetzner Cloud with ID: %s", rrset.ID),
+		})
+		recordSet.Status.ObservedGeneration = recordSet.Generation
+
+		if err := r.Status().Update(ctx, &recordSet); err != nil {
+			log.Error(err, "Failed to update HcloudDnsRecordSet status", "name", recordSet.Name)
+			return ctrl.Result{}, err
+		}
+
+		r.Recorder.Eventf(&recordSet, "Normal", "Ready", "HcloudDnsRecordSet created %s", recordSet.Status.RRSetId)
+	} else {
+		log.Info("RRSet not found in Hetzner Cloud and sync policy does not create it; skipping creation", "rrset", recordSet.Spec.Name, "type", recordSet.Spec.Type, "syncPolicy", policy.mode)
+		recordSet.Status.ZoneId = int(zone.ID)
+		meta.SetStatusCondition(&recordSet.Status.Conditions, metav1.Condition{
+			Type:               "Available",
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: recordSet.Generation,
+			Reason:             "Failed",
+			Message:            fmt.Sprintf("RRSet not found in Hetzner Cloud and sync policy is %s", policy.mode),
+		})
+		if err := r.Status().Update(ctx, &recordSet); err != nil {
+			log.Error(err, "Failed to update HcloudDnsRecordSet status", "name", recordSet.Name)
+			return ctrl.Result{}, err
+		}
+		r.Recorder.Eventf(&recordSet, "Warning", "Failed", "RRSet %s/%s not found in Hetzner cloud", recordSet.Spec.Name, recordSet.Spec.Type)
+	}
+
+	log.Info("HcloudDnsRecordSet resource reconciled successfully", "name", recordSet.Name)
+	return ctrl.Result{RequeueAfter: resyncAfter(&recordSet, r.ResyncInterval)}, nil
+}
+
+// resolveZoneId returns the Hetzner Cloud zone ID referenced by the record set. A zero ID
+// without error means the referenced HcloudDnsZone exists but has not been provisioned yet.
+func (r *HcloudDnsRecordSetReconciler) resolveZoneId(ctx context.Context, recordSet *hcloudv1alpha1.HcloudDnsRecordSet) (int, error) {
+	if recordSet.Spec.ZoneRef.ZoneId != 0 {
+		return recordSet.Spec.ZoneRef.ZoneId, nil
+	}
+
+	var hcloudDnsZone hcloudv1alpha1.HcloudDnsZone
+	key := types.NamespacedName{Name: recordSet.Spec.ZoneRef.Name, Namespace: recordSet.Namespace}
+	if err := r.Get(ctx, key, &hcloudDnsZone); err != nil {
+		return 0, err
+	}
+	return hcloudDnsZone.Status.ZoneId, nil
+}
+
+// toZoneRRSetRecords converts the records of the spec into their Hetzner Cloud representation
+func toZoneRRSetRecords(records []hcloudv1alpha1.HcloudDnsRecord) []hcloudgo.ZoneRRSetRecord {
+	result := make([]hcloudgo.ZoneRRSetRecord, 0, len(records))
+	for _, record := range records {
+		result = append(result, hcloudgo.ZoneRRSetRecord{Value: record.Value, Comment: record.Comment})
+	}
+	return result
+}
+
+// rrsetRecordsEqual compares two record lists ignoring their order
+func rrsetRecordsEqual(a, b []hcloudgo.ZoneRRSetRecord) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	sortedA := append([]hcloudgo.ZoneRRSetRecord(nil), a...)
+	sortedB := append([]hcloudgo.ZoneRRSetRecord(nil), b...)
+	less := func(records []hcloudgo.ZoneRRSetRecord) func(i, j int) bool {
+		return func(i, j int) bool {
+			if records[i].Value != records[j].Value {
+				return records[i].Value < records[j].Value
+			}
+			return records[i].Comment < records[j].Comment
+		}
+	}
+	sort.Slice(sortedA, less(sortedA))
+	sort.Slice(sortedB, less(sortedB))
+	return equality.Semantic.DeepEqual(sortedA, sortedB)
+}
+
+// recordSetDrift returns the fields in which the Hetzner Cloud RRSet differs from the spec,
+// fields left to other tools are skipped
+func recordSetDrift(spec hcloudv1alpha1.HcloudDnsRecordSetSpec, policy effectiveSyncPolicy, desiredRecords []hcloudgo.ZoneRRSetRecord, rrset *hcloudgo.ZoneRRSet) []string {
+	var drift []string
+	if !policy.ignores("records") && !rrsetRecordsEqual(desiredRecords, rrset.Records) {
+		drift = append(drift, driftEntry("records", formatRRSetRecords(rrset.Records), formatRRSetRecords(desiredRecords)))
+	}
+	if !policy.ignores("ttl") && !equality.Semantic.DeepEqual(spec.TTL, rrset.TTL) {
+		drift = append(drift, driftEntry("ttl", formatTTL(rrset.TTL), formatTTL(spec.TTL)))
+	}
+	return drift
+}
+
+// formatRRSetRecords formats the values of records as a sorted comma separated list
+func formatRRSetRecords(records []hcloudgo.ZoneRRSetRecord) string {
+	values := make([]string, 0, len(records))
+	for _, record := range records {
+		values = append(values, record.Value)
+	}
+	sort.Strings(values)
+	return strings.Join(values, ", ")
+}
+
+// formatTTL formats a TTL, an unset TTL is formatted as empty
+func formatTTL(ttl *int) string {
+	if ttl == nil {
+		return ""
+	}
+	return strconv.Itoa(*ttl)
+}
+
+// setRecordSetStatus copies the observed Hetzner Cloud RRSet into the resource status
+func setRecordSetStatus(recordSet *hcloudv1alpha1.HcloudDnsRecordSet, zone *hcloudgo.Zone, rrset *hcloudgo.ZoneRRSet) {
+	recordSet.Status.RRSetId = rrset.ID
+	recordSet.Status.ZoneId = int(zone.ID)
+	recordSet.Status.TTL = rrset.TTL
+	recordSet.Status.Labels = rrset.Labels
+	recordSet.Status.Records = make([]hcloudv1alpha1.HcloudDnsRecord, 0, len(rrset.Records))
+	for _, record := range rrset.Records {
+		recordSet.Status.Records = append(recordSet.Status.Records, hcloudv1alpha1.HcloudDnsRecord{Value: record.Value, Comment: record.Comment})
+	}
+}
+
+// dnsZoneClientFor returns the Hetzner Cloud client for the HcloudDnsRecordSet together with the resolved provider.
+// The client is built from the credentials of the resource or its provider config, or is the client
+// configured for the manager when neither is set.
+func (r *HcloudDnsRecordSetReconciler) dnsZoneClientFor(ctx context.Context, recordSet *hcloudv1alpha1.HcloudDnsRecordSet) (hcloud.DnsZoneClient, *hcloudProvider, error) {
+	// Record sets referencing an HcloudDnsZone by name use the credentials of that zone, only record
+	// sets referencing a zone by ID fall back to the default credentials
+	var credentialsRef *hcloudv1alpha1.HcloudCredentialsReference
+	var providerConfigRef *hcloudv1alpha1.HcloudProviderConfigReference
+	if recordSet.Spec.ZoneRef.Name != "" {
+		var hcloudDnsZone hcloudv1alpha1.HcloudDnsZone
+		key := types.NamespacedName{Name: recordSet.Spec.ZoneRef.Name, Namespace: recordSet.Namespace}
+		if err := r.Get(ctx, key, &hcloudDnsZone); err != nil {
+			return nil, nil, err
+		}
+		credentialsRef = hcloudDnsZone.Spec.CredentialsRef
+		providerConfigRef = hcloudDnsZone.Spec.ProviderConfigRef
+	}
+
+	provider, err := resolveProvider(ctx, r.Client, r.DefaultProviderConfig, recordSet.Namespace, credentialsRef, providerConfigRef)
+	if err != nil {
+		return nil, nil, err
+	}
+	if provider.token == "" {
+		if r.DnsZoneClient == nil {
+			return nil, nil, fmt.Errorf("no credentials configured for the resource and no Hetzner Cloud token configured for the manager")
+		}
+		return r.DnsZoneClient, provider, nil
+	}
+	if r.ClientCache == nil {
+		return nil, nil, fmt.Errorf("no client cache configured to resolve credentials")
+	}
+	return r.ClientCache.DnsZoneClient(provider.cacheKey, provider.cacheVersion, provider.token, provider.options...), provider, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *HcloudDnsRecordSetReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&hcloudv1alpha1.HcloudDnsRecordSet{}).
+		Named("hclouddnsrecordset").
+		WithEventFilter(predicate.Or(predicate.GenerationChangedPredicate{}, pausedAnnotationChanged)).
+		Complete(r)
+}
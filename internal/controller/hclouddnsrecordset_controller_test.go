@@ -0,0 +1,515 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hcloudv1alpha1 "bunskin.com/hcrm/api/v1alpha1"
+	"bunskin.com/hcrm/pkg/hcloud"
+	hcloudgo "github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+var _ = Describe("HcloudDnsRecordSet Controller", func() {
+	const namespace = "default"
+
+	ctx := context.Background()
+
+	newZoneMock := func() *hcloud.MockDnsZoneClient {
+		MockDnsZoneClient := &hcloud.MockDnsZoneClient{}
+		MockDnsZoneClient.GetZoneByIdFunc = func(ctx context.Context, id int64) (*hcloudgo.Zone, *hcloudgo.Response, error) {
+			return &hcloudgo.Zone{ID: id, Name: "example.com"}, nil, nil
+		}
+		return MockDnsZoneClient
+	}
+
+	Context("Create new HcloudDnsRecordSet", func() {
+		It("should create the RRSet in the referenced zone", func() {
+			const resourceName = "test-create-rrset"
+			typeNamespacedName := types.NamespacedName{
+				Name:      resourceName,
+				Namespace: namespace,
+			}
+
+			ttl := 300
+			By("creating the HcloudDnsRecordSet resource")
+			resource := &hcloudv1alpha1.HcloudDnsRecordSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: namespace,
+				},
+				Spec: hcloudv1alpha1.HcloudDnsRecordSetSpec{
+					ZoneRef: hcloudv1alpha1.HcloudDnsZoneReference{ZoneId: 4242},
+					Name:    "www",
+					Type:    "A",
+					TTL:     &ttl,
+					Records: []hcloudv1alpha1.HcloudDnsRecord{
+						{Value: "198.51.100.10", Comment: "web"},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			MockDnsZoneClient := newZoneMock()
+			MockDnsZoneClient.CreateRRSetFunc = func(ctx context.Context, zone *hcloudgo.Zone, name string, rrsetType string, ttl *int, records []hcloudgo.ZoneRRSetRecord, labels map[string]string) (*hcloudgo.ZoneRRSet, *hcloudgo.Response, error) {
+				return &hcloudgo.ZoneRRSet{
+					Zone:    zone,
+					ID:      name + "/" + rrsetType,
+					Name:    name,
+					Type:    hcloudgo.ZoneRRSetType(rrsetType),
+					TTL:     ttl,
+					Records: records,
+				}, nil, nil
+			}
+
+			reconciler := &HcloudDnsRecordSetReconciler{
+				Client:        k8sClient,
+				Scheme:        k8sClient.Scheme(),
+				DnsZoneClient: MockDnsZoneClient,
+				Recorder:      recorder,
+			}
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			By("verifying the resource status was updated")
+			updatedResource := &hcloudv1alpha1.HcloudDnsRecordSet{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			Expect(updatedResource.Status.RRSetId).To(Equal("www/A"))
+			Expect(updatedResource.Status.ZoneId).To(Equal(4242))
+			Expect(*updatedResource.Status.TTL).To(Equal(300))
+			Expect(updatedResource.Status.Records).To(Equal(resource.Spec.Records))
+			Expect(updatedResource.Finalizers).To(ContainElement(finalizerName))
+
+			condition := meta.FindStatusCondition(updatedResource.Status.Conditions, "Available")
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(condition.Reason).To(Equal("Ready"))
+
+			By("cleaning up the resource")
+			Expect(k8sClient.Delete(ctx, updatedResource)).To(Succeed())
+		})
+
+		It("should requeue while the referenced HcloudDnsZone has no zone ID", func() {
+			const resourceName = "test-rrset-zone-not-ready"
+			typeNamespacedName := types.NamespacedName{
+				Name:      resourceName,
+				Namespace: namespace,
+			}
+
+			By("creating an unprovisioned HcloudDnsZone")
+			zoneResource := &hcloudv1alpha1.HcloudDnsZone{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "rrset-pending-zone",
+					Namespace: namespace,
+				},
+				Spec: hcloudv1alpha1.HcloudDnsZoneSpec{
+					Name: "pending.example.com",
+				},
+			}
+			Expect(k8sClient.Create(ctx, zoneResource)).To(Succeed())
+
+			resource := &hcloudv1alpha1.HcloudDnsRecordSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: namespace,
+				},
+				Spec: hcloudv1alpha1.HcloudDnsRecordSetSpec{
+					ZoneRef: hcloudv1alpha1.HcloudDnsZoneReference{Name: "rrset-pending-zone"},
+					Name:    "@",
+					Type:    "TXT",
+					Records: []hcloudv1alpha1.HcloudDnsRecord{
+						{Value: "\"v=spf1 -all\""},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			reconciler := &HcloudDnsRecordSetReconciler{
+				Client:        k8sClient,
+				Scheme:        k8sClient.Scheme(),
+				DnsZoneClient: newZoneMock(),
+				Recorder:      recorder,
+			}
+
+			result, err := reconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(zoneNotReadyRequeueAfter))
+
+			updatedResource := &hcloudv1alpha1.HcloudDnsRecordSet{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			condition := meta.FindStatusCondition(updatedResource.Status.Conditions, "Available")
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Reason).To(Equal("ZoneNotReady"))
+
+			By("cleaning up the resources")
+			Expect(k8sClient.Delete(ctx, updatedResource)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, zoneResource)).To(Succeed())
+		})
+
+		It("should reject a zone reference with both name and zone ID", func() {
+			resource := &hcloudv1alpha1.HcloudDnsRecordSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-rrset-invalid-ref",
+					Namespace: namespace,
+				},
+				Spec: hcloudv1alpha1.HcloudDnsRecordSetSpec{
+					ZoneRef: hcloudv1alpha1.HcloudDnsZoneReference{Name: "zone", ZoneId: 1},
+					Name:    "www",
+					Type:    "A",
+					Records: []hcloudv1alpha1.HcloudDnsRecord{{Value: "198.51.100.10"}},
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).NotTo(Succeed())
+		})
+	})
+
+	Context("Update existing HcloudDnsRecordSet", func() {
+		It("should overwrite drifted records and reset the TTL", func() {
+			const resourceName = "test-update-rrset"
+			typeNamespacedName := types.NamespacedName{
+				Name:      resourceName,
+				Namespace: namespace,
+			}
+
+			By("creating a provisioned HcloudDnsZone")
+			zoneResource := &hcloudv1alpha1.HcloudDnsZone{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "rrset-ready-zone",
+					Namespace: namespace,
+				},
+				Spec: hcloudv1alpha1.HcloudDnsZoneSpec{
+					Name: "ready.example.com",
+				},
+			}
+			Expect(k8sClient.Create(ctx, zoneResource)).To(Succeed())
+			zoneResource.Status.ZoneId = 31337
+			Expect(k8sClient.Status().Update(ctx, zoneResource)).To(Succeed())
+
+			resource := &hcloudv1alpha1.HcloudDnsRecordSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: namespace,
+				},
+				Spec: hcloudv1alpha1.HcloudDnsRecordSetSpec{
+					ZoneRef: hcloudv1alpha1.HcloudDnsZoneReference{Name: "rrset-ready-zone"},
+					Name:    "mail",
+					Type:    "MX",
+					Records: []hcloudv1alpha1.HcloudDnsRecord{
+						{Value: "10 mx1.example.com."},
+						{Value: "20 mx2.example.com."},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			oldTTL := 60
+			MockDnsZoneClient := newZoneMock()
+			MockDnsZoneClient.GetRRSetFunc = func(ctx context.Context, zone *hcloudgo.Zone, name string, rrsetType string) (*hcloudgo.ZoneRRSet, *hcloudgo.Response, error) {
+				Expect(zone.ID).To(Equal(int64(31337)))
+				return &hcloudgo.ZoneRRSet{
+					Zone:    zone,
+					ID:      "mail/MX",
+					Name:    name,
+					Type:    hcloudgo.ZoneRRSetType(rrsetType),
+					TTL:     &oldTTL,
+					Records: []hcloudgo.ZoneRRSetRecord{{Value: "10 mx1.example.com."}},
+				}, nil, nil
+			}
+			var updatedRecords []hcloudgo.ZoneRRSetRecord
+			MockDnsZoneClient.UpdateRRSetRecordsFunc = func(ctx context.Context, rrset *hcloudgo.ZoneRRSet, records []hcloudgo.ZoneRRSetRecord) (*hcloudgo.ZoneRRSet, *hcloudgo.Response, error) {
+				updatedRecords = records
+				rrset.Records = records
+				return rrset, nil, nil
+			}
+			ttlReset := false
+			MockDnsZoneClient.UpdateRRSetTTLFunc = func(ctx context.Context, rrset *hcloudgo.ZoneRRSet, ttl *int) (*hcloudgo.ZoneRRSet, *hcloudgo.Response, error) {
+				ttlReset = ttl == nil
+				rrset.TTL = ttl
+				return rrset, nil, nil
+			}
+
+			reconciler := &HcloudDnsRecordSetReconciler{
+				Client:        k8sClient,
+				Scheme:        k8sClient.Scheme(),
+				DnsZoneClient: MockDnsZoneClient,
+				Recorder:      recorder,
+			}
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(updatedRecords).To(HaveLen(2))
+			Expect(ttlReset).To(BeTrue())
+
+			updatedResource := &hcloudv1alpha1.HcloudDnsRecordSet{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			Expect(updatedResource.Status.ZoneId).To(Equal(31337))
+			Expect(updatedResource.Status.Records).To(HaveLen(2))
+			Expect(updatedResource.Status.TTL).To(BeNil())
+
+			By("cleaning up the resources")
+			Expect(k8sClient.Delete(ctx, updatedResource)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, zoneResource)).To(Succeed())
+		})
+
+		It("should report drift of a read-only RRSet and resync it", func() {
+			const resourceName = "test-drifted-rrset"
+			typeNamespacedName := types.NamespacedName{
+				Name:      resourceName,
+				Namespace: namespace,
+			}
+
+			ttl := 300
+			resource := &hcloudv1alpha1.HcloudDnsRecordSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: namespace,
+					Annotations: map[string]string{
+						syncPolicy: "read-only",
+					},
+				},
+				Spec: hcloudv1alpha1.HcloudDnsRecordSetSpec{
+					ZoneRef: hcloudv1alpha1.HcloudDnsZoneReference{ZoneId: 4242},
+					Name:    "www",
+					Type:    "A",
+					TTL:     &ttl,
+					Records: []hcloudv1alpha1.HcloudDnsRecord{{Value: "192.0.2.1"}},
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			liveTTL := 60
+			MockDnsZoneClient := newZoneMock()
+			MockDnsZoneClient.GetRRSetFunc = func(ctx context.Context, zone *hcloudgo.Zone, name string, rrsetType string) (*hcloudgo.ZoneRRSet, *hcloudgo.Response, error) {
+				return &hcloudgo.ZoneRRSet{
+					Zone:    zone,
+					ID:      "www/A",
+					Name:    name,
+					Type:    hcloudgo.ZoneRRSetType(rrsetType),
+					TTL:     &liveTTL,
+					Records: []hcloudgo.ZoneRRSetRecord{{Value: "192.0.2.2"}},
+				}, nil, nil
+			}
+
+			reconciler := &HcloudDnsRecordSetReconciler{
+				Client:         k8sClient,
+				Scheme:         k8sClient.Scheme(),
+				DnsZoneClient:  MockDnsZoneClient,
+				ResyncInterval: 10 * time.Minute,
+				Recorder:       recorder,
+			}
+
+			result, err := reconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(10 * time.Minute))
+
+			By("verifying the drift was reported")
+			updatedResource := &hcloudv1alpha1.HcloudDnsRecordSet{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			condition := meta.FindStatusCondition(updatedResource.Status.Conditions, driftedCondition)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(condition.Reason).To(Equal("DriftDetected"))
+			Expect(condition.Message).To(Equal("records: 192.0.2.2 -> 192.0.2.1; ttl: 60 -> 300"))
+
+			By("cleaning up the resource")
+			Expect(k8sClient.Delete(ctx, updatedResource)).To(Succeed())
+		})
+	})
+
+	Context("Delete HcloudDnsRecordSet", func() {
+		It("should delete the RRSet from Hetzner Cloud and remove finalizer", func() {
+			const resourceName = "test-delete-rrset"
+			typeNamespacedName := types.NamespacedName{
+				Name:      resourceName,
+				Namespace: namespace,
+			}
+
+			resource := &hcloudv1alpha1.HcloudDnsRecordSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: namespace,
+				},
+				Spec: hcloudv1alpha1.HcloudDnsRecordSetSpec{
+					ZoneRef: hcloudv1alpha1.HcloudDnsZoneReference{ZoneId: 4242},
+					Name:    "old",
+					Type:    "CNAME",
+					Records: []hcloudv1alpha1.HcloudDnsRecord{{Value: "www.example.com."}},
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			MockDnsZoneClient := newZoneMock()
+			MockDnsZoneClient.GetRRSetFunc = func(ctx context.Context, zone *hcloudgo.Zone, name string, rrsetType string) (*hcloudgo.ZoneRRSet, *hcloudgo.Response, error) {
+				return &hcloudgo.ZoneRRSet{Zone: zone, ID: "old/CNAME", Name: name, Type: hcloudgo.ZoneRRSetType(rrsetType)}, nil, nil
+			}
+			deleted := false
+			MockDnsZoneClient.DeleteRRSetFunc = func(ctx context.Context, rrset *hcloudgo.ZoneRRSet) (*hcloudgo.Response, error) {
+				deleted = true
+				return nil, nil
+			}
+
+			By("setting zone ID and finalizer")
+			resource.Status.ZoneId = 4242
+			Expect(k8sClient.Status().Update(ctx, resource)).To(Succeed())
+
+			getResource := &hcloudv1alpha1.HcloudDnsRecordSet{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, getResource)).To(Succeed())
+			getResource.Finalizers = []string{finalizerName}
+			Expect(k8sClient.Update(ctx, getResource)).To(Succeed())
+
+			By("initiating deletion of the resource")
+			Expect(k8sClient.Delete(ctx, getResource)).To(Succeed())
+
+			reconciler := &HcloudDnsRecordSetReconciler{
+				Client:        k8sClient,
+				Scheme:        k8sClient.Scheme(),
+				DnsZoneClient: MockDnsZoneClient,
+				Recorder:      recorder,
+			}
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deleted).To(BeTrue())
+
+			By("verifying finalizer was removed and resource is gone")
+			deletedResource := &hcloudv1alpha1.HcloudDnsRecordSet{}
+			err = k8sClient.Get(ctx, typeNamespacedName, deletedResource)
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("should remove finalizer when the RRSet was deleted in the meantime", func() {
+			const resourceName = "test-delete-gone-rrset"
+			typeNamespacedName := types.NamespacedName{
+				Name:      resourceName,
+				Namespace: namespace,
+			}
+
+			resource := &hcloudv1alpha1.HcloudDnsRecordSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       resourceName,
+					Namespace:  namespace,
+					Finalizers: []string{finalizerName},
+				},
+				Spec: hcloudv1alpha1.HcloudDnsRecordSetSpec{
+					ZoneRef: hcloudv1alpha1.HcloudDnsZoneReference{ZoneId: 4242},
+					Name:    "gone",
+					Type:    "CNAME",
+					Records: []hcloudv1alpha1.HcloudDnsRecord{{Value: "www.example.com."}},
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+			resource.Status.ZoneId = 4242
+			Expect(k8sClient.Status().Update(ctx, resource)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+
+			MockDnsZoneClient := newZoneMock()
+			MockDnsZoneClient.GetRRSetFunc = func(ctx context.Context, zone *hcloudgo.Zone, name string, rrsetType string) (*hcloudgo.ZoneRRSet, *hcloudgo.Response, error) {
+				return &hcloudgo.ZoneRRSet{Zone: zone, ID: "gone/CNAME", Name: name, Type: hcloudgo.ZoneRRSetType(rrsetType)}, nil, nil
+			}
+			MockDnsZoneClient.DeleteRRSetFunc = func(ctx context.Context, rrset *hcloudgo.ZoneRRSet) (*hcloudgo.Response, error) {
+				return nil, hcloudgo.Error{Code: hcloudgo.ErrorCodeNotFound, Message: "rrset not found"}
+			}
+
+			reconciler := &HcloudDnsRecordSetReconciler{
+				Client:        k8sClient,
+				Scheme:        k8sClient.Scheme(),
+				DnsZoneClient: MockDnsZoneClient,
+				Recorder:      recorder,
+			}
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			By("verifying finalizer was removed and resource is gone")
+			err = k8sClient.Get(ctx, typeNamespacedName, &hcloudv1alpha1.HcloudDnsRecordSet{})
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("should remove finalizer without calling Hetzner Cloud when the referenced zone is gone", func() {
+			const resourceName = "test-delete-orphaned-rrset"
+			typeNamespacedName := types.NamespacedName{
+				Name:      resourceName,
+				Namespace: namespace,
+			}
+
+			resource := &hcloudv1alpha1.HcloudDnsRecordSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       resourceName,
+					Namespace:  namespace,
+					Finalizers: []string{finalizerName},
+				},
+				Spec: hcloudv1alpha1.HcloudDnsRecordSetSpec{
+					ZoneRef: hcloudv1alpha1.HcloudDnsZoneReference{Name: "deleted-zone"},
+					Name:    "www",
+					Type:    "A",
+					Records: []hcloudv1alpha1.HcloudDnsRecord{{Value: "192.0.2.1"}},
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+			resource.Status.ZoneId = 4242
+			Expect(k8sClient.Status().Update(ctx, resource)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+
+			called := false
+			MockDnsZoneClient := newZoneMock()
+			MockDnsZoneClient.GetRRSetFunc = func(ctx context.Context, zone *hcloudgo.Zone, name string, rrsetType string) (*hcloudgo.ZoneRRSet, *hcloudgo.Response, error) {
+				called = true
+				return nil, nil, nil
+			}
+
+			reconciler := &HcloudDnsRecordSetReconciler{
+				Client:        k8sClient,
+				Scheme:        k8sClient.Scheme(),
+				DnsZoneClient: MockDnsZoneClient,
+				Recorder:      recorder,
+			}
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(called).To(BeFalse())
+
+			By("verifying finalizer was removed and resource is gone")
+			err = k8sClient.Get(ctx, typeNamespacedName, &hcloudv1alpha1.HcloudDnsRecordSet{})
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+		})
+	})
+})
@@ -19,7 +19,12 @@ package controller
 import (
 	"context"
 	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
 
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -32,6 +37,14 @@ import (
 
 	hcloudv1alpha1 "bunskin.com/hcrm/api/v1alpha1"
 	"bunskin.com/hcrm/pkg/hcloud"
+	hcloudgo "github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+const (
+	// defaultDnsZoneMode is the mode used when creating a zone without spec.mode
+	defaultDnsZoneMode = "PRIMARY"
+	// defaultPrimaryNameserverPort is the port of a primary nameserver without spec.primaryNameservers[].port
+	defaultPrimaryNameserverPort = 53
 )
 
 // HcloudDnsZoneReconciler reconciles a HcloudDnsZone object
@@ -39,23 +52,44 @@ type HcloudDnsZoneReconciler struct {
 	client.Client
 	Scheme        *runtime.Scheme
 	DnsZoneClient hcloud.DnsZoneClient
+	ClientCache   *hcloud.ClientCache
 	Recorder      record.EventRecorder
+	// DefaultProviderConfig is the name of the ClusterHcloudProviderConfig used for resources without a providerConfigRef
+	DefaultProviderConfig string
+	// ResyncInterval is the interval after which reconciled resources are checked for drift, zero disables it
+	ResyncInterval time.Duration
+	// ClusterID identifies the cluster in the ownership labels of cloud resources
+	ClusterID string
+	// Pause pauses the reconciliation of whole kinds or namespaces, nil if it cannot be paused by the manager
+	Pause *PauseSwitch
 }
 
 // +kubebuilder:rbac:groups=hcloud.bunskin.com,resources=hclouddnszones,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=hcloud.bunskin.com,resources=hclouddnszones/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=hcloud.bunskin.com,resources=hclouddnszones/finalizers,verbs=update
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=hcloud.bunskin.com,resources=hcloudproviderconfigs;clusterhcloudproviderconfigs,verbs=get;list;watch
 
-// Reconcile is part of the main kubernetes reconciliation loop which aims to
-// move the current state of the cluster closer to the desired state.
-// TODO(user): Modify the Reconcile function to compare the state specified by
-// the HcloudDnsZone object against the actual cluster state, and then
-// perform operations to make the cluster state reflect the state specified by
-// the user.
+// Reconcile looks up the Hetzner Cloud DNS zone by spec.name and adopts, updates
+// or creates it according to the sync policy of the HcloudDnsZone resource.
 //
 // For more details, check Reconcile and its Result here:
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.22.4/pkg/reconcile
 func (r *HcloudDnsZoneReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	result, err := r.reconcile(ctx, req)
+	if reset, ok := hcloud.RateLimitReset(err); ok {
+		var hcloudDnsZone hcloudv1alpha1.HcloudDnsZone
+		if err := r.Get(ctx, req.NamespacedName, &hcloudDnsZone); err != nil {
+			return ctrl.Result{}, client.IgnoreNotFound(err)
+		}
+		return requeueRateLimited(ctx, r.Client, r.Recorder, &hcloudDnsZone, &hcloudDnsZone.Status.Conditions, reset, err)
+	}
+	return result, err
+}
+
+// reconcile implements Reconcile, errors caused by the Hetzner Cloud rate limit are handled by the caller
+func (r *HcloudDnsZoneReconciler) reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := logf.Log.WithName("hclouddnszone-controller")
 
 	// Fetch the HcloudDnsZone resource
@@ -65,6 +99,11 @@ func (r *HcloudDnsZoneReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
+	// A paused resource is left alone, including its deletion
+	if paused, result, err := reconcilePaused(ctx, r.Client, r.Recorder, r.Pause, "HcloudDnsZone", &hcloudDnsZone, &hcloudDnsZone.Status.Conditions); paused {
+		return result, err
+	}
+
 	log.Info("Reconciling HcloudDnsZone", "name", hcloudDnsZone.Name, "namespace", hcloudDnsZone.Namespace)
 	meta.SetStatusCondition(&hcloudDnsZone.Status.Conditions, metav1.Condition{
 		Type:               "Available",
@@ -95,10 +134,47 @@ func (r *HcloudDnsZoneReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 
 		// Check if finalizer exists
 		if controllerutil.ContainsFinalizer(&hcloudDnsZone, finalizerName) {
-			// Delete the DNS zone from Hetzner Cloud if it exists
-			if hcloudDnsZone.Status.ZoneId != 0 && hcloudDnsZone.Annotations[syncPolicy] != "orphan" {
+			policy, policyErr := resolveSyncPolicy(&hcloudDnsZone, hcloudDnsZone.Spec.SyncPolicy, hcloudv1alpha1.SyncPolicyManage, hcloudv1alpha1.HcloudDnsZoneIgnorableFields)
+			if policyErr != nil {
+				// A sync policy that cannot be understood never deletes the zone, it is released instead so
+				// that the orphan collector does not delete it either
+				log.Error(policyErr, "Invalid sync policy, will not remove cloud resource", "name", hcloudDnsZone.Name)
+				r.Recorder.Eventf(&hcloudDnsZone, "Warning", invalidSyncPolicyReason, "DNS zone %d is left in Hetzner cloud: %v", hcloudDnsZone.Status.ZoneId, policyErr)
+				if hcloudDnsZone.Status.ZoneId != 0 {
+					if err := r.releaseDnsZone(ctx, &hcloudDnsZone); err != nil {
+						return ctrl.Result{}, err
+					}
+				}
+			} else if hcloudDnsZone.Status.ZoneId != 0 && policy.deletes() {
+				// Delete the DNS zone from Hetzner Cloud if it exists
+				dnsZoneClient, provider, err := r.dnsZoneClientFor(ctx, &hcloudDnsZone)
+				if err != nil {
+					log.Error(err, "Failed to resolve Hetzner Cloud credentials", "name", hcloudDnsZone.Name)
+					meta.SetStatusCondition(&hcloudDnsZone.Status.Conditions, metav1.Condition{
+						Type:               "Available",
+						Status:             metav1.ConditionFalse,
+						ObservedGeneration: hcloudDnsZone.Generation,
+						Reason:             credentialsUnavailableReason,
+						Message:            fmt.Sprintf("Failed to resolve Hetzner Cloud credentials: %v", err),
+					})
+					if err := r.Status().Update(ctx, &hcloudDnsZone); err != nil {
+						log.Error(err, "Failed to update HcloudDnsZone status", "name", hcloudDnsZone.Name)
+					}
+					r.Recorder.Eventf(&hcloudDnsZone, "Warning", credentialsUnavailableReason, "Failed to resolve Hetzner Cloud credentials: %v", err)
+
+					return ctrl.Result{}, err
+				}
+
+				// Follow actions started by an earlier reconcile, including a running delete action
+				if len(hcloudDnsZone.Status.PendingActions) > 0 {
+					done, result, err := awaitPendingActions(ctx, r.Client, r.Recorder, &hcloudDnsZone, &hcloudDnsZone.Status.PendingActions, &hcloudDnsZone.Status.Conditions, dnsZoneClient.GetAction, provider.actionPollInterval())
+					if !done {
+						return result, err
+					}
+				}
+
 				log.Info("Fetching Hetzner Cloud DNS zone for deletion", "dnsZoneId", hcloudDnsZone.Status.ZoneId)
-				zone, response, err := r.DnsZoneClient.GetZoneById(ctx, int64(hcloudDnsZone.Status.ZoneId))
+				zone, response, err := dnsZoneClient.GetZoneById(ctx, int64(hcloudDnsZone.Status.ZoneId))
 				if err != nil {
 					log.Error(err, "Failed to get dns zone from Hetzner Cloud", "zoneId", hcloudDnsZone.Status.ZoneId)
 					meta.SetStatusCondition(&hcloudDnsZone.Status.Conditions, metav1.Condition{
@@ -106,7 +182,7 @@ func (r *HcloudDnsZoneReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 						Status:             metav1.ConditionFalse,
 						ObservedGeneration: hcloudDnsZone.Generation,
 						Reason:             "DeletionFailed",
-						Message:            fmt.Sprintf("Failed to get network for deletion: %v. %v", err, response),
+						Message:            fmt.Sprintf("Failed to get dns zone for deletion: %v. %v", err, response),
 					})
 					if err := r.Status().Update(ctx, &hcloudDnsZone); err != nil {
 						log.Error(err, "Failed to update HcloudDnsZone status", "name", hcloudDnsZone.Name)
@@ -117,11 +193,26 @@ func (r *HcloudDnsZoneReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 					return ctrl.Result{}, err
 				}
 
+				owner := ""
 				if zone != nil {
+					owner = claimant(zone.Labels, &hcloudDnsZone, r.ClusterID)
+				}
+
+				if owner != "" {
+					// A zone taken over by another resource is left to it, only its ID is dropped
+					log.Info("DNS zone is managed by another resource, will not remove it", "zoneId", hcloudDnsZone.Status.ZoneId, "owner", owner)
+					r.Recorder.Eventf(&hcloudDnsZone, "Warning", alreadyClaimedReason, "DNS zone %d is left in Hetzner cloud as it is managed by %s", hcloudDnsZone.Status.ZoneId, owner)
+					hcloudDnsZone.Status.ZoneId = 0
+					if err := r.Status().Update(ctx, &hcloudDnsZone); err != nil {
+						log.Error(err, "Failed to update HcloudDnsZone status", "name", hcloudDnsZone.Name)
+						return ctrl.Result{}, err
+					}
+				} else if zone != nil {
 					// Delete the dns zone
 					log.Info("Deleting Hetzner Cloud dns zone", "zoneId", hcloudDnsZone.Status.ZoneId)
-					response, err := r.DnsZoneClient.DeleteZone(ctx, zone)
-					if err != nil {
+					action, response, err := dnsZoneClient.DeleteZone(ctx, zone)
+					// A zone deleted by someone else since it was fetched is gone all the same
+					if err != nil && !hcloudgo.IsError(err, hcloudgo.ErrorCodeNotFound) {
 						log.Error(err, "Failed to delete dns zone from Hetzner Cloud", "zoneId", hcloudDnsZone.Status.ZoneId)
 						meta.SetStatusCondition(&hcloudDnsZone.Status.Conditions, metav1.Condition{
 							Type:               "Available",
@@ -139,13 +230,28 @@ func (r *HcloudDnsZoneReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 						return ctrl.Result{}, err
 					}
 
+					// Keep the finalizer until the zone is gone
+					if actionPending(action) {
+						log.Info("Started deleting Hetzner Cloud dns zone", "zoneId", hcloudDnsZone.Status.ZoneId, "actionId", action.ID)
+						recordPendingAction(&hcloudDnsZone.Status.PendingActions, &hcloudDnsZone.Status.Conditions, hcloudDnsZone.Generation, action)
+						if err := r.Status().Update(ctx, &hcloudDnsZone); err != nil {
+							log.Error(err, "Failed to update HcloudDnsZone status", "name", hcloudDnsZone.Name)
+							return ctrl.Result{}, err
+						}
+
+						return ctrl.Result{RequeueAfter: provider.actionPollInterval()}, nil
+					}
+
 					log.Info("Successfully deleted Hetzner Cloud dns zone", "zoneId", hcloudDnsZone.Status.ZoneId)
 					r.Recorder.Eventf(&hcloudDnsZone, "Normal", "Deleted", "HcloudDnsZone %s deleted successfully", hcloudDnsZone.Spec.Name)
 				} else {
 					log.Info("DNS zone not found in Hetzner Cloud, nothing to delete", "zoneId", hcloudDnsZone.Status.ZoneId)
 				}
-			} else if hcloudDnsZone.Annotations[syncPolicy] == "orphan" {
-				log.Info("Sync policy is set to orphan, will not remove cloud resource")
+			} else if hcloudDnsZone.Status.ZoneId != 0 {
+				log.Info("Sync policy does not delete the cloud resource, will not remove it", "syncPolicy", policy.mode)
+				if err := r.releaseDnsZone(ctx, &hcloudDnsZone); err != nil {
+					return ctrl.Result{}, err
+				}
 			}
 
 			// Remove finalizer
@@ -159,23 +265,62 @@ func (r *HcloudDnsZoneReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		return ctrl.Result{}, nil
 	}
 
+	// Resolve the Hetzner Cloud client for this resource
+	dnsZoneClient, provider, err := r.dnsZoneClientFor(ctx, &hcloudDnsZone)
+	if err != nil {
+		log.Error(err, "Failed to resolve Hetzner Cloud credentials", "name", hcloudDnsZone.Name)
+		meta.SetStatusCondition(&hcloudDnsZone.Status.Conditions, metav1.Condition{
+			Type:               "Available",
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: hcloudDnsZone.Generation,
+			Reason:             credentialsUnavailableReason,
+			Message:            fmt.Sprintf("Failed to resolve Hetzner Cloud credentials: %v", err),
+		})
+		if err := r.Status().Update(ctx, &hcloudDnsZone); err != nil {
+			log.Error(err, "Failed to update HcloudDnsZone status", "name", hcloudDnsZone.Name)
+		}
+		r.Recorder.Eventf(&hcloudDnsZone, "Warning", credentialsUnavailableReason, "Failed to resolve Hetzner Cloud credentials: %v", err)
+
+		return ctrl.Result{}, err
+	}
+
 	// Initialize annotations if not present
 	if hcloudDnsZone.Annotations == nil {
 		hcloudDnsZone.Annotations = make(map[string]string)
 	}
 
-	// Add sync policy annotation if not present
-	if hcloudDnsZone.Annotations[syncPolicy] == "" {
+	// Add sync policy annotation if neither it nor the sync policy mode is present
+	if hcloudDnsZone.Annotations[syncPolicy] == "" && (hcloudDnsZone.Spec.SyncPolicy == nil || hcloudDnsZone.Spec.SyncPolicy.Mode == "") {
 		log.Info("Adding sync policy annotation", "name", hcloudDnsZone.Name)
-		hcloudDnsZone.Annotations[syncPolicy] = "manage"
+		hcloudDnsZone.Annotations[syncPolicy] = string(provider.syncPolicy())
 		if err := r.Update(ctx, &hcloudDnsZone); err != nil {
 			log.Error(err, "Failed to add sync policy annotation", "name", hcloudDnsZone.Name)
 			return ctrl.Result{}, err
 		}
 	}
 
+	policy, err := resolveSyncPolicy(&hcloudDnsZone, hcloudDnsZone.Spec.SyncPolicy, provider.syncPolicy(), hcloudv1alpha1.HcloudDnsZoneIgnorableFields)
+	if err != nil {
+		log.Error(err, "Invalid sync policy", "name", hcloudDnsZone.Name)
+		meta.SetStatusCondition(&hcloudDnsZone.Status.Conditions, metav1.Condition{
+			Type:               "Available",
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: hcloudDnsZone.Generation,
+			Reason:             invalidSyncPolicyReason,
+			Message:            err.Error(),
+		})
+		if err := r.Status().Update(ctx, &hcloudDnsZone); err != nil {
+			log.Error(err, "Failed to update HcloudDnsZone status", "name", hcloudDnsZone.Name)
+			return ctrl.Result{}, err
+		}
+		r.Recorder.Eventf(&hcloudDnsZone, "Warning", invalidSyncPolicyReason, "Sync policy of dns zone %s is invalid: %v", hcloudDnsZone.Spec.Name, err)
+
+		// The sync policy has to change before the zone can be reconciled
+		return ctrl.Result{}, nil
+	}
+
 	// Add finalizer if not present and sync policy supports it
-	if !controllerutil.ContainsFinalizer(&hcloudDnsZone, finalizerName) && hcloudDnsZone.Annotations[syncPolicy] != "read-only" {
+	if !controllerutil.ContainsFinalizer(&hcloudDnsZone, finalizerName) && policy.claims() {
 		log.Info("Adding finalizer", "name", hcloudDnsZone.Name)
 		controllerutil.AddFinalizer(&hcloudDnsZone, finalizerName)
 		if err := r.Update(ctx, &hcloudDnsZone); err != nil {
@@ -184,15 +329,463 @@ func (r *HcloudDnsZoneReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		}
 	}
 
+	// Labels of the provider config are applied below the labels of the resource
+	desiredLabels := provider.labels(hcloudDnsZone.Spec.Labels)
+
+	// Follow actions started by an earlier reconcile before comparing the zone with the spec
+	if len(hcloudDnsZone.Status.PendingActions) > 0 {
+		done, result, err := awaitPendingActions(ctx, r.Client, r.Recorder, &hcloudDnsZone, &hcloudDnsZone.Status.PendingActions, &hcloudDnsZone.Status.Conditions, dnsZoneClient.GetAction, provider.actionPollInterval())
+		if !done {
+			return result, err
+		}
+	}
+
+	// A zone pinned by the external-id annotation is never looked up by name or created
+	pinnedID, err := externalID(&hcloudDnsZone)
+	if err != nil {
+		log.Error(err, "Invalid external ID annotation", "name", hcloudDnsZone.Name)
+		meta.SetStatusCondition(&hcloudDnsZone.Status.Conditions, metav1.Condition{
+			Type:               "Available",
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: hcloudDnsZone.Generation,
+			Reason:             invalidExternalIDReason,
+			Message:            err.Error(),
+		})
+		if err := r.Status().Update(ctx, &hcloudDnsZone); err != nil {
+			log.Error(err, "Failed to update HcloudDnsZone status", "name", hcloudDnsZone.Name)
+			return ctrl.Result{}, err
+		}
+		r.Recorder.Eventf(&hcloudDnsZone, "Warning", invalidExternalIDReason, "Cannot adopt dns zone for %s: %v", hcloudDnsZone.Name, err)
+
+		// The annotation has to change before the zone can be found
+		return ctrl.Result{}, nil
+	}
+
+	// Adopt existing zone if it exists
+	zone, response, err := findDnsZone(ctx, dnsZoneClient, &hcloudDnsZone, pinnedID)
+	if err != nil {
+		log.Error(err, "Failed to get dns zone from Hetzner Cloud", "name", hcloudDnsZone.Spec.Name)
+		meta.SetStatusCondition(&hcloudDnsZone.Status.Conditions, metav1.Condition{
+			Type:               "Available",
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: hcloudDnsZone.Generation,
+			Reason:             "Failed",
+			Message:            fmt.Sprintf("Failed to get dns zone from Hetzner Cloud: %v. %v", err, response),
+		})
+		if err := r.Status().Update(ctx, &hcloudDnsZone); err != nil {
+			log.Error(err, "Failed to update HcloudDnsZone status", "name", hcloudDnsZone.Name)
+		}
+		r.Recorder.Eventf(&hcloudDnsZone, "Warning", "UpdateFailed", "Failed to get dns zone %s from Hetzner cloud", hcloudDnsZone.Spec.Name)
+
+		return ctrl.Result{}, err
+	}
+
+	if zone == nil && pinnedID != 0 {
+		log.Info("DNS zone pinned by the external ID not found in Hetzner Cloud", "zoneId", pinnedID)
+		meta.SetStatusCondition(&hcloudDnsZone.Status.Conditions, metav1.Condition{
+			Type:               "Available",
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: hcloudDnsZone.Generation,
+			Reason:             externalResourceNotFoundReason,
+			Message:            fmt.Sprintf("DNS zone %d set by the %s annotation not found in Hetzner Cloud", pinnedID, hcloudv1alpha1.ExternalIDAnnotation),
+		})
+		if err := r.Status().Update(ctx, &hcloudDnsZone); err != nil {
+			log.Error(err, "Failed to update HcloudDnsZone status", "name", hcloudDnsZone.Name)
+			return ctrl.Result{}, err
+		}
+		r.Recorder.Eventf(&hcloudDnsZone, "Warning", externalResourceNotFoundReason, "DNS zone %d not found in Hetzner cloud", pinnedID)
+
+		return ctrl.Result{RequeueAfter: resyncAfter(&hcloudDnsZone, r.ResyncInterval)}, nil
+	}
+
+	if zone != nil && policy.claims() {
+		// A zone managed by another resource or cluster is left alone unless it is taken over,
+		// resources whose sync policy does not claim it may still observe it
+		if owner := claimant(zone.Labels, &hcloudDnsZone, r.ClusterID); owner != "" && !takeover(&hcloudDnsZone) {
+			log.Info("DNS zone is already claimed by another resource", "zoneId", zone.ID, "owner", owner)
+			meta.SetStatusCondition(&hcloudDnsZone.Status.Conditions, metav1.Condition{
+				Type:               "Available",
+				Status:             metav1.ConditionFalse,
+				ObservedGeneration: hcloudDnsZone.Generation,
+				Reason:             alreadyClaimedReason,
+				Message:            fmt.Sprintf("DNS zone %d is already managed by %s", zone.ID, owner),
+			})
+			if err := r.Status().Update(ctx, &hcloudDnsZone); err != nil {
+				log.Error(err, "Failed to update HcloudDnsZone status", "name", hcloudDnsZone.Name)
+				return ctrl.Result{}, err
+			}
+			r.Recorder.Eventf(&hcloudDnsZone, "Warning", alreadyClaimedReason, "DNS zone %s (%d) is already managed by %s, set the %s annotation to take it over", zone.Name, zone.ID, owner, hcloudv1alpha1.TakeoverAnnotation)
+
+			return ctrl.Result{RequeueAfter: resyncAfter(&hcloudDnsZone, r.ResyncInterval)}, nil
+		} else if owner != "" {
+			log.Info("Taking over DNS zone claimed by another resource", "zoneId", zone.ID, "owner", owner)
+			r.Recorder.Eventf(&hcloudDnsZone, "Warning", takenOverReason, "DNS zone %s (%d) was taken over from %s", zone.Name, zone.ID, owner)
+		}
+	}
+
+	if zone != nil {
+		log.Info("Found existing dns zone in Hetzner Cloud", "zoneId", zone.ID)
+
+		// Zones cannot be renamed, a different name means the external ID names another zone
+		if zone.Name != hcloudDnsZone.Spec.Name {
+			log.Info("DNS zone name differs from the spec", "zoneId", zone.ID, "name", zone.Name, "specName", hcloudDnsZone.Spec.Name)
+			r.Recorder.Eventf(&hcloudDnsZone, "Warning", renamedReason, "DNS zone %d is named %s in Hetzner cloud instead of %s", zone.ID, zone.Name, hcloudDnsZone.Spec.Name)
+		}
+
+		// Fields left to other tools are neither updated nor reported as drift
+		spec := ignoreDnsZoneFields(hcloudDnsZone.Spec, policy)
+		if policy.ignores("labels") {
+			desiredLabels = nil
+		}
+
+		// Differences found while the spec is unchanged were made outside of the operator
+		var drift []string
+		if policy.reportsDrift() && (!policy.updates() || hcloudDnsZone.Status.ObservedGeneration == hcloudDnsZone.Generation) {
+			drift = dnsZoneDrift(spec, desiredLabels, zone)
+		}
+		if len(drift) > 0 {
+			log.Info("DNS zone drifted from its spec", "zoneId", zone.ID, "drift", drift)
+			r.Recorder.Eventf(&hcloudDnsZone, "Warning", driftedCondition, "DNS zone %s drifted from its spec: %s", hcloudDnsZone.Spec.Name, strings.Join(drift, "; "))
+		}
+
+		// Update the existing zone if sync policy allows it
+		if policy.updates() {
+			// Evaluate if the zone spec matches the existing zone
+			needsLabelsUpdate := false
+			needsTTLUpdate := false
+			needsPrimaryNameserversUpdate := false
+			if spec.TTL != nil && *spec.TTL != zone.TTL {
+				log.Info("DNS zone TTL differs, updating", "current", zone.TTL, "desired", *spec.TTL)
+				needsTTLUpdate = true
+			}
+			if primaryNameserversDiffer(spec, zone) {
+				log.Info("DNS zone primary nameservers differ, updating", "current", formatPrimaryNameservers(zone.PrimaryNameservers), "desired", formatPrimaryNameservers(dnsZonePrimaryNameservers(hcloudDnsZone.Spec)))
+				needsPrimaryNameserversUpdate = true
+			}
+			ownedLabels := withOwnershipLabels(desiredLabels, zone.Labels, &hcloudDnsZone, r.ClusterID)
+			if !equality.Semantic.DeepEqual(ownedLabels, zone.Labels) {
+				log.Info("DNS zone labels differ, updating", "current", zone.Labels, "desired", ownedLabels)
+				needsLabelsUpdate = true
+			}
+
+			if needsLabelsUpdate {
+				updatedZone, response, err := dnsZoneClient.UpdateZoneLabels(ctx, zone, ownedLabels)
+				if err != nil {
+					log.Error(err, "Failed to update dns zone labels in Hetzner Cloud", "zoneId", zone.ID)
+					meta.SetStatusCondition(&hcloudDnsZone.Status.Conditions, metav1.Condition{
+						Type:               "Available",
+						Status:             metav1.ConditionFalse,
+						ObservedGeneration: hcloudDnsZone.Generation,
+						Reason:             "Failed",
+						Message:            fmt.Sprintf("Failed to update dns zone in Hetzner Cloud: %v. %v", err, response),
+					})
+					if err := r.Status().Update(ctx, &hcloudDnsZone); err != nil {
+						log.Error(err, "Failed to update HcloudDnsZone status", "name", hcloudDnsZone.Name)
+					}
+					r.Recorder.Eventf(&hcloudDnsZone, "Warning", "UpdateFailed", "Failed to update dns zone %s in Hetzner cloud", hcloudDnsZone.Spec.Name)
+
+					return ctrl.Result{}, err
+				}
+				zone = updatedZone
+			}
+			if needsTTLUpdate {
+				updatedZone, response, err := dnsZoneClient.UpdateZoneTTL(ctx, zone, *hcloudDnsZone.Spec.TTL)
+				if err != nil {
+					log.Error(err, "Failed to update dns zone TTL in Hetzner Cloud", "zoneId", zone.ID)
+					meta.SetStatusCondition(&hcloudDnsZone.Status.Conditions, metav1.Condition{
+						Type:               "Available",
+						Status:             metav1.ConditionFalse,
+						ObservedGeneration: hcloudDnsZone.Generation,
+						Reason:             "Failed",
+						Message:            fmt.Sprintf("Failed to update dns zone in Hetzner Cloud: %v. %v", err, response),
+					})
+					if err := r.Status().Update(ctx, &hcloudDnsZone); err != nil {
+						log.Error(err, "Failed to update HcloudDnsZone status", "name", hcloudDnsZone.Name)
+					}
+					r.Recorder.Eventf(&hcloudDnsZone, "Warning", "UpdateFailed", "Failed to update dns zone %s in Hetzner cloud", hcloudDnsZone.Spec.Name)
+
+					return ctrl.Result{}, err
+				}
+
+				zone = updatedZone
+				log.Info("Successfully updated dns zone in Hetzner Cloud", "zoneId", zone.ID)
+			}
+			if needsPrimaryNameserversUpdate {
+				updatedZone, response, err := dnsZoneClient.UpdateZonePrimaryNameservers(ctx, zone, dnsZonePrimaryNameservers(hcloudDnsZone.Spec))
+				if err != nil {
+					log.Error(err, "Failed to update dns zone primary nameservers in Hetzner Cloud", "zoneId", zone.ID)
+					meta.SetStatusCondition(&hcloudDnsZone.Status.Conditions, metav1.Condition{
+						Type:               "Available",
+						Status:             metav1.ConditionFalse,
+						ObservedGeneration: hcloudDnsZone.Generation,
+						Reason:             "Failed",
+						Message:            fmt.Sprintf("Failed to update dns zone in Hetzner Cloud: %v. %v", err, response),
+					})
+					if err := r.Status().Update(ctx, &hcloudDnsZone); err != nil {
+						log.Error(err, "Failed to update HcloudDnsZone status", "name", hcloudDnsZone.Name)
+					}
+					r.Recorder.Eventf(&hcloudDnsZone, "Warning", "UpdateFailed", "Failed to update dns zone %s in Hetzner cloud", hcloudDnsZone.Spec.Name)
+
+					return ctrl.Result{}, err
+				}
+
+				zone = updatedZone
+				log.Info("Successfully updated dns zone primary nameservers in Hetzner Cloud", "zoneId", zone.ID)
+			}
+			if !needsLabelsUpdate && !needsTTLUpdate && !needsPrimaryNameserversUpdate {
+				log.Info("No updates required for existing dns zone", "zoneId", zone.ID)
+			}
+		} else {
+			log.Info("Sync policy does not update existing dns zones; skipping updates", "zoneId", zone.ID, "syncPolicy", policy.mode)
+		}
+
+		// Update the resource status with the zone details and conditions
+		setDnsZoneStatus(&hcloudDnsZone, zone)
+		if policy.reportsDrift() {
+			setDriftCondition(&hcloudDnsZone.Status.Conditions, hcloudDnsZone.Generation, drift, policy.updates())
+		} else {
+			meta.RemoveStatusCondition(&hcloudDnsZone.Status.Conditions, driftedCondition)
+		}
+		meta.SetStatusCondition(&hcloudDnsZone.Status.Conditions, metav1.Condition{
+			Type:               "Available",
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: hcloudDnsZone.Generation,
+			Reason:             "Ready",
+			Message:            fmt.Sprintf("DNS zone ID %d reconciled successfully", zone.ID),
+		})
+		hcloudDnsZone.Status.ObservedGeneration = hcloudDnsZone.Generation
+
+		if err := r.Status().Update(ctx, &hcloudDnsZone); err != nil {
+			log.Error(err, "Failed to update HcloudDnsZone status", "name", hcloudDnsZone.Name)
+			return ctrl.Result{}, err
+		}
+
+		r.Recorder.Eventf(&hcloudDnsZone, "Normal", "Ready", "HcloudDnsZone updated %d", hcloudDnsZone.Status.ZoneId)
+
+	} else if policy.creates() {
+		log.Info("DNS zone not found in Hetzner Cloud, creating new zone", "name", hcloudDnsZone.Spec.Name)
+
+		mode := hcloudDnsZone.Spec.Mode
+		if mode == "" {
+			mode = defaultDnsZoneMode
+		}
+		result, response, err := dnsZoneClient.CreateZone(ctx, hcloudDnsZone.Spec.Name, mode, hcloudDnsZone.Spec.TTL, dnsZonePrimaryNameservers(hcloudDnsZone.Spec), withOwnershipLabels(desiredLabels, nil, &hcloudDnsZone, r.ClusterID))
+		if err != nil {
+			log.Error(err, "Failed to create dns zone in Hetzner Cloud", "name", hcloudDnsZone.Spec.Name)
+			meta.SetStatusCondition(&hcloudDnsZone.Status.Conditions, metav1.Condition{
+				Type:               "Available",
+				Status:             metav1.ConditionFalse,
+				ObservedGeneration: hcloudDnsZone.Generation,
+				Reason:             "Failed",
+				Message:            fmt.Sprintf("Failed to create dns zone in Hetzner Cloud: %v. %v", err, response),
+			})
+			if err := r.Status().Update(ctx, &hcloudDnsZone); err != nil {
+				log.Error(err, "Failed to update HcloudDnsZone status", "name", hcloudDnsZone.Name)
+			}
+			r.Recorder.Eventf(&hcloudDnsZone, "Warning", "CreateFailed", "Failed to create dns zone %s in Hetzner cloud", hcloudDnsZone.Spec.Name)
+
+			return ctrl.Result{}, err
+		}
+
+		zone := result.Zone
+		log.Info("Successfully created dns zone in Hetzner Cloud", "zoneId", zone.ID)
+
+		// The zone is reported ready once the create action completed
+		if actionPending(result.Action) {
+			setDnsZoneStatus(&hcloudDnsZone, zone)
+			recordPendingAction(&hcloudDnsZone.Status.PendingActions, &hcloudDnsZone.Status.Conditions, hcloudDnsZone.Generation, result.Action)
+			if err := r.Status().Update(ctx, &hcloudDnsZone); err != nil {
+				log.Error(err, "Failed to update HcloudDnsZone status", "name", hcloudDnsZone.Name)
+				return ctrl.Result{}, err
+			}
+			r.Recorder.Eventf(&hcloudDnsZone, "Normal", "Progressing", "Creating dns zone %s in Hetzner cloud", hcloudDnsZone.Spec.Name)
+
+			return ctrl.Result{RequeueAfter: provider.actionPollInterval()}, nil
+		}
+
+		setDnsZoneStatus(&hcloudDnsZone, zone)
+		meta.SetStatusCondition(&hcloudDnsZone.Status.Conditions, metav1.Condition{
+			Type:               "Available",
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: hcloudDnsZone.Generation,
+			Reason:             "Ready",
+			Message:            fmt.Sprintf("DNS zone created in Hetzner Cloud with ID: %d", zone.ID),
+		})
+		hcloudDnsZone.Status.ObservedGeneration = hcloudDnsZone.Generation
+
+		if err := r.Status().Update(ctx, &hcloudDnsZone); err != nil {
+			log.Error(err, "Failed to update HcloudDnsZone status", "name", hcloudDnsZone.Name)
+			return ctrl.Result{}, err
+		}
+
+		r.Recorder.Eventf(&hcloudDnsZone, "Normal", "Ready", "HcloudDnsZone created %d", hcloudDnsZone.Status.ZoneId)
+	} else {
+		log.Info("DNS zone not found in Hetzner Cloud and sync policy does not create it; skipping creation", "name", hcloudDnsZone.Spec.Name, "syncPolicy", policy.mode)
+		meta.SetStatusCondition(&hcloudDnsZone.Status.Conditions, metav1.Condition{
+			Type:               "Available",
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: hcloudDnsZone.Generation,
+			Reason:             "Failed",
+			Message:            fmt.Sprintf("DNS zone not found in Hetzner Cloud and sync policy is %s", policy.mode),
+		})
+		if err := r.Status().Update(ctx, &hcloudDnsZone); err != nil {
+			log.Error(err, "Failed to update HcloudDnsZone status", "name", hcloudDnsZone.Name)
+			return ctrl.Result{}, err
+		}
+		r.Recorder.Eventf(&hcloudDnsZone, "Warning", "Failed", "DNS zone %s not found in Hetzner cloud", hcloudDnsZone.Spec.Name)
+	}
+
 	log.Info("HcloudDnsZone reconciled successfully", "name", hcloudDnsZone.Name)
-	return ctrl.Result{}, nil
+	return ctrl.Result{RequeueAfter: resyncAfter(&hcloudDnsZone, r.ResyncInterval)}, nil
+}
+
+// setDnsZoneStatus copies the observed Hetzner Cloud zone into the resource status.
+// The mode is reported in the same upper-case form that the spec uses.
+func setDnsZoneStatus(hcloudDnsZone *hcloudv1alpha1.HcloudDnsZone, zone *hcloudgo.Zone) {
+	hcloudDnsZone.Status.ZoneId = int(zone.ID)
+	hcloudDnsZone.Status.Mode = strings.ToUpper(string(zone.Mode))
+	hcloudDnsZone.Status.TTL = zone.TTL
+	hcloudDnsZone.Status.Labels = withoutOwnershipLabels(zone.Labels)
+}
+
+// ignoreDnsZoneFields returns the spec with the fields the sync policy leaves to other tools
+// cleared, an empty field is not reconciled
+func ignoreDnsZoneFields(spec hcloudv1alpha1.HcloudDnsZoneSpec, policy effectiveSyncPolicy) hcloudv1alpha1.HcloudDnsZoneSpec {
+	if policy.ignores("labels") {
+		spec.Labels = nil
+	}
+	if policy.ignores("ttl") {
+		spec.TTL = nil
+	}
+	if policy.ignores("primaryNameservers") {
+		spec.PrimaryNameservers = nil
+	}
+	return spec
+}
+
+// findDnsZone looks up the Hetzner Cloud zone of the HcloudDnsZone. A zone pinned by the
+// external-id annotation is only looked up by its ID. Otherwise the zone found by an earlier
+// reconcile is looked up by the ID in the status, and the zone is looked up by name when it is
+// not known yet or no longer exists.
+func findDnsZone(ctx context.Context, dnsZoneClient hcloud.DnsZoneClient, hcloudDnsZone *hcloudv1alpha1.HcloudDnsZone, pinnedID int64) (*hcloudgo.Zone, *hcloudgo.Response, error) {
+	log := logf.Log.WithName("hclouddnszone-controller")
+
+	if pinnedID != 0 {
+		log.Info("Checking for existing dns zone in Hetzner Cloud by external ID", "zoneId", pinnedID)
+		return dnsZoneClient.GetZoneById(ctx, pinnedID)
+	}
+	if hcloudDnsZone.Status.ZoneId != 0 {
+		log.Info("Checking for existing dns zone in Hetzner Cloud by ID", "zoneId", hcloudDnsZone.Status.ZoneId)
+		zone, response, err := dnsZoneClient.GetZoneById(ctx, int64(hcloudDnsZone.Status.ZoneId))
+		if err != nil || zone != nil {
+			return zone, response, err
+		}
+		log.Info("DNS zone no longer exists in Hetzner Cloud", "zoneId", hcloudDnsZone.Status.ZoneId)
+	}
+	log.Info("Checking for existing dns zone in Hetzner Cloud by name", "name", hcloudDnsZone.Spec.Name)
+	return dnsZoneClient.GetZoneByName(ctx, hcloudDnsZone.Spec.Name)
+}
+
+// releaseDnsZone removes the ownership label from an orphaned zone, so that it can be adopted by
+// another resource. Failures are reported and returned, so that the finalizer is kept and the
+// orphan collector does not delete the still claimed zone.
+func (r *HcloudDnsZoneReconciler) releaseDnsZone(ctx context.Context, hcloudDnsZone *hcloudv1alpha1.HcloudDnsZone) error {
+	log := logf.Log.WithName("hclouddnszone-controller")
+
+	dnsZoneClient, _, err := r.dnsZoneClientFor(ctx, hcloudDnsZone)
+	if err == nil {
+		var zone *hcloudgo.Zone
+		zone, _, err = dnsZoneClient.GetZoneById(ctx, int64(hcloudDnsZone.Status.ZoneId))
+		if err == nil && zone != nil && zone.Labels[hcloudv1alpha1.OwnerUIDLabel] == string(hcloudDnsZone.UID) {
+			labels := withoutOwnershipLabels(zone.Labels)
+			if labels == nil {
+				labels = map[string]string{}
+			}
+			_, _, err = dnsZoneClient.UpdateZoneLabels(ctx, zone, labels)
+		}
+	}
+	if err != nil {
+		log.Error(err, "Failed to release orphaned dns zone", "zoneId", hcloudDnsZone.Status.ZoneId)
+		r.Recorder.Eventf(hcloudDnsZone, "Warning", "ReleaseFailed", "Failed to remove the ownership label from dns zone %d: %v", hcloudDnsZone.Status.ZoneId, err)
+	}
+	return err
+}
+
+// dnsZoneDrift returns the fields in which the Hetzner Cloud zone differs from the spec
+func dnsZoneDrift(spec hcloudv1alpha1.HcloudDnsZoneSpec, desiredLabels map[string]string, zone *hcloudgo.Zone) []string {
+	var drift []string
+	if spec.TTL != nil && *spec.TTL != zone.TTL {
+		drift = append(drift, driftEntry("ttl", strconv.Itoa(zone.TTL), strconv.Itoa(*spec.TTL)))
+	}
+	if primaryNameserversDiffer(spec, zone) {
+		drift = append(drift, driftEntry("primaryNameservers", formatPrimaryNameservers(zone.PrimaryNameservers), formatPrimaryNameservers(dnsZonePrimaryNameservers(spec))))
+	}
+	if liveLabels := withoutOwnershipLabels(zone.Labels); desiredLabels != nil && !equality.Semantic.DeepEqual(desiredLabels, liveLabels) {
+		drift = append(drift, labelsDrift(liveLabels, desiredLabels)...)
+	}
+	return drift
+}
+
+// dnsZonePrimaryNameservers returns the primary nameservers of the spec, with the default port filled in
+func dnsZonePrimaryNameservers(spec hcloudv1alpha1.HcloudDnsZoneSpec) []hcloudgo.ZonePrimaryNameserver {
+	var nameservers []hcloudgo.ZonePrimaryNameserver
+	for _, nameserver := range spec.PrimaryNameservers {
+		port := nameserver.Port
+		if port == 0 {
+			port = defaultPrimaryNameserverPort
+		}
+		nameservers = append(nameservers, hcloudgo.ZonePrimaryNameserver{Address: nameserver.Address, Port: port})
+	}
+	return nameservers
+}
+
+// primaryNameserversDiffer reports whether the spec sets primary nameservers of a secondary zone
+// that differ from those of the Hetzner Cloud zone. TSIG keys are not managed by the spec and are
+// not compared.
+func primaryNameserversDiffer(spec hcloudv1alpha1.HcloudDnsZoneSpec, zone *hcloudgo.Zone) bool {
+	if zone.Mode != hcloudgo.ZoneModeSecondary || len(spec.PrimaryNameservers) == 0 {
+		return false
+	}
+	return formatPrimaryNameservers(zone.PrimaryNameservers) != formatPrimaryNameservers(dnsZonePrimaryNameservers(spec))
+}
+
+// formatPrimaryNameservers formats the nameservers as a comma-separated list of address:port
+func formatPrimaryNameservers(nameservers []hcloudgo.ZonePrimaryNameserver) string {
+	formatted := make([]string, 0, len(nameservers))
+	for _, nameserver := range nameservers {
+		formatted = append(formatted, net.JoinHostPort(nameserver.Address, strconv.Itoa(nameserver.Port)))
+	}
+	return strings.Join(formatted, ",")
+}
+
+// dnsZoneClientFor returns the Hetzner Cloud client for the HcloudDnsZone together with the resolved provider.
+// The client is built from the credentials of the resource or its provider config, or is the client
+// configured for the manager when neither is set.
+func (r *HcloudDnsZoneReconciler) dnsZoneClientFor(ctx context.Context, hcloudDnsZone *hcloudv1alpha1.HcloudDnsZone) (hcloud.DnsZoneClient, *hcloudProvider, error) {
+	credentialsRef := hcloudDnsZone.Spec.CredentialsRef
+	providerConfigRef := hcloudDnsZone.Spec.ProviderConfigRef
+
+	provider, err := resolveProvider(ctx, r.Client, r.DefaultProviderConfig, hcloudDnsZone.Namespace, credentialsRef, providerConfigRef)
+	if err != nil {
+		return nil, nil, err
+	}
+	if provider.token == "" {
+		if r.DnsZoneClient == nil {
+			return nil, nil, fmt.Errorf("no credentials configured for the resource and no Hetzner Cloud token configured for the manager")
+		}
+		return r.DnsZoneClient, provider, nil
+	}
+	if r.ClientCache == nil {
+		return nil, nil, fmt.Errorf("no client cache configured to resolve credentials")
+	}
+	return r.ClientCache.DnsZoneClient(provider.cacheKey, provider.cacheVersion, provider.token, provider.options...), provider, nil
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *HcloudDnsZoneReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&hcloudv1alpha1.HcloudDnsZone{}).
-		WithEventFilter(predicate.GenerationChangedPredicate{}).
+		WithEventFilter(predicate.Or(predicate.GenerationChangedPredicate{}, pausedAnnotationChanged)).
 		Named("hclouddnszone").
 		Complete(r)
 }
@@ -18,67 +18,825 @@ package controller
 
 import (
 	"context"
+	"fmt"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	hcloudv1alpha1 "bunskin.com/hcrm/api/v1alpha1"
+	"bunskin.com/hcrm/pkg/hcloud"
+	hcloudgo "github.com/hetznercloud/hcloud-go/v2/hcloud"
 )
 
 var _ = Describe("HcloudDnsZone Controller", func() {
-	Context("When reconciling a resource", func() {
-		const resourceName = "test-resource"
+	Context("Create new HcloudDnsZone", func() {
+		const namespace = "default"
 
 		ctx := context.Background()
 
-		typeNamespacedName := types.NamespacedName{
-			Name:      resourceName,
-			Namespace: "default", // TODO(user):Modify as needed
-		}
-		hclouddnszone := &hcloudv1alpha1.HcloudDnsZone{}
-
-		BeforeEach(func() {
-			By("creating the custom resource for the Kind HcloudDnsZone")
-			err := k8sClient.Get(ctx, typeNamespacedName, hclouddnszone)
-			if err != nil && errors.IsNotFound(err) {
-				resource := &hcloudv1alpha1.HcloudDnsZone{
-					ObjectMeta: metav1.ObjectMeta{
-						Name:      resourceName,
-						Namespace: "default",
+		It("should successfully create a dns zone in Hetzner Cloud", func() {
+			const resourceName = "test-create-zone"
+			typeNamespacedName := types.NamespacedName{
+				Name:      resourceName,
+				Namespace: namespace,
+			}
+
+			ttl := 3600
+			By("creating the HcloudDnsZone resource")
+			resource := &hcloudv1alpha1.HcloudDnsZone{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: namespace,
+				},
+				Spec: hcloudv1alpha1.HcloudDnsZoneSpec{
+					Name: "create.example.com",
+					Mode: "PRIMARY",
+					TTL:  &ttl,
+					Labels: map[string]string{
+						"env": "test",
 					},
-					// TODO(user): Specify other spec details if needed.
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			By("creating a mock HCloud manager")
+			MockDnsZoneClient := &hcloud.MockDnsZoneClient{}
+			var createdMode string
+			MockDnsZoneClient.CreateZoneFunc = func(ctx context.Context, name string, mode string, ttl *int, primaryNameservers []hcloudgo.ZonePrimaryNameserver, labels map[string]string) (hcloudgo.ZoneCreateResult, *hcloudgo.Response, error) {
+				createdMode = mode
+				return hcloudgo.ZoneCreateResult{
+					Zone: &hcloudgo.Zone{
+						ID:     4242,
+						Name:   name,
+						Mode:   hcloudgo.ZoneModePrimary,
+						TTL:    *ttl,
+						Labels: labels,
+					},
+				}, nil, nil
+			}
+
+			client := hcloud.DnsZoneClient(MockDnsZoneClient)
+
+			By("reconciling the resource")
+			reconciler := &HcloudDnsZoneReconciler{
+				Client:        k8sClient,
+				Scheme:        k8sClient.Scheme(),
+				DnsZoneClient: client,
+				Recorder:      recorder,
+			}
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(createdMode).To(Equal("PRIMARY"))
+
+			By("verifying the resource status was updated")
+			updatedResource := &hcloudv1alpha1.HcloudDnsZone{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			Expect(updatedResource.Status.ZoneId).To(Equal(4242))
+			Expect(updatedResource.Status.Mode).To(Equal("PRIMARY"))
+			Expect(updatedResource.Status.TTL).To(Equal(3600))
+			Expect(updatedResource.Status.Labels).To(Equal(resource.Spec.Labels))
+			Expect(updatedResource.Status.ObservedGeneration).To(Equal(updatedResource.Generation))
+
+			By("verifying the Available condition was set")
+			condition := meta.FindStatusCondition(updatedResource.Status.Conditions, "Available")
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(condition.Reason).To(Equal("Ready"))
+
+			By("verifying finalizer was added")
+			Expect(updatedResource.ObjectMeta.Finalizers).To(ContainElement(finalizerName))
+
+			By("cleaning up the resource")
+			Expect(k8sClient.Delete(ctx, updatedResource)).To(Succeed())
+		})
+
+		It("should wait for the create action before reporting the zone ready", func() {
+			const resourceName = "test-create-zone-action"
+			typeNamespacedName := types.NamespacedName{
+				Name:      resourceName,
+				Namespace: namespace,
+			}
+
+			resource := &hcloudv1alpha1.HcloudDnsZone{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: namespace,
+				},
+				Spec: hcloudv1alpha1.HcloudDnsZoneSpec{
+					Name: "action.example.com",
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			zone := &hcloudgo.Zone{
+				ID:   4343,
+				Name: "action.example.com",
+				Mode: hcloudgo.ZoneModePrimary,
+				TTL:  3600,
+			}
+			action := &hcloudgo.Action{
+				ID:       999,
+				Status:   hcloudgo.ActionStatusRunning,
+				Command:  "create_zone",
+				Progress: 0,
+			}
+			created := false
+			MockDnsZoneClient := &hcloud.MockDnsZoneClient{}
+			MockDnsZoneClient.CreateZoneFunc = func(ctx context.Context, name string, mode string, ttl *int, primaryNameservers []hcloudgo.ZonePrimaryNameserver, labels map[string]string) (hcloudgo.ZoneCreateResult, *hcloudgo.Response, error) {
+				created = true
+				zone.Labels = labels
+				return hcloudgo.ZoneCreateResult{Zone: zone, Action: action}, nil, nil
+			}
+			MockDnsZoneClient.GetActionFunc = func(ctx context.Context, id int64) (*hcloudgo.Action, *hcloudgo.Response, error) {
+				return action, nil, nil
+			}
+			MockDnsZoneClient.GetZoneByNameFunc = func(ctx context.Context, name string) (*hcloudgo.Zone, *hcloudgo.Response, error) {
+				if created {
+					return zone, nil, nil
 				}
-				Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+				return nil, nil, nil
+			}
+
+			reconciler := &HcloudDnsZoneReconciler{
+				Client:        k8sClient,
+				Scheme:        k8sClient.Scheme(),
+				DnsZoneClient: MockDnsZoneClient,
+				Recorder:      recorder,
+			}
+
+			By("creating the zone")
+			result, err := reconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(defaultActionPollInterval))
+
+			updatedResource := &hcloudv1alpha1.HcloudDnsZone{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			Expect(updatedResource.Status.ZoneId).To(Equal(4343))
+			Expect(updatedResource.Status.PendingActions).To(HaveLen(1))
+			condition := meta.FindStatusCondition(updatedResource.Status.Conditions, "Available")
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+
+			By("completing the create action")
+			action.Status = hcloudgo.ActionStatusSuccess
+			action.Progress = 100
+			_, err = reconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			Expect(updatedResource.Status.PendingActions).To(BeEmpty())
+			condition = meta.FindStatusCondition(updatedResource.Status.Conditions, "Available")
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(condition.Reason).To(Equal("Ready"))
+
+			By("cleaning up the resource")
+			Expect(k8sClient.Delete(ctx, updatedResource)).To(Succeed())
+		})
+
+		It("should handle Hetzner Cloud API errors gracefully", func() {
+			const resourceName = "test-create-zone-error"
+			typeNamespacedName := types.NamespacedName{
+				Name:      resourceName,
+				Namespace: namespace,
+			}
+
+			By("creating the HcloudDnsZone resource")
+			resource := &hcloudv1alpha1.HcloudDnsZone{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: namespace,
+				},
+				Spec: hcloudv1alpha1.HcloudDnsZoneSpec{
+					Name: "error.example.com",
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			MockDnsZoneClient := &hcloud.MockDnsZoneClient{}
+			MockDnsZoneClient.CreateZoneFunc = func(ctx context.Context, name string, mode string, ttl *int, primaryNameservers []hcloudgo.ZonePrimaryNameserver, labels map[string]string) (hcloudgo.ZoneCreateResult, *hcloudgo.Response, error) {
+				return hcloudgo.ZoneCreateResult{}, nil, fmt.Errorf("API error: uniqueness error")
+			}
+
+			client := hcloud.DnsZoneClient(MockDnsZoneClient)
+
+			By("reconciling the resource")
+			reconciler := &HcloudDnsZoneReconciler{
+				Client:        k8sClient,
+				Scheme:        k8sClient.Scheme(),
+				DnsZoneClient: client,
+				Recorder:      recorder,
+			}
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).To(HaveOccurred())
+
+			By("verifying the Available condition indicates creation failure")
+			updatedResource := &hcloudv1alpha1.HcloudDnsZone{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			condition := meta.FindStatusCondition(updatedResource.Status.Conditions, "Available")
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).To(Equal("Failed"))
+
+			By("cleaning up the resource")
+			Expect(k8sClient.Delete(ctx, updatedResource)).To(Succeed())
+		})
+
+		It("should not create a dns zone when sync policy is read-only", func() {
+			const resourceName = "test-readonly-missing-zone"
+			typeNamespacedName := types.NamespacedName{
+				Name:      resourceName,
+				Namespace: namespace,
+			}
+
+			By("creating the HcloudDnsZone resource with read-only sync policy")
+			resource := &hcloudv1alpha1.HcloudDnsZone{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: namespace,
+					Annotations: map[string]string{
+						syncPolicy: "read-only",
+					},
+				},
+				Spec: hcloudv1alpha1.HcloudDnsZoneSpec{
+					Name: "missing.example.com",
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			MockDnsZoneClient := &hcloud.MockDnsZoneClient{}
+			MockDnsZoneClient.CreateZoneFunc = func(ctx context.Context, name string, mode string, ttl *int, primaryNameservers []hcloudgo.ZonePrimaryNameserver, labels map[string]string) (hcloudgo.ZoneCreateResult, *hcloudgo.Response, error) {
+				Fail("CreateZone must not be called for read-only zones")
+				return hcloudgo.ZoneCreateResult{}, nil, nil
+			}
+
+			reconciler := &HcloudDnsZoneReconciler{
+				Client:        k8sClient,
+				Scheme:        k8sClient.Scheme(),
+				DnsZoneClient: MockDnsZoneClient,
+				Recorder:      recorder,
+			}
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			updatedResource := &hcloudv1alpha1.HcloudDnsZone{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			Expect(updatedResource.Status.ZoneId).To(BeZero())
+			Expect(updatedResource.Finalizers).NotTo(ContainElement(finalizerName))
+			condition := meta.FindStatusCondition(updatedResource.Status.Conditions, "Available")
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).To(Equal("Failed"))
+
+			By("cleaning up the resource")
+			Expect(k8sClient.Delete(ctx, updatedResource)).To(Succeed())
+		})
+	})
+
+	Context("Update existing HcloudDnsZone", func() {
+		const namespace = "default"
+
+		ctx := context.Background()
+
+		It("should adopt the zone and update TTL and labels", func() {
+			const resourceName = "test-update-zone"
+			typeNamespacedName := types.NamespacedName{
+				Name:      resourceName,
+				Namespace: namespace,
+			}
+
+			ttl := 600
+			By("creating the HcloudDnsZone resource")
+			resource := &hcloudv1alpha1.HcloudDnsZone{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: namespace,
+				},
+				Spec: hcloudv1alpha1.HcloudDnsZoneSpec{
+					Name: "update.example.com",
+					Mode: "PRIMARY",
+					TTL:  &ttl,
+					Labels: map[string]string{
+						"env": "prod",
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			existingZone := &hcloudgo.Zone{
+				ID:     777,
+				Name:   "update.example.com",
+				Mode:   hcloudgo.ZoneModePrimary,
+				TTL:    3600,
+				Labels: map[string]string{"env": "test"},
+			}
+
+			MockDnsZoneClient := &hcloud.MockDnsZoneClient{}
+			MockDnsZoneClient.GetZoneByNameFunc = func(ctx context.Context, name string) (*hcloudgo.Zone, *hcloudgo.Response, error) {
+				return existingZone, nil, nil
+			}
+			labelsUpdated := false
+			MockDnsZoneClient.UpdateZoneLabelsFunc = func(ctx context.Context, zone *hcloudgo.Zone, labels map[string]string) (*hcloudgo.Zone, *hcloudgo.Response, error) {
+				labelsUpdated = true
+				return &hcloudgo.Zone{ID: zone.ID, Name: zone.Name, Mode: zone.Mode, TTL: zone.TTL, Labels: labels}, nil, nil
+			}
+			ttlUpdated := false
+			MockDnsZoneClient.UpdateZoneTTLFunc = func(ctx context.Context, zone *hcloudgo.Zone, ttl int) (*hcloudgo.Zone, *hcloudgo.Response, error) {
+				ttlUpdated = true
+				return &hcloudgo.Zone{ID: zone.ID, Name: zone.Name, Mode: zone.Mode, TTL: ttl, Labels: zone.Labels}, nil, nil
+			}
+
+			reconciler := &HcloudDnsZoneReconciler{
+				Client:        k8sClient,
+				Scheme:        k8sClient.Scheme(),
+				DnsZoneClient: MockDnsZoneClient,
+				Recorder:      recorder,
+			}
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(labelsUpdated).To(BeTrue())
+			Expect(ttlUpdated).To(BeTrue())
+
+			By("verifying the resource status was updated")
+			updatedResource := &hcloudv1alpha1.HcloudDnsZone{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			Expect(updatedResource.Status.ZoneId).To(Equal(777))
+			Expect(updatedResource.Status.TTL).To(Equal(600))
+			Expect(updatedResource.Status.Labels).To(Equal(resource.Spec.Labels))
+			Expect(updatedResource.Annotations[syncPolicy]).To(Equal("manage"))
+
+			By("cleaning up the resource")
+			Expect(k8sClient.Delete(ctx, updatedResource)).To(Succeed())
+		})
+
+		It("should adopt the zone pinned by the external-id annotation", func() {
+			const resourceName = "test-pinned-zone"
+			typeNamespacedName := types.NamespacedName{
+				Name:      resourceName,
+				Namespace: namespace,
+			}
+
+			ttl := 3600
+			resource := &hcloudv1alpha1.HcloudDnsZone{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        resourceName,
+					Namespace:   namespace,
+					Annotations: map[string]string{hcloudv1alpha1.ExternalIDAnnotation: "888"},
+				},
+				Spec: hcloudv1alpha1.HcloudDnsZoneSpec{
+					Name: "pinned.example.com",
+					Mode: "PRIMARY",
+					TTL:  &ttl,
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			existingZone := &hcloudgo.Zone{
+				ID:     888,
+				Name:   "pinned.example.com",
+				Mode:   hcloudgo.ZoneModePrimary,
+				TTL:    3600,
+				Labels: map[string]string{"env": "test"},
+			}
+
+			MockDnsZoneClient := &hcloud.MockDnsZoneClient{}
+			MockDnsZoneClient.GetZoneByIdFunc = func(ctx context.Context, id int64) (*hcloudgo.Zone, *hcloudgo.Response, error) {
+				Expect(id).To(Equal(int64(888)))
+				return existingZone, nil, nil
+			}
+			MockDnsZoneClient.GetZoneByNameFunc = func(ctx context.Context, name string) (*hcloudgo.Zone, *hcloudgo.Response, error) {
+				Fail("a pinned zone must not be looked up by name")
+				return nil, nil, nil
+			}
+			var updatedLabels map[string]string
+			MockDnsZoneClient.UpdateZoneLabelsFunc = func(ctx context.Context, zone *hcloudgo.Zone, labels map[string]string) (*hcloudgo.Zone, *hcloudgo.Response, error) {
+				updatedLabels = labels
+				updated := *zone
+				updated.Labels = labels
+				return &updated, nil, nil
+			}
+
+			reconciler := &HcloudDnsZoneReconciler{
+				Client:        k8sClient,
+				Scheme:        k8sClient.Scheme(),
+				DnsZoneClient: MockDnsZoneClient,
+				Recorder:      recorder,
+			}
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			By("verifying the live labels were kept and the zone was claimed")
+			Expect(updatedLabels).To(Equal(map[string]string{
+				"env":                         "test",
+				hcloudv1alpha1.NamespaceLabel: namespace,
+				hcloudv1alpha1.NameLabel:      resourceName,
+				hcloudv1alpha1.OwnerUIDLabel:  string(resource.UID),
+			}))
+			updatedResource := &hcloudv1alpha1.HcloudDnsZone{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			Expect(updatedResource.Status.ZoneId).To(Equal(888))
+			Expect(updatedResource.Status.Labels).To(Equal(map[string]string{"env": "test"}))
+
+			By("cleaning up the resource")
+			Expect(k8sClient.Delete(ctx, updatedResource)).To(Succeed())
+		})
+
+		It("should update the primary nameservers of a secondary zone", func() {
+			const resourceName = "test-secondary-zone"
+			typeNamespacedName := types.NamespacedName{
+				Name:      resourceName,
+				Namespace: namespace,
+			}
+
+			resource := &hcloudv1alpha1.HcloudDnsZone{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: namespace,
+				},
+				Spec: hcloudv1alpha1.HcloudDnsZoneSpec{
+					Name: "secondary.example.com",
+					Mode: "SECONDARY",
+					PrimaryNameservers: []hcloudv1alpha1.HcloudDnsZonePrimaryNameserver{
+						{Address: "192.0.2.53"},
+						{Address: "2001:db8::53", Port: 5353},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			existingZone := &hcloudgo.Zone{
+				ID:                 778,
+				Name:               "secondary.example.com",
+				Mode:               hcloudgo.ZoneModeSecondary,
+				TTL:                3600,
+				PrimaryNameservers: []hcloudgo.ZonePrimaryNameserver{{Address: "192.0.2.53", Port: 53}},
+			}
+
+			MockDnsZoneClient := &hcloud.MockDnsZoneClient{}
+			MockDnsZoneClient.GetZoneByNameFunc = func(ctx context.Context, name string) (*hcloudgo.Zone, *hcloudgo.Response, error) {
+				return existingZone, nil, nil
+			}
+			MockDnsZoneClient.UpdateZoneLabelsFunc = func(ctx context.Context, zone *hcloudgo.Zone, labels map[string]string) (*hcloudgo.Zone, *hcloudgo.Response, error) {
+				updated := *zone
+				updated.Labels = labels
+				return &updated, nil, nil
 			}
+			var updatedNameservers []hcloudgo.ZonePrimaryNameserver
+			MockDnsZoneClient.UpdateZonePrimaryNameserversFunc = func(ctx context.Context, zone *hcloudgo.Zone, primaryNameservers []hcloudgo.ZonePrimaryNameserver) (*hcloudgo.Zone, *hcloudgo.Response, error) {
+				updatedNameservers = primaryNameservers
+				return &hcloudgo.Zone{ID: zone.ID, Name: zone.Name, Mode: zone.Mode, TTL: zone.TTL, PrimaryNameservers: primaryNameservers}, nil, nil
+			}
+
+			reconciler := &HcloudDnsZoneReconciler{
+				Client:        k8sClient,
+				Scheme:        k8sClient.Scheme(),
+				DnsZoneClient: MockDnsZoneClient,
+				Recorder:      recorder,
+			}
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(updatedNameservers).To(Equal([]hcloudgo.ZonePrimaryNameserver{
+				{Address: "192.0.2.53", Port: 53},
+				{Address: "2001:db8::53", Port: 5353},
+			}))
+
+			By("cleaning up the resource")
+			updatedResource := &hcloudv1alpha1.HcloudDnsZone{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, updatedResource)).To(Succeed())
 		})
 
-		AfterEach(func() {
-			// TODO(user): Cleanup logic after each test, like removing the resource instance.
-			resource := &hcloudv1alpha1.HcloudDnsZone{}
-			err := k8sClient.Get(ctx, typeNamespacedName, resource)
+		It("should not update the zone when sync policy is read-only", func() {
+			const resourceName = "test-readonly-zone"
+			typeNamespacedName := types.NamespacedName{
+				Name:      resourceName,
+				Namespace: namespace,
+			}
+
+			ttl := 600
+			resource := &hcloudv1alpha1.HcloudDnsZone{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: namespace,
+					Annotations: map[string]string{
+						syncPolicy: "read-only",
+					},
+				},
+				Spec: hcloudv1alpha1.HcloudDnsZoneSpec{
+					Name: "readonly.example.com",
+					TTL:  &ttl,
+					Labels: map[string]string{
+						"env": "prod",
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			MockDnsZoneClient := &hcloud.MockDnsZoneClient{}
+			MockDnsZoneClient.GetZoneByNameFunc = func(ctx context.Context, name string) (*hcloudgo.Zone, *hcloudgo.Response, error) {
+				return &hcloudgo.Zone{
+					ID:     888,
+					Name:   name,
+					Mode:   hcloudgo.ZoneModePrimary,
+					TTL:    3600,
+					Labels: map[string]string{"env": "test"},
+				}, nil, nil
+			}
+
+			reconciler := &HcloudDnsZoneReconciler{
+				Client:        k8sClient,
+				Scheme:        k8sClient.Scheme(),
+				DnsZoneClient: MockDnsZoneClient,
+				Recorder:      recorder,
+			}
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
 			Expect(err).NotTo(HaveOccurred())
 
-			By("Cleanup the specific resource instance HcloudDnsZone")
+			By("verifying the zone was not updated")
+			finalResource := &hcloudv1alpha1.HcloudDnsZone{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, finalResource)).To(Succeed())
+			Expect(finalResource.Status.ZoneId).To(Equal(888))
+			Expect(finalResource.Status.TTL).To(Equal(3600))
+			Expect(finalResource.Status.Labels).NotTo(Equal(resource.Spec.Labels))
+
+			By("verifying the drift was reported")
+			condition := meta.FindStatusCondition(finalResource.Status.Conditions, driftedCondition)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(condition.Reason).To(Equal("DriftDetected"))
+			Expect(condition.Message).To(Equal("ttl: 3600 -> 600; labels.env: test -> prod"))
+
+			By("cleaning up the resource")
+			Expect(k8sClient.Delete(ctx, finalResource)).To(Succeed())
+		})
+	})
+
+	Context("Delete HcloudDnsZone", func() {
+		const namespace = "default"
+
+		ctx := context.Background()
+
+		It("should delete the zone from Hetzner Cloud and remove finalizer", func() {
+			const resourceName = "test-delete-zone"
+			typeNamespacedName := types.NamespacedName{
+				Name:      resourceName,
+				Namespace: namespace,
+			}
+
+			resource := &hcloudv1alpha1.HcloudDnsZone{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: namespace,
+				},
+				Spec: hcloudv1alpha1.HcloudDnsZoneSpec{
+					Name: "delete.example.com",
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			MockDnsZoneClient := &hcloud.MockDnsZoneClient{}
+			MockDnsZoneClient.GetZoneByIdFunc = func(ctx context.Context, id int64) (*hcloudgo.Zone, *hcloudgo.Response, error) {
+				return &hcloudgo.Zone{ID: id, Name: "delete.example.com"}, nil, nil
+			}
+			deleted := false
+			MockDnsZoneClient.DeleteZoneFunc = func(ctx context.Context, zone *hcloudgo.Zone) (*hcloudgo.Action, *hcloudgo.Response, error) {
+				deleted = true
+				return nil, nil, nil
+			}
+
+			By("setting zone ID and finalizer")
+			resource.Status.ZoneId = 5555
+			Expect(k8sClient.Status().Update(ctx, resource)).To(Succeed())
+
+			getResource := &hcloudv1alpha1.HcloudDnsZone{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, getResource)).To(Succeed())
+			getResource.Finalizers = []string{finalizerName}
+			Expect(k8sClient.Update(ctx, getResource)).To(Succeed())
+
+			By("initiating deletion of the resource")
+			Expect(k8sClient.Delete(ctx, getResource)).To(Succeed())
+
+			reconciler := &HcloudDnsZoneReconciler{
+				Client:        k8sClient,
+				Scheme:        k8sClient.Scheme(),
+				DnsZoneClient: MockDnsZoneClient,
+				Recorder:      recorder,
+			}
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deleted).To(BeTrue())
+
+			By("verifying finalizer was removed and resource is gone")
+			deletedResource := &hcloudv1alpha1.HcloudDnsZone{}
+			err = k8sClient.Get(ctx, typeNamespacedName, deletedResource)
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("should keep the zone and the finalizer while the resource is paused", func() {
+			const resourceName = "test-paused-delete-zone"
+			typeNamespacedName := types.NamespacedName{
+				Name:      resourceName,
+				Namespace: namespace,
+			}
+
+			resource := &hcloudv1alpha1.HcloudDnsZone{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        resourceName,
+					Namespace:   namespace,
+					Annotations: map[string]string{pausedAnnotation: "true"},
+					Finalizers:  []string{finalizerName},
+				},
+				Spec: hcloudv1alpha1.HcloudDnsZoneSpec{
+					Name: "paused.example.com",
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+			resource.Status.ZoneId = 5656
+			Expect(k8sClient.Status().Update(ctx, resource)).To(Succeed())
+
+			MockDnsZoneClient := &hcloud.MockDnsZoneClient{}
+			MockDnsZoneClient.DeleteZoneFunc = func(ctx context.Context, zone *hcloudgo.Zone) (*hcloudgo.Action, *hcloudgo.Response, error) {
+				Fail("the zone of a paused resource must not be deleted")
+				return nil, nil, nil
+			}
+
 			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+
+			reconciler := &HcloudDnsZoneReconciler{
+				Client:        k8sClient,
+				Scheme:        k8sClient.Scheme(),
+				DnsZoneClient: MockDnsZoneClient,
+				Recorder:      recorder,
+			}
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			updatedResource := &hcloudv1alpha1.HcloudDnsZone{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			Expect(updatedResource.Finalizers).To(ContainElement(finalizerName))
+			Expect(meta.IsStatusConditionTrue(updatedResource.Status.Conditions, pausedCondition)).To(BeTrue())
+
+			By("releasing the zone to let the resource go")
+			updatedResource.Annotations = map[string]string{syncPolicy: string(hcloudv1alpha1.SyncPolicyOrphan)}
+			Expect(k8sClient.Update(ctx, updatedResource)).To(Succeed())
+			MockDnsZoneClient.GetZoneByIdFunc = func(ctx context.Context, id int64) (*hcloudgo.Zone, *hcloudgo.Response, error) {
+				return nil, nil, nil
+			}
+
+			_, err = reconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			err = k8sClient.Get(ctx, typeNamespacedName, &hcloudv1alpha1.HcloudDnsZone{})
+			Expect(errors.IsNotFound(err)).To(BeTrue())
 		})
-		It("should successfully reconcile the resource", func() {
-			By("Reconciling the created resource")
-			controllerReconciler := &HcloudDnsZoneReconciler{
-				Client: k8sClient,
-				Scheme: k8sClient.Scheme(),
+
+		It("should leave a zone taken over by another resource when deleted", func() {
+			const resourceName = "test-taken-over-zone"
+			typeNamespacedName := types.NamespacedName{
+				Name:      resourceName,
+				Namespace: namespace,
 			}
 
-			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+			resource := &hcloudv1alpha1.HcloudDnsZone{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       resourceName,
+					Namespace:  namespace,
+					Finalizers: []string{finalizerName},
+				},
+				Spec: hcloudv1alpha1.HcloudDnsZoneSpec{
+					Name: "taken.example.com",
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+			resource.Status.ZoneId = 5757
+			Expect(k8sClient.Status().Update(ctx, resource)).To(Succeed())
+
+			By("labelling the zone as taken over by another resource")
+			MockDnsZoneClient := &hcloud.MockDnsZoneClient{}
+			MockDnsZoneClient.GetZoneByIdFunc = func(ctx context.Context, id int64) (*hcloudgo.Zone, *hcloudgo.Response, error) {
+				return &hcloudgo.Zone{ID: id, Name: "taken.example.com", Labels: map[string]string{
+					hcloudv1alpha1.NamespaceLabel: "other",
+					hcloudv1alpha1.NameLabel:      "new-owner",
+					hcloudv1alpha1.OwnerUIDLabel:  "new-owner-uid",
+				}}, nil, nil
+			}
+			MockDnsZoneClient.DeleteZoneFunc = func(ctx context.Context, zone *hcloudgo.Zone) (*hcloudgo.Action, *hcloudgo.Response, error) {
+				Fail("a zone taken over by another resource must not be deleted")
+				return nil, nil, nil
+			}
+
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+
+			reconciler := &HcloudDnsZoneReconciler{
+				Client:        k8sClient,
+				Scheme:        k8sClient.Scheme(),
+				DnsZoneClient: MockDnsZoneClient,
+				Recorder:      recorder,
+			}
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{
 				NamespacedName: typeNamespacedName,
 			})
 			Expect(err).NotTo(HaveOccurred())
-			// TODO(user): Add more specific assertions depending on your controller's reconciliation logic.
-			// Example: If you expect a certain status condition after reconciliation, verify it here.
+
+			By("verifying finalizer was removed and resource is gone")
+			err = k8sClient.Get(ctx, typeNamespacedName, &hcloudv1alpha1.HcloudDnsZone{})
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+		})
+	})
+
+	Context("Reconcile HcloudDnsZone against the fake Hetzner Cloud API", func() {
+		const namespace = "default"
+
+		ctx := context.Background()
+
+		It("should create and delete the zone", func() {
+			const resourceName = "test-fake-api-zone"
+			typeNamespacedName := types.NamespacedName{
+				Name:      resourceName,
+				Namespace: namespace,
+			}
+
+			ttl := 600
+			resource := &hcloudv1alpha1.HcloudDnsZone{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: namespace,
+				},
+				Spec: hcloudv1alpha1.HcloudDnsZoneSpec{
+					Name:   "fake-api.example.com",
+					Mode:   "PRIMARY",
+					TTL:    &ttl,
+					Labels: map[string]string{"env": "test"},
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			reconciler := &HcloudDnsZoneReconciler{
+				Client:        k8sClient,
+				Scheme:        k8sClient.Scheme(),
+				DnsZoneClient: hcloud.NewDnsZoneClient("fake-api-token", fakeHcloudOptions()...),
+				Recorder:      recorder,
+			}
+
+			By("creating the zone")
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			updatedResource := &hcloudv1alpha1.HcloudDnsZone{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			zone, ok := fakeHcloud.Zone(int64(updatedResource.Status.ZoneId))
+			Expect(ok).To(BeTrue())
+			Expect(zone.Name).To(Equal("fake-api.example.com"))
+			Expect(zone.TTL).To(Equal(600))
+			Expect(zone.Labels).To(HaveKeyWithValue("env", "test"))
+
+			By("deleting the zone")
+			Expect(k8sClient.Delete(ctx, updatedResource)).To(Succeed())
+			_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, ok = fakeHcloud.Zone(zone.ID)
+			Expect(ok).To(BeFalse())
+			Expect(errors.IsNotFound(k8sClient.Get(ctx, typeNamespacedName, updatedResource))).To(BeTrue())
 		})
 	})
 })
@@ -0,0 +1,1084 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	hcloudv1alpha1 "bunskin.com/hcrm/api/v1alpha1"
+	"bunskin.com/hcrm/pkg/hcloud"
+	hcloudgo "github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+const (
+	// invalidRulesReason is the condition reason for firewall rules that cannot be sent to Hetzner Cloud
+	invalidRulesReason = "InvalidRules"
+	// applyPendingReason is the condition reason while the firewall is being applied to resources
+	applyPendingReason = "ApplyPending"
+	// applyFailedReason is the condition reason when the firewall could not be applied to a resource
+	applyFailedReason = "ApplyFailed"
+)
+
+// HcloudFirewallReconciler reconciles a HcloudFirewall object
+type HcloudFirewallReconciler struct {
+	client.Client
+	Scheme         *runtime.Scheme
+	FirewallClient hcloud.FirewallClient
+	ClientCache    *hcloud.ClientCache
+	Recorder       record.EventRecorder
+	// DefaultProviderConfig is the name of the ClusterHcloudProviderConfig used for resources without a providerConfigRef
+	DefaultProviderConfig string
+	// ResyncInterval is the interval after which reconciled resources are checked for drift, zero disables it
+	ResyncInterval time.Duration
+	// ClusterID identifies the cluster in the ownership labels of cloud resources
+	ClusterID string
+	// Pause pauses the reconciliation of whole kinds or namespaces, nil if it cannot be paused by the manager
+	Pause *PauseSwitch
+	// APIReader reads the ConfigMaps referenced by rules, which are not cached to avoid caching all
+	// ConfigMaps of the cluster. The Client is used when nil.
+	APIReader client.Reader
+}
+
+// +kubebuilder:rbac:groups=hcloud.bunskin.com,resources=hcloudfirewalls,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=hcloud.bunskin.com,resources=hcloudfirewalls/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=hcloud.bunskin.com,resources=hcloudfirewalls/finalizers,verbs=update
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=hcloud.bunskin.com,resources=hcloudproviderconfigs;clusterhcloudproviderconfigs,verbs=get;list;watch
+// +kubebuilder:rbac:groups=hcloud.bunskin.com,resources=hcloudnetworks,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=nodes;configmaps,verbs=get;list;watch
+
+// Reconcile looks up the Hetzner Cloud firewall by spec.name and adopts, updates or creates it
+// according to the sync policy of the HcloudFirewall resource, then applies it to the servers and
+// label selectors of spec.applyTo. The Nodes, HcloudNetworks and ConfigMaps referenced by its rules
+// are watched, so that the rules are rewritten whenever the CIDRs resolved from them change.
+func (r *HcloudFirewallReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	result, err := r.reconcile(ctx, req)
+	if reset, ok := hcloud.RateLimitReset(err); ok {
+		var hcloudFirewall hcloudv1alpha1.HcloudFirewall
+		if err := r.Get(ctx, req.NamespacedName, &hcloudFirewall); err != nil {
+			return ctrl.Result{}, client.IgnoreNotFound(err)
+		}
+		return requeueRateLimited(ctx, r.Client, r.Recorder, &hcloudFirewall, &hcloudFirewall.Status.Conditions, reset, err)
+	}
+	return result, err
+}
+
+// reconcile implements Reconcile, errors caused by the Hetzner Cloud rate limit are handled by the caller
+func (r *HcloudFirewallReconciler) reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := logf.Log.WithName("hcloudfirewall-controller")
+
+	// Fetch the HcloudFirewall resource
+	var hcloudFirewall hcloudv1alpha1.HcloudFirewall
+	if err := r.Get(ctx, req.NamespacedName, &hcloudFirewall); err != nil {
+		// object does not exist, nothing to do
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	// A paused resource is left alone, including its deletion
+	if paused, result, err := reconcilePaused(ctx, r.Client, r.Recorder, r.Pause, "HcloudFirewall", &hcloudFirewall, &hcloudFirewall.Status.Conditions); paused {
+		return result, err
+	}
+
+	log.Info("Reconciling HcloudFirewall", "name", hcloudFirewall.Name, "namespace", hcloudFirewall.Namespace)
+	meta.SetStatusCondition(&hcloudFirewall.Status.Conditions, metav1.Condition{
+		Type:               "Available",
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: hcloudFirewall.Generation,
+		Reason:             "Progressing",
+		Message:            "HcloudFirewall resource reconciliation in progress",
+	})
+	if err := r.Status().Update(ctx, &hcloudFirewall); err != nil {
+		log.Error(err, "Failed to update HcloudFirewall status", "name", hcloudFirewall.Name)
+		return ctrl.Result{}, err
+	}
+
+	// Handle deletion with finalizer
+	if hcloudFirewall.DeletionTimestamp != nil {
+		return r.reconcileDelete(ctx, &hcloudFirewall)
+	}
+
+	// Resolve the Hetzner Cloud client for this resource
+	firewallClient, provider, err := r.firewallClientFor(ctx, &hcloudFirewall)
+	if err != nil {
+		log.Error(err, "Failed to resolve Hetzner Cloud credentials", "name", hcloudFirewall.Name)
+		meta.SetStatusCondition(&hcloudFirewall.Status.Conditions, metav1.Condition{
+			Type:               "Available",
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: hcloudFirewall.Generation,
+			Reason:             credentialsUnavailableReason,
+			Message:            fmt.Sprintf("Failed to resolve Hetzner Cloud credentials: %v", err),
+		})
+		if err := r.Status().Update(ctx, &hcloudFirewall); err != nil {
+			log.Error(err, "Failed to update HcloudFirewall status", "name", hcloudFirewall.Name)
+		}
+		r.Recorder.Eventf(&hcloudFirewall, "Warning", credentialsUnavailableReason, "Failed to resolve Hetzner Cloud credentials: %v", err)
+
+		return ctrl.Result{}, err
+	}
+
+	// Add sync policy annotation if neither it nor the sync policy mode is present
+	if hcloudFirewall.Annotations[syncPolicy] == "" && (hcloudFirewall.Spec.SyncPolicy == nil || hcloudFirewall.Spec.SyncPolicy.Mode == "") {
+		log.Info("Adding sync policy annotation", "name", hcloudFirewall.Name)
+		if hcloudFirewall.Annotations == nil {
+			hcloudFirewall.Annotations = make(map[string]string)
+		}
+		hcloudFirewall.Annotations[syncPolicy] = string(provider.syncPolicy())
+		if err := r.Update(ctx, &hcloudFirewall); err != nil {
+			log.Error(err, "Failed to add sync policy annotation", "name", hcloudFirewall.Name)
+			return ctrl.Result{}, err
+		}
+	}
+
+	policy, err := resolveSyncPolicy(&hcloudFirewall, hcloudFirewall.Spec.SyncPolicy, provider.syncPolicy(), hcloudv1alpha1.HcloudFirewallIgnorableFields)
+	if err != nil {
+		log.Error(err, "Invalid sync policy", "name", hcloudFirewall.Name)
+		meta.SetStatusCondition(&hcloudFirewall.Status.Conditions, metav1.Condition{
+			Type:               "Available",
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: hcloudFirewall.Generation,
+			Reason:             invalidSyncPolicyReason,
+			Message:            err.Error(),
+		})
+		if err := r.Status().Update(ctx, &hcloudFirewall); err != nil {
+			log.Error(err, "Failed to update HcloudFirewall status", "name", hcloudFirewall.Name)
+			return ctrl.Result{}, err
+		}
+		r.Recorder.Eventf(&hcloudFirewall, "Warning", invalidSyncPolicyReason, "Sync policy of firewall %s is invalid: %v", hcloudFirewall.Spec.Name, err)
+
+		// The sync policy has to change before the firewall can be reconciled
+		return ctrl.Result{}, nil
+	}
+
+	// Add finalizer if not present and sync policy supports it
+	if !controllerutil.ContainsFinalizer(&hcloudFirewall, finalizerName) && policy.claims() {
+		log.Info("Adding finalizer", "name", hcloudFirewall.Name)
+		controllerutil.AddFinalizer(&hcloudFirewall, finalizerName)
+		if err := r.Update(ctx, &hcloudFirewall); err != nil {
+			log.Error(err, "Failed to add finalizer", "name", hcloudFirewall.Name)
+			return ctrl.Result{}, err
+		}
+	}
+
+	// Resolve the CIDRs referenced by the rules
+	configMaps := r.APIReader
+	if configMaps == nil {
+		configMaps = r.Client
+	}
+	resolvedRules, err := resolveFirewallRules(ctx, r.Client, configMaps, hcloudFirewall.Namespace, hcloudFirewall.Spec.Rules)
+	if err != nil {
+		log.Error(err, "Failed to resolve the sources of the HcloudFirewall rules", "name", hcloudFirewall.Name)
+		meta.SetStatusCondition(&hcloudFirewall.Status.Conditions, metav1.Condition{
+			Type:               "Available",
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: hcloudFirewall.Generation,
+			Reason:             unresolvedSourcesReason,
+			Message:            err.Error(),
+		})
+		if err := r.Status().Update(ctx, &hcloudFirewall); err != nil {
+			log.Error(err, "Failed to update HcloudFirewall status", "name", hcloudFirewall.Name)
+			return ctrl.Result{}, err
+		}
+		r.Recorder.Eventf(&hcloudFirewall, "Warning", unresolvedSourcesReason, "Sources of the rules of firewall %s cannot be resolved: %v", hcloudFirewall.Spec.Name, err)
+
+		// The referenced objects are watched, changes to them trigger the next attempt
+		return ctrl.Result{RequeueAfter: resyncAfter(&hcloudFirewall, r.ResyncInterval)}, nil
+	}
+
+	// Validate the declared rules before touching the cloud resource
+	desiredRules, err := firewallRules(resolvedRules)
+	if err != nil {
+		log.Error(err, "Invalid rules in HcloudFirewall spec", "name", hcloudFirewall.Name)
+		meta.SetStatusCondition(&hcloudFirewall.Status.Conditions, metav1.Condition{
+			Type:               "Available",
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: hcloudFirewall.Generation,
+			Reason:             invalidRulesReason,
+			Message:            err.Error(),
+		})
+		if err := r.Status().Update(ctx, &hcloudFirewall); err != nil {
+			log.Error(err, "Failed to update HcloudFirewall status", "name", hcloudFirewall.Name)
+			return ctrl.Result{}, err
+		}
+		r.Recorder.Eventf(&hcloudFirewall, "Warning", invalidRulesReason, "Rules of firewall %s are invalid: %v", hcloudFirewall.Spec.Name, err)
+
+		// The spec has to change before the rules can become valid
+		return ctrl.Result{}, nil
+	}
+
+	// Labels of the provider config are applied below the labels of the resource
+	desiredLabels := provider.labels(hcloudFirewall.Spec.Labels)
+
+	// A firewall pinned by the external-id annotation is never looked up by name or created
+	pinnedID, err := externalID(&hcloudFirewall)
+	if err != nil {
+		log.Error(err, "Invalid external ID annotation", "name", hcloudFirewall.Name)
+		meta.SetStatusCondition(&hcloudFirewall.Status.Conditions, metav1.Condition{
+			Type:               "Available",
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: hcloudFirewall.Generation,
+			Reason:             invalidExternalIDReason,
+			Message:            err.Error(),
+		})
+		if err := r.Status().Update(ctx, &hcloudFirewall); err != nil {
+			log.Error(err, "Failed to update HcloudFirewall status", "name", hcloudFirewall.Name)
+			return ctrl.Result{}, err
+		}
+		r.Recorder.Eventf(&hcloudFirewall, "Warning", invalidExternalIDReason, "Cannot adopt firewall for %s: %v", hcloudFirewall.Name, err)
+
+		// The annotation has to change before the firewall can be found
+		return ctrl.Result{}, nil
+	}
+
+	// Adopt existing firewall if it exists
+	firewall, response, err := findFirewall(ctx, firewallClient, &hcloudFirewall, pinnedID)
+	if err != nil {
+		log.Error(err, "Failed to get firewall from Hetzner Cloud", "name", hcloudFirewall.Spec.Name)
+		meta.SetStatusCondition(&hcloudFirewall.Status.Conditions, metav1.Condition{
+			Type:               "Available",
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: hcloudFirewall.Generation,
+			Reason:             "Failed",
+			Message:            fmt.Sprintf("Failed to get firewall from Hetzner Cloud: %v. %v", err, response),
+		})
+		if err := r.Status().Update(ctx, &hcloudFirewall); err != nil {
+			log.Error(err, "Failed to update HcloudFirewall status", "name", hcloudFirewall.Name)
+		}
+		r.Recorder.Eventf(&hcloudFirewall, "Warning", "UpdateFailed", "Failed to get firewall %s from Hetzner cloud", hcloudFirewall.Spec.Name)
+
+		return ctrl.Result{}, err
+	}
+
+	if firewall == nil && pinnedID != 0 {
+		log.Info("Firewall pinned by the external ID not found in Hetzner Cloud", "firewallId", pinnedID)
+		meta.SetStatusCondition(&hcloudFirewall.Status.Conditions, metav1.Condition{
+			Type:               "Available",
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: hcloudFirewall.Generation,
+			Reason:             externalResourceNotFoundReason,
+			Message:            fmt.Sprintf("Firewall %d set by the %s annotation not found in Hetzner Cloud", pinnedID, hcloudv1alpha1.ExternalIDAnnotation),
+		})
+		if err := r.Status().Update(ctx, &hcloudFirewall); err != nil {
+			log.Error(err, "Failed to update HcloudFirewall status", "name", hcloudFirewall.Name)
+			return ctrl.Result{}, err
+		}
+		r.Recorder.Eventf(&hcloudFirewall, "Warning", externalResourceNotFoundReason, "Firewall %d not found in Hetzner cloud", pinnedID)
+
+		return ctrl.Result{RequeueAfter: resyncAfter(&hcloudFirewall, r.ResyncInterval)}, nil
+	}
+
+	if firewall != nil && policy.claims() {
+		// A firewall managed by another resource or cluster is left alone unless it is taken over,
+		// resources whose sync policy does not claim it may still observe it
+		if owner := claimant(firewall.Labels, &hcloudFirewall, r.ClusterID); owner != "" && !takeover(&hcloudFirewall) {
+			log.Info("Firewall is already claimed by another resource", "firewallId", firewall.ID, "owner", owner)
+			meta.SetStatusCondition(&hcloudFirewall.Status.Conditions, metav1.Condition{
+				Type:               "Available",
+				Status:             metav1.ConditionFalse,
+				ObservedGeneration: hcloudFirewall.Generation,
+				Reason:             alreadyClaimedReason,
+				Message:            fmt.Sprintf("Firewall %d is already managed by %s", firewall.ID, owner),
+			})
+			if err := r.Status().Update(ctx, &hcloudFirewall); err != nil {
+				log.Error(err, "Failed to update HcloudFirewall status", "name", hcloudFirewall.Name)
+				return ctrl.Result{}, err
+			}
+			r.Recorder.Eventf(&hcloudFirewall, "Warning", alreadyClaimedReason, "Firewall %s (%d) is already managed by %s, set the %s annotation to take it over", firewall.Name, firewall.ID, owner, hcloudv1alpha1.TakeoverAnnotation)
+
+			return ctrl.Result{RequeueAfter: resyncAfter(&hcloudFirewall, r.ResyncInterval)}, nil
+		} else if owner != "" {
+			log.Info("Taking over firewall claimed by another resource", "firewallId", firewall.ID, "owner", owner)
+			r.Recorder.Eventf(&hcloudFirewall, "Warning", takenOverReason, "Firewall %s (%d) was taken over from %s", firewall.Name, firewall.ID, owner)
+		}
+	}
+
+	var appliedTo []hcloudv1alpha1.HcloudFirewallResourceStatus
+	if firewall != nil {
+		log.Info("Found existing firewall in Hetzner Cloud", "firewallId", firewall.ID)
+
+		if firewall.Name != hcloudFirewall.Spec.Name {
+			log.Info("Firewall was renamed outside of the operator", "firewallId", firewall.ID, "name", firewall.Name, "specName", hcloudFirewall.Spec.Name)
+			r.Recorder.Eventf(&hcloudFirewall, "Warning", renamedReason, "Firewall %d is named %s in Hetzner cloud instead of %s", firewall.ID, firewall.Name, hcloudFirewall.Spec.Name)
+		}
+
+		// Fields left to other tools are neither updated nor reported as drift
+		if policy.ignores("labels") {
+			desiredLabels = nil
+		}
+
+		// Differences found while the spec is unchanged were made outside of the operator
+		var drift []string
+		if policy.reportsDrift() && (!policy.updates() || hcloudFirewall.Status.ObservedGeneration == hcloudFirewall.Generation) {
+			drift = firewallDrift(&hcloudFirewall, desiredLabels, desiredRules, firewall, policy)
+		}
+		if len(drift) > 0 {
+			log.Info("Firewall drifted from its spec", "firewallId", firewall.ID, "drift", drift)
+			r.Recorder.Eventf(&hcloudFirewall, "Warning", driftedCondition, "Firewall %s drifted from its spec: %s", hcloudFirewall.Spec.Name, strings.Join(drift, "; "))
+		}
+
+		// Update the existing firewall if sync policy allows it
+		if policy.updates() {
+			ownedLabels := withOwnershipLabels(desiredLabels, firewall.Labels, &hcloudFirewall, r.ClusterID)
+			if !equality.Semantic.DeepEqual(ownedLabels, firewall.Labels) {
+				log.Info("Firewall labels differ, updating", "current", firewall.Labels, "desired", ownedLabels)
+				updatedFirewall, response, err := firewallClient.UpdateFirewallLabels(ctx, firewall, ownedLabels)
+				if err != nil {
+					log.Error(err, "Failed to update firewall labels in Hetzner Cloud", "firewallId", firewall.ID)
+					return r.updateFailed(ctx, &hcloudFirewall, fmt.Errorf("failed to update firewall in Hetzner Cloud: %w. %v", err, response))
+				}
+				firewall = updatedFirewall
+			}
+
+			if !policy.ignores("rules") && !rulesMatch(desiredRules, firewall.Rules) {
+				log.Info("Firewall rules differ, updating", "firewallId", firewall.ID)
+				updatedFirewall, response, err := firewallClient.SetFirewallRules(ctx, firewall, desiredRules)
+				if err != nil {
+					log.Error(err, "Failed to set firewall rules in Hetzner Cloud", "firewallId", firewall.ID)
+					return r.updateFailed(ctx, &hcloudFirewall, fmt.Errorf("failed to set firewall rules in Hetzner Cloud: %w. %v", err, response))
+				}
+				firewall = updatedFirewall
+			}
+
+			if !policy.ignores("applyTo") {
+				appliedTo, err = r.reconcileResources(ctx, firewallClient, &hcloudFirewall, firewall)
+				if err != nil {
+					log.Error(err, "Failed to apply firewall in Hetzner Cloud", "firewallId", firewall.ID)
+					return r.updateFailed(ctx, &hcloudFirewall, err)
+				}
+			} else {
+				appliedTo = appliedResourceStatuses(firewall.AppliedTo)
+			}
+		} else {
+			log.Info("Sync policy does not update existing firewalls; skipping updates", "firewallId", firewall.ID, "syncPolicy", policy.mode)
+			appliedTo = appliedResourceStatuses(firewall.AppliedTo)
+		}
+
+		if policy.reportsDrift() {
+			setDriftCondition(&hcloudFirewall.Status.Conditions, hcloudFirewall.Generation, drift, policy.updates())
+		} else {
+			meta.RemoveStatusCondition(&hcloudFirewall.Status.Conditions, driftedCondition)
+		}
+	} else if policy.creates() {
+		log.Info("Firewall not found in Hetzner Cloud, creating new firewall", "name", hcloudFirewall.Spec.Name)
+
+		firewall, response, err = firewallClient.CreateFirewall(ctx, hcloudFirewall.Spec.Name, desiredRules, withOwnershipLabels(desiredLabels, nil, &hcloudFirewall, r.ClusterID))
+		if err != nil {
+			log.Error(err, "Failed to create firewall in Hetzner Cloud", "name", hcloudFirewall.Spec.Name)
+			meta.SetStatusCondition(&hcloudFirewall.Status.Conditions, metav1.Condition{
+				Type:               "Available",
+				Status:             metav1.ConditionFalse,
+				ObservedGeneration: hcloudFirewall.Generation,
+				Reason:             "Failed",
+				Message:            fmt.Sprintf("Failed to create firewall in Hetzner Cloud: %v. %v", err, response),
+			})
+			if err := r.Status().Update(ctx, &hcloudFirewall); err != nil {
+				log.Error(err, "Failed to update HcloudFirewall status", "name", hcloudFirewall.Name)
+			}
+			r.Recorder.Eventf(&hcloudFirewall, "Warning", "CreateFailed", "Failed to create firewall %s in Hetzner cloud", hcloudFirewall.Spec.Name)
+
+			return ctrl.Result{}, err
+		}
+
+		log.Info("Successfully created firewall in Hetzner Cloud", "firewallId", firewall.ID)
+		hcloudFirewall.Status.FirewallId = int(firewall.ID)
+
+		appliedTo, err = r.reconcileResources(ctx, firewallClient, &hcloudFirewall, firewall)
+		if err != nil {
+			log.Error(err, "Failed to apply firewall in Hetzner Cloud", "firewallId", firewall.ID)
+			return r.updateFailed(ctx, &hcloudFirewall, err)
+		}
+	} else {
+		log.Info("Firewall not found in Hetzner Cloud and sync policy does not create it; skipping creation", "name", hcloudFirewall.Spec.Name, "syncPolicy", policy.mode)
+		meta.SetStatusCondition(&hcloudFirewall.Status.Conditions, metav1.Condition{
+			Type:               "Available",
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: hcloudFirewall.Generation,
+			Reason:             "Failed",
+			Message:            fmt.Sprintf("Firewall not found in Hetzner Cloud and sync policy is %s", policy.mode),
+		})
+		if err := r.Status().Update(ctx, &hcloudFirewall); err != nil {
+			log.Error(err, "Failed to update HcloudFirewall status", "name", hcloudFirewall.Name)
+			return ctrl.Result{}, err
+		}
+		r.Recorder.Eventf(&hcloudFirewall, "Warning", "Failed", "Firewall %s not found in Hetzner cloud", hcloudFirewall.Spec.Name)
+
+		return ctrl.Result{RequeueAfter: resyncAfter(&hcloudFirewall, r.ResyncInterval)}, nil
+	}
+
+	// Update the resource status with the firewall details and the state of each applied resource
+	hcloudFirewall.Status.FirewallId = int(firewall.ID)
+	hcloudFirewall.Status.Labels = withoutOwnershipLabels(firewall.Labels)
+	hcloudFirewall.Status.Rules = firewallRuleStatuses(firewall.Rules)
+	hcloudFirewall.Status.AppliedTo = appliedTo
+	hcloudFirewall.Status.ObservedGeneration = hcloudFirewall.Generation
+
+	result := ctrl.Result{RequeueAfter: resyncAfter(&hcloudFirewall, r.ResyncInterval)}
+	pending, failed := resourceStates(appliedTo)
+	switch {
+	case len(failed) > 0:
+		meta.SetStatusCondition(&hcloudFirewall.Status.Conditions, metav1.Condition{
+			Type:               "Available",
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: hcloudFirewall.Generation,
+			Reason:             applyFailedReason,
+			Message:            fmt.Sprintf("Firewall %d could not be applied to %s", firewall.ID, strings.Join(failed, ", ")),
+		})
+	case len(pending) > 0:
+		meta.SetStatusCondition(&hcloudFirewall.Status.Conditions, metav1.Condition{
+			Type:               "Available",
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: hcloudFirewall.Generation,
+			Reason:             applyPendingReason,
+			Message:            fmt.Sprintf("Firewall %d is being applied to %s", firewall.ID, strings.Join(pending, ", ")),
+		})
+		result = ctrl.Result{RequeueAfter: provider.actionPollInterval()}
+	default:
+		meta.SetStatusCondition(&hcloudFirewall.Status.Conditions, metav1.Condition{
+			Type:               "Available",
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: hcloudFirewall.Generation,
+			Reason:             "Ready",
+			Message:            fmt.Sprintf("Firewall ID %d reconciled successfully", firewall.ID),
+		})
+	}
+	if err := r.Status().Update(ctx, &hcloudFirewall); err != nil {
+		log.Error(err, "Failed to update HcloudFirewall status", "name", hcloudFirewall.Name)
+		return ctrl.Result{}, err
+	}
+
+	if len(failed) > 0 {
+		r.Recorder.Eventf(&hcloudFirewall, "Warning", applyFailedReason, "Firewall %s could not be applied to %s", hcloudFirewall.Spec.Name, strings.Join(failed, ", "))
+	} else if len(pending) == 0 {
+		r.Recorder.Eventf(&hcloudFirewall, "Normal", "Ready", "HcloudFirewall reconciled %d", hcloudFirewall.Status.FirewallId)
+	}
+
+	log.Info("HcloudFirewall resource reconciled", "name", hcloudFirewall.Name, "pending", len(pending), "failed", len(failed))
+	return result, nil
+}
+
+// reconcileDelete deletes or releases the firewall of a deleted HcloudFirewall according to its sync
+// policy and removes the finalizer. A firewall cannot be deleted while it is applied to resources, so
+// it is removed from them first and the deletion is retried once the removal completed.
+func (r *HcloudFirewallReconciler) reconcileDelete(ctx context.Context, hcloudFirewall *hcloudv1alpha1.HcloudFirewall) (ctrl.Result, error) {
+	log := logf.Log.WithName("hcloudfirewall-controller")
+
+	log.Info("HcloudFirewall resource is being deleted", "name", hcloudFirewall.Name)
+	meta.SetStatusCondition(&hcloudFirewall.Status.Conditions, metav1.Condition{
+		Type:               "Available",
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: hcloudFirewall.Generation,
+		Reason:             "Deleting",
+		Message:            "HcloudFirewall resource is being deleted",
+	})
+	if err := r.Status().Update(ctx, hcloudFirewall); err != nil {
+		log.Error(err, "Failed to update HcloudFirewall status", "name", hcloudFirewall.Name)
+		return ctrl.Result{}, err
+	}
+
+	if !controllerutil.ContainsFinalizer(hcloudFirewall, finalizerName) {
+		return ctrl.Result{}, nil
+	}
+
+	policy, policyErr := resolveSyncPolicy(hcloudFirewall, hcloudFirewall.Spec.SyncPolicy, hcloudv1alpha1.SyncPolicyManage, hcloudv1alpha1.HcloudFirewallIgnorableFields)
+	if policyErr != nil {
+		// A sync policy that cannot be understood never deletes the firewall
+		log.Error(policyErr, "Invalid sync policy, will not remove cloud resource", "name", hcloudFirewall.Name)
+		r.Recorder.Eventf(hcloudFirewall, "Warning", invalidSyncPolicyReason, "Firewall %d is left in Hetzner cloud: %v", hcloudFirewall.Status.FirewallId, policyErr)
+	} else if hcloudFirewall.Status.FirewallId != 0 && policy.deletes() {
+		// Delete the firewall from Hetzner Cloud if it exists
+		firewallClient, provider, err := r.firewallClientFor(ctx, hcloudFirewall)
+		if err != nil {
+			log.Error(err, "Failed to resolve Hetzner Cloud credentials", "name", hcloudFirewall.Name)
+			meta.SetStatusCondition(&hcloudFirewall.Status.Conditions, metav1.Condition{
+				Type:               "Available",
+				Status:             metav1.ConditionFalse,
+				ObservedGeneration: hcloudFirewall.Generation,
+				Reason:             credentialsUnavailableReason,
+				Message:            fmt.Sprintf("Failed to resolve Hetzner Cloud credentials: %v", err),
+			})
+			if err := r.Status().Update(ctx, hcloudFirewall); err != nil {
+				log.Error(err, "Failed to update HcloudFirewall status", "name", hcloudFirewall.Name)
+			}
+			r.Recorder.Eventf(hcloudFirewall, "Warning", credentialsUnavailableReason, "Failed to resolve Hetzner Cloud credentials: %v", err)
+
+			return ctrl.Result{}, err
+		}
+
+		log.Info("Fetching Hetzner Cloud firewall for deletion", "firewallId", hcloudFirewall.Status.FirewallId)
+		firewall, response, err := firewallClient.GetFirewallById(ctx, int64(hcloudFirewall.Status.FirewallId))
+		if err != nil {
+			log.Error(err, "Failed to get firewall from Hetzner Cloud", "firewallId", hcloudFirewall.Status.FirewallId)
+			return r.deletionFailed(ctx, hcloudFirewall, fmt.Errorf("failed to get firewall for deletion: %w. %v", err, response))
+		}
+
+		owner := ""
+		if firewall != nil {
+			owner = claimant(firewall.Labels, hcloudFirewall, r.ClusterID)
+		}
+
+		if owner != "" {
+			// A firewall taken over by another resource is left to it and stays applied, only its ID is dropped
+			log.Info("Firewall is managed by another resource, will not remove it", "firewallId", hcloudFirewall.Status.FirewallId, "owner", owner)
+			r.Recorder.Eventf(hcloudFirewall, "Warning", alreadyClaimedReason, "Firewall %d is left in Hetzner cloud as it is managed by %s", hcloudFirewall.Status.FirewallId, owner)
+			hcloudFirewall.Status.FirewallId = 0
+			if err := r.Status().Update(ctx, hcloudFirewall); err != nil {
+				log.Error(err, "Failed to update HcloudFirewall status", "name", hcloudFirewall.Name)
+				return ctrl.Result{}, err
+			}
+		} else if firewall != nil && len(firewall.AppliedTo) > 0 {
+			log.Info("Removing Hetzner Cloud firewall from its resources before deletion", "firewallId", firewall.ID, "resources", len(firewall.AppliedTo))
+			if _, response, err := firewallClient.RemoveFirewallResources(ctx, firewall, directResources(firewall.AppliedTo)); err != nil {
+				log.Error(err, "Failed to remove firewall from its resources", "firewallId", firewall.ID)
+				return r.deletionFailed(ctx, hcloudFirewall, fmt.Errorf("failed to remove firewall from its resources: %w. %v", err, response))
+			}
+			return ctrl.Result{RequeueAfter: provider.actionPollInterval()}, nil
+		} else if firewall != nil {
+			log.Info("Deleting Hetzner Cloud firewall", "firewallId", firewall.ID)
+			response, err := firewallClient.DeleteFirewall(ctx, firewall)
+			// A firewall deleted by someone else since it was fetched is gone all the same
+			if err != nil && !hcloudgo.IsError(err, hcloudgo.ErrorCodeNotFound) {
+				log.Error(err, "Failed to delete firewall from Hetzner Cloud", "firewallId", firewall.ID)
+				return r.deletionFailed(ctx, hcloudFirewall, fmt.Errorf("failed to delete firewall from Hetzner Cloud: %w. %v", err, response))
+			}
+
+			log.Info("Successfully deleted Hetzner Cloud firewall", "firewallId", firewall.ID)
+			r.Recorder.Eventf(hcloudFirewall, "Normal", "Deleted", "HcloudFirewall %s deleted successfully", hcloudFirewall.Spec.Name)
+		} else {
+			log.Info("Firewall not found in Hetzner Cloud, nothing to delete", "firewallId", hcloudFirewall.Status.FirewallId)
+		}
+	} else if hcloudFirewall.Status.FirewallId != 0 {
+		log.Info("Sync policy does not delete the cloud resource, will not remove it", "syncPolicy", policy.mode)
+		r.releaseFirewall(ctx, hcloudFirewall)
+	}
+
+	// Remove finalizer
+	controllerutil.RemoveFinalizer(hcloudFirewall, finalizerName)
+	if err := r.Update(ctx, hcloudFirewall); err != nil {
+		log.Error(err, "Failed to remove finalizer", "name", hcloudFirewall.Name)
+		return ctrl.Result{}, err
+	}
+	log.Info("Finalizer removed, resource deletion complete", "name", hcloudFirewall.Name)
+	return ctrl.Result{}, nil
+}
+
+// updateFailed reports a failed change of the Hetzner Cloud firewall and returns the error for a retry
+func (r *HcloudFirewallReconciler) updateFailed(ctx context.Context, hcloudFirewall *hcloudv1alpha1.HcloudFirewall, err error) (ctrl.Result, error) {
+	log := logf.Log.WithName("hcloudfirewall-controller")
+
+	meta.SetStatusCondition(&hcloudFirewall.Status.Conditions, metav1.Condition{
+		Type:               "Available",
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: hcloudFirewall.Generation,
+		Reason:             "Failed",
+		Message:            err.Error(),
+	})
+	if err := r.Status().Update(ctx, hcloudFirewall); err != nil {
+		log.Error(err, "Failed to update HcloudFirewall status", "name", hcloudFirewall.Name)
+	}
+	r.Recorder.Eventf(hcloudFirewall, "Warning", "UpdateFailed", "Failed to update firewall %s in Hetzner cloud: %v", hcloudFirewall.Spec.Name, err)
+
+	return ctrl.Result{}, err
+}
+
+// deletionFailed reports a failed deletion of the Hetzner Cloud firewall and returns the error for a retry
+func (r *HcloudFirewallReconciler) deletionFailed(ctx context.Context, hcloudFirewall *hcloudv1alpha1.HcloudFirewall, err error) (ctrl.Result, error) {
+	log := logf.Log.WithName("hcloudfirewall-controller")
+
+	meta.SetStatusCondition(&hcloudFirewall.Status.Conditions, metav1.Condition{
+		Type:               "Available",
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: hcloudFirewall.Generation,
+		Reason:             "DeletionFailed",
+		Message:            err.Error(),
+	})
+	if err := r.Status().Update(ctx, hcloudFirewall); err != nil {
+		log.Error(err, "Failed to update HcloudFirewall status", "name", hcloudFirewall.Name)
+	}
+	r.Recorder.Eventf(hcloudFirewall, "Warning", "DeletionFailed", "Failed to delete firewall %d from Hetzner cloud: %v", hcloudFirewall.Status.FirewallId, err)
+
+	return ctrl.Result{}, err
+}
+
+// releaseFirewall removes the ownership label from an orphaned firewall, so that it can be adopted
+// by another resource. Failures are only reported, the firewall then stays claimed.
+func (r *HcloudFirewallReconciler) releaseFirewall(ctx context.Context, hcloudFirewall *hcloudv1alpha1.HcloudFirewall) {
+	log := logf.Log.WithName("hcloudfirewall-controller")
+
+	firewallClient, _, err := r.firewallClientFor(ctx, hcloudFirewall)
+	if err == nil {
+		var firewall *hcloudgo.Firewall
+		firewall, _, err = firewallClient.GetFirewallById(ctx, int64(hcloudFirewall.Status.FirewallId))
+		if err == nil && firewall != nil && firewall.Labels[hcloudv1alpha1.OwnerUIDLabel] == string(hcloudFirewall.UID) {
+			labels := withoutOwnershipLabels(firewall.Labels)
+			if labels == nil {
+				labels = map[string]string{}
+			}
+			_, _, err = firewallClient.UpdateFirewallLabels(ctx, firewall, labels)
+		}
+	}
+	if err != nil {
+		log.Error(err, "Failed to release orphaned firewall", "firewallId", hcloudFirewall.Status.FirewallId)
+		r.Recorder.Eventf(hcloudFirewall, "Warning", "ReleaseFailed", "Failed to remove the ownership label from firewall %d: %v", hcloudFirewall.Status.FirewallId, err)
+	}
+}
+
+// findFirewall looks up the Hetzner Cloud firewall of the HcloudFirewall the same way findNetwork
+// looks up networks: by the external ID when pinned, otherwise by the ID in the status and by name
+// when the firewall is not known yet or no longer exists.
+func findFirewall(ctx context.Context, firewallClient hcloud.FirewallClient, hcloudFirewall *hcloudv1alpha1.HcloudFirewall, pinnedID int64) (*hcloudgo.Firewall, *hcloudgo.Response, error) {
+	log := logf.Log.WithName("hcloudfirewall-controller")
+
+	if pinnedID != 0 {
+		log.Info("Checking for existing firewall in Hetzner Cloud by external ID", "firewallId", pinnedID)
+		return firewallClient.GetFirewallById(ctx, pinnedID)
+	}
+	if hcloudFirewall.Status.FirewallId != 0 {
+		log.Info("Checking for existing firewall in Hetzner Cloud by ID", "firewallId", hcloudFirewall.Status.FirewallId)
+		firewall, response, err := firewallClient.GetFirewallById(ctx, int64(hcloudFirewall.Status.FirewallId))
+		if err != nil || firewall != nil {
+			return firewall, response, err
+		}
+		log.Info("Firewall no longer exists in Hetzner Cloud", "firewallId", hcloudFirewall.Status.FirewallId)
+	}
+	log.Info("Checking for existing firewall in Hetzner Cloud by name", "name", hcloudFirewall.Spec.Name)
+	return firewallClient.GetFirewallByName(ctx, hcloudFirewall.Spec.Name)
+}
+
+// reconcileResources converges the resources the firewall is applied to towards spec.applyTo.
+// Resources that are no longer listed are removed from the firewall. Every listed resource is
+// applied on its own, so that a server that does not exist does not keep the firewall from being
+// applied to the others, and its state is returned together with the action still applying it.
+// Failures to apply a resource are reported in its state, only errors that affect all resources,
+// such as an exhausted rate limit, are returned.
+func (r *HcloudFirewallReconciler) reconcileResources(ctx context.Context, firewallClient hcloud.FirewallClient, hcloudFirewall *hcloudv1alpha1.HcloudFirewall, firewall *hcloudgo.Firewall) ([]hcloudv1alpha1.HcloudFirewallResourceStatus, error) {
+	log := logf.Log.WithName("hcloudfirewall-controller")
+
+	desired := desiredResources(hcloudFirewall.Spec.ApplyTo)
+	desiredKeys := make(map[string]bool, len(desired))
+	for _, resource := range desired {
+		desiredKeys[firewallResourceKey(resource)] = true
+	}
+
+	// Remove resources that are no longer desired
+	live := make(map[string]hcloudgo.FirewallResource, len(firewall.AppliedTo))
+	var remove []hcloudgo.FirewallResource
+	for _, resource := range firewall.AppliedTo {
+		key := firewallResourceKey(resource)
+		live[key] = resource
+		if !desiredKeys[key] {
+			remove = append(remove, resource)
+		}
+	}
+	if len(remove) > 0 {
+		log.Info("Removing firewall from resources", "firewallId", firewall.ID, "resources", describeResources(remove))
+		if _, response, err := firewallClient.RemoveFirewallResources(ctx, firewall, directResources(remove)); err != nil {
+			return nil, fmt.Errorf("failed to remove firewall from %s: %w. %v", describeResources(remove), err, response)
+		}
+	}
+
+	previous := make(map[string]hcloudv1alpha1.HcloudFirewallResourceStatus, len(hcloudFirewall.Status.AppliedTo))
+	for _, status := range hcloudFirewall.Status.AppliedTo {
+		previous[statusResourceKey(status)] = status
+	}
+
+	statuses := make([]hcloudv1alpha1.HcloudFirewallResourceStatus, 0, len(desired))
+	for _, resource := range desired {
+		key := firewallResourceKey(resource)
+		status := resourceStatus(resource)
+
+		if applied, ok := live[key]; ok {
+			status.State = hcloudv1alpha1.HcloudFirewallResourceApplied
+			status.Servers = appliedServers(applied)
+			statuses = append(statuses, status)
+			continue
+		}
+
+		// Follow the action started by an earlier reconcile instead of applying the resource again
+		if last, ok := previous[key]; ok && last.State == hcloudv1alpha1.HcloudFirewallResourcePending && last.ActionId != 0 {
+			action, response, err := firewallClient.GetAction(ctx, last.ActionId)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get action %d applying the firewall to %s: %w. %v", last.ActionId, key, err, response)
+			}
+			setActionState(&status, action)
+			statuses = append(statuses, status)
+			continue
+		}
+
+		log.Info("Applying firewall to resource", "firewallId", firewall.ID, "resource", key)
+		actions, response, err := firewallClient.ApplyFirewallResources(ctx, firewall, []hcloudgo.FirewallResource{resource})
+		if _, ok := hcloud.RateLimitReset(err); ok {
+			return nil, err
+		}
+		if err != nil {
+			log.Error(err, "Failed to apply firewall to resource", "firewallId", firewall.ID, "resource", key)
+			status.State = hcloudv1alpha1.HcloudFirewallResourceFailed
+			status.Message = fmt.Sprintf("%v. %v", err, response)
+			statuses = append(statuses, status)
+			continue
+		}
+		var action *hcloudgo.Action
+		for _, started := range actions {
+			if action == nil || started.Status != hcloudgo.ActionStatusSuccess {
+				action = started
+			}
+		}
+		setActionState(&status, action)
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// setActionState sets the state of a resource from the action applying the firewall to it
+func setActionState(status *hcloudv1alpha1.HcloudFirewallResourceStatus, action *hcloudgo.Action) {
+	switch {
+	case action == nil || action.Status == hcloudgo.ActionStatusSuccess:
+		status.State = hcloudv1alpha1.HcloudFirewallResourceApplied
+	case action.Status == hcloudgo.ActionStatusError:
+		status.State = hcloudv1alpha1.HcloudFirewallResourceFailed
+		status.Message = fmt.Sprintf("Hetzner Cloud action %d (%s) failed with %s: %s", action.ID, action.Command, action.ErrorCode, action.ErrorMessage)
+	default:
+		status.State = hcloudv1alpha1.HcloudFirewallResourcePending
+		status.ActionId = action.ID
+	}
+}
+
+// resourceStates returns the keys of the resources the firewall is still being applied to and of
+// those it could not be applied to
+func resourceStates(statuses []hcloudv1alpha1.HcloudFirewallResourceStatus) (pending, failed []string) {
+	for _, status := range statuses {
+		switch status.State {
+		case hcloudv1alpha1.HcloudFirewallResourcePending:
+			pending = append(pending, statusResourceKey(status))
+		case hcloudv1alpha1.HcloudFirewallResourceFailed:
+			failed = append(failed, fmt.Sprintf("%s (%s)", statusResourceKey(status), status.Message))
+		}
+	}
+	return pending, failed
+}
+
+// desiredResources converts spec.applyTo into the resources the firewall is applied to, servers first
+func desiredResources(applyTo hcloudv1alpha1.HcloudFirewallApplyTo) []hcloudgo.FirewallResource {
+	resources := make([]hcloudgo.FirewallResource, 0, len(applyTo.ServerIds)+len(applyTo.LabelSelectors))
+	for _, id := range applyTo.ServerIds {
+		resources = append(resources, hcloudgo.FirewallResource{
+			Type:   hcloudgo.FirewallResourceTypeServer,
+			Server: &hcloudgo.FirewallResourceServer{ID: int64(id)},
+		})
+	}
+	for _, selector := range applyTo.LabelSelectors {
+		resources = append(resources, hcloudgo.FirewallResource{
+			Type:          hcloudgo.FirewallResourceTypeLabelSelector,
+			LabelSelector: &hcloudgo.FirewallResourceLabelSelector{Selector: selector},
+		})
+	}
+	return resources
+}
+
+// directResources strips the servers matched by label selectors, which cannot be removed on their own
+func directResources(resources []hcloudgo.FirewallResource) []hcloudgo.FirewallResource {
+	direct := make([]hcloudgo.FirewallResource, 0, len(resources))
+	for _, resource := range resources {
+		direct = append(direct, hcloudgo.FirewallResource{
+			Type:          resource.Type,
+			Server:        resource.Server,
+			LabelSelector: resource.LabelSelector,
+		})
+	}
+	return direct
+}
+
+// firewallResourceKey identifies a resource a firewall is applied to, such as server:42 or label_selector:role=web
+func firewallResourceKey(resource hcloudgo.FirewallResource) string {
+	switch {
+	case resource.Type == hcloudgo.FirewallResourceTypeServer && resource.Server != nil:
+		return fmt.Sprintf("%s:%d", resource.Type, resource.Server.ID)
+	case resource.Type == hcloudgo.FirewallResourceTypeLabelSelector && resource.LabelSelector != nil:
+		return fmt.Sprintf("%s:%s", resource.Type, resource.LabelSelector.Selector)
+	}
+	return string(resource.Type)
+}
+
+// statusResourceKey identifies the resource of a status entry like firewallResourceKey
+func statusResourceKey(status hcloudv1alpha1.HcloudFirewallResourceStatus) string {
+	if status.Type == string(hcloudgo.FirewallResourceTypeLabelSelector) {
+		return fmt.Sprintf("%s:%s", status.Type, status.LabelSelector)
+	}
+	return fmt.Sprintf("%s:%d", status.Type, status.ServerId)
+}
+
+// describeResources joins the keys of the resources for messages
+func describeResources(resources []hcloudgo.FirewallResource) string {
+	keys := make([]string, 0, len(resources))
+	for _, resource := range resources {
+		keys = append(keys, firewallResourceKey(resource))
+	}
+	return strings.Join(keys, ", ")
+}
+
+// resourceStatus returns the status entry of a resource without its state
+func resourceStatus(resource hcloudgo.FirewallResource) hcloudv1alpha1.HcloudFirewallResourceStatus {
+	status := hcloudv1alpha1.HcloudFirewallResourceStatus{Type: string(resource.Type)}
+	if resource.Server != nil {
+		status.ServerId = int(resource.Server.ID)
+	}
+	if resource.LabelSelector != nil {
+		status.LabelSelector = resource.LabelSelector.Selector
+	}
+	return status
+}
+
+// appliedServers returns the IDs of the servers a label selector resource currently matches
+func appliedServers(resource hcloudgo.FirewallResource) []int {
+	var servers []int
+	for _, applied := range resource.AppliedToResources {
+		if applied.Server != nil {
+			servers = append(servers, int(applied.Server.ID))
+		}
+	}
+	return servers
+}
+
+// appliedResourceStatuses converts the resources a firewall is applied to in Hetzner Cloud into
+// their status representation, used when the operator does not manage them
+func appliedResourceStatuses(resources []hcloudgo.FirewallResource) []hcloudv1alpha1.HcloudFirewallResourceStatus {
+	if len(resources) == 0 {
+		return nil
+	}
+	statuses := make([]hcloudv1alpha1.HcloudFirewallResourceStatus, 0, len(resources))
+	for _, resource := range resources {
+		status := resourceStatus(resource)
+		status.State = hcloudv1alpha1.HcloudFirewallResourceApplied
+		status.Servers = appliedServers(resource)
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+// firewallRules converts the rules of the spec into Hetzner Cloud firewall rules, normalizing the CIDRs
+func firewallRules(rules []hcloudv1alpha1.HcloudFirewallRule) ([]hcloudgo.FirewallRule, error) {
+	converted := make([]hcloudgo.FirewallRule, 0, len(rules))
+	for i, rule := range rules {
+		sourceIPs, err := parseCIDRs(rule.SourceIPs)
+		if err != nil {
+			return nil, fmt.Errorf("rules[%d].sourceIPs: %w", i, err)
+		}
+		destinationIPs, err := parseCIDRs(rule.DestinationIPs)
+		if err != nil {
+			return nil, fmt.Errorf("rules[%d].destinationIPs: %w", i, err)
+		}
+		firewallRule := hcloudgo.FirewallRule{
+			Direction:      hcloudgo.FirewallRuleDirection(rule.Direction),
+			Protocol:       hcloudgo.FirewallRuleProtocol(rule.Protocol),
+			SourceIPs:      sourceIPs,
+			DestinationIPs: destinationIPs,
+		}
+		if rule.Port != "" {
+			firewallRule.Port = hcloudgo.Ptr(rule.Port)
+		}
+		if rule.Description != "" {
+			firewallRule.Description = hcloudgo.Ptr(rule.Description)
+		}
+		converted = append(converted, firewallRule)
+	}
+	return converted, nil
+}
+
+// parseCIDRs parses a list of CIDRs, an empty list is returned as an empty slice as the API requires
+func parseCIDRs(cidrs []string) ([]net.IPNet, error) {
+	parsed := make([]net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CIDR %q", cidr)
+		}
+		parsed = append(parsed, *ipNet)
+	}
+	return parsed, nil
+}
+
+// describeFirewallRule formats a rule with its CIDRs sorted, so that equal rules are described equally
+func describeFirewallRule(rule hcloudgo.FirewallRule) string {
+	ips := rule.SourceIPs
+	preposition := "from"
+	if rule.Direction == hcloudgo.FirewallRuleDirectionOut {
+		ips = rule.DestinationIPs
+		preposition = "to"
+	}
+	cidrs := make([]string, 0, len(ips))
+	for _, ip := range ips {
+		cidrs = append(cidrs, ip.String())
+	}
+	slices.Sort(cidrs)
+
+	description := fmt.Sprintf("%s %s", rule.Direction, rule.Protocol)
+	if rule.Port != nil {
+		description += " " + *rule.Port
+	}
+	description += fmt.Sprintf(" %s %s", preposition, strings.Join(cidrs, ","))
+	if rule.Description != nil && *rule.Description != "" {
+		description += fmt.Sprintf(" (%s)", *rule.Description)
+	}
+	return description
+}
+
+// describeFirewallRules describes each rule and sorts them, as the order of firewall rules does not matter
+func describeFirewallRules(rules []hcloudgo.FirewallRule) []string {
+	descriptions := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		descriptions = append(descriptions, describeFirewallRule(rule))
+	}
+	slices.Sort(descriptions)
+	return descriptions
+}
+
+// rulesMatch reports whether the firewall has the desired rules, regardless of their order
+func rulesMatch(desired, actual []hcloudgo.FirewallRule) bool {
+	return slices.Equal(describeFirewallRules(desired), describeFirewallRules(actual))
+}
+
+// firewallRuleStatuses converts the rules of a Hetzner Cloud firewall into their status representation
+func firewallRuleStatuses(rules []hcloudgo.FirewallRule) []hcloudv1alpha1.HcloudFirewallRule {
+	if len(rules) == 0 {
+		return nil
+	}
+	statuses := make([]hcloudv1alpha1.HcloudFirewallRule, 0, len(rules))
+	for _, rule := range rules {
+		status := hcloudv1alpha1.HcloudFirewallRule{
+			Direction: string(rule.Direction),
+			Protocol:  string(rule.Protocol),
+		}
+		if rule.Port != nil {
+			status.Port = *rule.Port
+		}
+		if rule.Description != nil {
+			status.Description = *rule.Description
+		}
+		for _, ip := range rule.SourceIPs {
+			status.SourceIPs = append(status.SourceIPs, ip.String())
+		}
+		for _, ip := range rule.DestinationIPs {
+			status.DestinationIPs = append(status.DestinationIPs, ip.String())
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+// firewallDrift returns the fields in which the Hetzner Cloud firewall differs from the spec. Fields
+// the sync policy ignores are not compared, and resources the firewall is still being applied to are
+// not reported as missing. Rules that still match the status are not reported when the policy
+// updates them, they differ because the CIDRs resolved from the referenced objects changed.
+func firewallDrift(hcloudFirewall *hcloudv1alpha1.HcloudFirewall, desiredLabels map[string]string, desiredRules []hcloudgo.FirewallRule, firewall *hcloudgo.Firewall, policy effectiveSyncPolicy) []string {
+	var drift []string
+	if liveLabels := withoutOwnershipLabels(firewall.Labels); desiredLabels != nil && !equality.Semantic.DeepEqual(desiredLabels, liveLabels) {
+		drift = append(drift, labelsDrift(liveLabels, desiredLabels)...)
+	}
+
+	if !policy.ignores("rules") && !rulesMatch(desiredRules, firewall.Rules) && (!policy.updates() || !rulesMatch(lastRules(hcloudFirewall), firewall.Rules)) {
+		drift = append(drift, driftEntry("rules", strings.Join(describeFirewallRules(firewall.Rules), ", "), strings.Join(describeFirewallRules(desiredRules), ", ")))
+	}
+
+	if !policy.ignores("applyTo") {
+		pending := make(map[string]bool)
+		for _, status := range hcloudFirewall.Status.AppliedTo {
+			if status.State == hcloudv1alpha1.HcloudFirewallResourcePending {
+				pending[statusResourceKey(status)] = true
+			}
+		}
+		live := make(map[string]bool, len(firewall.AppliedTo))
+		for _, resource := range firewall.AppliedTo {
+			live[firewallResourceKey(resource)] = true
+		}
+		desired := make(map[string]bool)
+		for _, resource := range desiredResources(hcloudFirewall.Spec.ApplyTo) {
+			key := firewallResourceKey(resource)
+			desired[key] = true
+			if !live[key] && !pending[key] {
+				drift = append(drift, driftEntry("applyTo["+key+"]", "", "applied"))
+			}
+		}
+		for _, resource := range firewall.AppliedTo {
+			if key := firewallResourceKey(resource); !desired[key] {
+				drift = append(drift, driftEntry("applyTo["+key+"]", "applied", ""))
+			}
+		}
+	}
+
+	return drift
+}
+
+// lastRules returns the rules of the firewall recorded in the status by the last reconcile
+func lastRules(hcloudFirewall *hcloudv1alpha1.HcloudFirewall) []hcloudgo.FirewallRule {
+	rules, err := firewallRules(hcloudFirewall.Status.Rules)
+	if err != nil {
+		return nil
+	}
+	return rules
+}
+
+// firewallClientFor returns the Hetzner Cloud client for the HcloudFirewall together with the resolved provider.
+// The client is built from the credentials of the resource or its provider config, or is the client
+// configured for the manager when neither is set.
+func (r *HcloudFirewallReconciler) firewallClientFor(ctx context.Context, hcloudFirewall *hcloudv1alpha1.HcloudFirewall) (hcloud.FirewallClient, *hcloudProvider, error) {
+	provider, err := resolveProvider(ctx, r.Client, r.DefaultProviderConfig, hcloudFirewall.Namespace, hcloudFirewall.Spec.CredentialsRef, hcloudFirewall.Spec.ProviderConfigRef)
+	if err != nil {
+		return nil, nil, err
+	}
+	if provider.token == "" {
+		if r.FirewallClient == nil {
+			return nil, nil, fmt.Errorf("no credentials configured for the resource and no Hetzner Cloud token configured for the manager")
+		}
+		return r.FirewallClient, provider, nil
+	}
+	if r.ClientCache == nil {
+		return nil, nil, fmt.Errorf("no client cache configured to resolve credentials")
+	}
+	return r.ClientCache.FirewallClient(provider.cacheKey, provider.cacheVersion, provider.token, provider.options...), provider, nil
+}
+
+// SetupWithManager sets up the controller with the Manager. Unlike the other controllers the event
+// filter only applies to HcloudFirewalls, the objects referenced by rules change through their
+// status or data without changing their generation.
+func (r *HcloudFirewallReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&hcloudv1alpha1.HcloudFirewall{}, builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, pausedAnnotationChanged))).
+		Named("hcloudfirewall").
+		Watches(&corev1.Node{}, handler.EnqueueRequestsFromMapFunc(r.firewallsForNode), builder.WithPredicates(nodeAddressesChanged)).
+		Watches(&hcloudv1alpha1.HcloudNetwork{}, handler.EnqueueRequestsFromMapFunc(r.firewallsForNetwork), builder.WithPredicates(networkIPRangeChanged)).
+		// Only the metadata of ConfigMaps is cached, their data is read through the APIReader
+		WatchesMetadata(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(r.firewallsForConfigMap)).
+		Complete(r)
+}
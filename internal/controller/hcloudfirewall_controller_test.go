@@ -0,0 +1,709 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hcloudv1alpha1 "bunskin.com/hcrm/api/v1alpha1"
+	"bunskin.com/hcrm/pkg/hcloud"
+	hcloudgo "github.com/hetznercloud/hcloud-go/v2/hcloud"
+	"github.com/hetznercloud/hcloud-go/v2/hcloud/schema"
+)
+
+var _ = Describe("HcloudFirewall Controller", func() {
+	const namespace = "default"
+
+	ctx := context.Background()
+
+	_, privateRange, _ := net.ParseCIDR("10.0.0.0/8")
+	_, anyRange, _ := net.ParseCIDR("0.0.0.0/0")
+
+	// newFirewall returns an HcloudFirewall allowing ssh from the private network and applied to server 42
+	newFirewall := func(name string, policy *hcloudv1alpha1.HcloudSyncPolicy) *hcloudv1alpha1.HcloudFirewall {
+		return &hcloudv1alpha1.HcloudFirewall{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+			Spec: hcloudv1alpha1.HcloudFirewallSpec{
+				Name:   name,
+				Labels: map[string]string{"env": "test"},
+				Rules: []hcloudv1alpha1.HcloudFirewallRule{{
+					Direction:   "in",
+					Protocol:    "tcp",
+					Port:        "22",
+					SourceIPs:   []string{"10.0.0.0/8"},
+					Description: "ssh",
+				}},
+				ApplyTo: hcloudv1alpha1.HcloudFirewallApplyTo{
+					ServerIds: []int{42},
+				},
+				SyncPolicy: policy,
+			},
+		}
+	}
+
+	// sshRule is the Hetzner Cloud rule of the spec returned by newFirewall
+	sshRule := hcloudgo.FirewallRule{
+		Direction:      hcloudgo.FirewallRuleDirectionIn,
+		Protocol:       hcloudgo.FirewallRuleProtocolTCP,
+		Port:           hcloudgo.Ptr("22"),
+		SourceIPs:      []net.IPNet{*privateRange},
+		DestinationIPs: []net.IPNet{},
+		Description:    hcloudgo.Ptr("ssh"),
+	}
+
+	serverResource := func(id int64) hcloudgo.FirewallResource {
+		return hcloudgo.FirewallResource{
+			Type:   hcloudgo.FirewallResourceTypeServer,
+			Server: &hcloudgo.FirewallResourceServer{ID: id},
+		}
+	}
+
+	Context("Create new HcloudFirewall", func() {
+		It("should create the firewall and follow the action applying it", func() {
+			const resourceName = "test-create-firewall"
+			typeNamespacedName := types.NamespacedName{Name: resourceName, Namespace: namespace}
+
+			resource := newFirewall(resourceName, nil)
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			var createdRules []hcloudgo.FirewallRule
+			var applied []hcloudgo.FirewallResource
+			actionStatus := hcloudgo.ActionStatusRunning
+			MockFirewallClient := &hcloud.MockFirewallClient{}
+			MockFirewallClient.CreateFirewallFunc = func(ctx context.Context, name string, rules []hcloudgo.FirewallRule, labels map[string]string) (*hcloudgo.Firewall, *hcloudgo.Response, error) {
+				createdRules = rules
+				return &hcloudgo.Firewall{ID: 4711, Name: name, Rules: rules, Labels: labels}, nil, nil
+			}
+			MockFirewallClient.ApplyFirewallResourcesFunc = func(ctx context.Context, firewall *hcloudgo.Firewall, resources []hcloudgo.FirewallResource) ([]*hcloudgo.Action, *hcloudgo.Response, error) {
+				applied = append(applied, resources...)
+				return []*hcloudgo.Action{{ID: 901, Status: hcloudgo.ActionStatusRunning}}, nil, nil
+			}
+			MockFirewallClient.GetActionFunc = func(ctx context.Context, id int64) (*hcloudgo.Action, *hcloudgo.Response, error) {
+				return &hcloudgo.Action{ID: id, Status: actionStatus}, nil, nil
+			}
+
+			reconciler := &HcloudFirewallReconciler{
+				Client:         k8sClient,
+				Scheme:         k8sClient.Scheme(),
+				FirewallClient: MockFirewallClient,
+				Recorder:       recorder,
+			}
+
+			By("creating the firewall and applying it to the server")
+			result, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(defaultActionPollInterval))
+			Expect(createdRules).To(Equal([]hcloudgo.FirewallRule{sshRule}))
+			Expect(applied).To(Equal([]hcloudgo.FirewallResource{serverResource(42)}))
+
+			updatedResource := &hcloudv1alpha1.HcloudFirewall{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			Expect(updatedResource.Status.FirewallId).To(Equal(4711))
+			Expect(updatedResource.Status.Labels).To(Equal(map[string]string{"env": "test"}))
+			Expect(updatedResource.Status.Rules).To(Equal(resource.Spec.Rules))
+			Expect(updatedResource.Status.AppliedTo).To(Equal([]hcloudv1alpha1.HcloudFirewallResourceStatus{{
+				Type:     "server",
+				ServerId: 42,
+				State:    hcloudv1alpha1.HcloudFirewallResourcePending,
+				ActionId: 901,
+			}}))
+			Expect(updatedResource.Finalizers).To(ContainElement(finalizerName))
+			condition := meta.FindStatusCondition(updatedResource.Status.Conditions, "Available")
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).To(Equal(applyPendingReason))
+
+			By("marking the server applied once the action succeeded")
+			actionStatus = hcloudgo.ActionStatusSuccess
+			MockFirewallClient.GetFirewallByIdFunc = func(ctx context.Context, id int64) (*hcloudgo.Firewall, *hcloudgo.Response, error) {
+				return &hcloudgo.Firewall{ID: id, Name: resourceName, Rules: createdRules, Labels: withOwnershipLabels(map[string]string{"env": "test"}, nil, updatedResource, "")}, nil, nil
+			}
+			_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(applied).To(HaveLen(1))
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			Expect(updatedResource.Status.AppliedTo).To(Equal([]hcloudv1alpha1.HcloudFirewallResourceStatus{{
+				Type:     "server",
+				ServerId: 42,
+				State:    hcloudv1alpha1.HcloudFirewallResourceApplied,
+			}}))
+			condition = meta.FindStatusCondition(updatedResource.Status.Conditions, "Available")
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(condition.Reason).To(Equal("Ready"))
+
+			Expect(k8sClient.Delete(ctx, updatedResource)).To(Succeed())
+		})
+
+		It("should report each resource the firewall cannot be applied to", func() {
+			const resourceName = "test-apply-failed-firewall"
+			typeNamespacedName := types.NamespacedName{Name: resourceName, Namespace: namespace}
+
+			resource := newFirewall(resourceName, nil)
+			resource.Spec.ApplyTo.LabelSelectors = []string{"role=web"}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			MockFirewallClient := &hcloud.MockFirewallClient{}
+			MockFirewallClient.CreateFirewallFunc = func(ctx context.Context, name string, rules []hcloudgo.FirewallRule, labels map[string]string) (*hcloudgo.Firewall, *hcloudgo.Response, error) {
+				return &hcloudgo.Firewall{ID: 4712, Name: name, Rules: rules, Labels: labels}, nil, nil
+			}
+			MockFirewallClient.ApplyFirewallResourcesFunc = func(ctx context.Context, firewall *hcloudgo.Firewall, resources []hcloudgo.FirewallResource) ([]*hcloudgo.Action, *hcloudgo.Response, error) {
+				if resources[0].Type == hcloudgo.FirewallResourceTypeServer {
+					return nil, nil, hcloudgo.ErrorFromSchema(schema.Error{Code: "not_found", Message: "server not found"})
+				}
+				return []*hcloudgo.Action{{ID: 902, Status: hcloudgo.ActionStatusSuccess}}, nil, nil
+			}
+
+			reconciler := &HcloudFirewallReconciler{
+				Client:         k8sClient,
+				Scheme:         k8sClient.Scheme(),
+				FirewallClient: MockFirewallClient,
+				Recorder:       recorder,
+			}
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			updatedResource := &hcloudv1alpha1.HcloudFirewall{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			Expect(updatedResource.Status.AppliedTo).To(HaveLen(2))
+			Expect(updatedResource.Status.AppliedTo[0].ServerId).To(Equal(42))
+			Expect(updatedResource.Status.AppliedTo[0].State).To(Equal(hcloudv1alpha1.HcloudFirewallResourceFailed))
+			Expect(updatedResource.Status.AppliedTo[0].Message).To(ContainSubstring("server not found"))
+			Expect(updatedResource.Status.AppliedTo[1].LabelSelector).To(Equal("role=web"))
+			Expect(updatedResource.Status.AppliedTo[1].State).To(Equal(hcloudv1alpha1.HcloudFirewallResourceApplied))
+
+			condition := meta.FindStatusCondition(updatedResource.Status.Conditions, "Available")
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).To(Equal(applyFailedReason))
+			Expect(condition.Message).To(ContainSubstring("server:42"))
+
+			Expect(k8sClient.Delete(ctx, updatedResource)).To(Succeed())
+		})
+
+		It("should reject rules with an invalid CIDR", func() {
+			const resourceName = "test-invalid-rules-firewall"
+			typeNamespacedName := types.NamespacedName{Name: resourceName, Namespace: namespace}
+
+			resource := newFirewall(resourceName, nil)
+			resource.Spec.Rules[0].SourceIPs = []string{"10.0.0.0/33"}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			MockFirewallClient := &hcloud.MockFirewallClient{}
+			MockFirewallClient.CreateFirewallFunc = func(ctx context.Context, name string, rules []hcloudgo.FirewallRule, labels map[string]string) (*hcloudgo.Firewall, *hcloudgo.Response, error) {
+				Fail("the firewall must not be created with invalid rules")
+				return nil, nil, nil
+			}
+
+			reconciler := &HcloudFirewallReconciler{
+				Client:         k8sClient,
+				Scheme:         k8sClient.Scheme(),
+				FirewallClient: MockFirewallClient,
+				Recorder:       recorder,
+			}
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			updatedResource := &hcloudv1alpha1.HcloudFirewall{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			condition := meta.FindStatusCondition(updatedResource.Status.Conditions, "Available")
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Reason).To(Equal(invalidRulesReason))
+			Expect(condition.Message).To(ContainSubstring("rules[0].sourceIPs"))
+
+			Expect(k8sClient.Delete(ctx, updatedResource)).To(Succeed())
+		})
+	})
+
+	Context("Update existing HcloudFirewall", func() {
+		It("should replace changed rules and converge the applied resources", func() {
+			const resourceName = "test-update-firewall"
+			typeNamespacedName := types.NamespacedName{Name: resourceName, Namespace: namespace}
+
+			resource := newFirewall(resourceName, nil)
+			resource.Spec.ApplyTo = hcloudv1alpha1.HcloudFirewallApplyTo{LabelSelectors: []string{"role=web"}}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			live := &hcloudgo.Firewall{
+				ID:   4713,
+				Name: resourceName,
+				Rules: []hcloudgo.FirewallRule{{
+					Direction: hcloudgo.FirewallRuleDirectionIn,
+					Protocol:  hcloudgo.FirewallRuleProtocolTCP,
+					Port:      hcloudgo.Ptr("22"),
+					SourceIPs: []net.IPNet{*anyRange},
+				}},
+				Labels: map[string]string{"env": "test"},
+				AppliedTo: []hcloudgo.FirewallResource{
+					serverResource(7),
+					{
+						Type:          hcloudgo.FirewallResourceTypeLabelSelector,
+						LabelSelector: &hcloudgo.FirewallResourceLabelSelector{Selector: "role=web"},
+						AppliedToResources: []hcloudgo.FirewallResource{
+							serverResource(11),
+							serverResource(12),
+						},
+					},
+				},
+			}
+			var setRules []hcloudgo.FirewallRule
+			var removed []hcloudgo.FirewallResource
+			MockFirewallClient := &hcloud.MockFirewallClient{}
+			MockFirewallClient.GetFirewallByNameFunc = func(ctx context.Context, name string) (*hcloudgo.Firewall, *hcloudgo.Response, error) {
+				return live, nil, nil
+			}
+			MockFirewallClient.UpdateFirewallLabelsFunc = func(ctx context.Context, firewall *hcloudgo.Firewall, labels map[string]string) (*hcloudgo.Firewall, *hcloudgo.Response, error) {
+				updated := *firewall
+				updated.Labels = labels
+				return &updated, nil, nil
+			}
+			MockFirewallClient.SetFirewallRulesFunc = func(ctx context.Context, firewall *hcloudgo.Firewall, rules []hcloudgo.FirewallRule) (*hcloudgo.Firewall, *hcloudgo.Response, error) {
+				setRules = rules
+				updated := *firewall
+				updated.Rules = rules
+				return &updated, nil, nil
+			}
+			MockFirewallClient.RemoveFirewallResourcesFunc = func(ctx context.Context, firewall *hcloudgo.Firewall, resources []hcloudgo.FirewallResource) ([]*hcloudgo.Action, *hcloudgo.Response, error) {
+				removed = resources
+				return nil, nil, nil
+			}
+			MockFirewallClient.ApplyFirewallResourcesFunc = func(ctx context.Context, firewall *hcloudgo.Firewall, resources []hcloudgo.FirewallResource) ([]*hcloudgo.Action, *hcloudgo.Response, error) {
+				Fail(fmt.Sprintf("resources already applied must not be applied again: %v", resources))
+				return nil, nil, nil
+			}
+
+			reconciler := &HcloudFirewallReconciler{
+				Client:         k8sClient,
+				Scheme:         k8sClient.Scheme(),
+				FirewallClient: MockFirewallClient,
+				Recorder:       recorder,
+			}
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			By("verifying the rules were replaced and server 7 was removed")
+			Expect(setRules).To(Equal([]hcloudgo.FirewallRule{sshRule}))
+			Expect(removed).To(Equal([]hcloudgo.FirewallResource{serverResource(7)}))
+
+			updatedResource := &hcloudv1alpha1.HcloudFirewall{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			Expect(updatedResource.Status.FirewallId).To(Equal(4713))
+			Expect(updatedResource.Status.Rules).To(Equal(resource.Spec.Rules))
+			Expect(updatedResource.Status.AppliedTo).To(Equal([]hcloudv1alpha1.HcloudFirewallResourceStatus{{
+				Type:          "label_selector",
+				LabelSelector: "role=web",
+				Servers:       []int{11, 12},
+				State:         hcloudv1alpha1.HcloudFirewallResourceApplied,
+			}}))
+			condition := meta.FindStatusCondition(updatedResource.Status.Conditions, "Available")
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Reason).To(Equal("Ready"))
+
+			Expect(k8sClient.Delete(ctx, updatedResource)).To(Succeed())
+		})
+
+		It("should leave ignored rules and resources to other tools", func() {
+			const resourceName = "test-ignore-rules-firewall"
+			typeNamespacedName := types.NamespacedName{Name: resourceName, Namespace: namespace}
+
+			resource := newFirewall(resourceName, &hcloudv1alpha1.HcloudSyncPolicy{IgnoreFields: []string{"rules", "applyTo"}})
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			MockFirewallClient := &hcloud.MockFirewallClient{}
+			MockFirewallClient.GetFirewallByNameFunc = func(ctx context.Context, name string) (*hcloudgo.Firewall, *hcloudgo.Response, error) {
+				return &hcloudgo.Firewall{ID: 4714, Name: name, AppliedTo: []hcloudgo.FirewallResource{serverResource(7)}}, nil, nil
+			}
+			MockFirewallClient.UpdateFirewallLabelsFunc = func(ctx context.Context, firewall *hcloudgo.Firewall, labels map[string]string) (*hcloudgo.Firewall, *hcloudgo.Response, error) {
+				updated := *firewall
+				updated.Labels = labels
+				return &updated, nil, nil
+			}
+			MockFirewallClient.SetFirewallRulesFunc = func(ctx context.Context, firewall *hcloudgo.Firewall, rules []hcloudgo.FirewallRule) (*hcloudgo.Firewall, *hcloudgo.Response, error) {
+				Fail("ignored rules must not be set")
+				return nil, nil, nil
+			}
+			MockFirewallClient.ApplyFirewallResourcesFunc = func(ctx context.Context, firewall *hcloudgo.Firewall, resources []hcloudgo.FirewallResource) ([]*hcloudgo.Action, *hcloudgo.Response, error) {
+				Fail("ignored resources must not be applied")
+				return nil, nil, nil
+			}
+			MockFirewallClient.RemoveFirewallResourcesFunc = func(ctx context.Context, firewall *hcloudgo.Firewall, resources []hcloudgo.FirewallResource) ([]*hcloudgo.Action, *hcloudgo.Response, error) {
+				Fail("ignored resources must not be removed")
+				return nil, nil, nil
+			}
+
+			reconciler := &HcloudFirewallReconciler{
+				Client:         k8sClient,
+				Scheme:         k8sClient.Scheme(),
+				FirewallClient: MockFirewallClient,
+				Recorder:       recorder,
+			}
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			By("verifying the status shows the resources applied in Hetzner Cloud")
+			updatedResource := &hcloudv1alpha1.HcloudFirewall{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			Expect(updatedResource.Status.Labels).To(Equal(map[string]string{"env": "test"}))
+			Expect(updatedResource.Status.Rules).To(BeEmpty())
+			Expect(updatedResource.Status.AppliedTo).To(Equal([]hcloudv1alpha1.HcloudFirewallResourceStatus{{
+				Type:     "server",
+				ServerId: 7,
+				State:    hcloudv1alpha1.HcloudFirewallResourceApplied,
+			}}))
+
+			Expect(k8sClient.Delete(ctx, updatedResource)).To(Succeed())
+		})
+
+		It("should not create a firewall when sync policy is read-only", func() {
+			const resourceName = "test-readonly-firewall"
+			typeNamespacedName := types.NamespacedName{Name: resourceName, Namespace: namespace}
+
+			resource := newFirewall(resourceName, &hcloudv1alpha1.HcloudSyncPolicy{Mode: hcloudv1alpha1.SyncPolicyReadOnly})
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			MockFirewallClient := &hcloud.MockFirewallClient{}
+			MockFirewallClient.CreateFirewallFunc = func(ctx context.Context, name string, rules []hcloudgo.FirewallRule, labels map[string]string) (*hcloudgo.Firewall, *hcloudgo.Response, error) {
+				Fail("a read-only firewall must not be created")
+				return nil, nil, nil
+			}
+
+			reconciler := &HcloudFirewallReconciler{
+				Client:         k8sClient,
+				Scheme:         k8sClient.Scheme(),
+				FirewallClient: MockFirewallClient,
+				Recorder:       recorder,
+			}
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			updatedResource := &hcloudv1alpha1.HcloudFirewall{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			Expect(updatedResource.Status.FirewallId).To(BeZero())
+			Expect(updatedResource.Finalizers).NotTo(ContainElement(finalizerName))
+			condition := meta.FindStatusCondition(updatedResource.Status.Conditions, "Available")
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+
+			Expect(k8sClient.Delete(ctx, updatedResource)).To(Succeed())
+		})
+	})
+
+	Context("Delete HcloudFirewall", func() {
+		It("should remove the firewall from its resources before deleting it", func() {
+			const resourceName = "test-delete-firewall"
+			typeNamespacedName := types.NamespacedName{Name: resourceName, Namespace: namespace}
+
+			resource := newFirewall(resourceName, nil)
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+			resource.Status.FirewallId = 4715
+			Expect(k8sClient.Status().Update(ctx, resource)).To(Succeed())
+
+			getResource := &hcloudv1alpha1.HcloudFirewall{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, getResource)).To(Succeed())
+			getResource.Finalizers = []string{finalizerName}
+			Expect(k8sClient.Update(ctx, getResource)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, getResource)).To(Succeed())
+
+			live := &hcloudgo.Firewall{ID: 4715, Name: resourceName, AppliedTo: []hcloudgo.FirewallResource{serverResource(42)}}
+			var removed []hcloudgo.FirewallResource
+			deleted := false
+			MockFirewallClient := &hcloud.MockFirewallClient{}
+			MockFirewallClient.GetFirewallByIdFunc = func(ctx context.Context, id int64) (*hcloudgo.Firewall, *hcloudgo.Response, error) {
+				return live, nil, nil
+			}
+			MockFirewallClient.RemoveFirewallResourcesFunc = func(ctx context.Context, firewall *hcloudgo.Firewall, resources []hcloudgo.FirewallResource) ([]*hcloudgo.Action, *hcloudgo.Response, error) {
+				removed = resources
+				return []*hcloudgo.Action{{ID: 903, Status: hcloudgo.ActionStatusRunning}}, nil, nil
+			}
+			MockFirewallClient.DeleteFirewallFunc = func(ctx context.Context, firewall *hcloudgo.Firewall) (*hcloudgo.Response, error) {
+				deleted = true
+				return nil, nil
+			}
+
+			reconciler := &HcloudFirewallReconciler{
+				Client:         k8sClient,
+				Scheme:         k8sClient.Scheme(),
+				FirewallClient: MockFirewallClient,
+				Recorder:       recorder,
+			}
+
+			By("removing the firewall from the server and keeping the finalizer")
+			result, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(defaultActionPollInterval))
+			Expect(removed).To(Equal([]hcloudgo.FirewallResource{serverResource(42)}))
+			Expect(deleted).To(BeFalse())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, getResource)).To(Succeed())
+			Expect(getResource.Finalizers).To(ContainElement(finalizerName))
+
+			By("deleting the firewall once it is no longer applied")
+			live.AppliedTo = nil
+			_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deleted).To(BeTrue())
+
+			err = k8sClient.Get(ctx, typeNamespacedName, getResource)
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("should leave a firewall taken over by another resource when deleted", func() {
+			const resourceName = "test-delete-taken-over-firewall"
+			typeNamespacedName := types.NamespacedName{Name: resourceName, Namespace: namespace}
+
+			resource := newFirewall(resourceName, nil)
+			resource.Finalizers = []string{finalizerName}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+			resource.Status.FirewallId = 4716
+			Expect(k8sClient.Status().Update(ctx, resource)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+
+			MockFirewallClient := &hcloud.MockFirewallClient{}
+			MockFirewallClient.GetFirewallByIdFunc = func(ctx context.Context, id int64) (*hcloudgo.Firewall, *hcloudgo.Response, error) {
+				return &hcloudgo.Firewall{ID: id, Name: resourceName, AppliedTo: []hcloudgo.FirewallResource{serverResource(42)}, Labels: map[string]string{
+					hcloudv1alpha1.ClusterIDLabel: "cluster-a",
+					hcloudv1alpha1.NamespaceLabel: "other",
+					hcloudv1alpha1.NameLabel:      "new-owner",
+					hcloudv1alpha1.OwnerUIDLabel:  "new-owner-uid",
+				}}, nil, nil
+			}
+			MockFirewallClient.RemoveFirewallResourcesFunc = func(ctx context.Context, firewall *hcloudgo.Firewall, resources []hcloudgo.FirewallResource) ([]*hcloudgo.Action, *hcloudgo.Response, error) {
+				Fail("a firewall taken over by another resource must stay applied")
+				return nil, nil, nil
+			}
+			MockFirewallClient.DeleteFirewallFunc = func(ctx context.Context, firewall *hcloudgo.Firewall) (*hcloudgo.Response, error) {
+				Fail("a firewall taken over by another resource must not be deleted")
+				return nil, nil
+			}
+
+			reconciler := &HcloudFirewallReconciler{
+				Client:         k8sClient,
+				Scheme:         k8sClient.Scheme(),
+				FirewallClient: MockFirewallClient,
+				Recorder:       recorder,
+				ClusterID:      "cluster-a",
+			}
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = k8sClient.Get(ctx, typeNamespacedName, &hcloudv1alpha1.HcloudFirewall{})
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+		})
+	})
+
+	Context("Resolve HcloudFirewall rule sources", func() {
+		It("should rewrite the rules when the resolved CIDRs change", func() {
+			const resourceName = "test-sources-firewall"
+			typeNamespacedName := types.NamespacedName{Name: resourceName, Namespace: namespace}
+
+			By("creating the referenced Node, HcloudNetwork and ConfigMap")
+			node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "test-sources-node", Labels: map[string]string{"role": "test-sources"}}}
+			Expect(k8sClient.Create(ctx, node)).To(Succeed())
+			node.Status.Addresses = []corev1.NodeAddress{
+				{Type: corev1.NodeInternalIP, Address: "10.0.1.5"},
+				{Type: corev1.NodeExternalIP, Address: "203.0.113.10"},
+			}
+			Expect(k8sClient.Status().Update(ctx, node)).To(Succeed())
+
+			network := &hcloudv1alpha1.HcloudNetwork{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-sources-network", Namespace: namespace},
+				Spec:       hcloudv1alpha1.HcloudNetworkSpec{Name: "test-sources-network", IpRange: "10.0.0.0/16"},
+			}
+			Expect(k8sClient.Create(ctx, network)).To(Succeed())
+			network.Status.IpRange = "10.0.0.0/16"
+			Expect(k8sClient.Status().Update(ctx, network)).To(Succeed())
+
+			configMap := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-sources-cidrs", Namespace: namespace},
+				Data:       map[string]string{"office": "198.51.100.0/24,\n2001:db8::/32"},
+			}
+			Expect(k8sClient.Create(ctx, configMap)).To(Succeed())
+
+			resource := newFirewall(resourceName, nil)
+			resource.Spec.Rules = []hcloudv1alpha1.HcloudFirewallRule{
+				{
+					Direction: "in",
+					Protocol:  "tcp",
+					Port:      "443",
+					SourceRefs: []hcloudv1alpha1.HcloudFirewallIPSource{
+						{NodeSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"role": "test-sources"}}},
+						{NetworkRef: &hcloudv1alpha1.HcloudNetworkReference{Name: "test-sources-network"}},
+						{ConfigMapRef: &hcloudv1alpha1.HcloudConfigMapKeyReference{Name: "test-sources-cidrs", Key: "office"}},
+					},
+				},
+				{
+					Direction: "in",
+					Protocol:  "udp",
+					Port:      "51820",
+					SourceRefs: []hcloudv1alpha1.HcloudFirewallIPSource{
+						{NodeSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"role": "test-sources-none"}}},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			var live *hcloudgo.Firewall
+			var setRules []hcloudgo.FirewallRule
+			MockFirewallClient := &hcloud.MockFirewallClient{}
+			MockFirewallClient.CreateFirewallFunc = func(ctx context.Context, name string, rules []hcloudgo.FirewallRule, labels map[string]string) (*hcloudgo.Firewall, *hcloudgo.Response, error) {
+				live = &hcloudgo.Firewall{ID: 4716, Name: name, Rules: rules, Labels: labels}
+				return live, nil, nil
+			}
+			MockFirewallClient.GetFirewallByIdFunc = func(ctx context.Context, id int64) (*hcloudgo.Firewall, *hcloudgo.Response, error) {
+				return live, nil, nil
+			}
+			MockFirewallClient.SetFirewallRulesFunc = func(ctx context.Context, firewall *hcloudgo.Firewall, rules []hcloudgo.FirewallRule) (*hcloudgo.Firewall, *hcloudgo.Response, error) {
+				setRules = rules
+				live.Rules = rules
+				return live, nil, nil
+			}
+			MockFirewallClient.ApplyFirewallResourcesFunc = func(ctx context.Context, firewall *hcloudgo.Firewall, resources []hcloudgo.FirewallResource) ([]*hcloudgo.Action, *hcloudgo.Response, error) {
+				live.AppliedTo = resources
+				return nil, nil, nil
+			}
+
+			reconciler := &HcloudFirewallReconciler{
+				Client:         k8sClient,
+				Scheme:         k8sClient.Scheme(),
+				FirewallClient: MockFirewallClient,
+				Recorder:       recorder,
+			}
+
+			By("creating the firewall with the resolved CIDRs and without the rule resolving to none")
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			updatedResource := &hcloudv1alpha1.HcloudFirewall{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			Expect(updatedResource.Status.Rules).To(Equal([]hcloudv1alpha1.HcloudFirewallRule{{
+				Direction: "in",
+				Protocol:  "tcp",
+				Port:      "443",
+				SourceIPs: []string{"203.0.113.10/32", "10.0.0.0/16", "198.51.100.0/24", "2001:db8::/32"},
+			}}))
+
+			By("mapping a change of the Node to the firewall")
+			Expect(reconciler.firewallsForNode(ctx, node)).To(ContainElement(reconcile.Request{NamespacedName: typeNamespacedName}))
+			Expect(reconciler.firewallsForConfigMap(ctx, configMap)).To(ContainElement(reconcile.Request{NamespacedName: typeNamespacedName}))
+			Expect(reconciler.firewallsForNetwork(ctx, network)).To(ContainElement(reconcile.Request{NamespacedName: typeNamespacedName}))
+
+			By("replacing the node address")
+			node.Status.Addresses = []corev1.NodeAddress{{Type: corev1.NodeExternalIP, Address: "203.0.113.11"}}
+			Expect(k8sClient.Status().Update(ctx, node)).To(Succeed())
+
+			_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(setRules).To(HaveLen(1))
+			Expect(describeFirewallRule(setRules[0])).To(Equal("in tcp 443 from 10.0.0.0/16,198.51.100.0/24,2001:db8::/32,203.0.113.11/32"))
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			Expect(updatedResource.Status.Rules[0].SourceIPs).To(ContainElement("203.0.113.11/32"))
+			Expect(meta.IsStatusConditionTrue(updatedResource.Status.Conditions, driftedCondition)).To(BeFalse())
+			condition := meta.FindStatusCondition(updatedResource.Status.Conditions, driftedCondition)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Reason).To(Equal("InSync"))
+
+			Expect(k8sClient.Delete(ctx, updatedResource)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, configMap)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, network)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, node)).To(Succeed())
+		})
+
+		It("should report a referenced network without an IP range", func() {
+			const resourceName = "test-unresolved-firewall"
+			typeNamespacedName := types.NamespacedName{Name: resourceName, Namespace: namespace}
+
+			resource := newFirewall(resourceName, nil)
+			resource.Spec.Rules = []hcloudv1alpha1.HcloudFirewallRule{{
+				Direction:  "in",
+				Protocol:   "icmp",
+				SourceRefs: []hcloudv1alpha1.HcloudFirewallIPSource{{NetworkRef: &hcloudv1alpha1.HcloudNetworkReference{Name: "test-unresolved-network"}}},
+			}}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			MockFirewallClient := &hcloud.MockFirewallClient{}
+			MockFirewallClient.CreateFirewallFunc = func(ctx context.Context, name string, rules []hcloudgo.FirewallRule, labels map[string]string) (*hcloudgo.Firewall, *hcloudgo.Response, error) {
+				Fail("the firewall must not be created before its sources are resolved")
+				return nil, nil, nil
+			}
+
+			reconciler := &HcloudFirewallReconciler{
+				Client:         k8sClient,
+				Scheme:         k8sClient.Scheme(),
+				FirewallClient: MockFirewallClient,
+				Recorder:       recorder,
+			}
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			updatedResource := &hcloudv1alpha1.HcloudFirewall{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			condition := meta.FindStatusCondition(updatedResource.Status.Conditions, "Available")
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Reason).To(Equal(unresolvedSourcesReason))
+			Expect(condition.Message).To(ContainSubstring("test-unresolved-network"))
+
+			Expect(k8sClient.Delete(ctx, updatedResource)).To(Succeed())
+		})
+	})
+
+	Context("Detect HcloudFirewall drift", func() {
+		It("should describe rule and resource drift", func() {
+			resource := newFirewall("test-drift-firewall", nil)
+			resource.Status.AppliedTo = []hcloudv1alpha1.HcloudFirewallResourceStatus{{
+				Type:          "label_selector",
+				LabelSelector: "role=web",
+				State:         hcloudv1alpha1.HcloudFirewallResourcePending,
+			}}
+			resource.Spec.ApplyTo.LabelSelectors = []string{"role=web"}
+			policy, err := resolveSyncPolicy(resource, nil, hcloudv1alpha1.SyncPolicyManage, hcloudv1alpha1.HcloudFirewallIgnorableFields)
+			Expect(err).NotTo(HaveOccurred())
+
+			desiredRules, err := firewallRules(resource.Spec.Rules)
+			Expect(err).NotTo(HaveOccurred())
+			live := &hcloudgo.Firewall{
+				Rules:     []hcloudgo.FirewallRule{{Direction: hcloudgo.FirewallRuleDirectionIn, Protocol: hcloudgo.FirewallRuleProtocolICMP, SourceIPs: []net.IPNet{*anyRange}}},
+				AppliedTo: []hcloudgo.FirewallResource{serverResource(7)},
+			}
+
+			Expect(firewallDrift(resource, nil, desiredRules, live, policy)).To(Equal([]string{
+				"rules: in icmp from 0.0.0.0/0 -> in tcp 22 from 10.0.0.0/8 (ssh)",
+				"applyTo[server:42]: <absent> -> applied",
+				"applyTo[server:7]: applied -> <absent>",
+			}))
+		})
+	})
+})
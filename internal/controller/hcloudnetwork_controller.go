@@ -19,6 +19,9 @@ package controller
 import (
 	"context"
 	"fmt"
+	"net"
+	"strings"
+	"time"
 
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -33,6 +36,7 @@ import (
 
 	hcloudv1alpha1 "bunskin.com/hcrm/api/v1alpha1"
 	"bunskin.com/hcrm/pkg/hcloud"
+	hcloudgo "github.com/hetznercloud/hcloud-go/v2/hcloud"
 )
 
 const (
@@ -47,15 +51,39 @@ type HcloudNetworkReconciler struct {
 	client.Client
 	Scheme        *runtime.Scheme
 	NetworkClient hcloud.NetworkClient
+	ClientCache   *hcloud.ClientCache
 	Recorder      record.EventRecorder
+	// DefaultProviderConfig is the name of the ClusterHcloudProviderConfig used for resources without a providerConfigRef
+	DefaultProviderConfig string
+	// ResyncInterval is the interval after which reconciled resources are checked for drift, zero disables it
+	ResyncInterval time.Duration
+	// ClusterID identifies the cluster in the ownership labels of cloud resources
+	ClusterID string
+	// Pause pauses the reconciliation of whole kinds or namespaces, nil if it cannot be paused by the manager
+	Pause *PauseSwitch
 }
 
 // +kubebuilder:rbac:groups=hcloud.bunskin.com,resources=hcloudnetworks,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=hcloud.bunskin.com,resources=hcloudnetworks/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=hcloud.bunskin.com,resources=hcloudnetworks/finalizers,verbs=update
 // +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=hcloud.bunskin.com,resources=hcloudproviderconfigs;clusterhcloudproviderconfigs,verbs=get;list;watch
 
 func (r *HcloudNetworkReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	result, err := r.reconcile(ctx, req)
+	if reset, ok := hcloud.RateLimitReset(err); ok {
+		var hcloudNetwork hcloudv1alpha1.HcloudNetwork
+		if err := r.Get(ctx, req.NamespacedName, &hcloudNetwork); err != nil {
+			return ctrl.Result{}, client.IgnoreNotFound(err)
+		}
+		return requeueRateLimited(ctx, r.Client, r.Recorder, &hcloudNetwork, &hcloudNetwork.Status.Conditions, reset, err)
+	}
+	return result, err
+}
+
+// reconcile implements Reconcile, errors caused by the Hetzner Cloud rate limit are handled by the caller
+func (r *HcloudNetworkReconciler) reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := logf.Log.WithName("hcloudnetwork-controller")
 
 	// Fetch the HcloudNetwork resource
@@ -65,6 +93,11 @@ func (r *HcloudNetworkReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
+	// A paused resource is left alone, including its deletion
+	if paused, result, err := reconcilePaused(ctx, r.Client, r.Recorder, r.Pause, "HcloudNetwork", &hcloudNetwork, &hcloudNetwork.Status.Conditions); paused {
+		return result, err
+	}
+
 	// defer func() {
 	// 	// Update status if it has changed
 	// 	if !equality.Semantic.DeepEqual(originalStatus, &hcloudNetwork.Status) {
@@ -104,10 +137,39 @@ func (r *HcloudNetworkReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 
 		// Check if finalizer exists
 		if controllerutil.ContainsFinalizer(&hcloudNetwork, finalizerName) {
-			// Delete the network from Hetzner Cloud if it exists
-			if hcloudNetwork.Status.NetworkId != 0 && hcloudNetwork.Annotations[syncPolicy] != "orphan" {
+			policy, policyErr := resolveSyncPolicy(&hcloudNetwork, hcloudNetwork.Spec.SyncPolicy, hcloudv1alpha1.SyncPolicyManage, hcloudv1alpha1.HcloudNetworkIgnorableFields)
+			if policyErr != nil {
+				// A sync policy that cannot be understood never deletes the network, it is released instead so
+				// that the orphan collector does not delete it either
+				log.Error(policyErr, "Invalid sync policy, will not remove cloud resource", "name", hcloudNetwork.Name)
+				r.Recorder.Eventf(&hcloudNetwork, "Warning", invalidSyncPolicyReason, "Network %d is left in Hetzner cloud: %v", hcloudNetwork.Status.NetworkId, policyErr)
+				if hcloudNetwork.Status.NetworkId != 0 {
+					if err := r.releaseNetwork(ctx, &hcloudNetwork); err != nil {
+						return ctrl.Result{}, err
+					}
+				}
+			} else if hcloudNetwork.Status.NetworkId != 0 && policy.deletes() {
+				// Delete the network from Hetzner Cloud if it exists
+				networkClient, _, err := r.networkClientFor(ctx, &hcloudNetwork)
+				if err != nil {
+					log.Error(err, "Failed to resolve Hetzner Cloud credentials", "name", hcloudNetwork.Name)
+					meta.SetStatusCondition(&hcloudNetwork.Status.Conditions, metav1.Condition{
+						Type:               "Available",
+						Status:             metav1.ConditionFalse,
+						ObservedGeneration: hcloudNetwork.Generation,
+						Reason:             credentialsUnavailableReason,
+						Message:            fmt.Sprintf("Failed to resolve Hetzner Cloud credentials: %v", err),
+					})
+					if err := r.Status().Update(ctx, &hcloudNetwork); err != nil {
+						log.Error(err, "Failed to update HcloudNetwork status", "name", hcloudNetwork.Name)
+					}
+					r.Recorder.Eventf(&hcloudNetwork, "Warning", credentialsUnavailableReason, "Failed to resolve Hetzner Cloud credentials: %v", err)
+
+					return ctrl.Result{}, err
+				}
+
 				log.Info("Fetching Hetzner Cloud network for deletion", "networkId", hcloudNetwork.Status.NetworkId)
-				network, response, err := r.NetworkClient.GetNetworkById(ctx, int64(hcloudNetwork.Status.NetworkId))
+				network, response, err := networkClient.GetNetworkById(ctx, int64(hcloudNetwork.Status.NetworkId))
 				if err != nil {
 					log.Error(err, "Failed to get network from Hetzner Cloud", "networkId", hcloudNetwork.Status.NetworkId)
 					meta.SetStatusCondition(&hcloudNetwork.Status.Conditions, metav1.Condition{
@@ -126,11 +188,26 @@ func (r *HcloudNetworkReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 					return ctrl.Result{}, err
 				}
 
+				owner := ""
 				if network != nil {
+					owner = claimant(network.Labels, &hcloudNetwork, r.ClusterID)
+				}
+
+				if owner != "" {
+					// A network taken over by another resource is left to it, only its ID is dropped
+					log.Info("Network is managed by another resource, will not remove it", "networkId", hcloudNetwork.Status.NetworkId, "owner", owner)
+					r.Recorder.Eventf(&hcloudNetwork, "Warning", alreadyClaimedReason, "Network %d is left in Hetzner cloud as it is managed by %s", hcloudNetwork.Status.NetworkId, owner)
+					hcloudNetwork.Status.NetworkId = 0
+					if err := r.Status().Update(ctx, &hcloudNetwork); err != nil {
+						log.Error(err, "Failed to update HcloudNetwork status", "name", hcloudNetwork.Name)
+						return ctrl.Result{}, err
+					}
+				} else if network != nil {
 					// Delete the network
 					log.Info("Deleting Hetzner Cloud network", "networkId", hcloudNetwork.Status.NetworkId)
-					response, err := r.NetworkClient.DeleteNetwork(ctx, network)
-					if err != nil {
+					response, err := networkClient.DeleteNetwork(ctx, network)
+					// A network deleted by someone else since it was fetched is gone all the same
+					if err != nil && !hcloudgo.IsError(err, hcloudgo.ErrorCodeNotFound) {
 						log.Error(err, "Failed to delete network from Hetzner Cloud", "networkId", hcloudNetwork.Status.NetworkId)
 						meta.SetStatusCondition(&hcloudNetwork.Status.Conditions, metav1.Condition{
 							Type:               "Available",
@@ -153,8 +230,11 @@ func (r *HcloudNetworkReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 				} else {
 					log.Info("Network not found in Hetzner Cloud, nothing to delete", "networkId", hcloudNetwork.Status.NetworkId)
 				}
-			} else if hcloudNetwork.Annotations[syncPolicy] == "orphan" {
-				log.Info("Sync policy is set to orphan, will not remove cloud resource")
+			} else if hcloudNetwork.Status.NetworkId != 0 {
+				log.Info("Sync policy does not delete the cloud resource, will not remove it", "syncPolicy", policy.mode)
+				if err := r.releaseNetwork(ctx, &hcloudNetwork); err != nil {
+					return ctrl.Result{}, err
+				}
 			}
 
 			// Remove finalizer
@@ -168,23 +248,62 @@ func (r *HcloudNetworkReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		return ctrl.Result{}, nil
 	}
 
+	// Resolve the Hetzner Cloud client for this resource
+	networkClient, provider, err := r.networkClientFor(ctx, &hcloudNetwork)
+	if err != nil {
+		log.Error(err, "Failed to resolve Hetzner Cloud credentials", "name", hcloudNetwork.Name)
+		meta.SetStatusCondition(&hcloudNetwork.Status.Conditions, metav1.Condition{
+			Type:               "Available",
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: hcloudNetwork.Generation,
+			Reason:             credentialsUnavailableReason,
+			Message:            fmt.Sprintf("Failed to resolve Hetzner Cloud credentials: %v", err),
+		})
+		if err := r.Status().Update(ctx, &hcloudNetwork); err != nil {
+			log.Error(err, "Failed to update HcloudNetwork status", "name", hcloudNetwork.Name)
+		}
+		r.Recorder.Eventf(&hcloudNetwork, "Warning", credentialsUnavailableReason, "Failed to resolve Hetzner Cloud credentials: %v", err)
+
+		return ctrl.Result{}, err
+	}
+
 	// Initialize annotations if not present
 	if hcloudNetwork.Annotations == nil {
 		hcloudNetwork.Annotations = make(map[string]string)
 	}
 
-	// Add sync policy annotation if not present
-	if hcloudNetwork.Annotations[syncPolicy] == "" {
+	// Add sync policy annotation if neither it nor the sync policy mode is present
+	if hcloudNetwork.Annotations[syncPolicy] == "" && (hcloudNetwork.Spec.SyncPolicy == nil || hcloudNetwork.Spec.SyncPolicy.Mode == "") {
 		log.Info("Adding sync policy annotation", "name", hcloudNetwork.Name)
-		hcloudNetwork.Annotations[syncPolicy] = "manage"
+		hcloudNetwork.Annotations[syncPolicy] = string(provider.syncPolicy())
 		if err := r.Update(ctx, &hcloudNetwork); err != nil {
 			log.Error(err, "Failed to add sync policy annotation", "name", hcloudNetwork.Name)
 			return ctrl.Result{}, err
 		}
 	}
 
+	policy, err := resolveSyncPolicy(&hcloudNetwork, hcloudNetwork.Spec.SyncPolicy, provider.syncPolicy(), hcloudv1alpha1.HcloudNetworkIgnorableFields)
+	if err != nil {
+		log.Error(err, "Invalid sync policy", "name", hcloudNetwork.Name)
+		meta.SetStatusCondition(&hcloudNetwork.Status.Conditions, metav1.Condition{
+			Type:               "Available",
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: hcloudNetwork.Generation,
+			Reason:             invalidSyncPolicyReason,
+			Message:            err.Error(),
+		})
+		if err := r.Status().Update(ctx, &hcloudNetwork); err != nil {
+			log.Error(err, "Failed to update HcloudNetwork status", "name", hcloudNetwork.Name)
+			return ctrl.Result{}, err
+		}
+		r.Recorder.Eventf(&hcloudNetwork, "Warning", invalidSyncPolicyReason, "Sync policy of network %s is invalid: %v", hcloudNetwork.Spec.Name, err)
+
+		// The sync policy has to change before the network can be reconciled
+		return ctrl.Result{}, nil
+	}
+
 	// Add finalizer if not present and sync policy supports it
-	if !controllerutil.ContainsFinalizer(&hcloudNetwork, finalizerName) && hcloudNetwork.Annotations[syncPolicy] != "read-only" {
+	if !controllerutil.ContainsFinalizer(&hcloudNetwork, finalizerName) && policy.claims() {
 		log.Info("Adding finalizer", "name", hcloudNetwork.Name)
 		controllerutil.AddFinalizer(&hcloudNetwork, finalizerName)
 		if err := r.Update(ctx, &hcloudNetwork); err != nil {
@@ -193,17 +312,68 @@ func (r *HcloudNetworkReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		}
 	}
 
+	// Validate the declared routes before touching the cloud resource
+	if err := validateRoutes(hcloudNetwork.Spec); err != nil {
+		log.Error(err, "Invalid routes in HcloudNetwork spec", "name", hcloudNetwork.Name)
+		meta.SetStatusCondition(&hcloudNetwork.Status.Conditions, metav1.Condition{
+			Type:               "Available",
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: hcloudNetwork.Generation,
+			Reason:             "InvalidRoutes",
+			Message:            err.Error(),
+		})
+		if err := r.Status().Update(ctx, &hcloudNetwork); err != nil {
+			log.Error(err, "Failed to update HcloudNetwork status", "name", hcloudNetwork.Name)
+			return ctrl.Result{}, err
+		}
+		r.Recorder.Eventf(&hcloudNetwork, "Warning", "InvalidRoutes", "Routes of network %s are invalid: %v", hcloudNetwork.Spec.Name, err)
+
+		// The spec has to change before the routes can become valid
+		return ctrl.Result{}, nil
+	}
+
+	// Labels of the provider config are applied below the labels of the resource
+	desiredLabels := provider.labels(hcloudNetwork.Spec.Labels)
+
+	// Follow actions started by an earlier reconcile before comparing the network with the spec
+	if len(hcloudNetwork.Status.PendingActions) > 0 {
+		done, result, err := awaitPendingActions(ctx, r.Client, r.Recorder, &hcloudNetwork, &hcloudNetwork.Status.PendingActions, &hcloudNetwork.Status.Conditions, networkClient.GetAction, provider.actionPollInterval())
+		if !done {
+			return result, err
+		}
+	}
+
+	// A network pinned by the external-id annotation is never looked up by name or created
+	pinnedID, err := externalID(&hcloudNetwork)
+	if err != nil {
+		log.Error(err, "Invalid external ID annotation", "name", hcloudNetwork.Name)
+		meta.SetStatusCondition(&hcloudNetwork.Status.Conditions, metav1.Condition{
+			Type:               "Available",
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: hcloudNetwork.Generation,
+			Reason:             invalidExternalIDReason,
+			Message:            err.Error(),
+		})
+		if err := r.Status().Update(ctx, &hcloudNetwork); err != nil {
+			log.Error(err, "Failed to update HcloudNetwork status", "name", hcloudNetwork.Name)
+			return ctrl.Result{}, err
+		}
+		r.Recorder.Eventf(&hcloudNetwork, "Warning", invalidExternalIDReason, "Cannot adopt network for %s: %v", hcloudNetwork.Name, err)
+
+		// The annotation has to change before the network can be found
+		return ctrl.Result{}, nil
+	}
+
 	// Adopt existing network if it exists
-	log.Info("Checking for existing network in Hetzner Cloud by name", "name", hcloudNetwork.Spec.Name)
-	network, response, err := r.NetworkClient.GetNetworkByName(ctx, hcloudNetwork.Spec.Name)
+	network, response, err := findNetwork(ctx, networkClient, &hcloudNetwork, pinnedID)
 	if err != nil {
-		log.Error(err, "Failed to get network from Hetzner Cloud by name", "name", hcloudNetwork.Spec.Name)
+		log.Error(err, "Failed to get network from Hetzner Cloud", "name", hcloudNetwork.Spec.Name)
 		meta.SetStatusCondition(&hcloudNetwork.Status.Conditions, metav1.Condition{
 			Type:               "Available",
 			Status:             metav1.ConditionFalse,
 			ObservedGeneration: hcloudNetwork.Generation,
 			Reason:             "Failed",
-			Message:            fmt.Sprintf("Failed to get network from Hetzner Cloud by name: %v. %v", err, response),
+			Message:            fmt.Sprintf("Failed to get network from Hetzner Cloud: %v. %v", err, response),
 		})
 		if err := r.Status().Update(ctx, &hcloudNetwork); err != nil {
 			log.Error(err, "Failed to update HcloudNetwork status", "name", hcloudNetwork.Name)
@@ -213,25 +383,90 @@ func (r *HcloudNetworkReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		return ctrl.Result{}, err
 	}
 
+	if network == nil && pinnedID != 0 {
+		log.Info("Network pinned by the external ID not found in Hetzner Cloud", "networkId", pinnedID)
+		meta.SetStatusCondition(&hcloudNetwork.Status.Conditions, metav1.Condition{
+			Type:               "Available",
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: hcloudNetwork.Generation,
+			Reason:             externalResourceNotFoundReason,
+			Message:            fmt.Sprintf("Network %d set by the %s annotation not found in Hetzner Cloud", pinnedID, hcloudv1alpha1.ExternalIDAnnotation),
+		})
+		if err := r.Status().Update(ctx, &hcloudNetwork); err != nil {
+			log.Error(err, "Failed to update HcloudNetwork status", "name", hcloudNetwork.Name)
+			return ctrl.Result{}, err
+		}
+		r.Recorder.Eventf(&hcloudNetwork, "Warning", externalResourceNotFoundReason, "Network %d not found in Hetzner cloud", pinnedID)
+
+		return ctrl.Result{RequeueAfter: resyncAfter(&hcloudNetwork, r.ResyncInterval)}, nil
+	}
+
+	if network != nil && policy.claims() {
+		// A network managed by another resource or cluster is left alone unless it is taken over,
+		// resources whose sync policy does not claim it may still observe it
+		if owner := claimant(network.Labels, &hcloudNetwork, r.ClusterID); owner != "" && !takeover(&hcloudNetwork) {
+			log.Info("Network is already claimed by another resource", "networkId", network.ID, "owner", owner)
+			meta.SetStatusCondition(&hcloudNetwork.Status.Conditions, metav1.Condition{
+				Type:               "Available",
+				Status:             metav1.ConditionFalse,
+				ObservedGeneration: hcloudNetwork.Generation,
+				Reason:             alreadyClaimedReason,
+				Message:            fmt.Sprintf("Network %d is already managed by %s", network.ID, owner),
+			})
+			if err := r.Status().Update(ctx, &hcloudNetwork); err != nil {
+				log.Error(err, "Failed to update HcloudNetwork status", "name", hcloudNetwork.Name)
+				return ctrl.Result{}, err
+			}
+			r.Recorder.Eventf(&hcloudNetwork, "Warning", alreadyClaimedReason, "Network %s (%d) is already managed by %s, set the %s annotation to take it over", network.Name, network.ID, owner, hcloudv1alpha1.TakeoverAnnotation)
+
+			return ctrl.Result{RequeueAfter: resyncAfter(&hcloudNetwork, r.ResyncInterval)}, nil
+		} else if owner != "" {
+			log.Info("Taking over network claimed by another resource", "networkId", network.ID, "owner", owner)
+			r.Recorder.Eventf(&hcloudNetwork, "Warning", takenOverReason, "Network %s (%d) was taken over from %s", network.Name, network.ID, owner)
+		}
+	}
+
 	if network != nil {
 		log.Info("Found existing network in Hetzner Cloud", "networkId", network.ID)
 
+		if network.Name != hcloudNetwork.Spec.Name {
+			log.Info("Network was renamed outside of the operator", "networkId", network.ID, "name", network.Name, "specName", hcloudNetwork.Spec.Name)
+			r.Recorder.Eventf(&hcloudNetwork, "Warning", renamedReason, "Network %d is named %s in Hetzner cloud instead of %s", network.ID, network.Name, hcloudNetwork.Spec.Name)
+		}
+
+		// Fields left to other tools are neither updated nor reported as drift
+		spec := ignoreNetworkFields(hcloudNetwork.Spec, network, policy)
+		if policy.ignores("labels") {
+			desiredLabels = nil
+		}
+
+		// Differences found while the spec is unchanged were made outside of the operator
+		var drift []string
+		if policy.reportsDrift() && (!policy.updates() || hcloudNetwork.Status.ObservedGeneration == hcloudNetwork.Generation) {
+			drift = networkDrift(spec, desiredLabels, network)
+		}
+		if len(drift) > 0 {
+			log.Info("Network drifted from its spec", "networkId", network.ID, "drift", drift)
+			r.Recorder.Eventf(&hcloudNetwork, "Warning", driftedCondition, "Network %s drifted from its spec: %s", hcloudNetwork.Spec.Name, strings.Join(drift, "; "))
+		}
+
 		// Update the existing network if sync policy allows it
-		if hcloudNetwork.Annotations[syncPolicy] != "read-only" {
+		if policy.updates() {
 			// Evaluate if the network spec matches the existing network
 			needsLabelsUpdate := false
 			needsCidrUpdate := false
-			if hcloudNetwork.Spec.IpRange != network.IPRange.String() {
+			if spec.IpRange != network.IPRange.String() {
 				log.Info("Network IP range differs, updating", "current", network.IPRange, "desired", hcloudNetwork.Spec.IpRange)
 				needsCidrUpdate = true
 			}
-			if hcloudNetwork.Spec.Labels != nil && !equality.Semantic.DeepEqual(hcloudNetwork.Spec.Labels, network.Labels) {
-				log.Info("Network labels differ, updating", "current", network.Labels, "desired", hcloudNetwork.Spec.Labels)
+			ownedLabels := withOwnershipLabels(desiredLabels, network.Labels, &hcloudNetwork, r.ClusterID)
+			if !equality.Semantic.DeepEqual(ownedLabels, network.Labels) {
+				log.Info("Network labels differ, updating", "current", network.Labels, "desired", ownedLabels)
 				needsLabelsUpdate = true
 			}
 
 			if needsLabelsUpdate {
-				updatedNetwork, response, err := r.NetworkClient.UpdateNetworkLabels(ctx, network, hcloudNetwork.Spec.Labels)
+				updatedNetwork, response, err := networkClient.UpdateNetworkLabels(ctx, network, ownedLabels)
 				if err != nil {
 					log.Error(err, "Failed to update network labels in Hetzner Cloud", "networkId", network.ID)
 					meta.SetStatusCondition(&hcloudNetwork.Status.Conditions, metav1.Condition{
@@ -251,7 +486,7 @@ func (r *HcloudNetworkReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 				network = updatedNetwork
 			}
 			if needsCidrUpdate {
-				updatedNetwork, response, err := r.NetworkClient.UpdateNetworkCidr(ctx, network, hcloudNetwork.Spec.IpRange)
+				action, response, err := networkClient.UpdateNetworkCidr(ctx, network, hcloudNetwork.Spec.IpRange)
 				if err != nil {
 					log.Error(err, "Failed to update network CIDR in Hetzner Cloud", "networkId", network.ID)
 					meta.SetStatusCondition(&hcloudNetwork.Status.Conditions, metav1.Condition{
@@ -269,20 +504,101 @@ func (r *HcloudNetworkReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 					return ctrl.Result{}, err
 				}
 
-				network = updatedNetwork
+				// Subnets and routes may depend on the new IP range, they are reconciled once the action completed
+				if actionPending(action) {
+					log.Info("Started changing network IP range in Hetzner Cloud", "networkId", network.ID, "actionId", action.ID)
+					hcloudNetwork.Status.NetworkId = int(network.ID)
+					recordPendingAction(&hcloudNetwork.Status.PendingActions, &hcloudNetwork.Status.Conditions, hcloudNetwork.Generation, action)
+					setDriftCondition(&hcloudNetwork.Status.Conditions, hcloudNetwork.Generation, drift, true)
+					if err := r.Status().Update(ctx, &hcloudNetwork); err != nil {
+						log.Error(err, "Failed to update HcloudNetwork status", "name", hcloudNetwork.Name)
+						return ctrl.Result{}, err
+					}
+					r.Recorder.Eventf(&hcloudNetwork, "Normal", "Progressing", "Changing IP range of network %s to %s", hcloudNetwork.Spec.Name, hcloudNetwork.Spec.IpRange)
+
+					return ctrl.Result{RequeueAfter: provider.actionPollInterval()}, nil
+				}
+
+				updatedNetwork, response, err := networkClient.GetNetworkById(ctx, network.ID)
+				if err != nil {
+					log.Error(err, "Failed to get network from Hetzner Cloud", "networkId", network.ID)
+					meta.SetStatusCondition(&hcloudNetwork.Status.Conditions, metav1.Condition{
+						Type:               "Available",
+						Status:             metav1.ConditionFalse,
+						ObservedGeneration: hcloudNetwork.Generation,
+						Reason:             "Failed",
+						Message:            fmt.Sprintf("Failed to get network from Hetzner Cloud: %v. %v", err, response),
+					})
+					if err := r.Status().Update(ctx, &hcloudNetwork); err != nil {
+						log.Error(err, "Failed to update HcloudNetwork status", "name", hcloudNetwork.Name)
+					}
+					r.Recorder.Eventf(&hcloudNetwork, "Warning", "UpdateFailed", "Failed to get network %s from Hetzner cloud", hcloudNetwork.Spec.Name)
+
+					return ctrl.Result{}, err
+				}
+				if updatedNetwork != nil {
+					network = updatedNetwork
+				}
 				log.Info("Successfully updated network in Hetzner Cloud", "networkId", network.ID)
 			}
+			if spec.Subnets != nil {
+				updatedNetwork, reason, err := r.reconcileSubnets(ctx, networkClient, &hcloudNetwork, network)
+				if err != nil {
+					log.Error(err, "Failed to reconcile network subnets in Hetzner Cloud", "networkId", network.ID)
+					meta.SetStatusCondition(&hcloudNetwork.Status.Conditions, metav1.Condition{
+						Type:               "Available",
+						Status:             metav1.ConditionFalse,
+						ObservedGeneration: hcloudNetwork.Generation,
+						Reason:             reason,
+						Message:            fmt.Sprintf("Failed to reconcile network subnets in Hetzner Cloud: %v", err),
+					})
+					if err := r.Status().Update(ctx, &hcloudNetwork); err != nil {
+						log.Error(err, "Failed to update HcloudNetwork status", "name", hcloudNetwork.Name)
+					}
+					r.Recorder.Eventf(&hcloudNetwork, "Warning", reason, "Failed to reconcile subnets of network %s: %v", hcloudNetwork.Spec.Name, err)
+
+					return ctrl.Result{}, err
+				}
+				network = updatedNetwork
+			}
+			if spec.Routes != nil {
+				updatedNetwork, err := r.reconcileRoutes(ctx, networkClient, &hcloudNetwork, network)
+				if err != nil {
+					log.Error(err, "Failed to reconcile network routes in Hetzner Cloud", "networkId", network.ID)
+					meta.SetStatusCondition(&hcloudNetwork.Status.Conditions, metav1.Condition{
+						Type:               "Available",
+						Status:             metav1.ConditionFalse,
+						ObservedGeneration: hcloudNetwork.Generation,
+						Reason:             "Failed",
+						Message:            fmt.Sprintf("Failed to reconcile network routes in Hetzner Cloud: %v", err),
+					})
+					if err := r.Status().Update(ctx, &hcloudNetwork); err != nil {
+						log.Error(err, "Failed to update HcloudNetwork status", "name", hcloudNetwork.Name)
+					}
+					r.Recorder.Eventf(&hcloudNetwork, "Warning", "Failed", "Failed to reconcile routes of network %s: %v", hcloudNetwork.Spec.Name, err)
+
+					return ctrl.Result{}, err
+				}
+				network = updatedNetwork
+			}
 			if !needsLabelsUpdate && !needsCidrUpdate {
 				log.Info("No updates required for existing network", "networkId", network.ID)
 			}
 		} else {
-			log.Info("Sync policy is read-only; skipping updates to existing network", "networkId", network.ID)
+			log.Info("Sync policy does not update existing networks; skipping updates", "networkId", network.ID, "syncPolicy", policy.mode)
 		}
 
 		// Update the resource status with the network details and conditions
 		hcloudNetwork.Status.NetworkId = int(network.ID)
 		hcloudNetwork.Status.IpRange = network.IPRange.String()
-		hcloudNetwork.Status.Labels = network.Labels
+		hcloudNetwork.Status.Labels = withoutOwnershipLabels(network.Labels)
+		hcloudNetwork.Status.Subnets = subnetStatuses(network.Subnets)
+		hcloudNetwork.Status.Routes = routeStatuses(network.Routes)
+		if policy.reportsDrift() {
+			setDriftCondition(&hcloudNetwork.Status.Conditions, hcloudNetwork.Generation, drift, policy.updates())
+		} else {
+			meta.RemoveStatusCondition(&hcloudNetwork.Status.Conditions, driftedCondition)
+		}
 		meta.SetStatusCondition(&hcloudNetwork.Status.Conditions, metav1.Condition{
 			Type:               "Available",
 			Status:             metav1.ConditionTrue,
@@ -299,10 +615,10 @@ func (r *HcloudNetworkReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 
 		r.Recorder.Eventf(&hcloudNetwork, "Normal", "Ready", "HcloudNetwork updated %d", hcloudNetwork.Status.NetworkId)
 
-	} else if hcloudNetwork.Annotations[syncPolicy] != "read-only" {
+	} else if policy.creates() {
 		log.Info("Network not found in Hetzner Cloud, creating new network", "name", hcloudNetwork.Spec.Name)
 
-		network, response, err := r.NetworkClient.CreateNetwork(ctx, hcloudNetwork.Spec.Name, hcloudNetwork.Spec.IpRange, hcloudNetwork.Spec.Labels)
+		network, response, err := networkClient.CreateNetwork(ctx, hcloudNetwork.Spec.Name, hcloudNetwork.Spec.IpRange, withOwnershipLabels(desiredLabels, nil, &hcloudNetwork, r.ClusterID))
 		if err != nil {
 			log.Error(err, "Failed to create network in Hetzner Cloud", "name", hcloudNetwork.Spec.Name)
 			meta.SetStatusCondition(&hcloudNetwork.Status.Conditions, metav1.Condition{
@@ -322,9 +638,55 @@ func (r *HcloudNetworkReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 
 		log.Info("Successfully created network in Hetzner Cloud", "networkId", network.ID)
 
+		if len(hcloudNetwork.Spec.Subnets) > 0 {
+			updatedNetwork, reason, err := r.reconcileSubnets(ctx, networkClient, &hcloudNetwork, network)
+			if err != nil {
+				log.Error(err, "Failed to add subnets to network in Hetzner Cloud", "networkId", network.ID)
+				hcloudNetwork.Status.NetworkId = int(network.ID)
+				meta.SetStatusCondition(&hcloudNetwork.Status.Conditions, metav1.Condition{
+					Type:               "Available",
+					Status:             metav1.ConditionFalse,
+					ObservedGeneration: hcloudNetwork.Generation,
+					Reason:             reason,
+					Message:            fmt.Sprintf("Failed to add subnets to network in Hetzner Cloud: %v", err),
+				})
+				if err := r.Status().Update(ctx, &hcloudNetwork); err != nil {
+					log.Error(err, "Failed to update HcloudNetwork status", "name", hcloudNetwork.Name)
+				}
+				r.Recorder.Eventf(&hcloudNetwork, "Warning", reason, "Failed to add subnets to network %s: %v", hcloudNetwork.Spec.Name, err)
+
+				return ctrl.Result{}, err
+			}
+			network = updatedNetwork
+		}
+
+		if len(hcloudNetwork.Spec.Routes) > 0 {
+			updatedNetwork, err := r.reconcileRoutes(ctx, networkClient, &hcloudNetwork, network)
+			if err != nil {
+				log.Error(err, "Failed to add routes to network in Hetzner Cloud", "networkId", network.ID)
+				hcloudNetwork.Status.NetworkId = int(network.ID)
+				meta.SetStatusCondition(&hcloudNetwork.Status.Conditions, metav1.Condition{
+					Type:               "Available",
+					Status:             metav1.ConditionFalse,
+					ObservedGeneration: hcloudNetwork.Generation,
+					Reason:             "Failed",
+					Message:            fmt.Sprintf("Failed to add routes to network in Hetzner Cloud: %v", err),
+				})
+				if err := r.Status().Update(ctx, &hcloudNetwork); err != nil {
+					log.Error(err, "Failed to update HcloudNetwork status", "name", hcloudNetwork.Name)
+				}
+				r.Recorder.Eventf(&hcloudNetwork, "Warning", "Failed", "Failed to add routes to network %s: %v", hcloudNetwork.Spec.Name, err)
+
+				return ctrl.Result{}, err
+			}
+			network = updatedNetwork
+		}
+
 		hcloudNetwork.Status.NetworkId = int(network.ID)
 		hcloudNetwork.Status.IpRange = network.IPRange.String()
-		hcloudNetwork.Status.Labels = network.Labels
+		hcloudNetwork.Status.Labels = withoutOwnershipLabels(network.Labels)
+		hcloudNetwork.Status.Subnets = subnetStatuses(network.Subnets)
+		hcloudNetwork.Status.Routes = routeStatuses(network.Routes)
 		meta.SetStatusCondition(&hcloudNetwork.Status.Conditions, metav1.Condition{
 			Type:               "Available",
 			Status:             metav1.ConditionTrue,
@@ -341,13 +703,13 @@ func (r *HcloudNetworkReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 
 		r.Recorder.Eventf(&hcloudNetwork, "Normal", "Ready", "HcloudNetwork created %d", hcloudNetwork.Status.NetworkId)
 	} else {
-		log.Info("Network not found in Hetzner Cloud and sync policy is read-only; skipping creation", "name", hcloudNetwork.Spec.Name)
+		log.Info("Network not found in Hetzner Cloud and sync policy does not create it; skipping creation", "name", hcloudNetwork.Spec.Name, "syncPolicy", policy.mode)
 		meta.SetStatusCondition(&hcloudNetwork.Status.Conditions, metav1.Condition{
 			Type:               "Available",
 			Status:             metav1.ConditionFalse,
 			ObservedGeneration: hcloudNetwork.Generation,
 			Reason:             "Failed",
-			Message:            "Network not found in Hetzner Cloud and sync policy is read-only",
+			Message:            fmt.Sprintf("Network not found in Hetzner Cloud and sync policy is %s", policy.mode),
 		})
 		if err := r.Status().Update(ctx, &hcloudNetwork); err != nil {
 			log.Error(err, "Failed to update HcloudNetwork status", "name", hcloudNetwork.Name)
@@ -357,7 +719,392 @@ func (r *HcloudNetworkReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 	}
 
 	log.Info("HcloudNetwork resource reconciled successfully", "name", hcloudNetwork.Name)
-	return ctrl.Result{}, nil
+	return ctrl.Result{RequeueAfter: resyncAfter(&hcloudNetwork, r.ResyncInterval)}, nil
+}
+
+// releaseNetwork removes the ownership label from an orphaned network, so that it can be adopted
+// by another resource. Failures are reported and returned, so that the finalizer is kept and the
+// orphan collector does not delete the still claimed network.
+func (r *HcloudNetworkReconciler) releaseNetwork(ctx context.Context, hcloudNetwork *hcloudv1alpha1.HcloudNetwork) error {
+	log := logf.Log.WithName("hcloudnetwork-controller")
+
+	networkClient, _, err := r.networkClientFor(ctx, hcloudNetwork)
+	if err == nil {
+		var network *hcloudgo.Network
+		network, _, err = networkClient.GetNetworkById(ctx, int64(hcloudNetwork.Status.NetworkId))
+		if err == nil && network != nil && network.Labels[hcloudv1alpha1.OwnerUIDLabel] == string(hcloudNetwork.UID) {
+			labels := withoutOwnershipLabels(network.Labels)
+			if labels == nil {
+				labels = map[string]string{}
+			}
+			_, _, err = networkClient.UpdateNetworkLabels(ctx, network, labels)
+		}
+	}
+	if err != nil {
+		log.Error(err, "Failed to release orphaned network", "networkId", hcloudNetwork.Status.NetworkId)
+		r.Recorder.Eventf(hcloudNetwork, "Warning", "ReleaseFailed", "Failed to remove the ownership label from network %d: %v", hcloudNetwork.Status.NetworkId, err)
+	}
+	return err
+}
+
+// findNetwork looks up the Hetzner Cloud network of the HcloudNetwork. A network pinned by the
+// external-id annotation is only looked up by its ID. Otherwise the network found by an earlier
+// reconcile is looked up by the ID in the status, so that a rename outside of the operator does not
+// lead to a duplicate network, and the network is looked up by name when it is not known yet or
+// no longer exists.
+func findNetwork(ctx context.Context, networkClient hcloud.NetworkClient, hcloudNetwork *hcloudv1alpha1.HcloudNetwork, pinnedID int64) (*hcloudgo.Network, *hcloudgo.Response, error) {
+	log := logf.Log.WithName("hcloudnetwork-controller")
+
+	if pinnedID != 0 {
+		log.Info("Checking for existing network in Hetzner Cloud by external ID", "networkId", pinnedID)
+		return networkClient.GetNetworkById(ctx, pinnedID)
+	}
+	if hcloudNetwork.Status.NetworkId != 0 {
+		log.Info("Checking for existing network in Hetzner Cloud by ID", "networkId", hcloudNetwork.Status.NetworkId)
+		network, response, err := networkClient.GetNetworkById(ctx, int64(hcloudNetwork.Status.NetworkId))
+		if err != nil || network != nil {
+			return network, response, err
+		}
+		log.Info("Network no longer exists in Hetzner Cloud", "networkId", hcloudNetwork.Status.NetworkId)
+	}
+	log.Info("Checking for existing network in Hetzner Cloud by name", "name", hcloudNetwork.Spec.Name)
+	return networkClient.GetNetworkByName(ctx, hcloudNetwork.Spec.Name)
+}
+
+// ignoreNetworkFields returns the spec with the fields the sync policy leaves to other tools taken
+// from the live network, or cleared where an empty field is not reconciled
+func ignoreNetworkFields(spec hcloudv1alpha1.HcloudNetworkSpec, network *hcloudgo.Network, policy effectiveSyncPolicy) hcloudv1alpha1.HcloudNetworkSpec {
+	if policy.ignores("ipRange") {
+		spec.IpRange = network.IPRange.String()
+	}
+	if policy.ignores("labels") {
+		spec.Labels = nil
+	}
+	if policy.ignores("subnets") {
+		spec.Subnets = nil
+	}
+	if policy.ignores("routes") {
+		spec.Routes = nil
+	}
+	return spec
+}
+
+// reconcileSubnets converges the subnets of the Hetzner Cloud network towards spec.subnets.
+// Subnets are matched by IP range and a subnet whose type, network zone or vSwitch differs is
+// replaced. Subnets that still have servers attached are never removed, the returned reason
+// is SubnetInUse in that case.
+func (r *HcloudNetworkReconciler) reconcileSubnets(ctx context.Context, networkClient hcloud.NetworkClient, hcloudNetwork *hcloudv1alpha1.HcloudNetwork, network *hcloudgo.Network) (*hcloudgo.Network, string, error) {
+	log := logf.Log.WithName("hcloudnetwork-controller")
+
+	desired := make(map[string]hcloudgo.NetworkSubnet, len(hcloudNetwork.Spec.Subnets))
+	desiredOrder := make([]string, 0, len(hcloudNetwork.Spec.Subnets))
+	for _, subnet := range hcloudNetwork.Spec.Subnets {
+		_, ipRange, err := net.ParseCIDR(subnet.IpRange)
+		if err != nil {
+			return network, "Failed", fmt.Errorf("invalid subnet ip range %q: %w", subnet.IpRange, err)
+		}
+		desired[ipRange.String()] = hcloudgo.NetworkSubnet{
+			Type:        hcloudgo.NetworkSubnetType(subnet.Type),
+			IPRange:     ipRange,
+			NetworkZone: hcloudgo.NetworkZone(subnet.NetworkZone),
+			VSwitchID:   int64(subnet.VSwitchId),
+		}
+		desiredOrder = append(desiredOrder, ipRange.String())
+	}
+
+	// Remove subnets that are no longer desired or whose attributes changed
+	var servers []*hcloudgo.Server
+	serversLoaded := false
+	for _, subnet := range network.Subnets {
+		if want, ok := desired[subnet.IPRange.String()]; ok && subnetMatches(want, subnet) {
+			continue
+		}
+		if !serversLoaded {
+			var err error
+			servers, err = networkClient.ListNetworkServers(ctx, network)
+			if err != nil {
+				return network, "Failed", fmt.Errorf("failed to list servers attached to network: %w", err)
+			}
+			serversLoaded = true
+		}
+		if attached := serversInSubnet(servers, network.ID, subnet); len(attached) > 0 {
+			return network, "SubnetInUse", fmt.Errorf("subnet %s still has attached servers: %s", subnet.IPRange, strings.Join(attached, ", "))
+		}
+
+		log.Info("Removing subnet from network", "networkId", network.ID, "ipRange", subnet.IPRange.String())
+		updatedNetwork, response, err := networkClient.DeleteNetworkSubnet(ctx, network, subnet)
+		if err != nil {
+			return network, "Failed", fmt.Errorf("failed to delete subnet %s: %w. %v", subnet.IPRange, err, response)
+		}
+		network = updatedNetwork
+	}
+
+	// Add desired subnets that do not exist yet
+	existing := make(map[string]bool, len(network.Subnets))
+	for _, subnet := range network.Subnets {
+		existing[subnet.IPRange.String()] = true
+	}
+	for _, key := range desiredOrder {
+		if existing[key] {
+			continue
+		}
+		log.Info("Adding subnet to network", "networkId", network.ID, "ipRange", key)
+		updatedNetwork, response, err := networkClient.AddNetworkSubnet(ctx, network, desired[key])
+		if err != nil {
+			return network, "Failed", fmt.Errorf("failed to add subnet %s: %w. %v", key, err, response)
+		}
+		network = updatedNetwork
+	}
+
+	return network, "", nil
+}
+
+// subnetMatches reports whether an existing subnet satisfies the desired subnet
+func subnetMatches(desired, actual hcloudgo.NetworkSubnet) bool {
+	if desired.Type != actual.Type || desired.NetworkZone != actual.NetworkZone {
+		return false
+	}
+	return desired.Type != hcloudgo.NetworkSubnetTypeVSwitch || desired.VSwitchID == actual.VSwitchID
+}
+
+// serversInSubnet returns the names of the servers whose private IP in the network lies inside the subnet
+func serversInSubnet(servers []*hcloudgo.Server, networkId int64, subnet hcloudgo.NetworkSubnet) []string {
+	var names []string
+	for _, server := range servers {
+		for _, privateNet := range server.PrivateNet {
+			if privateNet.Network != nil && privateNet.Network.ID == networkId && subnet.IPRange.Contains(privateNet.IP) {
+				names = append(names, server.Name)
+				break
+			}
+		}
+	}
+	return names
+}
+
+// subnetStatuses converts the subnets of a Hetzner Cloud network into their status representation
+func subnetStatuses(subnets []hcloudgo.NetworkSubnet) []hcloudv1alpha1.HcloudNetworkSubnetStatus {
+	if len(subnets) == 0 {
+		return nil
+	}
+	statuses := make([]hcloudv1alpha1.HcloudNetworkSubnetStatus, 0, len(subnets))
+	for _, subnet := range subnets {
+		status := hcloudv1alpha1.HcloudNetworkSubnetStatus{
+			Type:        string(subnet.Type),
+			NetworkZone: string(subnet.NetworkZone),
+			VSwitchId:   int(subnet.VSwitchID),
+		}
+		if subnet.IPRange != nil {
+			status.IpRange = subnet.IPRange.String()
+		}
+		if subnet.Gateway != nil {
+			status.Gateway = subnet.Gateway.String()
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+// validateRoutes checks that every route gateway lies inside the network IP range and
+// that no two route destinations overlap
+func validateRoutes(spec hcloudv1alpha1.HcloudNetworkSpec) error {
+	if len(spec.Routes) == 0 {
+		return nil
+	}
+	_, networkRange, err := net.ParseCIDR(spec.IpRange)
+	if err != nil {
+		return fmt.Errorf("invalid network ip range %q: %w", spec.IpRange, err)
+	}
+
+	destinations := make([]*net.IPNet, 0, len(spec.Routes))
+	for _, route := range spec.Routes {
+		_, destination, err := net.ParseCIDR(route.Destination)
+		if err != nil {
+			return fmt.Errorf("invalid route destination %q: %w", route.Destination, err)
+		}
+		gateway := net.ParseIP(route.Gateway)
+		if gateway == nil {
+			return fmt.Errorf("invalid gateway %q for route %s", route.Gateway, route.Destination)
+		}
+		if !networkRange.Contains(gateway) {
+			return fmt.Errorf("gateway %s of route %s is outside of the network ip range %s", route.Gateway, route.Destination, spec.IpRange)
+		}
+		for _, other := range destinations {
+			if other.Contains(destination.IP) || destination.Contains(other.IP) {
+				return fmt.Errorf("route destination %s overlaps with %s", destination, other)
+			}
+		}
+		destinations = append(destinations, destination)
+	}
+	return nil
+}
+
+// reconcileRoutes converges the static routes of the Hetzner Cloud network towards spec.routes.
+// Routes are matched by destination and a route whose gateway differs is replaced.
+func (r *HcloudNetworkReconciler) reconcileRoutes(ctx context.Context, networkClient hcloud.NetworkClient, hcloudNetwork *hcloudv1alpha1.HcloudNetwork, network *hcloudgo.Network) (*hcloudgo.Network, error) {
+	log := logf.Log.WithName("hcloudnetwork-controller")
+
+	desired := make(map[string]hcloudgo.NetworkRoute, len(hcloudNetwork.Spec.Routes))
+	desiredOrder := make([]string, 0, len(hcloudNetwork.Spec.Routes))
+	for _, route := range hcloudNetwork.Spec.Routes {
+		_, destination, err := net.ParseCIDR(route.Destination)
+		if err != nil {
+			return network, fmt.Errorf("invalid route destination %q: %w", route.Destination, err)
+		}
+		desired[destination.String()] = hcloudgo.NetworkRoute{
+			Destination: destination,
+			Gateway:     net.ParseIP(route.Gateway),
+		}
+		desiredOrder = append(desiredOrder, destination.String())
+	}
+
+	// Remove routes that are no longer desired or whose gateway changed
+	for _, route := range network.Routes {
+		if want, ok := desired[route.Destination.String()]; ok && want.Gateway.Equal(route.Gateway) {
+			continue
+		}
+		log.Info("Removing route from network", "networkId", network.ID, "destination", route.Destination.String(), "gateway", route.Gateway.String())
+		updatedNetwork, response, err := networkClient.DeleteNetworkRoute(ctx, network, route)
+		if err != nil {
+			return network, fmt.Errorf("failed to delete route %s: %w. %v", route.Destination, err, response)
+		}
+		network = updatedNetwork
+	}
+
+	// Add desired routes that do not exist yet
+	existing := make(map[string]bool, len(network.Routes))
+	for _, route := range network.Routes {
+		existing[route.Destination.String()] = true
+	}
+	for _, key := range desiredOrder {
+		if existing[key] {
+			continue
+		}
+		log.Info("Adding route to network", "networkId", network.ID, "destination", key, "gateway", desired[key].Gateway.String())
+		updatedNetwork, response, err := networkClient.AddNetworkRoute(ctx, network, desired[key])
+		if err != nil {
+			return network, fmt.Errorf("failed to add route %s: %w. %v", key, err, response)
+		}
+		network = updatedNetwork
+	}
+
+	return network, nil
+}
+
+// routeStatuses converts the routes of a Hetzner Cloud network into their status representation
+func routeStatuses(routes []hcloudgo.NetworkRoute) []hcloudv1alpha1.HcloudNetworkRoute {
+	if len(routes) == 0 {
+		return nil
+	}
+	statuses := make([]hcloudv1alpha1.HcloudNetworkRoute, 0, len(routes))
+	for _, route := range routes {
+		status := hcloudv1alpha1.HcloudNetworkRoute{}
+		if route.Destination != nil {
+			status.Destination = route.Destination.String()
+		}
+		if route.Gateway != nil {
+			status.Gateway = route.Gateway.String()
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+// networkDrift returns the fields in which the Hetzner Cloud network differs from the spec.
+// Subnets and routes are only compared when the spec declares them, an empty list included.
+func networkDrift(spec hcloudv1alpha1.HcloudNetworkSpec, desiredLabels map[string]string, network *hcloudgo.Network) []string {
+	var drift []string
+	if spec.IpRange != network.IPRange.String() {
+		drift = append(drift, driftEntry("ipRange", network.IPRange.String(), spec.IpRange))
+	}
+	if liveLabels := withoutOwnershipLabels(network.Labels); desiredLabels != nil && !equality.Semantic.DeepEqual(desiredLabels, liveLabels) {
+		drift = append(drift, labelsDrift(liveLabels, desiredLabels)...)
+	}
+
+	if spec.Subnets != nil {
+		live := make(map[string]hcloudgo.NetworkSubnet, len(network.Subnets))
+		for _, subnet := range network.Subnets {
+			live[subnet.IPRange.String()] = subnet
+		}
+		desired := make(map[string]bool, len(spec.Subnets))
+		for _, subnet := range spec.Subnets {
+			_, ipRange, err := net.ParseCIDR(subnet.IpRange)
+			if err != nil {
+				continue
+			}
+			want := hcloudgo.NetworkSubnet{
+				Type:        hcloudgo.NetworkSubnetType(subnet.Type),
+				NetworkZone: hcloudgo.NetworkZone(subnet.NetworkZone),
+				VSwitchID:   int64(subnet.VSwitchId),
+			}
+			desired[ipRange.String()] = true
+			if actual, ok := live[ipRange.String()]; !ok {
+				drift = append(drift, driftEntry("subnets["+ipRange.String()+"]", "", describeSubnet(want)))
+			} else if !subnetMatches(want, actual) {
+				drift = append(drift, driftEntry("subnets["+ipRange.String()+"]", describeSubnet(actual), describeSubnet(want)))
+			}
+		}
+		for _, subnet := range network.Subnets {
+			if !desired[subnet.IPRange.String()] {
+				drift = append(drift, driftEntry("subnets["+subnet.IPRange.String()+"]", describeSubnet(subnet), ""))
+			}
+		}
+	}
+
+	if spec.Routes != nil {
+		live := make(map[string]string, len(network.Routes))
+		for _, route := range network.Routes {
+			live[route.Destination.String()] = route.Gateway.String()
+		}
+		desired := make(map[string]bool, len(spec.Routes))
+		for _, route := range spec.Routes {
+			_, destination, err := net.ParseCIDR(route.Destination)
+			if err != nil {
+				continue
+			}
+			desired[destination.String()] = true
+			if gateway := net.ParseIP(route.Gateway); gateway == nil || live[destination.String()] != gateway.String() {
+				drift = append(drift, driftEntry("routes["+destination.String()+"]", live[destination.String()], route.Gateway))
+			}
+		}
+		for _, route := range network.Routes {
+			if !desired[route.Destination.String()] {
+				drift = append(drift, driftEntry("routes["+route.Destination.String()+"]", route.Gateway.String(), ""))
+			}
+		}
+	}
+
+	return drift
+}
+
+// describeSubnet formats the attributes of a subnet compared by subnetMatches
+func describeSubnet(subnet hcloudgo.NetworkSubnet) string {
+	if subnet.Type == hcloudgo.NetworkSubnetTypeVSwitch {
+		return fmt.Sprintf("%s/%s/%d", subnet.Type, subnet.NetworkZone, subnet.VSwitchID)
+	}
+	return fmt.Sprintf("%s/%s", subnet.Type, subnet.NetworkZone)
+}
+
+// networkClientFor returns the Hetzner Cloud client for the HcloudNetwork together with the resolved provider.
+// The client is built from the credentials of the resource or its provider config, or is the client
+// configured for the manager when neither is set.
+func (r *HcloudNetworkReconciler) networkClientFor(ctx context.Context, hcloudNetwork *hcloudv1alpha1.HcloudNetwork) (hcloud.NetworkClient, *hcloudProvider, error) {
+	credentialsRef := hcloudNetwork.Spec.CredentialsRef
+	providerConfigRef := hcloudNetwork.Spec.ProviderConfigRef
+
+	provider, err := resolveProvider(ctx, r.Client, r.DefaultProviderConfig, hcloudNetwork.Namespace, credentialsRef, providerConfigRef)
+	if err != nil {
+		return nil, nil, err
+	}
+	if provider.token == "" {
+		if r.NetworkClient == nil {
+			return nil, nil, fmt.Errorf("no credentials configured for the resource and no Hetzner Cloud token configured for the manager")
+		}
+		return r.NetworkClient, provider, nil
+	}
+	if r.ClientCache == nil {
+		return nil, nil, fmt.Errorf("no client cache configured to resolve credentials")
+	}
+	return r.ClientCache.NetworkClient(provider.cacheKey, provider.cacheVersion, provider.token, provider.options...), provider, nil
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -365,6 +1112,6 @@ func (r *HcloudNetworkReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&hcloudv1alpha1.HcloudNetwork{}).
 		Named("hcloudnetwork").
-		WithEventFilter(predicate.GenerationChangedPredicate{}).
+		WithEventFilter(predicate.Or(predicate.GenerationChangedPredicate{}, pausedAnnotationChanged)).
 		Complete(r)
 }
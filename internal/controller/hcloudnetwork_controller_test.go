@@ -20,9 +20,11 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/types"
@@ -33,6 +35,7 @@ import (
 	hcloudv1alpha1 "bunskin.com/hcrm/api/v1alpha1"
 	"bunskin.com/hcrm/pkg/hcloud"
 	hcloudgo "github.com/hetznercloud/hcloud-go/v2/hcloud"
+	"github.com/hetznercloud/hcloud-go/v2/hcloud/schema"
 )
 
 var _ = Describe("HcloudNetwork Controller", func() {
@@ -311,12 +314,26 @@ var _ = Describe("HcloudNetwork Controller", func() {
 			MockNetworkClient.GetNetworkByNameFunc = func(ctx context.Context, name string) (*hcloudgo.Network, *hcloudgo.Response, error) {
 				return existingNetwork, nil, nil
 			}
+			MockNetworkClient.UpdateNetworkLabelsFunc = func(ctx context.Context, network *hcloudgo.Network, labels map[string]string) (*hcloudgo.Network, *hcloudgo.Response, error) {
+				updated := *network
+				updated.Labels = labels
+				return &updated, nil, nil
+			}
 			MockNetworkClient.GetNetworkByIdFunc = func(ctx context.Context, id int64) (*hcloudgo.Network, *hcloudgo.Response, error) {
 				return existingNetwork, nil, nil
 			}
-			MockNetworkClient.UpdateNetworkCidrFunc = func(ctx context.Context, network *hcloudgo.Network, cidr string) (*hcloudgo.Network, *hcloudgo.Response, error) {
-				existingNetwork.IPRange = newCidr
-				return existingNetwork, nil, nil
+			action := &hcloudgo.Action{
+				ID:       777,
+				Status:   hcloudgo.ActionStatusRunning,
+				Command:  "change_ip_range",
+				Progress: 40,
+				Started:  time.Now(),
+			}
+			MockNetworkClient.UpdateNetworkCidrFunc = func(ctx context.Context, network *hcloudgo.Network, cidr string) (*hcloudgo.Action, *hcloudgo.Response, error) {
+				return action, nil, nil
+			}
+			MockNetworkClient.GetActionFunc = func(ctx context.Context, id int64) (*hcloudgo.Action, *hcloudgo.Response, error) {
+				return action, nil, nil
 			}
 
 			client := hcloud.NetworkClient(MockNetworkClient)
@@ -329,23 +346,55 @@ var _ = Describe("HcloudNetwork Controller", func() {
 				Recorder:      recorder,
 			}
 
-			_, err := reconciler.Reconcile(ctx, reconcile.Request{
+			result, err := reconciler.Reconcile(ctx, reconcile.Request{
 				NamespacedName: typeNamespacedName,
 			})
 			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(defaultActionPollInterval))
 
-			By("verifying the resource status was updated")
+			By("verifying the pending action was recorded")
 			updatedResource := &hcloudv1alpha1.HcloudNetwork{}
 			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			Expect(updatedResource.Status.PendingActions).To(HaveLen(1))
+			Expect(updatedResource.Status.PendingActions[0].Id).To(Equal(int64(777)))
+			Expect(updatedResource.Status.PendingActions[0].Command).To(Equal("change_ip_range"))
+			condition := meta.FindStatusCondition(updatedResource.Status.Conditions, progressingCondition)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(condition.Message).To(ContainSubstring("40%"))
+
+			By("polling the running action")
+			action.Progress = 80
+			result, err = reconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(defaultActionPollInterval))
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			Expect(updatedResource.Status.PendingActions[0].Progress).To(Equal(80))
+
+			By("completing the action")
+			action.Status = hcloudgo.ActionStatusSuccess
+			existingNetwork.IPRange = newCidr
+			_, err = reconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			By("verifying the resource status was updated")
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
 			Expect(updatedResource.Status.IpRange).To(Equal(resource.Spec.IpRange))
 			Expect(updatedResource.Status.Labels).To(Equal(resource.Spec.Labels))
+			Expect(updatedResource.Status.PendingActions).To(BeEmpty())
 
-			By("verifying the Available condition is still true")
-			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
-			condition := meta.FindStatusCondition(updatedResource.Status.Conditions, "Available")
+			By("verifying the Available condition is true")
+			condition = meta.FindStatusCondition(updatedResource.Status.Conditions, "Available")
 			Expect(condition).NotTo(BeNil())
 			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
 			Expect(condition.Reason).To(Equal("Ready"))
+			condition = meta.FindStatusCondition(updatedResource.Status.Conditions, progressingCondition)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
 
 			By("cleaning up the resource")
 			Expect(k8sClient.Delete(ctx, updatedResource)).To(Succeed())
@@ -383,10 +432,15 @@ var _ = Describe("HcloudNetwork Controller", func() {
 			MockNetworkClient.GetNetworkByNameFunc = func(ctx context.Context, name string) (*hcloudgo.Network, *hcloudgo.Response, error) {
 				return existingNetwork, nil, nil
 			}
+			MockNetworkClient.UpdateNetworkLabelsFunc = func(ctx context.Context, network *hcloudgo.Network, labels map[string]string) (*hcloudgo.Network, *hcloudgo.Response, error) {
+				updated := *network
+				updated.Labels = labels
+				return &updated, nil, nil
+			}
 			MockNetworkClient.GetNetworkByIdFunc = func(ctx context.Context, id int64) (*hcloudgo.Network, *hcloudgo.Response, error) {
 				return existingNetwork, nil, nil
 			}
-			MockNetworkClient.UpdateNetworkCidrFunc = func(ctx context.Context, network *hcloudgo.Network, cidr string) (*hcloudgo.Network, *hcloudgo.Response, error) {
+			MockNetworkClient.UpdateNetworkCidrFunc = func(ctx context.Context, network *hcloudgo.Network, cidr string) (*hcloudgo.Action, *hcloudgo.Response, error) {
 				return nil, nil, fmt.Errorf("API error: cannot update network CIDR")
 			}
 
@@ -688,6 +742,67 @@ var _ = Describe("HcloudNetwork Controller", func() {
 			Expect(errors.IsNotFound(err)).To(BeTrue())
 		})
 
+		It("should leave a network taken over by another resource when deleted", func() {
+			const resourceName = "test-delete-taken-over"
+			typeNamespacedName := types.NamespacedName{
+				Name:      resourceName,
+				Namespace: namespace,
+			}
+
+			By("creating the HcloudNetwork resource that used to manage the network")
+			resource := &hcloudv1alpha1.HcloudNetwork{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       resourceName,
+					Namespace:  namespace,
+					Finalizers: []string{finalizerName},
+				},
+				Spec: hcloudv1alpha1.HcloudNetworkSpec{
+					Name:    resourceName,
+					IpRange: "10.0.0.0/8",
+					Labels:  map[string]string{},
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+			resource.Status.NetworkId = 99999
+			Expect(k8sClient.Status().Update(ctx, resource)).To(Succeed())
+
+			By("labelling the network as taken over by another resource")
+			MockNetworkClient := &hcloud.MockNetworkClient{}
+			MockNetworkClient.GetNetworkByIdFunc = func(ctx context.Context, id int64) (*hcloudgo.Network, *hcloudgo.Response, error) {
+				return &hcloudgo.Network{ID: id, Name: resourceName, Labels: map[string]string{
+					hcloudv1alpha1.ClusterIDLabel: "cluster-a",
+					hcloudv1alpha1.NamespaceLabel: "other",
+					hcloudv1alpha1.NameLabel:      "new-owner",
+					hcloudv1alpha1.OwnerUIDLabel:  "new-owner-uid",
+				}}, nil, nil
+			}
+			MockNetworkClient.DeleteNetworkFunc = func(ctx context.Context, network *hcloudgo.Network) (*hcloudgo.Response, error) {
+				Fail("a network taken over by another resource must not be deleted")
+				return nil, nil
+			}
+
+			By("initiating deletion of the resource")
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+
+			By("reconciling the resource")
+			reconciler := &HcloudNetworkReconciler{
+				Client:        k8sClient,
+				Scheme:        k8sClient.Scheme(),
+				NetworkClient: MockNetworkClient,
+				Recorder:      recorder,
+				ClusterID:     "cluster-a",
+			}
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			By("verifying finalizer was removed and resource is gone")
+			err = k8sClient.Get(ctx, typeNamespacedName, &hcloudv1alpha1.HcloudNetwork{})
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+		})
+
 	})
 
 	// Context("Verify network exists", func() {
@@ -752,13 +867,13 @@ var _ = Describe("HcloudNetwork Controller", func() {
 	// 	})
 	// })
 
-	Context("Adopt existing HcloudNetwork", func() {
+	Context("Reconcile HcloudNetwork subnets", func() {
 		const namespace = "default"
 
 		ctx := context.Background()
 
-		It("should successfully reconcile a network in Hetzner Cloud", func() {
-			const resourceName = "test-adopt-network"
+		It("should add missing subnets and remove undeclared ones", func() {
+			const resourceName = "test-subnets-converge"
 			typeNamespacedName := types.NamespacedName{
 				Name:      resourceName,
 				Namespace: namespace,
@@ -773,26 +888,56 @@ var _ = Describe("HcloudNetwork Controller", func() {
 				Spec: hcloudv1alpha1.HcloudNetworkSpec{
 					Name:    resourceName,
 					IpRange: "10.0.0.0/8",
-					Labels: map[string]string{
-						"env": "test",
+					Subnets: []hcloudv1alpha1.HcloudNetworkSubnet{
+						{
+							Type:        "cloud",
+							NetworkZone: "eu-central",
+							IpRange:     "10.1.0.0/16",
+						},
 					},
 				},
 			}
 			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
 
-			By("creating a mock HCloud manager")
-			MockNetworkClient := &hcloud.MockNetworkClient{}
+			_, networkCidr, _ := net.ParseCIDR("10.0.0.0/8")
+			_, staleCidr, _ := net.ParseCIDR("10.2.0.0/16")
+			existingNetwork := &hcloudgo.Network{
+				ID:      23456,
+				Name:    resourceName,
+				IPRange: networkCidr,
+				Subnets: []hcloudgo.NetworkSubnet{
+					{
+						Type:        hcloudgo.NetworkSubnetTypeCloud,
+						NetworkZone: hcloudgo.NetworkZoneEUCentral,
+						IPRange:     staleCidr,
+						Gateway:     net.IPv4(10, 0, 0, 1),
+					},
+				},
+			}
 
+			MockNetworkClient := &hcloud.MockNetworkClient{}
 			MockNetworkClient.GetNetworkByNameFunc = func(ctx context.Context, name string) (*hcloudgo.Network, *hcloudgo.Response, error) {
-				return &hcloudgo.Network{
-					ID:   12345,
-					Name: resourceName,
-					IPRange: &net.IPNet{
-						IP:   net.IPv4(10, 0, 0, 0),
-						Mask: net.IPv4Mask(255, 0, 0, 0),
-					},
-					Labels: map[string]string{"env": "test"},
-				}, nil, nil
+				return existingNetwork, nil, nil
+			}
+			MockNetworkClient.UpdateNetworkLabelsFunc = func(ctx context.Context, network *hcloudgo.Network, labels map[string]string) (*hcloudgo.Network, *hcloudgo.Response, error) {
+				updated := *network
+				updated.Labels = labels
+				return &updated, nil, nil
+			}
+			MockNetworkClient.ListNetworkServersFunc = func(ctx context.Context, network *hcloudgo.Network) ([]*hcloudgo.Server, error) {
+				return nil, nil
+			}
+			var deletedSubnets, addedSubnets []string
+			MockNetworkClient.DeleteNetworkSubnetFunc = func(ctx context.Context, network *hcloudgo.Network, subnet hcloudgo.NetworkSubnet) (*hcloudgo.Network, *hcloudgo.Response, error) {
+				deletedSubnets = append(deletedSubnets, subnet.IPRange.String())
+				existingNetwork.Subnets = nil
+				return existingNetwork, nil, nil
+			}
+			MockNetworkClient.AddNetworkSubnetFunc = func(ctx context.Context, network *hcloudgo.Network, subnet hcloudgo.NetworkSubnet) (*hcloudgo.Network, *hcloudgo.Response, error) {
+				addedSubnets = append(addedSubnets, subnet.IPRange.String())
+				subnet.Gateway = net.IPv4(10, 0, 0, 1)
+				existingNetwork.Subnets = append(existingNetwork.Subnets, subnet)
+				return existingNetwork, nil, nil
 			}
 
 			client := hcloud.NetworkClient(MockNetworkClient)
@@ -809,29 +954,2030 @@ var _ = Describe("HcloudNetwork Controller", func() {
 				NamespacedName: typeNamespacedName,
 			})
 			Expect(err).NotTo(HaveOccurred())
+			Expect(deletedSubnets).To(Equal([]string{"10.2.0.0/16"}))
+			Expect(addedSubnets).To(Equal([]string{"10.1.0.0/16"}))
 
-			By("verifying the resource status was updated")
+			By("verifying the subnets were written to the status")
 			updatedResource := &hcloudv1alpha1.HcloudNetwork{}
 			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
-			Expect(updatedResource.Status.NetworkId).To(Equal(12345))
-			Expect(updatedResource.Status.IpRange).To(Equal(resource.Spec.IpRange))
-			Expect(updatedResource.Status.Labels).To(Equal(resource.Spec.Labels))
-			Expect(updatedResource.Status.ObservedGeneration).To(Equal(updatedResource.Generation))
+			Expect(updatedResource.Status.Subnets).To(HaveLen(1))
+			Expect(updatedResource.Status.Subnets[0].IpRange).To(Equal("10.1.0.0/16"))
+			Expect(updatedResource.Status.Subnets[0].NetworkZone).To(Equal("eu-central"))
+			Expect(updatedResource.Status.Subnets[0].Gateway).To(Equal("10.0.0.1"))
 
-			By("verifying the Available condition was set")
 			condition := meta.FindStatusCondition(updatedResource.Status.Conditions, "Available")
 			Expect(condition).NotTo(BeNil())
 			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
 			Expect(condition.Reason).To(Equal("Ready"))
 
-			By("verifying finalizer was added")
-			Expect(updatedResource.ObjectMeta.Finalizers).To(ContainElement(finalizerName))
+			By("cleaning up the resource")
+			Expect(k8sClient.Delete(ctx, updatedResource)).To(Succeed())
+		})
 
-			By("verifying the sync-policy annotation was added")
-			Expect(updatedResource.Annotations[syncPolicy]).To(Equal("manage"))
+		It("should refuse to remove a subnet that still has servers attached", func() {
+			const resourceName = "test-subnets-in-use"
+			typeNamespacedName := types.NamespacedName{
+				Name:      resourceName,
+				Namespace: namespace,
+			}
+
+			By("creating the HcloudNetwork resource")
+			resource := &hcloudv1alpha1.HcloudNetwork{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: namespace,
+				},
+				Spec: hcloudv1alpha1.HcloudNetworkSpec{
+					Name:    resourceName,
+					IpRange: "10.0.0.0/8",
+					Subnets: []hcloudv1alpha1.HcloudNetworkSubnet{
+						{
+							Type:        "cloud",
+							NetworkZone: "eu-central",
+							IpRange:     "10.1.0.0/16",
+						},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			_, networkCidr, _ := net.ParseCIDR("10.0.0.0/8")
+			_, usedCidr, _ := net.ParseCIDR("10.2.0.0/16")
+			existingNetwork := &hcloudgo.Network{
+				ID:      34567,
+				Name:    resourceName,
+				IPRange: networkCidr,
+				Subnets: []hcloudgo.NetworkSubnet{
+					{
+						Type:        hcloudgo.NetworkSubnetTypeCloud,
+						NetworkZone: hcloudgo.NetworkZoneEUCentral,
+						IPRange:     usedCidr,
+					},
+				},
+			}
+
+			MockNetworkClient := &hcloud.MockNetworkClient{}
+			MockNetworkClient.GetNetworkByNameFunc = func(ctx context.Context, name string) (*hcloudgo.Network, *hcloudgo.Response, error) {
+				return existingNetwork, nil, nil
+			}
+			MockNetworkClient.UpdateNetworkLabelsFunc = func(ctx context.Context, network *hcloudgo.Network, labels map[string]string) (*hcloudgo.Network, *hcloudgo.Response, error) {
+				updated := *network
+				updated.Labels = labels
+				return &updated, nil, nil
+			}
+			MockNetworkClient.ListNetworkServersFunc = func(ctx context.Context, network *hcloudgo.Network) ([]*hcloudgo.Server, error) {
+				return []*hcloudgo.Server{
+					{
+						ID:   1,
+						Name: "worker-1",
+						PrivateNet: []hcloudgo.ServerPrivateNet{
+							{
+								Network: &hcloudgo.Network{ID: 34567},
+								IP:      net.IPv4(10, 2, 0, 2),
+							},
+						},
+					},
+				}, nil
+			}
+			MockNetworkClient.DeleteNetworkSubnetFunc = func(ctx context.Context, network *hcloudgo.Network, subnet hcloudgo.NetworkSubnet) (*hcloudgo.Network, *hcloudgo.Response, error) {
+				Fail("subnet with attached servers must not be deleted")
+				return nil, nil, nil
+			}
+
+			client := hcloud.NetworkClient(MockNetworkClient)
+
+			By("reconciling the resource")
+			reconciler := &HcloudNetworkReconciler{
+				Client:        k8sClient,
+				Scheme:        k8sClient.Scheme(),
+				NetworkClient: client,
+				Recorder:      recorder,
+			}
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("worker-1"))
+
+			By("verifying the SubnetInUse condition was set")
+			updatedResource := &hcloudv1alpha1.HcloudNetwork{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			condition := meta.FindStatusCondition(updatedResource.Status.Conditions, "Available")
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).To(Equal("SubnetInUse"))
+
+			By("cleaning up the resource")
+			Expect(k8sClient.Delete(ctx, updatedResource)).To(Succeed())
+		})
+
+		It("should remove the last subnet once an empty list is declared and no servers are attached", func() {
+			const resourceName = "test-subnets-emptied"
+			typeNamespacedName := types.NamespacedName{
+				Name:      resourceName,
+				Namespace: namespace,
+			}
+
+			By("creating the HcloudNetwork resource with an empty subnet list")
+			resource := &hcloudv1alpha1.HcloudNetwork{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: namespace,
+				},
+				Spec: hcloudv1alpha1.HcloudNetworkSpec{
+					Name:    resourceName,
+					IpRange: "10.0.0.0/8",
+					Subnets: []hcloudv1alpha1.HcloudNetworkSubnet{},
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			_, networkCidr, _ := net.ParseCIDR("10.0.0.0/8")
+			_, lastCidr, _ := net.ParseCIDR("10.2.0.0/16")
+			existingNetwork := &hcloudgo.Network{
+				ID:      45678,
+				Name:    resourceName,
+				IPRange: networkCidr,
+				Subnets: []hcloudgo.NetworkSubnet{
+					{
+						Type:        hcloudgo.NetworkSubnetTypeCloud,
+						NetworkZone: hcloudgo.NetworkZoneEUCentral,
+						IPRange:     lastCidr,
+					},
+				},
+			}
+
+			attached := []*hcloudgo.Server{
+				{
+					ID:   1,
+					Name: "worker-1",
+					PrivateNet: []hcloudgo.ServerPrivateNet{
+						{
+							Network: &hcloudgo.Network{ID: 45678},
+							IP:      net.IPv4(10, 2, 0, 2),
+						},
+					},
+				},
+			}
+			var deletedSubnets []string
+			MockNetworkClient := &hcloud.MockNetworkClient{}
+			MockNetworkClient.GetNetworkByNameFunc = func(ctx context.Context, name string) (*hcloudgo.Network, *hcloudgo.Response, error) {
+				return existingNetwork, nil, nil
+			}
+			MockNetworkClient.GetNetworkByIdFunc = func(ctx context.Context, id int64) (*hcloudgo.Network, *hcloudgo.Response, error) {
+				return existingNetwork, nil, nil
+			}
+			MockNetworkClient.UpdateNetworkLabelsFunc = func(ctx context.Context, network *hcloudgo.Network, labels map[string]string) (*hcloudgo.Network, *hcloudgo.Response, error) {
+				existingNetwork.Labels = labels
+				return existingNetwork, nil, nil
+			}
+			MockNetworkClient.ListNetworkServersFunc = func(ctx context.Context, network *hcloudgo.Network) ([]*hcloudgo.Server, error) {
+				return attached, nil
+			}
+			MockNetworkClient.DeleteNetworkSubnetFunc = func(ctx context.Context, network *hcloudgo.Network, subnet hcloudgo.NetworkSubnet) (*hcloudgo.Network, *hcloudgo.Response, error) {
+				deletedSubnets = append(deletedSubnets, subnet.IPRange.String())
+				existingNetwork.Subnets = nil
+				return existingNetwork, nil, nil
+			}
+
+			reconciler := &HcloudNetworkReconciler{
+				Client:        k8sClient,
+				Scheme:        k8sClient.Scheme(),
+				NetworkClient: MockNetworkClient,
+				Recorder:      recorder,
+			}
+
+			By("refusing to remove the subnet while a server is attached")
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("worker-1"))
+			Expect(deletedSubnets).To(BeEmpty())
+
+			updatedResource := &hcloudv1alpha1.HcloudNetwork{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			condition := meta.FindStatusCondition(updatedResource.Status.Conditions, "Available")
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Reason).To(Equal("SubnetInUse"))
+
+			By("removing the subnet once the server is detached")
+			attached = nil
+			_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deletedSubnets).To(Equal([]string{"10.2.0.0/16"}))
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			Expect(updatedResource.Spec.Subnets).To(BeEmpty())
+			Expect(updatedResource.Spec.Subnets).NotTo(BeNil())
+			Expect(updatedResource.Status.Subnets).To(BeEmpty())
+			Expect(meta.IsStatusConditionTrue(updatedResource.Status.Conditions, "Available")).To(BeTrue())
 
 			By("cleaning up the resource")
 			Expect(k8sClient.Delete(ctx, updatedResource)).To(Succeed())
 		})
+
+		It("should leave the subnets alone when no list is declared", func() {
+			const resourceName = "test-subnets-unmanaged"
+			typeNamespacedName := types.NamespacedName{
+				Name:      resourceName,
+				Namespace: namespace,
+			}
+
+			resource := &hcloudv1alpha1.HcloudNetwork{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: namespace,
+				},
+				Spec: hcloudv1alpha1.HcloudNetworkSpec{
+					Name:    resourceName,
+					IpRange: "10.0.0.0/8",
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			_, networkCidr, _ := net.ParseCIDR("10.0.0.0/8")
+			_, subnetCidr, _ := net.ParseCIDR("10.2.0.0/16")
+			existingNetwork := &hcloudgo.Network{
+				ID:      56789,
+				Name:    resourceName,
+				IPRange: networkCidr,
+				Subnets: []hcloudgo.NetworkSubnet{
+					{
+						Type:        hcloudgo.NetworkSubnetTypeCloud,
+						NetworkZone: hcloudgo.NetworkZoneEUCentral,
+						IPRange:     subnetCidr,
+					},
+				},
+			}
+
+			MockNetworkClient := &hcloud.MockNetworkClient{}
+			MockNetworkClient.GetNetworkByNameFunc = func(ctx context.Context, name string) (*hcloudgo.Network, *hcloudgo.Response, error) {
+				return existingNetwork, nil, nil
+			}
+			MockNetworkClient.UpdateNetworkLabelsFunc = func(ctx context.Context, network *hcloudgo.Network, labels map[string]string) (*hcloudgo.Network, *hcloudgo.Response, error) {
+				existingNetwork.Labels = labels
+				return existingNetwork, nil, nil
+			}
+			MockNetworkClient.DeleteNetworkSubnetFunc = func(ctx context.Context, network *hcloudgo.Network, subnet hcloudgo.NetworkSubnet) (*hcloudgo.Network, *hcloudgo.Response, error) {
+				Fail("subnets must not be removed when spec.subnets is unset")
+				return nil, nil, nil
+			}
+
+			reconciler := &HcloudNetworkReconciler{
+				Client:        k8sClient,
+				Scheme:        k8sClient.Scheme(),
+				NetworkClient: MockNetworkClient,
+				Recorder:      recorder,
+			}
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			updatedResource := &hcloudv1alpha1.HcloudNetwork{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			Expect(updatedResource.Status.Subnets).To(HaveLen(1))
+			Expect(meta.IsStatusConditionTrue(updatedResource.Status.Conditions, "Available")).To(BeTrue())
+
+			Expect(k8sClient.Delete(ctx, updatedResource)).To(Succeed())
+		})
+	})
+
+	Context("Reconcile HcloudNetwork routes", func() {
+		const namespace = "default"
+
+		ctx := context.Background()
+
+		It("should replace routes whose gateway changed", func() {
+			const resourceName = "test-routes-converge"
+			typeNamespacedName := types.NamespacedName{
+				Name:      resourceName,
+				Namespace: namespace,
+			}
+
+			By("creating the HcloudNetwork resource")
+			resource := &hcloudv1alpha1.HcloudNetwork{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: namespace,
+				},
+				Spec: hcloudv1alpha1.HcloudNetworkSpec{
+					Name:    resourceName,
+					IpRange: "10.0.0.0/8",
+					Routes: []hcloudv1alpha1.HcloudNetworkRoute{
+						{
+							Destination: "0.0.0.0/0",
+							Gateway:     "10.0.0.2",
+						},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			_, networkCidr, _ := net.ParseCIDR("10.0.0.0/8")
+			_, defaultRoute, _ := net.ParseCIDR("0.0.0.0/0")
+			existingNetwork := &hcloudgo.Network{
+				ID:      45678,
+				Name:    resourceName,
+				IPRange: networkCidr,
+				Routes: []hcloudgo.NetworkRoute{
+					{
+						Destination: defaultRoute,
+						Gateway:     net.IPv4(10, 0, 0, 3),
+					},
+				},
+			}
+
+			MockNetworkClient := &hcloud.MockNetworkClient{}
+			MockNetworkClient.GetNetworkByNameFunc = func(ctx context.Context, name string) (*hcloudgo.Network, *hcloudgo.Response, error) {
+				return existingNetwork, nil, nil
+			}
+			MockNetworkClient.UpdateNetworkLabelsFunc = func(ctx context.Context, network *hcloudgo.Network, labels map[string]string) (*hcloudgo.Network, *hcloudgo.Response, error) {
+				updated := *network
+				updated.Labels = labels
+				return &updated, nil, nil
+			}
+			var deletedGateways []string
+			MockNetworkClient.DeleteNetworkRouteFunc = func(ctx context.Context, network *hcloudgo.Network, route hcloudgo.NetworkRoute) (*hcloudgo.Network, *hcloudgo.Response, error) {
+				deletedGateways = append(deletedGateways, route.Gateway.String())
+				existingNetwork.Routes = nil
+				return existingNetwork, nil, nil
+			}
+			MockNetworkClient.AddNetworkRouteFunc = func(ctx context.Context, network *hcloudgo.Network, route hcloudgo.NetworkRoute) (*hcloudgo.Network, *hcloudgo.Response, error) {
+				existingNetwork.Routes = append(existingNetwork.Routes, route)
+				return existingNetwork, nil, nil
+			}
+
+			client := hcloud.NetworkClient(MockNetworkClient)
+
+			By("reconciling the resource")
+			reconciler := &HcloudNetworkReconciler{
+				Client:        k8sClient,
+				Scheme:        k8sClient.Scheme(),
+				NetworkClient: client,
+				Recorder:      recorder,
+			}
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deletedGateways).To(Equal([]string{"10.0.0.3"}))
+
+			By("verifying the routes were written to the status")
+			updatedResource := &hcloudv1alpha1.HcloudNetwork{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			Expect(updatedResource.Status.Routes).To(Equal([]hcloudv1alpha1.HcloudNetworkRoute{
+				{Destination: "0.0.0.0/0", Gateway: "10.0.0.2"},
+			}))
+
+			condition := meta.FindStatusCondition(updatedResource.Status.Conditions, "Available")
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+
+			By("cleaning up the resource")
+			Expect(k8sClient.Delete(ctx, updatedResource)).To(Succeed())
+		})
+
+		It("should remove the last route once an empty list is declared", func() {
+			const resourceName = "test-routes-emptied"
+			typeNamespacedName := types.NamespacedName{
+				Name:      resourceName,
+				Namespace: namespace,
+			}
+
+			By("creating the HcloudNetwork resource with an empty route list")
+			resource := &hcloudv1alpha1.HcloudNetwork{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: namespace,
+				},
+				Spec: hcloudv1alpha1.HcloudNetworkSpec{
+					Name:    resourceName,
+					IpRange: "10.0.0.0/8",
+					Routes:  []hcloudv1alpha1.HcloudNetworkRoute{},
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			_, networkCidr, _ := net.ParseCIDR("10.0.0.0/8")
+			_, defaultRoute, _ := net.ParseCIDR("0.0.0.0/0")
+			existingNetwork := &hcloudgo.Network{
+				ID:      45679,
+				Name:    resourceName,
+				IPRange: networkCidr,
+				Routes: []hcloudgo.NetworkRoute{
+					{
+						Destination: defaultRoute,
+						Gateway:     net.IPv4(10, 0, 0, 3),
+					},
+				},
+			}
+
+			MockNetworkClient := &hcloud.MockNetworkClient{}
+			MockNetworkClient.GetNetworkByNameFunc = func(ctx context.Context, name string) (*hcloudgo.Network, *hcloudgo.Response, error) {
+				return existingNetwork, nil, nil
+			}
+			MockNetworkClient.UpdateNetworkLabelsFunc = func(ctx context.Context, network *hcloudgo.Network, labels map[string]string) (*hcloudgo.Network, *hcloudgo.Response, error) {
+				existingNetwork.Labels = labels
+				return existingNetwork, nil, nil
+			}
+			var deletedRoutes []string
+			MockNetworkClient.DeleteNetworkRouteFunc = func(ctx context.Context, network *hcloudgo.Network, route hcloudgo.NetworkRoute) (*hcloudgo.Network, *hcloudgo.Response, error) {
+				deletedRoutes = append(deletedRoutes, route.Destination.String())
+				existingNetwork.Routes = nil
+				return existingNetwork, nil, nil
+			}
+
+			reconciler := &HcloudNetworkReconciler{
+				Client:        k8sClient,
+				Scheme:        k8sClient.Scheme(),
+				NetworkClient: MockNetworkClient,
+				Recorder:      recorder,
+			}
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deletedRoutes).To(Equal([]string{"0.0.0.0/0"}))
+
+			updatedResource := &hcloudv1alpha1.HcloudNetwork{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			Expect(updatedResource.Spec.Routes).NotTo(BeNil())
+			Expect(updatedResource.Status.Routes).To(BeEmpty())
+			Expect(meta.IsStatusConditionTrue(updatedResource.Status.Conditions, "Available")).To(BeTrue())
+
+			By("cleaning up the resource")
+			Expect(k8sClient.Delete(ctx, updatedResource)).To(Succeed())
+		})
+
+		It("should leave the routes alone when no list is declared", func() {
+			const resourceName = "test-routes-unmanaged"
+			typeNamespacedName := types.NamespacedName{
+				Name:      resourceName,
+				Namespace: namespace,
+			}
+
+			resource := &hcloudv1alpha1.HcloudNetwork{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: namespace,
+				},
+				Spec: hcloudv1alpha1.HcloudNetworkSpec{
+					Name:    resourceName,
+					IpRange: "10.0.0.0/8",
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			_, networkCidr, _ := net.ParseCIDR("10.0.0.0/8")
+			_, defaultRoute, _ := net.ParseCIDR("0.0.0.0/0")
+			existingNetwork := &hcloudgo.Network{
+				ID:      45680,
+				Name:    resourceName,
+				IPRange: networkCidr,
+				Routes: []hcloudgo.NetworkRoute{
+					{
+						Destination: defaultRoute,
+						Gateway:     net.IPv4(10, 0, 0, 3),
+					},
+				},
+			}
+
+			MockNetworkClient := &hcloud.MockNetworkClient{}
+			MockNetworkClient.GetNetworkByNameFunc = func(ctx context.Context, name string) (*hcloudgo.Network, *hcloudgo.Response, error) {
+				return existingNetwork, nil, nil
+			}
+			MockNetworkClient.UpdateNetworkLabelsFunc = func(ctx context.Context, network *hcloudgo.Network, labels map[string]string) (*hcloudgo.Network, *hcloudgo.Response, error) {
+				existingNetwork.Labels = labels
+				return existingNetwork, nil, nil
+			}
+			MockNetworkClient.DeleteNetworkRouteFunc = func(ctx context.Context, network *hcloudgo.Network, route hcloudgo.NetworkRoute) (*hcloudgo.Network, *hcloudgo.Response, error) {
+				Fail("routes must not be removed when spec.routes is unset")
+				return nil, nil, nil
+			}
+
+			reconciler := &HcloudNetworkReconciler{
+				Client:        k8sClient,
+				Scheme:        k8sClient.Scheme(),
+				NetworkClient: MockNetworkClient,
+				Recorder:      recorder,
+			}
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			updatedResource := &hcloudv1alpha1.HcloudNetwork{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			Expect(updatedResource.Status.Routes).To(HaveLen(1))
+			Expect(meta.IsStatusConditionTrue(updatedResource.Status.Conditions, "Available")).To(BeTrue())
+
+			Expect(k8sClient.Delete(ctx, updatedResource)).To(Succeed())
+		})
+
+		It("should reject routes with a gateway outside of the network", func() {
+			const resourceName = "test-routes-invalid-gateway"
+			typeNamespacedName := types.NamespacedName{
+				Name:      resourceName,
+				Namespace: namespace,
+			}
+
+			By("creating the HcloudNetwork resource")
+			resource := &hcloudv1alpha1.HcloudNetwork{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: namespace,
+				},
+				Spec: hcloudv1alpha1.HcloudNetworkSpec{
+					Name:    resourceName,
+					IpRange: "10.0.0.0/16",
+					Routes: []hcloudv1alpha1.HcloudNetworkRoute{
+						{
+							Destination: "192.168.0.0/24",
+							Gateway:     "10.1.0.2",
+						},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			MockNetworkClient := &hcloud.MockNetworkClient{}
+			MockNetworkClient.GetNetworkByNameFunc = func(ctx context.Context, name string) (*hcloudgo.Network, *hcloudgo.Response, error) {
+				Fail("invalid routes must not reach Hetzner Cloud")
+				return nil, nil, nil
+			}
+
+			client := hcloud.NetworkClient(MockNetworkClient)
+
+			By("reconciling the resource")
+			reconciler := &HcloudNetworkReconciler{
+				Client:        k8sClient,
+				Scheme:        k8sClient.Scheme(),
+				NetworkClient: client,
+				Recorder:      recorder,
+			}
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			By("verifying the InvalidRoutes condition was set")
+			updatedResource := &hcloudv1alpha1.HcloudNetwork{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			condition := meta.FindStatusCondition(updatedResource.Status.Conditions, "Available")
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).To(Equal("InvalidRoutes"))
+			Expect(condition.Message).To(ContainSubstring("outside of the network ip range"))
+
+			By("cleaning up the resource")
+			Expect(k8sClient.Delete(ctx, updatedResource)).To(Succeed())
+		})
+
+		It("should detect overlapping route destinations", func() {
+			err := validateRoutes(hcloudv1alpha1.HcloudNetworkSpec{
+				IpRange: "10.0.0.0/8",
+				Routes: []hcloudv1alpha1.HcloudNetworkRoute{
+					{Destination: "192.168.0.0/16", Gateway: "10.0.0.2"},
+					{Destination: "192.168.10.0/24", Gateway: "10.0.0.3"},
+				},
+			})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("overlaps"))
+		})
+	})
+
+	Context("Resolve HcloudNetwork credentials", func() {
+		const namespace = "default"
+
+		ctx := context.Background()
+
+		It("should use the client built from the referenced secret", func() {
+			const resourceName = "test-credentials-secret"
+			typeNamespacedName := types.NamespacedName{
+				Name:      resourceName,
+				Namespace: namespace,
+			}
+
+			By("creating the credentials secret")
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: namespace,
+				},
+				Data: map[string][]byte{
+					"api-token": []byte("project-token"),
+				},
+			}
+			Expect(k8sClient.Create(ctx, secret)).To(Succeed())
+
+			By("creating the HcloudNetwork resource")
+			resource := &hcloudv1alpha1.HcloudNetwork{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: namespace,
+				},
+				Spec: hcloudv1alpha1.HcloudNetworkSpec{
+					Name:    resourceName,
+					IpRange: "10.0.0.0/8",
+					CredentialsRef: &hcloudv1alpha1.HcloudCredentialsReference{
+						Name: resourceName,
+						Key:  "api-token",
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			MockNetworkClient := &hcloud.MockNetworkClient{}
+			MockNetworkClient.GetNetworkByNameFunc = func(ctx context.Context, name string) (*hcloudgo.Network, *hcloudgo.Response, error) {
+				return nil, nil, nil
+			}
+			MockNetworkClient.CreateNetworkFunc = func(ctx context.Context, name string, ipRange string, labels map[string]string) (*hcloudgo.Network, *hcloudgo.Response, error) {
+				_, cidr, _ := net.ParseCIDR(ipRange)
+				return &hcloudgo.Network{ID: 56789, Name: name, IPRange: cidr, Labels: labels}, nil, nil
+			}
+
+			var tokens []string
+			clientCache := hcloud.NewClientCache()
+			clientCache.NewNetworkClientFunc = func(token string, opts ...hcloudgo.ClientOption) hcloud.NetworkClient {
+				tokens = append(tokens, token)
+				return MockNetworkClient
+			}
+
+			By("reconciling the resource")
+			reconciler := &HcloudNetworkReconciler{
+				Client:        k8sClient,
+				Scheme:        k8sClient.Scheme(),
+				NetworkClient: &hcloud.MockNetworkClient{},
+				ClientCache:   clientCache,
+				Recorder:      recorder,
+			}
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tokens).To(Equal([]string{"project-token"}))
+
+			updatedResource := &hcloudv1alpha1.HcloudNetwork{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			Expect(updatedResource.Status.NetworkId).To(Equal(56789))
+
+			By("cleaning up the resources")
+			Expect(k8sClient.Delete(ctx, updatedResource)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, secret)).To(Succeed())
+		})
+
+		It("should report CredentialsUnavailable when the secret is missing", func() {
+			const resourceName = "test-credentials-missing"
+			typeNamespacedName := types.NamespacedName{
+				Name:      resourceName,
+				Namespace: namespace,
+			}
+
+			By("creating the HcloudNetwork resource")
+			resource := &hcloudv1alpha1.HcloudNetwork{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: namespace,
+				},
+				Spec: hcloudv1alpha1.HcloudNetworkSpec{
+					Name:    resourceName,
+					IpRange: "10.0.0.0/8",
+					CredentialsRef: &hcloudv1alpha1.HcloudCredentialsReference{
+						Name: "does-not-exist",
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			By("reconciling the resource")
+			reconciler := &HcloudNetworkReconciler{
+				Client:      k8sClient,
+				Scheme:      k8sClient.Scheme(),
+				ClientCache: hcloud.NewClientCache(),
+				Recorder:    recorder,
+			}
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).To(HaveOccurred())
+
+			By("verifying the CredentialsUnavailable condition was set")
+			updatedResource := &hcloudv1alpha1.HcloudNetwork{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			condition := meta.FindStatusCondition(updatedResource.Status.Conditions, "Available")
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).To(Equal("CredentialsUnavailable"))
+
+			By("cleaning up the resource")
+			Expect(k8sClient.Delete(ctx, updatedResource)).To(Succeed())
+		})
+
+		It("should report CredentialsUnavailable when no client is configured", func() {
+			const resourceName = "test-credentials-none"
+			typeNamespacedName := types.NamespacedName{
+				Name:      resourceName,
+				Namespace: namespace,
+			}
+
+			By("creating the HcloudNetwork resource")
+			resource := &hcloudv1alpha1.HcloudNetwork{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: namespace,
+				},
+				Spec: hcloudv1alpha1.HcloudNetworkSpec{
+					Name:    resourceName,
+					IpRange: "10.0.0.0/8",
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			By("reconciling the resource")
+			reconciler := &HcloudNetworkReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: recorder,
+			}
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).To(HaveOccurred())
+
+			updatedResource := &hcloudv1alpha1.HcloudNetwork{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			condition := meta.FindStatusCondition(updatedResource.Status.Conditions, "Available")
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Reason).To(Equal("CredentialsUnavailable"))
+
+			By("cleaning up the resource")
+			Expect(k8sClient.Delete(ctx, updatedResource)).To(Succeed())
+		})
+	})
+
+	Context("Adopt existing HcloudNetwork", func() {
+		const namespace = "default"
+
+		ctx := context.Background()
+
+		It("should successfully reconcile a network in Hetzner Cloud", func() {
+			const resourceName = "test-adopt-network"
+			typeNamespacedName := types.NamespacedName{
+				Name:      resourceName,
+				Namespace: namespace,
+			}
+
+			By("creating the HcloudNetwork resource")
+			resource := &hcloudv1alpha1.HcloudNetwork{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: namespace,
+				},
+				Spec: hcloudv1alpha1.HcloudNetworkSpec{
+					Name:    resourceName,
+					IpRange: "10.0.0.0/8",
+					Labels: map[string]string{
+						"env": "test",
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			By("creating a mock HCloud manager")
+			MockNetworkClient := &hcloud.MockNetworkClient{}
+
+			MockNetworkClient.GetNetworkByNameFunc = func(ctx context.Context, name string) (*hcloudgo.Network, *hcloudgo.Response, error) {
+				return &hcloudgo.Network{
+					ID:   12345,
+					Name: resourceName,
+					IPRange: &net.IPNet{
+						IP:   net.IPv4(10, 0, 0, 0),
+						Mask: net.IPv4Mask(255, 0, 0, 0),
+					},
+					Labels: map[string]string{"env": "test"},
+				}, nil, nil
+			}
+			MockNetworkClient.UpdateNetworkLabelsFunc = func(ctx context.Context, network *hcloudgo.Network, labels map[string]string) (*hcloudgo.Network, *hcloudgo.Response, error) {
+				updated := *network
+				updated.Labels = labels
+				return &updated, nil, nil
+			}
+
+			client := hcloud.NetworkClient(MockNetworkClient)
+
+			By("reconciling the resource")
+			reconciler := &HcloudNetworkReconciler{
+				Client:        k8sClient,
+				Scheme:        k8sClient.Scheme(),
+				NetworkClient: client,
+				Recorder:      recorder,
+			}
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			By("verifying the resource status was updated")
+			updatedResource := &hcloudv1alpha1.HcloudNetwork{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			Expect(updatedResource.Status.NetworkId).To(Equal(12345))
+			Expect(updatedResource.Status.IpRange).To(Equal(resource.Spec.IpRange))
+			Expect(updatedResource.Status.Labels).To(Equal(resource.Spec.Labels))
+			Expect(updatedResource.Status.ObservedGeneration).To(Equal(updatedResource.Generation))
+
+			By("verifying the Available condition was set")
+			condition := meta.FindStatusCondition(updatedResource.Status.Conditions, "Available")
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(condition.Reason).To(Equal("Ready"))
+
+			By("verifying finalizer was added")
+			Expect(updatedResource.ObjectMeta.Finalizers).To(ContainElement(finalizerName))
+
+			By("verifying the sync-policy annotation was added")
+			Expect(updatedResource.Annotations[syncPolicy]).To(Equal("manage"))
+
+			By("cleaning up the resource")
+			Expect(k8sClient.Delete(ctx, updatedResource)).To(Succeed())
+		})
+	})
+
+	Context("Adopt and claim HcloudNetwork", func() {
+		const namespace = "default"
+
+		ctx := context.Background()
+
+		newNetwork := func(resourceName string, annotations map[string]string) *hcloudv1alpha1.HcloudNetwork {
+			return &hcloudv1alpha1.HcloudNetwork{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        resourceName,
+					Namespace:   namespace,
+					Annotations: annotations,
+				},
+				Spec: hcloudv1alpha1.HcloudNetworkSpec{
+					Name:    resourceName,
+					IpRange: "10.0.0.0/16",
+					Labels:  map[string]string{"env": "test"},
+				},
+			}
+		}
+
+		It("should adopt the network pinned by the external-id annotation", func() {
+			const resourceName = "test-pinned-network"
+			typeNamespacedName := types.NamespacedName{Name: resourceName, Namespace: namespace}
+
+			resource := newNetwork(resourceName, map[string]string{hcloudv1alpha1.ExternalIDAnnotation: "4242"})
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			_, cidr, _ := net.ParseCIDR("10.0.0.0/16")
+			existingNetwork := &hcloudgo.Network{ID: 4242, Name: "legacy-network", IPRange: cidr, Labels: map[string]string{"env": "test"}}
+			var updatedLabels map[string]string
+			MockNetworkClient := &hcloud.MockNetworkClient{}
+			MockNetworkClient.GetNetworkByIdFunc = func(ctx context.Context, id int64) (*hcloudgo.Network, *hcloudgo.Response, error) {
+				Expect(id).To(Equal(int64(4242)))
+				return existingNetwork, nil, nil
+			}
+			MockNetworkClient.GetNetworkByNameFunc = func(ctx context.Context, name string) (*hcloudgo.Network, *hcloudgo.Response, error) {
+				Fail("a pinned network must not be looked up by name")
+				return nil, nil, nil
+			}
+			MockNetworkClient.UpdateNetworkLabelsFunc = func(ctx context.Context, network *hcloudgo.Network, labels map[string]string) (*hcloudgo.Network, *hcloudgo.Response, error) {
+				updatedLabels = labels
+				updated := *network
+				updated.Labels = labels
+				return &updated, nil, nil
+			}
+
+			reconciler := &HcloudNetworkReconciler{
+				Client:        k8sClient,
+				Scheme:        k8sClient.Scheme(),
+				NetworkClient: MockNetworkClient,
+				Recorder:      recorder,
+			}
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(updatedLabels).To(Equal(map[string]string{
+				"env":                         "test",
+				hcloudv1alpha1.NamespaceLabel: namespace,
+				hcloudv1alpha1.NameLabel:      resourceName,
+				hcloudv1alpha1.OwnerUIDLabel:  string(resource.UID),
+			}))
+
+			By("verifying the pinned network was adopted")
+			updatedResource := &hcloudv1alpha1.HcloudNetwork{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			Expect(updatedResource.Status.NetworkId).To(Equal(4242))
+			Expect(updatedResource.Status.Labels).To(Equal(map[string]string{"env": "test"}))
+			condition := meta.FindStatusCondition(updatedResource.Status.Conditions, "Available")
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+
+			Expect(k8sClient.Delete(ctx, updatedResource)).To(Succeed())
+		})
+
+		It("should report a pinned network that does not exist", func() {
+			const resourceName = "test-pinned-missing-network"
+			typeNamespacedName := types.NamespacedName{Name: resourceName, Namespace: namespace}
+
+			resource := newNetwork(resourceName, map[string]string{hcloudv1alpha1.ExternalIDAnnotation: "4343"})
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			MockNetworkClient := &hcloud.MockNetworkClient{}
+			MockNetworkClient.CreateNetworkFunc = func(ctx context.Context, name string, ipRange string, labels map[string]string) (*hcloudgo.Network, *hcloudgo.Response, error) {
+				Fail("a pinned network must not be created")
+				return nil, nil, nil
+			}
+
+			reconciler := &HcloudNetworkReconciler{
+				Client:         k8sClient,
+				Scheme:         k8sClient.Scheme(),
+				NetworkClient:  MockNetworkClient,
+				Recorder:       recorder,
+				ResyncInterval: 10 * time.Minute,
+			}
+
+			result, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(10 * time.Minute))
+
+			updatedResource := &hcloudv1alpha1.HcloudNetwork{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			condition := meta.FindStatusCondition(updatedResource.Status.Conditions, "Available")
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).To(Equal(externalResourceNotFoundReason))
+			Expect(condition.Message).To(ContainSubstring("Network 4343"))
+
+			Expect(k8sClient.Delete(ctx, updatedResource)).To(Succeed())
+		})
+
+		It("should reject an invalid external-id annotation", func() {
+			const resourceName = "test-invalid-external-id"
+			typeNamespacedName := types.NamespacedName{Name: resourceName, Namespace: namespace}
+
+			resource := newNetwork(resourceName, map[string]string{hcloudv1alpha1.ExternalIDAnnotation: "legacy-network"})
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			reconciler := &HcloudNetworkReconciler{
+				Client:        k8sClient,
+				Scheme:        k8sClient.Scheme(),
+				NetworkClient: &hcloud.MockNetworkClient{},
+				Recorder:      recorder,
+			}
+
+			result, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeZero())
+
+			updatedResource := &hcloudv1alpha1.HcloudNetwork{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			condition := meta.FindStatusCondition(updatedResource.Status.Conditions, "Available")
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Reason).To(Equal(invalidExternalIDReason))
+
+			Expect(k8sClient.Delete(ctx, updatedResource)).To(Succeed())
+		})
+
+		It("should leave a network claimed by another resource alone", func() {
+			const resourceName = "test-claimed-network"
+			typeNamespacedName := types.NamespacedName{Name: resourceName, Namespace: namespace}
+
+			resource := newNetwork(resourceName, nil)
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			_, cidr, _ := net.ParseCIDR("10.0.0.0/8")
+			MockNetworkClient := &hcloud.MockNetworkClient{}
+			MockNetworkClient.GetNetworkByNameFunc = func(ctx context.Context, name string) (*hcloudgo.Network, *hcloudgo.Response, error) {
+				return &hcloudgo.Network{ID: 4444, Name: name, IPRange: cidr, Labels: map[string]string{hcloudv1alpha1.OwnerUIDLabel: "another-uid"}}, nil, nil
+			}
+			MockNetworkClient.UpdateNetworkLabelsFunc = func(ctx context.Context, network *hcloudgo.Network, labels map[string]string) (*hcloudgo.Network, *hcloudgo.Response, error) {
+				Fail("a claimed network must not be updated")
+				return nil, nil, nil
+			}
+			MockNetworkClient.UpdateNetworkCidrFunc = func(ctx context.Context, network *hcloudgo.Network, cidr string) (*hcloudgo.Action, *hcloudgo.Response, error) {
+				Fail("a claimed network must not be updated")
+				return nil, nil, nil
+			}
+
+			reconciler := &HcloudNetworkReconciler{
+				Client:         k8sClient,
+				Scheme:         k8sClient.Scheme(),
+				NetworkClient:  MockNetworkClient,
+				Recorder:       recorder,
+				ResyncInterval: 10 * time.Minute,
+			}
+
+			result, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(10 * time.Minute))
+
+			updatedResource := &hcloudv1alpha1.HcloudNetwork{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			Expect(updatedResource.Status.NetworkId).To(BeZero())
+			condition := meta.FindStatusCondition(updatedResource.Status.Conditions, "Available")
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).To(Equal(alreadyClaimedReason))
+			Expect(condition.Message).To(ContainSubstring("another-uid"))
+
+			Expect(k8sClient.Delete(ctx, updatedResource)).To(Succeed())
+		})
+
+		It("should leave a network of another cluster alone", func() {
+			const resourceName = "test-foreign-network"
+			typeNamespacedName := types.NamespacedName{Name: resourceName, Namespace: namespace}
+
+			resource := newNetwork(resourceName, nil)
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			_, cidr, _ := net.ParseCIDR("10.0.0.0/16")
+			MockNetworkClient := &hcloud.MockNetworkClient{}
+			MockNetworkClient.GetNetworkByNameFunc = func(ctx context.Context, name string) (*hcloudgo.Network, *hcloudgo.Response, error) {
+				return &hcloudgo.Network{ID: 4646, Name: name, IPRange: cidr, Labels: map[string]string{
+					"env":                         "test",
+					hcloudv1alpha1.ClusterIDLabel: "cluster-b",
+					hcloudv1alpha1.NamespaceLabel: namespace,
+					hcloudv1alpha1.NameLabel:      resourceName,
+					hcloudv1alpha1.OwnerUIDLabel:  "foreign-uid",
+				}}, nil, nil
+			}
+			MockNetworkClient.UpdateNetworkLabelsFunc = func(ctx context.Context, network *hcloudgo.Network, labels map[string]string) (*hcloudgo.Network, *hcloudgo.Response, error) {
+				Fail("a network of another cluster must not be updated")
+				return nil, nil, nil
+			}
+
+			reconciler := &HcloudNetworkReconciler{
+				Client:        k8sClient,
+				Scheme:        k8sClient.Scheme(),
+				NetworkClient: MockNetworkClient,
+				Recorder:      recorder,
+				ClusterID:     "cluster-a",
+			}
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			updatedResource := &hcloudv1alpha1.HcloudNetwork{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			condition := meta.FindStatusCondition(updatedResource.Status.Conditions, "Available")
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Reason).To(Equal(alreadyClaimedReason))
+			Expect(condition.Message).To(ContainSubstring("default/test-foreign-network (UID foreign-uid) of cluster cluster-b"))
+
+			Expect(k8sClient.Delete(ctx, updatedResource)).To(Succeed())
+		})
+
+		It("should take over a network of another cluster with the takeover annotation", func() {
+			const resourceName = "test-takeover-network"
+			typeNamespacedName := types.NamespacedName{Name: resourceName, Namespace: namespace}
+
+			resource := newNetwork(resourceName, map[string]string{hcloudv1alpha1.TakeoverAnnotation: "true"})
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			_, cidr, _ := net.ParseCIDR("10.0.0.0/16")
+			existingNetwork := &hcloudgo.Network{ID: 4747, Name: resourceName, IPRange: cidr, Labels: map[string]string{
+				"env":                         "test",
+				hcloudv1alpha1.ClusterIDLabel: "cluster-b",
+				hcloudv1alpha1.NamespaceLabel: "other",
+				hcloudv1alpha1.NameLabel:      "other-network",
+				hcloudv1alpha1.OwnerUIDLabel:  "foreign-uid",
+			}}
+			var updatedLabels map[string]string
+			MockNetworkClient := &hcloud.MockNetworkClient{}
+			MockNetworkClient.GetNetworkByNameFunc = func(ctx context.Context, name string) (*hcloudgo.Network, *hcloudgo.Response, error) {
+				return existingNetwork, nil, nil
+			}
+			MockNetworkClient.UpdateNetworkLabelsFunc = func(ctx context.Context, network *hcloudgo.Network, labels map[string]string) (*hcloudgo.Network, *hcloudgo.Response, error) {
+				updatedLabels = labels
+				updated := *network
+				updated.Labels = labels
+				return &updated, nil, nil
+			}
+
+			reconciler := &HcloudNetworkReconciler{
+				Client:        k8sClient,
+				Scheme:        k8sClient.Scheme(),
+				NetworkClient: MockNetworkClient,
+				Recorder:      recorder,
+				ClusterID:     "cluster-a",
+			}
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(updatedLabels).To(Equal(map[string]string{
+				"env":                         "test",
+				hcloudv1alpha1.ClusterIDLabel: "cluster-a",
+				hcloudv1alpha1.NamespaceLabel: namespace,
+				hcloudv1alpha1.NameLabel:      resourceName,
+				hcloudv1alpha1.OwnerUIDLabel:  string(resource.UID),
+			}))
+
+			By("verifying the ownership labels are not reported")
+			updatedResource := &hcloudv1alpha1.HcloudNetwork{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			Expect(updatedResource.Status.NetworkId).To(Equal(4747))
+			Expect(updatedResource.Status.Labels).To(Equal(map[string]string{"env": "test"}))
+			condition := meta.FindStatusCondition(updatedResource.Status.Conditions, "Available")
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+
+			Expect(k8sClient.Delete(ctx, updatedResource)).To(Succeed())
+		})
+
+		It("should keep managing a network renamed outside of the operator", func() {
+			const resourceName = "test-renamed-network"
+			typeNamespacedName := types.NamespacedName{Name: resourceName, Namespace: namespace}
+
+			resource := newNetwork(resourceName, nil)
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			_, cidr, _ := net.ParseCIDR("10.0.0.0/16")
+			existingNetwork := &hcloudgo.Network{ID: 4545, Name: resourceName, IPRange: cidr, Labels: map[string]string{"env": "test"}}
+			MockNetworkClient := &hcloud.MockNetworkClient{}
+			MockNetworkClient.GetNetworkByNameFunc = func(ctx context.Context, name string) (*hcloudgo.Network, *hcloudgo.Response, error) {
+				if name == existingNetwork.Name {
+					return existingNetwork, nil, nil
+				}
+				return nil, nil, nil
+			}
+			MockNetworkClient.GetNetworkByIdFunc = func(ctx context.Context, id int64) (*hcloudgo.Network, *hcloudgo.Response, error) {
+				if id == existingNetwork.ID {
+					return existingNetwork, nil, nil
+				}
+				return nil, nil, nil
+			}
+			MockNetworkClient.UpdateNetworkLabelsFunc = func(ctx context.Context, network *hcloudgo.Network, labels map[string]string) (*hcloudgo.Network, *hcloudgo.Response, error) {
+				existingNetwork.Labels = labels
+				return existingNetwork, nil, nil
+			}
+			MockNetworkClient.CreateNetworkFunc = func(ctx context.Context, name string, ipRange string, labels map[string]string) (*hcloudgo.Network, *hcloudgo.Response, error) {
+				Fail("a renamed network must not be created again")
+				return nil, nil, nil
+			}
+
+			reconciler := &HcloudNetworkReconciler{
+				Client:        k8sClient,
+				Scheme:        k8sClient.Scheme(),
+				NetworkClient: MockNetworkClient,
+				Recorder:      recorder,
+			}
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			By("renaming the network outside of the operator")
+			existingNetwork.Name = "renamed-network"
+
+			_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			updatedResource := &hcloudv1alpha1.HcloudNetwork{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			Expect(updatedResource.Status.NetworkId).To(Equal(4545))
+			condition := meta.FindStatusCondition(updatedResource.Status.Conditions, "Available")
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+
+			Expect(k8sClient.Delete(ctx, updatedResource)).To(Succeed())
+		})
+	})
+
+	Context("Apply the sync policy of HcloudNetwork", func() {
+		const namespace = "default"
+
+		ctx := context.Background()
+
+		newNetwork := func(resourceName string, policy *hcloudv1alpha1.HcloudSyncPolicy) *hcloudv1alpha1.HcloudNetwork {
+			return &hcloudv1alpha1.HcloudNetwork{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: namespace,
+				},
+				Spec: hcloudv1alpha1.HcloudNetworkSpec{
+					Name:       resourceName,
+					IpRange:    "10.0.0.0/16",
+					Labels:     map[string]string{"env": "test"},
+					SyncPolicy: policy,
+				},
+			}
+		}
+
+		It("should report an unknown sync policy instead of managing the network", func() {
+			const resourceName = "test-unknown-policy-network"
+			typeNamespacedName := types.NamespacedName{Name: resourceName, Namespace: namespace}
+
+			resource := newNetwork(resourceName, nil)
+			resource.Annotations = map[string]string{syncPolicy: "delete"}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			MockNetworkClient := &hcloud.MockNetworkClient{}
+			MockNetworkClient.GetNetworkByNameFunc = func(ctx context.Context, name string) (*hcloudgo.Network, *hcloudgo.Response, error) {
+				Fail("a network with an unknown sync policy must not be looked up")
+				return nil, nil, nil
+			}
+
+			reconciler := &HcloudNetworkReconciler{
+				Client:        k8sClient,
+				Scheme:        k8sClient.Scheme(),
+				NetworkClient: MockNetworkClient,
+				Recorder:      recorder,
+			}
+
+			result, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeZero())
+
+			updatedResource := &hcloudv1alpha1.HcloudNetwork{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			Expect(updatedResource.Finalizers).To(BeEmpty())
+			condition := meta.FindStatusCondition(updatedResource.Status.Conditions, "Available")
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).To(Equal(invalidSyncPolicyReason))
+			Expect(condition.Message).To(ContainSubstring(`unknown sync policy "delete"`))
+
+			Expect(k8sClient.Delete(ctx, updatedResource)).To(Succeed())
+		})
+
+		It("should prefer the sync policy mode over the annotation", func() {
+			const resourceName = "test-policy-mode-network"
+			typeNamespacedName := types.NamespacedName{Name: resourceName, Namespace: namespace}
+
+			resource := newNetwork(resourceName, &hcloudv1alpha1.HcloudSyncPolicy{Mode: hcloudv1alpha1.SyncPolicyReadOnly})
+			resource.Annotations = map[string]string{syncPolicy: "manage"}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			MockNetworkClient := &hcloud.MockNetworkClient{}
+			MockNetworkClient.CreateNetworkFunc = func(ctx context.Context, name string, ipRange string, labels map[string]string) (*hcloudgo.Network, *hcloudgo.Response, error) {
+				Fail("the network must not be created under the read-only sync policy")
+				return nil, nil, nil
+			}
+
+			reconciler := &HcloudNetworkReconciler{
+				Client:        k8sClient,
+				Scheme:        k8sClient.Scheme(),
+				NetworkClient: MockNetworkClient,
+				Recorder:      recorder,
+			}
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			updatedResource := &hcloudv1alpha1.HcloudNetwork{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			Expect(updatedResource.Finalizers).To(BeEmpty())
+			condition := meta.FindStatusCondition(updatedResource.Status.Conditions, "Available")
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Message).To(Equal("Network not found in Hetzner Cloud and sync policy is read-only"))
+
+			Expect(k8sClient.Delete(ctx, updatedResource)).To(Succeed())
+		})
+
+		It("should leave ignored labels to other tools", func() {
+			const resourceName = "test-ignore-labels-network"
+			typeNamespacedName := types.NamespacedName{Name: resourceName, Namespace: namespace}
+
+			resource := newNetwork(resourceName, &hcloudv1alpha1.HcloudSyncPolicy{IgnoreFields: []string{"labels"}})
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			_, cidr, _ := net.ParseCIDR("10.0.0.0/8")
+			var updatedLabels map[string]string
+			var updatedCidr string
+			MockNetworkClient := &hcloud.MockNetworkClient{}
+			MockNetworkClient.GetNetworkByNameFunc = func(ctx context.Context, name string) (*hcloudgo.Network, *hcloudgo.Response, error) {
+				return &hcloudgo.Network{ID: 5151, Name: name, IPRange: cidr, Labels: map[string]string{"team": "platform"}}, nil, nil
+			}
+			MockNetworkClient.UpdateNetworkLabelsFunc = func(ctx context.Context, network *hcloudgo.Network, labels map[string]string) (*hcloudgo.Network, *hcloudgo.Response, error) {
+				updatedLabels = labels
+				updated := *network
+				updated.Labels = labels
+				return &updated, nil, nil
+			}
+			MockNetworkClient.UpdateNetworkCidrFunc = func(ctx context.Context, network *hcloudgo.Network, cidr string) (*hcloudgo.Action, *hcloudgo.Response, error) {
+				updatedCidr = cidr
+				return nil, nil, nil
+			}
+
+			reconciler := &HcloudNetworkReconciler{
+				Client:        k8sClient,
+				Scheme:        k8sClient.Scheme(),
+				NetworkClient: MockNetworkClient,
+				Recorder:      recorder,
+			}
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			By("verifying only the ownership labels were added and the IP range was updated")
+			Expect(updatedLabels).To(Equal(map[string]string{
+				"team":                        "platform",
+				hcloudv1alpha1.NamespaceLabel: namespace,
+				hcloudv1alpha1.NameLabel:      resourceName,
+				hcloudv1alpha1.OwnerUIDLabel:  string(resource.UID),
+			}))
+			Expect(updatedCidr).To(Equal("10.0.0.0/16"))
+
+			updatedResource := &hcloudv1alpha1.HcloudNetwork{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			Expect(updatedResource.Status.Labels).To(Equal(map[string]string{"team": "platform"}))
+
+			Expect(k8sClient.Delete(ctx, updatedResource)).To(Succeed())
+		})
+
+		It("should not update an existing network under the create-only sync policy", func() {
+			const resourceName = "test-create-only-network"
+			typeNamespacedName := types.NamespacedName{Name: resourceName, Namespace: namespace}
+
+			resource := newNetwork(resourceName, &hcloudv1alpha1.HcloudSyncPolicy{Mode: hcloudv1alpha1.SyncPolicyCreateOnly})
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			_, cidr, _ := net.ParseCIDR("10.0.0.0/8")
+			MockNetworkClient := &hcloud.MockNetworkClient{}
+			MockNetworkClient.GetNetworkByNameFunc = func(ctx context.Context, name string) (*hcloudgo.Network, *hcloudgo.Response, error) {
+				return &hcloudgo.Network{ID: 5252, Name: name, IPRange: cidr, Labels: map[string]string{"env": "test"}}, nil, nil
+			}
+			MockNetworkClient.UpdateNetworkLabelsFunc = func(ctx context.Context, network *hcloudgo.Network, labels map[string]string) (*hcloudgo.Network, *hcloudgo.Response, error) {
+				Fail("the network must not be updated under the create-only sync policy")
+				return nil, nil, nil
+			}
+			MockNetworkClient.UpdateNetworkCidrFunc = func(ctx context.Context, network *hcloudgo.Network, cidr string) (*hcloudgo.Action, *hcloudgo.Response, error) {
+				Fail("the network must not be updated under the create-only sync policy")
+				return nil, nil, nil
+			}
+
+			reconciler := &HcloudNetworkReconciler{
+				Client:        k8sClient,
+				Scheme:        k8sClient.Scheme(),
+				NetworkClient: MockNetworkClient,
+				Recorder:      recorder,
+			}
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			updatedResource := &hcloudv1alpha1.HcloudNetwork{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			Expect(updatedResource.Finalizers).To(ContainElement(finalizerName))
+			Expect(updatedResource.Status.NetworkId).To(Equal(5252))
+			condition := meta.FindStatusCondition(updatedResource.Status.Conditions, driftedCondition)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(condition.Message).To(Equal("ipRange: 10.0.0.0/8 -> 10.0.0.0/16"))
+
+			Expect(k8sClient.Delete(ctx, updatedResource)).To(Succeed())
+		})
+
+		It("should not create a network under the update-only sync policy", func() {
+			const resourceName = "test-update-only-network"
+			typeNamespacedName := types.NamespacedName{Name: resourceName, Namespace: namespace}
+
+			resource := newNetwork(resourceName, &hcloudv1alpha1.HcloudSyncPolicy{Mode: hcloudv1alpha1.SyncPolicyUpdateOnly})
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			MockNetworkClient := &hcloud.MockNetworkClient{}
+			MockNetworkClient.CreateNetworkFunc = func(ctx context.Context, name string, ipRange string, labels map[string]string) (*hcloudgo.Network, *hcloudgo.Response, error) {
+				Fail("the network must not be created under the update-only sync policy")
+				return nil, nil, nil
+			}
+
+			reconciler := &HcloudNetworkReconciler{
+				Client:        k8sClient,
+				Scheme:        k8sClient.Scheme(),
+				NetworkClient: MockNetworkClient,
+				Recorder:      recorder,
+			}
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			updatedResource := &hcloudv1alpha1.HcloudNetwork{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			condition := meta.FindStatusCondition(updatedResource.Status.Conditions, "Available")
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Message).To(Equal("Network not found in Hetzner Cloud and sync policy is update-only"))
+
+			Expect(k8sClient.Delete(ctx, updatedResource)).To(Succeed())
+		})
+
+		It("should release instead of delete the network under the update-only sync policy", func() {
+			const resourceName = "test-update-only-delete-network"
+			typeNamespacedName := types.NamespacedName{Name: resourceName, Namespace: namespace}
+
+			resource := newNetwork(resourceName, &hcloudv1alpha1.HcloudSyncPolicy{Mode: hcloudv1alpha1.SyncPolicyUpdateOnly})
+			resource.Finalizers = []string{finalizerName}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+			resource.Status.NetworkId = 5353
+			Expect(k8sClient.Status().Update(ctx, resource)).To(Succeed())
+
+			var releasedLabels map[string]string
+			MockNetworkClient := &hcloud.MockNetworkClient{}
+			MockNetworkClient.GetNetworkByIdFunc = func(ctx context.Context, id int64) (*hcloudgo.Network, *hcloudgo.Response, error) {
+				return &hcloudgo.Network{ID: id, Name: resourceName, Labels: withOwnershipLabels(map[string]string{"env": "test"}, nil, resource, "")}, nil, nil
+			}
+			MockNetworkClient.UpdateNetworkLabelsFunc = func(ctx context.Context, network *hcloudgo.Network, labels map[string]string) (*hcloudgo.Network, *hcloudgo.Response, error) {
+				releasedLabels = labels
+				return network, nil, nil
+			}
+			MockNetworkClient.DeleteNetworkFunc = func(ctx context.Context, network *hcloudgo.Network) (*hcloudgo.Response, error) {
+				Fail("the network must not be deleted under the update-only sync policy")
+				return nil, nil
+			}
+
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+
+			reconciler := &HcloudNetworkReconciler{
+				Client:        k8sClient,
+				Scheme:        k8sClient.Scheme(),
+				NetworkClient: MockNetworkClient,
+				Recorder:      recorder,
+			}
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(releasedLabels).To(Equal(map[string]string{"env": "test"}))
+
+			err = k8sClient.Get(ctx, typeNamespacedName, &hcloudv1alpha1.HcloudNetwork{})
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+		})
+	})
+
+	Context("Pause reconciliation of HcloudNetwork", func() {
+		const namespace = "default"
+
+		ctx := context.Background()
+
+		newNetwork := func(resourceName string) *hcloudv1alpha1.HcloudNetwork {
+			return &hcloudv1alpha1.HcloudNetwork{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        resourceName,
+					Namespace:   namespace,
+					Annotations: map[string]string{pausedAnnotation: "true"},
+				},
+				Spec: hcloudv1alpha1.HcloudNetworkSpec{
+					Name:    resourceName,
+					IpRange: "10.0.0.0/16",
+				},
+			}
+		}
+
+		It("should leave a paused network alone until the annotation is removed", func() {
+			const resourceName = "test-paused-network"
+			typeNamespacedName := types.NamespacedName{Name: resourceName, Namespace: namespace}
+
+			Expect(k8sClient.Create(ctx, newNetwork(resourceName))).To(Succeed())
+
+			created := false
+			MockNetworkClient := &hcloud.MockNetworkClient{}
+			MockNetworkClient.CreateNetworkFunc = func(ctx context.Context, name string, ipRange string, labels map[string]string) (*hcloudgo.Network, *hcloudgo.Response, error) {
+				created = true
+				_, cidr, _ := net.ParseCIDR(ipRange)
+				return &hcloudgo.Network{ID: 6161, Name: name, IPRange: cidr, Labels: labels}, nil, nil
+			}
+
+			reconciler := &HcloudNetworkReconciler{
+				Client:        k8sClient,
+				Scheme:        k8sClient.Scheme(),
+				NetworkClient: MockNetworkClient,
+				Recorder:      recorder,
+			}
+
+			result, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeZero())
+			Expect(created).To(BeFalse())
+
+			updatedResource := &hcloudv1alpha1.HcloudNetwork{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			Expect(updatedResource.Finalizers).To(BeEmpty())
+			condition := meta.FindStatusCondition(updatedResource.Status.Conditions, pausedCondition)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(condition.Reason).To(Equal(pausedReason))
+			Expect(meta.FindStatusCondition(updatedResource.Status.Conditions, "Available")).To(BeNil())
+
+			By("removing the paused annotation")
+			updatedResource.Annotations = nil
+			Expect(k8sClient.Update(ctx, updatedResource)).To(Succeed())
+
+			_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(created).To(BeTrue())
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			Expect(updatedResource.Status.NetworkId).To(Equal(6161))
+			Expect(meta.FindStatusCondition(updatedResource.Status.Conditions, pausedCondition)).To(BeNil())
+
+			MockNetworkClient.GetNetworkByIdFunc = func(ctx context.Context, id int64) (*hcloudgo.Network, *hcloudgo.Response, error) {
+				return nil, nil, nil
+			}
+			Expect(k8sClient.Delete(ctx, updatedResource)).To(Succeed())
+			_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should hold the finalizer of a paused network that is being deleted", func() {
+			const resourceName = "test-paused-delete-network"
+			typeNamespacedName := types.NamespacedName{Name: resourceName, Namespace: namespace}
+
+			resource := newNetwork(resourceName)
+			resource.Finalizers = []string{finalizerName}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+			resource.Status.NetworkId = 6262
+			Expect(k8sClient.Status().Update(ctx, resource)).To(Succeed())
+
+			deleted := false
+			MockNetworkClient := &hcloud.MockNetworkClient{}
+			MockNetworkClient.GetNetworkByIdFunc = func(ctx context.Context, id int64) (*hcloudgo.Network, *hcloudgo.Response, error) {
+				return &hcloudgo.Network{ID: id, Name: resourceName}, nil, nil
+			}
+			MockNetworkClient.DeleteNetworkFunc = func(ctx context.Context, network *hcloudgo.Network) (*hcloudgo.Response, error) {
+				deleted = true
+				return nil, nil
+			}
+
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+
+			reconciler := &HcloudNetworkReconciler{
+				Client:        k8sClient,
+				Scheme:        k8sClient.Scheme(),
+				NetworkClient: MockNetworkClient,
+				Recorder:      recorder,
+			}
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deleted).To(BeFalse())
+
+			updatedResource := &hcloudv1alpha1.HcloudNetwork{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			Expect(updatedResource.Finalizers).To(ContainElement(finalizerName))
+			Expect(meta.IsStatusConditionTrue(updatedResource.Status.Conditions, pausedCondition)).To(BeTrue())
+
+			By("resuming the deletion")
+			updatedResource.Annotations = nil
+			Expect(k8sClient.Update(ctx, updatedResource)).To(Succeed())
+
+			_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deleted).To(BeTrue())
+
+			err = k8sClient.Get(ctx, typeNamespacedName, &hcloudv1alpha1.HcloudNetwork{})
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("should pause the networks of a kind or namespace paused by the manager", func() {
+			const resourceName = "test-pause-switch-network"
+			typeNamespacedName := types.NamespacedName{Name: resourceName, Namespace: namespace}
+
+			resource := newNetwork(resourceName)
+			resource.Annotations = nil
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			configMap := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-pause-switch", Namespace: namespace},
+				Data:       map[string]string{pauseKindsKey: "HcloudDnsZone", pauseNamespacesKey: "kube-system, default"},
+			}
+			Expect(k8sClient.Create(ctx, configMap)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(ctx, configMap)).To(Succeed())
+			})
+
+			MockNetworkClient := &hcloud.MockNetworkClient{}
+			MockNetworkClient.GetNetworkByNameFunc = func(ctx context.Context, name string) (*hcloudgo.Network, *hcloudgo.Response, error) {
+				Fail("a paused network must not be looked up")
+				return nil, nil, nil
+			}
+
+			reconciler := &HcloudNetworkReconciler{
+				Client:        k8sClient,
+				Scheme:        k8sClient.Scheme(),
+				NetworkClient: MockNetworkClient,
+				Recorder:      recorder,
+				Pause: &PauseSwitch{
+					Kinds:     []string{"HcloudDnsZone"},
+					Reader:    k8sClient,
+					ConfigMap: types.NamespacedName{Name: configMap.Name, Namespace: namespace},
+				},
+			}
+
+			result, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(pausedRequeueAfter))
+
+			updatedResource := &hcloudv1alpha1.HcloudNetwork{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			condition := meta.FindStatusCondition(updatedResource.Status.Conditions, pausedCondition)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Message).To(Equal("Reconciliation in namespace default is paused by ConfigMap default/test-pause-switch"))
+
+			By("pausing the kind with the manager flags")
+			reconciler.Pause.Kinds = []string{"HcloudNetwork"}
+			_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			condition = meta.FindStatusCondition(updatedResource.Status.Conditions, pausedCondition)
+			Expect(condition.Message).To(Equal("Reconciliation of HcloudNetwork is paused by the manager"))
+
+			Expect(k8sClient.Delete(ctx, updatedResource)).To(Succeed())
+		})
+	})
+
+	Context("Detect HcloudNetwork drift", func() {
+		const namespace = "default"
+
+		ctx := context.Background()
+
+		It("should report and correct drift found on resync", func() {
+			const resourceName = "test-drift-network"
+			typeNamespacedName := types.NamespacedName{
+				Name:      resourceName,
+				Namespace: namespace,
+			}
+
+			_, cidr, _ := net.ParseCIDR("10.0.0.0/16")
+
+			By("creating the HcloudNetwork resource")
+			resource := &hcloudv1alpha1.HcloudNetwork{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: namespace,
+					Annotations: map[string]string{
+						resyncIntervalAnnotation: "1m",
+					},
+				},
+				Spec: hcloudv1alpha1.HcloudNetworkSpec{
+					Name:    resourceName,
+					IpRange: "10.0.0.0/16",
+					Labels: map[string]string{
+						"env": "test",
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			network := &hcloudgo.Network{
+				ID:      2424,
+				Name:    resourceName,
+				IPRange: cidr,
+				Labels:  map[string]string{"env": "test"},
+			}
+			var updatedLabels map[string]string
+			MockNetworkClient := &hcloud.MockNetworkClient{}
+			MockNetworkClient.GetNetworkByNameFunc = func(ctx context.Context, name string) (*hcloudgo.Network, *hcloudgo.Response, error) {
+				return network, nil, nil
+			}
+			MockNetworkClient.UpdateNetworkLabelsFunc = func(ctx context.Context, network *hcloudgo.Network, labels map[string]string) (*hcloudgo.Network, *hcloudgo.Response, error) {
+				updatedLabels = labels
+				updated := *network
+				updated.Labels = labels
+				return &updated, nil, nil
+			}
+
+			reconciler := &HcloudNetworkReconciler{
+				Client:         k8sClient,
+				Scheme:         k8sClient.Scheme(),
+				NetworkClient:  MockNetworkClient,
+				Recorder:       recorder,
+				ResyncInterval: 10 * time.Minute,
+			}
+
+			By("reconciling the resource in sync with Hetzner Cloud")
+			result, err := reconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(time.Minute))
+
+			updatedResource := &hcloudv1alpha1.HcloudNetwork{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			condition := meta.FindStatusCondition(updatedResource.Status.Conditions, driftedCondition)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).To(Equal("InSync"))
+
+			By("changing the labels outside of the operator")
+			network.Labels = map[string]string{"env": "prod", "owner": "someone"}
+
+			By("reconciling the resource on resync")
+			_, err = reconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(updatedLabels).To(Equal(withOwnershipLabels(resource.Spec.Labels, nil, resource, "")))
+
+			By("verifying the drift was recorded")
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			condition = meta.FindStatusCondition(updatedResource.Status.Conditions, driftedCondition)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).To(Equal("DriftCorrected"))
+			Expect(condition.Message).To(ContainSubstring("labels.env: prod -> test"))
+			Expect(condition.Message).To(ContainSubstring("labels.owner: someone -> <absent>"))
+
+			By("cleaning up the resource")
+			Expect(k8sClient.Delete(ctx, updatedResource)).To(Succeed())
+		})
+
+		It("should only report drift when sync policy is read-only", func() {
+			const resourceName = "test-drift-readonly-network"
+			typeNamespacedName := types.NamespacedName{
+				Name:      resourceName,
+				Namespace: namespace,
+			}
+
+			_, cidr, _ := net.ParseCIDR("10.0.0.0/8")
+
+			resource := &hcloudv1alpha1.HcloudNetwork{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: namespace,
+					Annotations: map[string]string{
+						syncPolicy: "read-only",
+					},
+				},
+				Spec: hcloudv1alpha1.HcloudNetworkSpec{
+					Name:    resourceName,
+					IpRange: "10.0.0.0/16",
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			MockNetworkClient := &hcloud.MockNetworkClient{}
+			MockNetworkClient.GetNetworkByNameFunc = func(ctx context.Context, name string) (*hcloudgo.Network, *hcloudgo.Response, error) {
+				return &hcloudgo.Network{
+					ID:      2425,
+					Name:    resourceName,
+					IPRange: cidr,
+				}, nil, nil
+			}
+			MockNetworkClient.UpdateNetworkCidrFunc = func(ctx context.Context, network *hcloudgo.Network, cidr string) (*hcloudgo.Action, *hcloudgo.Response, error) {
+				Fail("the network must not be updated under the read-only sync policy")
+				return nil, nil, nil
+			}
+
+			reconciler := &HcloudNetworkReconciler{
+				Client:         k8sClient,
+				Scheme:         k8sClient.Scheme(),
+				NetworkClient:  MockNetworkClient,
+				Recorder:       recorder,
+				ResyncInterval: 10 * time.Minute,
+			}
+
+			result, err := reconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(10 * time.Minute))
+
+			By("verifying the drift was reported")
+			updatedResource := &hcloudv1alpha1.HcloudNetwork{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			condition := meta.FindStatusCondition(updatedResource.Status.Conditions, driftedCondition)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(condition.Reason).To(Equal("DriftDetected"))
+			Expect(condition.Message).To(Equal("ipRange: 10.0.0.0/8 -> 10.0.0.0/16"))
+
+			By("cleaning up the resource")
+			Expect(k8sClient.Delete(ctx, updatedResource)).To(Succeed())
+		})
+
+		It("should describe subnet and route drift", func() {
+			_, networkRange, _ := net.ParseCIDR("10.0.0.0/16")
+			_, subnetRange, _ := net.ParseCIDR("10.0.1.0/24")
+			_, strayRange, _ := net.ParseCIDR("10.0.9.0/24")
+			_, destination, _ := net.ParseCIDR("10.100.0.0/24")
+
+			spec := hcloudv1alpha1.HcloudNetworkSpec{
+				IpRange: "10.0.0.0/16",
+				Subnets: []hcloudv1alpha1.HcloudNetworkSubnet{
+					{Type: "cloud", NetworkZone: "eu-central", IpRange: "10.0.1.0/24"},
+				},
+				Routes: []hcloudv1alpha1.HcloudNetworkRoute{
+					{Destination: "10.100.0.0/24", Gateway: "10.0.1.2"},
+				},
+			}
+			network := &hcloudgo.Network{
+				IPRange: networkRange,
+				Subnets: []hcloudgo.NetworkSubnet{
+					{Type: hcloudgo.NetworkSubnetTypeCloud, NetworkZone: "eu-central", IPRange: subnetRange},
+					{Type: hcloudgo.NetworkSubnetTypeCloud, NetworkZone: "eu-central", IPRange: strayRange},
+				},
+				Routes: []hcloudgo.NetworkRoute{
+					{Destination: destination, Gateway: net.ParseIP("10.0.1.3")},
+				},
+			}
+
+			Expect(networkDrift(spec, nil, network)).To(Equal([]string{
+				"subnets[10.0.9.0/24]: cloud/eu-central -> <absent>",
+				"routes[10.100.0.0/24]: 10.0.1.3 -> 10.0.1.2",
+			}))
+		})
+	})
+
+	Context("Handle Hetzner Cloud rate limits", func() {
+		const namespace = "default"
+
+		ctx := context.Background()
+
+		It("should requeue after the rate limit resets", func() {
+			const resourceName = "test-ratelimited-network"
+			typeNamespacedName := types.NamespacedName{
+				Name:      resourceName,
+				Namespace: namespace,
+			}
+
+			resource := &hcloudv1alpha1.HcloudNetwork{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: namespace,
+				},
+				Spec: hcloudv1alpha1.HcloudNetworkSpec{
+					Name:    resourceName,
+					IpRange: "10.0.0.0/16",
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			reset := time.Now().Add(30 * time.Second)
+			MockNetworkClient := &hcloud.MockNetworkClient{}
+			MockNetworkClient.GetNetworkByNameFunc = func(ctx context.Context, name string) (*hcloudgo.Network, *hcloudgo.Response, error) {
+				return nil, nil, fmt.Errorf("get network: %w", &hcloud.RateLimitError{Reset: reset})
+			}
+
+			reconciler := &HcloudNetworkReconciler{
+				Client:        k8sClient,
+				Scheme:        k8sClient.Scheme(),
+				NetworkClient: MockNetworkClient,
+				Recorder:      recorder,
+			}
+
+			result, err := reconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeNumerically("~", 30*time.Second, time.Second))
+
+			By("verifying the RateLimited reason was set")
+			updatedResource := &hcloudv1alpha1.HcloudNetwork{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			condition := meta.FindStatusCondition(updatedResource.Status.Conditions, "Available")
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).To(Equal(rateLimitedReason))
+
+			By("cleaning up the resource")
+			Expect(k8sClient.Delete(ctx, updatedResource)).To(Succeed())
+		})
+	})
+
+	Context("Follow Hetzner Cloud actions", func() {
+		const namespace = "default"
+
+		ctx := context.Background()
+
+		It("should surface the error of a failed action", func() {
+			const resourceName = "test-failed-action-network"
+			typeNamespacedName := types.NamespacedName{
+				Name:      resourceName,
+				Namespace: namespace,
+			}
+
+			resource := &hcloudv1alpha1.HcloudNetwork{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: namespace,
+				},
+				Spec: hcloudv1alpha1.HcloudNetworkSpec{
+					Name:    resourceName,
+					IpRange: "10.0.0.0/16",
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			By("recording a pending action")
+			resource.Status.NetworkId = 3131
+			resource.Status.PendingActions = []hcloudv1alpha1.HcloudAction{
+				{Id: 888, Command: "change_ip_range", Progress: 10},
+			}
+			Expect(k8sClient.Status().Update(ctx, resource)).To(Succeed())
+
+			MockNetworkClient := &hcloud.MockNetworkClient{}
+			MockNetworkClient.GetActionFunc = func(ctx context.Context, id int64) (*hcloudgo.Action, *hcloudgo.Response, error) {
+				return &hcloudgo.Action{
+					ID:           id,
+					Status:       hcloudgo.ActionStatusError,
+					Command:      "change_ip_range",
+					ErrorCode:    "ip_range_conflict",
+					ErrorMessage: "ip range overlaps with a subnet",
+				}, nil, nil
+			}
+			MockNetworkClient.GetNetworkByNameFunc = func(ctx context.Context, name string) (*hcloudgo.Network, *hcloudgo.Response, error) {
+				Fail("the network must not be looked up while an action failed")
+				return nil, nil, nil
+			}
+
+			reconciler := &HcloudNetworkReconciler{
+				Client:        k8sClient,
+				Scheme:        k8sClient.Scheme(),
+				NetworkClient: MockNetworkClient,
+				Recorder:      recorder,
+			}
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).To(HaveOccurred())
+
+			By("verifying the action error was reported")
+			updatedResource := &hcloudv1alpha1.HcloudNetwork{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			Expect(updatedResource.Status.PendingActions).To(BeEmpty())
+			condition := meta.FindStatusCondition(updatedResource.Status.Conditions, "Available")
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).To(Equal(actionFailedReason))
+			Expect(condition.Message).To(ContainSubstring("ip_range_conflict"))
+			Expect(condition.Message).To(ContainSubstring("ip range overlaps with a subnet"))
+
+			By("cleaning up the resource")
+			Expect(k8sClient.Delete(ctx, updatedResource)).To(Succeed())
+		})
+	})
+
+	Context("Reconcile HcloudNetwork against the fake Hetzner Cloud API", func() {
+		const namespace = "default"
+
+		ctx := context.Background()
+
+		It("should create, update and delete the network", func() {
+			const resourceName = "test-fake-api-network"
+			typeNamespacedName := types.NamespacedName{
+				Name:      resourceName,
+				Namespace: namespace,
+			}
+
+			resource := &hcloudv1alpha1.HcloudNetwork{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: namespace,
+				},
+				Spec: hcloudv1alpha1.HcloudNetworkSpec{
+					Name:    resourceName,
+					IpRange: "10.0.0.0/16",
+					Labels:  map[string]string{"env": "test"},
+					Subnets: []hcloudv1alpha1.HcloudNetworkSubnet{
+						{Type: "cloud", NetworkZone: "eu-central", IpRange: "10.0.1.0/24"},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			reconciler := &HcloudNetworkReconciler{
+				Client:        k8sClient,
+				Scheme:        k8sClient.Scheme(),
+				NetworkClient: hcloud.NewNetworkClient("fake-api-token", fakeHcloudOptions()...),
+				Recorder:      recorder,
+			}
+
+			By("creating the network and its subnet")
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			updatedResource := &hcloudv1alpha1.HcloudNetwork{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			Expect(updatedResource.Status.NetworkId).NotTo(BeZero())
+			network, ok := fakeHcloud.Network(int64(updatedResource.Status.NetworkId))
+			Expect(ok).To(BeTrue())
+			Expect(network.Labels).To(HaveKeyWithValue("env", "test"))
+			Expect(network.Subnets).To(HaveLen(1))
+			Expect(network.Subnets[0].IPRange).To(Equal("10.0.1.0/24"))
+
+			By("correcting labels changed outside of the operator")
+			fakeHcloud.UpdateNetwork(network.ID, func(network *schema.Network) {
+				network.Labels["env"] = "changed"
+			})
+			_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			network, _ = fakeHcloud.Network(network.ID)
+			Expect(network.Labels).To(HaveKeyWithValue("env", "test"))
+
+			By("deleting the network")
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, updatedResource)).To(Succeed())
+			_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, ok = fakeHcloud.Network(network.ID)
+			Expect(ok).To(BeFalse())
+			Expect(errors.IsNotFound(k8sClient.Get(ctx, typeNamespacedName, updatedResource))).To(BeTrue())
+		})
 	})
 })
@@ -0,0 +1,340 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hcloudv1alpha1 "bunskin.com/hcrm/api/v1alpha1"
+	"bunskin.com/hcrm/pkg/hcloud"
+)
+
+const (
+	// providerConfigKind is the kind of the namespaced provider config
+	providerConfigKind = "HcloudProviderConfig"
+	// providerConfigRevalidateInterval is how often the token of a provider config is validated again
+	providerConfigRevalidateInterval = 10 * time.Minute
+)
+
+// HcloudProviderConfigReconciler reconciles a HcloudProviderConfig object
+type HcloudProviderConfigReconciler struct {
+	client.Client
+	Scheme      *runtime.Scheme
+	ClientCache *hcloud.ClientCache
+	Recorder    record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=hcloud.bunskin.com,resources=hcloudproviderconfigs,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=hcloud.bunskin.com,resources=hcloudproviderconfigs/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=hcloud.bunskin.com,resources=hcloudnetworks;hclouddnszones,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
+
+// Reconcile validates the API token of the HcloudProviderConfig against Hetzner Cloud and
+// publishes the Ready condition together with the number of resources using it.
+func (r *HcloudProviderConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := logf.Log.WithName("hcloudproviderconfig-controller")
+
+	var providerConfig hcloudv1alpha1.HcloudProviderConfig
+	if err := r.Get(ctx, req.NamespacedName, &providerConfig); err != nil {
+		// object does not exist, nothing to do
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	log.Info("Reconciling HcloudProviderConfig", "name", providerConfig.Name, "namespace", providerConfig.Namespace)
+
+	ref := &hcloudv1alpha1.HcloudProviderConfigReference{Kind: providerConfigKind, Name: providerConfig.Name}
+	readyCondition := validateProviderConfig(ctx, r.Client, r.ClientCache, providerConfig.Namespace, ref)
+	readyCondition.ObservedGeneration = providerConfig.Generation
+	meta.SetStatusCondition(&providerConfig.Status.Conditions, readyCondition)
+
+	usage, err := countProviderConfigUsage(ctx, r.Client, client.InNamespace(providerConfig.Namespace), func(providerConfigRef *hcloudv1alpha1.HcloudProviderConfigReference) bool {
+		return providerConfigRef != nil && providerConfigRef.Kind != clusterProviderConfigKind && providerConfigRef.Name == providerConfig.Name
+	})
+	if err != nil {
+		log.Error(err, "Failed to count resources using HcloudProviderConfig", "name", providerConfig.Name)
+		return ctrl.Result{}, err
+	}
+	providerConfig.Status.Usage = usage
+	providerConfig.Status.ObservedGeneration = providerConfig.Generation
+
+	if err := r.Status().Update(ctx, &providerConfig); err != nil {
+		log.Error(err, "Failed to update HcloudProviderConfig status", "name", providerConfig.Name)
+		return ctrl.Result{}, err
+	}
+	if readyCondition.Status != metav1.ConditionTrue {
+		r.Recorder.Event(&providerConfig, "Warning", readyCondition.Reason, readyCondition.Message)
+	}
+
+	return ctrl.Result{RequeueAfter: providerConfigRevalidateInterval}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *HcloudProviderConfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// Recount the usage whenever a resource starts or stops referencing a provider config
+	mapReference := func(namespace string, providerConfigRef *hcloudv1alpha1.HcloudProviderConfigReference) []reconcile.Request {
+		if providerConfigRef == nil || providerConfigRef.Kind == clusterProviderConfigKind {
+			return nil
+		}
+		return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: providerConfigRef.Name, Namespace: namespace}}}
+	}
+
+	builder := ctrl.NewControllerManagedBy(mgr).
+		For(&hcloudv1alpha1.HcloudProviderConfig{}).
+		Named("hcloudproviderconfig")
+	for _, user := range providerConfigUsers {
+		builder = builder.Watches(user.newObject(), handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+			return mapReference(obj.GetNamespace(), user.providerConfigRef(obj))
+		}))
+	}
+	return builder.
+		WithEventFilter(predicate.GenerationChangedPredicate{}).
+		Complete(r)
+}
+
+// ClusterHcloudProviderConfigReconciler reconciles a ClusterHcloudProviderConfig object
+type ClusterHcloudProviderConfigReconciler struct {
+	client.Client
+	Scheme      *runtime.Scheme
+	ClientCache *hcloud.ClientCache
+	Recorder    record.EventRecorder
+	// DefaultProviderConfig is the name of the ClusterHcloudProviderConfig used for resources without a providerConfigRef
+	DefaultProviderConfig string
+}
+
+// +kubebuilder:rbac:groups=hcloud.bunskin.com,resources=clusterhcloudproviderconfigs,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=hcloud.bunskin.com,resources=clusterhcloudproviderconfigs/status,verbs=get;update;patch
+
+// Reconcile validates the API token of the ClusterHcloudProviderConfig against Hetzner Cloud and
+// publishes the Ready condition together with the number of resources using it across all namespaces.
+func (r *ClusterHcloudProviderConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := logf.Log.WithName("clusterhcloudproviderconfig-controller")
+
+	var providerConfig hcloudv1alpha1.ClusterHcloudProviderConfig
+	if err := r.Get(ctx, req.NamespacedName, &providerConfig); err != nil {
+		// object does not exist, nothing to do
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	log.Info("Reconciling ClusterHcloudProviderConfig", "name", providerConfig.Name)
+
+	ref := &hcloudv1alpha1.HcloudProviderConfigReference{Kind: clusterProviderConfigKind, Name: providerConfig.Name}
+	readyCondition := validateProviderConfig(ctx, r.Client, r.ClientCache, "", ref)
+	readyCondition.ObservedGeneration = providerConfig.Generation
+	meta.SetStatusCondition(&providerConfig.Status.Conditions, readyCondition)
+
+	isDefault := providerConfig.Name == r.DefaultProviderConfig
+	usage, err := countProviderConfigUsage(ctx, r.Client, nil, func(providerConfigRef *hcloudv1alpha1.HcloudProviderConfigReference) bool {
+		if providerConfigRef == nil {
+			return isDefault
+		}
+		return providerConfigRef.Kind == clusterProviderConfigKind && providerConfigRef.Name == providerConfig.Name
+	})
+	if err != nil {
+		log.Error(err, "Failed to count resources using ClusterHcloudProviderConfig", "name", providerConfig.Name)
+		return ctrl.Result{}, err
+	}
+	providerConfig.Status.Usage = usage
+	providerConfig.Status.ObservedGeneration = providerConfig.Generation
+
+	if err := r.Status().Update(ctx, &providerConfig); err != nil {
+		log.Error(err, "Failed to update ClusterHcloudProviderConfig status", "name", providerConfig.Name)
+		return ctrl.Result{}, err
+	}
+	if readyCondition.Status != metav1.ConditionTrue {
+		r.Recorder.Event(&providerConfig, "Warning", readyCondition.Reason, readyCondition.Message)
+	}
+
+	return ctrl.Result{RequeueAfter: providerConfigRevalidateInterval}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ClusterHcloudProviderConfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// Recount the usage whenever a resource starts or stops referencing a provider config
+	mapReference := func(providerConfigRef *hcloudv1alpha1.HcloudProviderConfigReference) []reconcile.Request {
+		switch {
+		case providerConfigRef == nil && r.DefaultProviderConfig != "":
+			return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: r.DefaultProviderConfig}}}
+		case providerConfigRef != nil && providerConfigRef.Kind == clusterProviderConfigKind:
+			return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: providerConfigRef.Name}}}
+		}
+		return nil
+	}
+
+	builder := ctrl.NewControllerManagedBy(mgr).
+		For(&hcloudv1alpha1.ClusterHcloudProviderConfig{}).
+		Named("clusterhcloudproviderconfig")
+	for _, user := range providerConfigUsers {
+		builder = builder.Watches(user.newObject(), handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+			return mapReference(user.providerConfigRef(obj))
+		}))
+	}
+	return builder.
+		WithEventFilter(predicate.GenerationChangedPredicate{}).
+		Complete(r)
+}
+
+// validateProviderConfig checks the token of the referenced provider config against the Hetzner Cloud API
+// and returns the resulting Ready condition
+func validateProviderConfig(ctx context.Context, c client.Client, clientCache *hcloud.ClientCache, namespace string, ref *hcloudv1alpha1.HcloudProviderConfigReference) metav1.Condition {
+	provider, err := resolveProvider(ctx, c, "", namespace, nil, ref)
+	if err != nil {
+		return metav1.Condition{
+			Type:    "Ready",
+			Status:  metav1.ConditionFalse,
+			Reason:  credentialsUnavailableReason,
+			Message: fmt.Sprintf("Failed to resolve Hetzner Cloud credentials: %v", err),
+		}
+	}
+	if clientCache == nil {
+		return metav1.Condition{
+			Type:    "Ready",
+			Status:  metav1.ConditionFalse,
+			Reason:  credentialsUnavailableReason,
+			Message: "No client cache configured to validate the token",
+		}
+	}
+
+	networkClient := clientCache.NetworkClient(provider.cacheKey, provider.cacheVersion, provider.token, provider.options...)
+	if _, err := networkClient.VerifyToken(ctx); err != nil {
+		if _, ok := hcloud.RateLimitReset(err); ok {
+			return metav1.Condition{
+				Type:    "Ready",
+				Status:  metav1.ConditionUnknown,
+				Reason:  rateLimitedReason,
+				Message: fmt.Sprintf("Hetzner Cloud rate limit exhausted, the API token could not be validated: %v", err),
+			}
+		}
+		return metav1.Condition{
+			Type:    "Ready",
+			Status:  metav1.ConditionFalse,
+			Reason:  "InvalidToken",
+			Message: fmt.Sprintf("Hetzner Cloud rejected the API token: %v", err),
+		}
+	}
+
+	return metav1.Condition{
+		Type:    "Ready",
+		Status:  metav1.ConditionTrue,
+		Reason:  "Ready",
+		Message: "The API token was accepted by Hetzner Cloud",
+	}
+}
+
+// providerConfigUser is a kind of resource that can reference a provider config
+type providerConfigUser struct {
+	// newObject returns an empty resource of the kind, to watch the kind
+	newObject func() client.Object
+	// newList returns an empty list of the kind, to count the resources of the kind
+	newList func() client.ObjectList
+	// providerConfigRef returns the provider config reference of a resource of the kind
+	providerConfigRef func(obj client.Object) *hcloudv1alpha1.HcloudProviderConfigReference
+	// count returns the counter of the kind in the usage of a provider config
+	count func(usage *hcloudv1alpha1.HcloudProviderConfigUsage) *int
+}
+
+// usedBy describes the kind with the list type L whose resources reference a provider config
+// through providerConfigRef and are counted in the usage field returned by count
+func usedBy[L any, PL interface {
+	*L
+	client.ObjectList
+}, T any, PT interface {
+	*T
+	client.Object
+}](providerConfigRef func(PT) *hcloudv1alpha1.HcloudProviderConfigReference, count func(*hcloudv1alpha1.HcloudProviderConfigUsage) *int) providerConfigUser {
+	return providerConfigUser{
+		newObject: func() client.Object { return PT(new(T)) },
+		newList:   func() client.ObjectList { return PL(new(L)) },
+		providerConfigRef: func(obj client.Object) *hcloudv1alpha1.HcloudProviderConfigReference {
+			return providerConfigRef(obj.(PT))
+		},
+		count: count,
+	}
+}
+
+// providerConfigUsers are the kinds counted in the usage of provider configs, their resources are
+// watched to recount the usage whenever one starts or stops referencing a provider config
+var providerConfigUsers = []providerConfigUser{
+	usedBy[hcloudv1alpha1.HcloudNetworkList](func(hcloudNetwork *hcloudv1alpha1.HcloudNetwork) *hcloudv1alpha1.HcloudProviderConfigReference {
+		return hcloudNetwork.Spec.ProviderConfigRef
+	}, func(usage *hcloudv1alpha1.HcloudProviderConfigUsage) *int {
+		return &usage.HcloudNetworks
+	}),
+	usedBy[hcloudv1alpha1.HcloudDnsZoneList](func(hcloudDnsZone *hcloudv1alpha1.HcloudDnsZone) *hcloudv1alpha1.HcloudProviderConfigReference {
+		return hcloudDnsZone.Spec.ProviderConfigRef
+	}, func(usage *hcloudv1alpha1.HcloudProviderConfigUsage) *int {
+		return &usage.HcloudDnsZones
+	}),
+	usedBy[hcloudv1alpha1.HcloudFirewallList](func(hcloudFirewall *hcloudv1alpha1.HcloudFirewall) *hcloudv1alpha1.HcloudProviderConfigReference {
+		return hcloudFirewall.Spec.ProviderConfigRef
+	}, func(usage *hcloudv1alpha1.HcloudProviderConfigUsage) *int {
+		return &usage.HcloudFirewalls
+	}),
+	usedBy[hcloudv1alpha1.HcloudServerList](func(hcloudServer *hcloudv1alpha1.HcloudServer) *hcloudv1alpha1.HcloudProviderConfigReference {
+		return hcloudServer.Spec.ProviderConfigRef
+	}, func(usage *hcloudv1alpha1.HcloudProviderConfigUsage) *int {
+		return &usage.HcloudServers
+	}),
+	usedBy[hcloudv1alpha1.HcloudServerOperationList](func(operation *hcloudv1alpha1.HcloudServerOperation) *hcloudv1alpha1.HcloudProviderConfigReference {
+		return operation.Spec.ProviderConfigRef
+	}, func(usage *hcloudv1alpha1.HcloudProviderConfigUsage) *int {
+		return &usage.HcloudServerOperations
+	}),
+	usedBy[hcloudv1alpha1.HcloudVolumeList](func(hcloudVolume *hcloudv1alpha1.HcloudVolume) *hcloudv1alpha1.HcloudProviderConfigReference {
+		return hcloudVolume.Spec.ProviderConfigRef
+	}, func(usage *hcloudv1alpha1.HcloudProviderConfigUsage) *int {
+		return &usage.HcloudVolumes
+	}),
+}
+
+// countProviderConfigUsage counts the resources of each kind whose providerConfigRef matches
+func countProviderConfigUsage(ctx context.Context, c client.Client, namespace client.ListOption, matches func(*hcloudv1alpha1.HcloudProviderConfigReference) bool) (hcloudv1alpha1.HcloudProviderConfigUsage, error) {
+	var usage hcloudv1alpha1.HcloudProviderConfigUsage
+	var opts []client.ListOption
+	if namespace != nil {
+		opts = append(opts, namespace)
+	}
+
+	for _, user := range providerConfigUsers {
+		list := user.newList()
+		if err := c.List(ctx, list, opts...); err != nil {
+			return usage, err
+		}
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return usage, err
+		}
+		for _, item := range items {
+			if matches(user.providerConfigRef(item.(client.Object))) {
+				*user.count(&usage)++
+			}
+		}
+	}
+	return usage, nil
+}
@@ -0,0 +1,377 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"net"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hcloudv1alpha1 "bunskin.com/hcrm/api/v1alpha1"
+	"bunskin.com/hcrm/pkg/hcloud"
+	hcloudgo "github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+var _ = Describe("HcloudProviderConfig Controller", func() {
+	const namespace = "default"
+
+	ctx := context.Background()
+
+	newClientCache := func(networkClient hcloud.NetworkClient, tokens *[]string) *hcloud.ClientCache {
+		clientCache := hcloud.NewClientCache()
+		clientCache.NewNetworkClientFunc = func(token string, opts ...hcloudgo.ClientOption) hcloud.NetworkClient {
+			*tokens = append(*tokens, token)
+			return networkClient
+		}
+		return clientCache
+	}
+
+	Context("Validate HcloudProviderConfig", func() {
+		It("should mark the provider config ready and count the resources using it", func() {
+			const resourceName = "test-providerconfig-ready"
+			typeNamespacedName := types.NamespacedName{
+				Name:      resourceName,
+				Namespace: namespace,
+			}
+
+			By("creating the credentials secret")
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: namespace,
+				},
+				Data: map[string][]byte{
+					"token": []byte("provider-token"),
+				},
+			}
+			Expect(k8sClient.Create(ctx, secret)).To(Succeed())
+
+			By("creating the HcloudProviderConfig resource")
+			providerConfig := &hcloudv1alpha1.HcloudProviderConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: namespace,
+				},
+				Spec: hcloudv1alpha1.HcloudProviderConfigSpec{
+					CredentialsRef: hcloudv1alpha1.HcloudSecretKeyReference{
+						Name: resourceName,
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, providerConfig)).To(Succeed())
+
+			By("creating a network referencing the provider config")
+			hcloudNetwork := &hcloudv1alpha1.HcloudNetwork{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: namespace,
+				},
+				Spec: hcloudv1alpha1.HcloudNetworkSpec{
+					Name:    resourceName,
+					IpRange: "10.0.0.0/8",
+					ProviderConfigRef: &hcloudv1alpha1.HcloudProviderConfigReference{
+						Name: resourceName,
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, hcloudNetwork)).To(Succeed())
+
+			By("creating a firewall referencing the provider config")
+			hcloudFirewall := &hcloudv1alpha1.HcloudFirewall{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: namespace,
+				},
+				Spec: hcloudv1alpha1.HcloudFirewallSpec{
+					Name: resourceName,
+					ProviderConfigRef: &hcloudv1alpha1.HcloudProviderConfigReference{
+						Name: resourceName,
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, hcloudFirewall)).To(Succeed())
+
+			By("creating a server referencing the provider config")
+			hcloudServer := &hcloudv1alpha1.HcloudServer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: namespace,
+				},
+				Spec: hcloudv1alpha1.HcloudServerSpec{
+					Name:       resourceName,
+					ServerType: "cx22",
+					Image:      "ubuntu-24.04",
+					ProviderConfigRef: &hcloudv1alpha1.HcloudProviderConfigReference{
+						Name: resourceName,
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, hcloudServer)).To(Succeed())
+
+			By("creating a server operation referencing the provider config")
+			operation := &hcloudv1alpha1.HcloudServerOperation{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: namespace,
+				},
+				Spec: hcloudv1alpha1.HcloudServerOperationSpec{
+					Server:    hcloudv1alpha1.HcloudServerOperationTarget{Name: resourceName},
+					Operation: hcloudv1alpha1.ServerOperationReboot,
+					ProviderConfigRef: &hcloudv1alpha1.HcloudProviderConfigReference{
+						Name: resourceName,
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, operation)).To(Succeed())
+
+			By("creating a volume referencing the provider config")
+			hcloudVolume := &hcloudv1alpha1.HcloudVolume{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: namespace,
+				},
+				Spec: hcloudv1alpha1.HcloudVolumeSpec{
+					Name:     resourceName,
+					Size:     20,
+					Location: "fsn1",
+					ProviderConfigRef: &hcloudv1alpha1.HcloudProviderConfigReference{
+						Name: resourceName,
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, hcloudVolume)).To(Succeed())
+
+			MockNetworkClient := &hcloud.MockNetworkClient{}
+			MockNetworkClient.VerifyTokenFunc = func(ctx context.Context) (*hcloudgo.Response, error) {
+				return nil, nil
+			}
+			var tokens []string
+
+			By("reconciling the resource")
+			reconciler := &HcloudProviderConfigReconciler{
+				Client:      k8sClient,
+				Scheme:      k8sClient.Scheme(),
+				ClientCache: newClientCache(MockNetworkClient, &tokens),
+				Recorder:    recorder,
+			}
+
+			result, err := reconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(providerConfigRevalidateInterval))
+			Expect(tokens).To(Equal([]string{"provider-token"}))
+
+			By("verifying the status was updated")
+			updatedProviderConfig := &hcloudv1alpha1.HcloudProviderConfig{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedProviderConfig)).To(Succeed())
+			Expect(updatedProviderConfig.Status.Usage.HcloudNetworks).To(Equal(1))
+			Expect(updatedProviderConfig.Status.Usage.HcloudDnsZones).To(Equal(0))
+			Expect(updatedProviderConfig.Status.Usage.HcloudFirewalls).To(Equal(1))
+			Expect(updatedProviderConfig.Status.Usage.HcloudServers).To(Equal(1))
+			Expect(updatedProviderConfig.Status.Usage.HcloudServerOperations).To(Equal(1))
+			Expect(updatedProviderConfig.Status.Usage.HcloudVolumes).To(Equal(1))
+			condition := meta.FindStatusCondition(updatedProviderConfig.Status.Conditions, "Ready")
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+
+			By("cleaning up the resources")
+			Expect(k8sClient.Delete(ctx, hcloudNetwork)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, hcloudFirewall)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, hcloudServer)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, operation)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, hcloudVolume)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, updatedProviderConfig)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, secret)).To(Succeed())
+		})
+
+		It("should report an invalid token", func() {
+			const resourceName = "test-providerconfig-invalid"
+			typeNamespacedName := types.NamespacedName{
+				Name:      resourceName,
+				Namespace: namespace,
+			}
+
+			By("creating the credentials secret")
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: namespace,
+				},
+				Data: map[string][]byte{
+					"token": []byte("revoked-token"),
+				},
+			}
+			Expect(k8sClient.Create(ctx, secret)).To(Succeed())
+
+			By("creating the HcloudProviderConfig resource")
+			providerConfig := &hcloudv1alpha1.HcloudProviderConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: namespace,
+				},
+				Spec: hcloudv1alpha1.HcloudProviderConfigSpec{
+					CredentialsRef: hcloudv1alpha1.HcloudSecretKeyReference{
+						Name: resourceName,
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, providerConfig)).To(Succeed())
+
+			MockNetworkClient := &hcloud.MockNetworkClient{}
+			MockNetworkClient.VerifyTokenFunc = func(ctx context.Context) (*hcloudgo.Response, error) {
+				return nil, errors.New("unable to authenticate")
+			}
+			var tokens []string
+
+			By("reconciling the resource")
+			reconciler := &HcloudProviderConfigReconciler{
+				Client:      k8sClient,
+				Scheme:      k8sClient.Scheme(),
+				ClientCache: newClientCache(MockNetworkClient, &tokens),
+				Recorder:    recorder,
+			}
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			By("verifying the Ready condition is false")
+			updatedProviderConfig := &hcloudv1alpha1.HcloudProviderConfig{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedProviderConfig)).To(Succeed())
+			condition := meta.FindStatusCondition(updatedProviderConfig.Status.Conditions, "Ready")
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).To(Equal("InvalidToken"))
+
+			By("cleaning up the resources")
+			Expect(k8sClient.Delete(ctx, updatedProviderConfig)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, secret)).To(Succeed())
+		})
+	})
+
+	Context("Use the default ClusterHcloudProviderConfig", func() {
+		It("should apply the default labels and sync policy to a network", func() {
+			const resourceName = "test-providerconfig-default"
+			typeNamespacedName := types.NamespacedName{
+				Name:      resourceName,
+				Namespace: namespace,
+			}
+
+			By("creating the credentials secret")
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: namespace,
+				},
+				Data: map[string][]byte{
+					"token": []byte("cluster-token"),
+				},
+			}
+			Expect(k8sClient.Create(ctx, secret)).To(Succeed())
+
+			By("creating the ClusterHcloudProviderConfig resource")
+			providerConfig := &hcloudv1alpha1.ClusterHcloudProviderConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: resourceName,
+				},
+				Spec: hcloudv1alpha1.HcloudProviderConfigSpec{
+					CredentialsRef: hcloudv1alpha1.HcloudSecretKeyReference{
+						Name:      resourceName,
+						Namespace: namespace,
+					},
+					DefaultLabels: map[string]string{
+						"team": "platform",
+						"env":  "default",
+					},
+					DefaultSyncPolicy: "orphan",
+				},
+			}
+			Expect(k8sClient.Create(ctx, providerConfig)).To(Succeed())
+
+			By("creating the HcloudNetwork resource")
+			hcloudNetwork := &hcloudv1alpha1.HcloudNetwork{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: namespace,
+				},
+				Spec: hcloudv1alpha1.HcloudNetworkSpec{
+					Name:    resourceName,
+					IpRange: "10.0.0.0/8",
+					Labels: map[string]string{
+						"env": "test",
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, hcloudNetwork)).To(Succeed())
+
+			MockNetworkClient := &hcloud.MockNetworkClient{}
+			MockNetworkClient.GetNetworkByNameFunc = func(ctx context.Context, name string) (*hcloudgo.Network, *hcloudgo.Response, error) {
+				return nil, nil, nil
+			}
+			var createdLabels map[string]string
+			MockNetworkClient.CreateNetworkFunc = func(ctx context.Context, name string, ipRange string, labels map[string]string) (*hcloudgo.Network, *hcloudgo.Response, error) {
+				createdLabels = labels
+				_, cidr, _ := net.ParseCIDR(ipRange)
+				return &hcloudgo.Network{ID: 67890, Name: name, IPRange: cidr, Labels: labels}, nil, nil
+			}
+			var tokens []string
+
+			By("reconciling the resource")
+			reconciler := &HcloudNetworkReconciler{
+				Client:                k8sClient,
+				Scheme:                k8sClient.Scheme(),
+				ClientCache:           newClientCache(MockNetworkClient, &tokens),
+				Recorder:              recorder,
+				DefaultProviderConfig: resourceName,
+			}
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tokens).To(Equal([]string{"cluster-token"}))
+			Expect(createdLabels).To(Equal(map[string]string{
+				"team":                        "platform",
+				"env":                         "test",
+				hcloudv1alpha1.NamespaceLabel: namespace,
+				hcloudv1alpha1.NameLabel:      resourceName,
+				hcloudv1alpha1.OwnerUIDLabel:  string(hcloudNetwork.UID),
+			}))
+
+			By("verifying the default sync policy was applied")
+			updatedNetwork := &hcloudv1alpha1.HcloudNetwork{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedNetwork)).To(Succeed())
+			Expect(updatedNetwork.Annotations[syncPolicy]).To(Equal("orphan"))
+
+			By("cleaning up the resources")
+			Expect(k8sClient.Delete(ctx, updatedNetwork)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, providerConfig)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, secret)).To(Succeed())
+		})
+	})
+})
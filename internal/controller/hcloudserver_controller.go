@@ -0,0 +1,1034 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	hcloudv1alpha1 "bunskin.com/hcrm/api/v1alpha1"
+	"bunskin.com/hcrm/pkg/hcloud"
+	hcloudgo "github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+const (
+	// requiresReplacementCondition is the condition type reporting spec changes that cannot be made in place
+	requiresReplacementCondition = "RequiresReplacement"
+	// creatingReason is the condition reason while the server is being created
+	creatingReason = "Creating"
+)
+
+// HcloudServerReconciler reconciles a HcloudServer object
+type HcloudServerReconciler struct {
+	client.Client
+	Scheme       *runtime.Scheme
+	ServerClient hcloud.ServerClient
+	ClientCache  *hcloud.ClientCache
+	Recorder     record.EventRecorder
+	// DefaultProviderConfig is the name of the ClusterHcloudProviderConfig used for resources without a providerConfigRef
+	DefaultProviderConfig string
+	// ResyncInterval is the interval after which reconciled resources are checked for drift, zero disables it
+	ResyncInterval time.Duration
+	// ClusterID identifies the cluster in the ownership labels of cloud resources
+	ClusterID string
+	// Pause pauses the reconciliation of whole kinds or namespaces, nil if it cannot be paused by the manager
+	Pause *PauseSwitch
+	// APIReader reads the ConfigMaps holding user data, which are not cached to avoid caching all
+	// ConfigMaps of the cluster. The Client is used when nil.
+	APIReader client.Reader
+}
+
+// +kubebuilder:rbac:groups=hcloud.bunskin.com,resources=hcloudservers,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=hcloud.bunskin.com,resources=hcloudservers/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=hcloud.bunskin.com,resources=hcloudservers/finalizers,verbs=update
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get
+// +kubebuilder:rbac:groups=hcloud.bunskin.com,resources=hcloudproviderconfigs;clusterhcloudproviderconfigs,verbs=get;list;watch
+// +kubebuilder:rbac:groups=hcloud.bunskin.com,resources=hcloudnetworks;hcloudfirewalls,verbs=get;list;watch
+
+// Reconcile looks up the Hetzner Cloud server by spec.name and adopts, updates or creates it
+// according to the sync policy of the HcloudServer resource. Labels, networks and firewalls are
+// changed in place, other differences from the spec are only reported on the RequiresReplacement
+// condition, as applying them would need the server to be shut down, rebuilt or recreated.
+func (r *HcloudServerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	result, err := r.reconcile(ctx, req)
+	if reset, ok := hcloud.RateLimitReset(err); ok {
+		var hcloudServer hcloudv1alpha1.HcloudServer
+		if err := r.Get(ctx, req.NamespacedName, &hcloudServer); err != nil {
+			return ctrl.Result{}, client.IgnoreNotFound(err)
+		}
+		return requeueRateLimited(ctx, r.Client, r.Recorder, &hcloudServer, &hcloudServer.Status.Conditions, reset, err)
+	}
+	return result, err
+}
+
+// reconcile implements Reconcile, errors caused by the Hetzner Cloud rate limit are handled by the caller
+func (r *HcloudServerReconciler) reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := logf.Log.WithName("hcloudserver-controller")
+
+	// Fetch the HcloudServer resource
+	var hcloudServer hcloudv1alpha1.HcloudServer
+	if err := r.Get(ctx, req.NamespacedName, &hcloudServer); err != nil {
+		// object does not exist, nothing to do
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	// A paused resource is left alone, including its deletion
+	if paused, result, err := reconcilePaused(ctx, r.Client, r.Recorder, r.Pause, "HcloudServer", &hcloudServer, &hcloudServer.Status.Conditions); paused {
+		return result, err
+	}
+
+	log.Info("Reconciling HcloudServer", "name", hcloudServer.Name, "namespace", hcloudServer.Namespace)
+	meta.SetStatusCondition(&hcloudServer.Status.Conditions, metav1.Condition{
+		Type:               "Available",
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: hcloudServer.Generation,
+		Reason:             "Progressing",
+		Message:            "HcloudServer resource reconciliation in progress",
+	})
+	if err := r.Status().Update(ctx, &hcloudServer); err != nil {
+		log.Error(err, "Failed to update HcloudServer status", "name", hcloudServer.Name)
+		return ctrl.Result{}, err
+	}
+
+	// Handle deletion with finalizer
+	if hcloudServer.DeletionTimestamp != nil {
+		return r.reconcileDelete(ctx, &hcloudServer)
+	}
+
+	// Resolve the Hetzner Cloud client for this resource
+	serverClient, provider, err := r.serverClientFor(ctx, &hcloudServer)
+	if err != nil {
+		log.Error(err, "Failed to resolve Hetzner Cloud credentials", "name", hcloudServer.Name)
+		meta.SetStatusCondition(&hcloudServer.Status.Conditions, metav1.Condition{
+			Type:               "Available",
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: hcloudServer.Generation,
+			Reason:             credentialsUnavailableReason,
+			Message:            fmt.Sprintf("Failed to resolve Hetzner Cloud credentials: %v", err),
+		})
+		if err := r.Status().Update(ctx, &hcloudServer); err != nil {
+			log.Error(err, "Failed to update HcloudServer status", "name", hcloudServer.Name)
+		}
+		r.Recorder.Eventf(&hcloudServer, "Warning", credentialsUnavailableReason, "Failed to resolve Hetzner Cloud credentials: %v", err)
+
+		return ctrl.Result{}, err
+	}
+
+	// Add sync policy annotation if neither it nor the sync policy mode is present
+	if hcloudServer.Annotations[syncPolicy] == "" && (hcloudServer.Spec.SyncPolicy == nil || hcloudServer.Spec.SyncPolicy.Mode == "") {
+		log.Info("Adding sync policy annotation", "name", hcloudServer.Name)
+		if hcloudServer.Annotations == nil {
+			hcloudServer.Annotations = make(map[string]string)
+		}
+		hcloudServer.Annotations[syncPolicy] = string(provider.syncPolicy())
+		if err := r.Update(ctx, &hcloudServer); err != nil {
+			log.Error(err, "Failed to add sync policy annotation", "name", hcloudServer.Name)
+			return ctrl.Result{}, err
+		}
+	}
+
+	policy, err := resolveSyncPolicy(&hcloudServer, hcloudServer.Spec.SyncPolicy, provider.syncPolicy(), hcloudv1alpha1.HcloudServerIgnorableFields)
+	if err != nil {
+		log.Error(err, "Invalid sync policy", "name", hcloudServer.Name)
+		meta.SetStatusCondition(&hcloudServer.Status.Conditions, metav1.Condition{
+			Type:               "Available",
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: hcloudServer.Generation,
+			Reason:             invalidSyncPolicyReason,
+			Message:            err.Error(),
+		})
+		if err := r.Status().Update(ctx, &hcloudServer); err != nil {
+			log.Error(err, "Failed to update HcloudServer status", "name", hcloudServer.Name)
+			return ctrl.Result{}, err
+		}
+		r.Recorder.Eventf(&hcloudServer, "Warning", invalidSyncPolicyReason, "Sync policy of server %s is invalid: %v", hcloudServer.Spec.Name, err)
+
+		// The sync policy has to change before the server can be reconciled
+		return ctrl.Result{}, nil
+	}
+
+	// Add finalizer if not present and sync policy supports it
+	if !controllerutil.ContainsFinalizer(&hcloudServer, finalizerName) && policy.claims() {
+		log.Info("Adding finalizer", "name", hcloudServer.Name)
+		controllerutil.AddFinalizer(&hcloudServer, finalizerName)
+		if err := r.Update(ctx, &hcloudServer); err != nil {
+			log.Error(err, "Failed to add finalizer", "name", hcloudServer.Name)
+			return ctrl.Result{}, err
+		}
+	}
+
+	// Labels of the provider config are applied below the labels of the resource
+	desiredLabels := provider.labels(hcloudServer.Spec.Labels)
+
+	// Follow the create action and the actions started after it before comparing the server with the spec
+	if len(hcloudServer.Status.PendingActions) > 0 {
+		done, result, err := awaitPendingActions(ctx, r.Client, r.Recorder, &hcloudServer, &hcloudServer.Status.PendingActions, &hcloudServer.Status.Conditions, serverClient.GetAction, provider.actionPollInterval())
+		if !done {
+			return result, err
+		}
+	}
+
+	// A server pinned by the external-id annotation is never looked up by name or created
+	pinnedID, err := externalID(&hcloudServer)
+	if err != nil {
+		log.Error(err, "Invalid external ID annotation", "name", hcloudServer.Name)
+		meta.SetStatusCondition(&hcloudServer.Status.Conditions, metav1.Condition{
+			Type:               "Available",
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: hcloudServer.Generation,
+			Reason:             invalidExternalIDReason,
+			Message:            err.Error(),
+		})
+		if err := r.Status().Update(ctx, &hcloudServer); err != nil {
+			log.Error(err, "Failed to update HcloudServer status", "name", hcloudServer.Name)
+			return ctrl.Result{}, err
+		}
+		r.Recorder.Eventf(&hcloudServer, "Warning", invalidExternalIDReason, "Cannot adopt server for %s: %v", hcloudServer.Name, err)
+
+		// The annotation has to change before the server can be found
+		return ctrl.Result{}, nil
+	}
+
+	// Adopt existing server if it exists
+	server, response, err := findServer(ctx, serverClient, &hcloudServer, pinnedID)
+	if err != nil {
+		log.Error(err, "Failed to get server from Hetzner Cloud", "name", hcloudServer.Spec.Name)
+		meta.SetStatusCondition(&hcloudServer.Status.Conditions, metav1.Condition{
+			Type:               "Available",
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: hcloudServer.Generation,
+			Reason:             "Failed",
+			Message:            fmt.Sprintf("Failed to get server from Hetzner Cloud: %v. %v", err, response),
+		})
+		if err := r.Status().Update(ctx, &hcloudServer); err != nil {
+			log.Error(err, "Failed to update HcloudServer status", "name", hcloudServer.Name)
+		}
+		r.Recorder.Eventf(&hcloudServer, "Warning", "UpdateFailed", "Failed to get server %s from Hetzner cloud", hcloudServer.Spec.Name)
+
+		return ctrl.Result{}, err
+	}
+
+	if server == nil && pinnedID != 0 {
+		log.Info("Server pinned by the external ID not found in Hetzner Cloud", "serverId", pinnedID)
+		meta.SetStatusCondition(&hcloudServer.Status.Conditions, metav1.Condition{
+			Type:               "Available",
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: hcloudServer.Generation,
+			Reason:             externalResourceNotFoundReason,
+			Message:            fmt.Sprintf("Server %d set by the %s annotation not found in Hetzner Cloud", pinnedID, hcloudv1alpha1.ExternalIDAnnotation),
+		})
+		if err := r.Status().Update(ctx, &hcloudServer); err != nil {
+			log.Error(err, "Failed to update HcloudServer status", "name", hcloudServer.Name)
+			return ctrl.Result{}, err
+		}
+		r.Recorder.Eventf(&hcloudServer, "Warning", externalResourceNotFoundReason, "Server %d not found in Hetzner cloud", pinnedID)
+
+		return ctrl.Result{RequeueAfter: resyncAfter(&hcloudServer, r.ResyncInterval)}, nil
+	}
+
+	if server != nil && policy.claims() {
+		// A server managed by another resource or cluster is left alone unless it is taken over,
+		// resources whose sync policy does not claim it may still observe it
+		if owner := claimant(server.Labels, &hcloudServer, r.ClusterID); owner != "" && !takeover(&hcloudServer) {
+			log.Info("Server is already claimed by another resource", "serverId", server.ID, "owner", owner)
+			meta.SetStatusCondition(&hcloudServer.Status.Conditions, metav1.Condition{
+				Type:               "Available",
+				Status:             metav1.ConditionFalse,
+				ObservedGeneration: hcloudServer.Generation,
+				Reason:             alreadyClaimedReason,
+				Message:            fmt.Sprintf("Server %d is already managed by %s", server.ID, owner),
+			})
+			if err := r.Status().Update(ctx, &hcloudServer); err != nil {
+				log.Error(err, "Failed to update HcloudServer status", "name", hcloudServer.Name)
+				return ctrl.Result{}, err
+			}
+			r.Recorder.Eventf(&hcloudServer, "Warning", alreadyClaimedReason, "Server %s (%d) is already managed by %s, set the %s annotation to take it over", server.Name, server.ID, owner, hcloudv1alpha1.TakeoverAnnotation)
+
+			return ctrl.Result{RequeueAfter: resyncAfter(&hcloudServer, r.ResyncInterval)}, nil
+		} else if owner != "" {
+			log.Info("Taking over server claimed by another resource", "serverId", server.ID, "owner", owner)
+			r.Recorder.Eventf(&hcloudServer, "Warning", takenOverReason, "Server %s (%d) was taken over from %s", server.Name, server.ID, owner)
+		}
+	}
+
+	if server == nil && !policy.creates() {
+		log.Info("Server not found in Hetzner Cloud and sync policy does not create it; skipping creation", "name", hcloudServer.Spec.Name, "syncPolicy", policy.mode)
+		meta.SetStatusCondition(&hcloudServer.Status.Conditions, metav1.Condition{
+			Type:               "Available",
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: hcloudServer.Generation,
+			Reason:             "Failed",
+			Message:            fmt.Sprintf("Server not found in Hetzner Cloud and sync policy is %s", policy.mode),
+		})
+		if err := r.Status().Update(ctx, &hcloudServer); err != nil {
+			log.Error(err, "Failed to update HcloudServer status", "name", hcloudServer.Name)
+			return ctrl.Result{}, err
+		}
+		r.Recorder.Eventf(&hcloudServer, "Warning", "Failed", "Server %s not found in Hetzner cloud", hcloudServer.Spec.Name)
+
+		return ctrl.Result{RequeueAfter: resyncAfter(&hcloudServer, r.ResyncInterval)}, nil
+	}
+
+	// Resolve the networks and firewalls referenced by the spec, those left to other tools are only
+	// needed to create the server
+	var networks []serverNetwork
+	if server == nil || !policy.ignores("networks") {
+		networks, err = resolveServerNetworks(ctx, r.Client, hcloudServer.Namespace, hcloudServer.Spec.Networks)
+		if err != nil {
+			return r.referencesUnresolved(ctx, &hcloudServer, err)
+		}
+	}
+	var firewallIDs []int64
+	if server == nil || !policy.ignores("firewalls") {
+		firewallIDs, err = resolveServerFirewalls(ctx, r.Client, hcloudServer.Namespace, hcloudServer.Spec.Firewalls)
+		if err != nil {
+			return r.referencesUnresolved(ctx, &hcloudServer, err)
+		}
+	}
+
+	if server == nil {
+		return r.createServer(ctx, serverClient, provider, &hcloudServer, desiredLabels, networks, firewallIDs)
+	}
+
+	log.Info("Found existing server in Hetzner Cloud", "serverId", server.ID)
+
+	if server.Name != hcloudServer.Spec.Name {
+		log.Info("Server was renamed outside of the operator", "serverId", server.ID, "name", server.Name, "specName", hcloudServer.Spec.Name)
+		r.Recorder.Eventf(&hcloudServer, "Warning", renamedReason, "Server %d is named %s in Hetzner cloud instead of %s", server.ID, server.Name, hcloudServer.Spec.Name)
+	}
+
+	// Fields left to other tools are neither updated nor reported as drift
+	if policy.ignores("labels") {
+		desiredLabels = nil
+	}
+
+	// Differences found while the spec is unchanged were made outside of the operator
+	var drift []string
+	if policy.reportsDrift() && (!policy.updates() || hcloudServer.Status.ObservedGeneration == hcloudServer.Generation) {
+		drift = serverDrift(desiredLabels, networks, firewallIDs, server, policy)
+	}
+	if len(drift) > 0 {
+		log.Info("Server drifted from its spec", "serverId", server.ID, "drift", drift)
+		r.Recorder.Eventf(&hcloudServer, "Warning", driftedCondition, "Server %s drifted from its spec: %s", hcloudServer.Spec.Name, strings.Join(drift, "; "))
+	}
+
+	// Networks with a fixed IP cannot be attached by the create request, they are attached once the
+	// server created by this resource exists, even when the sync policy does not update servers
+	completingCreate := hcloudServer.Status.ObservedGeneration == 0 && server.Labels[hcloudv1alpha1.OwnerUIDLabel] == string(hcloudServer.UID)
+
+	// Update the existing server if sync policy allows it
+	if policy.updates() {
+		ownedLabels := withOwnershipLabels(desiredLabels, server.Labels, &hcloudServer, r.ClusterID)
+		if !equality.Semantic.DeepEqual(ownedLabels, server.Labels) {
+			log.Info("Server labels differ, updating", "current", server.Labels, "desired", ownedLabels)
+			updatedServer, response, err := serverClient.UpdateServerLabels(ctx, server, ownedLabels)
+			if err != nil {
+				log.Error(err, "Failed to update server labels in Hetzner Cloud", "serverId", server.ID)
+				return r.updateFailed(ctx, &hcloudServer, fmt.Errorf("failed to update server in Hetzner Cloud: %w. %v", err, response))
+			}
+			server = updatedServer
+		}
+	} else {
+		log.Info("Sync policy does not update existing servers; skipping updates", "serverId", server.ID, "syncPolicy", policy.mode)
+	}
+	if !policy.ignores("networks") && (policy.updates() || completingCreate) {
+		server, err = r.reconcileNetworks(ctx, serverClient, server, networks)
+		if err != nil {
+			log.Error(err, "Failed to reconcile server networks in Hetzner Cloud", "serverId", server.ID)
+			return r.updateFailed(ctx, &hcloudServer, err)
+		}
+	}
+	if !policy.ignores("firewalls") && policy.updates() {
+		server, err = r.reconcileFirewalls(ctx, serverClient, server, firewallIDs)
+		if err != nil {
+			log.Error(err, "Failed to reconcile server firewalls in Hetzner Cloud", "serverId", server.ID)
+			return r.updateFailed(ctx, &hcloudServer, err)
+		}
+	}
+
+	if policy.reportsDrift() {
+		setDriftCondition(&hcloudServer.Status.Conditions, hcloudServer.Generation, drift, policy.updates())
+	} else {
+		meta.RemoveStatusCondition(&hcloudServer.Status.Conditions, driftedCondition)
+	}
+
+	// Update the resource status with the server details and conditions
+	setServerStatus(&hcloudServer.Status, server, networks)
+	replacements := replacementChanges(hcloudServer.Spec, server)
+	replacementReported := setReplacementCondition(&hcloudServer.Status.Conditions, hcloudServer.Generation, replacements)
+	meta.SetStatusCondition(&hcloudServer.Status.Conditions, metav1.Condition{
+		Type:               "Available",
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: hcloudServer.Generation,
+		Reason:             "Ready",
+		Message:            fmt.Sprintf("Server ID %d reconciled successfully", server.ID),
+	})
+	hcloudServer.Status.ObservedGeneration = hcloudServer.Generation
+
+	if err := r.Status().Update(ctx, &hcloudServer); err != nil {
+		log.Error(err, "Failed to update HcloudServer status", "name", hcloudServer.Name)
+		return ctrl.Result{}, err
+	}
+
+	if replacementReported && len(replacements) > 0 {
+		r.Recorder.Eventf(&hcloudServer, "Warning", requiresReplacementCondition, "Server %s cannot be changed in place: %s", hcloudServer.Spec.Name, strings.Join(replacements, "; "))
+	}
+	r.Recorder.Eventf(&hcloudServer, "Normal", "Ready", "HcloudServer updated %d", hcloudServer.Status.ServerId)
+
+	log.Info("HcloudServer resource reconciled successfully", "name", hcloudServer.Name)
+	return ctrl.Result{RequeueAfter: resyncAfter(&hcloudServer, r.ResyncInterval)}, nil
+}
+
+// createServer creates the Hetzner Cloud server of the HcloudServer. The SSH keys and user data are
+// only resolved here, as they cannot be changed on an existing server. The create action and the
+// actions started after it are followed by later reconciles, which also attach the networks with a
+// fixed IP.
+func (r *HcloudServerReconciler) createServer(ctx context.Context, serverClient hcloud.ServerClient, provider *hcloudProvider, hcloudServer *hcloudv1alpha1.HcloudServer, desiredLabels map[string]string, networks []serverNetwork, firewallIDs []int64) (ctrl.Result, error) {
+	log := logf.Log.WithName("hcloudserver-controller")
+
+	configMaps := r.APIReader
+	if configMaps == nil {
+		configMaps = r.Client
+	}
+	userData, err := resolveUserData(ctx, r.Client, configMaps, hcloudServer.Namespace, hcloudServer.Spec.UserData)
+	if err != nil {
+		return r.referencesUnresolved(ctx, hcloudServer, err)
+	}
+	sshKeys, err := resolveSSHKeys(ctx, serverClient.GetSSHKeyByName, hcloudServer.Spec.SSHKeys)
+	if _, ok := hcloud.RateLimitReset(err); ok {
+		return ctrl.Result{}, err
+	}
+	if err != nil {
+		return r.referencesUnresolved(ctx, hcloudServer, err)
+	}
+
+	log.Info("Server not found in Hetzner Cloud, creating new server", "name", hcloudServer.Spec.Name)
+	opts := serverCreateOpts(hcloudServer.Spec, withOwnershipLabels(desiredLabels, nil, hcloudServer, r.ClusterID), userData, sshKeys, networks, firewallIDs)
+	result, response, err := serverClient.CreateServer(ctx, opts)
+	if err != nil {
+		log.Error(err, "Failed to create server in Hetzner Cloud", "name", hcloudServer.Spec.Name)
+		meta.SetStatusCondition(&hcloudServer.Status.Conditions, metav1.Condition{
+			Type:               "Available",
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: hcloudServer.Generation,
+			Reason:             "Failed",
+			Message:            fmt.Sprintf("Failed to create server in Hetzner Cloud: %v. %v", err, response),
+		})
+		if err := r.Status().Update(ctx, hcloudServer); err != nil {
+			log.Error(err, "Failed to update HcloudServer status", "name", hcloudServer.Name)
+		}
+		r.Recorder.Eventf(hcloudServer, "Warning", "CreateFailed", "Failed to create server %s in Hetzner cloud", hcloudServer.Spec.Name)
+
+		return ctrl.Result{}, err
+	}
+
+	log.Info("Started creating server in Hetzner Cloud", "serverId", result.Server.ID)
+	setServerStatus(&hcloudServer.Status, result.Server, networks)
+	for _, action := range append([]*hcloudgo.Action{result.Action}, result.NextActions...) {
+		if actionPending(action) {
+			recordPendingAction(&hcloudServer.Status.PendingActions, &hcloudServer.Status.Conditions, hcloudServer.Generation, action)
+		}
+	}
+	meta.SetStatusCondition(&hcloudServer.Status.Conditions, metav1.Condition{
+		Type:               "Available",
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: hcloudServer.Generation,
+		Reason:             creatingReason,
+		Message:            fmt.Sprintf("Server %d is being created in Hetzner Cloud", result.Server.ID),
+	})
+	if err := r.Status().Update(ctx, hcloudServer); err != nil {
+		log.Error(err, "Failed to update HcloudServer status", "name", hcloudServer.Name)
+		return ctrl.Result{}, err
+	}
+	r.Recorder.Eventf(hcloudServer, "Normal", creatingReason, "Creating server %s with ID %d", hcloudServer.Spec.Name, result.Server.ID)
+
+	return ctrl.Result{RequeueAfter: provider.actionPollInterval()}, nil
+}
+
+// reconcileDelete deletes or releases the server of a deleted HcloudServer according to its sync
+// policy and removes the finalizer. The delete action is not awaited, the server is gone for the
+// operator once Hetzner Cloud accepted its deletion.
+func (r *HcloudServerReconciler) reconcileDelete(ctx context.Context, hcloudServer *hcloudv1alpha1.HcloudServer) (ctrl.Result, error) {
+	log := logf.Log.WithName("hcloudserver-controller")
+
+	log.Info("HcloudServer resource is being deleted", "name", hcloudServer.Name)
+	meta.SetStatusCondition(&hcloudServer.Status.Conditions, metav1.Condition{
+		Type:               "Available",
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: hcloudServer.Generation,
+		Reason:             "Deleting",
+		Message:            "HcloudServer resource is being deleted",
+	})
+	if err := r.Status().Update(ctx, hcloudServer); err != nil {
+		log.Error(err, "Failed to update HcloudServer status", "name", hcloudServer.Name)
+		return ctrl.Result{}, err
+	}
+
+	if !controllerutil.ContainsFinalizer(hcloudServer, finalizerName) {
+		return ctrl.Result{}, nil
+	}
+
+	policy, policyErr := resolveSyncPolicy(hcloudServer, hcloudServer.Spec.SyncPolicy, hcloudv1alpha1.SyncPolicyManage, hcloudv1alpha1.HcloudServerIgnorableFields)
+	if policyErr != nil {
+		// A sync policy that cannot be understood never deletes the server
+		log.Error(policyErr, "Invalid sync policy, will not remove cloud resource", "name", hcloudServer.Name)
+		r.Recorder.Eventf(hcloudServer, "Warning", invalidSyncPolicyReason, "Server %d is left in Hetzner cloud: %v", hcloudServer.Status.ServerId, policyErr)
+	} else if hcloudServer.Status.ServerId != 0 && policy.deletes() {
+		// Delete the server from Hetzner Cloud if it exists
+		serverClient, _, err := r.serverClientFor(ctx, hcloudServer)
+		if err != nil {
+			log.Error(err, "Failed to resolve Hetzner Cloud credentials", "name", hcloudServer.Name)
+			meta.SetStatusCondition(&hcloudServer.Status.Conditions, metav1.Condition{
+				Type:               "Available",
+				Status:             metav1.ConditionFalse,
+				ObservedGeneration: hcloudServer.Generation,
+				Reason:             credentialsUnavailableReason,
+				Message:            fmt.Sprintf("Failed to resolve Hetzner Cloud credentials: %v", err),
+			})
+			if err := r.Status().Update(ctx, hcloudServer); err != nil {
+				log.Error(err, "Failed to update HcloudServer status", "name", hcloudServer.Name)
+			}
+			r.Recorder.Eventf(hcloudServer, "Warning", credentialsUnavailableReason, "Failed to resolve Hetzner Cloud credentials: %v", err)
+
+			return ctrl.Result{}, err
+		}
+
+		log.Info("Fetching Hetzner Cloud server for deletion", "serverId", hcloudServer.Status.ServerId)
+		server, response, err := serverClient.GetServerById(ctx, int64(hcloudServer.Status.ServerId))
+		if err != nil {
+			log.Error(err, "Failed to get server from Hetzner Cloud", "serverId", hcloudServer.Status.ServerId)
+			return r.deletionFailed(ctx, hcloudServer, fmt.Errorf("failed to get server for deletion: %w. %v", err, response))
+		}
+
+		owner := ""
+		if server != nil {
+			owner = claimant(server.Labels, hcloudServer, r.ClusterID)
+		}
+
+		if owner != "" {
+			// A server taken over by another resource is left to it, only its ID is dropped
+			log.Info("Server is managed by another resource, will not remove it", "serverId", hcloudServer.Status.ServerId, "owner", owner)
+			r.Recorder.Eventf(hcloudServer, "Warning", alreadyClaimedReason, "Server %d is left in Hetzner cloud as it is managed by %s", hcloudServer.Status.ServerId, owner)
+			hcloudServer.Status.ServerId = 0
+			if err := r.Status().Update(ctx, hcloudServer); err != nil {
+				log.Error(err, "Failed to update HcloudServer status", "name", hcloudServer.Name)
+				return ctrl.Result{}, err
+			}
+		} else if server != nil {
+			log.Info("Deleting Hetzner Cloud server", "serverId", server.ID)
+			_, response, err := serverClient.DeleteServer(ctx, server)
+			// A server deleted by someone else since it was fetched is gone all the same
+			if err != nil && !hcloudgo.IsError(err, hcloudgo.ErrorCodeNotFound) {
+				log.Error(err, "Failed to delete server from Hetzner Cloud", "serverId", server.ID)
+				return r.deletionFailed(ctx, hcloudServer, fmt.Errorf("failed to delete server from Hetzner Cloud: %w. %v", err, response))
+			}
+
+			log.Info("Successfully deleted Hetzner Cloud server", "serverId", server.ID)
+			r.Recorder.Eventf(hcloudServer, "Normal", "Deleted", "HcloudServer %s deleted successfully", hcloudServer.Spec.Name)
+		} else {
+			log.Info("Server not found in Hetzner Cloud, nothing to delete", "serverId", hcloudServer.Status.ServerId)
+		}
+	} else if hcloudServer.Status.ServerId != 0 {
+		log.Info("Sync policy does not delete the cloud resource, will not remove it", "syncPolicy", policy.mode)
+		r.releaseServer(ctx, hcloudServer)
+	}
+
+	// Remove finalizer
+	controllerutil.RemoveFinalizer(hcloudServer, finalizerName)
+	if err := r.Update(ctx, hcloudServer); err != nil {
+		log.Error(err, "Failed to remove finalizer", "name", hcloudServer.Name)
+		return ctrl.Result{}, err
+	}
+	log.Info("Finalizer removed, resource deletion complete", "name", hcloudServer.Name)
+	return ctrl.Result{}, nil
+}
+
+// referencesUnresolved reports networks, firewalls, SSH keys or user data that cannot be resolved.
+// The referenced HcloudNetworks and HcloudFirewalls are watched, the resource is retried after the
+// resync interval for the other references.
+func (r *HcloudServerReconciler) referencesUnresolved(ctx context.Context, hcloudServer *hcloudv1alpha1.HcloudServer, err error) (ctrl.Result, error) {
+	log := logf.Log.WithName("hcloudserver-controller")
+
+	log.Error(err, "Failed to resolve the references of the HcloudServer", "name", hcloudServer.Name)
+	meta.SetStatusCondition(&hcloudServer.Status.Conditions, metav1.Condition{
+		Type:               "Available",
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: hcloudServer.Generation,
+		Reason:             unresolvedReferencesReason,
+		Message:            err.Error(),
+	})
+	if err := r.Status().Update(ctx, hcloudServer); err != nil {
+		log.Error(err, "Failed to update HcloudServer status", "name", hcloudServer.Name)
+		return ctrl.Result{}, err
+	}
+	r.Recorder.Eventf(hcloudServer, "Warning", unresolvedReferencesReason, "References of server %s cannot be resolved: %v", hcloudServer.Spec.Name, err)
+
+	return ctrl.Result{RequeueAfter: resyncAfter(hcloudServer, r.ResyncInterval)}, nil
+}
+
+// updateFailed reports a failed change of the Hetzner Cloud server and returns the error for a retry
+func (r *HcloudServerReconciler) updateFailed(ctx context.Context, hcloudServer *hcloudv1alpha1.HcloudServer, err error) (ctrl.Result, error) {
+	log := logf.Log.WithName("hcloudserver-controller")
+
+	meta.SetStatusCondition(&hcloudServer.Status.Conditions, metav1.Condition{
+		Type:               "Available",
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: hcloudServer.Generation,
+		Reason:             "Failed",
+		Message:            err.Error(),
+	})
+	if err := r.Status().Update(ctx, hcloudServer); err != nil {
+		log.Error(err, "Failed to update HcloudServer status", "name", hcloudServer.Name)
+	}
+	r.Recorder.Eventf(hcloudServer, "Warning", "UpdateFailed", "Failed to update server %s in Hetzner cloud: %v", hcloudServer.Spec.Name, err)
+
+	return ctrl.Result{}, err
+}
+
+// deletionFailed reports a failed deletion of the Hetzner Cloud server and returns the error for a retry
+func (r *HcloudServerReconciler) deletionFailed(ctx context.Context, hcloudServer *hcloudv1alpha1.HcloudServer, err error) (ctrl.Result, error) {
+	log := logf.Log.WithName("hcloudserver-controller")
+
+	meta.SetStatusCondition(&hcloudServer.Status.Conditions, metav1.Condition{
+		Type:               "Available",
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: hcloudServer.Generation,
+		Reason:             "DeletionFailed",
+		Message:            err.Error(),
+	})
+	if err := r.Status().Update(ctx, hcloudServer); err != nil {
+		log.Error(err, "Failed to update HcloudServer status", "name", hcloudServer.Name)
+	}
+	r.Recorder.Eventf(hcloudServer, "Warning", "DeletionFailed", "Failed to delete server %d from Hetzner cloud: %v", hcloudServer.Status.ServerId, err)
+
+	return ctrl.Result{}, err
+}
+
+// releaseServer removes the ownership label from an orphaned server, so that it can be adopted
+// by another resource. Failures are only reported, the server then stays claimed.
+func (r *HcloudServerReconciler) releaseServer(ctx context.Context, hcloudServer *hcloudv1alpha1.HcloudServer) {
+	log := logf.Log.WithName("hcloudserver-controller")
+
+	serverClient, _, err := r.serverClientFor(ctx, hcloudServer)
+	if err == nil {
+		var server *hcloudgo.Server
+		server, _, err = serverClient.GetServerById(ctx, int64(hcloudServer.Status.ServerId))
+		if err == nil && server != nil && server.Labels[hcloudv1alpha1.OwnerUIDLabel] == string(hcloudServer.UID) {
+			labels := withoutOwnershipLabels(server.Labels)
+			if labels == nil {
+				labels = map[string]string{}
+			}
+			_, _, err = serverClient.UpdateServerLabels(ctx, server, labels)
+		}
+	}
+	if err != nil {
+		log.Error(err, "Failed to release orphaned server", "serverId", hcloudServer.Status.ServerId)
+		r.Recorder.Eventf(hcloudServer, "Warning", "ReleaseFailed", "Failed to remove the ownership label from server %d: %v", hcloudServer.Status.ServerId, err)
+	}
+}
+
+// findServer looks up the Hetzner Cloud server of the HcloudServer the same way findNetwork looks
+// up networks: by the external ID when pinned, otherwise by the ID in the status and by name when
+// the server is not known yet or no longer exists.
+func findServer(ctx context.Context, serverClient hcloud.ServerClient, hcloudServer *hcloudv1alpha1.HcloudServer, pinnedID int64) (*hcloudgo.Server, *hcloudgo.Response, error) {
+	log := logf.Log.WithName("hcloudserver-controller")
+
+	if pinnedID != 0 {
+		log.Info("Checking for existing server in Hetzner Cloud by external ID", "serverId", pinnedID)
+		return serverClient.GetServerById(ctx, pinnedID)
+	}
+	if hcloudServer.Status.ServerId != 0 {
+		log.Info("Checking for existing server in Hetzner Cloud by ID", "serverId", hcloudServer.Status.ServerId)
+		server, response, err := serverClient.GetServerById(ctx, int64(hcloudServer.Status.ServerId))
+		if err != nil || server != nil {
+			return server, response, err
+		}
+		log.Info("Server no longer exists in Hetzner Cloud", "serverId", hcloudServer.Status.ServerId)
+	}
+	log.Info("Checking for existing server in Hetzner Cloud by name", "name", hcloudServer.Spec.Name)
+	return serverClient.GetServerByName(ctx, hcloudServer.Spec.Name)
+}
+
+// serverCreateOpts builds the request creating the server of the spec. Networks with a fixed IP
+// are left out, the create request cannot choose the IP of the server in a network.
+func serverCreateOpts(spec hcloudv1alpha1.HcloudServerSpec, labels map[string]string, userData string, sshKeys []*hcloudgo.SSHKey, networks []serverNetwork, firewallIDs []int64) hcloudgo.ServerCreateOpts {
+	opts := hcloudgo.ServerCreateOpts{
+		Name:       spec.Name,
+		ServerType: &hcloudgo.ServerType{Name: spec.ServerType},
+		Image:      imageReference(spec.Image),
+		SSHKeys:    sshKeys,
+		UserData:   userData,
+		Labels:     labels,
+	}
+	if spec.Location != "" {
+		opts.Location = &hcloudgo.Location{Name: spec.Location}
+	}
+	if spec.PlacementGroupId != 0 {
+		opts.PlacementGroup = &hcloudgo.PlacementGroup{ID: int64(spec.PlacementGroupId)}
+	}
+	if enableIPv4, enableIPv6 := publicNetEnabled(spec.PublicNet); !enableIPv4 || !enableIPv6 {
+		opts.PublicNet = &hcloudgo.ServerCreatePublicNet{EnableIPv4: enableIPv4, EnableIPv6: enableIPv6}
+	}
+	for _, network := range networks {
+		if network.ip == nil {
+			opts.Networks = append(opts.Networks, &hcloudgo.Network{ID: network.id})
+		}
+	}
+	for _, id := range firewallIDs {
+		opts.Firewalls = append(opts.Firewalls, &hcloudgo.ServerCreateFirewall{Firewall: hcloudgo.Firewall{ID: id}})
+	}
+	return opts
+}
+
+// imageReference selects an image by ID when image is numeric, such as a snapshot ID, otherwise by name
+func imageReference(image string) *hcloudgo.Image {
+	if id, err := strconv.ParseInt(image, 10, 64); err == nil {
+		return &hcloudgo.Image{ID: id}
+	}
+	return &hcloudgo.Image{Name: image}
+}
+
+// publicNetEnabled returns whether the server has a public IPv4 address and IPv6 network, both default to true
+func publicNetEnabled(publicNet hcloudv1alpha1.HcloudServerPublicNet) (enableIPv4, enableIPv6 bool) {
+	enableIPv4 = publicNet.EnableIPv4 == nil || *publicNet.EnableIPv4
+	enableIPv6 = publicNet.EnableIPv6 == nil || *publicNet.EnableIPv6
+	return enableIPv4, enableIPv6
+}
+
+// reconcileNetworks converges the networks the server is attached to towards the resolved
+// spec.networks. A network in which the server has another IP than the fixed IP of the spec is
+// detached and attached again, as the IP of an attached server cannot be changed.
+func (r *HcloudServerReconciler) reconcileNetworks(ctx context.Context, serverClient hcloud.ServerClient, server *hcloudgo.Server, networks []serverNetwork) (*hcloudgo.Server, error) {
+	log := logf.Log.WithName("hcloudserver-controller")
+
+	desired := make(map[int64]serverNetwork, len(networks))
+	for _, network := range networks {
+		desired[network.id] = network
+	}
+
+	// Detach networks that are no longer desired or whose IP changed
+	for _, privateNet := range server.PrivateNet {
+		if want, ok := desired[privateNet.Network.ID]; ok && (want.ip == nil || want.ip.Equal(privateNet.IP)) {
+			continue
+		}
+		log.Info("Detaching server from network", "serverId", server.ID, "networkId", privateNet.Network.ID, "ip", privateNet.IP.String())
+		updatedServer, response, err := serverClient.DetachServerFromNetwork(ctx, server, privateNet.Network)
+		if err != nil {
+			return server, fmt.Errorf("failed to detach server from network %d: %w. %v", privateNet.Network.ID, err, response)
+		}
+		server = updatedServer
+	}
+
+	// Attach desired networks the server is not attached to yet
+	attached := make(map[int64]bool, len(server.PrivateNet))
+	for _, privateNet := range server.PrivateNet {
+		attached[privateNet.Network.ID] = true
+	}
+	for _, network := range networks {
+		if attached[network.id] {
+			continue
+		}
+		log.Info("Attaching server to network", "serverId", server.ID, "networkId", network.id, "ip", network.ip.String())
+		updatedServer, response, err := serverClient.AttachServerToNetwork(ctx, server, &hcloudgo.Network{ID: network.id}, network.ip)
+		if err != nil {
+			return server, fmt.Errorf("failed to attach server to network %s (%d): %w. %v", network.name, network.id, err, response)
+		}
+		server = updatedServer
+	}
+
+	return server, nil
+}
+
+// reconcileFirewalls converges the firewalls applied to the server towards the resolved
+// spec.firewalls. Firewalls applied through a label selector cannot be removed from a single
+// server, they are left in place.
+func (r *HcloudServerReconciler) reconcileFirewalls(ctx context.Context, serverClient hcloud.ServerClient, server *hcloudgo.Server, firewallIDs []int64) (*hcloudgo.Server, error) {
+	log := logf.Log.WithName("hcloudserver-controller")
+
+	desired := make(map[int64]bool, len(firewallIDs))
+	for _, id := range firewallIDs {
+		desired[id] = true
+	}
+
+	// Remove firewalls that are no longer desired
+	for _, status := range server.PublicNet.Firewalls {
+		if desired[status.Firewall.ID] {
+			continue
+		}
+		log.Info("Removing firewall from server", "serverId", server.ID, "firewallId", status.Firewall.ID)
+		updatedServer, response, err := serverClient.RemoveServerFirewall(ctx, server, &hcloudgo.Firewall{ID: status.Firewall.ID})
+		if hcloudgo.IsError(err, hcloudgo.ErrorCodeFirewallResourceNotFound) {
+			log.Info("Firewall is applied through a label selector, leaving it in place", "serverId", server.ID, "firewallId", status.Firewall.ID)
+			continue
+		}
+		if err != nil {
+			return server, fmt.Errorf("failed to remove firewall %d from server: %w. %v", status.Firewall.ID, err, response)
+		}
+		server = updatedServer
+	}
+
+	// Apply desired firewalls that are not applied yet
+	applied := make(map[int64]bool, len(server.PublicNet.Firewalls))
+	for _, status := range server.PublicNet.Firewalls {
+		applied[status.Firewall.ID] = true
+	}
+	for _, id := range firewallIDs {
+		if applied[id] {
+			continue
+		}
+		log.Info("Applying firewall to server", "serverId", server.ID, "firewallId", id)
+		updatedServer, response, err := serverClient.ApplyServerFirewall(ctx, server, &hcloudgo.Firewall{ID: id})
+		if err != nil {
+			return server, fmt.Errorf("failed to apply firewall %d to server: %w. %v", id, err, response)
+		}
+		server = updatedServer
+	}
+
+	return server, nil
+}
+
+// serverDrift returns the fields in which the Hetzner Cloud server differs from the spec. Fields the
+// sync policy ignores are not compared, the fields reported by replacementChanges are not drift.
+func serverDrift(desiredLabels map[string]string, networks []serverNetwork, firewallIDs []int64, server *hcloudgo.Server, policy effectiveSyncPolicy) []string {
+	var drift []string
+	if liveLabels := withoutOwnershipLabels(server.Labels); desiredLabels != nil && !equality.Semantic.DeepEqual(desiredLabels, liveLabels) {
+		drift = append(drift, labelsDrift(liveLabels, desiredLabels)...)
+	}
+
+	if !policy.ignores("networks") {
+		live := make(map[int64]string, len(server.PrivateNet))
+		for _, privateNet := range server.PrivateNet {
+			live[privateNet.Network.ID] = privateNet.IP.String()
+		}
+		desired := make(map[int64]bool, len(networks))
+		for _, network := range networks {
+			desired[network.id] = true
+			ip, ok := live[network.id]
+			switch {
+			case !ok && network.ip == nil:
+				drift = append(drift, driftEntry("networks["+network.name+"]", "", "attached"))
+			case !ok:
+				drift = append(drift, driftEntry("networks["+network.name+"]", "", network.ip.String()))
+			case network.ip != nil && ip != network.ip.String():
+				drift = append(drift, driftEntry("networks["+network.name+"]", ip, network.ip.String()))
+			}
+		}
+		for _, privateNet := range server.PrivateNet {
+			if !desired[privateNet.Network.ID] {
+				drift = append(drift, driftEntry(fmt.Sprintf("networks[%d]", privateNet.Network.ID), privateNet.IP.String(), ""))
+			}
+		}
+	}
+
+	if !policy.ignores("firewalls") {
+		applied := make(map[int64]bool, len(server.PublicNet.Firewalls))
+		for _, status := range server.PublicNet.Firewalls {
+			applied[status.Firewall.ID] = true
+		}
+		desired := make(map[int64]bool, len(firewallIDs))
+		for _, id := range firewallIDs {
+			desired[id] = true
+			if !applied[id] {
+				drift = append(drift, driftEntry(fmt.Sprintf("firewalls[%d]", id), "", "applied"))
+			}
+		}
+		for _, status := range server.PublicNet.Firewalls {
+			if !desired[status.Firewall.ID] {
+				drift = append(drift, driftEntry(fmt.Sprintf("firewalls[%d]", status.Firewall.ID), "applied", ""))
+			}
+		}
+	}
+
+	return drift
+}
+
+// replacementChanges returns the fields in which the server differs from the spec that cannot be
+// changed without shutting down, rebuilding or recreating the server. An image given by name is
+// only compared while the server still reports the image it was created from.
+func replacementChanges(spec hcloudv1alpha1.HcloudServerSpec, server *hcloudgo.Server) []string {
+	var changes []string
+	if server.ServerType != nil && server.ServerType.Name != spec.ServerType {
+		changes = append(changes, driftEntry("serverType", server.ServerType.Name, spec.ServerType))
+	}
+	if location := serverLocation(server); spec.Location != "" && location != spec.Location {
+		changes = append(changes, driftEntry("location", location, spec.Location))
+	}
+	placementGroupId := 0
+	if server.PlacementGroup != nil {
+		placementGroupId = int(server.PlacementGroup.ID)
+	}
+	if placementGroupId != spec.PlacementGroupId {
+		changes = append(changes, driftEntry("placementGroupId", formatID(placementGroupId), formatID(spec.PlacementGroupId)))
+	}
+	enableIPv4, enableIPv6 := publicNetEnabled(spec.PublicNet)
+	if hasIPv4 := !server.PublicNet.IPv4.IsUnspecified(); hasIPv4 != enableIPv4 {
+		changes = append(changes, driftEntry("publicNet.enableIPv4", strconv.FormatBool(hasIPv4), strconv.FormatBool(enableIPv4)))
+	}
+	if hasIPv6 := !server.PublicNet.IPv6.IsUnspecified(); hasIPv6 != enableIPv6 {
+		changes = append(changes, driftEntry("publicNet.enableIPv6", strconv.FormatBool(hasIPv6), strconv.FormatBool(enableIPv6)))
+	}
+	if server.Image != nil {
+		if id, err := strconv.ParseInt(spec.Image, 10, 64); err == nil {
+			if server.Image.ID != id {
+				changes = append(changes, driftEntry("image", strconv.FormatInt(server.Image.ID, 10), spec.Image))
+			}
+		} else if server.Image.Name != "" && server.Image.Name != spec.Image {
+			changes = append(changes, driftEntry("image", server.Image.Name, spec.Image))
+		}
+	}
+	return changes
+}
+
+// formatID formats an optional ID for drift entries, zero is absent
+func formatID(id int) string {
+	if id == 0 {
+		return ""
+	}
+	return strconv.Itoa(id)
+}
+
+// setReplacementCondition reports the changes that cannot be made in place on the RequiresReplacement
+// condition and returns whether the condition changed
+func setReplacementCondition(conditions *[]metav1.Condition, generation int64, changes []string) bool {
+	if len(changes) == 0 {
+		return meta.SetStatusCondition(conditions, metav1.Condition{
+			Type:               requiresReplacementCondition,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: generation,
+			Reason:             "UpToDate",
+			Message:            "All fields of the spec are applied in place",
+		})
+	}
+	return meta.SetStatusCondition(conditions, metav1.Condition{
+		Type:               requiresReplacementCondition,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: generation,
+		Reason:             "SpecChanged",
+		Message:            "Fields that cannot be changed in place differ: " + strings.Join(changes, "; "),
+	})
+}
+
+// serverLocation returns the name of the location of the server
+func serverLocation(server *hcloudgo.Server) string {
+	if server.Datacenter == nil || server.Datacenter.Location == nil {
+		return ""
+	}
+	return server.Datacenter.Location.Name
+}
+
+// setServerStatus records the details of the Hetzner Cloud server in the status. Private networks
+// are named after the HcloudNetworks of the spec they were resolved from.
+func setServerStatus(status *hcloudv1alpha1.HcloudServerStatus, server *hcloudgo.Server, networks []serverNetwork) {
+	status.ServerId = int(server.ID)
+	status.Status = string(server.Status)
+	status.ServerType = ""
+	if server.ServerType != nil {
+		status.ServerType = server.ServerType.Name
+	}
+	status.Location = serverLocation(server)
+	status.Image = ""
+	if server.Image != nil {
+		status.Image = server.Image.Name
+		if status.Image == "" {
+			status.Image = strconv.FormatInt(server.Image.ID, 10)
+		}
+	}
+	status.PublicIPv4 = ""
+	if !server.PublicNet.IPv4.IsUnspecified() {
+		status.PublicIPv4 = server.PublicNet.IPv4.IP.String()
+	}
+	status.PublicIPv6 = ""
+	if server.PublicNet.IPv6.Network != nil {
+		status.PublicIPv6 = server.PublicNet.IPv6.Network.String()
+	}
+
+	names := make(map[int64]string, len(networks))
+	for _, network := range networks {
+		names[network.id] = network.name
+	}
+	status.PrivateNets = nil
+	for _, privateNet := range server.PrivateNet {
+		entry := hcloudv1alpha1.HcloudServerPrivateNet{
+			Name:      names[privateNet.Network.ID],
+			NetworkId: int(privateNet.Network.ID),
+			IP:        privateNet.IP.String(),
+		}
+		for _, alias := range privateNet.Aliases {
+			entry.AliasIPs = append(entry.AliasIPs, alias.String())
+		}
+		status.PrivateNets = append(status.PrivateNets, entry)
+	}
+
+	status.Firewalls = nil
+	for _, firewall := range server.PublicNet.Firewalls {
+		status.Firewalls = append(status.Firewalls, hcloudv1alpha1.HcloudServerFirewallStatus{
+			FirewallId: int(firewall.Firewall.ID),
+			Status:     string(firewall.Status),
+		})
+	}
+	status.Labels = withoutOwnershipLabels(server.Labels)
+}
+
+// serverClientFor returns the Hetzner Cloud client for the HcloudServer together with the resolved provider.
+// The client is built from the credentials of the resource or its provider config, or is the client
+// configured for the manager when neither is set.
+func (r *HcloudServerReconciler) serverClientFor(ctx context.Context, hcloudServer *hcloudv1alpha1.HcloudServer) (hcloud.ServerClient, *hcloudProvider, error) {
+	provider, err := resolveProvider(ctx, r.Client, r.DefaultProviderConfig, hcloudServer.Namespace, hcloudServer.Spec.CredentialsRef, hcloudServer.Spec.ProviderConfigRef)
+	if err != nil {
+		return nil, nil, err
+	}
+	if provider.token == "" {
+		if r.ServerClient == nil {
+			return nil, nil, fmt.Errorf("no credentials configured for the resource and no Hetzner Cloud token configured for the manager")
+		}
+		return r.ServerClient, provider, nil
+	}
+	if r.ClientCache == nil {
+		return nil, nil, fmt.Errorf("no client cache configured to resolve credentials")
+	}
+	return r.ClientCache.ServerClient(provider.cacheKey, provider.cacheVersion, provider.token, provider.options...), provider, nil
+}
+
+// SetupWithManager sets up the controller with the Manager. Like for HcloudFirewalls the event filter
+// only applies to HcloudServers, the referenced HcloudNetworks and HcloudFirewalls become usable
+// through their status without changing their generation.
+func (r *HcloudServerReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&hcloudv1alpha1.HcloudServer{}, builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, pausedAnnotationChanged))).
+		Named("hcloudserver").
+		Watches(&hcloudv1alpha1.HcloudNetwork{}, handler.EnqueueRequestsFromMapFunc(r.serversForNetwork), builder.WithPredicates(networkIdChanged)).
+		Watches(&hcloudv1alpha1.HcloudFirewall{}, handler.EnqueueRequestsFromMapFunc(r.serversForFirewall), builder.WithPredicates(firewallIdChanged)).
+		Complete(r)
+}
@@ -0,0 +1,499 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"net"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hcloudv1alpha1 "bunskin.com/hcrm/api/v1alpha1"
+	"bunskin.com/hcrm/pkg/hcloud"
+	hcloudgo "github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+var _ = Describe("HcloudServer Controller", func() {
+	const namespace = "default"
+
+	ctx := context.Background()
+
+	// newServer returns an HcloudServer of type cx22 running ubuntu-24.04 in fsn1
+	newServer := func(name string, policy *hcloudv1alpha1.HcloudSyncPolicy) *hcloudv1alpha1.HcloudServer {
+		return &hcloudv1alpha1.HcloudServer{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+			Spec: hcloudv1alpha1.HcloudServerSpec{
+				Name:       name,
+				ServerType: "cx22",
+				Image:      "ubuntu-24.04",
+				Location:   "fsn1",
+				Labels:     map[string]string{"env": "test"},
+				SyncPolicy: policy,
+			},
+		}
+	}
+
+	// liveServer returns the Hetzner Cloud server matching the spec returned by newServer
+	liveServer := func(id int64, name string, labels map[string]string) *hcloudgo.Server {
+		return &hcloudgo.Server{
+			ID:         id,
+			Name:       name,
+			Status:     hcloudgo.ServerStatusRunning,
+			ServerType: &hcloudgo.ServerType{Name: "cx22"},
+			Image:      &hcloudgo.Image{ID: 161547269, Name: "ubuntu-24.04"},
+			Datacenter: &hcloudgo.Datacenter{Location: &hcloudgo.Location{Name: "fsn1"}},
+			PublicNet: hcloudgo.ServerPublicNet{
+				IPv4: hcloudgo.ServerPublicNetIPv4{IP: net.ParseIP("203.0.113.20")},
+				IPv6: hcloudgo.ServerPublicNetIPv6{IP: net.ParseIP("2001:db8::1"), Network: &net.IPNet{IP: net.ParseIP("2001:db8::"), Mask: net.CIDRMask(64, 128)}},
+			},
+			Labels: labels,
+		}
+	}
+
+	// newNetwork creates an HcloudNetwork whose Hetzner Cloud network has the given ID
+	newNetwork := func(name string, id int) *hcloudv1alpha1.HcloudNetwork {
+		network := &hcloudv1alpha1.HcloudNetwork{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec:       hcloudv1alpha1.HcloudNetworkSpec{Name: name, IpRange: "10.0.0.0/16"},
+		}
+		Expect(k8sClient.Create(ctx, network)).To(Succeed())
+		network.Status.NetworkId = id
+		Expect(k8sClient.Status().Update(ctx, network)).To(Succeed())
+		return network
+	}
+
+	Context("Create new HcloudServer", func() {
+		It("should create the server and attach the network with a fixed IP once it exists", func() {
+			const resourceName = "test-create-server"
+			typeNamespacedName := types.NamespacedName{Name: resourceName, Namespace: namespace}
+
+			network := newNetwork("test-create-server-network", 501)
+			userData := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-create-server-cloud-init", Namespace: namespace},
+				Data:       map[string]string{"user-data": "#cloud-config\npackages: [nginx]\n"},
+			}
+			Expect(k8sClient.Create(ctx, userData)).To(Succeed())
+
+			resource := newServer(resourceName, nil)
+			resource.Spec.SSHKeys = []string{"admin"}
+			resource.Spec.UserData = &hcloudv1alpha1.HcloudServerUserData{
+				ConfigMapKeyRef: &hcloudv1alpha1.HcloudObjectKeyReference{Name: "test-create-server-cloud-init", Key: "user-data"},
+			}
+			resource.Spec.Networks = []hcloudv1alpha1.HcloudServerNetwork{{Name: "test-create-server-network", IP: "10.0.0.10"}}
+			resource.Spec.Firewalls = []hcloudv1alpha1.HcloudServerFirewall{{FirewallId: 4711}}
+			resource.Spec.PublicNet.EnableIPv6 = hcloudgo.Ptr(false)
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			var live *hcloudgo.Server
+			var createOpts hcloudgo.ServerCreateOpts
+			var attachedIP net.IP
+			actionStatus := hcloudgo.ActionStatusRunning
+			MockServerClient := &hcloud.MockServerClient{}
+			MockServerClient.GetSSHKeyByNameFunc = func(ctx context.Context, name string) (*hcloudgo.SSHKey, *hcloudgo.Response, error) {
+				return &hcloudgo.SSHKey{ID: 77, Name: name}, nil, nil
+			}
+			MockServerClient.CreateServerFunc = func(ctx context.Context, opts hcloudgo.ServerCreateOpts) (*hcloudgo.ServerCreateResult, *hcloudgo.Response, error) {
+				createOpts = opts
+				live = liveServer(9001, opts.Name, opts.Labels)
+				live.Status = hcloudgo.ServerStatusInitializing
+				live.PublicNet.IPv6 = hcloudgo.ServerPublicNetIPv6{}
+				live.PublicNet.Firewalls = []*hcloudgo.ServerFirewallStatus{{Firewall: hcloudgo.Firewall{ID: 4711}, Status: hcloudgo.FirewallStatusApplied}}
+				return &hcloudgo.ServerCreateResult{
+					Server:      live,
+					Action:      &hcloudgo.Action{ID: 801, Command: "create_server", Status: hcloudgo.ActionStatusRunning},
+					NextActions: []*hcloudgo.Action{{ID: 802, Command: "start_server", Status: hcloudgo.ActionStatusRunning}},
+				}, nil, nil
+			}
+			MockServerClient.GetActionFunc = func(ctx context.Context, id int64) (*hcloudgo.Action, *hcloudgo.Response, error) {
+				return &hcloudgo.Action{ID: id, Status: actionStatus}, nil, nil
+			}
+			MockServerClient.GetServerByIdFunc = func(ctx context.Context, id int64) (*hcloudgo.Server, *hcloudgo.Response, error) {
+				return live, nil, nil
+			}
+			MockServerClient.AttachServerToNetworkFunc = func(ctx context.Context, server *hcloudgo.Server, network *hcloudgo.Network, ip net.IP) (*hcloudgo.Server, *hcloudgo.Response, error) {
+				attachedIP = ip
+				live.PrivateNet = append(live.PrivateNet, hcloudgo.ServerPrivateNet{Network: network, IP: ip})
+				return live, nil, nil
+			}
+
+			reconciler := &HcloudServerReconciler{
+				Client:       k8sClient,
+				Scheme:       k8sClient.Scheme(),
+				ServerClient: MockServerClient,
+				Recorder:     recorder,
+			}
+
+			By("creating the server with the SSH keys, user data and firewalls of the spec")
+			result, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(defaultActionPollInterval))
+			Expect(createOpts.ServerType.Name).To(Equal("cx22"))
+			Expect(createOpts.Image.Name).To(Equal("ubuntu-24.04"))
+			Expect(createOpts.Location.Name).To(Equal("fsn1"))
+			Expect(createOpts.SSHKeys).To(HaveLen(1))
+			Expect(createOpts.SSHKeys[0].ID).To(Equal(int64(77)))
+			Expect(createOpts.UserData).To(Equal(userData.Data["user-data"]))
+			Expect(createOpts.PublicNet).To(Equal(&hcloudgo.ServerCreatePublicNet{EnableIPv4: true, EnableIPv6: false}))
+			Expect(createOpts.Networks).To(BeEmpty())
+			Expect(createOpts.Firewalls).To(Equal([]*hcloudgo.ServerCreateFirewall{{Firewall: hcloudgo.Firewall{ID: 4711}}}))
+			Expect(createOpts.Labels).To(HaveKeyWithValue("env", "test"))
+			Expect(createOpts.Labels).To(HaveKey(hcloudv1alpha1.OwnerUIDLabel))
+
+			updatedResource := &hcloudv1alpha1.HcloudServer{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			Expect(updatedResource.Status.ServerId).To(Equal(9001))
+			Expect(updatedResource.Status.Status).To(Equal("initializing"))
+			Expect(updatedResource.Status.PendingActions).To(HaveLen(2))
+			Expect(updatedResource.Finalizers).To(ContainElement(finalizerName))
+			condition := meta.FindStatusCondition(updatedResource.Status.Conditions, "Available")
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Reason).To(Equal(creatingReason))
+			Expect(meta.IsStatusConditionTrue(updatedResource.Status.Conditions, progressingCondition)).To(BeTrue())
+
+			By("attaching the network with its fixed IP once the server is running")
+			actionStatus = hcloudgo.ActionStatusSuccess
+			live.Status = hcloudgo.ServerStatusRunning
+			_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(attachedIP.String()).To(Equal("10.0.0.10"))
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			Expect(updatedResource.Status.PendingActions).To(BeEmpty())
+			Expect(updatedResource.Status.Status).To(Equal("running"))
+			Expect(updatedResource.Status.PublicIPv4).To(Equal("203.0.113.20"))
+			Expect(updatedResource.Status.PublicIPv6).To(BeEmpty())
+			Expect(updatedResource.Status.Location).To(Equal("fsn1"))
+			Expect(updatedResource.Status.Image).To(Equal("ubuntu-24.04"))
+			Expect(updatedResource.Status.Labels).To(Equal(map[string]string{"env": "test"}))
+			Expect(updatedResource.Status.PrivateNets).To(Equal([]hcloudv1alpha1.HcloudServerPrivateNet{{Name: "test-create-server-network", NetworkId: 501, IP: "10.0.0.10"}}))
+			Expect(updatedResource.Status.Firewalls).To(Equal([]hcloudv1alpha1.HcloudServerFirewallStatus{{FirewallId: 4711, Status: "applied"}}))
+			condition = meta.FindStatusCondition(updatedResource.Status.Conditions, "Available")
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(condition.Reason).To(Equal("Ready"))
+			Expect(meta.IsStatusConditionTrue(updatedResource.Status.Conditions, requiresReplacementCondition)).To(BeFalse())
+
+			Expect(k8sClient.Delete(ctx, updatedResource)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, userData)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, network)).To(Succeed())
+		})
+
+		It("should wait for a referenced network without a network ID", func() {
+			const resourceName = "test-unresolved-server"
+			typeNamespacedName := types.NamespacedName{Name: resourceName, Namespace: namespace}
+
+			network := newNetwork("test-unresolved-server-network", 0)
+			resource := newServer(resourceName, nil)
+			resource.Spec.Networks = []hcloudv1alpha1.HcloudServerNetwork{{Name: "test-unresolved-server-network"}}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			MockServerClient := &hcloud.MockServerClient{}
+			MockServerClient.CreateServerFunc = func(ctx context.Context, opts hcloudgo.ServerCreateOpts) (*hcloudgo.ServerCreateResult, *hcloudgo.Response, error) {
+				Fail("the server must not be created before its networks are resolved")
+				return nil, nil, nil
+			}
+
+			reconciler := &HcloudServerReconciler{
+				Client:       k8sClient,
+				Scheme:       k8sClient.Scheme(),
+				ServerClient: MockServerClient,
+				Recorder:     recorder,
+			}
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			updatedResource := &hcloudv1alpha1.HcloudServer{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			condition := meta.FindStatusCondition(updatedResource.Status.Conditions, "Available")
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Reason).To(Equal(unresolvedReferencesReason))
+			Expect(condition.Message).To(ContainSubstring("test-unresolved-server-network"))
+
+			By("mapping the network to the server once it gets its ID")
+			Expect(reconciler.serversForNetwork(ctx, network)).To(ContainElement(reconcile.Request{NamespacedName: typeNamespacedName}))
+
+			Expect(k8sClient.Delete(ctx, updatedResource)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, network)).To(Succeed())
+		})
+
+		It("should not create a server when sync policy is read-only", func() {
+			const resourceName = "test-readonly-server"
+			typeNamespacedName := types.NamespacedName{Name: resourceName, Namespace: namespace}
+
+			resource := newServer(resourceName, &hcloudv1alpha1.HcloudSyncPolicy{Mode: hcloudv1alpha1.SyncPolicyReadOnly})
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			MockServerClient := &hcloud.MockServerClient{}
+			MockServerClient.CreateServerFunc = func(ctx context.Context, opts hcloudgo.ServerCreateOpts) (*hcloudgo.ServerCreateResult, *hcloudgo.Response, error) {
+				Fail("the server must not be created when sync policy is read-only")
+				return nil, nil, nil
+			}
+
+			reconciler := &HcloudServerReconciler{
+				Client:       k8sClient,
+				Scheme:       k8sClient.Scheme(),
+				ServerClient: MockServerClient,
+				Recorder:     recorder,
+			}
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			updatedResource := &hcloudv1alpha1.HcloudServer{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			Expect(updatedResource.Finalizers).NotTo(ContainElement(finalizerName))
+			condition := meta.FindStatusCondition(updatedResource.Status.Conditions, "Available")
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).To(Equal("Failed"))
+
+			Expect(k8sClient.Delete(ctx, updatedResource)).To(Succeed())
+		})
+	})
+
+	Context("Update existing HcloudServer", func() {
+		It("should change networks and firewalls in place and report fields needing a replacement", func() {
+			const resourceName = "test-update-server"
+			typeNamespacedName := types.NamespacedName{Name: resourceName, Namespace: namespace}
+
+			network := newNetwork("test-update-server-network", 502)
+			resource := newServer(resourceName, nil)
+			resource.Spec.ServerType = "cx32"
+			resource.Spec.Networks = []hcloudv1alpha1.HcloudServerNetwork{{Name: "test-update-server-network", IP: "10.0.0.20"}}
+			resource.Spec.Firewalls = []hcloudv1alpha1.HcloudServerFirewall{{FirewallId: 4712}}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			live := liveServer(9002, resourceName, map[string]string{"env": "old"})
+			live.PrivateNet = []hcloudgo.ServerPrivateNet{
+				{Network: &hcloudgo.Network{ID: 502}, IP: net.ParseIP("10.0.0.5")},
+				{Network: &hcloudgo.Network{ID: 503}, IP: net.ParseIP("10.1.0.5")},
+			}
+			live.PublicNet.Firewalls = []*hcloudgo.ServerFirewallStatus{{Firewall: hcloudgo.Firewall{ID: 4711}, Status: hcloudgo.FirewallStatusApplied}}
+
+			var detached []int64
+			var attached []string
+			var applied, removed []int64
+			MockServerClient := &hcloud.MockServerClient{}
+			MockServerClient.GetServerByNameFunc = func(ctx context.Context, name string) (*hcloudgo.Server, *hcloudgo.Response, error) {
+				return live, nil, nil
+			}
+			MockServerClient.UpdateServerLabelsFunc = func(ctx context.Context, server *hcloudgo.Server, labels map[string]string) (*hcloudgo.Server, *hcloudgo.Response, error) {
+				live.Labels = labels
+				return live, nil, nil
+			}
+			MockServerClient.DetachServerFromNetworkFunc = func(ctx context.Context, server *hcloudgo.Server, network *hcloudgo.Network) (*hcloudgo.Server, *hcloudgo.Response, error) {
+				detached = append(detached, network.ID)
+				var privateNets []hcloudgo.ServerPrivateNet
+				for _, privateNet := range live.PrivateNet {
+					if privateNet.Network.ID != network.ID {
+						privateNets = append(privateNets, privateNet)
+					}
+				}
+				live.PrivateNet = privateNets
+				return live, nil, nil
+			}
+			MockServerClient.AttachServerToNetworkFunc = func(ctx context.Context, server *hcloudgo.Server, network *hcloudgo.Network, ip net.IP) (*hcloudgo.Server, *hcloudgo.Response, error) {
+				attached = append(attached, ip.String())
+				live.PrivateNet = append(live.PrivateNet, hcloudgo.ServerPrivateNet{Network: network, IP: ip})
+				return live, nil, nil
+			}
+			MockServerClient.ApplyServerFirewallFunc = func(ctx context.Context, server *hcloudgo.Server, firewall *hcloudgo.Firewall) (*hcloudgo.Server, *hcloudgo.Response, error) {
+				applied = append(applied, firewall.ID)
+				live.PublicNet.Firewalls = append(live.PublicNet.Firewalls, &hcloudgo.ServerFirewallStatus{Firewall: *firewall, Status: hcloudgo.FirewallStatusPending})
+				return live, nil, nil
+			}
+			MockServerClient.RemoveServerFirewallFunc = func(ctx context.Context, server *hcloudgo.Server, firewall *hcloudgo.Firewall) (*hcloudgo.Server, *hcloudgo.Response, error) {
+				removed = append(removed, firewall.ID)
+				live.PublicNet.Firewalls = nil
+				return live, nil, nil
+			}
+
+			reconciler := &HcloudServerReconciler{
+				Client:       k8sClient,
+				Scheme:       k8sClient.Scheme(),
+				ServerClient: MockServerClient,
+				Recorder:     recorder,
+			}
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(live.Labels).To(HaveKeyWithValue("env", "test"))
+			Expect(detached).To(ConsistOf(int64(502), int64(503)))
+			Expect(attached).To(Equal([]string{"10.0.0.20"}))
+			Expect(removed).To(Equal([]int64{4711}))
+			Expect(applied).To(Equal([]int64{4712}))
+
+			updatedResource := &hcloudv1alpha1.HcloudServer{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			Expect(updatedResource.Status.ServerId).To(Equal(9002))
+			Expect(updatedResource.Status.ServerType).To(Equal("cx22"))
+			Expect(updatedResource.Status.PublicIPv6).To(Equal("2001:db8::/64"))
+			Expect(updatedResource.Status.PrivateNets).To(Equal([]hcloudv1alpha1.HcloudServerPrivateNet{{Name: "test-update-server-network", NetworkId: 502, IP: "10.0.0.20"}}))
+			Expect(updatedResource.Status.Firewalls).To(Equal([]hcloudv1alpha1.HcloudServerFirewallStatus{{FirewallId: 4712, Status: "pending"}}))
+
+			condition := meta.FindStatusCondition(updatedResource.Status.Conditions, requiresReplacementCondition)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(condition.Message).To(ContainSubstring("serverType: cx22 -> cx32"))
+			condition = meta.FindStatusCondition(updatedResource.Status.Conditions, "Available")
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+
+			Expect(k8sClient.Delete(ctx, updatedResource)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, network)).To(Succeed())
+		})
+
+		It("should leave ignored networks and firewalls to other tools", func() {
+			const resourceName = "test-ignore-server"
+			typeNamespacedName := types.NamespacedName{Name: resourceName, Namespace: namespace}
+
+			resource := newServer(resourceName, &hcloudv1alpha1.HcloudSyncPolicy{IgnoreFields: []string{"networks", "firewalls"}})
+			resource.Spec.Networks = []hcloudv1alpha1.HcloudServerNetwork{{Name: "test-ignore-server-missing-network"}}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			live := liveServer(9003, resourceName, nil)
+			live.PrivateNet = []hcloudgo.ServerPrivateNet{{Network: &hcloudgo.Network{ID: 504}, IP: net.ParseIP("10.2.0.5")}}
+			live.PublicNet.Firewalls = []*hcloudgo.ServerFirewallStatus{{Firewall: hcloudgo.Firewall{ID: 4713}, Status: hcloudgo.FirewallStatusApplied}}
+
+			MockServerClient := &hcloud.MockServerClient{}
+			MockServerClient.GetServerByNameFunc = func(ctx context.Context, name string) (*hcloudgo.Server, *hcloudgo.Response, error) {
+				return live, nil, nil
+			}
+			MockServerClient.UpdateServerLabelsFunc = func(ctx context.Context, server *hcloudgo.Server, labels map[string]string) (*hcloudgo.Server, *hcloudgo.Response, error) {
+				live.Labels = labels
+				return live, nil, nil
+			}
+			MockServerClient.DetachServerFromNetworkFunc = func(ctx context.Context, server *hcloudgo.Server, network *hcloudgo.Network) (*hcloudgo.Server, *hcloudgo.Response, error) {
+				Fail("ignored networks must not be detached")
+				return nil, nil, nil
+			}
+			MockServerClient.RemoveServerFirewallFunc = func(ctx context.Context, server *hcloudgo.Server, firewall *hcloudgo.Firewall) (*hcloudgo.Server, *hcloudgo.Response, error) {
+				Fail("ignored firewalls must not be removed")
+				return nil, nil, nil
+			}
+
+			reconciler := &HcloudServerReconciler{
+				Client:       k8sClient,
+				Scheme:       k8sClient.Scheme(),
+				ServerClient: MockServerClient,
+				Recorder:     recorder,
+			}
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			updatedResource := &hcloudv1alpha1.HcloudServer{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			Expect(updatedResource.Status.PrivateNets).To(Equal([]hcloudv1alpha1.HcloudServerPrivateNet{{NetworkId: 504, IP: "10.2.0.5"}}))
+			Expect(updatedResource.Status.Firewalls).To(HaveLen(1))
+			condition := meta.FindStatusCondition(updatedResource.Status.Conditions, "Available")
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+
+			Expect(k8sClient.Delete(ctx, updatedResource)).To(Succeed())
+		})
+	})
+
+	Context("Delete HcloudServer", func() {
+		It("should delete the server from Hetzner Cloud", func() {
+			const resourceName = "test-delete-server"
+			typeNamespacedName := types.NamespacedName{Name: resourceName, Namespace: namespace}
+
+			resource := newServer(resourceName, nil)
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+			resource.Status.ServerId = 9004
+			Expect(k8sClient.Status().Update(ctx, resource)).To(Succeed())
+
+			getResource := &hcloudv1alpha1.HcloudServer{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, getResource)).To(Succeed())
+			getResource.Finalizers = []string{finalizerName}
+			Expect(k8sClient.Update(ctx, getResource)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, getResource)).To(Succeed())
+
+			var deleted int64
+			MockServerClient := &hcloud.MockServerClient{}
+			MockServerClient.GetServerByIdFunc = func(ctx context.Context, id int64) (*hcloudgo.Server, *hcloudgo.Response, error) {
+				return liveServer(id, resourceName, nil), nil, nil
+			}
+			MockServerClient.DeleteServerFunc = func(ctx context.Context, server *hcloudgo.Server) (*hcloudgo.Action, *hcloudgo.Response, error) {
+				deleted = server.ID
+				return &hcloudgo.Action{ID: 803, Command: "delete_server", Status: hcloudgo.ActionStatusRunning}, nil, nil
+			}
+
+			reconciler := &HcloudServerReconciler{
+				Client:       k8sClient,
+				Scheme:       k8sClient.Scheme(),
+				ServerClient: MockServerClient,
+				Recorder:     recorder,
+			}
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deleted).To(Equal(int64(9004)))
+
+			err = k8sClient.Get(ctx, typeNamespacedName, getResource)
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("should leave a server taken over by another resource when deleted", func() {
+			const resourceName = "test-delete-taken-over-server"
+			typeNamespacedName := types.NamespacedName{Name: resourceName, Namespace: namespace}
+
+			resource := newServer(resourceName, nil)
+			resource.Finalizers = []string{finalizerName}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+			resource.Status.ServerId = 9005
+			Expect(k8sClient.Status().Update(ctx, resource)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+
+			MockServerClient := &hcloud.MockServerClient{}
+			MockServerClient.GetServerByIdFunc = func(ctx context.Context, id int64) (*hcloudgo.Server, *hcloudgo.Response, error) {
+				return liveServer(id, resourceName, map[string]string{
+					hcloudv1alpha1.ClusterIDLabel: "cluster-a",
+					hcloudv1alpha1.NamespaceLabel: "other",
+					hcloudv1alpha1.NameLabel:      "new-owner",
+					hcloudv1alpha1.OwnerUIDLabel:  "new-owner-uid",
+				}), nil, nil
+			}
+			MockServerClient.DeleteServerFunc = func(ctx context.Context, server *hcloudgo.Server) (*hcloudgo.Action, *hcloudgo.Response, error) {
+				Fail("a server taken over by another resource must not be deleted")
+				return nil, nil, nil
+			}
+
+			reconciler := &HcloudServerReconciler{
+				Client:       k8sClient,
+				Scheme:       k8sClient.Scheme(),
+				ServerClient: MockServerClient,
+				Recorder:     recorder,
+				ClusterID:    "cluster-a",
+			}
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = k8sClient.Get(ctx, typeNamespacedName, &hcloudv1alpha1.HcloudServer{})
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,588 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	hcloudv1alpha1 "bunskin.com/hcrm/api/v1alpha1"
+	"bunskin.com/hcrm/pkg/hcloud"
+	hcloudgo "github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+const (
+	// defaultShutdownTimeout is how long a shutdown step waits for the server to be off when the
+	// operation does not set a shutdown timeout
+	defaultShutdownTimeout = 5 * time.Minute
+	// shutdownTimeoutReason is the condition reason of an operation whose server did not shut down in time
+	shutdownTimeoutReason = "ShutdownTimeout"
+	// serverNotFoundReason is the condition reason of an operation whose server does not exist
+	serverNotFoundReason = "ServerNotFound"
+	// stepInterruptedReason is the condition reason of an operation whose step may have been started
+	// without its action being recorded
+	stepInterruptedReason = "StepInterrupted"
+
+	// Steps are named after the commands of the Hetzner Cloud actions performing them
+	startServerStep      = "start_server"
+	stopServerStep       = "stop_server"
+	shutdownServerStep   = "shutdown_server"
+	rebootServerStep     = "reboot_server"
+	resetServerStep      = "reset_server"
+	enableRescueStep     = "enable_rescue"
+	rebuildServerStep    = "rebuild_server"
+	changeServerTypeStep = "change_server_type"
+	createImageStep      = "create_image"
+)
+
+// operationSteps lists the steps of each operation in the order they are performed. Rescue mode
+// only takes effect on the next boot, so the server is reset once it is enabled.
+var operationSteps = map[hcloudv1alpha1.HcloudServerOperationType][]string{
+	hcloudv1alpha1.ServerOperationPowerOn:        {startServerStep},
+	hcloudv1alpha1.ServerOperationPowerOff:       {stopServerStep},
+	hcloudv1alpha1.ServerOperationShutdown:       {shutdownServerStep},
+	hcloudv1alpha1.ServerOperationReboot:         {rebootServerStep},
+	hcloudv1alpha1.ServerOperationReset:          {resetServerStep},
+	hcloudv1alpha1.ServerOperationEnableRescue:   {enableRescueStep, resetServerStep},
+	hcloudv1alpha1.ServerOperationRebuild:        {rebuildServerStep},
+	hcloudv1alpha1.ServerOperationChangeType:     {shutdownServerStep, changeServerTypeStep, startServerStep},
+	hcloudv1alpha1.ServerOperationCreateSnapshot: {createImageStep},
+}
+
+// HcloudServerOperationReconciler reconciles a HcloudServerOperation object
+type HcloudServerOperationReconciler struct {
+	client.Client
+	Scheme             *runtime.Scheme
+	ServerActionClient hcloud.ServerActionClient
+	ClientCache        *hcloud.ClientCache
+	Recorder           record.EventRecorder
+	// DefaultProviderConfig is the name of the ClusterHcloudProviderConfig used for resources without a providerConfigRef
+	DefaultProviderConfig string
+	// Pause pauses the reconciliation of whole kinds or namespaces, nil if it cannot be paused by the manager
+	Pause *PauseSwitch
+}
+
+// +kubebuilder:rbac:groups=hcloud.bunskin.com,resources=hcloudserveroperations,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=hcloud.bunskin.com,resources=hcloudserveroperations/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=hcloud.bunskin.com,resources=hcloudproviderconfigs;clusterhcloudproviderconfigs,verbs=get;list;watch
+
+// Reconcile runs the steps of an HcloudServerOperation one after another. Every step starts a
+// Hetzner Cloud action that is followed across reconciles like the pending actions of other
+// resources. Like a Job, an operation runs once: a failed step fails the operation, which is not
+// retried, and finished operations are left alone.
+func (r *HcloudServerOperationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	result, err := r.reconcile(ctx, req)
+	if reset, ok := hcloud.RateLimitReset(err); ok {
+		var operation hcloudv1alpha1.HcloudServerOperation
+		if err := r.Get(ctx, req.NamespacedName, &operation); err != nil {
+			return ctrl.Result{}, client.IgnoreNotFound(err)
+		}
+		return requeueRateLimited(ctx, r.Client, r.Recorder, &operation, &operation.Status.Conditions, reset, err)
+	}
+	return result, err
+}
+
+// reconcile implements Reconcile, errors caused by the Hetzner Cloud rate limit are handled by the caller
+func (r *HcloudServerOperationReconciler) reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := logf.Log.WithName("hcloudserveroperation-controller")
+
+	// Fetch the HcloudServerOperation resource
+	var operation hcloudv1alpha1.HcloudServerOperation
+	if err := r.Get(ctx, req.NamespacedName, &operation); err != nil {
+		// object does not exist, nothing to do
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if operationFinished(operation.Status.Phase) {
+		return ctrl.Result{}, nil
+	}
+
+	// A paused operation does not start its next step
+	if paused, result, err := reconcilePaused(ctx, r.Client, r.Recorder, r.Pause, "HcloudServerOperation", &operation, &operation.Status.Conditions); paused {
+		return result, err
+	}
+
+	log.Info("Reconciling HcloudServerOperation", "name", operation.Name, "namespace", operation.Namespace)
+
+	// Resolve the Hetzner Cloud client for this resource
+	actionClient, provider, err := r.actionClientFor(ctx, &operation)
+	if err != nil {
+		log.Error(err, "Failed to resolve Hetzner Cloud credentials", "name", operation.Name)
+		meta.SetStatusCondition(&operation.Status.Conditions, metav1.Condition{
+			Type:               "Available",
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: operation.Generation,
+			Reason:             credentialsUnavailableReason,
+			Message:            fmt.Sprintf("Failed to resolve Hetzner Cloud credentials: %v", err),
+		})
+		if err := r.Status().Update(ctx, &operation); err != nil {
+			log.Error(err, "Failed to update HcloudServerOperation status", "name", operation.Name)
+		}
+		r.Recorder.Eventf(&operation, "Warning", credentialsUnavailableReason, "Failed to resolve Hetzner Cloud credentials: %v", err)
+
+		return ctrl.Result{}, err
+	}
+
+	if operation.Status.StartTime == nil {
+		result, err := r.start(ctx, actionClient, &operation)
+		if err != nil || operationFinished(operation.Status.Phase) {
+			return result, err
+		}
+	}
+
+	return r.runSteps(ctx, actionClient, provider, &operation)
+}
+
+// start looks up the server of the operation and records its steps. Once started, the spec of the
+// operation can no longer be changed.
+func (r *HcloudServerOperationReconciler) start(ctx context.Context, actionClient hcloud.ServerActionClient, operation *hcloudv1alpha1.HcloudServerOperation) (ctrl.Result, error) {
+	log := logf.Log.WithName("hcloudserveroperation-controller")
+
+	target := operation.Spec.Server
+	var server *hcloudgo.Server
+	var response *hcloudgo.Response
+	var err error
+	if target.ServerId != 0 {
+		server, response, err = actionClient.GetServerById(ctx, int64(target.ServerId))
+	} else {
+		server, response, err = actionClient.GetServerByName(ctx, target.Name)
+	}
+	if err != nil {
+		return r.operationError(ctx, operation, fmt.Errorf("failed to get server %s from Hetzner Cloud: %w. %v", describeTarget(target), err, response))
+	}
+	if server == nil {
+		return r.operationFailed(ctx, operation, serverNotFoundReason, fmt.Sprintf("Server %s not found in Hetzner Cloud", describeTarget(target)))
+	}
+
+	// Missing SSH keys fail the operation before anything is done to the server
+	if operation.Spec.Rescue != nil {
+		if _, err := resolveSSHKeys(ctx, actionClient.GetSSHKeyByName, operation.Spec.Rescue.SSHKeys); err != nil {
+			var apiErr hcloudgo.Error
+			if errors.As(err, &apiErr) {
+				return r.operationError(ctx, operation, err)
+			}
+			return r.operationFailed(ctx, operation, unresolvedReferencesReason, err.Error())
+		}
+	}
+
+	now := metav1.Now()
+	operation.Status.Phase = hcloudv1alpha1.ServerOperationRunning
+	operation.Status.ServerId = int(server.ID)
+	operation.Status.StartTime = &now
+	operation.Status.Steps = nil
+	for _, name := range operationSteps[operation.Spec.Operation] {
+		operation.Status.Steps = append(operation.Status.Steps, hcloudv1alpha1.HcloudServerOperationStep{
+			Name:  name,
+			Phase: hcloudv1alpha1.ServerOperationPending,
+		})
+	}
+	setOperationRunning(operation)
+	if err := r.Status().Update(ctx, operation); err != nil {
+		log.Error(err, "Failed to update HcloudServerOperation status", "name", operation.Name)
+		return ctrl.Result{}, err
+	}
+	log.Info("Started server operation", "name", operation.Name, "operation", operation.Spec.Operation, "serverId", server.ID)
+	r.Recorder.Eventf(operation, "Normal", "Started", "Started %s on server %d", operation.Spec.Operation, server.ID)
+
+	return ctrl.Result{}, nil
+}
+
+// runSteps follows the running step and starts the next ones. A step is saved as running before
+// its action is started and saved again with the ID of the action. A running step without an
+// action ID may or may not have started its action, so it fails the operation instead of starting
+// the action a second time.
+func (r *HcloudServerOperationReconciler) runSteps(ctx context.Context, actionClient hcloud.ServerActionClient, provider *hcloudProvider, operation *hcloudv1alpha1.HcloudServerOperation) (ctrl.Result, error) {
+	log := logf.Log.WithName("hcloudserveroperation-controller")
+
+	server := &hcloudgo.Server{ID: int64(operation.Status.ServerId)}
+	for i := range operation.Status.Steps {
+		step := &operation.Status.Steps[i]
+		if step.Phase == hcloudv1alpha1.ServerOperationSucceeded {
+			continue
+		}
+
+		var action *hcloudgo.Action
+		if step.Phase == hcloudv1alpha1.ServerOperationPending {
+			// The running step is saved first, so that a start interrupted before its action was
+			// recorded is noticed by the next reconcile
+			now := metav1.Now()
+			step.Phase = hcloudv1alpha1.ServerOperationRunning
+			step.StartTime = &now
+			setOperationRunning(operation)
+			if err := r.Status().Update(ctx, operation); err != nil {
+				log.Error(err, "Failed to update HcloudServerOperation status", "name", operation.Name)
+				return ctrl.Result{}, err
+			}
+			step = &operation.Status.Steps[i]
+
+			started, message, response, err := r.startStep(ctx, actionClient, operation, server, step.Name)
+			if err != nil {
+				return r.stepError(ctx, operation, step, fmt.Errorf("%w. %v", err, response))
+			}
+			step.Message = message
+			if started == nil {
+				// Nothing to do, such as shutting down a server that is already off
+				completeStep(step)
+				continue
+			}
+			log.Info("Started operation step", "name", operation.Name, "step", step.Name, "actionId", started.ID)
+			step.ActionId = started.ID
+			step.Progress = started.Progress
+			setOperationRunning(operation)
+			if err := r.Status().Update(ctx, operation); err != nil {
+				log.Error(err, "Failed to update HcloudServerOperation status", "name", operation.Name)
+				return ctrl.Result{}, err
+			}
+			// The update decoded the saved status into new steps
+			step = &operation.Status.Steps[i]
+			action = started
+		} else if step.ActionId == 0 {
+			failStep(step, "The step was interrupted before its action was recorded, it is not started again as it may already be running")
+			return r.operationFailed(ctx, operation, stepInterruptedReason, fmt.Sprintf("Step %s failed: %s", step.Name, step.Message))
+		} else {
+			var response *hcloudgo.Response
+			var err error
+			action, response, err = actionClient.GetAction(ctx, step.ActionId)
+			if err != nil {
+				return r.operationError(ctx, operation, fmt.Errorf("failed to get action %d from Hetzner Cloud: %w. %v", step.ActionId, err, response))
+			}
+		}
+
+		switch {
+		case action != nil && action.Status == hcloudgo.ActionStatusError:
+			failStep(step, fmt.Sprintf("Hetzner Cloud action %d failed with %s: %s", action.ID, action.ErrorCode, action.ErrorMessage))
+			return r.operationFailed(ctx, operation, actionFailedReason, fmt.Sprintf("Step %s failed: %s", step.Name, step.Message))
+		case actionPending(action):
+			step.Progress = action.Progress
+			setOperationRunning(operation)
+			if err := r.Status().Update(ctx, operation); err != nil {
+				log.Error(err, "Failed to update HcloudServerOperation status", "name", operation.Name)
+				return ctrl.Result{}, err
+			}
+			return ctrl.Result{RequeueAfter: provider.actionPollInterval()}, nil
+		}
+
+		// The shutdown action completes once the request was sent to the server, the step waits
+		// until the server is off
+		if step.Name == shutdownServerStep {
+			current, response, err := actionClient.GetServerById(ctx, server.ID)
+			if err != nil {
+				return r.operationError(ctx, operation, fmt.Errorf("failed to get server %d from Hetzner Cloud: %w. %v", server.ID, err, response))
+			}
+			if current == nil {
+				failStep(step, "Server no longer exists")
+				return r.operationFailed(ctx, operation, serverNotFoundReason, fmt.Sprintf("Server %d no longer exists in Hetzner Cloud", server.ID))
+			}
+			if current.Status != hcloudgo.ServerStatusOff {
+				timeout := shutdownTimeout(operation.Spec)
+				if time.Since(step.StartTime.Time) > timeout {
+					failStep(step, fmt.Sprintf("Server is still %s after %s", current.Status, timeout))
+					return r.operationFailed(ctx, operation, shutdownTimeoutReason, fmt.Sprintf("Server %d did not shut down within %s", server.ID, timeout))
+				}
+				step.Progress = 100
+				step.Message = fmt.Sprintf("Waiting for the server to shut down, it is %s", current.Status)
+				setOperationRunning(operation)
+				if err := r.Status().Update(ctx, operation); err != nil {
+					log.Error(err, "Failed to update HcloudServerOperation status", "name", operation.Name)
+					return ctrl.Result{}, err
+				}
+				return ctrl.Result{RequeueAfter: provider.actionPollInterval()}, nil
+			}
+			step.Message = "Server is off"
+		}
+
+		log.Info("Operation step completed", "name", operation.Name, "step", step.Name)
+		completeStep(step)
+	}
+
+	return r.operationSucceeded(ctx, operation)
+}
+
+// startStep starts the Hetzner Cloud action of a step and returns it together with a message
+// describing the result of the step. No action is returned when the step has nothing to do.
+func (r *HcloudServerOperationReconciler) startStep(ctx context.Context, actionClient hcloud.ServerActionClient, operation *hcloudv1alpha1.HcloudServerOperation, server *hcloudgo.Server, name string) (*hcloudgo.Action, string, *hcloudgo.Response, error) {
+	spec := operation.Spec
+	switch name {
+	case startServerStep:
+		action, response, err := actionClient.PowerOnServer(ctx, server)
+		return action, "", response, err
+	case stopServerStep:
+		action, response, err := actionClient.PowerOffServer(ctx, server)
+		return action, "", response, err
+	case shutdownServerStep:
+		current, response, err := actionClient.GetServerById(ctx, server.ID)
+		if err != nil {
+			return nil, "", response, err
+		}
+		if current != nil && current.Status == hcloudgo.ServerStatusOff {
+			return nil, "Server is already off", response, nil
+		}
+		action, response, err := actionClient.ShutdownServer(ctx, server)
+		return action, "", response, err
+	case rebootServerStep:
+		action, response, err := actionClient.RebootServer(ctx, server)
+		return action, "", response, err
+	case resetServerStep:
+		action, response, err := actionClient.ResetServer(ctx, server)
+		return action, "", response, err
+	case enableRescueStep:
+		opts := hcloudgo.ServerEnableRescueOpts{Type: hcloudgo.ServerRescueTypeLinux64}
+		if spec.Rescue != nil {
+			if spec.Rescue.Type != "" {
+				opts.Type = hcloudgo.ServerRescueType(spec.Rescue.Type)
+			}
+			sshKeys, err := resolveSSHKeys(ctx, actionClient.GetSSHKeyByName, spec.Rescue.SSHKeys)
+			if err != nil {
+				return nil, "", nil, err
+			}
+			opts.SSHKeys = sshKeys
+		}
+		action, response, err := actionClient.EnableServerRescue(ctx, server, opts)
+		return action, fmt.Sprintf("Rescue system %s", opts.Type), response, err
+	case rebuildServerStep:
+		action, response, err := actionClient.RebuildServer(ctx, server, imageReference(spec.Rebuild.Image))
+		return action, fmt.Sprintf("Image %s", spec.Rebuild.Image), response, err
+	case changeServerTypeStep:
+		opts := hcloudgo.ServerChangeTypeOpts{
+			ServerType:  &hcloudgo.ServerType{Name: spec.ChangeType.ServerType},
+			UpgradeDisk: spec.ChangeType.UpgradeDisk,
+		}
+		action, response, err := actionClient.ChangeServerType(ctx, server, opts)
+		return action, fmt.Sprintf("Server type %s", spec.ChangeType.ServerType), response, err
+	case createImageStep:
+		opts := hcloudgo.ServerCreateImageOpts{Type: hcloudgo.ImageTypeSnapshot}
+		if spec.Snapshot != nil {
+			if spec.Snapshot.Description != "" {
+				opts.Description = hcloudgo.Ptr(spec.Snapshot.Description)
+			}
+			opts.Labels = spec.Snapshot.Labels
+		}
+		result, response, err := actionClient.CreateServerImage(ctx, server, opts)
+		if err != nil {
+			return nil, "", response, err
+		}
+		operation.Status.ImageId = int(result.Image.ID)
+		return result.Action, fmt.Sprintf("Snapshot image %d", result.Image.ID), response, nil
+	}
+	return nil, "", nil, fmt.Errorf("unknown step %s", name)
+}
+
+// stepError reports an error starting a step. An exhausted rate limit rejected the request, so the
+// step is pending again and returned for a retry. Errors returned by the Hetzner Cloud API, such as
+// a server that is not stopped or an unknown image, fail the operation. Other errors, such as
+// timeouts, leave it open whether the action was started, so they fail the operation as well.
+func (r *HcloudServerOperationReconciler) stepError(ctx context.Context, operation *hcloudv1alpha1.HcloudServerOperation, step *hcloudv1alpha1.HcloudServerOperationStep, err error) (ctrl.Result, error) {
+	log := logf.Log.WithName("hcloudserveroperation-controller")
+
+	if _, ok := hcloud.RateLimitReset(err); ok {
+		step.Phase = hcloudv1alpha1.ServerOperationPending
+		step.StartTime = nil
+		setOperationRunning(operation)
+		if err := r.Status().Update(ctx, operation); err != nil {
+			log.Error(err, "Failed to update HcloudServerOperation status", "name", operation.Name)
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, fmt.Errorf("failed to start step %s: %w", step.Name, err)
+	}
+
+	var apiErr hcloudgo.Error
+	if !errors.As(err, &apiErr) {
+		failStep(step, fmt.Sprintf("Starting the step failed, it is not started again as it may already be running: %v", err))
+		return r.operationFailed(ctx, operation, stepInterruptedReason, fmt.Sprintf("Step %s failed: %s", step.Name, step.Message))
+	}
+	failStep(step, apiErr.Error())
+	return r.operationFailed(ctx, operation, "Failed", fmt.Sprintf("Step %s failed: %v", step.Name, apiErr))
+}
+
+// operationError reports an error that is retried, the phase of the operation does not change
+func (r *HcloudServerOperationReconciler) operationError(ctx context.Context, operation *hcloudv1alpha1.HcloudServerOperation, err error) (ctrl.Result, error) {
+	log := logf.Log.WithName("hcloudserveroperation-controller")
+
+	if _, ok := hcloud.RateLimitReset(err); ok {
+		return ctrl.Result{}, err
+	}
+	log.Error(err, "Server operation failed, retrying", "name", operation.Name)
+	meta.SetStatusCondition(&operation.Status.Conditions, metav1.Condition{
+		Type:               "Available",
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: operation.Generation,
+		Reason:             "Failed",
+		Message:            err.Error(),
+	})
+	if err := r.Status().Update(ctx, operation); err != nil {
+		log.Error(err, "Failed to update HcloudServerOperation status", "name", operation.Name)
+	}
+
+	return ctrl.Result{}, err
+}
+
+// operationFailed fails the operation for good
+func (r *HcloudServerOperationReconciler) operationFailed(ctx context.Context, operation *hcloudv1alpha1.HcloudServerOperation, reason, message string) (ctrl.Result, error) {
+	log := logf.Log.WithName("hcloudserveroperation-controller")
+
+	log.Info("Server operation failed", "name", operation.Name, "reason", reason, "message", message)
+	now := metav1.Now()
+	operation.Status.Phase = hcloudv1alpha1.ServerOperationFailed
+	operation.Status.CompletionTime = &now
+	setProgressingCondition(&operation.Status.Conditions, operation.Generation, nil)
+	meta.SetStatusCondition(&operation.Status.Conditions, metav1.Condition{
+		Type:               "Available",
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: operation.Generation,
+		Reason:             reason,
+		Message:            message,
+	})
+	if err := r.Status().Update(ctx, operation); err != nil {
+		log.Error(err, "Failed to update HcloudServerOperation status", "name", operation.Name)
+		return ctrl.Result{}, err
+	}
+	r.Recorder.Eventf(operation, "Warning", reason, "%s failed: %s", operation.Spec.Operation, message)
+
+	return ctrl.Result{}, nil
+}
+
+// operationSucceeded completes the operation once all of its steps succeeded
+func (r *HcloudServerOperationReconciler) operationSucceeded(ctx context.Context, operation *hcloudv1alpha1.HcloudServerOperation) (ctrl.Result, error) {
+	log := logf.Log.WithName("hcloudserveroperation-controller")
+
+	now := metav1.Now()
+	operation.Status.Phase = hcloudv1alpha1.ServerOperationSucceeded
+	operation.Status.CompletionTime = &now
+	setProgressingCondition(&operation.Status.Conditions, operation.Generation, nil)
+	meta.SetStatusCondition(&operation.Status.Conditions, metav1.Condition{
+		Type:               "Available",
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: operation.Generation,
+		Reason:             string(hcloudv1alpha1.ServerOperationSucceeded),
+		Message:            fmt.Sprintf("%s completed on server %d", operation.Spec.Operation, operation.Status.ServerId),
+	})
+	if err := r.Status().Update(ctx, operation); err != nil {
+		log.Error(err, "Failed to update HcloudServerOperation status", "name", operation.Name)
+		return ctrl.Result{}, err
+	}
+	log.Info("Server operation succeeded", "name", operation.Name, "operation", operation.Spec.Operation, "serverId", operation.Status.ServerId)
+	r.Recorder.Eventf(operation, "Normal", string(hcloudv1alpha1.ServerOperationSucceeded), "%s completed on server %d", operation.Spec.Operation, operation.Status.ServerId)
+
+	return ctrl.Result{}, nil
+}
+
+// setOperationRunning reports the running step on the Available condition and the progress of its
+// action on the Progressing condition
+func setOperationRunning(operation *hcloudv1alpha1.HcloudServerOperation) {
+	var running []hcloudv1alpha1.HcloudAction
+	message := fmt.Sprintf("%s is starting", operation.Spec.Operation)
+	for i, step := range operation.Status.Steps {
+		if step.Phase != hcloudv1alpha1.ServerOperationRunning {
+			continue
+		}
+		message = fmt.Sprintf("Step %d of %d: %s", i+1, len(operation.Status.Steps), step.Name)
+		if step.ActionId != 0 {
+			running = append(running, hcloudv1alpha1.HcloudAction{
+				Id:       step.ActionId,
+				Command:  step.Name,
+				Progress: step.Progress,
+				Started:  *step.StartTime,
+			})
+		}
+	}
+	setProgressingCondition(&operation.Status.Conditions, operation.Generation, running)
+	meta.SetStatusCondition(&operation.Status.Conditions, metav1.Condition{
+		Type:               "Available",
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: operation.Generation,
+		Reason:             string(hcloudv1alpha1.ServerOperationRunning),
+		Message:            message,
+	})
+}
+
+// completeStep marks a step as succeeded
+func completeStep(step *hcloudv1alpha1.HcloudServerOperationStep) {
+	now := metav1.Now()
+	step.Phase = hcloudv1alpha1.ServerOperationSucceeded
+	step.Progress = 100
+	step.CompletionTime = &now
+}
+
+// failStep marks a step as failed with the reason in its message
+func failStep(step *hcloudv1alpha1.HcloudServerOperationStep, message string) {
+	now := metav1.Now()
+	step.Phase = hcloudv1alpha1.ServerOperationFailed
+	step.CompletionTime = &now
+	step.Message = message
+}
+
+// operationFinished reports whether an operation in the phase has completed, successfully or not
+func operationFinished(phase hcloudv1alpha1.HcloudServerOperationPhase) bool {
+	return phase == hcloudv1alpha1.ServerOperationSucceeded || phase == hcloudv1alpha1.ServerOperationFailed
+}
+
+// shutdownTimeout returns how long a shutdown step waits for the server to be off
+func shutdownTimeout(spec hcloudv1alpha1.HcloudServerOperationSpec) time.Duration {
+	if spec.ChangeType != nil && spec.ChangeType.ShutdownTimeout != nil && spec.ChangeType.ShutdownTimeout.Duration > 0 {
+		return spec.ChangeType.ShutdownTimeout.Duration
+	}
+	return defaultShutdownTimeout
+}
+
+// describeTarget describes the server of an operation for messages
+func describeTarget(target hcloudv1alpha1.HcloudServerOperationTarget) string {
+	if target.ServerId != 0 {
+		return fmt.Sprintf("with ID %d", target.ServerId)
+	}
+	return target.Name
+}
+
+// actionClientFor returns the Hetzner Cloud client for the credentials of the HcloudServerOperation
+// together with its resolved provider settings
+func (r *HcloudServerOperationReconciler) actionClientFor(ctx context.Context, operation *hcloudv1alpha1.HcloudServerOperation) (hcloud.ServerActionClient, *hcloudProvider, error) {
+	provider, err := resolveProvider(ctx, r.Client, r.DefaultProviderConfig, operation.Namespace, operation.Spec.CredentialsRef, operation.Spec.ProviderConfigRef)
+	if err != nil {
+		return nil, nil, err
+	}
+	if provider.token == "" {
+		if r.ServerActionClient == nil {
+			return nil, nil, fmt.Errorf("no credentials configured for the resource and no Hetzner Cloud token configured for the manager")
+		}
+		return r.ServerActionClient, provider, nil
+	}
+	if r.ClientCache == nil {
+		return nil, nil, fmt.Errorf("no client cache configured to resolve credentials")
+	}
+	return r.ClientCache.ServerActionClient(provider.cacheKey, provider.cacheVersion, provider.token, provider.options...), provider, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *HcloudServerOperationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&hcloudv1alpha1.HcloudServerOperation{}, builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, pausedAnnotationChanged))).
+		Named("hcloudserveroperation").
+		Complete(r)
+}
@@ -0,0 +1,525 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hcloudv1alpha1 "bunskin.com/hcrm/api/v1alpha1"
+	"bunskin.com/hcrm/pkg/hcloud"
+	hcloudgo "github.com/hetznercloud/hcloud-go/v2/hcloud"
+	"github.com/hetznercloud/hcloud-go/v2/hcloud/schema"
+)
+
+var _ = Describe("HcloudServerOperation Controller", func() {
+	const namespace = "default"
+
+	ctx := context.Background()
+
+	// newOperation returns an HcloudServerOperation performing operation on the server web-1
+	newOperation := func(name string, operation hcloudv1alpha1.HcloudServerOperationType) *hcloudv1alpha1.HcloudServerOperation {
+		return &hcloudv1alpha1.HcloudServerOperation{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+			Spec: hcloudv1alpha1.HcloudServerOperationSpec{
+				Server:    hcloudv1alpha1.HcloudServerOperationTarget{Name: "web-1"},
+				Operation: operation,
+			},
+		}
+	}
+
+	// runningServer returns a mock whose server web-1 with ID 42 is running
+	runningServer := func() (*hcloud.MockServerActionClient, *hcloudgo.Server) {
+		server := &hcloudgo.Server{ID: 42, Name: "web-1", Status: hcloudgo.ServerStatusRunning}
+		mock := &hcloud.MockServerActionClient{}
+		mock.GetServerByNameFunc = func(ctx context.Context, name string) (*hcloudgo.Server, *hcloudgo.Response, error) {
+			if name != server.Name {
+				return nil, nil, nil
+			}
+			return server, nil, nil
+		}
+		mock.GetServerByIdFunc = func(ctx context.Context, id int64) (*hcloudgo.Server, *hcloudgo.Response, error) {
+			return server, nil, nil
+		}
+		return mock, server
+	}
+
+	Context("ChangeType", func() {
+		It("should shut the server down, change its type and start it again", func() {
+			const resourceName = "test-change-type"
+			typeNamespacedName := types.NamespacedName{Name: resourceName, Namespace: namespace}
+
+			resource := newOperation(resourceName, hcloudv1alpha1.ServerOperationChangeType)
+			resource.Spec.ChangeType = &hcloudv1alpha1.HcloudServerChangeType{ServerType: "cx32"}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			var calls []string
+			var changeOpts hcloudgo.ServerChangeTypeOpts
+			shutdownStatus := hcloudgo.ActionStatusRunning
+			MockServerActionClient, server := runningServer()
+			MockServerActionClient.ShutdownServerFunc = func(ctx context.Context, s *hcloudgo.Server) (*hcloudgo.Action, *hcloudgo.Response, error) {
+				calls = append(calls, "shutdown")
+				return &hcloudgo.Action{ID: 901, Command: "shutdown_server", Status: hcloudgo.ActionStatusRunning, Progress: 10}, nil, nil
+			}
+			MockServerActionClient.GetActionFunc = func(ctx context.Context, id int64) (*hcloudgo.Action, *hcloudgo.Response, error) {
+				return &hcloudgo.Action{ID: id, Status: shutdownStatus, Progress: 50}, nil, nil
+			}
+			MockServerActionClient.ChangeServerTypeFunc = func(ctx context.Context, s *hcloudgo.Server, opts hcloudgo.ServerChangeTypeOpts) (*hcloudgo.Action, *hcloudgo.Response, error) {
+				calls = append(calls, "change_type")
+				changeOpts = opts
+				return &hcloudgo.Action{ID: 902, Command: "change_server_type", Status: hcloudgo.ActionStatusSuccess}, nil, nil
+			}
+			MockServerActionClient.PowerOnServerFunc = func(ctx context.Context, s *hcloudgo.Server) (*hcloudgo.Action, *hcloudgo.Response, error) {
+				calls = append(calls, "poweron")
+				return &hcloudgo.Action{ID: 903, Command: "start_server", Status: hcloudgo.ActionStatusSuccess}, nil, nil
+			}
+
+			reconciler := &HcloudServerOperationReconciler{
+				Client:             k8sClient,
+				Scheme:             k8sClient.Scheme(),
+				ServerActionClient: MockServerActionClient,
+				Recorder:           recorder,
+			}
+
+			By("starting the shutdown")
+			result, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(defaultActionPollInterval))
+			Expect(calls).To(Equal([]string{"shutdown"}))
+
+			updatedResource := &hcloudv1alpha1.HcloudServerOperation{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			Expect(updatedResource.Status.Phase).To(Equal(hcloudv1alpha1.ServerOperationRunning))
+			Expect(updatedResource.Status.ServerId).To(Equal(42))
+			Expect(updatedResource.Status.StartTime).NotTo(BeNil())
+			Expect(updatedResource.Status.Steps).To(HaveLen(3))
+			Expect(updatedResource.Status.Steps[0].Name).To(Equal(shutdownServerStep))
+			Expect(updatedResource.Status.Steps[0].Phase).To(Equal(hcloudv1alpha1.ServerOperationRunning))
+			Expect(updatedResource.Status.Steps[0].ActionId).To(Equal(int64(901)))
+			Expect(updatedResource.Status.Steps[1].Phase).To(Equal(hcloudv1alpha1.ServerOperationPending))
+			condition := meta.FindStatusCondition(updatedResource.Status.Conditions, progressingCondition)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(condition.Message).To(ContainSubstring("shutdown_server (action 901) 10%"))
+			condition = meta.FindStatusCondition(updatedResource.Status.Conditions, "Available")
+			Expect(condition.Reason).To(Equal(string(hcloudv1alpha1.ServerOperationRunning)))
+			Expect(condition.Message).To(Equal("Step 1 of 3: shutdown_server"))
+
+			By("waiting for the server to be off after the shutdown action completed")
+			shutdownStatus = hcloudgo.ActionStatusSuccess
+			result, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(defaultActionPollInterval))
+			Expect(calls).To(Equal([]string{"shutdown"}))
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			Expect(updatedResource.Status.Steps[0].Phase).To(Equal(hcloudv1alpha1.ServerOperationRunning))
+			Expect(updatedResource.Status.Steps[0].Message).To(ContainSubstring("Waiting for the server to shut down"))
+
+			By("changing the type and starting the server once it is off")
+			server.Status = hcloudgo.ServerStatusOff
+			result, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeZero())
+			Expect(calls).To(Equal([]string{"shutdown", "change_type", "poweron"}))
+			Expect(changeOpts.ServerType.Name).To(Equal("cx32"))
+			Expect(changeOpts.UpgradeDisk).To(BeFalse())
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			Expect(updatedResource.Status.Phase).To(Equal(hcloudv1alpha1.ServerOperationSucceeded))
+			Expect(updatedResource.Status.CompletionTime).NotTo(BeNil())
+			for _, step := range updatedResource.Status.Steps {
+				Expect(step.Phase).To(Equal(hcloudv1alpha1.ServerOperationSucceeded))
+				Expect(step.Progress).To(Equal(100))
+			}
+			Expect(updatedResource.Status.Steps[1].ActionId).To(Equal(int64(902)))
+			Expect(updatedResource.Status.Steps[1].Message).To(Equal("Server type cx32"))
+			condition = meta.FindStatusCondition(updatedResource.Status.Conditions, "Available")
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(condition.Reason).To(Equal(string(hcloudv1alpha1.ServerOperationSucceeded)))
+			Expect(meta.IsStatusConditionTrue(updatedResource.Status.Conditions, progressingCondition)).To(BeFalse())
+
+			By("leaving the finished operation alone")
+			_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(calls).To(HaveLen(3))
+
+			Expect(k8sClient.Delete(ctx, updatedResource)).To(Succeed())
+		})
+
+		It("should fail when the server does not shut down in time", func() {
+			const resourceName = "test-shutdown-timeout"
+			typeNamespacedName := types.NamespacedName{Name: resourceName, Namespace: namespace}
+
+			resource := newOperation(resourceName, hcloudv1alpha1.ServerOperationChangeType)
+			resource.Spec.ChangeType = &hcloudv1alpha1.HcloudServerChangeType{
+				ServerType:      "cx32",
+				ShutdownTimeout: &metav1.Duration{Duration: time.Nanosecond},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			MockServerActionClient, _ := runningServer()
+			MockServerActionClient.ShutdownServerFunc = func(ctx context.Context, s *hcloudgo.Server) (*hcloudgo.Action, *hcloudgo.Response, error) {
+				return &hcloudgo.Action{ID: 904, Command: "shutdown_server", Status: hcloudgo.ActionStatusSuccess}, nil, nil
+			}
+			MockServerActionClient.ChangeServerTypeFunc = func(ctx context.Context, s *hcloudgo.Server, opts hcloudgo.ServerChangeTypeOpts) (*hcloudgo.Action, *hcloudgo.Response, error) {
+				Fail("the server type must not be changed while the server is running")
+				return nil, nil, nil
+			}
+
+			reconciler := &HcloudServerOperationReconciler{
+				Client:             k8sClient,
+				Scheme:             k8sClient.Scheme(),
+				ServerActionClient: MockServerActionClient,
+				Recorder:           recorder,
+			}
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			updatedResource := &hcloudv1alpha1.HcloudServerOperation{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			Expect(updatedResource.Status.Phase).To(Equal(hcloudv1alpha1.ServerOperationFailed))
+			Expect(updatedResource.Status.Steps[0].Phase).To(Equal(hcloudv1alpha1.ServerOperationFailed))
+			Expect(updatedResource.Status.Steps[1].Phase).To(Equal(hcloudv1alpha1.ServerOperationPending))
+			condition := meta.FindStatusCondition(updatedResource.Status.Conditions, "Available")
+			Expect(condition.Reason).To(Equal(shutdownTimeoutReason))
+
+			Expect(k8sClient.Delete(ctx, updatedResource)).To(Succeed())
+		})
+	})
+
+	Context("CreateSnapshot", func() {
+		It("should record the ID of the snapshot image", func() {
+			const resourceName = "test-create-snapshot"
+			typeNamespacedName := types.NamespacedName{Name: resourceName, Namespace: namespace}
+
+			resource := newOperation(resourceName, hcloudv1alpha1.ServerOperationCreateSnapshot)
+			resource.Spec.Server = hcloudv1alpha1.HcloudServerOperationTarget{ServerId: 42}
+			resource.Spec.Snapshot = &hcloudv1alpha1.HcloudServerSnapshot{Description: "before upgrade", Labels: map[string]string{"env": "test"}}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			var imageOpts hcloudgo.ServerCreateImageOpts
+			MockServerActionClient, _ := runningServer()
+			MockServerActionClient.GetServerByNameFunc = func(ctx context.Context, name string) (*hcloudgo.Server, *hcloudgo.Response, error) {
+				Fail("a server selected by ID must not be looked up by name")
+				return nil, nil, nil
+			}
+			MockServerActionClient.CreateServerImageFunc = func(ctx context.Context, s *hcloudgo.Server, opts hcloudgo.ServerCreateImageOpts) (*hcloudgo.ServerCreateImageResult, *hcloudgo.Response, error) {
+				imageOpts = opts
+				return &hcloudgo.ServerCreateImageResult{
+					Image:  &hcloudgo.Image{ID: 5001},
+					Action: &hcloudgo.Action{ID: 905, Command: "create_image", Status: hcloudgo.ActionStatusSuccess},
+				}, nil, nil
+			}
+
+			reconciler := &HcloudServerOperationReconciler{
+				Client:             k8sClient,
+				Scheme:             k8sClient.Scheme(),
+				ServerActionClient: MockServerActionClient,
+				Recorder:           recorder,
+			}
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(imageOpts.Type).To(Equal(hcloudgo.ImageTypeSnapshot))
+			Expect(*imageOpts.Description).To(Equal("before upgrade"))
+			Expect(imageOpts.Labels).To(Equal(map[string]string{"env": "test"}))
+
+			updatedResource := &hcloudv1alpha1.HcloudServerOperation{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			Expect(updatedResource.Status.Phase).To(Equal(hcloudv1alpha1.ServerOperationSucceeded))
+			Expect(updatedResource.Status.ImageId).To(Equal(5001))
+			Expect(updatedResource.Status.Steps).To(HaveLen(1))
+			Expect(updatedResource.Status.Steps[0].Message).To(Equal("Snapshot image 5001"))
+
+			Expect(k8sClient.Delete(ctx, updatedResource)).To(Succeed())
+		})
+	})
+
+	Context("Failures", func() {
+		It("should fail when the server does not exist", func() {
+			const resourceName = "test-operation-missing-server"
+			typeNamespacedName := types.NamespacedName{Name: resourceName, Namespace: namespace}
+
+			resource := newOperation(resourceName, hcloudv1alpha1.ServerOperationReboot)
+			resource.Spec.Server.Name = "web-2"
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			MockServerActionClient, _ := runningServer()
+			MockServerActionClient.RebootServerFunc = func(ctx context.Context, s *hcloudgo.Server) (*hcloudgo.Action, *hcloudgo.Response, error) {
+				Fail("a missing server must not be rebooted")
+				return nil, nil, nil
+			}
+
+			reconciler := &HcloudServerOperationReconciler{
+				Client:             k8sClient,
+				Scheme:             k8sClient.Scheme(),
+				ServerActionClient: MockServerActionClient,
+				Recorder:           recorder,
+			}
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			updatedResource := &hcloudv1alpha1.HcloudServerOperation{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			Expect(updatedResource.Status.Phase).To(Equal(hcloudv1alpha1.ServerOperationFailed))
+			Expect(updatedResource.Status.StartTime).To(BeNil())
+			condition := meta.FindStatusCondition(updatedResource.Status.Conditions, "Available")
+			Expect(condition.Reason).To(Equal(serverNotFoundReason))
+			Expect(condition.Message).To(ContainSubstring("web-2"))
+
+			Expect(k8sClient.Delete(ctx, updatedResource)).To(Succeed())
+		})
+
+		It("should fail before touching the server when a rescue SSH key is missing", func() {
+			const resourceName = "test-rescue-missing-key"
+			typeNamespacedName := types.NamespacedName{Name: resourceName, Namespace: namespace}
+
+			resource := newOperation(resourceName, hcloudv1alpha1.ServerOperationEnableRescue)
+			resource.Spec.Rescue = &hcloudv1alpha1.HcloudServerRescue{SSHKeys: []string{"admin"}}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			MockServerActionClient, _ := runningServer()
+			MockServerActionClient.EnableServerRescueFunc = func(ctx context.Context, s *hcloudgo.Server, opts hcloudgo.ServerEnableRescueOpts) (*hcloudgo.Action, *hcloudgo.Response, error) {
+				Fail("rescue must not be enabled without the SSH keys")
+				return nil, nil, nil
+			}
+
+			reconciler := &HcloudServerOperationReconciler{
+				Client:             k8sClient,
+				Scheme:             k8sClient.Scheme(),
+				ServerActionClient: MockServerActionClient,
+				Recorder:           recorder,
+			}
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			updatedResource := &hcloudv1alpha1.HcloudServerOperation{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			Expect(updatedResource.Status.Phase).To(Equal(hcloudv1alpha1.ServerOperationFailed))
+			condition := meta.FindStatusCondition(updatedResource.Status.Conditions, "Available")
+			Expect(condition.Reason).To(Equal(unresolvedReferencesReason))
+			Expect(condition.Message).To(ContainSubstring("SSH key admin not found"))
+
+			Expect(k8sClient.Delete(ctx, updatedResource)).To(Succeed())
+		})
+
+		It("should fail the operation when Hetzner Cloud rejects a step", func() {
+			const resourceName = "test-rebuild-rejected"
+			typeNamespacedName := types.NamespacedName{Name: resourceName, Namespace: namespace}
+
+			resource := newOperation(resourceName, hcloudv1alpha1.ServerOperationRebuild)
+			resource.Spec.Rebuild = &hcloudv1alpha1.HcloudServerRebuild{Image: "12345"}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			var image *hcloudgo.Image
+			MockServerActionClient, _ := runningServer()
+			MockServerActionClient.RebuildServerFunc = func(ctx context.Context, s *hcloudgo.Server, i *hcloudgo.Image) (*hcloudgo.Action, *hcloudgo.Response, error) {
+				image = i
+				return nil, nil, hcloudgo.ErrorFromSchema(schema.Error{Code: string(hcloudgo.ErrorCodeNotFound), Message: "image not found"})
+			}
+
+			reconciler := &HcloudServerOperationReconciler{
+				Client:             k8sClient,
+				Scheme:             k8sClient.Scheme(),
+				ServerActionClient: MockServerActionClient,
+				Recorder:           recorder,
+			}
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(image.ID).To(Equal(int64(12345)))
+
+			updatedResource := &hcloudv1alpha1.HcloudServerOperation{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			Expect(updatedResource.Status.Phase).To(Equal(hcloudv1alpha1.ServerOperationFailed))
+			Expect(updatedResource.Status.Steps[0].Phase).To(Equal(hcloudv1alpha1.ServerOperationFailed))
+			Expect(updatedResource.Status.Steps[0].Message).To(ContainSubstring("image not found"))
+			condition := meta.FindStatusCondition(updatedResource.Status.Conditions, "Available")
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Message).To(ContainSubstring("Step rebuild_server failed"))
+
+			Expect(k8sClient.Delete(ctx, updatedResource)).To(Succeed())
+		})
+
+		It("should save the running step before starting its action", func() {
+			const resourceName = "test-reboot-saved-first"
+			typeNamespacedName := types.NamespacedName{Name: resourceName, Namespace: namespace}
+
+			resource := newOperation(resourceName, hcloudv1alpha1.ServerOperationReboot)
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			MockServerActionClient, _ := runningServer()
+			MockServerActionClient.RebootServerFunc = func(ctx context.Context, s *hcloudgo.Server) (*hcloudgo.Action, *hcloudgo.Response, error) {
+				saved := &hcloudv1alpha1.HcloudServerOperation{}
+				Expect(k8sClient.Get(ctx, typeNamespacedName, saved)).To(Succeed())
+				Expect(saved.Status.Steps[0].Phase).To(Equal(hcloudv1alpha1.ServerOperationRunning))
+				Expect(saved.Status.Steps[0].ActionId).To(BeZero())
+				return &hcloudgo.Action{ID: 907, Command: "reboot_server", Status: hcloudgo.ActionStatusRunning}, nil, nil
+			}
+
+			reconciler := &HcloudServerOperationReconciler{
+				Client:             k8sClient,
+				Scheme:             k8sClient.Scheme(),
+				ServerActionClient: MockServerActionClient,
+				Recorder:           recorder,
+			}
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			updatedResource := &hcloudv1alpha1.HcloudServerOperation{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			Expect(updatedResource.Status.Steps[0].ActionId).To(Equal(int64(907)))
+
+			Expect(k8sClient.Delete(ctx, updatedResource)).To(Succeed())
+		})
+
+		It("should fail a step interrupted before its action was recorded instead of starting it again", func() {
+			const resourceName = "test-reboot-interrupted"
+			typeNamespacedName := types.NamespacedName{Name: resourceName, Namespace: namespace}
+
+			resource := newOperation(resourceName, hcloudv1alpha1.ServerOperationReboot)
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+			now := metav1.Now()
+			resource.Status.Phase = hcloudv1alpha1.ServerOperationRunning
+			resource.Status.ServerId = 42
+			resource.Status.StartTime = &now
+			resource.Status.Steps = []hcloudv1alpha1.HcloudServerOperationStep{{
+				Name:      rebootServerStep,
+				Phase:     hcloudv1alpha1.ServerOperationRunning,
+				StartTime: &now,
+			}}
+			Expect(k8sClient.Status().Update(ctx, resource)).To(Succeed())
+
+			MockServerActionClient, _ := runningServer()
+			MockServerActionClient.RebootServerFunc = func(ctx context.Context, s *hcloudgo.Server) (*hcloudgo.Action, *hcloudgo.Response, error) {
+				Fail("an interrupted step must not be started again")
+				return nil, nil, nil
+			}
+
+			reconciler := &HcloudServerOperationReconciler{
+				Client:             k8sClient,
+				Scheme:             k8sClient.Scheme(),
+				ServerActionClient: MockServerActionClient,
+				Recorder:           recorder,
+			}
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			updatedResource := &hcloudv1alpha1.HcloudServerOperation{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			Expect(updatedResource.Status.Phase).To(Equal(hcloudv1alpha1.ServerOperationFailed))
+			Expect(updatedResource.Status.Steps[0].Phase).To(Equal(hcloudv1alpha1.ServerOperationFailed))
+			condition := meta.FindStatusCondition(updatedResource.Status.Conditions, "Available")
+			Expect(condition.Reason).To(Equal(stepInterruptedReason))
+
+			Expect(k8sClient.Delete(ctx, updatedResource)).To(Succeed())
+		})
+
+		It("should start a step again once the rate limit rejecting it resets", func() {
+			const resourceName = "test-reboot-rate-limited"
+			typeNamespacedName := types.NamespacedName{Name: resourceName, Namespace: namespace}
+
+			resource := newOperation(resourceName, hcloudv1alpha1.ServerOperationReboot)
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			reboots := 0
+			MockServerActionClient, _ := runningServer()
+			MockServerActionClient.RebootServerFunc = func(ctx context.Context, s *hcloudgo.Server) (*hcloudgo.Action, *hcloudgo.Response, error) {
+				reboots++
+				if reboots == 1 {
+					return nil, nil, &hcloud.RateLimitError{Reset: time.Now().Add(time.Minute)}
+				}
+				return &hcloudgo.Action{ID: 908, Command: "reboot_server", Status: hcloudgo.ActionStatusRunning}, nil, nil
+			}
+
+			reconciler := &HcloudServerOperationReconciler{
+				Client:             k8sClient,
+				Scheme:             k8sClient.Scheme(),
+				ServerActionClient: MockServerActionClient,
+				Recorder:           recorder,
+			}
+
+			result, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+
+			updatedResource := &hcloudv1alpha1.HcloudServerOperation{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			Expect(updatedResource.Status.Steps[0].Phase).To(Equal(hcloudv1alpha1.ServerOperationPending))
+
+			_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reboots).To(Equal(2))
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			Expect(updatedResource.Status.Steps[0].ActionId).To(Equal(int64(908)))
+
+			Expect(k8sClient.Delete(ctx, updatedResource)).To(Succeed())
+		})
+
+		It("should fail the operation when the action of a step fails", func() {
+			const resourceName = "test-reset-action-failed"
+			typeNamespacedName := types.NamespacedName{Name: resourceName, Namespace: namespace}
+
+			resource := newOperation(resourceName, hcloudv1alpha1.ServerOperationReset)
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			MockServerActionClient, _ := runningServer()
+			MockServerActionClient.ResetServerFunc = func(ctx context.Context, s *hcloudgo.Server) (*hcloudgo.Action, *hcloudgo.Response, error) {
+				return &hcloudgo.Action{ID: 906, Command: "reset_server", Status: hcloudgo.ActionStatusRunning}, nil, nil
+			}
+			MockServerActionClient.GetActionFunc = func(ctx context.Context, id int64) (*hcloudgo.Action, *hcloudgo.Response, error) {
+				return &hcloudgo.Action{ID: id, Command: "reset_server", Status: hcloudgo.ActionStatusError, ErrorCode: "action_failed", ErrorMessage: "host unreachable"}, nil, nil
+			}
+
+			reconciler := &HcloudServerOperationReconciler{
+				Client:             k8sClient,
+				Scheme:             k8sClient.Scheme(),
+				ServerActionClient: MockServerActionClient,
+				Recorder:           recorder,
+			}
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			updatedResource := &hcloudv1alpha1.HcloudServerOperation{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			Expect(updatedResource.Status.Phase).To(Equal(hcloudv1alpha1.ServerOperationFailed))
+			Expect(updatedResource.Status.Steps[0].Message).To(ContainSubstring("host unreachable"))
+			condition := meta.FindStatusCondition(updatedResource.Status.Conditions, "Available")
+			Expect(condition.Reason).To(Equal(actionFailedReason))
+
+			Expect(k8sClient.Delete(ctx, updatedResource)).To(Succeed())
+		})
+	})
+})
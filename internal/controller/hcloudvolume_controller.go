@@ -0,0 +1,867 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hcloudv1alpha1 "bunskin.com/hcrm/api/v1alpha1"
+	"bunskin.com/hcrm/pkg/hcloud"
+	hcloudgo "github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+// HcloudVolumeReconciler reconciles a HcloudVolume object
+type HcloudVolumeReconciler struct {
+	client.Client
+	Scheme       *runtime.Scheme
+	VolumeClient hcloud.VolumeClient
+	ClientCache  *hcloud.ClientCache
+	Recorder     record.EventRecorder
+	// DefaultProviderConfig is the name of the ClusterHcloudProviderConfig used for resources without a providerConfigRef
+	DefaultProviderConfig string
+	// ResyncInterval is the interval after which reconciled resources are checked for drift, zero disables it
+	ResyncInterval time.Duration
+	// ClusterID identifies the cluster in the ownership labels of cloud resources
+	ClusterID string
+	// Pause pauses the reconciliation of whole kinds or namespaces, nil if it cannot be paused by the manager
+	Pause *PauseSwitch
+}
+
+// +kubebuilder:rbac:groups=hcloud.bunskin.com,resources=hcloudvolumes,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=hcloud.bunskin.com,resources=hcloudvolumes/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=hcloud.bunskin.com,resources=hcloudvolumes/finalizers,verbs=update
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=hcloud.bunskin.com,resources=hcloudproviderconfigs;clusterhcloudproviderconfigs,verbs=get;list;watch
+// +kubebuilder:rbac:groups=hcloud.bunskin.com,resources=hcloudservers,verbs=get;list;watch
+
+// Reconcile looks up the Hetzner Cloud volume by spec.name and adopts, updates or creates it
+// according to the sync policy of the HcloudVolume resource. Labels are changed in place, while
+// resizing, attaching and detaching start one Hetzner Cloud action at a time that is followed by
+// later reconciles, as the volume is locked while an action runs.
+func (r *HcloudVolumeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	result, err := r.reconcile(ctx, req)
+	if reset, ok := hcloud.RateLimitReset(err); ok {
+		var hcloudVolume hcloudv1alpha1.HcloudVolume
+		if err := r.Get(ctx, req.NamespacedName, &hcloudVolume); err != nil {
+			return ctrl.Result{}, client.IgnoreNotFound(err)
+		}
+		return requeueRateLimited(ctx, r.Client, r.Recorder, &hcloudVolume, &hcloudVolume.Status.Conditions, reset, err)
+	}
+	return result, err
+}
+
+// reconcile implements Reconcile, errors caused by the Hetzner Cloud rate limit are handled by the caller
+func (r *HcloudVolumeReconciler) reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := logf.Log.WithName("hcloudvolume-controller")
+
+	// Fetch the HcloudVolume resource
+	var hcloudVolume hcloudv1alpha1.HcloudVolume
+	if err := r.Get(ctx, req.NamespacedName, &hcloudVolume); err != nil {
+		// object does not exist, nothing to do
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	// A paused resource is left alone, including its deletion
+	if paused, result, err := reconcilePaused(ctx, r.Client, r.Recorder, r.Pause, "HcloudVolume", &hcloudVolume, &hcloudVolume.Status.Conditions); paused {
+		return result, err
+	}
+
+	log.Info("Reconciling HcloudVolume", "name", hcloudVolume.Name, "namespace", hcloudVolume.Namespace)
+	meta.SetStatusCondition(&hcloudVolume.Status.Conditions, metav1.Condition{
+		Type:               "Available",
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: hcloudVolume.Generation,
+		Reason:             "Progressing",
+		Message:            "HcloudVolume resource reconciliation in progress",
+	})
+	if err := r.Status().Update(ctx, &hcloudVolume); err != nil {
+		log.Error(err, "Failed to update HcloudVolume status", "name", hcloudVolume.Name)
+		return ctrl.Result{}, err
+	}
+
+	// Handle deletion with finalizer
+	if hcloudVolume.DeletionTimestamp != nil {
+		return r.reconcileDelete(ctx, &hcloudVolume)
+	}
+
+	// Resolve the Hetzner Cloud client for this resource
+	volumeClient, provider, err := r.volumeClientFor(ctx, &hcloudVolume)
+	if err != nil {
+		log.Error(err, "Failed to resolve Hetzner Cloud credentials", "name", hcloudVolume.Name)
+		meta.SetStatusCondition(&hcloudVolume.Status.Conditions, metav1.Condition{
+			Type:               "Available",
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: hcloudVolume.Generation,
+			Reason:             credentialsUnavailableReason,
+			Message:            fmt.Sprintf("Failed to resolve Hetzner Cloud credentials: %v", err),
+		})
+		if err := r.Status().Update(ctx, &hcloudVolume); err != nil {
+			log.Error(err, "Failed to update HcloudVolume status", "name", hcloudVolume.Name)
+		}
+		r.Recorder.Eventf(&hcloudVolume, "Warning", credentialsUnavailableReason, "Failed to resolve Hetzner Cloud credentials: %v", err)
+
+		return ctrl.Result{}, err
+	}
+
+	// Add sync policy annotation if neither it nor the sync policy mode is present
+	if hcloudVolume.Annotations[syncPolicy] == "" && (hcloudVolume.Spec.SyncPolicy == nil || hcloudVolume.Spec.SyncPolicy.Mode == "") {
+		log.Info("Adding sync policy annotation", "name", hcloudVolume.Name)
+		if hcloudVolume.Annotations == nil {
+			hcloudVolume.Annotations = make(map[string]string)
+		}
+		hcloudVolume.Annotations[syncPolicy] = string(provider.syncPolicy())
+		if err := r.Update(ctx, &hcloudVolume); err != nil {
+			log.Error(err, "Failed to add sync policy annotation", "name", hcloudVolume.Name)
+			return ctrl.Result{}, err
+		}
+	}
+
+	policy, err := resolveSyncPolicy(&hcloudVolume, hcloudVolume.Spec.SyncPolicy, provider.syncPolicy(), hcloudv1alpha1.HcloudVolumeIgnorableFields)
+	if err != nil {
+		log.Error(err, "Invalid sync policy", "name", hcloudVolume.Name)
+		meta.SetStatusCondition(&hcloudVolume.Status.Conditions, metav1.Condition{
+			Type:               "Available",
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: hcloudVolume.Generation,
+			Reason:             invalidSyncPolicyReason,
+			Message:            err.Error(),
+		})
+		if err := r.Status().Update(ctx, &hcloudVolume); err != nil {
+			log.Error(err, "Failed to update HcloudVolume status", "name", hcloudVolume.Name)
+			return ctrl.Result{}, err
+		}
+		r.Recorder.Eventf(&hcloudVolume, "Warning", invalidSyncPolicyReason, "Sync policy of volume %s is invalid: %v", hcloudVolume.Spec.Name, err)
+
+		// The sync policy has to change before the volume can be reconciled
+		return ctrl.Result{}, nil
+	}
+
+	// Add finalizer if not present and sync policy supports it
+	if !controllerutil.ContainsFinalizer(&hcloudVolume, finalizerName) && policy.claims() {
+		log.Info("Adding finalizer", "name", hcloudVolume.Name)
+		controllerutil.AddFinalizer(&hcloudVolume, finalizerName)
+		if err := r.Update(ctx, &hcloudVolume); err != nil {
+			log.Error(err, "Failed to add finalizer", "name", hcloudVolume.Name)
+			return ctrl.Result{}, err
+		}
+	}
+
+	// Labels of the provider config are applied below the labels of the resource
+	desiredLabels := provider.labels(hcloudVolume.Spec.Labels)
+
+	// Follow the create, resize, attach and detach actions before comparing the volume with the spec
+	if len(hcloudVolume.Status.PendingActions) > 0 {
+		done, result, err := awaitPendingActions(ctx, r.Client, r.Recorder, &hcloudVolume, &hcloudVolume.Status.PendingActions, &hcloudVolume.Status.Conditions, volumeClient.GetAction, provider.actionPollInterval())
+		if !done {
+			return result, err
+		}
+	}
+
+	// A volume pinned by the external-id annotation is never looked up by name or created
+	pinnedID, err := externalID(&hcloudVolume)
+	if err != nil {
+		log.Error(err, "Invalid external ID annotation", "name", hcloudVolume.Name)
+		meta.SetStatusCondition(&hcloudVolume.Status.Conditions, metav1.Condition{
+			Type:               "Available",
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: hcloudVolume.Generation,
+			Reason:             invalidExternalIDReason,
+			Message:            err.Error(),
+		})
+		if err := r.Status().Update(ctx, &hcloudVolume); err != nil {
+			log.Error(err, "Failed to update HcloudVolume status", "name", hcloudVolume.Name)
+			return ctrl.Result{}, err
+		}
+		r.Recorder.Eventf(&hcloudVolume, "Warning", invalidExternalIDReason, "Cannot adopt volume for %s: %v", hcloudVolume.Name, err)
+
+		// The annotation has to change before the volume can be found
+		return ctrl.Result{}, nil
+	}
+
+	// Adopt existing volume if it exists
+	volume, response, err := findVolume(ctx, volumeClient, &hcloudVolume, pinnedID)
+	if err != nil {
+		log.Error(err, "Failed to get volume from Hetzner Cloud", "name", hcloudVolume.Spec.Name)
+		meta.SetStatusCondition(&hcloudVolume.Status.Conditions, metav1.Condition{
+			Type:               "Available",
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: hcloudVolume.Generation,
+			Reason:             "Failed",
+			Message:            fmt.Sprintf("Failed to get volume from Hetzner Cloud: %v. %v", err, response),
+		})
+		if err := r.Status().Update(ctx, &hcloudVolume); err != nil {
+			log.Error(err, "Failed to update HcloudVolume status", "name", hcloudVolume.Name)
+		}
+		r.Recorder.Eventf(&hcloudVolume, "Warning", "UpdateFailed", "Failed to get volume %s from Hetzner cloud", hcloudVolume.Spec.Name)
+
+		return ctrl.Result{}, err
+	}
+
+	if volume == nil && pinnedID != 0 {
+		log.Info("Volume pinned by the external ID not found in Hetzner Cloud", "volumeId", pinnedID)
+		meta.SetStatusCondition(&hcloudVolume.Status.Conditions, metav1.Condition{
+			Type:               "Available",
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: hcloudVolume.Generation,
+			Reason:             externalResourceNotFoundReason,
+			Message:            fmt.Sprintf("Volume %d set by the %s annotation not found in Hetzner Cloud", pinnedID, hcloudv1alpha1.ExternalIDAnnotation),
+		})
+		if err := r.Status().Update(ctx, &hcloudVolume); err != nil {
+			log.Error(err, "Failed to update HcloudVolume status", "name", hcloudVolume.Name)
+			return ctrl.Result{}, err
+		}
+		r.Recorder.Eventf(&hcloudVolume, "Warning", externalResourceNotFoundReason, "Volume %d not found in Hetzner cloud", pinnedID)
+
+		return ctrl.Result{RequeueAfter: resyncAfter(&hcloudVolume, r.ResyncInterval)}, nil
+	}
+
+	if volume != nil && policy.claims() {
+		// A volume managed by another resource or cluster is left alone unless it is taken over,
+		// resources whose sync policy does not claim it may still observe it
+		if owner := claimant(volume.Labels, &hcloudVolume, r.ClusterID); owner != "" && !takeover(&hcloudVolume) {
+			log.Info("Volume is already claimed by another resource", "volumeId", volume.ID, "owner", owner)
+			meta.SetStatusCondition(&hcloudVolume.Status.Conditions, metav1.Condition{
+				Type:               "Available",
+				Status:             metav1.ConditionFalse,
+				ObservedGeneration: hcloudVolume.Generation,
+				Reason:             alreadyClaimedReason,
+				Message:            fmt.Sprintf("Volume %d is already managed by %s", volume.ID, owner),
+			})
+			if err := r.Status().Update(ctx, &hcloudVolume); err != nil {
+				log.Error(err, "Failed to update HcloudVolume status", "name", hcloudVolume.Name)
+				return ctrl.Result{}, err
+			}
+			r.Recorder.Eventf(&hcloudVolume, "Warning", alreadyClaimedReason, "Volume %s (%d) is already managed by %s, set the %s annotation to take it over", volume.Name, volume.ID, owner, hcloudv1alpha1.TakeoverAnnotation)
+
+			return ctrl.Result{RequeueAfter: resyncAfter(&hcloudVolume, r.ResyncInterval)}, nil
+		} else if owner != "" {
+			log.Info("Taking over volume claimed by another resource", "volumeId", volume.ID, "owner", owner)
+			r.Recorder.Eventf(&hcloudVolume, "Warning", takenOverReason, "Volume %s (%d) was taken over from %s", volume.Name, volume.ID, owner)
+		}
+	}
+
+	if volume == nil && !policy.creates() {
+		log.Info("Volume not found in Hetzner Cloud and sync policy does not create it; skipping creation", "name", hcloudVolume.Spec.Name, "syncPolicy", policy.mode)
+		meta.SetStatusCondition(&hcloudVolume.Status.Conditions, metav1.Condition{
+			Type:               "Available",
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: hcloudVolume.Generation,
+			Reason:             "Failed",
+			Message:            fmt.Sprintf("Volume not found in Hetzner Cloud and sync policy is %s", policy.mode),
+		})
+		if err := r.Status().Update(ctx, &hcloudVolume); err != nil {
+			log.Error(err, "Failed to update HcloudVolume status", "name", hcloudVolume.Name)
+			return ctrl.Result{}, err
+		}
+		r.Recorder.Eventf(&hcloudVolume, "Warning", "Failed", "Volume %s not found in Hetzner cloud", hcloudVolume.Spec.Name)
+
+		return ctrl.Result{RequeueAfter: resyncAfter(&hcloudVolume, r.ResyncInterval)}, nil
+	}
+
+	// Resolve the server of the spec, a server left to other tools is only needed to create the volume
+	var serverID int64
+	if volume == nil || !policy.ignores("serverRef") {
+		serverID, err = resolveVolumeServer(ctx, r.Client, hcloudVolume.Namespace, hcloudVolume.Spec.ServerRef)
+		if err != nil {
+			return r.referencesUnresolved(ctx, &hcloudVolume, err)
+		}
+	}
+
+	if volume == nil {
+		return r.createVolume(ctx, volumeClient, provider, &hcloudVolume, desiredLabels, serverID)
+	}
+
+	log.Info("Found existing volume in Hetzner Cloud", "volumeId", volume.ID)
+
+	if volume.Name != hcloudVolume.Spec.Name {
+		log.Info("Volume was renamed outside of the operator", "volumeId", volume.ID, "name", volume.Name, "specName", hcloudVolume.Spec.Name)
+		r.Recorder.Eventf(&hcloudVolume, "Warning", renamedReason, "Volume %d is named %s in Hetzner cloud instead of %s", volume.ID, volume.Name, hcloudVolume.Spec.Name)
+	}
+
+	// Fields left to other tools are neither updated nor reported as drift
+	if policy.ignores("labels") {
+		desiredLabels = nil
+	}
+
+	// Differences found while the spec is unchanged were made outside of the operator
+	var drift []string
+	if policy.reportsDrift() && (!policy.updates() || hcloudVolume.Status.ObservedGeneration == hcloudVolume.Generation) {
+		drift = volumeDrift(desiredLabels, serverID, hcloudVolume.Spec, volume, policy)
+	}
+	if len(drift) > 0 {
+		log.Info("Volume drifted from its spec", "volumeId", volume.ID, "drift", drift)
+		r.Recorder.Eventf(&hcloudVolume, "Warning", driftedCondition, "Volume %s drifted from its spec: %s", hcloudVolume.Spec.Name, strings.Join(drift, "; "))
+	}
+	if policy.reportsDrift() {
+		setDriftCondition(&hcloudVolume.Status.Conditions, hcloudVolume.Generation, drift, policy.updates())
+	} else {
+		meta.RemoveStatusCondition(&hcloudVolume.Status.Conditions, driftedCondition)
+	}
+
+	// Update the existing volume if sync policy allows it. A volume attached to another server is
+	// detached first and attached to the server of the spec by the next reconcile.
+	if policy.updates() {
+		ownedLabels := withOwnershipLabels(desiredLabels, volume.Labels, &hcloudVolume, r.ClusterID)
+		if !equality.Semantic.DeepEqual(ownedLabels, volume.Labels) {
+			log.Info("Volume labels differ, updating", "current", volume.Labels, "desired", ownedLabels)
+			updatedVolume, response, err := volumeClient.UpdateVolumeLabels(ctx, volume, ownedLabels)
+			if err != nil {
+				log.Error(err, "Failed to update volume labels in Hetzner Cloud", "volumeId", volume.ID)
+				return r.updateFailed(ctx, &hcloudVolume, fmt.Errorf("failed to update volume in Hetzner Cloud: %w. %v", err, response))
+			}
+			volume = updatedVolume
+		}
+
+		attachedID := volumeServerID(volume)
+		switch {
+		case !policy.ignores("serverRef") && attachedID != 0 && attachedID != serverID:
+			log.Info("Detaching volume from server", "volumeId", volume.ID, "serverId", attachedID)
+			action, response, err := volumeClient.DetachVolume(ctx, volume)
+			if err != nil {
+				log.Error(err, "Failed to detach volume in Hetzner Cloud", "volumeId", volume.ID)
+				return r.updateFailed(ctx, &hcloudVolume, fmt.Errorf("failed to detach volume from server %d: %w. %v", attachedID, err, response))
+			}
+			return r.volumeActionStarted(ctx, &hcloudVolume, volume, action, provider, "Detaching", fmt.Sprintf("Volume %d is being detached from server %d", volume.ID, attachedID))
+		case !policy.ignores("serverRef") && serverID != 0 && attachedID == 0:
+			log.Info("Attaching volume to server", "volumeId", volume.ID, "serverId", serverID)
+			action, response, err := volumeClient.AttachVolume(ctx, volume, &hcloudgo.Server{ID: serverID}, hcloudVolume.Spec.Automount)
+			if err != nil {
+				log.Error(err, "Failed to attach volume in Hetzner Cloud", "volumeId", volume.ID)
+				return r.updateFailed(ctx, &hcloudVolume, fmt.Errorf("failed to attach volume to server %d: %w. %v", serverID, err, response))
+			}
+			return r.volumeActionStarted(ctx, &hcloudVolume, volume, action, provider, "Attaching", fmt.Sprintf("Volume %d is being attached to server %d", volume.ID, serverID))
+		case !policy.ignores("size") && volume.Size < hcloudVolume.Spec.Size:
+			log.Info("Resizing volume", "volumeId", volume.ID, "size", volume.Size, "desiredSize", hcloudVolume.Spec.Size)
+			action, response, err := volumeClient.ResizeVolume(ctx, volume, hcloudVolume.Spec.Size)
+			if err != nil {
+				log.Error(err, "Failed to resize volume in Hetzner Cloud", "volumeId", volume.ID)
+				return r.updateFailed(ctx, &hcloudVolume, fmt.Errorf("failed to resize volume to %d GB: %w. %v", hcloudVolume.Spec.Size, err, response))
+			}
+			return r.volumeActionStarted(ctx, &hcloudVolume, volume, action, provider, "Resizing", fmt.Sprintf("Volume %d is being resized from %d GB to %d GB", volume.ID, volume.Size, hcloudVolume.Spec.Size))
+		}
+	} else {
+		log.Info("Sync policy does not update existing volumes; skipping updates", "volumeId", volume.ID, "syncPolicy", policy.mode)
+	}
+
+	// Update the resource status with the volume details and conditions
+	setVolumeStatus(&hcloudVolume.Status, volume)
+	replacements := volumeReplacementChanges(hcloudVolume.Spec, volume, policy)
+	replacementReported := setReplacementCondition(&hcloudVolume.Status.Conditions, hcloudVolume.Generation, replacements)
+	meta.SetStatusCondition(&hcloudVolume.Status.Conditions, metav1.Condition{
+		Type:               "Available",
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: hcloudVolume.Generation,
+		Reason:             "Ready",
+		Message:            fmt.Sprintf("Volume ID %d reconciled successfully", volume.ID),
+	})
+	hcloudVolume.Status.ObservedGeneration = hcloudVolume.Generation
+
+	if err := r.Status().Update(ctx, &hcloudVolume); err != nil {
+		log.Error(err, "Failed to update HcloudVolume status", "name", hcloudVolume.Name)
+		return ctrl.Result{}, err
+	}
+
+	if replacementReported && len(replacements) > 0 {
+		r.Recorder.Eventf(&hcloudVolume, "Warning", requiresReplacementCondition, "Volume %s cannot be changed in place: %s", hcloudVolume.Spec.Name, strings.Join(replacements, "; "))
+	}
+	r.Recorder.Eventf(&hcloudVolume, "Normal", "Ready", "HcloudVolume updated %d", hcloudVolume.Status.VolumeId)
+
+	log.Info("HcloudVolume resource reconciled successfully", "name", hcloudVolume.Name)
+	return ctrl.Result{RequeueAfter: resyncAfter(&hcloudVolume, r.ResyncInterval)}, nil
+}
+
+// createVolume creates the Hetzner Cloud volume of the HcloudVolume, attached to the server of the
+// spec when it has one. The create action and the actions started after it are followed by later
+// reconciles.
+func (r *HcloudVolumeReconciler) createVolume(ctx context.Context, volumeClient hcloud.VolumeClient, provider *hcloudProvider, hcloudVolume *hcloudv1alpha1.HcloudVolume, desiredLabels map[string]string, serverID int64) (ctrl.Result, error) {
+	log := logf.Log.WithName("hcloudvolume-controller")
+
+	log.Info("Volume not found in Hetzner Cloud, creating new volume", "name", hcloudVolume.Spec.Name)
+	opts := volumeCreateOpts(hcloudVolume.Spec, withOwnershipLabels(desiredLabels, nil, hcloudVolume, r.ClusterID), serverID)
+	result, response, err := volumeClient.CreateVolume(ctx, opts)
+	if err != nil {
+		log.Error(err, "Failed to create volume in Hetzner Cloud", "name", hcloudVolume.Spec.Name)
+		meta.SetStatusCondition(&hcloudVolume.Status.Conditions, metav1.Condition{
+			Type:               "Available",
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: hcloudVolume.Generation,
+			Reason:             "Failed",
+			Message:            fmt.Sprintf("Failed to create volume in Hetzner Cloud: %v. %v", err, response),
+		})
+		if err := r.Status().Update(ctx, hcloudVolume); err != nil {
+			log.Error(err, "Failed to update HcloudVolume status", "name", hcloudVolume.Name)
+		}
+		r.Recorder.Eventf(hcloudVolume, "Warning", "CreateFailed", "Failed to create volume %s in Hetzner cloud", hcloudVolume.Spec.Name)
+
+		return ctrl.Result{}, err
+	}
+
+	log.Info("Started creating volume in Hetzner Cloud", "volumeId", result.Volume.ID)
+	setVolumeStatus(&hcloudVolume.Status, result.Volume)
+	for _, action := range append([]*hcloudgo.Action{result.Action}, result.NextActions...) {
+		if actionPending(action) {
+			recordPendingAction(&hcloudVolume.Status.PendingActions, &hcloudVolume.Status.Conditions, hcloudVolume.Generation, action)
+		}
+	}
+	meta.SetStatusCondition(&hcloudVolume.Status.Conditions, metav1.Condition{
+		Type:               "Available",
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: hcloudVolume.Generation,
+		Reason:             creatingReason,
+		Message:            fmt.Sprintf("Volume %d is being created in Hetzner Cloud", result.Volume.ID),
+	})
+	if err := r.Status().Update(ctx, hcloudVolume); err != nil {
+		log.Error(err, "Failed to update HcloudVolume status", "name", hcloudVolume.Name)
+		return ctrl.Result{}, err
+	}
+	r.Recorder.Eventf(hcloudVolume, "Normal", creatingReason, "Creating volume %s with ID %d", hcloudVolume.Spec.Name, result.Volume.ID)
+
+	return ctrl.Result{RequeueAfter: provider.actionPollInterval()}, nil
+}
+
+// volumeActionStarted records a resize, attach or detach action started on the volume and reports
+// it on the Available condition. The volume is compared with the spec again once the action completed.
+func (r *HcloudVolumeReconciler) volumeActionStarted(ctx context.Context, hcloudVolume *hcloudv1alpha1.HcloudVolume, volume *hcloudgo.Volume, action *hcloudgo.Action, provider *hcloudProvider, reason, message string) (ctrl.Result, error) {
+	log := logf.Log.WithName("hcloudvolume-controller")
+
+	setVolumeStatus(&hcloudVolume.Status, volume)
+	if actionPending(action) {
+		recordPendingAction(&hcloudVolume.Status.PendingActions, &hcloudVolume.Status.Conditions, hcloudVolume.Generation, action)
+	}
+	meta.SetStatusCondition(&hcloudVolume.Status.Conditions, metav1.Condition{
+		Type:               "Available",
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: hcloudVolume.Generation,
+		Reason:             reason,
+		Message:            message,
+	})
+	if err := r.Status().Update(ctx, hcloudVolume); err != nil {
+		log.Error(err, "Failed to update HcloudVolume status", "name", hcloudVolume.Name)
+		return ctrl.Result{}, err
+	}
+	r.Recorder.Event(hcloudVolume, "Normal", reason, message)
+
+	return ctrl.Result{RequeueAfter: provider.actionPollInterval()}, nil
+}
+
+// reconcileDelete deletes or releases the volume of a deleted HcloudVolume according to its sync
+// policy and removes the finalizer. An attached volume is detached first, the detach action is
+// followed by later reconciles as a volume cannot be deleted while it is attached.
+func (r *HcloudVolumeReconciler) reconcileDelete(ctx context.Context, hcloudVolume *hcloudv1alpha1.HcloudVolume) (ctrl.Result, error) {
+	log := logf.Log.WithName("hcloudvolume-controller")
+
+	log.Info("HcloudVolume resource is being deleted", "name", hcloudVolume.Name)
+	meta.SetStatusCondition(&hcloudVolume.Status.Conditions, metav1.Condition{
+		Type:               "Available",
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: hcloudVolume.Generation,
+		Reason:             "Deleting",
+		Message:            "HcloudVolume resource is being deleted",
+	})
+	if err := r.Status().Update(ctx, hcloudVolume); err != nil {
+		log.Error(err, "Failed to update HcloudVolume status", "name", hcloudVolume.Name)
+		return ctrl.Result{}, err
+	}
+
+	if !controllerutil.ContainsFinalizer(hcloudVolume, finalizerName) {
+		return ctrl.Result{}, nil
+	}
+
+	policy, policyErr := resolveSyncPolicy(hcloudVolume, hcloudVolume.Spec.SyncPolicy, hcloudv1alpha1.SyncPolicyManage, hcloudv1alpha1.HcloudVolumeIgnorableFields)
+	if policyErr != nil {
+		// A sync policy that cannot be understood never deletes the volume
+		log.Error(policyErr, "Invalid sync policy, will not remove cloud resource", "name", hcloudVolume.Name)
+		r.Recorder.Eventf(hcloudVolume, "Warning", invalidSyncPolicyReason, "Volume %d is left in Hetzner cloud: %v", hcloudVolume.Status.VolumeId, policyErr)
+	} else if hcloudVolume.Status.VolumeId != 0 && policy.deletes() {
+		// Delete the volume from Hetzner Cloud if it exists
+		volumeClient, provider, err := r.volumeClientFor(ctx, hcloudVolume)
+		if err != nil {
+			log.Error(err, "Failed to resolve Hetzner Cloud credentials", "name", hcloudVolume.Name)
+			meta.SetStatusCondition(&hcloudVolume.Status.Conditions, metav1.Condition{
+				Type:               "Available",
+				Status:             metav1.ConditionFalse,
+				ObservedGeneration: hcloudVolume.Generation,
+				Reason:             credentialsUnavailableReason,
+				Message:            fmt.Sprintf("Failed to resolve Hetzner Cloud credentials: %v", err),
+			})
+			if err := r.Status().Update(ctx, hcloudVolume); err != nil {
+				log.Error(err, "Failed to update HcloudVolume status", "name", hcloudVolume.Name)
+			}
+			r.Recorder.Eventf(hcloudVolume, "Warning", credentialsUnavailableReason, "Failed to resolve Hetzner Cloud credentials: %v", err)
+
+			return ctrl.Result{}, err
+		}
+
+		// Follow the detach action started by an earlier reconcile
+		if len(hcloudVolume.Status.PendingActions) > 0 {
+			done, result, err := awaitPendingActions(ctx, r.Client, r.Recorder, hcloudVolume, &hcloudVolume.Status.PendingActions, &hcloudVolume.Status.Conditions, volumeClient.GetAction, provider.actionPollInterval())
+			if !done {
+				return result, err
+			}
+		}
+
+		log.Info("Fetching Hetzner Cloud volume for deletion", "volumeId", hcloudVolume.Status.VolumeId)
+		volume, response, err := volumeClient.GetVolumeById(ctx, int64(hcloudVolume.Status.VolumeId))
+		if err != nil {
+			log.Error(err, "Failed to get volume from Hetzner Cloud", "volumeId", hcloudVolume.Status.VolumeId)
+			return r.deletionFailed(ctx, hcloudVolume, fmt.Errorf("failed to get volume for deletion: %w. %v", err, response))
+		}
+
+		owner := ""
+		if volume != nil {
+			owner = claimant(volume.Labels, hcloudVolume, r.ClusterID)
+		}
+
+		if owner != "" {
+			// A volume taken over by another resource is left to it and stays attached, only its ID is dropped
+			log.Info("Volume is managed by another resource, will not remove it", "volumeId", hcloudVolume.Status.VolumeId, "owner", owner)
+			r.Recorder.Eventf(hcloudVolume, "Warning", alreadyClaimedReason, "Volume %d is left in Hetzner cloud as it is managed by %s", hcloudVolume.Status.VolumeId, owner)
+			hcloudVolume.Status.VolumeId = 0
+			if err := r.Status().Update(ctx, hcloudVolume); err != nil {
+				log.Error(err, "Failed to update HcloudVolume status", "name", hcloudVolume.Name)
+				return ctrl.Result{}, err
+			}
+		} else if volume != nil {
+			if volume.Server != nil {
+				attachedID := volume.Server.ID
+				log.Info("Detaching Hetzner Cloud volume before deletion", "volumeId", volume.ID, "serverId", attachedID)
+				action, response, err := volumeClient.DetachVolume(ctx, volume)
+				if err != nil {
+					log.Error(err, "Failed to detach volume in Hetzner Cloud", "volumeId", volume.ID)
+					return r.deletionFailed(ctx, hcloudVolume, fmt.Errorf("failed to detach volume from server %d: %w. %v", attachedID, err, response))
+				}
+				if actionPending(action) {
+					return r.volumeActionStarted(ctx, hcloudVolume, volume, action, provider, "Detaching", fmt.Sprintf("Volume %d is being detached from server %d before its deletion", volume.ID, attachedID))
+				}
+			}
+
+			log.Info("Deleting Hetzner Cloud volume", "volumeId", volume.ID)
+			response, err := volumeClient.DeleteVolume(ctx, volume)
+			// A volume deleted by someone else since it was fetched is gone all the same
+			if err != nil && !hcloudgo.IsError(err, hcloudgo.ErrorCodeNotFound) {
+				log.Error(err, "Failed to delete volume from Hetzner Cloud", "volumeId", volume.ID)
+				return r.deletionFailed(ctx, hcloudVolume, fmt.Errorf("failed to delete volume from Hetzner Cloud: %w. %v", err, response))
+			}
+
+			log.Info("Successfully deleted Hetzner Cloud volume", "volumeId", volume.ID)
+			r.Recorder.Eventf(hcloudVolume, "Normal", "Deleted", "HcloudVolume %s deleted successfully", hcloudVolume.Spec.Name)
+		} else {
+			log.Info("Volume not found in Hetzner Cloud, nothing to delete", "volumeId", hcloudVolume.Status.VolumeId)
+		}
+	} else if hcloudVolume.Status.VolumeId != 0 {
+		// Orphaned volumes stay attached, so that the data remains available to the server
+		log.Info("Sync policy does not delete the cloud resource, will not remove it", "syncPolicy", policy.mode)
+		r.releaseVolume(ctx, hcloudVolume)
+	}
+
+	// Remove finalizer
+	controllerutil.RemoveFinalizer(hcloudVolume, finalizerName)
+	if err := r.Update(ctx, hcloudVolume); err != nil {
+		log.Error(err, "Failed to remove finalizer", "name", hcloudVolume.Name)
+		return ctrl.Result{}, err
+	}
+	log.Info("Finalizer removed, resource deletion complete", "name", hcloudVolume.Name)
+	return ctrl.Result{}, nil
+}
+
+// referencesUnresolved reports a server reference that cannot be resolved. The referenced
+// HcloudServer is watched, the resource is also retried after the resync interval.
+func (r *HcloudVolumeReconciler) referencesUnresolved(ctx context.Context, hcloudVolume *hcloudv1alpha1.HcloudVolume, err error) (ctrl.Result, error) {
+	log := logf.Log.WithName("hcloudvolume-controller")
+
+	log.Error(err, "Failed to resolve the references of the HcloudVolume", "name", hcloudVolume.Name)
+	meta.SetStatusCondition(&hcloudVolume.Status.Conditions, metav1.Condition{
+		Type:               "Available",
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: hcloudVolume.Generation,
+		Reason:             unresolvedReferencesReason,
+		Message:            err.Error(),
+	})
+	if err := r.Status().Update(ctx, hcloudVolume); err != nil {
+		log.Error(err, "Failed to update HcloudVolume status", "name", hcloudVolume.Name)
+		return ctrl.Result{}, err
+	}
+	r.Recorder.Eventf(hcloudVolume, "Warning", unresolvedReferencesReason, "References of volume %s cannot be resolved: %v", hcloudVolume.Spec.Name, err)
+
+	return ctrl.Result{RequeueAfter: resyncAfter(hcloudVolume, r.ResyncInterval)}, nil
+}
+
+// updateFailed reports a failed change of the Hetzner Cloud volume and returns the error for a retry
+func (r *HcloudVolumeReconciler) updateFailed(ctx context.Context, hcloudVolume *hcloudv1alpha1.HcloudVolume, err error) (ctrl.Result, error) {
+	log := logf.Log.WithName("hcloudvolume-controller")
+
+	meta.SetStatusCondition(&hcloudVolume.Status.Conditions, metav1.Condition{
+		Type:               "Available",
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: hcloudVolume.Generation,
+		Reason:             "Failed",
+		Message:            err.Error(),
+	})
+	if err := r.Status().Update(ctx, hcloudVolume); err != nil {
+		log.Error(err, "Failed to update HcloudVolume status", "name", hcloudVolume.Name)
+	}
+	r.Recorder.Eventf(hcloudVolume, "Warning", "UpdateFailed", "Failed to update volume %s in Hetzner cloud: %v", hcloudVolume.Spec.Name, err)
+
+	return ctrl.Result{}, err
+}
+
+// deletionFailed reports a failed deletion of the Hetzner Cloud volume and returns the error for a retry
+func (r *HcloudVolumeReconciler) deletionFailed(ctx context.Context, hcloudVolume *hcloudv1alpha1.HcloudVolume, err error) (ctrl.Result, error) {
+	log := logf.Log.WithName("hcloudvolume-controller")
+
+	meta.SetStatusCondition(&hcloudVolume.Status.Conditions, metav1.Condition{
+		Type:               "Available",
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: hcloudVolume.Generation,
+		Reason:             "DeletionFailed",
+		Message:            err.Error(),
+	})
+	if err := r.Status().Update(ctx, hcloudVolume); err != nil {
+		log.Error(err, "Failed to update HcloudVolume status", "name", hcloudVolume.Name)
+	}
+	r.Recorder.Eventf(hcloudVolume, "Warning", "DeletionFailed", "Failed to delete volume %d from Hetzner cloud: %v", hcloudVolume.Status.VolumeId, err)
+
+	return ctrl.Result{}, err
+}
+
+// releaseVolume removes the ownership label from an orphaned volume, so that it can be adopted
+// by another resource. Failures are only reported, the volume then stays claimed.
+func (r *HcloudVolumeReconciler) releaseVolume(ctx context.Context, hcloudVolume *hcloudv1alpha1.HcloudVolume) {
+	log := logf.Log.WithName("hcloudvolume-controller")
+
+	volumeClient, _, err := r.volumeClientFor(ctx, hcloudVolume)
+	if err == nil {
+		var volume *hcloudgo.Volume
+		volume, _, err = volumeClient.GetVolumeById(ctx, int64(hcloudVolume.Status.VolumeId))
+		if err == nil && volume != nil && volume.Labels[hcloudv1alpha1.OwnerUIDLabel] == string(hcloudVolume.UID) {
+			labels := withoutOwnershipLabels(volume.Labels)
+			if labels == nil {
+				labels = map[string]string{}
+			}
+			_, _, err = volumeClient.UpdateVolumeLabels(ctx, volume, labels)
+		}
+	}
+	if err != nil {
+		log.Error(err, "Failed to release orphaned volume", "volumeId", hcloudVolume.Status.VolumeId)
+		r.Recorder.Eventf(hcloudVolume, "Warning", "ReleaseFailed", "Failed to remove the ownership label from volume %d: %v", hcloudVolume.Status.VolumeId, err)
+	}
+}
+
+// findVolume looks up the Hetzner Cloud volume of the HcloudVolume the same way findServer looks
+// up servers: by the external ID when pinned, otherwise by the ID in the status and by name when
+// the volume is not known yet or no longer exists.
+func findVolume(ctx context.Context, volumeClient hcloud.VolumeClient, hcloudVolume *hcloudv1alpha1.HcloudVolume, pinnedID int64) (*hcloudgo.Volume, *hcloudgo.Response, error) {
+	log := logf.Log.WithName("hcloudvolume-controller")
+
+	if pinnedID != 0 {
+		log.Info("Checking for existing volume in Hetzner Cloud by external ID", "volumeId", pinnedID)
+		return volumeClient.GetVolumeById(ctx, pinnedID)
+	}
+	if hcloudVolume.Status.VolumeId != 0 {
+		log.Info("Checking for existing volume in Hetzner Cloud by ID", "volumeId", hcloudVolume.Status.VolumeId)
+		volume, response, err := volumeClient.GetVolumeById(ctx, int64(hcloudVolume.Status.VolumeId))
+		if err != nil || volume != nil {
+			return volume, response, err
+		}
+		log.Info("Volume no longer exists in Hetzner Cloud", "volumeId", hcloudVolume.Status.VolumeId)
+	}
+	log.Info("Checking for existing volume in Hetzner Cloud by name", "name", hcloudVolume.Spec.Name)
+	return volumeClient.GetVolumeByName(ctx, hcloudVolume.Spec.Name)
+}
+
+// resolveVolumeServer resolves spec.serverRef to a Hetzner Cloud server ID, zero when the volume is
+// not attached. An HcloudServer is resolved through the ID in its status.
+func resolveVolumeServer(ctx context.Context, c client.Reader, namespace string, ref *hcloudv1alpha1.HcloudVolumeServerReference) (int64, error) {
+	switch {
+	case ref == nil:
+		return 0, nil
+	case ref.ServerId != 0:
+		return int64(ref.ServerId), nil
+	}
+	var hcloudServer hcloudv1alpha1.HcloudServer
+	if err := c.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: namespace}, &hcloudServer); err != nil {
+		return 0, fmt.Errorf("serverRef: failed to get HcloudServer %s: %w", ref.Name, err)
+	}
+	if hcloudServer.Status.ServerId == 0 {
+		return 0, fmt.Errorf("serverRef: HcloudServer %s has no server ID yet", ref.Name)
+	}
+	return int64(hcloudServer.Status.ServerId), nil
+}
+
+// volumeCreateOpts builds the request creating the volume of the spec. A volume created for a
+// server is created in the location of the server, Hetzner Cloud does not accept both.
+func volumeCreateOpts(spec hcloudv1alpha1.HcloudVolumeSpec, labels map[string]string, serverID int64) hcloudgo.VolumeCreateOpts {
+	opts := hcloudgo.VolumeCreateOpts{
+		Name:   spec.Name,
+		Size:   spec.Size,
+		Labels: labels,
+	}
+	if serverID != 0 {
+		opts.Server = &hcloudgo.Server{ID: serverID}
+		opts.Automount = hcloudgo.Ptr(spec.Automount)
+	} else {
+		opts.Location = &hcloudgo.Location{Name: spec.Location}
+	}
+	if spec.Format != "" {
+		opts.Format = hcloudgo.Ptr(spec.Format)
+	}
+	return opts
+}
+
+// volumeServerID returns the ID of the server the volume is attached to, zero when it is detached
+func volumeServerID(volume *hcloudgo.Volume) int64 {
+	if volume.Server == nil {
+		return 0
+	}
+	return volume.Server.ID
+}
+
+// volumeLocation returns the name of the location of the volume
+func volumeLocation(volume *hcloudgo.Volume) string {
+	if volume.Location == nil {
+		return ""
+	}
+	return volume.Location.Name
+}
+
+// volumeDrift returns the fields in which the Hetzner Cloud volume differs from the spec. Fields the
+// sync policy ignores are not compared, a volume larger than the spec is reported by
+// volumeReplacementChanges instead.
+func volumeDrift(desiredLabels map[string]string, serverID int64, spec hcloudv1alpha1.HcloudVolumeSpec, volume *hcloudgo.Volume, policy effectiveSyncPolicy) []string {
+	var drift []string
+	if liveLabels := withoutOwnershipLabels(volume.Labels); desiredLabels != nil && !equality.Semantic.DeepEqual(desiredLabels, liveLabels) {
+		drift = append(drift, labelsDrift(liveLabels, desiredLabels)...)
+	}
+	if !policy.ignores("size") && volume.Size < spec.Size {
+		drift = append(drift, driftEntry("size", strconv.Itoa(volume.Size), strconv.Itoa(spec.Size)))
+	}
+	if attachedID := volumeServerID(volume); !policy.ignores("serverRef") && attachedID != serverID {
+		drift = append(drift, driftEntry("serverRef", formatID(int(attachedID)), formatID(int(serverID))))
+	}
+	return drift
+}
+
+// volumeReplacementChanges returns the fields in which the volume differs from the spec that
+// cannot be changed in place: volumes cannot move to another location and cannot shrink.
+func volumeReplacementChanges(spec hcloudv1alpha1.HcloudVolumeSpec, volume *hcloudgo.Volume, policy effectiveSyncPolicy) []string {
+	var changes []string
+	if location := volumeLocation(volume); spec.Location != "" && location != spec.Location {
+		changes = append(changes, driftEntry("location", location, spec.Location))
+	}
+	if !policy.ignores("size") && volume.Size > spec.Size {
+		changes = append(changes, driftEntry("size", strconv.Itoa(volume.Size), strconv.Itoa(spec.Size)))
+	}
+	return changes
+}
+
+// setVolumeStatus records the details of the Hetzner Cloud volume in the status
+func setVolumeStatus(status *hcloudv1alpha1.HcloudVolumeStatus, volume *hcloudgo.Volume) {
+	status.VolumeId = int(volume.ID)
+	status.Status = string(volume.Status)
+	status.Size = volume.Size
+	status.Location = volumeLocation(volume)
+	status.Format = ""
+	if volume.Format != nil {
+		status.Format = *volume.Format
+	}
+	status.ServerId = int(volumeServerID(volume))
+	status.LinuxDevice = volume.LinuxDevice
+	status.Labels = withoutOwnershipLabels(volume.Labels)
+}
+
+// volumeClientFor returns the Hetzner Cloud client for the HcloudVolume together with the resolved provider.
+// The client is built from the credentials of the resource or its provider config, or is the client
+// configured for the manager when neither is set.
+func (r *HcloudVolumeReconciler) volumeClientFor(ctx context.Context, hcloudVolume *hcloudv1alpha1.HcloudVolume) (hcloud.VolumeClient, *hcloudProvider, error) {
+	provider, err := resolveProvider(ctx, r.Client, r.DefaultProviderConfig, hcloudVolume.Namespace, hcloudVolume.Spec.CredentialsRef, hcloudVolume.Spec.ProviderConfigRef)
+	if err != nil {
+		return nil, nil, err
+	}
+	if provider.token == "" {
+		if r.VolumeClient == nil {
+			return nil, nil, fmt.Errorf("no credentials configured for the resource and no Hetzner Cloud token configured for the manager")
+		}
+		return r.VolumeClient, provider, nil
+	}
+	if r.ClientCache == nil {
+		return nil, nil, fmt.Errorf("no client cache configured to resolve credentials")
+	}
+	return r.ClientCache.VolumeClient(provider.cacheKey, provider.cacheVersion, provider.token, provider.options...), provider, nil
+}
+
+// volumesForServer maps an HcloudServer to the HcloudVolumes of its namespace referencing it
+func (r *HcloudVolumeReconciler) volumesForServer(ctx context.Context, obj client.Object) []reconcile.Request {
+	log := logf.Log.WithName("hcloudvolume-controller")
+
+	var list hcloudv1alpha1.HcloudVolumeList
+	if err := r.List(ctx, &list, client.InNamespace(obj.GetNamespace())); err != nil {
+		log.Error(err, "Failed to list HcloudVolumes referencing a changed HcloudServer")
+		return nil
+	}
+	var requests []reconcile.Request
+	for i := range list.Items {
+		if ref := list.Items[i].Spec.ServerRef; ref != nil && ref.Name == obj.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&list.Items[i])})
+		}
+	}
+	return requests
+}
+
+// serverIdChanged lets through updates of HcloudServers that change their server ID, such as the
+// first reconcile creating the server a volume waits for
+var serverIdChanged = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldServer, okOld := e.ObjectOld.(*hcloudv1alpha1.HcloudServer)
+		newServer, okNew := e.ObjectNew.(*hcloudv1alpha1.HcloudServer)
+		if !okOld || !okNew {
+			return true
+		}
+		return oldServer.Status.ServerId != newServer.Status.ServerId
+	},
+}
+
+// SetupWithManager sets up the controller with the Manager. Like for HcloudServers the event filter
+// only applies to HcloudVolumes, the referenced HcloudServers become usable through their status
+// without changing their generation.
+func (r *HcloudVolumeReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&hcloudv1alpha1.HcloudVolume{}, builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, pausedAnnotationChanged))).
+		Named("hcloudvolume").
+		Watches(&hcloudv1alpha1.HcloudServer{}, handler.EnqueueRequestsFromMapFunc(r.volumesForServer), builder.WithPredicates(serverIdChanged)).
+		Complete(r)
+}
@@ -0,0 +1,505 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hcloudv1alpha1 "bunskin.com/hcrm/api/v1alpha1"
+	"bunskin.com/hcrm/pkg/hcloud"
+	hcloudgo "github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+var _ = Describe("HcloudVolume Controller", func() {
+	const namespace = "default"
+
+	ctx := context.Background()
+
+	// newVolume returns an HcloudVolume of 20 GB in fsn1
+	newVolume := func(name string, policy *hcloudv1alpha1.HcloudSyncPolicy) *hcloudv1alpha1.HcloudVolume {
+		return &hcloudv1alpha1.HcloudVolume{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+			Spec: hcloudv1alpha1.HcloudVolumeSpec{
+				Name:       name,
+				Size:       20,
+				Location:   "fsn1",
+				Labels:     map[string]string{"env": "test"},
+				SyncPolicy: policy,
+			},
+		}
+	}
+
+	// liveVolume returns the Hetzner Cloud volume matching the spec returned by newVolume
+	liveVolume := func(id int64, name string, labels map[string]string) *hcloudgo.Volume {
+		return &hcloudgo.Volume{
+			ID:          id,
+			Name:        name,
+			Status:      hcloudgo.VolumeStatusAvailable,
+			Size:        20,
+			Location:    &hcloudgo.Location{Name: "fsn1"},
+			LinuxDevice: "/dev/disk/by-id/scsi-0HC_Volume_" + name,
+			Labels:      labels,
+		}
+	}
+
+	// newServer creates an HcloudServer whose Hetzner Cloud server has the given ID
+	newServer := func(name string, id int) *hcloudv1alpha1.HcloudServer {
+		server := &hcloudv1alpha1.HcloudServer{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec:       hcloudv1alpha1.HcloudServerSpec{Name: name, ServerType: "cx22", Image: "ubuntu-24.04"},
+		}
+		Expect(k8sClient.Create(ctx, server)).To(Succeed())
+		server.Status.ServerId = id
+		Expect(k8sClient.Status().Update(ctx, server)).To(Succeed())
+		return server
+	}
+
+	Context("Create new HcloudVolume", func() {
+		It("should create the volume attached to the server of an HcloudServer", func() {
+			const resourceName = "test-create-volume"
+			typeNamespacedName := types.NamespacedName{Name: resourceName, Namespace: namespace}
+
+			server := newServer("test-create-volume-server", 9101)
+			resource := newVolume(resourceName, nil)
+			resource.Spec.Format = hcloudgo.VolumeFormatExt4
+			resource.Spec.ServerRef = &hcloudv1alpha1.HcloudVolumeServerReference{Name: "test-create-volume-server"}
+			resource.Spec.Automount = true
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			var live *hcloudgo.Volume
+			var createOpts hcloudgo.VolumeCreateOpts
+			actionStatus := hcloudgo.ActionStatusRunning
+			MockVolumeClient := &hcloud.MockVolumeClient{}
+			MockVolumeClient.CreateVolumeFunc = func(ctx context.Context, opts hcloudgo.VolumeCreateOpts) (*hcloudgo.VolumeCreateResult, *hcloudgo.Response, error) {
+				createOpts = opts
+				live = liveVolume(7001, opts.Name, opts.Labels)
+				live.Status = hcloudgo.VolumeStatusCreating
+				live.Format = opts.Format
+				live.Server = opts.Server
+				return &hcloudgo.VolumeCreateResult{
+					Volume:      live,
+					Action:      &hcloudgo.Action{ID: 901, Command: "create_volume", Status: hcloudgo.ActionStatusRunning},
+					NextActions: []*hcloudgo.Action{{ID: 902, Command: "attach_volume", Status: hcloudgo.ActionStatusRunning}},
+				}, nil, nil
+			}
+			MockVolumeClient.GetActionFunc = func(ctx context.Context, id int64) (*hcloudgo.Action, *hcloudgo.Response, error) {
+				return &hcloudgo.Action{ID: id, Status: actionStatus}, nil, nil
+			}
+			MockVolumeClient.GetVolumeByIdFunc = func(ctx context.Context, id int64) (*hcloudgo.Volume, *hcloudgo.Response, error) {
+				return live, nil, nil
+			}
+
+			reconciler := &HcloudVolumeReconciler{
+				Client:       k8sClient,
+				Scheme:       k8sClient.Scheme(),
+				VolumeClient: MockVolumeClient,
+				Recorder:     recorder,
+			}
+
+			By("creating the volume in the location of the server")
+			result, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(defaultActionPollInterval))
+			Expect(createOpts.Size).To(Equal(20))
+			Expect(createOpts.Server).To(Equal(&hcloudgo.Server{ID: 9101}))
+			Expect(createOpts.Location).To(BeNil())
+			Expect(createOpts.Automount).To(Equal(hcloudgo.Ptr(true)))
+			Expect(createOpts.Format).To(Equal(hcloudgo.Ptr("ext4")))
+			Expect(createOpts.Labels).To(HaveKeyWithValue("env", "test"))
+			Expect(createOpts.Labels).To(HaveKey(hcloudv1alpha1.OwnerUIDLabel))
+
+			updatedResource := &hcloudv1alpha1.HcloudVolume{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			Expect(updatedResource.Status.VolumeId).To(Equal(7001))
+			Expect(updatedResource.Status.Status).To(Equal("creating"))
+			Expect(updatedResource.Status.PendingActions).To(HaveLen(2))
+			Expect(updatedResource.Finalizers).To(ContainElement(finalizerName))
+			condition := meta.FindStatusCondition(updatedResource.Status.Conditions, "Available")
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Reason).To(Equal(creatingReason))
+
+			By("reporting the device path once the volume is attached")
+			actionStatus = hcloudgo.ActionStatusSuccess
+			live.Status = hcloudgo.VolumeStatusAvailable
+			_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			Expect(updatedResource.Status.PendingActions).To(BeEmpty())
+			Expect(updatedResource.Status.Status).To(Equal("available"))
+			Expect(updatedResource.Status.Size).To(Equal(20))
+			Expect(updatedResource.Status.Location).To(Equal("fsn1"))
+			Expect(updatedResource.Status.Format).To(Equal("ext4"))
+			Expect(updatedResource.Status.ServerId).To(Equal(9101))
+			Expect(updatedResource.Status.LinuxDevice).To(Equal("/dev/disk/by-id/scsi-0HC_Volume_" + resourceName))
+			Expect(updatedResource.Status.Labels).To(Equal(map[string]string{"env": "test"}))
+			condition = meta.FindStatusCondition(updatedResource.Status.Conditions, "Available")
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(condition.Reason).To(Equal("Ready"))
+
+			Expect(k8sClient.Delete(ctx, updatedResource)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, server)).To(Succeed())
+		})
+
+		It("should wait for a referenced HcloudServer without a server ID", func() {
+			const resourceName = "test-unresolved-volume"
+			typeNamespacedName := types.NamespacedName{Name: resourceName, Namespace: namespace}
+
+			server := newServer("test-unresolved-volume-server", 0)
+			resource := newVolume(resourceName, nil)
+			resource.Spec.ServerRef = &hcloudv1alpha1.HcloudVolumeServerReference{Name: "test-unresolved-volume-server"}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			MockVolumeClient := &hcloud.MockVolumeClient{}
+			MockVolumeClient.CreateVolumeFunc = func(ctx context.Context, opts hcloudgo.VolumeCreateOpts) (*hcloudgo.VolumeCreateResult, *hcloudgo.Response, error) {
+				Fail("the volume must not be created before its server is resolved")
+				return nil, nil, nil
+			}
+
+			reconciler := &HcloudVolumeReconciler{
+				Client:       k8sClient,
+				Scheme:       k8sClient.Scheme(),
+				VolumeClient: MockVolumeClient,
+				Recorder:     recorder,
+			}
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			updatedResource := &hcloudv1alpha1.HcloudVolume{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			condition := meta.FindStatusCondition(updatedResource.Status.Conditions, "Available")
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Reason).To(Equal(unresolvedReferencesReason))
+			Expect(condition.Message).To(ContainSubstring("test-unresolved-volume-server"))
+
+			By("mapping the server to the volume once it gets its ID")
+			Expect(reconciler.volumesForServer(ctx, server)).To(ContainElement(reconcile.Request{NamespacedName: typeNamespacedName}))
+
+			Expect(k8sClient.Delete(ctx, updatedResource)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, server)).To(Succeed())
+		})
+	})
+
+	Context("Update existing HcloudVolume", func() {
+		It("should move the volume to another server and grow it one action at a time", func() {
+			const resourceName = "test-update-volume"
+			typeNamespacedName := types.NamespacedName{Name: resourceName, Namespace: namespace}
+
+			resource := newVolume(resourceName, nil)
+			resource.Spec.Size = 30
+			resource.Spec.ServerRef = &hcloudv1alpha1.HcloudVolumeServerReference{ServerId: 9103}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			live := liveVolume(7002, resourceName, map[string]string{"env": "test"})
+			live.Server = &hcloudgo.Server{ID: 9102}
+
+			var calls []string
+			var automount bool
+			actionStatus := hcloudgo.ActionStatusSuccess
+			MockVolumeClient := &hcloud.MockVolumeClient{}
+			MockVolumeClient.GetVolumeByNameFunc = func(ctx context.Context, name string) (*hcloudgo.Volume, *hcloudgo.Response, error) {
+				return live, nil, nil
+			}
+			MockVolumeClient.GetVolumeByIdFunc = func(ctx context.Context, id int64) (*hcloudgo.Volume, *hcloudgo.Response, error) {
+				return live, nil, nil
+			}
+			MockVolumeClient.UpdateVolumeLabelsFunc = func(ctx context.Context, volume *hcloudgo.Volume, labels map[string]string) (*hcloudgo.Volume, *hcloudgo.Response, error) {
+				live.Labels = labels
+				return live, nil, nil
+			}
+			MockVolumeClient.DetachVolumeFunc = func(ctx context.Context, volume *hcloudgo.Volume) (*hcloudgo.Action, *hcloudgo.Response, error) {
+				calls = append(calls, "detach")
+				live.Server = nil
+				return &hcloudgo.Action{ID: 903, Command: "detach_volume", Status: hcloudgo.ActionStatusRunning}, nil, nil
+			}
+			MockVolumeClient.AttachVolumeFunc = func(ctx context.Context, volume *hcloudgo.Volume, server *hcloudgo.Server, mount bool) (*hcloudgo.Action, *hcloudgo.Response, error) {
+				calls = append(calls, "attach")
+				automount = mount
+				live.Server = server
+				return &hcloudgo.Action{ID: 904, Command: "attach_volume", Status: hcloudgo.ActionStatusRunning}, nil, nil
+			}
+			MockVolumeClient.ResizeVolumeFunc = func(ctx context.Context, volume *hcloudgo.Volume, size int) (*hcloudgo.Action, *hcloudgo.Response, error) {
+				calls = append(calls, "resize")
+				live.Size = size
+				return &hcloudgo.Action{ID: 905, Command: "resize_volume", Status: hcloudgo.ActionStatusRunning}, nil, nil
+			}
+			MockVolumeClient.GetActionFunc = func(ctx context.Context, id int64) (*hcloudgo.Action, *hcloudgo.Response, error) {
+				return &hcloudgo.Action{ID: id, Status: actionStatus}, nil, nil
+			}
+
+			reconciler := &HcloudVolumeReconciler{
+				Client:       k8sClient,
+				Scheme:       k8sClient.Scheme(),
+				VolumeClient: MockVolumeClient,
+				Recorder:     recorder,
+			}
+
+			updatedResource := &hcloudv1alpha1.HcloudVolume{}
+			for _, step := range []struct{ call, reason string }{{"detach", "Detaching"}, {"attach", "Attaching"}, {"resize", "Resizing"}} {
+				By("starting the " + step.call + " action once the previous one completed")
+				result, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.RequeueAfter).To(Equal(defaultActionPollInterval))
+				Expect(calls[len(calls)-1]).To(Equal(step.call))
+
+				Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+				Expect(updatedResource.Status.PendingActions).To(HaveLen(1))
+				condition := meta.FindStatusCondition(updatedResource.Status.Conditions, "Available")
+				Expect(condition.Reason).To(Equal(step.reason))
+
+				By("waiting while the " + step.call + " action is running")
+				actionStatus = hcloudgo.ActionStatusRunning
+				started := len(calls)
+				_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(calls).To(HaveLen(started))
+				actionStatus = hcloudgo.ActionStatusSuccess
+			}
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(calls).To(Equal([]string{"detach", "attach", "resize"}))
+			Expect(automount).To(BeFalse())
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			Expect(updatedResource.Status.PendingActions).To(BeEmpty())
+			Expect(updatedResource.Status.ServerId).To(Equal(9103))
+			Expect(updatedResource.Status.Size).To(Equal(30))
+			condition := meta.FindStatusCondition(updatedResource.Status.Conditions, "Available")
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(meta.IsStatusConditionTrue(updatedResource.Status.Conditions, requiresReplacementCondition)).To(BeFalse())
+
+			Expect(k8sClient.Delete(ctx, updatedResource)).To(Succeed())
+		})
+
+		It("should report a volume larger than the spec instead of shrinking it", func() {
+			const resourceName = "test-shrink-volume"
+			typeNamespacedName := types.NamespacedName{Name: resourceName, Namespace: namespace}
+
+			resource := newVolume(resourceName, nil)
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			live := liveVolume(7003, resourceName, nil)
+			live.Size = 50
+
+			MockVolumeClient := &hcloud.MockVolumeClient{}
+			MockVolumeClient.GetVolumeByNameFunc = func(ctx context.Context, name string) (*hcloudgo.Volume, *hcloudgo.Response, error) {
+				return live, nil, nil
+			}
+			MockVolumeClient.UpdateVolumeLabelsFunc = func(ctx context.Context, volume *hcloudgo.Volume, labels map[string]string) (*hcloudgo.Volume, *hcloudgo.Response, error) {
+				live.Labels = labels
+				return live, nil, nil
+			}
+			MockVolumeClient.ResizeVolumeFunc = func(ctx context.Context, volume *hcloudgo.Volume, size int) (*hcloudgo.Action, *hcloudgo.Response, error) {
+				Fail("volumes must not be shrunk")
+				return nil, nil, nil
+			}
+
+			reconciler := &HcloudVolumeReconciler{
+				Client:       k8sClient,
+				Scheme:       k8sClient.Scheme(),
+				VolumeClient: MockVolumeClient,
+				Recorder:     recorder,
+			}
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			updatedResource := &hcloudv1alpha1.HcloudVolume{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			Expect(updatedResource.Status.Size).To(Equal(50))
+			condition := meta.FindStatusCondition(updatedResource.Status.Conditions, requiresReplacementCondition)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(condition.Message).To(ContainSubstring("size: 50 -> 20"))
+			condition = meta.FindStatusCondition(updatedResource.Status.Conditions, "Available")
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+
+			Expect(k8sClient.Delete(ctx, updatedResource)).To(Succeed())
+		})
+	})
+
+	Context("Delete HcloudVolume", func() {
+		It("should detach the volume before deleting it from Hetzner Cloud", func() {
+			const resourceName = "test-delete-volume"
+			typeNamespacedName := types.NamespacedName{Name: resourceName, Namespace: namespace}
+
+			resource := newVolume(resourceName, nil)
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+			resource.Status.VolumeId = 7004
+			Expect(k8sClient.Status().Update(ctx, resource)).To(Succeed())
+
+			getResource := &hcloudv1alpha1.HcloudVolume{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, getResource)).To(Succeed())
+			getResource.Finalizers = []string{finalizerName}
+			Expect(k8sClient.Update(ctx, getResource)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, getResource)).To(Succeed())
+
+			live := liveVolume(7004, resourceName, nil)
+			live.Server = &hcloudgo.Server{ID: 9104}
+			actionStatus := hcloudgo.ActionStatusRunning
+			var deleted int64
+			MockVolumeClient := &hcloud.MockVolumeClient{}
+			MockVolumeClient.GetVolumeByIdFunc = func(ctx context.Context, id int64) (*hcloudgo.Volume, *hcloudgo.Response, error) {
+				return live, nil, nil
+			}
+			MockVolumeClient.DetachVolumeFunc = func(ctx context.Context, volume *hcloudgo.Volume) (*hcloudgo.Action, *hcloudgo.Response, error) {
+				live.Server = nil
+				return &hcloudgo.Action{ID: 906, Command: "detach_volume", Status: hcloudgo.ActionStatusRunning}, nil, nil
+			}
+			MockVolumeClient.GetActionFunc = func(ctx context.Context, id int64) (*hcloudgo.Action, *hcloudgo.Response, error) {
+				return &hcloudgo.Action{ID: id, Status: actionStatus}, nil, nil
+			}
+			MockVolumeClient.DeleteVolumeFunc = func(ctx context.Context, volume *hcloudgo.Volume) (*hcloudgo.Response, error) {
+				Expect(live.Server).To(BeNil())
+				deleted = volume.ID
+				return nil, nil
+			}
+
+			reconciler := &HcloudVolumeReconciler{
+				Client:       k8sClient,
+				Scheme:       k8sClient.Scheme(),
+				VolumeClient: MockVolumeClient,
+				Recorder:     recorder,
+			}
+
+			By("detaching the volume from its server")
+			result, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(defaultActionPollInterval))
+			Expect(deleted).To(BeZero())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, getResource)).To(Succeed())
+			Expect(getResource.Status.PendingActions).To(HaveLen(1))
+
+			By("deleting the volume once it is detached")
+			actionStatus = hcloudgo.ActionStatusSuccess
+			_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deleted).To(Equal(int64(7004)))
+
+			err = k8sClient.Get(ctx, typeNamespacedName, getResource)
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("should leave a volume taken over by another resource when deleted", func() {
+			const resourceName = "test-delete-taken-over-volume"
+			typeNamespacedName := types.NamespacedName{Name: resourceName, Namespace: namespace}
+
+			resource := newVolume(resourceName, nil)
+			resource.Finalizers = []string{finalizerName}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+			resource.Status.VolumeId = 7006
+			Expect(k8sClient.Status().Update(ctx, resource)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+
+			live := liveVolume(7006, resourceName, map[string]string{
+				hcloudv1alpha1.ClusterIDLabel: "cluster-a",
+				hcloudv1alpha1.NamespaceLabel: "other",
+				hcloudv1alpha1.NameLabel:      "new-owner",
+				hcloudv1alpha1.OwnerUIDLabel:  "new-owner-uid",
+			})
+			live.Server = &hcloudgo.Server{ID: 9106}
+			MockVolumeClient := &hcloud.MockVolumeClient{}
+			MockVolumeClient.GetVolumeByIdFunc = func(ctx context.Context, id int64) (*hcloudgo.Volume, *hcloudgo.Response, error) {
+				return live, nil, nil
+			}
+			MockVolumeClient.DetachVolumeFunc = func(ctx context.Context, volume *hcloudgo.Volume) (*hcloudgo.Action, *hcloudgo.Response, error) {
+				Fail("a volume taken over by another resource must stay attached")
+				return nil, nil, nil
+			}
+			MockVolumeClient.DeleteVolumeFunc = func(ctx context.Context, volume *hcloudgo.Volume) (*hcloudgo.Response, error) {
+				Fail("a volume taken over by another resource must not be deleted")
+				return nil, nil
+			}
+
+			reconciler := &HcloudVolumeReconciler{
+				Client:       k8sClient,
+				Scheme:       k8sClient.Scheme(),
+				VolumeClient: MockVolumeClient,
+				Recorder:     recorder,
+				ClusterID:    "cluster-a",
+			}
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = k8sClient.Get(ctx, typeNamespacedName, &hcloudv1alpha1.HcloudVolume{})
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("should leave the volume attached when sync policy is orphan", func() {
+			const resourceName = "test-orphan-volume"
+			typeNamespacedName := types.NamespacedName{Name: resourceName, Namespace: namespace}
+
+			resource := newVolume(resourceName, &hcloudv1alpha1.HcloudSyncPolicy{Mode: hcloudv1alpha1.SyncPolicyOrphan})
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+			resource.Status.VolumeId = 7005
+			Expect(k8sClient.Status().Update(ctx, resource)).To(Succeed())
+
+			getResource := &hcloudv1alpha1.HcloudVolume{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, getResource)).To(Succeed())
+			getResource.Finalizers = []string{finalizerName}
+			Expect(k8sClient.Update(ctx, getResource)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, getResource)).To(Succeed())
+
+			live := liveVolume(7005, resourceName, map[string]string{"env": "test", hcloudv1alpha1.OwnerUIDLabel: string(getResource.UID)})
+			live.Server = &hcloudgo.Server{ID: 9105}
+			MockVolumeClient := &hcloud.MockVolumeClient{}
+			MockVolumeClient.GetVolumeByIdFunc = func(ctx context.Context, id int64) (*hcloudgo.Volume, *hcloudgo.Response, error) {
+				return live, nil, nil
+			}
+			MockVolumeClient.UpdateVolumeLabelsFunc = func(ctx context.Context, volume *hcloudgo.Volume, labels map[string]string) (*hcloudgo.Volume, *hcloudgo.Response, error) {
+				live.Labels = labels
+				return live, nil, nil
+			}
+			MockVolumeClient.DetachVolumeFunc = func(ctx context.Context, volume *hcloudgo.Volume) (*hcloudgo.Action, *hcloudgo.Response, error) {
+				Fail("orphaned volumes must stay attached")
+				return nil, nil, nil
+			}
+			MockVolumeClient.DeleteVolumeFunc = func(ctx context.Context, volume *hcloudgo.Volume) (*hcloudgo.Response, error) {
+				Fail("orphaned volumes must not be deleted")
+				return nil, nil
+			}
+
+			reconciler := &HcloudVolumeReconciler{
+				Client:       k8sClient,
+				Scheme:       k8sClient.Scheme(),
+				VolumeClient: MockVolumeClient,
+				Recorder:     recorder,
+			}
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(live.Labels).To(Equal(map[string]string{"env": "test"}))
+			Expect(live.Server).NotTo(BeNil())
+
+			err = k8sClient.Get(ctx, typeNamespacedName, getResource)
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,138 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	hcloudv1alpha1 "bunskin.com/hcrm/api/v1alpha1"
+)
+
+// collectTimeout bounds the time spent listing resources on a metrics scrape
+const collectTimeout = 5 * time.Second
+
+var resourceConditionsDesc = prometheus.NewDesc(
+	"hcloud_resource_conditions",
+	"Number of resources by kind and by type, status and reason of their conditions.",
+	[]string{"kind", "condition", "status", "reason"},
+	nil,
+)
+
+// conditionLister lists the conditions of all resources of a kind
+type conditionLister struct {
+	kind string
+	list func(ctx context.Context, reader client.Reader) ([][]metav1.Condition, error)
+}
+
+// conditionedObject is a resource reporting its state in status conditions
+type conditionedObject interface {
+	GetConditions() []metav1.Condition
+}
+
+// listConditions returns a conditionLister listing the conditions of the items of the list type L
+func listConditions[T any, L interface {
+	*T
+	client.ObjectList
+}](kind string) conditionLister {
+	return conditionLister{kind: kind, list: func(ctx context.Context, reader client.Reader) ([][]metav1.Condition, error) {
+		list := L(new(T))
+		if err := reader.List(ctx, list); err != nil {
+			return nil, err
+		}
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return nil, err
+		}
+		conditions := make([][]metav1.Condition, 0, len(items))
+		for _, item := range items {
+			if obj, ok := item.(conditionedObject); ok {
+				conditions = append(conditions, obj.GetConditions())
+			}
+		}
+		return conditions, nil
+	}}
+}
+
+var conditionListers = []conditionLister{
+	listConditions[hcloudv1alpha1.HcloudNetworkList]("HcloudNetwork"),
+	listConditions[hcloudv1alpha1.HcloudDnsZoneList]("HcloudDnsZone"),
+	listConditions[hcloudv1alpha1.HcloudDnsRecordSetList]("HcloudDnsRecordSet"),
+	listConditions[hcloudv1alpha1.HcloudFirewallList]("HcloudFirewall"),
+	listConditions[hcloudv1alpha1.HcloudServerList]("HcloudServer"),
+	listConditions[hcloudv1alpha1.HcloudServerOperationList]("HcloudServerOperation"),
+	listConditions[hcloudv1alpha1.HcloudVolumeList]("HcloudVolume"),
+	listConditions[hcloudv1alpha1.HcloudProviderConfigList]("HcloudProviderConfig"),
+	listConditions[hcloudv1alpha1.ClusterHcloudProviderConfigList]("ClusterHcloudProviderConfig"),
+}
+
+// ResourceConditionCollector exports the number of resources per kind by the type, status and
+// reason of their conditions, such as resources whose Available condition has the reason Failed
+// or DeletionFailed and resources whose Drifted condition is true. Resources are counted from the
+// reader on every scrape, so deleted resources disappear from the metric without bookkeeping.
+type ResourceConditionCollector struct {
+	reader client.Reader
+}
+
+// NewResourceConditionCollector creates a collector counting the resources listed from the reader,
+// usually the cached client of the manager
+func NewResourceConditionCollector(reader client.Reader) *ResourceConditionCollector {
+	return &ResourceConditionCollector{reader: reader}
+}
+
+// Describe implements prometheus.Collector
+func (c *ResourceConditionCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- resourceConditionsDesc
+}
+
+// Collect implements prometheus.Collector
+func (c *ResourceConditionCollector) Collect(ch chan<- prometheus.Metric) {
+	log := logf.Log.WithName("metrics")
+
+	ctx, cancel := context.WithTimeout(context.Background(), collectTimeout)
+	defer cancel()
+
+	type conditionKey struct {
+		condition string
+		status    metav1.ConditionStatus
+		reason    string
+	}
+	for _, lister := range conditionListers {
+		resources, err := lister.list(ctx, c.reader)
+		if err != nil {
+			log.Error(err, "Failed to list resources for metrics", "kind", lister.kind)
+			continue
+		}
+
+		counts := make(map[conditionKey]int)
+		for _, conditions := range resources {
+			for _, condition := range conditions {
+				counts[conditionKey{condition: condition.Type, status: condition.Status, reason: condition.Reason}]++
+			}
+		}
+		for key, count := range counts {
+			ch <- prometheus.MustNewConstMetric(resourceConditionsDesc, prometheus.GaugeValue, float64(count),
+				lister.kind, key.condition, string(key.status), key.reason)
+		}
+	}
+}
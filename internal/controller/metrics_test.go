@@ -0,0 +1,59 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	hcloudv1alpha1 "bunskin.com/hcrm/api/v1alpha1"
+)
+
+var _ = Describe("ResourceConditionCollector", func() {
+	It("should count resources by condition reason", func() {
+		network := func(name string, conditions ...metav1.Condition) *hcloudv1alpha1.HcloudNetwork {
+			return &hcloudv1alpha1.HcloudNetwork{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+				Status:     hcloudv1alpha1.HcloudNetworkStatus{Conditions: conditions},
+			}
+		}
+		ready := metav1.Condition{Type: "Available", Status: metav1.ConditionTrue, Reason: "Ready"}
+		failed := metav1.Condition{Type: "Available", Status: metav1.ConditionFalse, Reason: "Failed"}
+		drifted := metav1.Condition{Type: driftedCondition, Status: metav1.ConditionTrue, Reason: "DriftDetected"}
+
+		reader := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(
+			network("network-a", ready),
+			network("network-b", ready, drifted),
+			network("network-c", failed),
+		).Build()
+
+		expected := `
+# HELP hcloud_resource_conditions Number of resources by kind and by type, status and reason of their conditions.
+# TYPE hcloud_resource_conditions gauge
+hcloud_resource_conditions{condition="Available",kind="HcloudNetwork",reason="Failed",status="False"} 1
+hcloud_resource_conditions{condition="Available",kind="HcloudNetwork",reason="Ready",status="True"} 2
+hcloud_resource_conditions{condition="Drifted",kind="HcloudNetwork",reason="DriftDetected",status="True"} 1
+`
+		Expect(testutil.CollectAndCompare(NewResourceConditionCollector(reader), strings.NewReader(expected))).To(Succeed())
+	})
+})
@@ -0,0 +1,315 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	hcloudv1alpha1 "bunskin.com/hcrm/api/v1alpha1"
+	"bunskin.com/hcrm/pkg/hcloud"
+)
+
+const (
+	// orphanDetectedReason is the event reason for a cloud resource whose managing resource no longer exists
+	orphanDetectedReason = "OrphanDetected"
+	// orphanDeletedReason is the event reason for an orphaned cloud resource deleted after the grace period
+	orphanDeletedReason = "OrphanDeleted"
+	// orphanDeletionFailedReason is the event reason for an orphaned cloud resource that could not be deleted
+	orphanDeletionFailedReason = "OrphanDeletionFailed"
+)
+
+var (
+	orphanedResources = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "hcloud_orphaned_resources",
+		Help: "Number of Hetzner Cloud resources labelled as managed by this cluster whose resource no longer exists, by kind.",
+	}, []string{"kind"})
+
+	orphanedResourcesDeleted = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "hcloud_orphaned_resources_deleted_total",
+		Help: "Number of orphaned Hetzner Cloud resources deleted by the orphan collector, by kind.",
+	}, []string{"kind"})
+)
+
+// RegisterOrphanMetrics registers the metrics of the OrphanCollector with the registerer, usually
+// the registry of controller-runtime so they are served on the metrics endpoint of the manager
+func RegisterOrphanMetrics(registerer prometheus.Registerer) {
+	registerer.MustRegister(orphanedResources, orphanedResourcesDeleted)
+}
+
+// cloudResource is a Hetzner Cloud resource as seen by the orphan collector
+type cloudResource struct {
+	id     int64
+	name   string
+	labels map[string]string
+	delete func(ctx context.Context) error
+}
+
+// orphanSource lists the cloud resources of a kind and the UIDs of the resources managing them
+type orphanSource struct {
+	kind   string
+	list   func(ctx context.Context) ([]cloudResource, error)
+	owners func(ctx context.Context) (map[types.UID]bool, error)
+}
+
+// orphanKey identifies an orphaned cloud resource across collection runs
+type orphanKey struct {
+	kind string
+	id   int64
+}
+
+// OrphanCollector periodically looks for Hetzner Cloud networks and zones whose ownership labels
+// name a resource of this cluster that no longer exists, which happens when a resource is deleted
+// while the operator is down or its finalizer is removed by hand. Orphans are reported as events on
+// the missing resource and in the hcloud_orphaned_resources metric, and are deleted once they have
+// been orphaned for the grace period unless the collector runs in dry-run mode. Orphans of kinds
+// and namespaces paused by the PauseSwitch are only reported, as the controllers leave their
+// resources alone. A resource paused by the paused annotation still exists, so the cloud resources
+// it manages are never orphans.
+//
+// Only the project of the default clients is scanned. Without a cluster ID the ownership labels
+// cannot tell the resources of this cluster apart from those of other clusters sharing the
+// project, so orphans are then only reported.
+//
+// Firewalls, servers and volumes are never collected, even though their controllers write the same
+// ownership labels: deleting a server or a volume destroys data and deleting a firewall exposes the
+// servers it protects. Their orphans are left to be removed by hand.
+type OrphanCollector struct {
+	// Reader reads the managing resources, it should bypass the cache so that a cache that is not
+	// yet synced does not make every cloud resource look orphaned
+	Reader        client.Reader
+	NetworkClient hcloud.NetworkClient
+	DnsZoneClient hcloud.DnsZoneClient
+	Recorder      record.EventRecorder
+	// ClusterID is the cluster ID written into the ownership labels by the controllers
+	ClusterID string
+	// Interval is the time between two collection runs
+	Interval time.Duration
+	// GracePeriod is the time a cloud resource has to be orphaned before it is deleted
+	GracePeriod time.Duration
+	// DryRun only reports orphans without deleting them
+	DryRun bool
+	// Pause keeps the orphans of paused kinds and namespaces, the namespace is read from their ownership labels
+	Pause *PauseSwitch
+
+	// firstSeen records when each orphan was first found
+	firstSeen map[orphanKey]time.Time
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable, only the leader deletes orphans
+func (c *OrphanCollector) NeedLeaderElection() bool {
+	return true
+}
+
+// Start implements manager.Runnable and collects orphans until the context is cancelled
+func (c *OrphanCollector) Start(ctx context.Context) error {
+	log := logf.Log.WithName("orphan-collector")
+	log.Info("Starting orphan collector", "interval", c.Interval, "gracePeriod", c.GracePeriod, "dryRun", c.dryRun())
+
+	ticker := time.NewTicker(c.Interval)
+	defer ticker.Stop()
+	for {
+		c.collect(ctx, time.Now())
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// dryRun reports whether orphans are only reported
+func (c *OrphanCollector) dryRun() bool {
+	return c.DryRun || c.ClusterID == ""
+}
+
+// collect runs a single collection at the given time
+func (c *OrphanCollector) collect(ctx context.Context, now time.Time) {
+	log := logf.Log.WithName("orphan-collector")
+
+	seen := make(map[orphanKey]time.Time)
+	for _, source := range c.sources() {
+		// A failed listing keeps the orphans of the kind, so that their grace period is not reset
+		keep := func() {
+			for key, first := range c.firstSeen {
+				if key.kind == source.kind {
+					seen[key] = first
+				}
+			}
+		}
+		resources, err := source.list(ctx)
+		if err != nil {
+			log.Error(err, "Failed to list cloud resources", "kind", source.kind)
+			keep()
+			continue
+		}
+		owners, err := source.owners(ctx)
+		if err != nil {
+			log.Error(err, "Failed to list resources", "kind", source.kind)
+			keep()
+			continue
+		}
+
+		orphans := 0
+		for _, resource := range resources {
+			uid := types.UID(resource.labels[hcloudv1alpha1.OwnerUIDLabel])
+			if uid == "" || resource.labels[hcloudv1alpha1.ClusterIDLabel] != c.ClusterID || owners[uid] {
+				continue
+			}
+
+			key := orphanKey{kind: source.kind, id: resource.id}
+			owner := orphanOwner(source.kind, resource.labels)
+			first, known := c.firstSeen[key]
+			if !known {
+				first = now
+				log.Info("Found orphaned cloud resource", "kind", source.kind, "id", resource.id, "name", resource.name, "owner", owner.GetNamespace()+"/"+owner.GetName())
+				c.Recorder.Eventf(owner, "Warning", orphanDetectedReason, "%s %s (%d) in Hetzner Cloud is managed by this resource, which no longer exists", source.kind, resource.name, resource.id)
+			}
+
+			if c.dryRun() || now.Sub(first) < c.GracePeriod {
+				seen[key] = first
+				orphans++
+				continue
+			}
+
+			paused, err := c.Pause.pauses(ctx, source.kind, owner.GetNamespace())
+			if err != nil {
+				log.Error(err, "Failed to check whether reconciliation is paused", "kind", source.kind, "id", resource.id)
+			} else if paused != "" {
+				log.Info("Orphaned cloud resource is paused, will not delete it", "kind", source.kind, "id", resource.id, "reason", paused)
+			}
+			if err != nil || paused != "" {
+				seen[key] = first
+				orphans++
+				continue
+			}
+
+			log.Info("Deleting orphaned cloud resource", "kind", source.kind, "id", resource.id, "name", resource.name)
+			if err := resource.delete(ctx); err != nil {
+				log.Error(err, "Failed to delete orphaned cloud resource", "kind", source.kind, "id", resource.id)
+				c.Recorder.Eventf(owner, "Warning", orphanDeletionFailedReason, "Failed to delete orphaned %s %s (%d): %v", source.kind, resource.name, resource.id, err)
+				seen[key] = first
+				orphans++
+				continue
+			}
+			c.Recorder.Eventf(owner, "Normal", orphanDeletedReason, "Deleted orphaned %s %s (%d) from Hetzner Cloud", source.kind, resource.name, resource.id)
+			orphanedResourcesDeleted.WithLabelValues(source.kind).Inc()
+		}
+		orphanedResources.WithLabelValues(source.kind).Set(float64(orphans))
+	}
+	c.firstSeen = seen
+}
+
+// sources returns the kinds of cloud resources the collector has a client for, only networks and
+// zones are ever collected
+func (c *OrphanCollector) sources() []orphanSource {
+	var sources []orphanSource
+	if c.NetworkClient != nil {
+		sources = append(sources, orphanSource{
+			kind: "HcloudNetwork",
+			list: func(ctx context.Context) ([]cloudResource, error) {
+				networks, err := c.NetworkClient.ListNetworks(ctx)
+				if err != nil {
+					return nil, err
+				}
+				resources := make([]cloudResource, 0, len(networks))
+				for _, network := range networks {
+					resources = append(resources, cloudResource{
+						id:     network.ID,
+						name:   network.Name,
+						labels: network.Labels,
+						delete: func(ctx context.Context) error {
+							_, err := c.NetworkClient.DeleteNetwork(ctx, network)
+							return err
+						},
+					})
+				}
+				return resources, nil
+			},
+			owners: func(ctx context.Context) (map[types.UID]bool, error) {
+				var list hcloudv1alpha1.HcloudNetworkList
+				if err := c.Reader.List(ctx, &list); err != nil {
+					return nil, err
+				}
+				owners := make(map[types.UID]bool, len(list.Items))
+				for _, item := range list.Items {
+					owners[item.UID] = true
+				}
+				return owners, nil
+			},
+		})
+	}
+	if c.DnsZoneClient != nil {
+		sources = append(sources, orphanSource{
+			kind: "HcloudDnsZone",
+			list: func(ctx context.Context) ([]cloudResource, error) {
+				zones, err := c.DnsZoneClient.ListZones(ctx)
+				if err != nil {
+					return nil, err
+				}
+				resources := make([]cloudResource, 0, len(zones))
+				for _, zone := range zones {
+					resources = append(resources, cloudResource{
+						id:     zone.ID,
+						name:   zone.Name,
+						labels: zone.Labels,
+						delete: func(ctx context.Context) error {
+							_, _, err := c.DnsZoneClient.DeleteZone(ctx, zone)
+							return err
+						},
+					})
+				}
+				return resources, nil
+			},
+			owners: func(ctx context.Context) (map[types.UID]bool, error) {
+				var list hcloudv1alpha1.HcloudDnsZoneList
+				if err := c.Reader.List(ctx, &list); err != nil {
+					return nil, err
+				}
+				owners := make(map[types.UID]bool, len(list.Items))
+				for _, item := range list.Items {
+					owners[item.UID] = true
+				}
+				return owners, nil
+			},
+		})
+	}
+	return sources
+}
+
+// orphanOwner rebuilds a reference to the missing resource from the ownership labels, so that the
+// events of the orphan are listed with the events of the namespace it was managed from
+func orphanOwner(kind string, labels map[string]string) *metav1.PartialObjectMetadata {
+	return &metav1.PartialObjectMetadata{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: hcloudv1alpha1.GroupVersion.String(),
+			Kind:       kind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      labels[hcloudv1alpha1.NameLabel],
+			Namespace: labels[hcloudv1alpha1.NamespaceLabel],
+			UID:       types.UID(labels[hcloudv1alpha1.OwnerUIDLabel]),
+		},
+	}
+}
@@ -0,0 +1,265 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	hcloudgo "github.com/hetznercloud/hcloud-go/v2/hcloud"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hcloudv1alpha1 "bunskin.com/hcrm/api/v1alpha1"
+	"bunskin.com/hcrm/pkg/hcloud"
+)
+
+var _ = Describe("OrphanCollector", func() {
+	var (
+		ctx           context.Context
+		now           time.Time
+		events        *record.FakeRecorder
+		deleted       []int64
+		networkClient *hcloud.MockNetworkClient
+		collector     *OrphanCollector
+	)
+
+	ownedBy := func(cluster, uid, name string) map[string]string {
+		return map[string]string{
+			"env":                         "test",
+			hcloudv1alpha1.ClusterIDLabel: cluster,
+			hcloudv1alpha1.NamespaceLabel: "default",
+			hcloudv1alpha1.NameLabel:      name,
+			hcloudv1alpha1.OwnerUIDLabel:  uid,
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		now = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+		events = record.NewFakeRecorder(16)
+		deleted = nil
+
+		reader := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(&hcloudv1alpha1.HcloudNetwork{
+			ObjectMeta: metav1.ObjectMeta{Name: "live-network", Namespace: "default", UID: "live-uid"},
+		}).Build()
+
+		networkClient = &hcloud.MockNetworkClient{}
+		networkClient.ListNetworksFunc = func(ctx context.Context) ([]*hcloudgo.Network, error) {
+			return []*hcloudgo.Network{
+				{ID: 1, Name: "live-network", Labels: ownedBy("cluster-a", "live-uid", "live-network")},
+				{ID: 2, Name: "leaked-network", Labels: ownedBy("cluster-a", "gone-uid", "leaked-network")},
+				{ID: 3, Name: "foreign-network", Labels: ownedBy("cluster-b", "foreign-uid", "foreign-network")},
+				{ID: 4, Name: "unmanaged-network", Labels: map[string]string{"env": "test"}},
+			}, nil
+		}
+		networkClient.DeleteNetworkFunc = func(ctx context.Context, network *hcloudgo.Network) (*hcloudgo.Response, error) {
+			deleted = append(deleted, network.ID)
+			return nil, nil
+		}
+
+		collector = &OrphanCollector{
+			Reader:        reader,
+			NetworkClient: networkClient,
+			Recorder:      events,
+			ClusterID:     "cluster-a",
+			GracePeriod:   time.Hour,
+			DryRun:        true,
+		}
+	})
+
+	It("should only report orphans in dry-run mode", func() {
+		collector.collect(ctx, now)
+		collector.collect(ctx, now.Add(2*time.Hour))
+
+		Expect(deleted).To(BeEmpty())
+		Expect(testutil.ToFloat64(orphanedResources.WithLabelValues("HcloudNetwork"))).To(Equal(1.0))
+		Expect(events.Events).To(Receive(ContainSubstring("OrphanDetected HcloudNetwork leaked-network (2)")))
+		Expect(events.Events).NotTo(Receive())
+	})
+
+	It("should delete orphans after the grace period", func() {
+		collector.DryRun = false
+		deletedBefore := testutil.ToFloat64(orphanedResourcesDeleted.WithLabelValues("HcloudNetwork"))
+
+		collector.collect(ctx, now)
+		collector.collect(ctx, now.Add(30*time.Minute))
+		Expect(deleted).To(BeEmpty())
+
+		collector.collect(ctx, now.Add(time.Hour))
+		Expect(deleted).To(Equal([]int64{2}))
+		Expect(testutil.ToFloat64(orphanedResources.WithLabelValues("HcloudNetwork"))).To(BeZero())
+		Expect(testutil.ToFloat64(orphanedResourcesDeleted.WithLabelValues("HcloudNetwork"))).To(Equal(deletedBefore + 1))
+		Expect(events.Events).To(Receive(ContainSubstring(orphanDetectedReason)))
+		Expect(events.Events).To(Receive(ContainSubstring("OrphanDeleted Deleted orphaned HcloudNetwork leaked-network (2)")))
+	})
+
+	It("should keep the grace period of orphans when listing fails", func() {
+		collector.DryRun = false
+		collector.collect(ctx, now)
+
+		list := networkClient.ListNetworksFunc
+		networkClient.ListNetworksFunc = func(ctx context.Context) ([]*hcloudgo.Network, error) {
+			return nil, fmt.Errorf("service unavailable")
+		}
+		collector.collect(ctx, now.Add(30*time.Minute))
+
+		networkClient.ListNetworksFunc = list
+		collector.collect(ctx, now.Add(time.Hour))
+		Expect(deleted).To(Equal([]int64{2}))
+	})
+
+	It("should not delete orphans of paused kinds and namespaces", func() {
+		collector.DryRun = false
+		collector.Pause = &PauseSwitch{Kinds: []string{"HcloudNetwork"}}
+		collector.collect(ctx, now)
+		collector.collect(ctx, now.Add(2*time.Hour))
+		Expect(deleted).To(BeEmpty())
+		Expect(testutil.ToFloat64(orphanedResources.WithLabelValues("HcloudNetwork"))).To(Equal(1.0))
+
+		collector.Pause = &PauseSwitch{Namespaces: []string{"default"}}
+		collector.collect(ctx, now.Add(3*time.Hour))
+		Expect(deleted).To(BeEmpty())
+
+		By("deleting the orphan once it is resumed, without restarting its grace period")
+		collector.Pause = &PauseSwitch{Namespaces: []string{"other"}}
+		collector.collect(ctx, now.Add(4*time.Hour))
+		Expect(deleted).To(Equal([]int64{2}))
+	})
+
+	It("should not delete orphans without a cluster ID", func() {
+		collector.DryRun = false
+		collector.ClusterID = ""
+		networkClient.ListNetworksFunc = func(ctx context.Context) ([]*hcloudgo.Network, error) {
+			labels := ownedBy("", "gone-uid", "leaked-network")
+			delete(labels, hcloudv1alpha1.ClusterIDLabel)
+			return []*hcloudgo.Network{{ID: 2, Name: "leaked-network", Labels: labels}}, nil
+		}
+
+		collector.collect(ctx, now)
+		collector.collect(ctx, now.Add(2*time.Hour))
+		Expect(deleted).To(BeEmpty())
+		Expect(testutil.ToFloat64(orphanedResources.WithLabelValues("HcloudNetwork"))).To(Equal(1.0))
+	})
+
+	Context("Collect networks of deleted HcloudNetworks", func() {
+		var (
+			network    *hcloudgo.Network
+			reconciler *HcloudNetworkReconciler
+		)
+
+		// deleteNetwork creates an HcloudNetwork managing network and deletes it again
+		deleteNetwork := func(resourceName string, annotations map[string]string) types.NamespacedName {
+			typeNamespacedName := types.NamespacedName{Name: resourceName, Namespace: "default"}
+			resource := &hcloudv1alpha1.HcloudNetwork{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        resourceName,
+					Namespace:   "default",
+					Annotations: annotations,
+					Finalizers:  []string{finalizerName},
+				},
+				Spec: hcloudv1alpha1.HcloudNetworkSpec{
+					Name:    resourceName,
+					IpRange: "10.0.0.0/16",
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+			resource.Status.NetworkId = 10
+			Expect(k8sClient.Status().Update(ctx, resource)).To(Succeed())
+
+			network = &hcloudgo.Network{ID: 10, Name: resourceName, Labels: withOwnershipLabels(map[string]string{"env": "test"}, nil, resource, "cluster-a")}
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+			return typeNamespacedName
+		}
+
+		BeforeEach(func() {
+			networkClient.ListNetworksFunc = func(ctx context.Context) ([]*hcloudgo.Network, error) {
+				return []*hcloudgo.Network{network}, nil
+			}
+			networkClient.GetNetworkByIdFunc = func(ctx context.Context, id int64) (*hcloudgo.Network, *hcloudgo.Response, error) {
+				return network, nil, nil
+			}
+			networkClient.UpdateNetworkLabelsFunc = func(ctx context.Context, n *hcloudgo.Network, labels map[string]string) (*hcloudgo.Network, *hcloudgo.Response, error) {
+				network.Labels = labels
+				return network, nil, nil
+			}
+
+			collector.Reader = k8sClient
+			collector.DryRun = false
+			reconciler = &HcloudNetworkReconciler{
+				Client:        k8sClient,
+				Scheme:        k8sClient.Scheme(),
+				NetworkClient: networkClient,
+				Recorder:      recorder,
+				ClusterID:     "cluster-a",
+			}
+		})
+
+		It("should not delete a network left by a resource with an invalid sync policy", func() {
+			typeNamespacedName := deleteNetwork("test-orphan-invalid-policy", map[string]string{syncPolicy: "delete"})
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			err = k8sClient.Get(ctx, typeNamespacedName, &hcloudv1alpha1.HcloudNetwork{})
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+			Expect(network.Labels).To(Equal(map[string]string{"env": "test"}))
+
+			collector.collect(ctx, now)
+			collector.collect(ctx, now.Add(2*time.Hour))
+			Expect(deleted).To(BeEmpty())
+		})
+
+		It("should not delete a network whose release failed", func() {
+			typeNamespacedName := deleteNetwork("test-orphan-release-failed", map[string]string{syncPolicy: string(hcloudv1alpha1.SyncPolicyOrphan)})
+			update := networkClient.UpdateNetworkLabelsFunc
+			networkClient.UpdateNetworkLabelsFunc = func(ctx context.Context, n *hcloudgo.Network, labels map[string]string) (*hcloudgo.Network, *hcloudgo.Response, error) {
+				return nil, nil, fmt.Errorf("service unavailable")
+			}
+
+			By("keeping the finalizer while the network is claimed")
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).To(HaveOccurred())
+			updatedResource := &hcloudv1alpha1.HcloudNetwork{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updatedResource)).To(Succeed())
+			Expect(updatedResource.Finalizers).To(ContainElement(finalizerName))
+
+			collector.collect(ctx, now)
+			collector.collect(ctx, now.Add(2*time.Hour))
+			Expect(deleted).To(BeEmpty())
+
+			By("letting the resource go once the network is released")
+			networkClient.UpdateNetworkLabelsFunc = update
+			_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(network.Labels).To(Equal(map[string]string{"env": "test"}))
+			err = k8sClient.Get(ctx, typeNamespacedName, &hcloudv1alpha1.HcloudNetwork{})
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+
+			collector.collect(ctx, now.Add(4*time.Hour))
+			Expect(deleted).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,170 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+const (
+	// pausedAnnotation is the annotation key that pauses the reconciliation of a resource when set to "true"
+	pausedAnnotation = "hcloud.bunskin.com/paused"
+	// pausedCondition is the condition type reporting that the reconciliation of a resource is paused
+	pausedCondition = "Paused"
+	// pausedReason is the reason of the Paused condition and of the event emitted when a resource is paused
+	pausedReason = "Paused"
+	// resumedReason is the reason of the event emitted when a paused resource is reconciled again
+	resumedReason = "Resumed"
+	// pausedRequeueAfter is the interval at which resources paused by the PauseSwitch check whether they were resumed
+	pausedRequeueAfter = time.Minute
+
+	// pauseKindsKey is the ConfigMap key listing the paused kinds, separated by commas
+	pauseKindsKey = "kinds"
+	// pauseNamespacesKey is the ConfigMap key listing the paused namespaces, separated by commas
+	pauseNamespacesKey = "namespaces"
+)
+
+// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get
+
+// PauseSwitch pauses the reconciliation of all resources of a kind or in a namespace, for example
+// during a Hetzner Cloud maintenance. Kinds and namespaces are either fixed when the manager starts
+// or read from a ConfigMap on every reconcile, so that they can be changed without a restart.
+type PauseSwitch struct {
+	// Kinds are the kinds whose resources are paused, for example HcloudNetwork
+	Kinds []string
+	// Namespaces are the namespaces whose resources are paused
+	Namespaces []string
+	// Reader reads the ConfigMap, it is not cached to avoid watching all ConfigMaps of the cluster
+	Reader client.Reader
+	// ConfigMap lists further kinds and namespaces in its kinds and namespaces keys, the name is empty if there is none
+	ConfigMap types.NamespacedName
+}
+
+// pauses returns why resources of kind in namespace are paused, or an empty string if they are not
+func (s *PauseSwitch) pauses(ctx context.Context, kind, namespace string) (string, error) {
+	if s == nil {
+		return "", nil
+	}
+	if slices.Contains(s.Kinds, kind) {
+		return fmt.Sprintf("Reconciliation of %s is paused by the manager", kind), nil
+	}
+	if slices.Contains(s.Namespaces, namespace) {
+		return fmt.Sprintf("Reconciliation in namespace %s is paused by the manager", namespace), nil
+	}
+	if s.ConfigMap.Name == "" {
+		return "", nil
+	}
+
+	var configMap corev1.ConfigMap
+	if err := s.Reader.Get(ctx, s.ConfigMap, &configMap); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to read pause ConfigMap %s: %w", s.ConfigMap, err)
+	}
+	if slices.Contains(SplitList(configMap.Data[pauseKindsKey]), kind) {
+		return fmt.Sprintf("Reconciliation of %s is paused by ConfigMap %s", kind, s.ConfigMap), nil
+	}
+	if slices.Contains(SplitList(configMap.Data[pauseNamespacesKey]), namespace) {
+		return fmt.Sprintf("Reconciliation in namespace %s is paused by ConfigMap %s", namespace, s.ConfigMap), nil
+	}
+	return "", nil
+}
+
+// SplitList splits a comma separated list, such as a flag value or a ConfigMap entry, ignoring
+// blanks around and between its items
+func SplitList(value string) []string {
+	var items []string
+	for item := range strings.SplitSeq(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// reconcilePaused reports whether the reconciliation of obj is paused by the paused annotation or
+// by the PauseSwitch. A paused resource gets the Paused condition and is left alone entirely, even
+// while it is being deleted, so that its finalizer holds on to the cloud resource. Resources paused
+// by the annotation are reconciled again when it is removed, resources paused by the PauseSwitch
+// are requeued to notice that the ConfigMap resumed them. When obj is not paused, the Paused
+// condition is removed and saved by the next status update of the caller.
+func reconcilePaused(ctx context.Context, c client.Client, recorder record.EventRecorder, pause *PauseSwitch, kind string, obj client.Object, conditions *[]metav1.Condition) (bool, ctrl.Result, error) {
+	log := logf.Log.WithName("pause")
+
+	var result ctrl.Result
+	message := ""
+	if obj.GetAnnotations()[pausedAnnotation] == "true" {
+		message = fmt.Sprintf("Reconciliation is paused by the %s annotation", pausedAnnotation)
+	} else {
+		var err error
+		if message, err = pause.pauses(ctx, kind, obj.GetNamespace()); err != nil {
+			log.Error(err, "Failed to check whether reconciliation is paused", "name", obj.GetName())
+			return true, ctrl.Result{}, err
+		}
+		result.RequeueAfter = pausedRequeueAfter
+	}
+
+	if message == "" {
+		if meta.RemoveStatusCondition(conditions, pausedCondition) {
+			log.Info("Reconciliation resumed", "kind", kind, "name", obj.GetName())
+			recorder.Event(obj, "Normal", resumedReason, "Reconciliation resumed")
+		}
+		return false, ctrl.Result{}, nil
+	}
+
+	log.Info("Reconciliation paused", "kind", kind, "name", obj.GetName(), "reason", message)
+	changed := meta.SetStatusCondition(conditions, metav1.Condition{
+		Type:               pausedCondition,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: obj.GetGeneration(),
+		Reason:             pausedReason,
+		Message:            message,
+	})
+	if changed {
+		if err := c.Status().Update(ctx, obj); err != nil {
+			log.Error(err, "Failed to update status", "name", obj.GetName())
+			return true, ctrl.Result{}, err
+		}
+		recorder.Event(obj, "Normal", pausedReason, message)
+	}
+	return true, result, nil
+}
+
+// pausedAnnotationChanged lets updates of the paused annotation through to the reconciler, which
+// would otherwise be filtered out for not changing the generation of the resource.
+var pausedAnnotationChanged = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		return e.ObjectOld.GetAnnotations()[pausedAnnotation] != e.ObjectNew.GetAnnotations()[pausedAnnotation]
+	},
+}
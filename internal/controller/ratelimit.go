@@ -0,0 +1,62 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// rateLimitedReason is the condition reason used while the Hetzner Cloud rate limit is exhausted
+	rateLimitedReason = "RateLimited"
+	// minRateLimitedRequeueAfter keeps resources from being requeued immediately when the reset has already passed
+	minRateLimitedRequeueAfter = time.Second
+)
+
+// requeueRateLimited reports an exhausted Hetzner Cloud rate limit on the Available condition of the
+// resource and requeues it once the next request is allowed, instead of returning the error for
+// the exponential backoff of controller-runtime.
+func requeueRateLimited(ctx context.Context, c client.Client, recorder record.EventRecorder, obj client.Object, conditions *[]metav1.Condition, reset time.Time, err error) (ctrl.Result, error) {
+	log := logf.Log.WithName("ratelimit")
+
+	requeueAfter := max(time.Until(reset), minRateLimitedRequeueAfter)
+	log.Info("Hetzner Cloud rate limit exhausted, requeueing", "name", obj.GetName(), "requeueAfter", requeueAfter)
+
+	meta.SetStatusCondition(conditions, metav1.Condition{
+		Type:               "Available",
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: obj.GetGeneration(),
+		Reason:             rateLimitedReason,
+		Message:            fmt.Sprintf("Hetzner Cloud rate limit exhausted, retrying after %s: %v", reset.Format(time.RFC3339), err),
+	})
+	if err := c.Status().Update(ctx, obj); err != nil {
+		log.Error(err, "Failed to update status", "name", obj.GetName())
+		return ctrl.Result{}, err
+	}
+	recorder.Eventf(obj, "Warning", rateLimitedReason, "Hetzner Cloud rate limit exhausted, retrying in %s", requeueAfter.Round(time.Second))
+
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
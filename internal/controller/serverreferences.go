@@ -0,0 +1,201 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hcloudv1alpha1 "bunskin.com/hcrm/api/v1alpha1"
+	hcloudgo "github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+// unresolvedReferencesReason is the condition reason for a server whose networks, firewalls, SSH
+// keys or user data cannot be resolved
+const unresolvedReferencesReason = "UnresolvedReferences"
+
+// serverNetwork is a network of spec.networks resolved to its Hetzner Cloud network ID
+type serverNetwork struct {
+	name string
+	id   int64
+	ip   net.IP
+}
+
+// resolveServerNetworks resolves the HcloudNetworks of spec.networks to the IDs of their Hetzner Cloud networks
+func resolveServerNetworks(ctx context.Context, c client.Reader, namespace string, networks []hcloudv1alpha1.HcloudServerNetwork) ([]serverNetwork, error) {
+	resolved := make([]serverNetwork, 0, len(networks))
+	for i, network := range networks {
+		var hcloudNetwork hcloudv1alpha1.HcloudNetwork
+		if err := c.Get(ctx, types.NamespacedName{Name: network.Name, Namespace: namespace}, &hcloudNetwork); err != nil {
+			return nil, fmt.Errorf("networks[%d]: failed to get HcloudNetwork %s: %w", i, network.Name, err)
+		}
+		if hcloudNetwork.Status.NetworkId == 0 {
+			return nil, fmt.Errorf("networks[%d]: HcloudNetwork %s has no network ID yet", i, network.Name)
+		}
+		entry := serverNetwork{name: network.Name, id: int64(hcloudNetwork.Status.NetworkId)}
+		if network.IP != "" {
+			if entry.ip = net.ParseIP(network.IP); entry.ip == nil {
+				return nil, fmt.Errorf("networks[%d]: invalid IP %q", i, network.IP)
+			}
+		}
+		resolved = append(resolved, entry)
+	}
+	return resolved, nil
+}
+
+// resolveServerFirewalls resolves spec.firewalls to Hetzner Cloud firewall IDs, HcloudFirewalls are
+// resolved through the ID in their status
+func resolveServerFirewalls(ctx context.Context, c client.Reader, namespace string, firewalls []hcloudv1alpha1.HcloudServerFirewall) ([]int64, error) {
+	ids := make([]int64, 0, len(firewalls))
+	for i, firewall := range firewalls {
+		if firewall.FirewallId != 0 {
+			ids = append(ids, int64(firewall.FirewallId))
+			continue
+		}
+		var hcloudFirewall hcloudv1alpha1.HcloudFirewall
+		if err := c.Get(ctx, types.NamespacedName{Name: firewall.Name, Namespace: namespace}, &hcloudFirewall); err != nil {
+			return nil, fmt.Errorf("firewalls[%d]: failed to get HcloudFirewall %s: %w", i, firewall.Name, err)
+		}
+		if hcloudFirewall.Status.FirewallId == 0 {
+			return nil, fmt.Errorf("firewalls[%d]: HcloudFirewall %s has no firewall ID yet", i, firewall.Name)
+		}
+		ids = append(ids, int64(hcloudFirewall.Status.FirewallId))
+	}
+	return ids, nil
+}
+
+// resolveUserData reads the cloud-init user data of spec.userData from its Secret or ConfigMap.
+// ConfigMaps are read through configMaps, so that they do not have to be cached.
+func resolveUserData(ctx context.Context, c client.Reader, configMaps client.Reader, namespace string, userData *hcloudv1alpha1.HcloudServerUserData) (string, error) {
+	switch {
+	case userData == nil:
+		return "", nil
+	case userData.SecretKeyRef != nil:
+		ref := userData.SecretKeyRef
+		var secret corev1.Secret
+		if err := c.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: namespace}, &secret); err != nil {
+			return "", fmt.Errorf("userData: failed to get Secret %s: %w", ref.Name, err)
+		}
+		value, ok := secret.Data[ref.Key]
+		if !ok {
+			return "", fmt.Errorf("userData: Secret %s has no key %s", ref.Name, ref.Key)
+		}
+		return string(value), nil
+	case userData.ConfigMapKeyRef != nil:
+		ref := userData.ConfigMapKeyRef
+		var configMap corev1.ConfigMap
+		if err := configMaps.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: namespace}, &configMap); err != nil {
+			return "", fmt.Errorf("userData: failed to get ConfigMap %s: %w", ref.Name, err)
+		}
+		value, ok := configMap.Data[ref.Key]
+		if !ok {
+			return "", fmt.Errorf("userData: ConfigMap %s has no key %s", ref.Name, ref.Key)
+		}
+		return value, nil
+	}
+	return "", nil
+}
+
+// getSSHKeyFunc retrieves a Hetzner Cloud SSH key by name
+type getSSHKeyFunc func(ctx context.Context, name string) (*hcloudgo.SSHKey, *hcloudgo.Response, error)
+
+// resolveSSHKeys looks up the Hetzner Cloud SSH keys of spec.sshKeys by name
+func resolveSSHKeys(ctx context.Context, getSSHKey getSSHKeyFunc, names []string) ([]*hcloudgo.SSHKey, error) {
+	keys := make([]*hcloudgo.SSHKey, 0, len(names))
+	for _, name := range names {
+		key, response, err := getSSHKey(ctx, name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get SSH key %s from Hetzner Cloud: %w. %v", name, err, response)
+		}
+		if key == nil {
+			return nil, fmt.Errorf("SSH key %s not found in Hetzner Cloud", name)
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// serversReferencing returns requests for the HcloudServers in namespace matching references
+func serversReferencing(ctx context.Context, c client.Reader, namespace string, references func(hcloudServer *hcloudv1alpha1.HcloudServer) bool) []reconcile.Request {
+	log := logf.Log.WithName("hcloudserver-controller")
+
+	var list hcloudv1alpha1.HcloudServerList
+	if err := c.List(ctx, &list, client.InNamespace(namespace)); err != nil {
+		log.Error(err, "Failed to list HcloudServers referencing a changed object")
+		return nil
+	}
+	var requests []reconcile.Request
+	for i := range list.Items {
+		if references(&list.Items[i]) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&list.Items[i])})
+		}
+	}
+	return requests
+}
+
+// serversForNetwork maps an HcloudNetwork to the HcloudServers of its namespace attached to it
+func (r *HcloudServerReconciler) serversForNetwork(ctx context.Context, obj client.Object) []reconcile.Request {
+	return serversReferencing(ctx, r.Client, obj.GetNamespace(), func(hcloudServer *hcloudv1alpha1.HcloudServer) bool {
+		return slices.ContainsFunc(hcloudServer.Spec.Networks, func(network hcloudv1alpha1.HcloudServerNetwork) bool {
+			return network.Name == obj.GetName()
+		})
+	})
+}
+
+// serversForFirewall maps an HcloudFirewall to the HcloudServers of its namespace referencing it
+func (r *HcloudServerReconciler) serversForFirewall(ctx context.Context, obj client.Object) []reconcile.Request {
+	return serversReferencing(ctx, r.Client, obj.GetNamespace(), func(hcloudServer *hcloudv1alpha1.HcloudServer) bool {
+		return slices.ContainsFunc(hcloudServer.Spec.Firewalls, func(firewall hcloudv1alpha1.HcloudServerFirewall) bool {
+			return firewall.Name == obj.GetName()
+		})
+	})
+}
+
+// networkIdChanged lets through updates of HcloudNetworks that change their network ID, such as
+// the first reconcile creating the network a server waits for
+var networkIdChanged = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldNetwork, okOld := e.ObjectOld.(*hcloudv1alpha1.HcloudNetwork)
+		newNetwork, okNew := e.ObjectNew.(*hcloudv1alpha1.HcloudNetwork)
+		if !okOld || !okNew {
+			return true
+		}
+		return oldNetwork.Status.NetworkId != newNetwork.Status.NetworkId
+	},
+}
+
+// firewallIdChanged lets through updates of HcloudFirewalls that change their firewall ID
+var firewallIdChanged = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldFirewall, okOld := e.ObjectOld.(*hcloudv1alpha1.HcloudFirewall)
+		newFirewall, okNew := e.ObjectNew.(*hcloudv1alpha1.HcloudFirewall)
+		if !okOld || !okNew {
+			return true
+		}
+		return oldFirewall.Status.FirewallId != newFirewall.Status.FirewallId
+	},
+}
@@ -21,10 +21,12 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	hcloudgo "github.com/hetznercloud/hcloud-go/v2/hcloud"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/record"
@@ -34,6 +36,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
 	hcloudv1alpha1 "bunskin.com/hcrm/api/v1alpha1"
+	"bunskin.com/hcrm/pkg/hcloud/fake"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -47,6 +50,8 @@ var (
 	cfg       *rest.Config
 	k8sClient client.Client
 	recorder  record.EventRecorder
+	// fakeHcloud serves the Hetzner Cloud API for tests exercising the real API clients
+	fakeHcloud *fake.Server
 )
 
 func TestControllers(t *testing.T) {
@@ -86,17 +91,31 @@ var _ = BeforeSuite(func() {
 	Expect(err).NotTo(HaveOccurred())
 	Expect(k8sClient).NotTo(BeNil())
 
-	recorder = record.NewFakeRecorder(10)
+	recorder = record.NewFakeRecorder(1024)
 	Expect(recorder).NotTo(BeNil())
+
+	By("starting the fake Hetzner Cloud API")
+	fakeHcloud = fake.NewServer()
 })
 
 var _ = AfterSuite(func() {
 	By("tearing down the test environment")
+	if fakeHcloud != nil {
+		fakeHcloud.Close()
+	}
 	cancel()
 	err := testEnv.Stop()
 	Expect(err).NotTo(HaveOccurred())
 })
 
+// fakeHcloudOptions returns the hcloud-go options for clients of the fake Hetzner Cloud API
+func fakeHcloudOptions() []hcloudgo.ClientOption {
+	return []hcloudgo.ClientOption{
+		hcloudgo.WithEndpoint(fakeHcloud.URL),
+		hcloudgo.WithPollOpts(hcloudgo.PollOpts{BackoffFunc: hcloudgo.ConstantBackoff(10 * time.Millisecond)}),
+	}
+}
+
 // getFirstFoundEnvTestBinaryDir locates the first binary in the specified path.
 // ENVTEST-based tests depend on specific binaries, usually located in paths set by
 // controller-runtime. When running tests directly (e.g., via an IDE) without using
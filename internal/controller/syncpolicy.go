@@ -0,0 +1,93 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"slices"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hcloudv1alpha1 "bunskin.com/hcrm/api/v1alpha1"
+)
+
+// invalidSyncPolicyReason is the condition reason for a sync policy the controllers do not understand
+const invalidSyncPolicyReason = "InvalidSyncPolicy"
+
+// effectiveSyncPolicy is the sync policy a resource is reconciled with
+type effectiveSyncPolicy struct {
+	mode   hcloudv1alpha1.SyncPolicyMode
+	ignore []string
+}
+
+// resolveSyncPolicy returns the sync policy of obj. The mode of spec.syncPolicy takes precedence
+// over the sync-policy annotation, which is kept for compatibility, and defaultMode applies when
+// neither is set. An unknown mode or a field the kind cannot ignore is an error, so that a typo is
+// not silently reconciled as manage.
+func resolveSyncPolicy(obj client.Object, spec *hcloudv1alpha1.HcloudSyncPolicy, defaultMode hcloudv1alpha1.SyncPolicyMode, ignorable []string) (effectiveSyncPolicy, error) {
+	policy := effectiveSyncPolicy{mode: defaultMode}
+	if value := obj.GetAnnotations()[syncPolicy]; value != "" {
+		policy.mode = hcloudv1alpha1.SyncPolicyMode(value)
+	}
+	if spec != nil {
+		if spec.Mode != "" {
+			policy.mode = spec.Mode
+		}
+		policy.ignore = spec.IgnoreFields
+	}
+
+	if !slices.Contains(hcloudv1alpha1.SyncPolicyModes, policy.mode) {
+		return policy, fmt.Errorf("unknown sync policy %q, must be one of %v", policy.mode, hcloudv1alpha1.SyncPolicyModes)
+	}
+	for _, field := range policy.ignore {
+		if !slices.Contains(ignorable, field) {
+			return policy, fmt.Errorf("field %q cannot be ignored, must be one of %v", field, ignorable)
+		}
+	}
+	return policy, nil
+}
+
+// creates reports whether a missing cloud resource is created
+func (p effectiveSyncPolicy) creates() bool {
+	return p.mode == hcloudv1alpha1.SyncPolicyManage || p.mode == hcloudv1alpha1.SyncPolicyCreateOnly || p.mode == hcloudv1alpha1.SyncPolicyOrphan
+}
+
+// updates reports whether an existing cloud resource is changed to match the spec
+func (p effectiveSyncPolicy) updates() bool {
+	return p.mode == hcloudv1alpha1.SyncPolicyManage || p.mode == hcloudv1alpha1.SyncPolicyUpdateOnly || p.mode == hcloudv1alpha1.SyncPolicyOrphan
+}
+
+// deletes reports whether the cloud resource is deleted together with the resource
+func (p effectiveSyncPolicy) deletes() bool {
+	return p.mode == hcloudv1alpha1.SyncPolicyManage || p.mode == hcloudv1alpha1.SyncPolicyCreateOnly
+}
+
+// claims reports whether the resource takes ownership of the cloud resource. Claimed cloud
+// resources carry the ownership labels and are deleted or released through the finalizer.
+func (p effectiveSyncPolicy) claims() bool {
+	return p.creates() || p.updates()
+}
+
+// reportsDrift reports whether differences between the cloud resource and the spec are recorded
+func (p effectiveSyncPolicy) reportsDrift() bool {
+	return p.mode != hcloudv1alpha1.SyncPolicyObserve
+}
+
+// ignores reports whether the spec field is left to other tools
+func (p effectiveSyncPolicy) ignores(field string) bool {
+	return slices.Contains(p.ignore, field)
+}
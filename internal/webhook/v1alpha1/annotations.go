@@ -0,0 +1,111 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	hcloudv1alpha1 "bunskin.com/hcrm/api/v1alpha1"
+)
+
+const (
+	// syncPolicyAnnotation is the annotation key for the sync policy of a resource
+	syncPolicyAnnotation = "hcloud.bunskin.com/sync-policy"
+)
+
+// validateAnnotations validates the annotations the controllers understand
+func validateAnnotations(annotations map[string]string) field.ErrorList {
+	allErrs := validateSyncPolicyAnnotation(annotations)
+	allErrs = append(allErrs, validateExternalIDAnnotation(annotations)...)
+	allErrs = append(allErrs, validateTakeoverAnnotation(annotations)...)
+	return allErrs
+}
+
+// validateSyncPolicyAnnotation rejects sync policy annotations the controllers would not recognise.
+// An absent annotation is valid, the controllers add the default policy of the provider config.
+func validateSyncPolicyAnnotation(annotations map[string]string) field.ErrorList {
+	policy, ok := annotations[syncPolicyAnnotation]
+	if !ok {
+		return nil
+	}
+	if slices.Contains(hcloudv1alpha1.SyncPolicyModes, hcloudv1alpha1.SyncPolicyMode(policy)) {
+		return nil
+	}
+	return field.ErrorList{field.NotSupported(field.NewPath("metadata", "annotations").Key(syncPolicyAnnotation), policy, hcloudv1alpha1.SyncPolicyModes)}
+}
+
+// validateSyncPolicy rejects ignored fields the kind cannot leave to other tools. The mode is
+// validated by the schema of the field.
+func validateSyncPolicy(policy *hcloudv1alpha1.HcloudSyncPolicy, ignorable []string, path *field.Path) field.ErrorList {
+	if policy == nil {
+		return nil
+	}
+	var allErrs field.ErrorList
+	for i, ignored := range policy.IgnoreFields {
+		if !slices.Contains(ignorable, ignored) {
+			allErrs = append(allErrs, field.NotSupported(path.Child("ignoreFields").Index(i), ignored, ignorable))
+		}
+	}
+	return allErrs
+}
+
+// syncPolicyMode returns the sync policy mode the controllers apply to a resource, an empty mode
+// leaves the choice to the default of the provider config
+func syncPolicyMode(annotations map[string]string, policy *hcloudv1alpha1.HcloudSyncPolicy) hcloudv1alpha1.SyncPolicyMode {
+	if policy != nil && policy.Mode != "" {
+		return policy.Mode
+	}
+	return hcloudv1alpha1.SyncPolicyMode(annotations[syncPolicyAnnotation])
+}
+
+// validateExternalIDAnnotation rejects external IDs that cannot be the ID of a Hetzner Cloud resource
+func validateExternalIDAnnotation(annotations map[string]string) field.ErrorList {
+	value, ok := annotations[hcloudv1alpha1.ExternalIDAnnotation]
+	if !ok {
+		return nil
+	}
+	if id, err := strconv.ParseInt(value, 10, 64); err != nil || id <= 0 {
+		return field.ErrorList{field.Invalid(field.NewPath("metadata", "annotations").Key(hcloudv1alpha1.ExternalIDAnnotation), value, "must be the ID of a Hetzner Cloud resource")}
+	}
+	return nil
+}
+
+// validateTakeoverAnnotation rejects takeover annotations other than true and false
+func validateTakeoverAnnotation(annotations map[string]string) field.ErrorList {
+	value, ok := annotations[hcloudv1alpha1.TakeoverAnnotation]
+	if !ok || value == "true" || value == "false" {
+		return nil
+	}
+	return field.ErrorList{field.NotSupported(field.NewPath("metadata", "annotations").Key(hcloudv1alpha1.TakeoverAnnotation), value, []string{"true", "false"})}
+}
+
+// validateLabels rejects labels the controllers reserve for recording the ownership of cloud resources
+func validateLabels(labels map[string]string, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	for _, key := range slices.Sorted(maps.Keys(labels)) {
+		if strings.HasPrefix(key, hcloudv1alpha1.ReservedLabelPrefix) {
+			allErrs = append(allErrs, field.Invalid(path.Key(key), key, fmt.Sprintf("the prefix %s is reserved for the ownership labels of the operator", hcloudv1alpha1.ReservedLabelPrefix)))
+		}
+	}
+	return allErrs
+}
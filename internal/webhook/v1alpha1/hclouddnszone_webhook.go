@@ -0,0 +1,290 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"strings"
+
+	"golang.org/x/net/idna"
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	hcloudv1alpha1 "bunskin.com/hcrm/api/v1alpha1"
+)
+
+const (
+	// defaultDnsZoneMode is the mode of zones created without spec.mode
+	defaultDnsZoneMode = "PRIMARY"
+	// secondaryDnsZoneMode is the mode of zones transferred from primary nameservers
+	secondaryDnsZoneMode = "SECONDARY"
+	// defaultDnsZoneTTL is the default TTL Hetzner Cloud assigns to new zones
+	defaultDnsZoneTTL = 3600
+	// minDnsZoneTTL and maxDnsZoneTTL are the bounds of the zone TTL Hetzner Cloud accepts
+	minDnsZoneTTL = 60
+	maxDnsZoneTTL = math.MaxInt32
+)
+
+// zoneNameProfile converts zone names to the ASCII form used by the DNS, mapping internationalized
+// names to punycode and rejecting names that are not valid hostnames
+var zoneNameProfile = idna.New(idna.MapForLookup(), idna.BidiRule(), idna.VerifyDNSLength(true), idna.Transitional(false))
+
+// log is for logging in this package.
+var hclouddnszonelog = logf.Log.WithName("hclouddnszone-resource")
+
+// SetupHcloudDnsZoneWebhookWithManager registers the webhook for HcloudDnsZone in the manager.
+func SetupHcloudDnsZoneWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&hcloudv1alpha1.HcloudDnsZone{}).
+		WithValidator(&HcloudDnsZoneCustomValidator{Client: mgr.GetClient()}).
+		WithDefaulter(&HcloudDnsZoneCustomDefaulter{}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-hcloud-bunskin-com-v1alpha1-hclouddnszone,mutating=true,failurePolicy=fail,sideEffects=None,groups=hcloud.bunskin.com,resources=hclouddnszones,verbs=create;update,versions=v1alpha1,name=mhclouddnszone-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// HcloudDnsZoneCustomDefaulter struct is responsible for setting default values on the custom resource of the
+// Kind HcloudDnsZone when those are created or updated. The zone name is normalized to its lower-case
+// punycode form and the mode to upper case, so that the spec names the zone as Hetzner Cloud does.
+//
+// NOTE: The +kubebuilder:object:generate=false marker prevents controller-gen from generating DeepCopy methods,
+// as it is used only for temporary operations and does not need to be deeply copied.
+// +kubebuilder:object:generate=false
+type HcloudDnsZoneCustomDefaulter struct{}
+
+var _ webhook.CustomDefaulter = &HcloudDnsZoneCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the Kind HcloudDnsZone.
+func (d *HcloudDnsZoneCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	hclouddnszone, ok := obj.(*hcloudv1alpha1.HcloudDnsZone)
+	if !ok {
+		return fmt.Errorf("expected an HcloudDnsZone object but got %T", obj)
+	}
+	hclouddnszonelog.Info("Defaulting for HcloudDnsZone", "name", hclouddnszone.GetName())
+
+	if name, err := normalizeZoneName(hclouddnszone.Spec.Name); err == nil {
+		hclouddnszone.Spec.Name = name
+	}
+	hclouddnszone.Spec.Mode = strings.ToUpper(hclouddnszone.Spec.Mode)
+
+	// Defaults are only applied to new zones, an existing zone without a TTL keeps the TTL it has
+	// in Hetzner Cloud instead of being changed on its next update
+	if req, err := admission.RequestFromContext(ctx); err == nil && req.Operation != admissionv1.Create {
+		return nil
+	}
+	if hclouddnszone.Spec.Mode == "" {
+		hclouddnszone.Spec.Mode = defaultDnsZoneMode
+	}
+	// Read-only and observed zones mirror the zone in Hetzner Cloud, a default TTL would only be
+	// reported as drift
+	mode := syncPolicyMode(hclouddnszone.Annotations, hclouddnszone.Spec.SyncPolicy)
+	if hclouddnszone.Spec.TTL == nil && mode != hcloudv1alpha1.SyncPolicyReadOnly && mode != hcloudv1alpha1.SyncPolicyObserve {
+		ttl := defaultDnsZoneTTL
+		hclouddnszone.Spec.TTL = &ttl
+	}
+	return nil
+}
+
+// NOTE: The 'path' attribute must follow a specific pattern and should not be modified directly here.
+// Modifying the path for an invalid path can cause API server errors; failing to locate the webhook.
+// +kubebuilder:webhook:path=/validate-hcloud-bunskin-com-v1alpha1-hclouddnszone,mutating=false,failurePolicy=fail,sideEffects=None,groups=hcloud.bunskin.com,resources=hclouddnszones,verbs=create;update,versions=v1alpha1,name=vhclouddnszone-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// HcloudDnsZoneCustomValidator struct is responsible for validating the HcloudDnsZone resource
+// when it is created or updated. The zone name must be a valid domain name in its normalized
+// form and must not be claimed by another HcloudDnsZone of the cluster, name and mode cannot be
+// changed once the zone exists, and SECONDARY zones need the nameservers they are transferred from.
+//
+// NOTE: The +kubebuilder:object:generate=false marker prevents controller-gen from generating DeepCopy methods,
+// as this struct is used only for temporary operations and does not need to be deeply copied.
+// +kubebuilder:object:generate=false
+type HcloudDnsZoneCustomValidator struct {
+	// Client lists the HcloudDnsZones of the cluster to find duplicate zones
+	Client client.Reader
+}
+
+var _ webhook.CustomValidator = &HcloudDnsZoneCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type HcloudDnsZone.
+func (v *HcloudDnsZoneCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	hclouddnszone, ok := obj.(*hcloudv1alpha1.HcloudDnsZone)
+	if !ok {
+		return nil, fmt.Errorf("expected a HcloudDnsZone object but got %T", obj)
+	}
+	hclouddnszonelog.Info("Validation for HcloudDnsZone upon creation", "name", hclouddnszone.GetName())
+
+	allErrs := validateAnnotations(hclouddnszone.Annotations)
+	allErrs = append(allErrs, validateHcloudDnsZoneSpec(hclouddnszone)...)
+	duplicateErr, err := v.validateUniqueZone(ctx, hclouddnszone)
+	if err != nil {
+		return nil, err
+	}
+	if duplicateErr != nil {
+		allErrs = append(allErrs, duplicateErr)
+	}
+
+	return nil, invalidHcloudDnsZone(hclouddnszone, allErrs)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type HcloudDnsZone.
+func (v *HcloudDnsZoneCustomValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	hclouddnszone, ok := newObj.(*hcloudv1alpha1.HcloudDnsZone)
+	if !ok {
+		return nil, fmt.Errorf("expected a HcloudDnsZone object for the newObj but got %T", newObj)
+	}
+	oldHcloudDnsZone, ok := oldObj.(*hcloudv1alpha1.HcloudDnsZone)
+	if !ok {
+		return nil, fmt.Errorf("expected a HcloudDnsZone object for the oldObj but got %T", oldObj)
+	}
+	hclouddnszonelog.Info("Validation for HcloudDnsZone upon update", "name", hclouddnszone.GetName())
+
+	// Objects being deleted only lose their finalizer, which must never be blocked
+	if !hclouddnszone.DeletionTimestamp.IsZero() {
+		return nil, nil
+	}
+
+	// The spec is only validated when it changes, so that objects created before the webhook
+	// was installed can still be updated by the controller
+	allErrs := validateAnnotations(hclouddnszone.Annotations)
+	if !equality.Semantic.DeepEqual(oldHcloudDnsZone.Spec, hclouddnszone.Spec) {
+		allErrs = append(allErrs, validateHcloudDnsZoneSpec(hclouddnszone)...)
+		allErrs = append(allErrs, validateImmutableZoneFields(oldHcloudDnsZone, hclouddnszone)...)
+	}
+
+	return nil, invalidHcloudDnsZone(hclouddnszone, allErrs)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type HcloudDnsZone.
+func (v *HcloudDnsZoneCustomValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	// Deletion is not validated, the webhook is not registered for the delete verb
+	return nil, nil
+}
+
+// validateHcloudDnsZoneSpec validates the zone name, its TTL, its labels, its sync policy and the primary nameservers of the zone
+func validateHcloudDnsZoneSpec(hclouddnszone *hcloudv1alpha1.HcloudDnsZone) field.ErrorList {
+	specPath := field.NewPath("spec")
+	allErrs := validateLabels(hclouddnszone.Spec.Labels, specPath.Child("labels"))
+	allErrs = append(allErrs, validateSyncPolicy(hclouddnszone.Spec.SyncPolicy, hcloudv1alpha1.HcloudDnsZoneIgnorableFields, specPath.Child("syncPolicy"))...)
+
+	name, err := normalizeZoneName(hclouddnszone.Spec.Name)
+	switch {
+	case err != nil:
+		allErrs = append(allErrs, field.Invalid(specPath.Child("name"), hclouddnszone.Spec.Name, err.Error()))
+	case name != hclouddnszone.Spec.Name:
+		allErrs = append(allErrs, field.Invalid(specPath.Child("name"), hclouddnszone.Spec.Name, fmt.Sprintf("must be given in its normalized form %s", name)))
+	}
+
+	if ttl := hclouddnszone.Spec.TTL; ttl != nil && (*ttl < minDnsZoneTTL || *ttl > maxDnsZoneTTL) {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("ttl"), *ttl, fmt.Sprintf("must be between %d and %d seconds", minDnsZoneTTL, maxDnsZoneTTL)))
+	}
+
+	nameserversPath := specPath.Child("primaryNameservers")
+	if strings.ToUpper(hclouddnszone.Spec.Mode) == secondaryDnsZoneMode {
+		if len(hclouddnszone.Spec.PrimaryNameservers) == 0 {
+			allErrs = append(allErrs, field.Required(nameserversPath, "SECONDARY zones need the primary nameservers they are transferred from"))
+		}
+	} else if len(hclouddnszone.Spec.PrimaryNameservers) > 0 {
+		allErrs = append(allErrs, field.Forbidden(nameserversPath, "only SECONDARY zones have primary nameservers"))
+	}
+	for i, nameserver := range hclouddnszone.Spec.PrimaryNameservers {
+		if net.ParseIP(nameserver.Address) == nil {
+			allErrs = append(allErrs, field.Invalid(nameserversPath.Index(i).Child("address"), nameserver.Address, "must be an IPv4 or IPv6 address"))
+		}
+	}
+
+	return allErrs
+}
+
+// validateImmutableZoneFields rejects changes of the zone name and mode, Hetzner Cloud cannot rename
+// a zone or change its mode. Names are compared in their normalized form and an unset mode as
+// PRIMARY, so that defaulting objects created before the webhook was installed is no change.
+func validateImmutableZoneFields(oldHcloudDnsZone, hclouddnszone *hcloudv1alpha1.HcloudDnsZone) field.ErrorList {
+	var allErrs field.ErrorList
+	oldName, err := normalizeZoneName(oldHcloudDnsZone.Spec.Name)
+	if err != nil {
+		oldName = oldHcloudDnsZone.Spec.Name
+	}
+	if oldName != hclouddnszone.Spec.Name && oldHcloudDnsZone.Spec.Name != hclouddnszone.Spec.Name {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "name"), hclouddnszone.Spec.Name, "field is immutable"))
+	}
+	if dnsZoneMode(oldHcloudDnsZone) != dnsZoneMode(hclouddnszone) {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "mode"), hclouddnszone.Spec.Mode, "field is immutable"))
+	}
+	return allErrs
+}
+
+// validateUniqueZone rejects a zone already claimed by another HcloudDnsZone, as both would
+// reconcile the same cloud resource
+func (v *HcloudDnsZoneCustomValidator) validateUniqueZone(ctx context.Context, hclouddnszone *hcloudv1alpha1.HcloudDnsZone) (*field.Error, error) {
+	name, err := normalizeZoneName(hclouddnszone.Spec.Name)
+	if err != nil {
+		// Reported by validateHcloudDnsZoneSpec
+		return nil, nil
+	}
+	var hcloudDnsZones hcloudv1alpha1.HcloudDnsZoneList
+	if err := v.Client.List(ctx, &hcloudDnsZones); err != nil {
+		return nil, fmt.Errorf("failed to list HcloudDnsZones: %w", err)
+	}
+	for _, other := range hcloudDnsZones.Items {
+		if other.Namespace == hclouddnszone.Namespace && other.Name == hclouddnszone.Name {
+			continue
+		}
+		if otherName, err := normalizeZoneName(other.Spec.Name); err == nil && otherName == name {
+			return field.Duplicate(field.NewPath("spec", "name"),
+				fmt.Sprintf("%s is already managed by HcloudDnsZone %s/%s", hclouddnszone.Spec.Name, other.Namespace, other.Name)), nil
+		}
+	}
+	return nil, nil
+}
+
+// invalidHcloudDnsZone returns the Invalid error for the errors found, nil if there are none
+func invalidHcloudDnsZone(hclouddnszone *hcloudv1alpha1.HcloudDnsZone, allErrs field.ErrorList) error {
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(hcloudv1alpha1.GroupVersion.WithKind("HcloudDnsZone").GroupKind(), hclouddnszone.Name, allErrs)
+}
+
+// normalizeZoneName returns the lower-case ASCII form of a domain name without a trailing dot,
+// internationalized names are converted to punycode. Zones must have at least two labels.
+func normalizeZoneName(name string) (string, error) {
+	normalized, err := zoneNameProfile.ToASCII(strings.TrimSuffix(name, "."))
+	if err != nil {
+		return "", fmt.Errorf("must be a valid domain name such as example.com: %w", err)
+	}
+	if !strings.Contains(normalized, ".") {
+		return "", fmt.Errorf("must be a domain name with at least two labels such as example.com")
+	}
+	return normalized, nil
+}
+
+// dnsZoneMode returns the mode of the zone in upper case, PRIMARY if it is unset
+func dnsZoneMode(hclouddnszone *hcloudv1alpha1.HcloudDnsZone) string {
+	if hclouddnszone.Spec.Mode == "" {
+		return defaultDnsZoneMode
+	}
+	return strings.ToUpper(hclouddnszone.Spec.Mode)
+}
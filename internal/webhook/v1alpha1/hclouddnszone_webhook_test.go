@@ -0,0 +1,262 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	hcloudv1alpha1 "bunskin.com/hcrm/api/v1alpha1"
+)
+
+var _ = Describe("HcloudDnsZone Webhook", func() {
+	var (
+		obj       *hcloudv1alpha1.HcloudDnsZone
+		oldObj    *hcloudv1alpha1.HcloudDnsZone
+		validator HcloudDnsZoneCustomValidator
+		defaulter HcloudDnsZoneCustomDefaulter
+	)
+
+	admissionContext := func(operation admissionv1.Operation) context.Context {
+		return admission.NewContextWithRequest(ctx, admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{Operation: operation}})
+	}
+
+	BeforeEach(func() {
+		ttl := 3600
+		obj = &hcloudv1alpha1.HcloudDnsZone{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-zone",
+				Namespace: "default",
+			},
+			Spec: hcloudv1alpha1.HcloudDnsZoneSpec{
+				Name: "example.com",
+				Mode: "PRIMARY",
+				TTL:  &ttl,
+			},
+		}
+		oldObj = obj.DeepCopy()
+		validator = HcloudDnsZoneCustomValidator{
+			Client: fake.NewClientBuilder().WithScheme(scheme.Scheme).Build(),
+		}
+		defaulter = HcloudDnsZoneCustomDefaulter{}
+	})
+
+	Context("When creating HcloudDnsZone under Defaulting Webhook", func() {
+		It("Should apply the Hetzner Cloud defaults", func() {
+			obj.Spec.Mode = ""
+			obj.Spec.TTL = nil
+			Expect(defaulter.Default(admissionContext(admissionv1.Create), obj)).To(Succeed())
+			Expect(obj.Spec.Mode).To(Equal("PRIMARY"))
+			Expect(obj.Spec.TTL).To(HaveValue(Equal(3600)))
+		})
+
+		It("Should normalize the zone name and mode", func() {
+			obj.Spec.Name = "Bücher.Example."
+			obj.Spec.Mode = "Primary"
+			Expect(defaulter.Default(admissionContext(admissionv1.Create), obj)).To(Succeed())
+			Expect(obj.Spec.Name).To(Equal("xn--bcher-kva.example"))
+			Expect(obj.Spec.Mode).To(Equal("PRIMARY"))
+			Expect(validator.ValidateCreate(ctx, obj)).To(BeNil())
+		})
+
+		It("Should not default the TTL of read-only zones", func() {
+			obj.Annotations = map[string]string{syncPolicyAnnotation: "read-only"}
+			obj.Spec.TTL = nil
+			Expect(defaulter.Default(admissionContext(admissionv1.Create), obj)).To(Succeed())
+			Expect(obj.Spec.TTL).To(BeNil())
+		})
+
+		It("Should not default the TTL of zones whose sync policy mode is observe", func() {
+			obj.Annotations = map[string]string{syncPolicyAnnotation: "manage"}
+			obj.Spec.SyncPolicy = &hcloudv1alpha1.HcloudSyncPolicy{Mode: hcloudv1alpha1.SyncPolicyObserve}
+			obj.Spec.TTL = nil
+			Expect(defaulter.Default(admissionContext(admissionv1.Create), obj)).To(Succeed())
+			Expect(obj.Spec.TTL).To(BeNil())
+		})
+
+		It("Should only normalize existing zones on update", func() {
+			obj.Spec.Name = "Example.com"
+			obj.Spec.Mode = ""
+			obj.Spec.TTL = nil
+			Expect(defaulter.Default(admissionContext(admissionv1.Update), obj)).To(Succeed())
+			Expect(obj.Spec.Name).To(Equal("example.com"))
+			Expect(obj.Spec.Mode).To(BeEmpty())
+			Expect(obj.Spec.TTL).To(BeNil())
+		})
+	})
+
+	Context("When creating HcloudDnsZone under Validating Webhook", func() {
+		It("Should admit a valid zone", func() {
+			Expect(validator.ValidateCreate(ctx, obj)).To(BeNil())
+		})
+
+		DescribeTable("Should reject invalid zone names",
+			func(name, message string) {
+				obj.Spec.Name = name
+				_, err := validator.ValidateCreate(ctx, obj)
+				Expect(apierrors.IsInvalid(err)).To(BeTrue())
+				Expect(err).To(MatchError(ContainSubstring(message)))
+			},
+			Entry("empty", "", "must be a"),
+			Entry("top-level domain", "com", "at least two labels"),
+			Entry("underscore", "foo_bar.com", "must be a valid domain name"),
+			Entry("leading hyphen", "-example.com", "must be a valid domain name"),
+			Entry("empty label", "example..com", "must be a valid domain name"),
+			Entry("invalid punycode", "xn--zz.com", "must be a valid domain name"),
+			Entry("upper case", "Example.com", "must be given in its normalized form example.com"),
+			Entry("unicode", "bücher.de", "must be given in its normalized form xn--bcher-kva.de"),
+		)
+
+		It("Should reject TTLs outside the limits of Hetzner Cloud", func() {
+			for _, ttl := range []int{0, 59, 2147483648} {
+				obj.Spec.TTL = &ttl
+				_, err := validator.ValidateCreate(ctx, obj)
+				Expect(err).To(MatchError(ContainSubstring("spec.ttl: Invalid value")), "%d", ttl)
+			}
+			for _, ttl := range []int{60, 86400, 2147483647} {
+				obj.Spec.TTL = &ttl
+				Expect(validator.ValidateCreate(ctx, obj)).To(BeNil(), "%d", ttl)
+			}
+		})
+
+		It("Should require primary nameservers for secondary zones only", func() {
+			obj.Spec.Mode = "SECONDARY"
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.primaryNameservers: Required value")))
+
+			obj.Spec.PrimaryNameservers = []hcloudv1alpha1.HcloudDnsZonePrimaryNameserver{
+				{Address: "192.0.2.53"}, {Address: "2001:db8::53", Port: 5353}, {Address: "ns1.example.net"},
+			}
+			_, err = validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring(`spec.primaryNameservers[2].address: Invalid value: "ns1.example.net"`)))
+
+			obj.Spec.PrimaryNameservers = obj.Spec.PrimaryNameservers[:2]
+			Expect(validator.ValidateCreate(ctx, obj)).To(BeNil())
+
+			obj.Spec.Mode = "PRIMARY"
+			_, err = validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.primaryNameservers: Forbidden")))
+		})
+
+		It("Should reject unknown sync policies", func() {
+			obj.Annotations = map[string]string{syncPolicyAnnotation: "delete"}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err).To(MatchError(ContainSubstring(`supported values: "manage", "read-only", "observe", "create-only", "update-only", "orphan"`)))
+		})
+
+		It("Should reject ignored fields zones do not have", func() {
+			obj.Spec.SyncPolicy = &hcloudv1alpha1.HcloudSyncPolicy{IgnoreFields: []string{"ipRange"}}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err).To(MatchError(ContainSubstring(`spec.syncPolicy.ignoreFields[0]: Unsupported value: "ipRange"`)))
+
+			obj.Spec.SyncPolicy.IgnoreFields = []string{"ttl"}
+			Expect(validator.ValidateCreate(ctx, obj)).To(BeNil())
+		})
+
+		It("Should reject an external-id annotation that is not a Hetzner Cloud ID", func() {
+			obj.Annotations = map[string]string{hcloudv1alpha1.ExternalIDAnnotation: "example.com"}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err).To(MatchError(ContainSubstring("must be the ID of a Hetzner Cloud resource")))
+		})
+
+		It("Should reject labels reserved for the ownership of cloud resources", func() {
+			obj.Spec.Labels = map[string]string{"hcloud.bunskin.com/cluster-id": "other"}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err).To(MatchError(ContainSubstring("spec.labels[hcloud.bunskin.com/cluster-id]")))
+		})
+
+		It("Should reject a second zone with the same name in any namespace", func() {
+			existing := obj.DeepCopy()
+			existing.Name = "existing-zone"
+			existing.Namespace = "other"
+			existing.Spec.Name = "Example.com."
+			validator.Client = fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(existing).Build()
+
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err).To(MatchError(ContainSubstring("example.com is already managed by HcloudDnsZone other/existing-zone")))
+
+			obj.Spec.Name = "example.org"
+			Expect(validator.ValidateCreate(ctx, obj)).To(BeNil())
+		})
+	})
+
+	Context("When updating HcloudDnsZone under Validating Webhook", func() {
+		It("Should admit changing the TTL", func() {
+			ttl := 600
+			obj.Spec.TTL = &ttl
+			Expect(validator.ValidateUpdate(ctx, oldObj, obj)).To(BeNil())
+		})
+
+		It("Should reject changing the name or the mode", func() {
+			obj.Spec.Name = "example.org"
+			obj.Spec.Mode = "SECONDARY"
+			obj.Spec.PrimaryNameservers = []hcloudv1alpha1.HcloudDnsZonePrimaryNameserver{{Address: "192.0.2.53"}}
+			_, err := validator.ValidateUpdate(ctx, oldObj, obj)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err).To(MatchError(ContainSubstring(`spec.name: Invalid value: "example.org": field is immutable`)))
+			Expect(err).To(MatchError(ContainSubstring(`spec.mode: Invalid value: "SECONDARY": field is immutable`)))
+		})
+
+		It("Should admit defaulting zones created before the webhook was installed", func() {
+			oldObj.Spec.Name = "Example.com"
+			oldObj.Spec.Mode = ""
+			obj.Spec.Name = "example.com"
+			obj.Spec.Mode = "PRIMARY"
+			Expect(validator.ValidateUpdate(ctx, oldObj, obj)).To(BeNil())
+		})
+
+		It("Should admit metadata changes of zones with an invalid spec", func() {
+			oldObj.Spec.Name = "invalid_zone"
+			obj = oldObj.DeepCopy()
+			obj.Finalizers = []string{"hcloud.bunskin.com/finalizer"}
+			Expect(validator.ValidateUpdate(ctx, oldObj, obj)).To(BeNil())
+		})
+	})
+
+	Context("When the webhook is installed", func() {
+		It("Should default and validate zones in the API server", func() {
+			zone := &hcloudv1alpha1.HcloudDnsZone{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-webhook-zone", Namespace: "default"},
+				Spec:       hcloudv1alpha1.HcloudDnsZoneSpec{Name: "Webhook.Example.com", Mode: "Primary"},
+			}
+			Expect(k8sClient.Create(ctx, zone)).To(Succeed())
+			Expect(zone.Spec.Name).To(Equal("webhook.example.com"))
+			Expect(zone.Spec.Mode).To(Equal("PRIMARY"))
+			Expect(zone.Spec.TTL).To(HaveValue(Equal(3600)))
+
+			zone.Spec.Name = "other.example.com"
+			err := k8sClient.Update(ctx, zone)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err).To(MatchError(ContainSubstring("admission webhook \"vhclouddnszone-v1alpha1.kb.io\" denied the request")))
+
+			Expect(k8sClient.Delete(ctx, zone)).To(Succeed())
+		})
+	})
+})
@@ -0,0 +1,288 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	hcloudv1alpha1 "bunskin.com/hcrm/api/v1alpha1"
+)
+
+const (
+	// minNetworkPrefixLength is the prefix length of the largest network, a network may span a whole RFC1918 range
+	minNetworkPrefixLength = 8
+	// maxNetworkPrefixLength is the prefix length of the smallest network Hetzner Cloud accepts
+	maxNetworkPrefixLength = 24
+	// maxSubnetPrefixLength is the prefix length of the smallest subnet Hetzner Cloud accepts
+	maxSubnetPrefixLength = 30
+)
+
+// privateRanges are the RFC1918 ranges Hetzner Cloud networks have to lie in
+var privateRanges = []*net.IPNet{
+	mustParseCIDR("10.0.0.0/8"),
+	mustParseCIDR("172.16.0.0/12"),
+	mustParseCIDR("192.168.0.0/16"),
+}
+
+// log is for logging in this package.
+var hcloudnetworklog = logf.Log.WithName("hcloudnetwork-resource")
+
+// SetupHcloudNetworkWebhookWithManager registers the webhook for HcloudNetwork in the manager.
+func SetupHcloudNetworkWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&hcloudv1alpha1.HcloudNetwork{}).
+		WithValidator(&HcloudNetworkCustomValidator{Client: mgr.GetClient()}).
+		Complete()
+}
+
+// NOTE: The 'path' attribute must follow a specific pattern and should not be modified directly here.
+// Modifying the path for an invalid path can cause API server errors; failing to locate the webhook.
+// +kubebuilder:webhook:path=/validate-hcloud-bunskin-com-v1alpha1-hcloudnetwork,mutating=false,failurePolicy=fail,sideEffects=None,groups=hcloud.bunskin.com,resources=hcloudnetworks,verbs=create;update,versions=v1alpha1,name=vhcloudnetwork-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// HcloudNetworkCustomValidator struct is responsible for validating the HcloudNetwork resource
+// when it is created or updated. It checks what the CRD schema cannot express: the IP ranges
+// are parsed and checked against the ranges Hetzner Cloud accepts, and the Hetzner network name
+// must not be claimed by another HcloudNetwork of the cluster.
+//
+// NOTE: The +kubebuilder:object:generate=false marker prevents controller-gen from generating DeepCopy methods,
+// as this struct is used only for temporary operations and does not need to be deeply copied.
+// +kubebuilder:object:generate=false
+type HcloudNetworkCustomValidator struct {
+	// Client lists the HcloudNetworks of the cluster to find duplicate network names
+	Client client.Reader
+}
+
+var _ webhook.CustomValidator = &HcloudNetworkCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type HcloudNetwork.
+func (v *HcloudNetworkCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	hcloudnetwork, ok := obj.(*hcloudv1alpha1.HcloudNetwork)
+	if !ok {
+		return nil, fmt.Errorf("expected a HcloudNetwork object but got %T", obj)
+	}
+	hcloudnetworklog.Info("Validation for HcloudNetwork upon creation", "name", hcloudnetwork.GetName())
+
+	allErrs := validateAnnotations(hcloudnetwork.Annotations)
+	allErrs = append(allErrs, validateHcloudNetworkSpec(hcloudnetwork)...)
+	duplicateErr, err := v.validateUniqueName(ctx, hcloudnetwork)
+	if err != nil {
+		return nil, err
+	}
+	if duplicateErr != nil {
+		allErrs = append(allErrs, duplicateErr)
+	}
+
+	return nil, invalidHcloudNetwork(hcloudnetwork, allErrs)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type HcloudNetwork.
+func (v *HcloudNetworkCustomValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	hcloudnetwork, ok := newObj.(*hcloudv1alpha1.HcloudNetwork)
+	if !ok {
+		return nil, fmt.Errorf("expected a HcloudNetwork object for the newObj but got %T", newObj)
+	}
+	oldHcloudNetwork, ok := oldObj.(*hcloudv1alpha1.HcloudNetwork)
+	if !ok {
+		return nil, fmt.Errorf("expected a HcloudNetwork object for the oldObj but got %T", oldObj)
+	}
+	hcloudnetworklog.Info("Validation for HcloudNetwork upon update", "name", hcloudnetwork.GetName())
+
+	// Objects being deleted only lose their finalizer, which must never be blocked
+	if !hcloudnetwork.DeletionTimestamp.IsZero() {
+		return nil, nil
+	}
+
+	// The spec is only validated when it changes, so that objects created before the webhook
+	// was installed can still be updated by the controller
+	// The duplicate name check only runs on create, the CEL rule on spec.name rejects renames
+	allErrs := validateAnnotations(hcloudnetwork.Annotations)
+	if !equality.Semantic.DeepEqual(oldHcloudNetwork.Spec, hcloudnetwork.Spec) {
+		allErrs = append(allErrs, validateHcloudNetworkSpec(hcloudnetwork)...)
+		allErrs = append(allErrs, validateIPRangeChange(oldHcloudNetwork, hcloudnetwork)...)
+	}
+
+	return nil, invalidHcloudNetwork(hcloudnetwork, allErrs)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type HcloudNetwork.
+func (v *HcloudNetworkCustomValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	// Deletion is not validated, the webhook is not registered for the delete verb
+	return nil, nil
+}
+
+// validateHcloudNetworkSpec validates the labels, the sync policy and the IP ranges of the network, its subnets and its routes
+func validateHcloudNetworkSpec(hcloudnetwork *hcloudv1alpha1.HcloudNetwork) field.ErrorList {
+	specPath := field.NewPath("spec")
+	allErrs := validateLabels(hcloudnetwork.Spec.Labels, specPath.Child("labels"))
+	allErrs = append(allErrs, validateSyncPolicy(hcloudnetwork.Spec.SyncPolicy, hcloudv1alpha1.HcloudNetworkIgnorableFields, specPath.Child("syncPolicy"))...)
+	ipRange, err := parseNetworkRange(hcloudnetwork.Spec.IpRange, specPath.Child("ipRange"), minNetworkPrefixLength, maxNetworkPrefixLength)
+	if err != nil {
+		allErrs = append(allErrs, err)
+	} else if !isPrivateRange(ipRange) {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("ipRange"), hcloudnetwork.Spec.IpRange,
+			"must lie in one of the private ranges of RFC1918: 10.0.0.0/8, 172.16.0.0/12 or 192.168.0.0/16"))
+	}
+
+	subnets := make([]*net.IPNet, len(hcloudnetwork.Spec.Subnets))
+	for i, subnet := range hcloudnetwork.Spec.Subnets {
+		subnetPath := specPath.Child("subnets").Index(i).Child("ipRange")
+		subnetRange, err := parseNetworkRange(subnet.IpRange, subnetPath, 0, maxSubnetPrefixLength)
+		if err != nil {
+			allErrs = append(allErrs, err)
+			continue
+		}
+		if ipRange != nil && !containsRange(ipRange, subnetRange) {
+			allErrs = append(allErrs, field.Invalid(subnetPath, subnet.IpRange, fmt.Sprintf("must lie inside spec.ipRange %s", hcloudnetwork.Spec.IpRange)))
+		}
+		for j, other := range subnets[:i] {
+			if other != nil && (other.Contains(subnetRange.IP) || subnetRange.Contains(other.IP)) {
+				allErrs = append(allErrs, field.Invalid(subnetPath, subnet.IpRange, fmt.Sprintf("overlaps with spec.subnets[%d].ipRange %s", j, other)))
+			}
+		}
+		subnets[i] = subnetRange
+	}
+
+	for i, route := range hcloudnetwork.Spec.Routes {
+		routePath := specPath.Child("routes").Index(i)
+		if _, err := parseNetworkRange(route.Destination, routePath.Child("destination"), 0, 32); err != nil {
+			allErrs = append(allErrs, err)
+		}
+		gateway := net.ParseIP(route.Gateway).To4()
+		switch {
+		case gateway == nil:
+			allErrs = append(allErrs, field.Invalid(routePath.Child("gateway"), route.Gateway, "must be an IPv4 address"))
+		case ipRange != nil && !ipRange.Contains(gateway):
+			allErrs = append(allErrs, field.Invalid(routePath.Child("gateway"), route.Gateway, fmt.Sprintf("must lie inside spec.ipRange %s", hcloudnetwork.Spec.IpRange)))
+		}
+	}
+
+	return allErrs
+}
+
+// validateIPRangeChange rejects a new IP range that no longer contains the subnets of the network.
+// Both the subnets of the previous spec and the subnets observed in Hetzner Cloud are checked, as
+// subnets that are not managed through the spec still exist in the network.
+func validateIPRangeChange(oldHcloudNetwork, hcloudnetwork *hcloudv1alpha1.HcloudNetwork) field.ErrorList {
+	if oldHcloudNetwork.Spec.IpRange == hcloudnetwork.Spec.IpRange {
+		return nil
+	}
+	_, ipRange, err := net.ParseCIDR(hcloudnetwork.Spec.IpRange)
+	if err != nil {
+		// Reported by validateHcloudNetworkSpec
+		return nil
+	}
+
+	existing := make([]string, 0, len(oldHcloudNetwork.Spec.Subnets)+len(oldHcloudNetwork.Status.Subnets))
+	for _, subnet := range oldHcloudNetwork.Spec.Subnets {
+		existing = append(existing, subnet.IpRange)
+	}
+	for _, subnet := range oldHcloudNetwork.Status.Subnets {
+		existing = append(existing, subnet.IpRange)
+	}
+
+	var allErrs field.ErrorList
+	seen := make(map[string]bool)
+	for _, subnet := range existing {
+		_, subnetRange, err := net.ParseCIDR(subnet)
+		if err != nil || seen[subnet] || containsRange(ipRange, subnetRange) {
+			continue
+		}
+		seen[subnet] = true
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "ipRange"), hcloudnetwork.Spec.IpRange,
+			fmt.Sprintf("must contain the existing subnet %s", subnet)))
+	}
+	return allErrs
+}
+
+// validateUniqueName rejects a network whose Hetzner network name is already claimed by another
+// HcloudNetwork, as both would reconcile the same cloud resource
+func (v *HcloudNetworkCustomValidator) validateUniqueName(ctx context.Context, hcloudnetwork *hcloudv1alpha1.HcloudNetwork) (*field.Error, error) {
+	var hcloudNetworks hcloudv1alpha1.HcloudNetworkList
+	if err := v.Client.List(ctx, &hcloudNetworks); err != nil {
+		return nil, fmt.Errorf("failed to list HcloudNetworks: %w", err)
+	}
+	for _, other := range hcloudNetworks.Items {
+		if other.Namespace == hcloudnetwork.Namespace && other.Name == hcloudnetwork.Name {
+			continue
+		}
+		if other.Spec.Name == hcloudnetwork.Spec.Name {
+			return field.Duplicate(field.NewPath("spec", "name"),
+				fmt.Sprintf("%s is already managed by HcloudNetwork %s/%s", hcloudnetwork.Spec.Name, other.Namespace, other.Name)), nil
+		}
+	}
+	return nil, nil
+}
+
+// invalidHcloudNetwork returns the Invalid error for the errors found, nil if there are none
+func invalidHcloudNetwork(hcloudnetwork *hcloudv1alpha1.HcloudNetwork, allErrs field.ErrorList) error {
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(hcloudv1alpha1.GroupVersion.WithKind("HcloudNetwork").GroupKind(), hcloudnetwork.Name, allErrs)
+}
+
+// parseNetworkRange parses an IPv4 CIDR given by its network address, whose prefix length lies
+// between minPrefix and maxPrefix
+func parseNetworkRange(value string, path *field.Path, minPrefix, maxPrefix int) (*net.IPNet, *field.Error) {
+	ip, ipRange, err := net.ParseCIDR(value)
+	if err != nil || ip.To4() == nil {
+		return nil, field.Invalid(path, value, "must be an IPv4 CIDR such as 10.0.0.0/16")
+	}
+	if !ip.Equal(ipRange.IP) {
+		return nil, field.Invalid(path, value, fmt.Sprintf("must be given by its network address %s", ipRange))
+	}
+	if prefix, _ := ipRange.Mask.Size(); prefix < minPrefix || prefix > maxPrefix {
+		return nil, field.Invalid(path, value, fmt.Sprintf("prefix length must be between /%d and /%d", minPrefix, maxPrefix))
+	}
+	return ipRange, nil
+}
+
+// isPrivateRange reports whether the range lies in one of the RFC1918 ranges
+func isPrivateRange(ipRange *net.IPNet) bool {
+	for _, private := range privateRanges {
+		if containsRange(private, ipRange) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsRange reports whether inner lies completely inside outer
+func containsRange(outer, inner *net.IPNet) bool {
+	outerPrefix, _ := outer.Mask.Size()
+	innerPrefix, _ := inner.Mask.Size()
+	return innerPrefix >= outerPrefix && outer.Contains(inner.IP)
+}
+
+func mustParseCIDR(value string) *net.IPNet {
+	_, ipRange, err := net.ParseCIDR(value)
+	if err != nil {
+		panic(err)
+	}
+	return ipRange
+}
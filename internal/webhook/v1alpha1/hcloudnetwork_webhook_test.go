@@ -0,0 +1,225 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	hcloudv1alpha1 "bunskin.com/hcrm/api/v1alpha1"
+)
+
+var _ = Describe("HcloudNetwork Webhook", func() {
+	var (
+		obj       *hcloudv1alpha1.HcloudNetwork
+		oldObj    *hcloudv1alpha1.HcloudNetwork
+		validator HcloudNetworkCustomValidator
+	)
+
+	BeforeEach(func() {
+		obj = &hcloudv1alpha1.HcloudNetwork{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-network",
+				Namespace: "default",
+			},
+			Spec: hcloudv1alpha1.HcloudNetworkSpec{
+				Name:    "test-network",
+				IpRange: "10.0.0.0/16",
+				Subnets: []hcloudv1alpha1.HcloudNetworkSubnet{
+					{Type: "cloud", NetworkZone: "eu-central", IpRange: "10.0.1.0/24"},
+				},
+				Routes: []hcloudv1alpha1.HcloudNetworkRoute{
+					{Destination: "10.100.0.0/24", Gateway: "10.0.1.2"},
+				},
+			},
+		}
+		oldObj = obj.DeepCopy()
+		validator = HcloudNetworkCustomValidator{
+			Client: fake.NewClientBuilder().WithScheme(scheme.Scheme).Build(),
+		}
+	})
+
+	Context("When creating HcloudNetwork under Validating Webhook", func() {
+		It("Should admit a valid network", func() {
+			Expect(validator.ValidateCreate(ctx, obj)).To(BeNil())
+		})
+
+		DescribeTable("Should reject invalid IP ranges",
+			func(ipRange, message string) {
+				obj.Spec.IpRange = ipRange
+				obj.Spec.Subnets = nil
+				obj.Spec.Routes = nil
+				_, err := validator.ValidateCreate(ctx, obj)
+				Expect(apierrors.IsInvalid(err)).To(BeTrue())
+				Expect(err).To(MatchError(ContainSubstring(message)))
+			},
+			Entry("out of range octets", "999.1.1.1/99", "must be an IPv4 CIDR"),
+			Entry("IPv6", "fd00::/64", "must be an IPv4 CIDR"),
+			Entry("host bits set", "10.0.0.1/16", "must be given by its network address 10.0.0.0/16"),
+			Entry("public range", "100.64.0.0/16", "RFC1918"),
+			Entry("partly private range", "172.0.0.0/8", "RFC1918"),
+			Entry("too small network", "10.0.0.0/25", "prefix length must be between /8 and /24"),
+		)
+
+		It("Should accept every RFC1918 range", func() {
+			obj.Spec.Subnets = nil
+			obj.Spec.Routes = nil
+			for _, ipRange := range []string{"10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/24"} {
+				obj.Spec.IpRange = ipRange
+				Expect(validator.ValidateCreate(ctx, obj)).To(BeNil(), ipRange)
+			}
+		})
+
+		It("Should reject subnets outside the network or overlapping each other", func() {
+			obj.Spec.Subnets = []hcloudv1alpha1.HcloudNetworkSubnet{
+				{Type: "cloud", NetworkZone: "eu-central", IpRange: "10.1.0.0/24"},
+				{Type: "cloud", NetworkZone: "eu-central", IpRange: "10.0.0.0/23"},
+				{Type: "cloud", NetworkZone: "eu-central", IpRange: "10.0.1.0/24"},
+				{Type: "cloud", NetworkZone: "eu-central", IpRange: "10.0.2.0/31"},
+			}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err).To(MatchError(ContainSubstring("spec.subnets[0].ipRange: Invalid value: \"10.1.0.0/24\": must lie inside spec.ipRange")))
+			Expect(err).To(MatchError(ContainSubstring("spec.subnets[2].ipRange: Invalid value: \"10.0.1.0/24\": overlaps with spec.subnets[1].ipRange")))
+			Expect(err).To(MatchError(ContainSubstring("spec.subnets[3].ipRange: Invalid value: \"10.0.2.0/31\": prefix length")))
+		})
+
+		It("Should reject route gateways outside the network", func() {
+			obj.Spec.Routes = []hcloudv1alpha1.HcloudNetworkRoute{{Destination: "10.100.0.0/24", Gateway: "192.168.0.1"}}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.routes[0].gateway")))
+		})
+
+		It("Should reject unknown sync policies", func() {
+			obj.Annotations = map[string]string{syncPolicyAnnotation: "delete"}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err).To(MatchError(ContainSubstring(`supported values: "manage", "read-only", "observe", "create-only", "update-only", "orphan"`)))
+
+			for _, policy := range hcloudv1alpha1.SyncPolicyModes {
+				obj.Annotations[syncPolicyAnnotation] = string(policy)
+				Expect(validator.ValidateCreate(ctx, obj)).To(BeNil(), string(policy))
+			}
+		})
+
+		It("Should reject ignored fields networks do not have", func() {
+			obj.Spec.SyncPolicy = &hcloudv1alpha1.HcloudSyncPolicy{
+				Mode:         hcloudv1alpha1.SyncPolicyManage,
+				IgnoreFields: []string{"labels", "ttl"},
+			}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err).To(MatchError(ContainSubstring(`spec.syncPolicy.ignoreFields[1]: Unsupported value: "ttl"`)))
+
+			obj.Spec.SyncPolicy.IgnoreFields = []string{"labels", "ipRange"}
+			Expect(validator.ValidateCreate(ctx, obj)).To(BeNil())
+		})
+
+		It("Should reject an external-id annotation that is not a Hetzner Cloud ID", func() {
+			for _, id := range []string{"legacy-network", "0", "-1"} {
+				obj.Annotations = map[string]string{hcloudv1alpha1.ExternalIDAnnotation: id}
+				_, err := validator.ValidateCreate(ctx, obj)
+				Expect(apierrors.IsInvalid(err)).To(BeTrue(), id)
+				Expect(err).To(MatchError(ContainSubstring("must be the ID of a Hetzner Cloud resource")), id)
+			}
+
+			obj.Annotations[hcloudv1alpha1.ExternalIDAnnotation] = "4242"
+			Expect(validator.ValidateCreate(ctx, obj)).To(BeNil())
+		})
+
+		It("Should reject labels reserved for the ownership of cloud resources", func() {
+			obj.Spec.Labels = map[string]string{"env": "test", "hcloud.bunskin.com/owner-uid": "1234"}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err).To(MatchError(ContainSubstring("spec.labels[hcloud.bunskin.com/owner-uid]")))
+		})
+
+		It("Should reject takeover annotations other than true and false", func() {
+			obj.Annotations = map[string]string{hcloudv1alpha1.TakeoverAnnotation: "yes"}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err).To(MatchError(ContainSubstring(`supported values: "true", "false"`)))
+
+			obj.Annotations[hcloudv1alpha1.TakeoverAnnotation] = "true"
+			Expect(validator.ValidateCreate(ctx, obj)).To(BeNil())
+		})
+
+		It("Should reject a second network with the same Hetzner network name", func() {
+			existing := obj.DeepCopy()
+			existing.Name = "existing-network"
+			existing.Namespace = "other"
+			validator.Client = fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(existing).Build()
+
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err).To(MatchError(ContainSubstring("test-network is already managed by HcloudNetwork other/existing-network")))
+
+			obj.Spec.Name = "another-network"
+			Expect(validator.ValidateCreate(ctx, obj)).To(BeNil())
+		})
+	})
+
+	Context("When updating HcloudNetwork under Validating Webhook", func() {
+		It("Should admit growing the IP range", func() {
+			obj.Spec.IpRange = "10.0.0.0/15"
+			Expect(validator.ValidateUpdate(ctx, oldObj, obj)).To(BeNil())
+		})
+
+		It("Should reject shrinking the IP range below the existing subnets", func() {
+			oldObj.Status.Subnets = []hcloudv1alpha1.HcloudNetworkSubnetStatus{
+				{Type: "cloud", NetworkZone: "eu-central", IpRange: "10.0.200.0/24"},
+			}
+			obj.Spec.IpRange = "10.0.0.0/17"
+			_, err := validator.ValidateUpdate(ctx, oldObj, obj)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err).To(MatchError(ContainSubstring("must contain the existing subnet 10.0.200.0/24")))
+
+			obj.Spec.IpRange = "10.0.0.0/24"
+			_, err = validator.ValidateUpdate(ctx, oldObj, obj)
+			Expect(err).To(MatchError(ContainSubstring("must contain the existing subnet 10.0.1.0/24")))
+		})
+
+		It("Should admit metadata changes of networks with an invalid spec", func() {
+			oldObj.Spec.IpRange = "100.64.0.0/16"
+			oldObj.Spec.Subnets = nil
+			oldObj.Spec.Routes = nil
+			obj = oldObj.DeepCopy()
+			obj.Finalizers = []string{"hcloud.bunskin.com/finalizer"}
+			Expect(validator.ValidateUpdate(ctx, oldObj, obj)).To(BeNil())
+
+			obj.Annotations = map[string]string{syncPolicyAnnotation: "invalid"}
+			_, err := validator.ValidateUpdate(ctx, oldObj, obj)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+		})
+	})
+
+	Context("When the webhook is installed", func() {
+		It("Should reject invalid networks in the API server", func() {
+			obj.Name = "test-webhook-network"
+			obj.Spec.IpRange = "999.1.1.1/16"
+			obj.Spec.Subnets = nil
+			obj.Spec.Routes = nil
+			err := k8sClient.Create(ctx, obj)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err).To(MatchError(ContainSubstring("admission webhook \"vhcloudnetwork-v1alpha1.kb.io\" denied the request")))
+		})
+	})
+})
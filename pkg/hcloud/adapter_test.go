@@ -0,0 +1,267 @@
+package hcloud
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+	"github.com/hetznercloud/hcloud-go/v2/hcloud/schema"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"bunskin.com/hcrm/pkg/hcloud/fake"
+)
+
+var _ = Describe("Adapters against the fake Hetzner Cloud API", func() {
+	var server *fake.Server
+	var opts []hcloud.ClientOption
+	var token string
+
+	BeforeEach(func() {
+		server = fake.NewServer()
+		opts = []hcloud.ClientOption{
+			hcloud.WithEndpoint(server.URL),
+			hcloud.WithPollOpts(hcloud.PollOpts{BackoffFunc: hcloud.ConstantBackoff(time.Millisecond)}),
+		}
+		// Every test uses its own project so that the shared rate limiter does not carry over
+		token = "adapter-token " + CurrentSpecReport().FullText()
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("NetworkClient", func() {
+		var client NetworkClient
+
+		BeforeEach(func() {
+			client = NewNetworkClient(token, opts...)
+		})
+
+		It("should create and look up networks", func() {
+			created, _, err := client.CreateNetwork(context.Background(), "test-network", "10.0.0.0/16", map[string]string{"env": "test"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(created.IPRange.String()).To(Equal("10.0.0.0/16"))
+
+			byName, _, err := client.GetNetworkByName(context.Background(), "test-network")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(byName.ID).To(Equal(created.ID))
+			Expect(byName.Labels).To(Equal(map[string]string{"env": "test"}))
+
+			missing, _, err := client.GetNetworkById(context.Background(), created.ID+100)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(missing).To(BeNil())
+		})
+
+		It("should decode uniqueness errors", func() {
+			_, _, err := client.CreateNetwork(context.Background(), "test-network", "10.0.0.0/16", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, _, err = client.CreateNetwork(context.Background(), "test-network", "10.1.0.0/16", nil)
+			Expect(hcloud.IsError(err, hcloud.ErrorCodeUniquenessError)).To(BeTrue())
+		})
+
+		It("should wait for subnet and route actions and return the refreshed network", func() {
+			created, _, err := client.CreateNetwork(context.Background(), "test-network", "10.0.0.0/16", nil)
+			Expect(err).NotTo(HaveOccurred())
+			server.SetActionPolls(3)
+
+			_, subnet, _ := net.ParseCIDR("10.0.1.0/24")
+			network, _, err := client.AddNetworkSubnet(context.Background(), created, hcloud.NetworkSubnet{
+				Type:        hcloud.NetworkSubnetTypeCloud,
+				IPRange:     subnet,
+				NetworkZone: hcloud.NetworkZoneEUCentral,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(network.Subnets).To(HaveLen(1))
+			Expect(network.Subnets[0].Gateway.String()).To(Equal("10.0.0.1"))
+
+			_, destination, _ := net.ParseCIDR("10.100.0.0/24")
+			network, _, err = client.AddNetworkRoute(context.Background(), network, hcloud.NetworkRoute{
+				Destination: destination,
+				Gateway:     net.ParseIP("10.0.1.2"),
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(network.Routes).To(HaveLen(1))
+		})
+
+		It("should return the running CIDR change action", func() {
+			created, _, err := client.CreateNetwork(context.Background(), "test-network", "10.0.0.0/16", nil)
+			Expect(err).NotTo(HaveOccurred())
+			server.SetActionPolls(2)
+
+			action, _, err := client.UpdateNetworkCidr(context.Background(), created, "10.0.0.0/15")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(action.Status).To(Equal(hcloud.ActionStatusRunning))
+
+			action, _, err = client.GetAction(context.Background(), action.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(action.Status).To(Equal(hcloud.ActionStatusRunning))
+			Expect(action.Progress).To(BeNumerically(">", 0))
+
+			action, _, err = client.GetAction(context.Background(), action.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(action.Status).To(Equal(hcloud.ActionStatusSuccess))
+
+			stored, _ := server.Network(created.ID)
+			Expect(stored.IPRange).To(Equal("10.0.0.0/15"))
+		})
+
+		It("should list networks across pages", func() {
+			for i := range 60 {
+				server.AddNetwork(schema.Network{Name: fmt.Sprintf("network-%d", i), IPRange: "10.0.0.0/16"})
+			}
+
+			networks, err := client.ListNetworks(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(networks).To(HaveLen(60))
+			Expect(server.Requests("GET /networks")).To(Equal(2))
+		})
+
+		It("should list the servers attached to a network with their private addresses", func() {
+			id := server.AddNetwork(schema.Network{Name: "test-network", IPRange: "10.0.0.0/16"})
+			serverID := server.AddServer(schema.Server{
+				Name:       "worker-1",
+				PrivateNet: []schema.ServerPrivateNet{{Network: id, IP: "10.0.1.2"}},
+			})
+
+			network, _, err := client.GetNetworkById(context.Background(), id)
+			Expect(err).NotTo(HaveOccurred())
+			servers, err := client.ListNetworkServers(context.Background(), network)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(servers).To(HaveLen(1))
+			Expect(servers[0].ID).To(Equal(serverID))
+			Expect(servers[0].Name).To(Equal("worker-1"))
+			Expect(servers[0].PrivateNet).To(HaveLen(1))
+			Expect(servers[0].PrivateNet[0].IP.String()).To(Equal("10.0.1.2"))
+
+			Expect(server.RemoveServer(serverID)).To(BeTrue())
+			network, _, err = client.GetNetworkById(context.Background(), id)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(network.Servers).To(BeEmpty())
+		})
+
+		It("should verify the token with a single request", func() {
+			for i := range 60 {
+				server.AddNetwork(schema.Network{Name: fmt.Sprintf("network-%d", i), IPRange: "10.0.0.0/16"})
+			}
+
+			_, err := client.VerifyToken(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(server.Requests("GET /networks")).To(Equal(1))
+		})
+
+		It("should delete networks", func() {
+			created, _, err := client.CreateNetwork(context.Background(), "test-network", "10.0.0.0/16", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = client.DeleteNetwork(context.Background(), created)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(server.Networks()).To(BeEmpty())
+
+			_, err = client.DeleteNetwork(context.Background(), created)
+			Expect(hcloud.IsError(err, hcloud.ErrorCodeNotFound)).To(BeTrue())
+		})
+
+		It("should report an exhausted rate limit", func() {
+			server.SetRateLimit(3600, 0)
+			client = NewNetworkClient(token, append(opts, hcloud.WithRetryOpts(hcloud.RetryOpts{BackoffFunc: hcloud.ConstantBackoff(0), MaxRetries: 0}))...)
+
+			_, _, err := client.GetNetworkById(context.Background(), 1)
+			Expect(hcloud.IsError(err, hcloud.ErrorCodeRateLimitExceeded)).To(BeTrue())
+			_, ok := RateLimitReset(err)
+			Expect(ok).To(BeTrue())
+		})
+	})
+
+	Describe("DnsZoneClient", func() {
+		var client DnsZoneClient
+
+		BeforeEach(func() {
+			client = NewDnsZoneClient(token, opts...)
+		})
+
+		It("should create zones with their SOA and NS RRSets", func() {
+			ttl := 600
+			result, _, err := client.CreateZone(context.Background(), "example.com", "PRIMARY", &ttl, nil, map[string]string{"env": "test"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Zone.TTL).To(Equal(600))
+			Expect(result.Action.Status).To(Equal(hcloud.ActionStatusSuccess))
+
+			zone, _, err := client.GetZoneByName(context.Background(), "example.com")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(zone.ID).To(Equal(result.Zone.ID))
+
+			rrsets, err := client.ListRRSets(context.Background(), zone)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(rrsets).To(HaveLen(2))
+
+			_, _, err = client.CreateZone(context.Background(), "example.com", "primary", nil, nil, nil)
+			Expect(hcloud.IsError(err, hcloud.ErrorCodeUniquenessError)).To(BeTrue())
+		})
+
+		It("should manage RRSets and wait for their actions", func() {
+			result, _, err := client.CreateZone(context.Background(), "example.com", "primary", nil, nil, nil)
+			Expect(err).NotTo(HaveOccurred())
+			server.SetActionPolls(2)
+
+			rrset, _, err := client.CreateRRSet(context.Background(), result.Zone, "www", "A", nil,
+				[]hcloud.ZoneRRSetRecord{{Value: "192.0.2.1"}}, map[string]string{"env": "test"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(rrset.Records).To(HaveLen(1))
+
+			rrset, _, err = client.UpdateRRSetRecords(context.Background(), rrset, []hcloud.ZoneRRSetRecord{{Value: "192.0.2.1"}, {Value: "192.0.2.2"}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(rrset.Records).To(HaveLen(2))
+
+			ttl := 300
+			rrset, _, err = client.UpdateRRSetTTL(context.Background(), rrset, &ttl)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(rrset.TTL).To(HaveValue(Equal(300)))
+
+			zone, _, err := client.UpdateZoneTTL(context.Background(), result.Zone, 7200)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(zone.TTL).To(Equal(7200))
+
+			_, err = client.DeleteRRSet(context.Background(), rrset)
+			Expect(err).NotTo(HaveOccurred())
+			missing, _, err := client.GetRRSet(context.Background(), result.Zone, "www", "A")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(missing).To(BeNil())
+		})
+
+		It("should create secondary zones and change their primary nameservers", func() {
+			_, _, err := client.CreateZone(context.Background(), "example.org", "secondary", nil, nil, nil)
+			Expect(hcloud.IsError(err, hcloud.ErrorCodeInvalidInput)).To(BeTrue())
+
+			result, _, err := client.CreateZone(context.Background(), "example.org", "SECONDARY", nil,
+				[]hcloud.ZonePrimaryNameserver{{Address: "192.0.2.53"}}, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Zone.Mode).To(Equal(hcloud.ZoneModeSecondary))
+			Expect(result.Zone.PrimaryNameservers).To(Equal([]hcloud.ZonePrimaryNameserver{{Address: "192.0.2.53", Port: 53}}))
+
+			server.SetActionPolls(1)
+			zone, _, err := client.UpdateZonePrimaryNameservers(context.Background(), result.Zone,
+				[]hcloud.ZonePrimaryNameserver{{Address: "192.0.2.53", Port: 5353}, {Address: "2001:db8::53", Port: 53}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(zone.PrimaryNameservers).To(HaveLen(2))
+			Expect(zone.PrimaryNameservers[0].Port).To(Equal(5353))
+		})
+
+		It("should return the running delete action", func() {
+			result, _, err := client.CreateZone(context.Background(), "example.com", "primary", nil, nil, nil)
+			Expect(err).NotTo(HaveOccurred())
+			server.SetActionPolls(1)
+
+			action, _, err := client.DeleteZone(context.Background(), result.Zone)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(action.Status).To(Equal(hcloud.ActionStatusRunning))
+
+			zone, _, err := client.GetZoneById(context.Background(), result.Zone.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(zone).To(BeNil())
+		})
+	})
+})
@@ -0,0 +1,132 @@
+package hcloud
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+// ClientCache holds Hetzner Cloud clients per credential Secret so that reconcilers can manage
+// resources in several projects without building a new client on every reconcile.
+// Entries are keyed by the credential source, typically the Secret UID, and replaced once the
+// version of the credentials, typically the Secret resourceVersion, changes.
+type ClientCache struct {
+	// NewNetworkClientFunc builds a NetworkClient for a token
+	NewNetworkClientFunc func(token string, opts ...hcloud.ClientOption) NetworkClient
+	// NewDnsZoneClientFunc builds a DnsZoneClient for a token
+	NewDnsZoneClientFunc func(token string, opts ...hcloud.ClientOption) DnsZoneClient
+	// NewFirewallClientFunc builds a FirewallClient for a token
+	NewFirewallClientFunc func(token string, opts ...hcloud.ClientOption) FirewallClient
+	// NewServerClientFunc builds a ServerClient for a token
+	NewServerClientFunc func(token string, opts ...hcloud.ClientOption) ServerClient
+	// NewServerActionClientFunc builds a ServerActionClient for a token
+	NewServerActionClientFunc func(token string, opts ...hcloud.ClientOption) ServerActionClient
+	// NewVolumeClientFunc builds a VolumeClient for a token
+	NewVolumeClientFunc func(token string, opts ...hcloud.ClientOption) VolumeClient
+	// Options are applied to every client before the options passed for a key, such as an
+	// endpoint override for all projects
+	Options []hcloud.ClientOption
+
+	mu              sync.Mutex
+	networkClients  map[string]cachedClient[NetworkClient]
+	dnsZoneClients  map[string]cachedClient[DnsZoneClient]
+	firewallClients map[string]cachedClient[FirewallClient]
+	serverClients   map[string]cachedClient[ServerClient]
+	actionClients   map[string]cachedClient[ServerActionClient]
+	volumeClients   map[string]cachedClient[VolumeClient]
+}
+
+// cachedClient is a client together with the version of the credentials it was built from
+type cachedClient[T any] struct {
+	version string
+	client  T
+}
+
+// NewClientCache creates a ClientCache building Hetzner Cloud API clients
+func NewClientCache() *ClientCache {
+	return &ClientCache{
+		NewNetworkClientFunc: func(token string, opts ...hcloud.ClientOption) NetworkClient {
+			return NewNetworkClient(token, opts...)
+		},
+		NewDnsZoneClientFunc: func(token string, opts ...hcloud.ClientOption) DnsZoneClient {
+			return NewDnsZoneClient(token, opts...)
+		},
+		NewFirewallClientFunc: func(token string, opts ...hcloud.ClientOption) FirewallClient {
+			return NewFirewallClient(token, opts...)
+		},
+		NewServerClientFunc: func(token string, opts ...hcloud.ClientOption) ServerClient {
+			return NewServerClient(token, opts...)
+		},
+		NewServerActionClientFunc: func(token string, opts ...hcloud.ClientOption) ServerActionClient {
+			return NewServerActionClient(token, opts...)
+		},
+		NewVolumeClientFunc: func(token string, opts ...hcloud.ClientOption) VolumeClient {
+			return NewVolumeClient(token, opts...)
+		},
+	}
+}
+
+// cached returns the client cached in m for the key or builds and caches a new one when there is
+// none or it was built from another version of the credentials
+func cached[T any](c *ClientCache, m *map[string]cachedClient[T], key, version string, build func() T) T {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if entry, ok := (*m)[key]; ok && entry.version == version {
+		return entry.client
+	}
+	if *m == nil {
+		*m = make(map[string]cachedClient[T])
+	}
+	client := build()
+	(*m)[key] = cachedClient[T]{version: version, client: client}
+	return client
+}
+
+// options returns the options of the cache followed by the options passed for a key
+func (c *ClientCache) options(opts []hcloud.ClientOption) []hcloud.ClientOption {
+	return append(slices.Clone(c.Options), opts...)
+}
+
+// NetworkClient returns the cached NetworkClient for the key or builds a new one from the token
+func (c *ClientCache) NetworkClient(key string, version string, token string, opts ...hcloud.ClientOption) NetworkClient {
+	return cached(c, &c.networkClients, key, version, func() NetworkClient {
+		return c.NewNetworkClientFunc(token, c.options(opts)...)
+	})
+}
+
+// DnsZoneClient returns the cached DnsZoneClient for the key or builds a new one from the token
+func (c *ClientCache) DnsZoneClient(key string, version string, token string, opts ...hcloud.ClientOption) DnsZoneClient {
+	return cached(c, &c.dnsZoneClients, key, version, func() DnsZoneClient {
+		return c.NewDnsZoneClientFunc(token, c.options(opts)...)
+	})
+}
+
+// FirewallClient returns the cached FirewallClient for the key or builds a new one from the token
+func (c *ClientCache) FirewallClient(key string, version string, token string, opts ...hcloud.ClientOption) FirewallClient {
+	return cached(c, &c.firewallClients, key, version, func() FirewallClient {
+		return c.NewFirewallClientFunc(token, c.options(opts)...)
+	})
+}
+
+// ServerClient returns the cached ServerClient for the key or builds a new one from the token
+func (c *ClientCache) ServerClient(key string, version string, token string, opts ...hcloud.ClientOption) ServerClient {
+	return cached(c, &c.serverClients, key, version, func() ServerClient {
+		return c.NewServerClientFunc(token, c.options(opts)...)
+	})
+}
+
+// ServerActionClient returns the cached ServerActionClient for the key or builds a new one from the token
+func (c *ClientCache) ServerActionClient(key string, version string, token string, opts ...hcloud.ClientOption) ServerActionClient {
+	return cached(c, &c.actionClients, key, version, func() ServerActionClient {
+		return c.NewServerActionClientFunc(token, c.options(opts)...)
+	})
+}
+
+// VolumeClient returns the cached VolumeClient for the key or builds a new one from the token
+func (c *ClientCache) VolumeClient(key string, version string, token string, opts ...hcloud.ClientOption) VolumeClient {
+	return cached(c, &c.volumeClients, key, version, func() VolumeClient {
+		return c.NewVolumeClientFunc(token, c.options(opts)...)
+	})
+}
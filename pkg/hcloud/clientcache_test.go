@@ -0,0 +1,137 @@
+package hcloud
+
+import (
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ClientCache", func() {
+	var cache *ClientCache
+	var tokens []string
+	var options int
+
+	BeforeEach(func() {
+		tokens = nil
+		options = 0
+		cache = NewClientCache()
+		cache.NewNetworkClientFunc = func(token string, opts ...hcloud.ClientOption) NetworkClient {
+			tokens = append(tokens, token)
+			options = len(opts)
+			return &MockNetworkClient{}
+		}
+		cache.NewDnsZoneClientFunc = func(token string, opts ...hcloud.ClientOption) DnsZoneClient {
+			tokens = append(tokens, token)
+			return &MockDnsZoneClient{}
+		}
+		cache.NewFirewallClientFunc = func(token string, opts ...hcloud.ClientOption) FirewallClient {
+			tokens = append(tokens, token)
+			return &MockFirewallClient{}
+		}
+		cache.NewServerClientFunc = func(token string, opts ...hcloud.ClientOption) ServerClient {
+			tokens = append(tokens, token)
+			return &MockServerClient{}
+		}
+		cache.NewServerActionClientFunc = func(token string, opts ...hcloud.ClientOption) ServerActionClient {
+			tokens = append(tokens, token)
+			return &MockServerActionClient{}
+		}
+		cache.NewVolumeClientFunc = func(token string, opts ...hcloud.ClientOption) VolumeClient {
+			tokens = append(tokens, token)
+			return &MockVolumeClient{}
+		}
+	})
+
+	Describe("NetworkClient", func() {
+		When("the secret did not change", func() {
+			It("should reuse the cached client", func() {
+				first := cache.NetworkClient("uid-1", "1", "token-a")
+				second := cache.NetworkClient("uid-1", "1", "token-a")
+				Expect(second).To(BeIdenticalTo(first))
+				Expect(tokens).To(Equal([]string{"token-a"}))
+			})
+		})
+
+		When("the secret was updated", func() {
+			It("should build a new client with the new token", func() {
+				first := cache.NetworkClient("uid-1", "1", "token-a")
+				second := cache.NetworkClient("uid-1", "2", "token-b")
+				Expect(second).NotTo(BeIdenticalTo(first))
+				Expect(tokens).To(Equal([]string{"token-a", "token-b"}))
+			})
+		})
+
+		When("different secrets are used", func() {
+			It("should keep a client per secret", func() {
+				cache.NetworkClient("uid-1", "1", "token-a")
+				cache.NetworkClient("uid-2", "1", "token-b")
+				cache.NetworkClient("uid-1", "1", "token-a")
+				Expect(tokens).To(Equal([]string{"token-a", "token-b"}))
+			})
+		})
+
+		When("options are set for all clients", func() {
+			It("should pass them together with the options of the key", func() {
+				cache.Options = []hcloud.ClientOption{hcloud.WithEndpoint("http://localhost:8080/v1")}
+				cache.NetworkClient("uid-1", "1", "token-a", hcloud.WithApplication("hcrm", "test"))
+				Expect(options).To(Equal(2))
+			})
+		})
+	})
+
+	Describe("DnsZoneClient", func() {
+		When("the secret did not change", func() {
+			It("should reuse the cached client", func() {
+				first := cache.DnsZoneClient("uid-1", "1", "token-a")
+				second := cache.DnsZoneClient("uid-1", "1", "token-a")
+				Expect(second).To(BeIdenticalTo(first))
+				Expect(tokens).To(Equal([]string{"token-a"}))
+			})
+		})
+	})
+
+	Describe("FirewallClient", func() {
+		When("the secret was updated", func() {
+			It("should build a new client with the new token", func() {
+				first := cache.FirewallClient("uid-1", "1", "token-a")
+				Expect(cache.FirewallClient("uid-1", "1", "token-a")).To(BeIdenticalTo(first))
+				Expect(cache.FirewallClient("uid-1", "2", "token-b")).NotTo(BeIdenticalTo(first))
+				Expect(tokens).To(Equal([]string{"token-a", "token-b"}))
+			})
+		})
+	})
+
+	Describe("ServerClient", func() {
+		When("the secret was updated", func() {
+			It("should build a new client with the new token", func() {
+				first := cache.ServerClient("uid-1", "1", "token-a")
+				Expect(cache.ServerClient("uid-1", "1", "token-a")).To(BeIdenticalTo(first))
+				Expect(cache.ServerClient("uid-1", "2", "token-b")).NotTo(BeIdenticalTo(first))
+				Expect(tokens).To(Equal([]string{"token-a", "token-b"}))
+			})
+		})
+	})
+
+	Describe("ServerActionClient", func() {
+		When("the secret was updated", func() {
+			It("should build a new client with the new token", func() {
+				first := cache.ServerActionClient("uid-1", "1", "token-a")
+				Expect(cache.ServerActionClient("uid-1", "1", "token-a")).To(BeIdenticalTo(first))
+				Expect(cache.ServerActionClient("uid-1", "2", "token-b")).NotTo(BeIdenticalTo(first))
+				Expect(tokens).To(Equal([]string{"token-a", "token-b"}))
+			})
+		})
+	})
+
+	Describe("VolumeClient", func() {
+		When("clients are requested for different secrets", func() {
+			It("should build one client per secret", func() {
+				first := cache.VolumeClient("uid-1", "1", "token-a")
+				second := cache.VolumeClient("uid-2", "1", "token-b")
+				Expect(second).NotTo(BeIdenticalTo(first))
+				Expect(cache.VolumeClient("uid-1", "1", "token-a")).To(BeIdenticalTo(first))
+				Expect(tokens).To(Equal([]string{"token-a", "token-b"}))
+			})
+		})
+	})
+})
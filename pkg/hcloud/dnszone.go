@@ -2,6 +2,7 @@ package hcloud
 
 import (
 	"context"
+	"strings"
 
 	"github.com/hetznercloud/hcloud-go/v2/hcloud"
 )
@@ -10,22 +11,38 @@ import (
 type DnsZoneClient interface {
 	GetZoneById(ctx context.Context, id int64) (*hcloud.Zone, *hcloud.Response, error)
 	GetZoneByName(ctx context.Context, name string) (*hcloud.Zone, *hcloud.Response, error)
-	CreateZone(ctx context.Context, name string, mode string, ttl *int, labels map[string]string) (*hcloud.Zone, *hcloud.Response, error)
-	// UpdateZone(ctx context.Context, zone *hcloud.Zone, name string, labels map[string]string) (*hcloud.Zone, *hcloud.Response, error)
-	DeleteZone(ctx context.Context, zone *hcloud.Zone) (*hcloud.Response, error)
+	CreateZone(ctx context.Context, name string, mode string, ttl *int, primaryNameservers []hcloud.ZonePrimaryNameserver, labels map[string]string) (hcloud.ZoneCreateResult, *hcloud.Response, error)
+	UpdateZoneLabels(ctx context.Context, zone *hcloud.Zone, labels map[string]string) (*hcloud.Zone, *hcloud.Response, error)
+	UpdateZoneTTL(ctx context.Context, zone *hcloud.Zone, ttl int) (*hcloud.Zone, *hcloud.Response, error)
+	UpdateZonePrimaryNameservers(ctx context.Context, zone *hcloud.Zone, primaryNameservers []hcloud.ZonePrimaryNameserver) (*hcloud.Zone, *hcloud.Response, error)
+	DeleteZone(ctx context.Context, zone *hcloud.Zone) (*hcloud.Action, *hcloud.Response, error)
 	ListZones(ctx context.Context) ([]*hcloud.Zone, error)
+
+	// RRSet operations
+	GetRRSet(ctx context.Context, zone *hcloud.Zone, name string, rrsetType string) (*hcloud.ZoneRRSet, *hcloud.Response, error)
+	CreateRRSet(ctx context.Context, zone *hcloud.Zone, name string, rrsetType string, ttl *int, records []hcloud.ZoneRRSetRecord, labels map[string]string) (*hcloud.ZoneRRSet, *hcloud.Response, error)
+	UpdateRRSetRecords(ctx context.Context, rrset *hcloud.ZoneRRSet, records []hcloud.ZoneRRSetRecord) (*hcloud.ZoneRRSet, *hcloud.Response, error)
+	UpdateRRSetTTL(ctx context.Context, rrset *hcloud.ZoneRRSet, ttl *int) (*hcloud.ZoneRRSet, *hcloud.Response, error)
+	UpdateRRSetLabels(ctx context.Context, rrset *hcloud.ZoneRRSet, labels map[string]string) (*hcloud.ZoneRRSet, *hcloud.Response, error)
+	DeleteRRSet(ctx context.Context, rrset *hcloud.ZoneRRSet) (*hcloud.Response, error)
+	ListRRSets(ctx context.Context, zone *hcloud.Zone) ([]*hcloud.ZoneRRSet, error)
+
+	// Action operations
+	GetAction(ctx context.Context, id int64) (*hcloud.Action, *hcloud.Response, error)
 }
 
 type hcloudDnsZoneAdapter struct {
 	client *hcloud.Client
 }
 
-// NewClient creates a new HCloud client with the provided token
-func NewDnsZoneClient(token string) *hcloudDnsZoneAdapter {
-	client := hcloud.NewClient(hcloud.WithToken(token))
-	return &hcloudDnsZoneAdapter{
+// NewClient creates a new HCloud client with the provided token. Additional options, such as an
+// endpoint override or poll interval, are passed on to the underlying hcloud-go client. Requests
+// pass the DefaultRateLimiter and every call is recorded in the Hetzner Cloud API metrics.
+func NewDnsZoneClient(token string, opts ...hcloud.ClientOption) DnsZoneClient {
+	client := newRateLimitedClient(token, opts...)
+	return InstrumentDnsZoneClient(&hcloudDnsZoneAdapter{
 		client: client,
-	}
+	})
 }
 
 func (a *hcloudDnsZoneAdapter) GetZoneById(ctx context.Context, id int64) (*hcloud.Zone, *hcloud.Response, error) {
@@ -36,32 +53,178 @@ func (a *hcloudDnsZoneAdapter) GetZoneByName(ctx context.Context, name string) (
 	return a.client.Zone.GetByName(ctx, name)
 }
 
-func (a *hcloudDnsZoneAdapter) CreateZone(ctx context.Context, name string, mode string, ttl *int, labels map[string]string) (*hcloud.Zone, *hcloud.Response, error) {
+// CreateZone creates a new zone and returns it together with the pending create action.
+// The mode is accepted in either case (PRIMARY or primary). Primary nameservers are only
+// accepted by Hetzner Cloud for secondary zones.
+func (a *hcloudDnsZoneAdapter) CreateZone(ctx context.Context, name string, mode string, ttl *int, primaryNameservers []hcloud.ZonePrimaryNameserver, labels map[string]string) (hcloud.ZoneCreateResult, *hcloud.Response, error) {
 	opts := hcloud.ZoneCreateOpts{
 		Name:   name,
-		Mode:   hcloud.ZoneMode(mode),
+		Mode:   hcloud.ZoneMode(strings.ToLower(mode)),
 		TTL:    ttl,
 		Labels: labels,
 	}
+	for _, nameserver := range primaryNameservers {
+		opts.PrimaryNameservers = append(opts.PrimaryNameservers, hcloud.ZoneCreateOptsPrimaryNameserver{
+			Address:       nameserver.Address,
+			Port:          nameserver.Port,
+			TSIGAlgorithm: nameserver.TSIGAlgorithm,
+			TSIGKey:       nameserver.TSIGKey,
+		})
+	}
+
+	return a.client.Zone.Create(ctx, opts)
+}
+
+// UpdateZoneLabels replaces the labels of an existing zone
+func (a *hcloudDnsZoneAdapter) UpdateZoneLabels(ctx context.Context, zone *hcloud.Zone, labels map[string]string) (*hcloud.Zone, *hcloud.Response, error) {
+	opts := hcloud.ZoneUpdateOpts{
+		Labels: labels,
+	}
+	return a.client.Zone.Update(ctx, zone, opts)
+}
+
+// UpdateZoneTTL changes the default TTL of an existing zone
+func (a *hcloudDnsZoneAdapter) UpdateZoneTTL(ctx context.Context, zone *hcloud.Zone, ttl int) (*hcloud.Zone, *hcloud.Response, error) {
+	opts := hcloud.ZoneChangeTTLOpts{
+		TTL: ttl,
+	}
+	action, resp, err := a.client.Zone.ChangeTTL(ctx, zone, opts)
+	if err != nil {
+		return nil, resp, err
+	}
+	// Wait for the action to complete
+	err = a.client.Action.WaitFor(ctx, action)
+	if err != nil {
+		return nil, resp, err
+	}
+	// Retrieve the updated zone
+	updatedZone, resp, err := a.client.Zone.GetByID(ctx, zone.ID)
+	if err != nil {
+		return nil, resp, err
+	}
+	return updatedZone, resp, nil
+}
+
+// UpdateZonePrimaryNameservers replaces the primary nameservers of an existing secondary zone
+func (a *hcloudDnsZoneAdapter) UpdateZonePrimaryNameservers(ctx context.Context, zone *hcloud.Zone, primaryNameservers []hcloud.ZonePrimaryNameserver) (*hcloud.Zone, *hcloud.Response, error) {
+	opts := hcloud.ZoneChangePrimaryNameserversOpts{
+		PrimaryNameservers: make([]hcloud.ZoneChangePrimaryNameserversOptsPrimaryNameserver, 0, len(primaryNameservers)),
+	}
+	for _, nameserver := range primaryNameservers {
+		opts.PrimaryNameservers = append(opts.PrimaryNameservers, hcloud.ZoneChangePrimaryNameserversOptsPrimaryNameserver{
+			Address:       nameserver.Address,
+			Port:          nameserver.Port,
+			TSIGAlgorithm: nameserver.TSIGAlgorithm,
+			TSIGKey:       nameserver.TSIGKey,
+		})
+	}
+	action, resp, err := a.client.Zone.ChangePrimaryNameservers(ctx, zone, opts)
+	if err != nil {
+		return nil, resp, err
+	}
+	// Wait for the action to complete
+	err = a.client.Action.WaitFor(ctx, action)
+	if err != nil {
+		return nil, resp, err
+	}
+	// Retrieve the updated zone
+	updatedZone, resp, err := a.client.Zone.GetByID(ctx, zone.ID)
+	if err != nil {
+		return nil, resp, err
+	}
+	return updatedZone, resp, nil
+}
+
+// DeleteZone starts deleting a zone and returns the pending delete action
+func (a *hcloudDnsZoneAdapter) DeleteZone(ctx context.Context, zone *hcloud.Zone) (*hcloud.Action, *hcloud.Response, error) {
+	result, response, err := a.client.Zone.Delete(ctx, zone)
+	if err != nil {
+		return nil, response, err
+	}
+	return result.Action, response, nil
+}
+
+func (a *hcloudDnsZoneAdapter) ListZones(ctx context.Context) ([]*hcloud.Zone, error) {
+	zones, err := a.client.Zone.All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return zones, nil
+}
+
+// GetRRSet retrieves an RRSet of a zone by name and type, it returns nil if the RRSet does not exist
+func (a *hcloudDnsZoneAdapter) GetRRSet(ctx context.Context, zone *hcloud.Zone, name string, rrsetType string) (*hcloud.ZoneRRSet, *hcloud.Response, error) {
+	return a.client.Zone.GetRRSetByNameAndType(ctx, zone, name, hcloud.ZoneRRSetType(rrsetType))
+}
+
+// CreateRRSet creates a new RRSet in the zone and waits for the create action to complete
+func (a *hcloudDnsZoneAdapter) CreateRRSet(ctx context.Context, zone *hcloud.Zone, name string, rrsetType string, ttl *int, records []hcloud.ZoneRRSetRecord, labels map[string]string) (*hcloud.ZoneRRSet, *hcloud.Response, error) {
+	opts := hcloud.ZoneRRSetCreateOpts{
+		Name:    name,
+		Type:    hcloud.ZoneRRSetType(rrsetType),
+		TTL:     ttl,
+		Records: records,
+		Labels:  labels,
+	}
 
-	result, response, err := a.client.Zone.Create(ctx, opts)
+	result, response, err := a.client.Zone.CreateRRSet(ctx, zone, opts)
 	if err != nil {
 		return nil, response, err
 	}
 
 	err = a.client.Action.WaitFor(ctx, result.Action)
 	if err != nil {
-		zone := result.Zone
-		return zone, response, nil
-	} else {
 		return nil, response, err
 	}
 
+	return a.refreshRRSet(ctx, result.RRSet, response)
 }
 
-func (a *hcloudDnsZoneAdapter) DeleteZone(ctx context.Context, zone *hcloud.Zone) (*hcloud.Response, error) {
+// UpdateRRSetRecords overwrites the records of an RRSet
+func (a *hcloudDnsZoneAdapter) UpdateRRSetRecords(ctx context.Context, rrset *hcloud.ZoneRRSet, records []hcloud.ZoneRRSetRecord) (*hcloud.ZoneRRSet, *hcloud.Response, error) {
+	opts := hcloud.ZoneRRSetSetRecordsOpts{
+		Records: records,
+	}
+	action, resp, err := a.client.Zone.SetRRSetRecords(ctx, rrset, opts)
+	if err != nil {
+		return nil, resp, err
+	}
+	// Wait for the action to complete
+	err = a.client.Action.WaitFor(ctx, action)
+	if err != nil {
+		return nil, resp, err
+	}
+	return a.refreshRRSet(ctx, rrset, resp)
+}
 
-	result, response, err := a.client.Zone.Delete(ctx, zone)
+// UpdateRRSetTTL changes the TTL of an RRSet, a nil TTL resets it to the zone default
+func (a *hcloudDnsZoneAdapter) UpdateRRSetTTL(ctx context.Context, rrset *hcloud.ZoneRRSet, ttl *int) (*hcloud.ZoneRRSet, *hcloud.Response, error) {
+	opts := hcloud.ZoneRRSetChangeTTLOpts{
+		TTL: ttl,
+	}
+	action, resp, err := a.client.Zone.ChangeRRSetTTL(ctx, rrset, opts)
+	if err != nil {
+		return nil, resp, err
+	}
+	// Wait for the action to complete
+	err = a.client.Action.WaitFor(ctx, action)
+	if err != nil {
+		return nil, resp, err
+	}
+	return a.refreshRRSet(ctx, rrset, resp)
+}
+
+// UpdateRRSetLabels replaces the labels of an RRSet
+func (a *hcloudDnsZoneAdapter) UpdateRRSetLabels(ctx context.Context, rrset *hcloud.ZoneRRSet, labels map[string]string) (*hcloud.ZoneRRSet, *hcloud.Response, error) {
+	opts := hcloud.ZoneRRSetUpdateOpts{
+		Labels: labels,
+	}
+	return a.client.Zone.UpdateRRSet(ctx, rrset, opts)
+}
+
+// DeleteRRSet deletes an RRSet and waits for the delete action to complete
+func (a *hcloudDnsZoneAdapter) DeleteRRSet(ctx context.Context, rrset *hcloud.ZoneRRSet) (*hcloud.Response, error) {
+	result, response, err := a.client.Zone.DeleteRRSet(ctx, rrset)
 	if err != nil {
 		return response, err
 	}
@@ -71,10 +234,24 @@ func (a *hcloudDnsZoneAdapter) DeleteZone(ctx context.Context, zone *hcloud.Zone
 	return response, err
 }
 
-func (a *hcloudDnsZoneAdapter) ListZones(ctx context.Context) ([]*hcloud.Zone, error) {
-	zones, err := a.client.Zone.All(ctx)
+// ListRRSets lists all RRSets of a zone
+func (a *hcloudDnsZoneAdapter) ListRRSets(ctx context.Context, zone *hcloud.Zone) ([]*hcloud.ZoneRRSet, error) {
+	return a.client.Zone.AllRRSets(ctx, zone)
+}
+
+// GetAction retrieves an action by ID to follow its progress
+func (a *hcloudDnsZoneAdapter) GetAction(ctx context.Context, id int64) (*hcloud.Action, *hcloud.Response, error) {
+	return a.client.Action.GetByID(ctx, id)
+}
+
+// refreshRRSet retrieves the current state of an RRSet after an action has completed
+func (a *hcloudDnsZoneAdapter) refreshRRSet(ctx context.Context, rrset *hcloud.ZoneRRSet, resp *hcloud.Response) (*hcloud.ZoneRRSet, *hcloud.Response, error) {
+	updatedRRSet, getResp, err := a.client.Zone.GetRRSetByNameAndType(ctx, rrset.Zone, rrset.Name, rrset.Type)
 	if err != nil {
-		return nil, err
+		return nil, getResp, err
 	}
-	return zones, nil
+	if updatedRRSet == nil {
+		return rrset, resp, nil
+	}
+	return updatedRRSet, getResp, nil
 }
@@ -8,29 +8,150 @@ import (
 
 // MockDnsZoneClient is a mock implementation of the Client interface for testing
 type MockDnsZoneClient struct {
-	GetZoneByIdFunc   func(ctx context.Context, id int64) (*hcloud.Zone, *hcloud.Response, error)
-	GetZoneByNameFunc func(ctx context.Context, name string) (*hcloud.Zone, *hcloud.Response, error)
-	CreateZoneFunc    func(ctx context.Context, name string, mode string, ttl *int, labels map[string]string) (*hcloud.Zone, *hcloud.Response, error)
-	DeleteZoneFunc    func(ctx context.Context, dnszone *hcloud.Zone) (*hcloud.Response, error)
-	ListZonesFunc     func(ctx context.Context) ([]*hcloud.Zone, error)
+	GetZoneByIdFunc                  func(ctx context.Context, id int64) (*hcloud.Zone, *hcloud.Response, error)
+	GetZoneByNameFunc                func(ctx context.Context, name string) (*hcloud.Zone, *hcloud.Response, error)
+	CreateZoneFunc                   func(ctx context.Context, name string, mode string, ttl *int, primaryNameservers []hcloud.ZonePrimaryNameserver, labels map[string]string) (hcloud.ZoneCreateResult, *hcloud.Response, error)
+	UpdateZoneLabelsFunc             func(ctx context.Context, zone *hcloud.Zone, labels map[string]string) (*hcloud.Zone, *hcloud.Response, error)
+	UpdateZoneTTLFunc                func(ctx context.Context, zone *hcloud.Zone, ttl int) (*hcloud.Zone, *hcloud.Response, error)
+	UpdateZonePrimaryNameserversFunc func(ctx context.Context, zone *hcloud.Zone, primaryNameservers []hcloud.ZonePrimaryNameserver) (*hcloud.Zone, *hcloud.Response, error)
+	DeleteZoneFunc                   func(ctx context.Context, dnszone *hcloud.Zone) (*hcloud.Action, *hcloud.Response, error)
+	ListZonesFunc                    func(ctx context.Context) ([]*hcloud.Zone, error)
+
+	GetRRSetFunc           func(ctx context.Context, zone *hcloud.Zone, name string, rrsetType string) (*hcloud.ZoneRRSet, *hcloud.Response, error)
+	CreateRRSetFunc        func(ctx context.Context, zone *hcloud.Zone, name string, rrsetType string, ttl *int, records []hcloud.ZoneRRSetRecord, labels map[string]string) (*hcloud.ZoneRRSet, *hcloud.Response, error)
+	UpdateRRSetRecordsFunc func(ctx context.Context, rrset *hcloud.ZoneRRSet, records []hcloud.ZoneRRSetRecord) (*hcloud.ZoneRRSet, *hcloud.Response, error)
+	UpdateRRSetTTLFunc     func(ctx context.Context, rrset *hcloud.ZoneRRSet, ttl *int) (*hcloud.ZoneRRSet, *hcloud.Response, error)
+	UpdateRRSetLabelsFunc  func(ctx context.Context, rrset *hcloud.ZoneRRSet, labels map[string]string) (*hcloud.ZoneRRSet, *hcloud.Response, error)
+	DeleteRRSetFunc        func(ctx context.Context, rrset *hcloud.ZoneRRSet) (*hcloud.Response, error)
+	ListRRSetsFunc         func(ctx context.Context, zone *hcloud.Zone) ([]*hcloud.ZoneRRSet, error)
+
+	GetActionFunc func(ctx context.Context, id int64) (*hcloud.Action, *hcloud.Response, error)
 }
 
+// GetZoneById calls the mocked GetZoneByIdFunc
 func (m *MockDnsZoneClient) GetZoneById(ctx context.Context, id int64) (*hcloud.Zone, *hcloud.Response, error) {
-	return m.GetZoneByIdFunc(ctx, id)
+	if m.GetZoneByIdFunc != nil {
+		return m.GetZoneByIdFunc(ctx, id)
+	}
+	return nil, nil, nil
 }
 
+// GetZoneByName calls the mocked GetZoneByNameFunc
 func (m *MockDnsZoneClient) GetZoneByName(ctx context.Context, name string) (*hcloud.Zone, *hcloud.Response, error) {
-	return m.GetZoneByNameFunc(ctx, name)
+	if m.GetZoneByNameFunc != nil {
+		return m.GetZoneByNameFunc(ctx, name)
+	}
+	return nil, nil, nil
+}
+
+// CreateZone calls the mocked CreateZoneFunc
+func (m *MockDnsZoneClient) CreateZone(ctx context.Context, name string, mode string, ttl *int, primaryNameservers []hcloud.ZonePrimaryNameserver, labels map[string]string) (hcloud.ZoneCreateResult, *hcloud.Response, error) {
+	if m.CreateZoneFunc != nil {
+		return m.CreateZoneFunc(ctx, name, mode, ttl, primaryNameservers, labels)
+	}
+	return hcloud.ZoneCreateResult{}, nil, nil
+}
+
+// UpdateZoneLabels calls the mocked UpdateZoneLabelsFunc
+func (m *MockDnsZoneClient) UpdateZoneLabels(ctx context.Context, zone *hcloud.Zone, labels map[string]string) (*hcloud.Zone, *hcloud.Response, error) {
+	if m.UpdateZoneLabelsFunc != nil {
+		return m.UpdateZoneLabelsFunc(ctx, zone, labels)
+	}
+	return nil, nil, nil
 }
 
-func (m *MockDnsZoneClient) CreateZone(ctx context.Context, name string, mode string, ttl *int, labels map[string]string) (*hcloud.Zone, *hcloud.Response, error) {
-	return m.CreateZoneFunc(ctx, name, mode, ttl, labels)
+// UpdateZoneTTL calls the mocked UpdateZoneTTLFunc
+func (m *MockDnsZoneClient) UpdateZoneTTL(ctx context.Context, zone *hcloud.Zone, ttl int) (*hcloud.Zone, *hcloud.Response, error) {
+	if m.UpdateZoneTTLFunc != nil {
+		return m.UpdateZoneTTLFunc(ctx, zone, ttl)
+	}
+	return nil, nil, nil
 }
 
-func (m *MockDnsZoneClient) DeleteZone(ctx context.Context, dnszone *hcloud.Zone) (*hcloud.Response, error) {
-	return m.DeleteZoneFunc(ctx, dnszone)
+// UpdateZonePrimaryNameservers calls the mocked UpdateZonePrimaryNameserversFunc
+func (m *MockDnsZoneClient) UpdateZonePrimaryNameservers(ctx context.Context, zone *hcloud.Zone, primaryNameservers []hcloud.ZonePrimaryNameserver) (*hcloud.Zone, *hcloud.Response, error) {
+	if m.UpdateZonePrimaryNameserversFunc != nil {
+		return m.UpdateZonePrimaryNameserversFunc(ctx, zone, primaryNameservers)
+	}
+	return nil, nil, nil
 }
 
+// DeleteZone calls the mocked DeleteZoneFunc
+func (m *MockDnsZoneClient) DeleteZone(ctx context.Context, dnszone *hcloud.Zone) (*hcloud.Action, *hcloud.Response, error) {
+	if m.DeleteZoneFunc != nil {
+		return m.DeleteZoneFunc(ctx, dnszone)
+	}
+	return nil, nil, nil
+}
+
+// ListZones calls the mocked ListZonesFunc
 func (m *MockDnsZoneClient) ListZones(ctx context.Context) ([]*hcloud.Zone, error) {
-	return m.ListZonesFunc(ctx)
+	if m.ListZonesFunc != nil {
+		return m.ListZonesFunc(ctx)
+	}
+	return nil, nil
+}
+
+// GetRRSet calls the mocked GetRRSetFunc
+func (m *MockDnsZoneClient) GetRRSet(ctx context.Context, zone *hcloud.Zone, name string, rrsetType string) (*hcloud.ZoneRRSet, *hcloud.Response, error) {
+	if m.GetRRSetFunc != nil {
+		return m.GetRRSetFunc(ctx, zone, name, rrsetType)
+	}
+	return nil, nil, nil
+}
+
+// CreateRRSet calls the mocked CreateRRSetFunc
+func (m *MockDnsZoneClient) CreateRRSet(ctx context.Context, zone *hcloud.Zone, name string, rrsetType string, ttl *int, records []hcloud.ZoneRRSetRecord, labels map[string]string) (*hcloud.ZoneRRSet, *hcloud.Response, error) {
+	if m.CreateRRSetFunc != nil {
+		return m.CreateRRSetFunc(ctx, zone, name, rrsetType, ttl, records, labels)
+	}
+	return nil, nil, nil
+}
+
+// UpdateRRSetRecords calls the mocked UpdateRRSetRecordsFunc
+func (m *MockDnsZoneClient) UpdateRRSetRecords(ctx context.Context, rrset *hcloud.ZoneRRSet, records []hcloud.ZoneRRSetRecord) (*hcloud.ZoneRRSet, *hcloud.Response, error) {
+	if m.UpdateRRSetRecordsFunc != nil {
+		return m.UpdateRRSetRecordsFunc(ctx, rrset, records)
+	}
+	return nil, nil, nil
+}
+
+// UpdateRRSetTTL calls the mocked UpdateRRSetTTLFunc
+func (m *MockDnsZoneClient) UpdateRRSetTTL(ctx context.Context, rrset *hcloud.ZoneRRSet, ttl *int) (*hcloud.ZoneRRSet, *hcloud.Response, error) {
+	if m.UpdateRRSetTTLFunc != nil {
+		return m.UpdateRRSetTTLFunc(ctx, rrset, ttl)
+	}
+	return nil, nil, nil
+}
+
+// UpdateRRSetLabels calls the mocked UpdateRRSetLabelsFunc
+func (m *MockDnsZoneClient) UpdateRRSetLabels(ctx context.Context, rrset *hcloud.ZoneRRSet, labels map[string]string) (*hcloud.ZoneRRSet, *hcloud.Response, error) {
+	if m.UpdateRRSetLabelsFunc != nil {
+		return m.UpdateRRSetLabelsFunc(ctx, rrset, labels)
+	}
+	return nil, nil, nil
+}
+
+// DeleteRRSet calls the mocked DeleteRRSetFunc
+func (m *MockDnsZoneClient) DeleteRRSet(ctx context.Context, rrset *hcloud.ZoneRRSet) (*hcloud.Response, error) {
+	if m.DeleteRRSetFunc != nil {
+		return m.DeleteRRSetFunc(ctx, rrset)
+	}
+	return nil, nil
+}
+
+// ListRRSets calls the mocked ListRRSetsFunc
+func (m *MockDnsZoneClient) ListRRSets(ctx context.Context, zone *hcloud.Zone) ([]*hcloud.ZoneRRSet, error) {
+	if m.ListRRSetsFunc != nil {
+		return m.ListRRSetsFunc(ctx, zone)
+	}
+	return nil, nil
+}
+
+// GetAction calls the mocked GetActionFunc
+func (m *MockDnsZoneClient) GetAction(ctx context.Context, id int64) (*hcloud.Action, *hcloud.Response, error) {
+	if m.GetActionFunc != nil {
+		return m.GetActionFunc(ctx, id)
+	}
+	return nil, nil, nil
 }
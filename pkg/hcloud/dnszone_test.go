@@ -2,6 +2,7 @@ package hcloud
 
 import (
 	"context"
+	"errors"
 
 	"github.com/hetznercloud/hcloud-go/v2/hcloud"
 	. "github.com/onsi/ginkgo/v2"
@@ -35,4 +36,92 @@ var _ = Describe("DnsZoneManager", func() {
 			})
 		})
 	})
+
+	Describe("UpdateZoneLabels", func() {
+		When("valid labels are provided", func() {
+			BeforeEach(func() {
+				mockDnsZoneClient.UpdateZoneLabelsFunc = func(ctx context.Context, zone *hcloud.Zone, labels map[string]string) (*hcloud.Zone, *hcloud.Response, error) {
+					return &hcloud.Zone{ID: zone.ID, Name: zone.Name, Labels: labels}, nil, nil
+				}
+			})
+
+			It("should update zone labels", func() {
+				labels := map[string]string{"newKey": "newValue"}
+				zone, _, err := zc.UpdateZoneLabels(context.Background(), &hcloud.Zone{ID: 123, Name: "example.com"}, labels)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(zone.Labels).To(Equal(labels))
+			})
+		})
+	})
+
+	Describe("UpdateZoneTTL", func() {
+		When("valid TTL is provided", func() {
+			BeforeEach(func() {
+				mockDnsZoneClient.UpdateZoneTTLFunc = func(ctx context.Context, zone *hcloud.Zone, ttl int) (*hcloud.Zone, *hcloud.Response, error) {
+					return &hcloud.Zone{ID: zone.ID, Name: zone.Name, TTL: ttl}, nil, nil
+				}
+			})
+
+			It("should update zone TTL", func() {
+				zone, _, err := zc.UpdateZoneTTL(context.Background(), &hcloud.Zone{ID: 123, Name: "example.com", TTL: 3600}, 600)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(zone.TTL).To(Equal(600))
+			})
+		})
+
+		When("API returns an error", func() {
+			BeforeEach(func() {
+				mockDnsZoneClient.UpdateZoneTTLFunc = func(ctx context.Context, zone *hcloud.Zone, ttl int) (*hcloud.Zone, *hcloud.Response, error) {
+					return nil, nil, errors.New("update failed")
+				}
+			})
+
+			It("should propagate the error", func() {
+				_, _, err := zc.UpdateZoneTTL(context.Background(), &hcloud.Zone{ID: 123, Name: "example.com"}, 600)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("update failed"))
+			})
+		})
+	})
+
+	Describe("GetRRSet", func() {
+		When("RRSet exists", func() {
+			BeforeEach(func() {
+				mockDnsZoneClient.GetRRSetFunc = func(ctx context.Context, zone *hcloud.Zone, name string, rrsetType string) (*hcloud.ZoneRRSet, *hcloud.Response, error) {
+					return &hcloud.ZoneRRSet{Zone: zone, ID: name + "/" + rrsetType, Name: name, Type: hcloud.ZoneRRSetType(rrsetType)}, nil, nil
+				}
+			})
+
+			It("should retrieve RRSet by name and type", func() {
+				rrset, _, err := zc.GetRRSet(context.Background(), &hcloud.Zone{ID: 123}, "www", "A")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(rrset).NotTo(BeNil())
+				Expect(rrset.ID).To(Equal("www/A"))
+			})
+		})
+
+		When("RRSet does not exist", func() {
+			It("should return nil without error", func() {
+				rrset, _, err := zc.GetRRSet(context.Background(), &hcloud.Zone{ID: 123}, "www", "A")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(rrset).To(BeNil())
+			})
+		})
+	})
+
+	Describe("DeleteRRSet", func() {
+		When("API returns an error", func() {
+			BeforeEach(func() {
+				mockDnsZoneClient.DeleteRRSetFunc = func(ctx context.Context, rrset *hcloud.ZoneRRSet) (*hcloud.Response, error) {
+					return nil, errors.New("delete failed")
+				}
+			})
+
+			It("should propagate the error", func() {
+				_, err := zc.DeleteRRSet(context.Background(), &hcloud.ZoneRRSet{ID: "www/A"})
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("delete failed"))
+			})
+		})
+	})
 })
@@ -0,0 +1,76 @@
+package fake
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+	"github.com/hetznercloud/hcloud-go/v2/hcloud/schema"
+)
+
+// AddServer stores a server as if it had been created outside of the operator and returns its ID,
+// the server is attached to every existing network of its private networks
+func (s *Server) AddServer(server schema.Server) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	server.ID = s.id()
+	if server.Created.IsZero() {
+		server.Created = time.Now()
+	}
+	if server.Status == "" {
+		server.Status = "running"
+	}
+	stored := copyServer(&server)
+	s.servers[server.ID] = &stored
+	for _, privateNet := range stored.PrivateNet {
+		if network, ok := s.networks[privateNet.Network]; ok {
+			network.Servers = append(network.Servers, server.ID)
+		}
+	}
+	return server.ID
+}
+
+// RemoveServer deletes a server as if it had been deleted outside of the operator and reports
+// whether it existed, the server is detached from its networks
+func (s *Server) RemoveServer(id int64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	server, ok := s.servers[id]
+	if !ok {
+		return false
+	}
+	for _, privateNet := range server.PrivateNet {
+		if network, ok := s.networks[privateNet.Network]; ok {
+			network.Servers = slices.DeleteFunc(network.Servers, func(attached int64) bool { return attached == id })
+		}
+	}
+	delete(s.servers, id)
+	return true
+}
+
+func (s *Server) getServer(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	server, ok := s.servers[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, hcloud.ErrorCodeNotFound, fmt.Sprintf("server with ID %d not found", id))
+		return
+	}
+	writeJSON(w, http.StatusOK, schema.ServerGetResponse{Server: copyServer(server)})
+}
+
+// copyServer returns a deep copy of the server so that callers cannot change the stored state
+func copyServer(server *schema.Server) schema.Server {
+	copied := *server
+	copied.Labels = copyLabels(server.Labels)
+	copied.PrivateNet = make([]schema.ServerPrivateNet, 0, len(server.PrivateNet))
+	for _, privateNet := range server.PrivateNet {
+		privateNet.AliasIPs = append([]string{}, privateNet.AliasIPs...)
+		copied.PrivateNet = append(copied.PrivateNet, privateNet)
+	}
+	return copied
+}
@@ -0,0 +1,255 @@
+package fake
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+	"github.com/hetznercloud/hcloud-go/v2/hcloud/schema"
+)
+
+// Fault handles a request in place of the next RoundTripper. It may answer the request itself,
+// fail it, or forward it to next after delaying or changing the state of the backend.
+type Fault func(req *http.Request, next http.RoundTripper) (*http.Response, error)
+
+// FaultTransport is an http.RoundTripper that injects faults into the requests to the Hetzner Cloud
+// API, to be passed to the clients of the hcloud package with hcloudgo.WithHTTPClient:
+//
+//	faults := fake.NewFaultTransport(nil, 1)
+//	faults.Sometimes("GET /networks/{id}", 0.3, fake.Status(http.StatusServiceUnavailable, "service_error", "try again"))
+//	faults.Sequence("DELETE /networks/{id}", fake.RateLimited(time.Second), nil, fake.Status(http.StatusBadGateway, "", ""))
+//
+// Patterns are an optional method followed by a path whose segments are matched against the end
+// of the request path, "{name}" matches any segment. Rules are evaluated in the order they were
+// added and the first rule returning a fault handles the request, requests without a fault are
+// forwarded to the base transport. Random faults use a generator seeded by the caller, so that a
+// failing scenario can be replayed.
+type FaultTransport struct {
+	base http.RoundTripper
+
+	mu       sync.Mutex
+	random   *rand.Rand
+	rules    []*faultRule
+	injected map[string]int
+}
+
+// faultRule is either a fixed sequence of faults or a fault injected with a probability
+type faultRule struct {
+	pattern     string
+	method      string
+	segments    []string
+	probability float64
+	fault       Fault
+	sequence    []Fault
+}
+
+// NewFaultTransport creates a FaultTransport forwarding to base, http.DefaultTransport if base is nil
+func NewFaultTransport(base http.RoundTripper, seed uint64) *FaultTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &FaultTransport{
+		base:     base,
+		random:   rand.New(rand.NewPCG(seed, seed)),
+		injected: make(map[string]int),
+	}
+}
+
+// Sequence handles the next matching requests with the faults in order, a nil fault leaves the
+// request to the following rules. The rule stops matching once the sequence is exhausted.
+func (t *FaultTransport) Sequence(pattern string, faults ...Fault) {
+	t.add(&faultRule{pattern: pattern, sequence: faults})
+}
+
+// Sometimes handles every matching request with the fault with the given probability
+func (t *FaultTransport) Sometimes(pattern string, probability float64, fault Fault) {
+	t.add(&faultRule{pattern: pattern, probability: probability, fault: fault})
+}
+
+// Always handles every matching request with the fault
+func (t *FaultTransport) Always(pattern string, fault Fault) {
+	t.Sometimes(pattern, 1, fault)
+}
+
+// Reset removes all rules, so that requests are forwarded unchanged
+func (t *FaultTransport) Reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.rules = nil
+}
+
+// Injected returns the number of faults injected by the rules with the pattern
+func (t *FaultTransport) Injected(pattern string) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.injected[pattern]
+}
+
+func (t *FaultTransport) add(rule *faultRule) {
+	method, path, ok := strings.Cut(rule.pattern, " ")
+	if !ok {
+		method, path = "", rule.pattern
+	}
+	rule.method = method
+	rule.segments = splitPath(path)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.rules = append(t.rules, rule)
+}
+
+// RoundTrip handles the request with the fault of the first matching rule
+func (t *FaultTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	fault := t.next(req)
+	if fault == nil {
+		return t.base.RoundTrip(req)
+	}
+	return fault(req, t.base)
+}
+
+// next returns the fault for the request, nil if the request is forwarded unchanged
+func (t *FaultTransport) next(req *http.Request) Fault {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, rule := range t.rules {
+		if !rule.matches(req) {
+			continue
+		}
+		var fault Fault
+		if rule.sequence != nil {
+			if len(rule.sequence) == 0 {
+				continue
+			}
+			fault, rule.sequence = rule.sequence[0], rule.sequence[1:]
+		} else if t.random.Float64() < rule.probability {
+			fault = rule.fault
+		}
+		if fault != nil {
+			t.injected[rule.pattern]++
+			return fault
+		}
+	}
+	return nil
+}
+
+// matches reports whether the method and the end of the request path match the rule
+func (r *faultRule) matches(req *http.Request) bool {
+	if r.method != "" && r.method != req.Method {
+		return false
+	}
+	path := splitPath(req.URL.Path)
+	if len(path) < len(r.segments) {
+		return false
+	}
+	path = path[len(path)-len(r.segments):]
+	for i, segment := range r.segments {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			continue
+		}
+		if segment != path[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func splitPath(path string) []string {
+	return strings.FieldsFunc(path, func(c rune) bool { return c == '/' })
+}
+
+// Status answers the request with the status and a Hetzner Cloud error of the code and message.
+// Without a code the body is not JSON, as for errors returned by a load balancer in front of the API.
+func Status(status int, code hcloud.ErrorCode, message string) Fault {
+	return func(req *http.Request, _ http.RoundTripper) (*http.Response, error) {
+		if code == "" {
+			return response(req, status, "text/html", fmt.Sprintf("<html><body><h1>%d %s</h1></body></html>", status, http.StatusText(status))), nil
+		}
+		return errorResponse(req, status, code, message), nil
+	}
+}
+
+// RateLimited answers the request with rate_limit_exceeded and rate limit headers announcing a
+// reset after the delay
+func RateLimited(reset time.Duration) Fault {
+	return func(req *http.Request, _ http.RoundTripper) (*http.Response, error) {
+		resp := errorResponse(req, http.StatusTooManyRequests, hcloud.ErrorCodeRateLimitExceeded, "limit of requests per hour reached")
+		resp.Header.Set("RateLimit-Limit", strconv.Itoa(defaultRateLimit))
+		resp.Header.Set("RateLimit-Remaining", "0")
+		resp.Header.Set("RateLimit-Reset", strconv.FormatInt(time.Now().Add(reset).Unix(), 10))
+		return resp, nil
+	}
+}
+
+// Latency forwards the request after the delay, or fails it if its context ends before
+func Latency(delay time.Duration) Fault {
+	return func(req *http.Request, next http.RoundTripper) (*http.Response, error) {
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-timer.C:
+			return next.RoundTrip(req)
+		}
+	}
+}
+
+// Timeout fails the request with a network timeout as if the connection had stalled
+func Timeout() Fault {
+	return func(*http.Request, http.RoundTripper) (*http.Response, error) {
+		return nil, timeoutError{}
+	}
+}
+
+// ConnectionReset fails the request with a network error that is not a timeout
+func ConnectionReset() Fault {
+	return func(*http.Request, http.RoundTripper) (*http.Response, error) {
+		return nil, errors.New("read: connection reset by peer")
+	}
+}
+
+// Before runs the hook and forwards the request, for example to delete the resource a request
+// refers to on the Server before the request reaches it
+func Before(hook func(req *http.Request)) Fault {
+	return func(req *http.Request, next http.RoundTripper) (*http.Response, error) {
+		hook(req)
+		return next.RoundTrip(req)
+	}
+}
+
+// timeoutError is a net.Error reporting a timeout
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func errorResponse(req *http.Request, status int, code hcloud.ErrorCode, message string) *http.Response {
+	body, _ := json.Marshal(schema.ErrorResponse{Error: schema.Error{Code: string(code), Message: message}})
+	return response(req, status, "application/json", string(body))
+}
+
+func response(req *http.Request, status int, contentType, body string) *http.Response {
+	header := make(http.Header)
+	header.Set("Content-Type", contentType)
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,180 @@
+package fake
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+	"github.com/hetznercloud/hcloud-go/v2/hcloud/schema"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FaultTransport", func() {
+	var server *Server
+	var faults *FaultTransport
+	var client *hcloud.Client
+	var networkID int64
+
+	newClient := func(maxRetries int) *hcloud.Client {
+		return hcloud.NewClient(
+			hcloud.WithToken("fault-token"),
+			hcloud.WithEndpoint(server.URL),
+			hcloud.WithHTTPClient(&http.Client{Transport: faults}),
+			hcloud.WithRetryOpts(hcloud.RetryOpts{BackoffFunc: hcloud.ConstantBackoff(0), MaxRetries: maxRetries}),
+			hcloud.WithPollOpts(hcloud.PollOpts{BackoffFunc: hcloud.ConstantBackoff(time.Millisecond)}),
+		)
+	}
+
+	BeforeEach(func() {
+		server = NewServer()
+		faults = NewFaultTransport(nil, 1)
+		client = newClient(0)
+		networkID = server.AddNetwork(schema.Network{Name: "test-network", IPRange: "10.0.0.0/16"})
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should forward requests without matching rules", func() {
+		faults.Always("GET /zones/{id}", Status(http.StatusInternalServerError, hcloud.ErrorCodeServerError, "broken"))
+
+		network, _, err := client.Network.GetByID(context.Background(), networkID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(network.Name).To(Equal("test-network"))
+		Expect(faults.Injected("GET /zones/{id}")).To(BeZero())
+	})
+
+	It("should inject a fixed sequence of faults and pass requests once it is exhausted", func() {
+		faults.Sequence("GET /networks/{id}",
+			Status(http.StatusServiceUnavailable, hcloud.ErrorCodeServiceError, "try again"),
+			nil,
+			Status(http.StatusLocked, hcloud.ErrorCodeLocked, "locked"),
+		)
+
+		_, _, err := client.Network.GetByID(context.Background(), networkID)
+		Expect(hcloud.IsError(err, hcloud.ErrorCodeServiceError)).To(BeTrue())
+		_, _, err = client.Network.GetByID(context.Background(), networkID)
+		Expect(err).NotTo(HaveOccurred())
+		_, _, err = client.Network.GetByID(context.Background(), networkID)
+		Expect(hcloud.IsError(err, hcloud.ErrorCodeLocked)).To(BeTrue())
+		_, _, err = client.Network.GetByID(context.Background(), networkID)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(faults.Injected("GET /networks/{id}")).To(Equal(2))
+		Expect(server.Requests("GET /networks/{id}")).To(Equal(2))
+	})
+
+	It("should match methods and path segments", func() {
+		faults.Always("DELETE /networks/{id}", Status(http.StatusInternalServerError, hcloud.ErrorCodeServerError, "broken"))
+		faults.Always("/actions", Status(http.StatusInternalServerError, hcloud.ErrorCodeServerError, "broken"))
+
+		_, _, err := client.Network.GetByID(context.Background(), networkID)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = client.Network.Delete(context.Background(), &hcloud.Network{ID: networkID})
+		Expect(hcloud.IsError(err, hcloud.ErrorCodeServerError)).To(BeTrue())
+		_, _, err = client.Action.GetByID(context.Background(), 1)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = client.Action.All(context.Background())
+		Expect(hcloud.IsError(err, hcloud.ErrorCodeServerError)).To(BeTrue())
+	})
+
+	It("should inject faults with a reproducible probability", func() {
+		run := func() []bool {
+			faults = NewFaultTransport(nil, 42)
+			faults.Sometimes("GET /networks/{id}", 0.5, Status(http.StatusInternalServerError, hcloud.ErrorCodeServerError, "broken"))
+			client = newClient(0)
+			var failed []bool
+			for range 40 {
+				_, _, err := client.Network.GetByID(context.Background(), networkID)
+				failed = append(failed, err != nil)
+			}
+			return failed
+		}
+
+		failed := run()
+		Expect(failed).To(ContainElement(true))
+		Expect(failed).To(ContainElement(false))
+		Expect(run()).To(Equal(failed))
+	})
+
+	It("should answer with errors the client retries", func() {
+		client = newClient(3)
+		faults.Sequence("GET /networks/{id}",
+			Status(http.StatusBadGateway, "", ""),
+			Status(http.StatusGatewayTimeout, "", ""),
+			Timeout(),
+		)
+
+		network, _, err := client.Network.GetByID(context.Background(), networkID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(network.ID).To(Equal(networkID))
+		Expect(faults.Injected("GET /networks/{id}")).To(Equal(3))
+	})
+
+	It("should fail requests with network errors", func() {
+		faults.Sequence("GET /networks/{id}", Timeout(), ConnectionReset())
+
+		_, _, err := client.Network.GetByID(context.Background(), networkID)
+		var netErr net.Error
+		Expect(errors.As(err, &netErr)).To(BeTrue())
+		Expect(netErr.Timeout()).To(BeTrue())
+
+		_, _, err = client.Network.GetByID(context.Background(), networkID)
+		Expect(err).To(MatchError(ContainSubstring("connection reset by peer")))
+	})
+
+	It("should answer with rate limit headers", func() {
+		faults.Always("", RateLimited(time.Minute))
+
+		_, response, err := client.Network.GetByID(context.Background(), networkID)
+		Expect(hcloud.IsError(err, hcloud.ErrorCodeRateLimitExceeded)).To(BeTrue())
+		Expect(response.Meta.Ratelimit.Remaining).To(BeZero())
+		Expect(response.Meta.Ratelimit.Reset).To(BeTemporally("~", time.Now().Add(time.Minute), 2*time.Second))
+	})
+
+	It("should delay requests", func() {
+		faults.Always("GET /networks/{id}", Latency(50*time.Millisecond))
+
+		start := time.Now()
+		_, _, err := client.Network.GetByID(context.Background(), networkID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(time.Since(start)).To(BeNumerically(">=", 50*time.Millisecond))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		_, _, err = client.Network.GetByID(ctx, networkID)
+		Expect(err).To(MatchError(context.DeadlineExceeded))
+	})
+
+	It("should change the backend before forwarding a request", func() {
+		faults.Sequence("DELETE /networks/{id}", Before(func(*http.Request) {
+			Expect(server.RemoveNetwork(networkID)).To(BeTrue())
+		}))
+
+		network, _, err := client.Network.GetByID(context.Background(), networkID)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = client.Network.Delete(context.Background(), network)
+		Expect(hcloud.IsError(err, hcloud.ErrorCodeNotFound)).To(BeTrue())
+	})
+
+	It("should fail actions of a command", func() {
+		server.FailActions("change_ip_range", 1, "action_failed", "ip range change failed")
+
+		network := &hcloud.Network{ID: networkID}
+		_, ipRange, _ := net.ParseCIDR("10.0.0.0/15")
+		action, _, err := client.Network.ChangeIPRange(context.Background(), network, hcloud.NetworkChangeIPRangeOpts{IPRange: ipRange})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(action.Status).To(Equal(hcloud.ActionStatusError))
+		Expect(action.ErrorCode).To(Equal("action_failed"))
+		Expect(client.Action.WaitFor(context.Background(), action)).To(MatchError(ContainSubstring("ip range change failed")))
+
+		action, _, err = client.Network.ChangeIPRange(context.Background(), network, hcloud.NetworkChangeIPRangeOpts{IPRange: ipRange})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(action.Status).To(Equal(hcloud.ActionStatusSuccess))
+	})
+})
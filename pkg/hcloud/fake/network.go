@@ -0,0 +1,367 @@
+package fake
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+	"github.com/hetznercloud/hcloud-go/v2/hcloud/schema"
+)
+
+// Network returns a copy of the network with the ID
+func (s *Server) Network(id int64) (schema.Network, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	network, ok := s.networks[id]
+	if !ok {
+		return schema.Network{}, false
+	}
+	return copyNetwork(network), true
+}
+
+// Networks returns copies of all networks ordered by ID
+func (s *Server) Networks() []schema.Network {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	networks := make([]schema.Network, 0, len(s.networks))
+	for _, network := range s.sortedNetworks() {
+		networks = append(networks, copyNetwork(network))
+	}
+	return networks
+}
+
+// AddNetwork stores a network as if it had been created outside of the operator and returns its ID
+func (s *Server) AddNetwork(network schema.Network) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	network.ID = s.id()
+	if network.Created.IsZero() {
+		network.Created = time.Now()
+	}
+	network.Labels = copyLabels(network.Labels)
+	stored := copyNetwork(&network)
+	s.networks[network.ID] = &stored
+	return network.ID
+}
+
+// UpdateNetwork changes a network as if it had been changed outside of the operator, it reports
+// whether the network exists
+func (s *Server) UpdateNetwork(id int64, update func(network *schema.Network)) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	network, ok := s.networks[id]
+	if ok {
+		update(network)
+	}
+	return ok
+}
+
+// RemoveNetwork deletes a network as if it had been deleted outside of the operator and reports
+// whether it existed
+func (s *Server) RemoveNetwork(id int64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.networks[id]
+	delete(s.networks, id)
+	return ok
+}
+
+func (s *Server) sortedNetworks() []*schema.Network {
+	networks := make([]*schema.Network, 0, len(s.networks))
+	for _, network := range s.networks {
+		networks = append(networks, network)
+	}
+	sort.Slice(networks, func(i, j int) bool { return networks[i].ID < networks[j].ID })
+	return networks
+}
+
+func (s *Server) listNetworks(w http.ResponseWriter, r *http.Request) {
+	selector, err := parseLabelSelector(r.URL.Query().Get("label_selector"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, hcloud.ErrorCodeInvalidInput, err.Error())
+		return
+	}
+	name := r.URL.Query().Get("name")
+
+	networks := []schema.Network{}
+	for _, network := range s.sortedNetworks() {
+		if (name == "" || network.Name == name) && selector.matches(network.Labels) {
+			networks = append(networks, copyNetwork(network))
+		}
+	}
+	writeList(w, r, "networks", networks)
+}
+
+func (s *Server) getNetwork(w http.ResponseWriter, r *http.Request) {
+	network, ok := s.lookupNetwork(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, schema.NetworkGetResponse{Network: copyNetwork(network)})
+}
+
+func (s *Server) createNetwork(w http.ResponseWriter, r *http.Request) {
+	var req schema.NetworkCreateRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+	if req.Name == "" {
+		writeError(w, http.StatusBadRequest, hcloud.ErrorCodeInvalidInput, "name is required")
+		return
+	}
+	ipRange, err := parseCIDR(req.IPRange)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, hcloud.ErrorCodeInvalidInput, err.Error())
+		return
+	}
+	for _, network := range s.networks {
+		if network.Name == req.Name {
+			writeError(w, http.StatusConflict, hcloud.ErrorCodeUniquenessError, fmt.Sprintf("network with name %q already exists", req.Name))
+			return
+		}
+	}
+
+	network := &schema.Network{
+		ID:            s.id(),
+		Name:          req.Name,
+		Created:       time.Now(),
+		IPRange:       ipRange.String(),
+		Subnets:       []schema.NetworkSubnet{},
+		Routes:        []schema.NetworkRoute{},
+		Servers:       []int64{},
+		LoadBalancers: []int64{},
+		Labels:        map[string]string{},
+	}
+	if req.Labels != nil {
+		network.Labels = copyLabels(*req.Labels)
+	}
+	for _, subnet := range req.Subnets {
+		if code, message := addSubnet(network, ipRange, subnet); code != "" {
+			writeError(w, statusForCode(code), code, message)
+			return
+		}
+	}
+	network.Routes = append(network.Routes, req.Routes...)
+	s.networks[network.ID] = network
+
+	writeJSON(w, http.StatusCreated, schema.NetworkCreateResponse{Network: copyNetwork(network)})
+}
+
+func (s *Server) updateNetwork(w http.ResponseWriter, r *http.Request) {
+	network, ok := s.lookupNetwork(w, r)
+	if !ok {
+		return
+	}
+	var req schema.NetworkUpdateRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+	if req.Name != "" {
+		network.Name = req.Name
+	}
+	if req.Labels != nil {
+		network.Labels = copyLabels(*req.Labels)
+	}
+	if req.ExposeRoutesToVSwitch != nil {
+		network.ExposeRoutesToVSwitch = *req.ExposeRoutesToVSwitch
+	}
+	writeJSON(w, http.StatusOK, schema.NetworkUpdateResponse{Network: copyNetwork(network)})
+}
+
+func (s *Server) deleteNetwork(w http.ResponseWriter, r *http.Request) {
+	network, ok := s.lookupNetwork(w, r)
+	if !ok {
+		return
+	}
+	if network.Protection.Delete {
+		writeError(w, http.StatusLocked, hcloud.ErrorCodeProtected, "network is delete protected")
+		return
+	}
+	delete(s.networks, network.ID)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) networkAction(w http.ResponseWriter, r *http.Request) {
+	network, ok := s.lookupNetwork(w, r)
+	if !ok {
+		return
+	}
+
+	var code hcloud.ErrorCode
+	var message string
+	command := r.PathValue("action")
+	switch command {
+	case "change_ip_range":
+		var req schema.NetworkActionChangeIPRangeRequest
+		if !readJSON(w, r, &req) {
+			return
+		}
+		code, message = changeIPRange(network, req.IPRange)
+	case "add_subnet":
+		var req schema.NetworkActionAddSubnetRequest
+		if !readJSON(w, r, &req) {
+			return
+		}
+		ipRange, _ := parseCIDR(network.IPRange)
+		code, message = addSubnet(network, ipRange, schema.NetworkSubnet{
+			Type:        req.Type,
+			IPRange:     req.IPRange,
+			NetworkZone: req.NetworkZone,
+			VSwitchID:   req.VSwitchID,
+		})
+	case "delete_subnet":
+		var req schema.NetworkActionDeleteSubnetRequest
+		if !readJSON(w, r, &req) {
+			return
+		}
+		code, message = deleteSubnet(network, req.IPRange)
+	case "add_route":
+		var req schema.NetworkActionAddRouteRequest
+		if !readJSON(w, r, &req) {
+			return
+		}
+		code, message = addRoute(network, schema.NetworkRoute{Destination: req.Destination, Gateway: req.Gateway})
+	case "delete_route":
+		var req schema.NetworkActionDeleteRouteRequest
+		if !readJSON(w, r, &req) {
+			return
+		}
+		code, message = deleteRoute(network, schema.NetworkRoute{Destination: req.Destination, Gateway: req.Gateway})
+	default:
+		writeError(w, http.StatusNotFound, hcloud.ErrorCodeNotFound, "unknown network action "+command)
+		return
+	}
+	if code != "" {
+		writeError(w, statusForCode(code), code, message)
+		return
+	}
+
+	action := s.startAction(command, schema.ActionResourceReference{ID: network.ID, Type: "network"})
+	writeJSON(w, http.StatusCreated, schema.ActionGetResponse{Action: action})
+}
+
+// lookupNetwork returns the network of the request path, it writes an error response if the
+// network does not exist
+func (s *Server) lookupNetwork(w http.ResponseWriter, r *http.Request) (*schema.Network, bool) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return nil, false
+	}
+	network, ok := s.networks[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, hcloud.ErrorCodeNotFound, fmt.Sprintf("network with ID %d not found", id))
+		return nil, false
+	}
+	return network, true
+}
+
+func changeIPRange(network *schema.Network, value string) (hcloud.ErrorCode, string) {
+	ipRange, err := parseCIDR(value)
+	if err != nil {
+		return hcloud.ErrorCodeInvalidInput, err.Error()
+	}
+	current, _ := parseCIDR(network.IPRange)
+	currentSize, _ := current.Mask.Size()
+	size, _ := ipRange.Mask.Size()
+	if size > currentSize || !ipRange.Contains(current.IP) {
+		return hcloud.ErrorCodeInvalidInput, fmt.Sprintf("ip range %s must contain the current ip range %s", ipRange, current)
+	}
+	network.IPRange = ipRange.String()
+	return "", ""
+}
+
+func addSubnet(network *schema.Network, networkRange *net.IPNet, subnet schema.NetworkSubnet) (hcloud.ErrorCode, string) {
+	if subnet.Type == "" || subnet.NetworkZone == "" {
+		return hcloud.ErrorCodeInvalidInput, "type and network_zone are required"
+	}
+	ipRange, err := parseCIDR(subnet.IPRange)
+	if err != nil {
+		return hcloud.ErrorCodeInvalidInput, err.Error()
+	}
+	networkSize, _ := networkRange.Mask.Size()
+	size, _ := ipRange.Mask.Size()
+	if size < networkSize || !networkRange.Contains(ipRange.IP) {
+		return hcloud.ErrorCodeInvalidInput, fmt.Sprintf("subnet %s is not part of the network ip range %s", ipRange, networkRange)
+	}
+	for _, existing := range network.Subnets {
+		existingRange, _ := parseCIDR(existing.IPRange)
+		if existingRange.Contains(ipRange.IP) || ipRange.Contains(existingRange.IP) {
+			return hcloud.ErrorCodeConflict, fmt.Sprintf("subnet %s overlaps with subnet %s", ipRange, existingRange)
+		}
+	}
+
+	subnet.IPRange = ipRange.String()
+	subnet.Gateway = gateway(networkRange)
+	network.Subnets = append(network.Subnets, subnet)
+	return "", ""
+}
+
+func deleteSubnet(network *schema.Network, ipRange string) (hcloud.ErrorCode, string) {
+	for i, subnet := range network.Subnets {
+		if subnet.IPRange == ipRange {
+			network.Subnets = append(network.Subnets[:i:i], network.Subnets[i+1:]...)
+			return "", ""
+		}
+	}
+	return hcloud.ErrorCodeNotFound, fmt.Sprintf("subnet %s not found", ipRange)
+}
+
+func addRoute(network *schema.Network, route schema.NetworkRoute) (hcloud.ErrorCode, string) {
+	if _, err := parseCIDR(route.Destination); err != nil {
+		return hcloud.ErrorCodeInvalidInput, err.Error()
+	}
+	if net.ParseIP(route.Gateway) == nil {
+		return hcloud.ErrorCodeInvalidInput, fmt.Sprintf("invalid gateway %q", route.Gateway)
+	}
+	for _, existing := range network.Routes {
+		if existing.Destination == route.Destination {
+			return hcloud.ErrorCodeUniquenessError, fmt.Sprintf("route to %s already exists", route.Destination)
+		}
+	}
+	network.Routes = append(network.Routes, route)
+	return "", ""
+}
+
+func deleteRoute(network *schema.Network, route schema.NetworkRoute) (hcloud.ErrorCode, string) {
+	for i, existing := range network.Routes {
+		if existing == route {
+			network.Routes = append(network.Routes[:i:i], network.Routes[i+1:]...)
+			return "", ""
+		}
+	}
+	return hcloud.ErrorCodeNotFound, fmt.Sprintf("route to %s via %s not found", route.Destination, route.Gateway)
+}
+
+// gateway returns the first address of the network, which the API assigns as gateway of all subnets
+func gateway(ipRange *net.IPNet) string {
+	ip := ipRange.IP.To4()
+	if ip == nil {
+		return ""
+	}
+	gw := make(net.IP, len(ip))
+	copy(gw, ip)
+	gw[3]++
+	return gw.String()
+}
+
+func parseCIDR(value string) (*net.IPNet, error) {
+	_, ipRange, err := net.ParseCIDR(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ip range %q", value)
+	}
+	return ipRange, nil
+}
+
+// copyNetwork returns a deep copy of the network so that callers cannot change the stored state
+func copyNetwork(network *schema.Network) schema.Network {
+	copied := *network
+	copied.Labels = copyLabels(network.Labels)
+	copied.Subnets = append([]schema.NetworkSubnet{}, network.Subnets...)
+	copied.Routes = append([]schema.NetworkRoute{}, network.Routes...)
+	copied.Servers = append([]int64{}, network.Servers...)
+	copied.LoadBalancers = append([]int64{}, network.LoadBalancers...)
+	return copied
+}
@@ -0,0 +1,111 @@
+package fake
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// labelSelector is a parsed label selector in the syntax of the API, such as
+// "env=prod,tier!=db,owner,!legacy,region in (fsn1,nbg1)"
+type labelSelector []labelRequirement
+
+type labelRequirement struct {
+	key      string
+	operator string
+	values   []string
+}
+
+// parseLabelSelector parses the label_selector query parameter, an empty selector matches everything
+func parseLabelSelector(selector string) (labelSelector, error) {
+	var requirements labelSelector
+	for _, term := range splitSelector(selector) {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+		requirement, err := parseRequirement(term)
+		if err != nil {
+			return nil, err
+		}
+		requirements = append(requirements, requirement)
+	}
+	return requirements, nil
+}
+
+// splitSelector splits the selector at the commas outside of value sets
+func splitSelector(selector string) []string {
+	var terms []string
+	depth, start := 0, 0
+	for i, c := range selector {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				terms = append(terms, selector[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(terms, selector[start:])
+}
+
+func parseRequirement(term string) (labelRequirement, error) {
+	for _, operator := range []string{" notin ", " in "} {
+		if key, set, ok := strings.Cut(term, operator); ok {
+			set = strings.TrimSpace(set)
+			if !strings.HasPrefix(set, "(") || !strings.HasSuffix(set, ")") {
+				return labelRequirement{}, fmt.Errorf("invalid label selector %q", term)
+			}
+			var values []string
+			for _, value := range strings.Split(set[1:len(set)-1], ",") {
+				values = append(values, strings.TrimSpace(value))
+			}
+			return labelRequirement{key: strings.TrimSpace(key), operator: strings.TrimSpace(operator), values: values}, nil
+		}
+	}
+	for _, operator := range []string{"!=", "==", "="} {
+		if key, value, ok := strings.Cut(term, operator); ok {
+			if operator == "==" {
+				operator = "="
+			}
+			return labelRequirement{key: strings.TrimSpace(key), operator: operator, values: []string{strings.TrimSpace(value)}}, nil
+		}
+	}
+	if key, ok := strings.CutPrefix(term, "!"); ok {
+		return labelRequirement{key: strings.TrimSpace(key), operator: "!"}, nil
+	}
+	if strings.ContainsAny(term, " ()") {
+		return labelRequirement{}, fmt.Errorf("invalid label selector %q", term)
+	}
+	return labelRequirement{key: term, operator: "exists"}, nil
+}
+
+// matches reports whether the labels satisfy all requirements of the selector
+func (s labelSelector) matches(labels map[string]string) bool {
+	for _, requirement := range s {
+		value, ok := labels[requirement.key]
+		var matched bool
+		switch requirement.operator {
+		case "=":
+			matched = ok && value == requirement.values[0]
+		case "!=":
+			matched = !ok || value != requirement.values[0]
+		case "in":
+			matched = ok && slices.Contains(requirement.values, value)
+		case "notin":
+			matched = !ok || !slices.Contains(requirement.values, value)
+		case "!":
+			matched = !ok
+		default:
+			matched = ok
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
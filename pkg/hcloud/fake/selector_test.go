@@ -0,0 +1,33 @@
+package fake
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Label selectors", func() {
+	labels := map[string]string{"env": "prod", "region": "fsn1"}
+
+	DescribeTable("matching labels",
+		func(selector string, expected bool) {
+			parsed, err := parseLabelSelector(selector)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed.matches(labels)).To(Equal(expected))
+		},
+		Entry("empty selector", "", true),
+		Entry("equality", "env=prod", true),
+		Entry("double equality", "env==staging", false),
+		Entry("inequality", "env!=prod", false),
+		Entry("inequality of a missing label", "tier!=db", true),
+		Entry("existence", "region", true),
+		Entry("absence", "!region", false),
+		Entry("set membership", "region in (nbg1, fsn1)", true),
+		Entry("set exclusion", "region notin (nbg1,fsn1)", false),
+		Entry("all requirements", "env=prod,region in (fsn1),!legacy", true),
+	)
+
+	It("should reject malformed selectors", func() {
+		_, err := parseLabelSelector("region in fsn1")
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,386 @@
+// Package fake provides a stateful in-process fake of the Hetzner Cloud API for tests.
+//
+// The Server keeps networks, the servers attached to them, DNS zones, RRSets and actions in memory and serves them with the
+// JSON schema of the real API, so the clients of the hcloud package can be exercised end to end,
+// including pagination, label selectors, action polling, rate limit headers and API error codes:
+//
+//	server := fake.NewServer()
+//	defer server.Close()
+//	client := hcloud.NewNetworkClient("token", hcloudgo.WithEndpoint(server.URL))
+//
+// Changes are applied when a request is accepted, the actions started by a request are reported
+// as running for the number of reads set with SetActionPolls before they complete. Actions can be
+// made to end in an error with FailActions, and requests can be disturbed on the client side with
+// a FaultTransport.
+package fake
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+	"github.com/hetznercloud/hcloud-go/v2/hcloud/schema"
+)
+
+const (
+	// defaultRateLimit is the number of requests per hour the fake allows, as for a real project
+	defaultRateLimit = 3600
+	// defaultPerPage is the page size used for list requests without per_page
+	defaultPerPage = 25
+	// maxPerPage is the largest page size accepted by the API
+	maxPerPage = 50
+)
+
+// Server is a fake Hetzner Cloud API served by an httptest.Server
+type Server struct {
+	*httptest.Server
+
+	mu          sync.Mutex
+	nextID      int64
+	actionPolls int
+	networks    map[int64]*schema.Network
+	servers     map[int64]*schema.Server
+	zones       map[int64]*schema.Zone
+	rrsets      map[int64]map[string]*schema.ZoneRRSet
+	actions     map[int64]*action
+	failures    map[string][]schema.ActionError
+
+	rateLimit     int
+	rateRemaining float64
+	rateUpdated   time.Time
+	requests      map[string]int
+}
+
+// action is an action together with the number of reads left until it completes and the
+// error it ends with, if any
+type action struct {
+	schema.Action
+	polls   int
+	failure *schema.ActionError
+}
+
+// NewServer starts a fake Hetzner Cloud API, the server has to be closed by the caller
+func NewServer() *Server {
+	s := NewUnstartedServer()
+	s.Start()
+	return s
+}
+
+// NewUnstartedServer creates a fake Hetzner Cloud API without starting it, so that it can be
+// started with TLS or on a specific listener
+func NewUnstartedServer() *Server {
+	s := &Server{
+		networks:      make(map[int64]*schema.Network),
+		servers:       make(map[int64]*schema.Server),
+		zones:         make(map[int64]*schema.Zone),
+		rrsets:        make(map[int64]map[string]*schema.ZoneRRSet),
+		actions:       make(map[int64]*action),
+		failures:      make(map[string][]schema.ActionError),
+		rateLimit:     defaultRateLimit,
+		rateRemaining: defaultRateLimit,
+		rateUpdated:   time.Now(),
+		requests:      make(map[string]int),
+	}
+	s.Server = httptest.NewUnstartedServer(s.handler())
+	return s
+}
+
+// SetActionPolls sets the number of times actions started afterwards are reported as running
+// before they complete, zero completes actions immediately
+func (s *Server) SetActionPolls(polls int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.actionPolls = polls
+}
+
+// FailActions makes the next count actions of the command, such as "change_ip_range" or
+// "create_zone", end in error status with the code and message. The change requested is applied
+// nonetheless, as the API does not roll back the steps an action completed before it failed.
+func (s *Server) FailActions(command string, count int, code, message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for range count {
+		s.failures[command] = append(s.failures[command], schema.ActionError{Code: code, Message: message})
+	}
+}
+
+// SetRateLimit sets the requests per hour and the requests left in the current hour. Requests
+// beyond the limit are rejected with rate_limit_exceeded until the limit has been refilled.
+func (s *Server) SetRateLimit(limit, remaining int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rateLimit = limit
+	s.rateRemaining = float64(remaining)
+	s.rateUpdated = time.Now()
+}
+
+// Requests returns the number of requests served for a method and path pattern of the API,
+// such as "POST /networks" or "GET /zones/{id}/rrsets/{name}/{type}"
+func (s *Server) Requests(pattern string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests[pattern]
+}
+
+func (s *Server) handler() http.Handler {
+	mux := http.NewServeMux()
+	routes := map[string]func(w http.ResponseWriter, r *http.Request){
+		"GET /actions":                            s.listActions,
+		"GET /actions/{id}":                       s.getAction,
+		"GET /networks":                           s.listNetworks,
+		"POST /networks":                          s.createNetwork,
+		"GET /networks/{id}":                      s.getNetwork,
+		"PUT /networks/{id}":                      s.updateNetwork,
+		"DELETE /networks/{id}":                   s.deleteNetwork,
+		"POST /networks/{id}/actions/{action}":    s.networkAction,
+		"GET /servers/{id}":                       s.getServer,
+		"GET /zones":                              s.listZones,
+		"POST /zones":                             s.createZone,
+		"GET /zones/{id}":                         s.getZone,
+		"PUT /zones/{id}":                         s.updateZone,
+		"DELETE /zones/{id}":                      s.deleteZone,
+		"POST /zones/{id}/actions/{action}":       s.zoneAction,
+		"GET /zones/{id}/rrsets":                  s.listRRSets,
+		"POST /zones/{id}/rrsets":                 s.createRRSet,
+		"GET /zones/{id}/rrsets/{name}/{type}":    s.getRRSet,
+		"PUT /zones/{id}/rrsets/{name}/{type}":    s.updateRRSet,
+		"DELETE /zones/{id}/rrsets/{name}/{type}": s.deleteRRSet,
+		"POST /zones/{id}/rrsets/{name}/{type}/actions/{action}": s.rrsetAction,
+	}
+
+	for pattern, handle := range routes {
+		mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+			s.requests[pattern]++
+			handle(w, r)
+		})
+	}
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, hcloud.ErrorCodeNotFound, "route not found: "+r.Method+" "+r.URL.Path)
+	})
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		authorization := r.Header.Get("Authorization")
+		if token, ok := strings.CutPrefix(authorization, "Bearer "); !ok || token == "" {
+			writeError(w, http.StatusUnauthorized, hcloud.ErrorCodeUnauthorized, "unable to authenticate")
+			return
+		}
+		if !s.takeRequest(w) {
+			writeError(w, http.StatusTooManyRequests, hcloud.ErrorCodeRateLimitExceeded, fmt.Sprintf("limit of %d requests per hour reached", s.rateLimit))
+			return
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+// takeRequest refills the rate limit, sets the rate limit headers and reports whether a request
+// was left, the caller must hold the lock
+func (s *Server) takeRequest(w http.ResponseWriter) bool {
+	now := time.Now()
+	rate := float64(s.rateLimit) / time.Hour.Seconds()
+	s.rateRemaining = min(float64(s.rateLimit), s.rateRemaining+now.Sub(s.rateUpdated).Seconds()*rate)
+	s.rateUpdated = now
+
+	allowed := s.rateRemaining >= 1
+	if allowed {
+		s.rateRemaining--
+	}
+	reset := now
+	if rate > 0 {
+		reset = now.Add(time.Duration((float64(s.rateLimit) - s.rateRemaining) / rate * float64(time.Second)))
+	}
+	w.Header().Set("RateLimit-Limit", strconv.Itoa(s.rateLimit))
+	w.Header().Set("RateLimit-Remaining", strconv.Itoa(int(s.rateRemaining)))
+	w.Header().Set("RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+	return allowed
+}
+
+// id returns the next ID for a resource or action, the caller must hold the lock
+func (s *Server) id() int64 {
+	s.nextID++
+	return s.nextID
+}
+
+// startAction records a new action for the resources, the caller must hold the lock
+func (s *Server) startAction(command string, resources ...schema.ActionResourceReference) schema.Action {
+	a := &action{
+		Action: schema.Action{
+			ID:        s.id(),
+			Status:    string(hcloud.ActionStatusRunning),
+			Command:   command,
+			Started:   time.Now(),
+			Resources: resources,
+		},
+		polls: s.actionPolls,
+	}
+	if failures := s.failures[command]; len(failures) > 0 {
+		a.failure = &failures[0]
+		s.failures[command] = failures[1:]
+	}
+	if a.polls == 0 {
+		a.complete()
+	}
+	s.actions[a.ID] = a
+	return a.Action
+}
+
+// poll advances a running action by one read
+func (a *action) poll() {
+	if a.Status != string(hcloud.ActionStatusRunning) {
+		return
+	}
+	a.polls--
+	if a.polls <= 0 {
+		a.complete()
+		return
+	}
+	a.Progress = min(99, a.Progress+max(1, (100-a.Progress)/(a.polls+1)))
+}
+
+func (a *action) complete() {
+	finished := time.Now()
+	a.Finished = &finished
+	if a.failure != nil {
+		a.Status = string(hcloud.ActionStatusError)
+		a.Error = a.failure
+		return
+	}
+	a.Status = string(hcloud.ActionStatusSuccess)
+	a.Progress = 100
+}
+
+func (s *Server) getAction(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	a, ok := s.actions[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, hcloud.ErrorCodeNotFound, fmt.Sprintf("action with ID %d not found", id))
+		return
+	}
+	a.poll()
+	writeJSON(w, http.StatusOK, schema.ActionGetResponse{Action: a.Action})
+}
+
+func (s *Server) listActions(w http.ResponseWriter, r *http.Request) {
+	ids := r.URL.Query()["id"]
+	if len(ids) == 0 {
+		writeError(w, http.StatusBadRequest, hcloud.ErrorCodeInvalidInput, "filtering by id is required")
+		return
+	}
+	var actions []schema.Action
+	for _, value := range ids {
+		id, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, hcloud.ErrorCodeInvalidInput, "invalid id "+value)
+			return
+		}
+		if a, ok := s.actions[id]; ok {
+			a.poll()
+			actions = append(actions, a.Action)
+		}
+	}
+	sort.Slice(actions, func(i, j int) bool { return actions[i].ID < actions[j].ID })
+	writeList(w, r, "actions", actions)
+}
+
+// pathID parses the numeric ID of the request path, it writes an error response if the ID is invalid
+func pathID(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, hcloud.ErrorCodeInvalidInput, "invalid id "+r.PathValue("id"))
+		return 0, false
+	}
+	return id, true
+}
+
+// readJSON decodes the request body, it writes an error response if the body is invalid
+func readJSON(w http.ResponseWriter, r *http.Request, body any) bool {
+	if err := json.NewDecoder(r.Body).Decode(body); err != nil {
+		writeError(w, http.StatusBadRequest, hcloud.ErrorCodeInvalidInput, "invalid request body: "+err.Error())
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, status int, code hcloud.ErrorCode, message string) {
+	writeJSON(w, status, schema.ErrorResponse{Error: schema.Error{Code: string(code), Message: message}})
+}
+
+// statusForCode returns the HTTP status the API responds with for an error code
+func statusForCode(code hcloud.ErrorCode) int {
+	switch code {
+	case hcloud.ErrorCodeNotFound:
+		return http.StatusNotFound
+	case hcloud.ErrorCodeUniquenessError, hcloud.ErrorCodeConflict:
+		return http.StatusConflict
+	case hcloud.ErrorCodeProtected, hcloud.ErrorCodeLocked:
+		return http.StatusLocked
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+// writeList writes a page of the items as the list response field with the pagination meta
+// of the page and per_page query parameters
+func writeList[T any](w http.ResponseWriter, r *http.Request, field string, items []T) {
+	page, err := strconv.Atoi(r.URL.Query().Get("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+	perPage, err := strconv.Atoi(r.URL.Query().Get("per_page"))
+	if err != nil || perPage < 1 {
+		perPage = defaultPerPage
+	}
+	perPage = min(perPage, maxPerPage)
+
+	lastPage := max(1, (len(items)+perPage-1)/perPage)
+	pagination := schema.MetaPagination{
+		Page:         page,
+		PerPage:      perPage,
+		LastPage:     lastPage,
+		TotalEntries: len(items),
+	}
+	if page > 1 {
+		pagination.PreviousPage = page - 1
+	}
+	if page < lastPage {
+		pagination.NextPage = page + 1
+	}
+
+	start := min(len(items), (page-1)*perPage)
+	end := min(len(items), start+perPage)
+	pageItems := items[start:end]
+	if pageItems == nil {
+		pageItems = []T{}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		field:  pageItems,
+		"meta": schema.Meta{Pagination: &pagination},
+	})
+}
+
+// copyLabels returns a copy of the labels that is never nil, as the API never returns null labels
+func copyLabels(labels map[string]string) map[string]string {
+	copied := make(map[string]string, len(labels))
+	for key, value := range labels {
+		copied[key] = value
+	}
+	return copied
+}
@@ -0,0 +1,562 @@
+package fake
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+	"github.com/hetznercloud/hcloud-go/v2/hcloud/schema"
+)
+
+// defaultZoneTTL is the TTL of zones created without a TTL
+const defaultZoneTTL = 3600
+
+// nameservers are assigned to every primary zone
+var nameservers = []string{"hydrogen.ns.hetzner.com.", "oxygen.ns.hetzner.com.", "helium.ns.hetzner.de."}
+
+// Zone returns a copy of the zone with the ID
+func (s *Server) Zone(id int64) (schema.Zone, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	zone, ok := s.zones[id]
+	if !ok {
+		return schema.Zone{}, false
+	}
+	return copyZone(zone), true
+}
+
+// Zones returns copies of all zones ordered by ID
+func (s *Server) Zones() []schema.Zone {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	zones := make([]schema.Zone, 0, len(s.zones))
+	for _, zone := range s.sortedZones() {
+		zones = append(zones, copyZone(zone))
+	}
+	return zones
+}
+
+// AddZone stores a zone with its SOA and NS RRSets as if it had been created outside of the
+// operator and returns its ID
+func (s *Server) AddZone(zone schema.Zone) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.storeZone(zone).ID
+}
+
+// UpdateZone changes a zone as if it had been changed outside of the operator, it reports
+// whether the zone exists
+func (s *Server) UpdateZone(id int64, update func(zone *schema.Zone)) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	zone, ok := s.zones[id]
+	if ok {
+		update(zone)
+	}
+	return ok
+}
+
+// RemoveZone deletes a zone and its RRSets as if it had been deleted outside of the operator and
+// reports whether it existed
+func (s *Server) RemoveZone(id int64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.zones[id]
+	delete(s.zones, id)
+	delete(s.rrsets, id)
+	return ok
+}
+
+// RRSet returns a copy of an RRSet of the zone
+func (s *Server) RRSet(zoneID int64, name, rrsetType string) (schema.ZoneRRSet, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	rrset, ok := s.rrsets[zoneID][rrsetKey(name, rrsetType)]
+	if !ok {
+		return schema.ZoneRRSet{}, false
+	}
+	return copyRRSet(rrset), true
+}
+
+// RRSets returns copies of all RRSets of the zone ordered by name and type
+func (s *Server) RRSets(zoneID int64) []schema.ZoneRRSet {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	rrsets := []schema.ZoneRRSet{}
+	for _, rrset := range s.sortedRRSets(zoneID) {
+		rrsets = append(rrsets, copyRRSet(rrset))
+	}
+	return rrsets
+}
+
+// AddRRSet stores an RRSet in the zone as if it had been created outside of the operator, it
+// reports whether the zone exists
+func (s *Server) AddRRSet(zoneID int64, rrset schema.ZoneRRSet) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.zones[zoneID]; !ok {
+		return false
+	}
+	s.storeRRSet(zoneID, rrset)
+	return true
+}
+
+// UpdateRRSet changes an RRSet as if it had been changed outside of the operator, it reports
+// whether the RRSet exists
+func (s *Server) UpdateRRSet(zoneID int64, name, rrsetType string, update func(rrset *schema.ZoneRRSet)) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	rrset, ok := s.rrsets[zoneID][rrsetKey(name, rrsetType)]
+	if ok {
+		update(rrset)
+	}
+	return ok
+}
+
+// storeZone stores a new zone with the RRSets the API creates for every primary zone, the caller
+// must hold the lock
+func (s *Server) storeZone(zone schema.Zone) *schema.Zone {
+	zone.ID = s.id()
+	if zone.Created.IsZero() {
+		zone.Created = time.Now()
+	}
+	if zone.TTL == 0 {
+		zone.TTL = defaultZoneTTL
+	}
+	if zone.Mode == "" {
+		zone.Mode = string(hcloud.ZoneModePrimary)
+	}
+	if zone.Status == "" {
+		zone.Status = string(hcloud.ZoneStatusOk)
+	}
+	zone.Labels = copyLabels(zone.Labels)
+	zone.AuthoritativeNameservers.Assigned = slices.Clone(nameservers)
+	stored := copyZone(&zone)
+	s.zones[zone.ID] = &stored
+	s.rrsets[zone.ID] = make(map[string]*schema.ZoneRRSet)
+
+	if zone.Mode == string(hcloud.ZoneModePrimary) {
+		soa := fmt.Sprintf("%s dns.hetzner.com. %d 86400 10800 3600000 3600", nameservers[0], zone.Created.Unix())
+		s.storeRRSet(zone.ID, schema.ZoneRRSet{Name: "@", Type: string(hcloud.ZoneRRSetTypeSOA), Records: []schema.ZoneRRSetRecord{{Value: soa}}})
+		ns := make([]schema.ZoneRRSetRecord, 0, len(nameservers))
+		for _, nameserver := range nameservers {
+			ns = append(ns, schema.ZoneRRSetRecord{Value: nameserver})
+		}
+		s.storeRRSet(zone.ID, schema.ZoneRRSet{Name: "@", Type: string(hcloud.ZoneRRSetTypeNS), Records: ns})
+	}
+	return &stored
+}
+
+// storeRRSet stores an RRSet in an existing zone, the caller must hold the lock
+func (s *Server) storeRRSet(zoneID int64, rrset schema.ZoneRRSet) *schema.ZoneRRSet {
+	rrset.ID = rrsetKey(rrset.Name, rrset.Type)
+	rrset.Zone = zoneID
+	rrset.Labels = copyLabels(rrset.Labels)
+	stored := copyRRSet(&rrset)
+	s.rrsets[zoneID][rrset.ID] = &stored
+	s.zones[zoneID].RecordCount = s.recordCount(zoneID)
+	return &stored
+}
+
+func (s *Server) recordCount(zoneID int64) int {
+	count := 0
+	for _, rrset := range s.rrsets[zoneID] {
+		count += len(rrset.Records)
+	}
+	return count
+}
+
+func (s *Server) sortedZones() []*schema.Zone {
+	zones := make([]*schema.Zone, 0, len(s.zones))
+	for _, zone := range s.zones {
+		zones = append(zones, zone)
+	}
+	sort.Slice(zones, func(i, j int) bool { return zones[i].ID < zones[j].ID })
+	return zones
+}
+
+func (s *Server) sortedRRSets(zoneID int64) []*schema.ZoneRRSet {
+	rrsets := make([]*schema.ZoneRRSet, 0, len(s.rrsets[zoneID]))
+	for _, rrset := range s.rrsets[zoneID] {
+		rrsets = append(rrsets, rrset)
+	}
+	sort.Slice(rrsets, func(i, j int) bool { return rrsets[i].ID < rrsets[j].ID })
+	return rrsets
+}
+
+func (s *Server) listZones(w http.ResponseWriter, r *http.Request) {
+	selector, err := parseLabelSelector(r.URL.Query().Get("label_selector"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, hcloud.ErrorCodeInvalidInput, err.Error())
+		return
+	}
+	name := r.URL.Query().Get("name")
+	mode := r.URL.Query().Get("mode")
+
+	zones := []schema.Zone{}
+	for _, zone := range s.sortedZones() {
+		if (name == "" || zone.Name == name) && (mode == "" || zone.Mode == mode) && selector.matches(zone.Labels) {
+			zones = append(zones, copyZone(zone))
+		}
+	}
+	writeList(w, r, "zones", zones)
+}
+
+func (s *Server) getZone(w http.ResponseWriter, r *http.Request) {
+	zone, ok := s.lookupZone(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, schema.ZoneGetResponse{Zone: copyZone(zone)})
+}
+
+func (s *Server) createZone(w http.ResponseWriter, r *http.Request) {
+	var req schema.ZoneCreateRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+	if req.Name == "" || !strings.Contains(req.Name, ".") {
+		writeError(w, http.StatusBadRequest, hcloud.ErrorCodeInvalidInput, fmt.Sprintf("invalid zone name %q", req.Name))
+		return
+	}
+	if req.Mode != string(hcloud.ZoneModePrimary) && req.Mode != string(hcloud.ZoneModeSecondary) {
+		writeError(w, http.StatusBadRequest, hcloud.ErrorCodeInvalidInput, fmt.Sprintf("invalid zone mode %q", req.Mode))
+		return
+	}
+	if (req.Mode == string(hcloud.ZoneModeSecondary)) != (len(req.PrimaryNameservers) > 0) {
+		writeError(w, http.StatusBadRequest, hcloud.ErrorCodeInvalidInput, "primary nameservers are required for and only allowed for secondary zones")
+		return
+	}
+	for _, zone := range s.zones {
+		if zone.Name == req.Name {
+			writeError(w, http.StatusConflict, hcloud.ErrorCodeUniquenessError, fmt.Sprintf("zone with name %q already exists", req.Name))
+			return
+		}
+	}
+
+	zone := schema.Zone{Name: req.Name, Mode: req.Mode}
+	for _, nameserver := range req.PrimaryNameservers {
+		zone.PrimaryNameservers = append(zone.PrimaryNameservers, primaryNameserver(nameserver.Address, nameserver.Port, nameserver.TSIGAlgorithm, nameserver.TSIGKey))
+	}
+	if req.TTL != nil {
+		zone.TTL = *req.TTL
+	}
+	if req.Labels != nil {
+		zone.Labels = *req.Labels
+	}
+	stored := s.storeZone(zone)
+	for _, rrset := range req.RRSets {
+		s.storeRRSet(stored.ID, schema.ZoneRRSet{Name: rrset.Name, Type: rrset.Type, TTL: rrset.TTL, Records: rrset.Records})
+	}
+
+	action := s.startAction("create_zone", schema.ActionResourceReference{ID: stored.ID, Type: "zone"})
+	writeJSON(w, http.StatusCreated, schema.ZoneCreateResponse{Zone: copyZone(stored), Action: action})
+}
+
+func (s *Server) updateZone(w http.ResponseWriter, r *http.Request) {
+	zone, ok := s.lookupZone(w, r)
+	if !ok {
+		return
+	}
+	var req schema.ZoneUpdateRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+	if req.Labels != nil {
+		zone.Labels = copyLabels(*req.Labels)
+	}
+	writeJSON(w, http.StatusOK, schema.ZoneUpdateResponse{Zone: copyZone(zone)})
+}
+
+func (s *Server) deleteZone(w http.ResponseWriter, r *http.Request) {
+	zone, ok := s.lookupZone(w, r)
+	if !ok {
+		return
+	}
+	if zone.Protection.Delete {
+		writeError(w, http.StatusLocked, hcloud.ErrorCodeProtected, "zone is delete protected")
+		return
+	}
+	delete(s.zones, zone.ID)
+	delete(s.rrsets, zone.ID)
+
+	action := s.startAction("delete_zone", schema.ActionResourceReference{ID: zone.ID, Type: "zone"})
+	writeJSON(w, http.StatusCreated, schema.ActionGetResponse{Action: action})
+}
+
+func (s *Server) zoneAction(w http.ResponseWriter, r *http.Request) {
+	zone, ok := s.lookupZone(w, r)
+	if !ok {
+		return
+	}
+
+	command := r.PathValue("action")
+	switch command {
+	case "change_ttl":
+		var req schema.ZoneChangeTTLRequest
+		if !readJSON(w, r, &req) {
+			return
+		}
+		if req.TTL < 60 {
+			writeError(w, http.StatusBadRequest, hcloud.ErrorCodeInvalidInput, fmt.Sprintf("invalid ttl %d", req.TTL))
+			return
+		}
+		zone.TTL = req.TTL
+	case "change_primary_nameservers":
+		var req schema.ZoneChangePrimaryNameserversRequest
+		if !readJSON(w, r, &req) {
+			return
+		}
+		if zone.Mode != string(hcloud.ZoneModeSecondary) || len(req.PrimaryNameservers) == 0 {
+			writeError(w, http.StatusBadRequest, hcloud.ErrorCodeInvalidInput, "primary nameservers are required for and only allowed for secondary zones")
+			return
+		}
+		zone.PrimaryNameservers = nil
+		for _, nameserver := range req.PrimaryNameservers {
+			zone.PrimaryNameservers = append(zone.PrimaryNameservers, primaryNameserver(nameserver.Address, nameserver.Port, nameserver.TSIGAlgorithm, nameserver.TSIGKey))
+		}
+	case "change_protection":
+		var req schema.ZoneChangeProtectionRequest
+		if !readJSON(w, r, &req) {
+			return
+		}
+		if req.Delete != nil {
+			zone.Protection.Delete = *req.Delete
+		}
+	default:
+		writeError(w, http.StatusNotFound, hcloud.ErrorCodeNotFound, "unknown zone action "+command)
+		return
+	}
+
+	action := s.startAction(command, schema.ActionResourceReference{ID: zone.ID, Type: "zone"})
+	writeJSON(w, http.StatusCreated, schema.ActionGetResponse{Action: action})
+}
+
+func (s *Server) listRRSets(w http.ResponseWriter, r *http.Request) {
+	zone, ok := s.lookupZone(w, r)
+	if !ok {
+		return
+	}
+	selector, err := parseLabelSelector(r.URL.Query().Get("label_selector"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, hcloud.ErrorCodeInvalidInput, err.Error())
+		return
+	}
+	name := r.URL.Query().Get("name")
+	types := r.URL.Query()["type"]
+
+	rrsets := []schema.ZoneRRSet{}
+	for _, rrset := range s.sortedRRSets(zone.ID) {
+		if (name == "" || rrset.Name == name) && (len(types) == 0 || slices.Contains(types, rrset.Type)) && selector.matches(rrset.Labels) {
+			rrsets = append(rrsets, copyRRSet(rrset))
+		}
+	}
+	writeList(w, r, "rrsets", rrsets)
+}
+
+func (s *Server) getRRSet(w http.ResponseWriter, r *http.Request) {
+	rrset, ok := s.lookupRRSet(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, schema.ZoneRRSetGetResponse{RRSet: copyRRSet(rrset)})
+}
+
+func (s *Server) createRRSet(w http.ResponseWriter, r *http.Request) {
+	zone, ok := s.lookupZone(w, r)
+	if !ok {
+		return
+	}
+	var req schema.ZoneRRSetCreateRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+	if req.Name == "" || req.Type == "" {
+		writeError(w, http.StatusBadRequest, hcloud.ErrorCodeInvalidInput, "name and type are required")
+		return
+	}
+	if len(req.Records) == 0 {
+		writeError(w, http.StatusBadRequest, hcloud.ErrorCodeInvalidInput, "at least one record is required")
+		return
+	}
+	if _, ok := s.rrsets[zone.ID][rrsetKey(req.Name, req.Type)]; ok {
+		writeError(w, http.StatusConflict, hcloud.ErrorCodeUniquenessError, fmt.Sprintf("rrset %s %s already exists", req.Name, req.Type))
+		return
+	}
+
+	rrset := schema.ZoneRRSet{Name: req.Name, Type: req.Type, TTL: req.TTL, Records: req.Records}
+	if req.Labels != nil {
+		rrset.Labels = *req.Labels
+	}
+	stored := s.storeRRSet(zone.ID, rrset)
+
+	action := s.startAction("create_rrset", schema.ActionResourceReference{ID: zone.ID, Type: "zone"})
+	writeJSON(w, http.StatusCreated, schema.ZoneRRSetCreateResponse{RRSet: copyRRSet(stored), Action: action})
+}
+
+func (s *Server) updateRRSet(w http.ResponseWriter, r *http.Request) {
+	rrset, ok := s.lookupRRSet(w, r)
+	if !ok {
+		return
+	}
+	var req schema.ZoneRRSetUpdateRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+	if req.Labels != nil {
+		rrset.Labels = copyLabels(*req.Labels)
+	}
+	writeJSON(w, http.StatusOK, schema.ZoneRRSetUpdateResponse{RRSet: copyRRSet(rrset)})
+}
+
+func (s *Server) deleteRRSet(w http.ResponseWriter, r *http.Request) {
+	rrset, ok := s.lookupRRSet(w, r)
+	if !ok {
+		return
+	}
+	if rrset.Protection.Change {
+		writeError(w, http.StatusLocked, hcloud.ErrorCodeProtected, "rrset is change protected")
+		return
+	}
+	delete(s.rrsets[rrset.Zone], rrset.ID)
+	s.zones[rrset.Zone].RecordCount = s.recordCount(rrset.Zone)
+
+	action := s.startAction("delete_rrset", schema.ActionResourceReference{ID: rrset.Zone, Type: "zone"})
+	writeJSON(w, http.StatusCreated, schema.ActionGetResponse{Action: action})
+}
+
+func (s *Server) rrsetAction(w http.ResponseWriter, r *http.Request) {
+	rrset, ok := s.lookupRRSet(w, r)
+	if !ok {
+		return
+	}
+
+	command := r.PathValue("action")
+	if rrset.Protection.Change && command != "change_protection" {
+		writeError(w, http.StatusLocked, hcloud.ErrorCodeProtected, "rrset is change protected")
+		return
+	}
+	switch command {
+	case "set_records":
+		var req schema.ZoneRRSetSetRecordsRequest
+		if !readJSON(w, r, &req) {
+			return
+		}
+		rrset.Records = slices.Clone(req.Records)
+	case "add_records":
+		var req schema.ZoneRRSetAddRecordsRequest
+		if !readJSON(w, r, &req) {
+			return
+		}
+		for _, record := range req.Records {
+			if !slices.ContainsFunc(rrset.Records, func(existing schema.ZoneRRSetRecord) bool { return existing.Value == record.Value }) {
+				rrset.Records = append(rrset.Records, record)
+			}
+		}
+		if req.TTL != nil {
+			rrset.TTL = req.TTL
+		}
+	case "remove_records":
+		var req schema.ZoneRRSetRemoveRecordsRequest
+		if !readJSON(w, r, &req) {
+			return
+		}
+		rrset.Records = slices.DeleteFunc(rrset.Records, func(existing schema.ZoneRRSetRecord) bool {
+			return slices.ContainsFunc(req.Records, func(record schema.ZoneRRSetRecord) bool { return existing.Value == record.Value })
+		})
+	case "change_ttl":
+		var req schema.ZoneRRSetChangeTTLRequest
+		if !readJSON(w, r, &req) {
+			return
+		}
+		rrset.TTL = req.TTL
+	case "change_protection":
+		var req schema.ZoneRRSetChangeProtectionRequest
+		if !readJSON(w, r, &req) {
+			return
+		}
+		if req.Change != nil {
+			rrset.Protection.Change = *req.Change
+		}
+	default:
+		writeError(w, http.StatusNotFound, hcloud.ErrorCodeNotFound, "unknown rrset action "+command)
+		return
+	}
+	s.zones[rrset.Zone].RecordCount = s.recordCount(rrset.Zone)
+
+	action := s.startAction(command, schema.ActionResourceReference{ID: rrset.Zone, Type: "zone"})
+	writeJSON(w, http.StatusCreated, schema.ActionGetResponse{Action: action})
+}
+
+// lookupZone returns the zone of the request path by ID or name, it writes an error response if
+// the zone does not exist
+func (s *Server) lookupZone(w http.ResponseWriter, r *http.Request) (*schema.Zone, bool) {
+	idOrName := r.PathValue("id")
+	if id, err := strconv.ParseInt(idOrName, 10, 64); err == nil {
+		if zone, ok := s.zones[id]; ok {
+			return zone, true
+		}
+	}
+	for _, zone := range s.zones {
+		if zone.Name == idOrName {
+			return zone, true
+		}
+	}
+	writeError(w, http.StatusNotFound, hcloud.ErrorCodeNotFound, fmt.Sprintf("zone %s not found", idOrName))
+	return nil, false
+}
+
+// lookupRRSet returns the RRSet of the request path, it writes an error response if the zone or
+// the RRSet does not exist
+func (s *Server) lookupRRSet(w http.ResponseWriter, r *http.Request) (*schema.ZoneRRSet, bool) {
+	zone, ok := s.lookupZone(w, r)
+	if !ok {
+		return nil, false
+	}
+	rrset, ok := s.rrsets[zone.ID][rrsetKey(r.PathValue("name"), r.PathValue("type"))]
+	if !ok {
+		writeError(w, http.StatusNotFound, hcloud.ErrorCodeNotFound, fmt.Sprintf("rrset %s %s not found", r.PathValue("name"), r.PathValue("type")))
+		return nil, false
+	}
+	return rrset, true
+}
+
+// rrsetKey returns the ID the API uses for an RRSet
+func rrsetKey(name, rrsetType string) string {
+	return name + "/" + rrsetType
+}
+
+// primaryNameserver returns a primary nameserver with the default port when none is given
+func primaryNameserver(address string, port int, tsigAlgorithm, tsigKey string) schema.ZonePrimaryNameserver {
+	if port == 0 {
+		port = 53
+	}
+	return schema.ZonePrimaryNameserver{Address: address, Port: port, TSIGAlgorithm: tsigAlgorithm, TSIGKey: tsigKey}
+}
+
+// copyZone returns a deep copy of the zone so that callers cannot change the stored state
+func copyZone(zone *schema.Zone) schema.Zone {
+	copied := *zone
+	copied.Labels = copyLabels(zone.Labels)
+	copied.PrimaryNameservers = append([]schema.ZonePrimaryNameserver{}, zone.PrimaryNameservers...)
+	copied.AuthoritativeNameservers.Assigned = append([]string{}, zone.AuthoritativeNameservers.Assigned...)
+	copied.AuthoritativeNameservers.Delegated = append([]string{}, zone.AuthoritativeNameservers.Delegated...)
+	return copied
+}
+
+// copyRRSet returns a deep copy of the RRSet so that callers cannot change the stored state
+func copyRRSet(rrset *schema.ZoneRRSet) schema.ZoneRRSet {
+	copied := *rrset
+	copied.Labels = copyLabels(rrset.Labels)
+	copied.Records = append([]schema.ZoneRRSetRecord{}, rrset.Records...)
+	if rrset.TTL != nil {
+		ttl := *rrset.TTL
+		copied.TTL = &ttl
+	}
+	return copied
+}
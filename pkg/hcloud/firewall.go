@@ -0,0 +1,119 @@
+package hcloud
+
+import (
+	"context"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+// FirewallClient is an interface for managing firewalls through the Hetzner Cloud API
+type FirewallClient interface {
+	// Firewall operations
+	GetFirewallById(ctx context.Context, id int64) (*hcloud.Firewall, *hcloud.Response, error)
+	GetFirewallByName(ctx context.Context, name string) (*hcloud.Firewall, *hcloud.Response, error)
+	CreateFirewall(ctx context.Context, name string, rules []hcloud.FirewallRule, labels map[string]string) (*hcloud.Firewall, *hcloud.Response, error)
+	UpdateFirewallLabels(ctx context.Context, firewall *hcloud.Firewall, labels map[string]string) (*hcloud.Firewall, *hcloud.Response, error)
+	SetFirewallRules(ctx context.Context, firewall *hcloud.Firewall, rules []hcloud.FirewallRule) (*hcloud.Firewall, *hcloud.Response, error)
+	DeleteFirewall(ctx context.Context, firewall *hcloud.Firewall) (*hcloud.Response, error)
+	ListFirewalls(ctx context.Context) ([]*hcloud.Firewall, error)
+
+	// Resource operations
+	ApplyFirewallResources(ctx context.Context, firewall *hcloud.Firewall, resources []hcloud.FirewallResource) ([]*hcloud.Action, *hcloud.Response, error)
+	RemoveFirewallResources(ctx context.Context, firewall *hcloud.Firewall, resources []hcloud.FirewallResource) ([]*hcloud.Action, *hcloud.Response, error)
+
+	// Action operations
+	GetAction(ctx context.Context, id int64) (*hcloud.Action, *hcloud.Response, error)
+}
+
+type hcloudFirewallAdapter struct {
+	client *hcloud.Client
+}
+
+// NewFirewallClient creates a new FirewallClient with the provided token. Additional options are
+// passed on to the underlying hcloud-go client. Requests pass the DefaultRateLimiter and every call
+// is recorded in the Hetzner Cloud API metrics.
+func NewFirewallClient(token string, opts ...hcloud.ClientOption) FirewallClient {
+	client := newRateLimitedClient(token, opts...)
+	return InstrumentFirewallClient(&hcloudFirewallAdapter{
+		client: client,
+	})
+}
+
+// GetFirewallById retrieves a firewall by ID
+func (a *hcloudFirewallAdapter) GetFirewallById(ctx context.Context, id int64) (*hcloud.Firewall, *hcloud.Response, error) {
+	return a.client.Firewall.GetByID(ctx, id)
+}
+
+// GetFirewallByName retrieves a firewall by name
+func (a *hcloudFirewallAdapter) GetFirewallByName(ctx context.Context, name string) (*hcloud.Firewall, *hcloud.Response, error) {
+	return a.client.Firewall.GetByName(ctx, name)
+}
+
+// CreateFirewall creates a new firewall with the given rules. The firewall is not applied to any
+// resource, resources are applied with ApplyFirewallResources so that each can be followed on its own.
+func (a *hcloudFirewallAdapter) CreateFirewall(ctx context.Context, name string, rules []hcloud.FirewallRule, labels map[string]string) (*hcloud.Firewall, *hcloud.Response, error) {
+	opts := hcloud.FirewallCreateOpts{
+		Name:   name,
+		Labels: labels,
+		Rules:  rules,
+	}
+	result, resp, err := a.client.Firewall.Create(ctx, opts)
+	if err != nil {
+		return nil, resp, err
+	}
+	if err := a.client.Action.WaitFor(ctx, result.Actions...); err != nil {
+		return nil, resp, err
+	}
+	return result.Firewall, resp, nil
+}
+
+// UpdateFirewallLabels replaces the labels of an existing firewall
+func (a *hcloudFirewallAdapter) UpdateFirewallLabels(ctx context.Context, firewall *hcloud.Firewall, labels map[string]string) (*hcloud.Firewall, *hcloud.Response, error) {
+	opts := hcloud.FirewallUpdateOpts{
+		Labels: labels,
+	}
+	return a.client.Firewall.Update(ctx, firewall, opts)
+}
+
+// SetFirewallRules replaces the rules of an existing firewall, waits until they are in effect on
+// all resources the firewall is applied to and returns the refreshed firewall
+func (a *hcloudFirewallAdapter) SetFirewallRules(ctx context.Context, firewall *hcloud.Firewall, rules []hcloud.FirewallRule) (*hcloud.Firewall, *hcloud.Response, error) {
+	opts := hcloud.FirewallSetRulesOpts{
+		Rules: rules,
+	}
+	actions, resp, err := a.client.Firewall.SetRules(ctx, firewall, opts)
+	if err != nil {
+		return nil, resp, err
+	}
+	if err := a.client.Action.WaitFor(ctx, actions...); err != nil {
+		return nil, resp, err
+	}
+	return a.client.Firewall.GetByID(ctx, firewall.ID)
+}
+
+// DeleteFirewall deletes a firewall, which fails while it is still applied to resources
+func (a *hcloudFirewallAdapter) DeleteFirewall(ctx context.Context, firewall *hcloud.Firewall) (*hcloud.Response, error) {
+	return a.client.Firewall.Delete(ctx, firewall)
+}
+
+// ListFirewalls lists all firewalls
+func (a *hcloudFirewallAdapter) ListFirewalls(ctx context.Context) ([]*hcloud.Firewall, error) {
+	return a.client.Firewall.All(ctx)
+}
+
+// ApplyFirewallResources starts applying the firewall to servers or label selectors and returns
+// the pending actions without waiting for them, they can be followed with GetAction
+func (a *hcloudFirewallAdapter) ApplyFirewallResources(ctx context.Context, firewall *hcloud.Firewall, resources []hcloud.FirewallResource) ([]*hcloud.Action, *hcloud.Response, error) {
+	return a.client.Firewall.ApplyResources(ctx, firewall, resources)
+}
+
+// RemoveFirewallResources starts removing the firewall from servers or label selectors and returns
+// the pending actions without waiting for them, they can be followed with GetAction
+func (a *hcloudFirewallAdapter) RemoveFirewallResources(ctx context.Context, firewall *hcloud.Firewall, resources []hcloud.FirewallResource) ([]*hcloud.Action, *hcloud.Response, error) {
+	return a.client.Firewall.RemoveResources(ctx, firewall, resources)
+}
+
+// GetAction retrieves an action by ID to follow its progress
+func (a *hcloudFirewallAdapter) GetAction(ctx context.Context, id int64) (*hcloud.Action, *hcloud.Response, error) {
+	return a.client.Action.GetByID(ctx, id)
+}
@@ -0,0 +1,101 @@
+package hcloud
+
+import (
+	"context"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+// MockFirewallClient is a mock implementation of the FirewallClient interface for testing
+type MockFirewallClient struct {
+	GetFirewallByIdFunc         func(ctx context.Context, id int64) (*hcloud.Firewall, *hcloud.Response, error)
+	GetFirewallByNameFunc       func(ctx context.Context, name string) (*hcloud.Firewall, *hcloud.Response, error)
+	CreateFirewallFunc          func(ctx context.Context, name string, rules []hcloud.FirewallRule, labels map[string]string) (*hcloud.Firewall, *hcloud.Response, error)
+	UpdateFirewallLabelsFunc    func(ctx context.Context, firewall *hcloud.Firewall, labels map[string]string) (*hcloud.Firewall, *hcloud.Response, error)
+	SetFirewallRulesFunc        func(ctx context.Context, firewall *hcloud.Firewall, rules []hcloud.FirewallRule) (*hcloud.Firewall, *hcloud.Response, error)
+	DeleteFirewallFunc          func(ctx context.Context, firewall *hcloud.Firewall) (*hcloud.Response, error)
+	ListFirewallsFunc           func(ctx context.Context) ([]*hcloud.Firewall, error)
+	ApplyFirewallResourcesFunc  func(ctx context.Context, firewall *hcloud.Firewall, resources []hcloud.FirewallResource) ([]*hcloud.Action, *hcloud.Response, error)
+	RemoveFirewallResourcesFunc func(ctx context.Context, firewall *hcloud.Firewall, resources []hcloud.FirewallResource) ([]*hcloud.Action, *hcloud.Response, error)
+	GetActionFunc               func(ctx context.Context, id int64) (*hcloud.Action, *hcloud.Response, error)
+}
+
+// GetFirewallById calls the mocked GetFirewallByIdFunc
+func (m *MockFirewallClient) GetFirewallById(ctx context.Context, id int64) (*hcloud.Firewall, *hcloud.Response, error) {
+	if m.GetFirewallByIdFunc != nil {
+		return m.GetFirewallByIdFunc(ctx, id)
+	}
+	return nil, nil, nil
+}
+
+// GetFirewallByName calls the mocked GetFirewallByNameFunc
+func (m *MockFirewallClient) GetFirewallByName(ctx context.Context, name string) (*hcloud.Firewall, *hcloud.Response, error) {
+	if m.GetFirewallByNameFunc != nil {
+		return m.GetFirewallByNameFunc(ctx, name)
+	}
+	return nil, nil, nil
+}
+
+// CreateFirewall calls the mocked CreateFirewallFunc
+func (m *MockFirewallClient) CreateFirewall(ctx context.Context, name string, rules []hcloud.FirewallRule, labels map[string]string) (*hcloud.Firewall, *hcloud.Response, error) {
+	if m.CreateFirewallFunc != nil {
+		return m.CreateFirewallFunc(ctx, name, rules, labels)
+	}
+	return nil, nil, nil
+}
+
+// UpdateFirewallLabels calls the mocked UpdateFirewallLabelsFunc
+func (m *MockFirewallClient) UpdateFirewallLabels(ctx context.Context, firewall *hcloud.Firewall, labels map[string]string) (*hcloud.Firewall, *hcloud.Response, error) {
+	if m.UpdateFirewallLabelsFunc != nil {
+		return m.UpdateFirewallLabelsFunc(ctx, firewall, labels)
+	}
+	return nil, nil, nil
+}
+
+// SetFirewallRules calls the mocked SetFirewallRulesFunc
+func (m *MockFirewallClient) SetFirewallRules(ctx context.Context, firewall *hcloud.Firewall, rules []hcloud.FirewallRule) (*hcloud.Firewall, *hcloud.Response, error) {
+	if m.SetFirewallRulesFunc != nil {
+		return m.SetFirewallRulesFunc(ctx, firewall, rules)
+	}
+	return nil, nil, nil
+}
+
+// DeleteFirewall calls the mocked DeleteFirewallFunc
+func (m *MockFirewallClient) DeleteFirewall(ctx context.Context, firewall *hcloud.Firewall) (*hcloud.Response, error) {
+	if m.DeleteFirewallFunc != nil {
+		return m.DeleteFirewallFunc(ctx, firewall)
+	}
+	return nil, nil
+}
+
+// ListFirewalls calls the mocked ListFirewallsFunc
+func (m *MockFirewallClient) ListFirewalls(ctx context.Context) ([]*hcloud.Firewall, error) {
+	if m.ListFirewallsFunc != nil {
+		return m.ListFirewallsFunc(ctx)
+	}
+	return nil, nil
+}
+
+// ApplyFirewallResources calls the mocked ApplyFirewallResourcesFunc
+func (m *MockFirewallClient) ApplyFirewallResources(ctx context.Context, firewall *hcloud.Firewall, resources []hcloud.FirewallResource) ([]*hcloud.Action, *hcloud.Response, error) {
+	if m.ApplyFirewallResourcesFunc != nil {
+		return m.ApplyFirewallResourcesFunc(ctx, firewall, resources)
+	}
+	return nil, nil, nil
+}
+
+// RemoveFirewallResources calls the mocked RemoveFirewallResourcesFunc
+func (m *MockFirewallClient) RemoveFirewallResources(ctx context.Context, firewall *hcloud.Firewall, resources []hcloud.FirewallResource) ([]*hcloud.Action, *hcloud.Response, error) {
+	if m.RemoveFirewallResourcesFunc != nil {
+		return m.RemoveFirewallResourcesFunc(ctx, firewall, resources)
+	}
+	return nil, nil, nil
+}
+
+// GetAction calls the mocked GetActionFunc
+func (m *MockFirewallClient) GetAction(ctx context.Context, id int64) (*hcloud.Action, *hcloud.Response, error) {
+	if m.GetActionFunc != nil {
+		return m.GetActionFunc(ctx, id)
+	}
+	return nil, nil, nil
+}
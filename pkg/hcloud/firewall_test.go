@@ -0,0 +1,83 @@
+package hcloud
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FirewallManager", func() {
+
+	var mockFirewallClient *MockFirewallClient
+	var fc FirewallClient
+
+	BeforeEach(func() {
+		mockFirewallClient = &MockFirewallClient{}
+		fc = FirewallClient(mockFirewallClient)
+	})
+
+	Describe("GetFirewallByName", func() {
+		When("firewall exists", func() {
+			BeforeEach(func() {
+				mockFirewallClient.GetFirewallByNameFunc = func(ctx context.Context, name string) (*hcloud.Firewall, *hcloud.Response, error) {
+					return &hcloud.Firewall{ID: 123, Name: name}, nil, nil
+				}
+			})
+
+			It("should retrieve firewall by name", func() {
+				firewall, _, err := fc.GetFirewallByName(context.Background(), "test-firewall")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(firewall).NotTo(BeNil())
+				Expect(firewall.ID).To(Equal(int64(123)))
+			})
+		})
+
+		When("firewall does not exist", func() {
+			It("should return nil without error", func() {
+				firewall, _, err := fc.GetFirewallByName(context.Background(), "test-firewall")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(firewall).To(BeNil())
+			})
+		})
+	})
+
+	Describe("SetFirewallRules", func() {
+		When("valid rules are provided", func() {
+			BeforeEach(func() {
+				mockFirewallClient.SetFirewallRulesFunc = func(ctx context.Context, firewall *hcloud.Firewall, rules []hcloud.FirewallRule) (*hcloud.Firewall, *hcloud.Response, error) {
+					return &hcloud.Firewall{ID: firewall.ID, Name: firewall.Name, Rules: rules}, nil, nil
+				}
+			})
+
+			It("should replace the firewall rules", func() {
+				port := "22"
+				rules := []hcloud.FirewallRule{{Direction: hcloud.FirewallRuleDirectionIn, Protocol: hcloud.FirewallRuleProtocolTCP, Port: &port}}
+				firewall, _, err := fc.SetFirewallRules(context.Background(), &hcloud.Firewall{ID: 123}, rules)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(firewall.Rules).To(Equal(rules))
+			})
+		})
+	})
+
+	Describe("ApplyFirewallResources", func() {
+		When("API returns an error", func() {
+			BeforeEach(func() {
+				mockFirewallClient.ApplyFirewallResourcesFunc = func(ctx context.Context, firewall *hcloud.Firewall, resources []hcloud.FirewallResource) ([]*hcloud.Action, *hcloud.Response, error) {
+					return nil, nil, errors.New("server not found")
+				}
+			})
+
+			It("should propagate the error", func() {
+				_, _, err := fc.ApplyFirewallResources(context.Background(), &hcloud.Firewall{ID: 123}, []hcloud.FirewallResource{{
+					Type:   hcloud.FirewallResourceTypeServer,
+					Server: &hcloud.FirewallResourceServer{ID: 42},
+				}})
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("server not found"))
+			})
+		})
+	})
+})
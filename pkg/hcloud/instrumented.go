@@ -0,0 +1,533 @@
+package hcloud
+
+import (
+	"context"
+	"net"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+const (
+	// networkClientLabel is the client label of the metrics recorded for NetworkClient calls
+	networkClientLabel = "network"
+	// dnsZoneClientLabel is the client label of the metrics recorded for DnsZoneClient calls
+	dnsZoneClientLabel = "dnszone"
+	// firewallClientLabel is the client label of the metrics recorded for FirewallClient calls
+	firewallClientLabel = "firewall"
+	// serverClientLabel is the client label of the metrics recorded for ServerClient calls
+	serverClientLabel = "server"
+	// serverActionClientLabel is the client label of the metrics recorded for ServerActionClient calls
+	serverActionClientLabel = "serveraction"
+	// volumeClientLabel is the client label of the metrics recorded for VolumeClient calls
+	volumeClientLabel = "volume"
+)
+
+// instrumentedNetworkClient records request count, latency and errors of every call of a NetworkClient
+type instrumentedNetworkClient struct {
+	client NetworkClient
+}
+
+// InstrumentNetworkClient wraps the client so that every call is recorded in the Hetzner Cloud API metrics
+func InstrumentNetworkClient(client NetworkClient) NetworkClient {
+	return &instrumentedNetworkClient{client: client}
+}
+
+func (c *instrumentedNetworkClient) GetNetworkById(ctx context.Context, id int64) (*hcloud.Network, *hcloud.Response, error) {
+	return observeCall(networkClientLabel, "GetNetworkById", func() (*hcloud.Network, *hcloud.Response, error) {
+		return c.client.GetNetworkById(ctx, id)
+	})
+}
+
+func (c *instrumentedNetworkClient) GetNetworkByName(ctx context.Context, name string) (*hcloud.Network, *hcloud.Response, error) {
+	return observeCall(networkClientLabel, "GetNetworkByName", func() (*hcloud.Network, *hcloud.Response, error) {
+		return c.client.GetNetworkByName(ctx, name)
+	})
+}
+
+func (c *instrumentedNetworkClient) CreateNetwork(ctx context.Context, name string, ipRange string, labels map[string]string) (*hcloud.Network, *hcloud.Response, error) {
+	return observeCall(networkClientLabel, "CreateNetwork", func() (*hcloud.Network, *hcloud.Response, error) {
+		return c.client.CreateNetwork(ctx, name, ipRange, labels)
+	})
+}
+
+func (c *instrumentedNetworkClient) UpdateNetworkLabels(ctx context.Context, network *hcloud.Network, labels map[string]string) (*hcloud.Network, *hcloud.Response, error) {
+	return observeCall(networkClientLabel, "UpdateNetworkLabels", func() (*hcloud.Network, *hcloud.Response, error) {
+		return c.client.UpdateNetworkLabels(ctx, network, labels)
+	})
+}
+
+func (c *instrumentedNetworkClient) UpdateNetworkCidr(ctx context.Context, network *hcloud.Network, cidr string) (*hcloud.Action, *hcloud.Response, error) {
+	return observeCall(networkClientLabel, "UpdateNetworkCidr", func() (*hcloud.Action, *hcloud.Response, error) {
+		return c.client.UpdateNetworkCidr(ctx, network, cidr)
+	})
+}
+
+func (c *instrumentedNetworkClient) DeleteNetwork(ctx context.Context, network *hcloud.Network) (*hcloud.Response, error) {
+	return observeResponse(networkClientLabel, "DeleteNetwork", func() (*hcloud.Response, error) {
+		return c.client.DeleteNetwork(ctx, network)
+	})
+}
+
+func (c *instrumentedNetworkClient) ListNetworks(ctx context.Context) ([]*hcloud.Network, error) {
+	return observeList(networkClientLabel, "ListNetworks", func() ([]*hcloud.Network, error) {
+		return c.client.ListNetworks(ctx)
+	})
+}
+
+func (c *instrumentedNetworkClient) VerifyToken(ctx context.Context) (*hcloud.Response, error) {
+	return observeResponse(networkClientLabel, "VerifyToken", func() (*hcloud.Response, error) {
+		return c.client.VerifyToken(ctx)
+	})
+}
+
+func (c *instrumentedNetworkClient) AddNetworkSubnet(ctx context.Context, network *hcloud.Network, subnet hcloud.NetworkSubnet) (*hcloud.Network, *hcloud.Response, error) {
+	return observeCall(networkClientLabel, "AddNetworkSubnet", func() (*hcloud.Network, *hcloud.Response, error) {
+		return c.client.AddNetworkSubnet(ctx, network, subnet)
+	})
+}
+
+func (c *instrumentedNetworkClient) DeleteNetworkSubnet(ctx context.Context, network *hcloud.Network, subnet hcloud.NetworkSubnet) (*hcloud.Network, *hcloud.Response, error) {
+	return observeCall(networkClientLabel, "DeleteNetworkSubnet", func() (*hcloud.Network, *hcloud.Response, error) {
+		return c.client.DeleteNetworkSubnet(ctx, network, subnet)
+	})
+}
+
+func (c *instrumentedNetworkClient) ListNetworkServers(ctx context.Context, network *hcloud.Network) ([]*hcloud.Server, error) {
+	return observeList(networkClientLabel, "ListNetworkServers", func() ([]*hcloud.Server, error) {
+		return c.client.ListNetworkServers(ctx, network)
+	})
+}
+
+func (c *instrumentedNetworkClient) AddNetworkRoute(ctx context.Context, network *hcloud.Network, route hcloud.NetworkRoute) (*hcloud.Network, *hcloud.Response, error) {
+	return observeCall(networkClientLabel, "AddNetworkRoute", func() (*hcloud.Network, *hcloud.Response, error) {
+		return c.client.AddNetworkRoute(ctx, network, route)
+	})
+}
+
+func (c *instrumentedNetworkClient) DeleteNetworkRoute(ctx context.Context, network *hcloud.Network, route hcloud.NetworkRoute) (*hcloud.Network, *hcloud.Response, error) {
+	return observeCall(networkClientLabel, "DeleteNetworkRoute", func() (*hcloud.Network, *hcloud.Response, error) {
+		return c.client.DeleteNetworkRoute(ctx, network, route)
+	})
+}
+
+func (c *instrumentedNetworkClient) GetAction(ctx context.Context, id int64) (*hcloud.Action, *hcloud.Response, error) {
+	return observeCall(networkClientLabel, "GetAction", func() (*hcloud.Action, *hcloud.Response, error) {
+		return c.client.GetAction(ctx, id)
+	})
+}
+
+// instrumentedDnsZoneClient records request count, latency and errors of every call of a DnsZoneClient
+type instrumentedDnsZoneClient struct {
+	client DnsZoneClient
+}
+
+// InstrumentDnsZoneClient wraps the client so that every call is recorded in the Hetzner Cloud API metrics
+func InstrumentDnsZoneClient(client DnsZoneClient) DnsZoneClient {
+	return &instrumentedDnsZoneClient{client: client}
+}
+
+func (c *instrumentedDnsZoneClient) GetZoneById(ctx context.Context, id int64) (*hcloud.Zone, *hcloud.Response, error) {
+	return observeCall(dnsZoneClientLabel, "GetZoneById", func() (*hcloud.Zone, *hcloud.Response, error) {
+		return c.client.GetZoneById(ctx, id)
+	})
+}
+
+func (c *instrumentedDnsZoneClient) GetZoneByName(ctx context.Context, name string) (*hcloud.Zone, *hcloud.Response, error) {
+	return observeCall(dnsZoneClientLabel, "GetZoneByName", func() (*hcloud.Zone, *hcloud.Response, error) {
+		return c.client.GetZoneByName(ctx, name)
+	})
+}
+
+func (c *instrumentedDnsZoneClient) CreateZone(ctx context.Context, name string, mode string, ttl *int, primaryNameservers []hcloud.ZonePrimaryNameserver, labels map[string]string) (hcloud.ZoneCreateResult, *hcloud.Response, error) {
+	return observeCall(dnsZoneClientLabel, "CreateZone", func() (hcloud.ZoneCreateResult, *hcloud.Response, error) {
+		return c.client.CreateZone(ctx, name, mode, ttl, primaryNameservers, labels)
+	})
+}
+
+func (c *instrumentedDnsZoneClient) UpdateZoneLabels(ctx context.Context, zone *hcloud.Zone, labels map[string]string) (*hcloud.Zone, *hcloud.Response, error) {
+	return observeCall(dnsZoneClientLabel, "UpdateZoneLabels", func() (*hcloud.Zone, *hcloud.Response, error) {
+		return c.client.UpdateZoneLabels(ctx, zone, labels)
+	})
+}
+
+func (c *instrumentedDnsZoneClient) UpdateZoneTTL(ctx context.Context, zone *hcloud.Zone, ttl int) (*hcloud.Zone, *hcloud.Response, error) {
+	return observeCall(dnsZoneClientLabel, "UpdateZoneTTL", func() (*hcloud.Zone, *hcloud.Response, error) {
+		return c.client.UpdateZoneTTL(ctx, zone, ttl)
+	})
+}
+
+func (c *instrumentedDnsZoneClient) UpdateZonePrimaryNameservers(ctx context.Context, zone *hcloud.Zone, primaryNameservers []hcloud.ZonePrimaryNameserver) (*hcloud.Zone, *hcloud.Response, error) {
+	return observeCall(dnsZoneClientLabel, "UpdateZonePrimaryNameservers", func() (*hcloud.Zone, *hcloud.Response, error) {
+		return c.client.UpdateZonePrimaryNameservers(ctx, zone, primaryNameservers)
+	})
+}
+
+func (c *instrumentedDnsZoneClient) DeleteZone(ctx context.Context, zone *hcloud.Zone) (*hcloud.Action, *hcloud.Response, error) {
+	return observeCall(dnsZoneClientLabel, "DeleteZone", func() (*hcloud.Action, *hcloud.Response, error) {
+		return c.client.DeleteZone(ctx, zone)
+	})
+}
+
+func (c *instrumentedDnsZoneClient) ListZones(ctx context.Context) ([]*hcloud.Zone, error) {
+	return observeList(dnsZoneClientLabel, "ListZones", func() ([]*hcloud.Zone, error) {
+		return c.client.ListZones(ctx)
+	})
+}
+
+func (c *instrumentedDnsZoneClient) GetRRSet(ctx context.Context, zone *hcloud.Zone, name string, rrsetType string) (*hcloud.ZoneRRSet, *hcloud.Response, error) {
+	return observeCall(dnsZoneClientLabel, "GetRRSet", func() (*hcloud.ZoneRRSet, *hcloud.Response, error) {
+		return c.client.GetRRSet(ctx, zone, name, rrsetType)
+	})
+}
+
+func (c *instrumentedDnsZoneClient) CreateRRSet(ctx context.Context, zone *hcloud.Zone, name string, rrsetType string, ttl *int, records []hcloud.ZoneRRSetRecord, labels map[string]string) (*hcloud.ZoneRRSet, *hcloud.Response, error) {
+	return observeCall(dnsZoneClientLabel, "CreateRRSet", func() (*hcloud.ZoneRRSet, *hcloud.Response, error) {
+		return c.client.CreateRRSet(ctx, zone, name, rrsetType, ttl, records, labels)
+	})
+}
+
+func (c *instrumentedDnsZoneClient) UpdateRRSetRecords(ctx context.Context, rrset *hcloud.ZoneRRSet, records []hcloud.ZoneRRSetRecord) (*hcloud.ZoneRRSet, *hcloud.Response, error) {
+	return observeCall(dnsZoneClientLabel, "UpdateRRSetRecords", func() (*hcloud.ZoneRRSet, *hcloud.Response, error) {
+		return c.client.UpdateRRSetRecords(ctx, rrset, records)
+	})
+}
+
+func (c *instrumentedDnsZoneClient) UpdateRRSetTTL(ctx context.Context, rrset *hcloud.ZoneRRSet, ttl *int) (*hcloud.ZoneRRSet, *hcloud.Response, error) {
+	return observeCall(dnsZoneClientLabel, "UpdateRRSetTTL", func() (*hcloud.ZoneRRSet, *hcloud.Response, error) {
+		return c.client.UpdateRRSetTTL(ctx, rrset, ttl)
+	})
+}
+
+func (c *instrumentedDnsZoneClient) UpdateRRSetLabels(ctx context.Context, rrset *hcloud.ZoneRRSet, labels map[string]string) (*hcloud.ZoneRRSet, *hcloud.Response, error) {
+	return observeCall(dnsZoneClientLabel, "UpdateRRSetLabels", func() (*hcloud.ZoneRRSet, *hcloud.Response, error) {
+		return c.client.UpdateRRSetLabels(ctx, rrset, labels)
+	})
+}
+
+func (c *instrumentedDnsZoneClient) DeleteRRSet(ctx context.Context, rrset *hcloud.ZoneRRSet) (*hcloud.Response, error) {
+	return observeResponse(dnsZoneClientLabel, "DeleteRRSet", func() (*hcloud.Response, error) {
+		return c.client.DeleteRRSet(ctx, rrset)
+	})
+}
+
+func (c *instrumentedDnsZoneClient) ListRRSets(ctx context.Context, zone *hcloud.Zone) ([]*hcloud.ZoneRRSet, error) {
+	return observeList(dnsZoneClientLabel, "ListRRSets", func() ([]*hcloud.ZoneRRSet, error) {
+		return c.client.ListRRSets(ctx, zone)
+	})
+}
+
+func (c *instrumentedDnsZoneClient) GetAction(ctx context.Context, id int64) (*hcloud.Action, *hcloud.Response, error) {
+	return observeCall(dnsZoneClientLabel, "GetAction", func() (*hcloud.Action, *hcloud.Response, error) {
+		return c.client.GetAction(ctx, id)
+	})
+}
+
+// instrumentedFirewallClient records request count, latency and errors of every call of a FirewallClient
+type instrumentedFirewallClient struct {
+	client FirewallClient
+}
+
+// InstrumentFirewallClient wraps the client so that every call is recorded in the Hetzner Cloud API metrics
+func InstrumentFirewallClient(client FirewallClient) FirewallClient {
+	return &instrumentedFirewallClient{client: client}
+}
+
+func (c *instrumentedFirewallClient) GetFirewallById(ctx context.Context, id int64) (*hcloud.Firewall, *hcloud.Response, error) {
+	return observeCall(firewallClientLabel, "GetFirewallById", func() (*hcloud.Firewall, *hcloud.Response, error) {
+		return c.client.GetFirewallById(ctx, id)
+	})
+}
+
+func (c *instrumentedFirewallClient) GetFirewallByName(ctx context.Context, name string) (*hcloud.Firewall, *hcloud.Response, error) {
+	return observeCall(firewallClientLabel, "GetFirewallByName", func() (*hcloud.Firewall, *hcloud.Response, error) {
+		return c.client.GetFirewallByName(ctx, name)
+	})
+}
+
+func (c *instrumentedFirewallClient) CreateFirewall(ctx context.Context, name string, rules []hcloud.FirewallRule, labels map[string]string) (*hcloud.Firewall, *hcloud.Response, error) {
+	return observeCall(firewallClientLabel, "CreateFirewall", func() (*hcloud.Firewall, *hcloud.Response, error) {
+		return c.client.CreateFirewall(ctx, name, rules, labels)
+	})
+}
+
+func (c *instrumentedFirewallClient) UpdateFirewallLabels(ctx context.Context, firewall *hcloud.Firewall, labels map[string]string) (*hcloud.Firewall, *hcloud.Response, error) {
+	return observeCall(firewallClientLabel, "UpdateFirewallLabels", func() (*hcloud.Firewall, *hcloud.Response, error) {
+		return c.client.UpdateFirewallLabels(ctx, firewall, labels)
+	})
+}
+
+func (c *instrumentedFirewallClient) SetFirewallRules(ctx context.Context, firewall *hcloud.Firewall, rules []hcloud.FirewallRule) (*hcloud.Firewall, *hcloud.Response, error) {
+	return observeCall(firewallClientLabel, "SetFirewallRules", func() (*hcloud.Firewall, *hcloud.Response, error) {
+		return c.client.SetFirewallRules(ctx, firewall, rules)
+	})
+}
+
+func (c *instrumentedFirewallClient) DeleteFirewall(ctx context.Context, firewall *hcloud.Firewall) (*hcloud.Response, error) {
+	return observeResponse(firewallClientLabel, "DeleteFirewall", func() (*hcloud.Response, error) {
+		return c.client.DeleteFirewall(ctx, firewall)
+	})
+}
+
+func (c *instrumentedFirewallClient) ListFirewalls(ctx context.Context) ([]*hcloud.Firewall, error) {
+	return observeList(firewallClientLabel, "ListFirewalls", func() ([]*hcloud.Firewall, error) {
+		return c.client.ListFirewalls(ctx)
+	})
+}
+
+func (c *instrumentedFirewallClient) ApplyFirewallResources(ctx context.Context, firewall *hcloud.Firewall, resources []hcloud.FirewallResource) ([]*hcloud.Action, *hcloud.Response, error) {
+	return observeCall(firewallClientLabel, "ApplyFirewallResources", func() ([]*hcloud.Action, *hcloud.Response, error) {
+		return c.client.ApplyFirewallResources(ctx, firewall, resources)
+	})
+}
+
+func (c *instrumentedFirewallClient) RemoveFirewallResources(ctx context.Context, firewall *hcloud.Firewall, resources []hcloud.FirewallResource) ([]*hcloud.Action, *hcloud.Response, error) {
+	return observeCall(firewallClientLabel, "RemoveFirewallResources", func() ([]*hcloud.Action, *hcloud.Response, error) {
+		return c.client.RemoveFirewallResources(ctx, firewall, resources)
+	})
+}
+
+func (c *instrumentedFirewallClient) GetAction(ctx context.Context, id int64) (*hcloud.Action, *hcloud.Response, error) {
+	return observeCall(firewallClientLabel, "GetAction", func() (*hcloud.Action, *hcloud.Response, error) {
+		return c.client.GetAction(ctx, id)
+	})
+}
+
+// instrumentedServerClient records request count, latency and errors of every call of a ServerClient
+type instrumentedServerClient struct {
+	client ServerClient
+}
+
+// InstrumentServerClient wraps the client so that every call is recorded in the Hetzner Cloud API metrics
+func InstrumentServerClient(client ServerClient) ServerClient {
+	return &instrumentedServerClient{client: client}
+}
+
+func (c *instrumentedServerClient) GetServerById(ctx context.Context, id int64) (*hcloud.Server, *hcloud.Response, error) {
+	return observeCall(serverClientLabel, "GetServerById", func() (*hcloud.Server, *hcloud.Response, error) {
+		return c.client.GetServerById(ctx, id)
+	})
+}
+
+func (c *instrumentedServerClient) GetServerByName(ctx context.Context, name string) (*hcloud.Server, *hcloud.Response, error) {
+	return observeCall(serverClientLabel, "GetServerByName", func() (*hcloud.Server, *hcloud.Response, error) {
+		return c.client.GetServerByName(ctx, name)
+	})
+}
+
+func (c *instrumentedServerClient) CreateServer(ctx context.Context, opts hcloud.ServerCreateOpts) (*hcloud.ServerCreateResult, *hcloud.Response, error) {
+	return observeCall(serverClientLabel, "CreateServer", func() (*hcloud.ServerCreateResult, *hcloud.Response, error) {
+		return c.client.CreateServer(ctx, opts)
+	})
+}
+
+func (c *instrumentedServerClient) UpdateServerLabels(ctx context.Context, server *hcloud.Server, labels map[string]string) (*hcloud.Server, *hcloud.Response, error) {
+	return observeCall(serverClientLabel, "UpdateServerLabels", func() (*hcloud.Server, *hcloud.Response, error) {
+		return c.client.UpdateServerLabels(ctx, server, labels)
+	})
+}
+
+func (c *instrumentedServerClient) DeleteServer(ctx context.Context, server *hcloud.Server) (*hcloud.Action, *hcloud.Response, error) {
+	return observeCall(serverClientLabel, "DeleteServer", func() (*hcloud.Action, *hcloud.Response, error) {
+		return c.client.DeleteServer(ctx, server)
+	})
+}
+
+func (c *instrumentedServerClient) ListServers(ctx context.Context) ([]*hcloud.Server, error) {
+	return observeList(serverClientLabel, "ListServers", func() ([]*hcloud.Server, error) {
+		return c.client.ListServers(ctx)
+	})
+}
+
+func (c *instrumentedServerClient) AttachServerToNetwork(ctx context.Context, server *hcloud.Server, network *hcloud.Network, ip net.IP) (*hcloud.Server, *hcloud.Response, error) {
+	return observeCall(serverClientLabel, "AttachServerToNetwork", func() (*hcloud.Server, *hcloud.Response, error) {
+		return c.client.AttachServerToNetwork(ctx, server, network, ip)
+	})
+}
+
+func (c *instrumentedServerClient) DetachServerFromNetwork(ctx context.Context, server *hcloud.Server, network *hcloud.Network) (*hcloud.Server, *hcloud.Response, error) {
+	return observeCall(serverClientLabel, "DetachServerFromNetwork", func() (*hcloud.Server, *hcloud.Response, error) {
+		return c.client.DetachServerFromNetwork(ctx, server, network)
+	})
+}
+
+func (c *instrumentedServerClient) ApplyServerFirewall(ctx context.Context, server *hcloud.Server, firewall *hcloud.Firewall) (*hcloud.Server, *hcloud.Response, error) {
+	return observeCall(serverClientLabel, "ApplyServerFirewall", func() (*hcloud.Server, *hcloud.Response, error) {
+		return c.client.ApplyServerFirewall(ctx, server, firewall)
+	})
+}
+
+func (c *instrumentedServerClient) RemoveServerFirewall(ctx context.Context, server *hcloud.Server, firewall *hcloud.Firewall) (*hcloud.Server, *hcloud.Response, error) {
+	return observeCall(serverClientLabel, "RemoveServerFirewall", func() (*hcloud.Server, *hcloud.Response, error) {
+		return c.client.RemoveServerFirewall(ctx, server, firewall)
+	})
+}
+
+func (c *instrumentedServerClient) GetSSHKeyByName(ctx context.Context, name string) (*hcloud.SSHKey, *hcloud.Response, error) {
+	return observeCall(serverClientLabel, "GetSSHKeyByName", func() (*hcloud.SSHKey, *hcloud.Response, error) {
+		return c.client.GetSSHKeyByName(ctx, name)
+	})
+}
+
+func (c *instrumentedServerClient) GetAction(ctx context.Context, id int64) (*hcloud.Action, *hcloud.Response, error) {
+	return observeCall(serverClientLabel, "GetAction", func() (*hcloud.Action, *hcloud.Response, error) {
+		return c.client.GetAction(ctx, id)
+	})
+}
+
+// instrumentedServerActionClient records request count, latency and errors of every call of a ServerActionClient
+type instrumentedServerActionClient struct {
+	client ServerActionClient
+}
+
+// InstrumentServerActionClient wraps the client so that every call is recorded in the Hetzner Cloud API metrics
+func InstrumentServerActionClient(client ServerActionClient) ServerActionClient {
+	return &instrumentedServerActionClient{client: client}
+}
+
+func (c *instrumentedServerActionClient) GetServerById(ctx context.Context, id int64) (*hcloud.Server, *hcloud.Response, error) {
+	return observeCall(serverActionClientLabel, "GetServerById", func() (*hcloud.Server, *hcloud.Response, error) {
+		return c.client.GetServerById(ctx, id)
+	})
+}
+
+func (c *instrumentedServerActionClient) GetServerByName(ctx context.Context, name string) (*hcloud.Server, *hcloud.Response, error) {
+	return observeCall(serverActionClientLabel, "GetServerByName", func() (*hcloud.Server, *hcloud.Response, error) {
+		return c.client.GetServerByName(ctx, name)
+	})
+}
+
+func (c *instrumentedServerActionClient) PowerOnServer(ctx context.Context, server *hcloud.Server) (*hcloud.Action, *hcloud.Response, error) {
+	return observeCall(serverActionClientLabel, "PowerOnServer", func() (*hcloud.Action, *hcloud.Response, error) {
+		return c.client.PowerOnServer(ctx, server)
+	})
+}
+
+func (c *instrumentedServerActionClient) PowerOffServer(ctx context.Context, server *hcloud.Server) (*hcloud.Action, *hcloud.Response, error) {
+	return observeCall(serverActionClientLabel, "PowerOffServer", func() (*hcloud.Action, *hcloud.Response, error) {
+		return c.client.PowerOffServer(ctx, server)
+	})
+}
+
+func (c *instrumentedServerActionClient) ShutdownServer(ctx context.Context, server *hcloud.Server) (*hcloud.Action, *hcloud.Response, error) {
+	return observeCall(serverActionClientLabel, "ShutdownServer", func() (*hcloud.Action, *hcloud.Response, error) {
+		return c.client.ShutdownServer(ctx, server)
+	})
+}
+
+func (c *instrumentedServerActionClient) RebootServer(ctx context.Context, server *hcloud.Server) (*hcloud.Action, *hcloud.Response, error) {
+	return observeCall(serverActionClientLabel, "RebootServer", func() (*hcloud.Action, *hcloud.Response, error) {
+		return c.client.RebootServer(ctx, server)
+	})
+}
+
+func (c *instrumentedServerActionClient) ResetServer(ctx context.Context, server *hcloud.Server) (*hcloud.Action, *hcloud.Response, error) {
+	return observeCall(serverActionClientLabel, "ResetServer", func() (*hcloud.Action, *hcloud.Response, error) {
+		return c.client.ResetServer(ctx, server)
+	})
+}
+
+func (c *instrumentedServerActionClient) EnableServerRescue(ctx context.Context, server *hcloud.Server, opts hcloud.ServerEnableRescueOpts) (*hcloud.Action, *hcloud.Response, error) {
+	return observeCall(serverActionClientLabel, "EnableServerRescue", func() (*hcloud.Action, *hcloud.Response, error) {
+		return c.client.EnableServerRescue(ctx, server, opts)
+	})
+}
+
+func (c *instrumentedServerActionClient) RebuildServer(ctx context.Context, server *hcloud.Server, image *hcloud.Image) (*hcloud.Action, *hcloud.Response, error) {
+	return observeCall(serverActionClientLabel, "RebuildServer", func() (*hcloud.Action, *hcloud.Response, error) {
+		return c.client.RebuildServer(ctx, server, image)
+	})
+}
+
+func (c *instrumentedServerActionClient) ChangeServerType(ctx context.Context, server *hcloud.Server, opts hcloud.ServerChangeTypeOpts) (*hcloud.Action, *hcloud.Response, error) {
+	return observeCall(serverActionClientLabel, "ChangeServerType", func() (*hcloud.Action, *hcloud.Response, error) {
+		return c.client.ChangeServerType(ctx, server, opts)
+	})
+}
+
+func (c *instrumentedServerActionClient) CreateServerImage(ctx context.Context, server *hcloud.Server, opts hcloud.ServerCreateImageOpts) (*hcloud.ServerCreateImageResult, *hcloud.Response, error) {
+	return observeCall(serverActionClientLabel, "CreateServerImage", func() (*hcloud.ServerCreateImageResult, *hcloud.Response, error) {
+		return c.client.CreateServerImage(ctx, server, opts)
+	})
+}
+
+func (c *instrumentedServerActionClient) GetSSHKeyByName(ctx context.Context, name string) (*hcloud.SSHKey, *hcloud.Response, error) {
+	return observeCall(serverActionClientLabel, "GetSSHKeyByName", func() (*hcloud.SSHKey, *hcloud.Response, error) {
+		return c.client.GetSSHKeyByName(ctx, name)
+	})
+}
+
+func (c *instrumentedServerActionClient) GetAction(ctx context.Context, id int64) (*hcloud.Action, *hcloud.Response, error) {
+	return observeCall(serverActionClientLabel, "GetAction", func() (*hcloud.Action, *hcloud.Response, error) {
+		return c.client.GetAction(ctx, id)
+	})
+}
+
+// instrumentedVolumeClient records request count, latency and errors of every call of a VolumeClient
+type instrumentedVolumeClient struct {
+	client VolumeClient
+}
+
+// InstrumentVolumeClient wraps the client so that every call is recorded in the Hetzner Cloud API metrics
+func InstrumentVolumeClient(client VolumeClient) VolumeClient {
+	return &instrumentedVolumeClient{client: client}
+}
+
+func (c *instrumentedVolumeClient) GetVolumeById(ctx context.Context, id int64) (*hcloud.Volume, *hcloud.Response, error) {
+	return observeCall(volumeClientLabel, "GetVolumeById", func() (*hcloud.Volume, *hcloud.Response, error) {
+		return c.client.GetVolumeById(ctx, id)
+	})
+}
+
+func (c *instrumentedVolumeClient) GetVolumeByName(ctx context.Context, name string) (*hcloud.Volume, *hcloud.Response, error) {
+	return observeCall(volumeClientLabel, "GetVolumeByName", func() (*hcloud.Volume, *hcloud.Response, error) {
+		return c.client.GetVolumeByName(ctx, name)
+	})
+}
+
+func (c *instrumentedVolumeClient) CreateVolume(ctx context.Context, opts hcloud.VolumeCreateOpts) (*hcloud.VolumeCreateResult, *hcloud.Response, error) {
+	return observeCall(volumeClientLabel, "CreateVolume", func() (*hcloud.VolumeCreateResult, *hcloud.Response, error) {
+		return c.client.CreateVolume(ctx, opts)
+	})
+}
+
+func (c *instrumentedVolumeClient) UpdateVolumeLabels(ctx context.Context, volume *hcloud.Volume, labels map[string]string) (*hcloud.Volume, *hcloud.Response, error) {
+	return observeCall(volumeClientLabel, "UpdateVolumeLabels", func() (*hcloud.Volume, *hcloud.Response, error) {
+		return c.client.UpdateVolumeLabels(ctx, volume, labels)
+	})
+}
+
+func (c *instrumentedVolumeClient) ResizeVolume(ctx context.Context, volume *hcloud.Volume, size int) (*hcloud.Action, *hcloud.Response, error) {
+	return observeCall(volumeClientLabel, "ResizeVolume", func() (*hcloud.Action, *hcloud.Response, error) {
+		return c.client.ResizeVolume(ctx, volume, size)
+	})
+}
+
+func (c *instrumentedVolumeClient) DeleteVolume(ctx context.Context, volume *hcloud.Volume) (*hcloud.Response, error) {
+	return observeResponse(volumeClientLabel, "DeleteVolume", func() (*hcloud.Response, error) {
+		return c.client.DeleteVolume(ctx, volume)
+	})
+}
+
+func (c *instrumentedVolumeClient) ListVolumes(ctx context.Context) ([]*hcloud.Volume, error) {
+	return observeList(volumeClientLabel, "ListVolumes", func() ([]*hcloud.Volume, error) {
+		return c.client.ListVolumes(ctx)
+	})
+}
+
+func (c *instrumentedVolumeClient) AttachVolume(ctx context.Context, volume *hcloud.Volume, server *hcloud.Server, automount bool) (*hcloud.Action, *hcloud.Response, error) {
+	return observeCall(volumeClientLabel, "AttachVolume", func() (*hcloud.Action, *hcloud.Response, error) {
+		return c.client.AttachVolume(ctx, volume, server, automount)
+	})
+}
+
+func (c *instrumentedVolumeClient) DetachVolume(ctx context.Context, volume *hcloud.Volume) (*hcloud.Action, *hcloud.Response, error) {
+	return observeCall(volumeClientLabel, "DetachVolume", func() (*hcloud.Action, *hcloud.Response, error) {
+		return c.client.DetachVolume(ctx, volume)
+	})
+}
+
+func (c *instrumentedVolumeClient) GetAction(ctx context.Context, id int64) (*hcloud.Action, *hcloud.Response, error) {
+	return observeCall(volumeClientLabel, "GetAction", func() (*hcloud.Action, *hcloud.Response, error) {
+		return c.client.GetAction(ctx, id)
+	})
+}
@@ -0,0 +1,123 @@
+package hcloud
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// metricsNamespace prefixes all metrics exported by this package
+	metricsNamespace = "hcloud"
+	// rateLimitedErrorCode is reported for requests held back by the RateLimiter
+	rateLimitedErrorCode = "rate_limited"
+	// timeoutErrorCode is reported for requests that were cancelled or timed out
+	timeoutErrorCode = "timeout"
+	// unknownErrorCode is reported for errors without a Hetzner Cloud error code, such as network errors
+	unknownErrorCode = "unknown"
+)
+
+var (
+	apiRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "api",
+		Name:      "requests_total",
+		Help:      "Number of Hetzner Cloud client calls by client and method.",
+	}, []string{"client", "method"})
+
+	apiRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "api",
+		Name:      "request_duration_seconds",
+		Help:      "Latency of Hetzner Cloud client calls by client and method, including retries and pagination.",
+		Buckets:   []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+	}, []string{"client", "method"})
+
+	apiErrorsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "api",
+		Name:      "errors_total",
+		Help:      "Number of failed Hetzner Cloud client calls by client, method and Hetzner Cloud error code.",
+	}, []string{"client", "method", "code"})
+
+	rateLimitRemaining = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "api",
+		Name:      "rate_limit_remaining",
+		Help:      "Requests left in the rate limit of a Hetzner Cloud project as last reported by the API.",
+	}, []string{"project"})
+
+	rateLimitLimit = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "api",
+		Name:      "rate_limit_limit",
+		Help:      "Rate limit of a Hetzner Cloud project in requests per hour as last reported by the API.",
+	}, []string{"project"})
+)
+
+// RegisterMetrics registers the metrics of the Hetzner Cloud clients with the registerer, usually
+// the registry of controller-runtime so they are served on the metrics endpoint of the manager
+func RegisterMetrics(registerer prometheus.Registerer) {
+	registerer.MustRegister(apiRequestsTotal, apiRequestDuration, apiErrorsTotal, rateLimitRemaining, rateLimitLimit)
+}
+
+// observeCall records a client call that returns a result and the API response
+func observeCall[T any](client, method string, call func() (T, *hcloud.Response, error)) (T, *hcloud.Response, error) {
+	start := time.Now()
+	result, resp, err := call()
+	observeRequest(client, method, start, err)
+	return result, resp, err
+}
+
+// observeList records a client call that returns all pages of a list
+func observeList[T any](client, method string, call func() (T, error)) (T, error) {
+	start := time.Now()
+	result, err := call()
+	observeRequest(client, method, start, err)
+	return result, err
+}
+
+// observeResponse records a client call that only returns the API response
+func observeResponse(client, method string, call func() (*hcloud.Response, error)) (*hcloud.Response, error) {
+	start := time.Now()
+	resp, err := call()
+	observeRequest(client, method, start, err)
+	return resp, err
+}
+
+func observeRequest(client, method string, start time.Time, err error) {
+	apiRequestsTotal.WithLabelValues(client, method).Inc()
+	apiRequestDuration.WithLabelValues(client, method).Observe(time.Since(start).Seconds())
+	if err != nil {
+		apiErrorsTotal.WithLabelValues(client, method, errorCode(err)).Inc()
+	}
+}
+
+// errorCode returns the Hetzner Cloud error code of err, or one of the codes of this package for
+// errors that were not returned by the API
+func errorCode(err error) string {
+	var apiErr hcloud.Error
+	if errors.As(err, &apiErr) && apiErr.Code != "" {
+		return string(apiErr.Code)
+	}
+	var rateLimitErr *RateLimitError
+	if errors.As(err, &rateLimitErr) {
+		return rateLimitedErrorCode
+	}
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		return timeoutErrorCode
+	}
+	return unknownErrorCode
+}
+
+// projectLabel identifies the Hetzner Cloud project of a rate limit bucket in metrics without
+// exposing its token
+func projectLabel(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:4])
+}
@@ -0,0 +1,92 @@
+package hcloud
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+var _ = Describe("Metrics", func() {
+	Describe("InstrumentNetworkClient", func() {
+		var mock *MockNetworkClient
+		var client NetworkClient
+
+		BeforeEach(func() {
+			mock = &MockNetworkClient{}
+			client = InstrumentNetworkClient(mock)
+		})
+
+		It("should count calls and record their latency", func() {
+			requests := testutil.ToFloat64(apiRequestsTotal.WithLabelValues(networkClientLabel, "GetNetworkById"))
+
+			_, _, err := client.GetNetworkById(context.Background(), 1)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(testutil.ToFloat64(apiRequestsTotal.WithLabelValues(networkClientLabel, "GetNetworkById"))).To(Equal(requests + 1))
+			Expect(testutil.CollectAndCount(apiRequestDuration)).To(BeNumerically(">=", 1))
+		})
+
+		It("should count errors by Hetzner Cloud error code", func() {
+			mock.DeleteNetworkFunc = func(ctx context.Context, network *hcloud.Network) (*hcloud.Response, error) {
+				return nil, hcloud.Error{Code: hcloud.ErrorCodeConflict, Message: "network is in use"}
+			}
+			conflicts := testutil.ToFloat64(apiErrorsTotal.WithLabelValues(networkClientLabel, "DeleteNetwork", string(hcloud.ErrorCodeConflict)))
+
+			_, err := client.DeleteNetwork(context.Background(), &hcloud.Network{ID: 1})
+			Expect(err).To(HaveOccurred())
+
+			Expect(testutil.ToFloat64(apiErrorsTotal.WithLabelValues(networkClientLabel, "DeleteNetwork", string(hcloud.ErrorCodeConflict)))).To(Equal(conflicts + 1))
+		})
+	})
+
+	Describe("InstrumentDnsZoneClient", func() {
+		It("should count requests held back by the rate limiter", func() {
+			mock := &MockDnsZoneClient{
+				ListZonesFunc: func(ctx context.Context) ([]*hcloud.Zone, error) {
+					return nil, &RateLimitError{}
+				},
+			}
+			rateLimited := testutil.ToFloat64(apiErrorsTotal.WithLabelValues(dnsZoneClientLabel, "ListZones", rateLimitedErrorCode))
+
+			_, err := InstrumentDnsZoneClient(mock).ListZones(context.Background())
+			Expect(err).To(HaveOccurred())
+
+			Expect(testutil.ToFloat64(apiErrorsTotal.WithLabelValues(dnsZoneClientLabel, "ListZones", rateLimitedErrorCode))).To(Equal(rateLimited + 1))
+		})
+	})
+
+	Describe("RateLimiter", func() {
+		It("should export the last reported rate limit of the project", func() {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("RateLimit-Limit", "7200")
+				w.Header().Set("RateLimit-Remaining", "7150")
+			}))
+			defer server.Close()
+
+			request, err := http.NewRequest(http.MethodGet, server.URL, nil)
+			Expect(err).NotTo(HaveOccurred())
+			request.Header.Set("Authorization", "Bearer metrics-token")
+			resp, err := NewRateLimiter().Transport(nil).RoundTrip(request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.Body.Close()).To(Succeed())
+
+			project := projectLabel("Bearer metrics-token")
+			Expect(project).NotTo(ContainSubstring("metrics-token"))
+			Expect(testutil.ToFloat64(rateLimitLimit.WithLabelValues(project))).To(Equal(7200.0))
+			Expect(testutil.ToFloat64(rateLimitRemaining.WithLabelValues(project))).To(Equal(7150.0))
+		})
+	})
+
+	Describe("RegisterMetrics", func() {
+		It("should register all collectors", func() {
+			registry := prometheus.NewRegistry()
+			Expect(func() { RegisterMetrics(registry) }).NotTo(Panic())
+		})
+	})
+})
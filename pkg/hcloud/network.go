@@ -14,21 +14,38 @@ type NetworkClient interface {
 	GetNetworkByName(ctx context.Context, name string) (*hcloud.Network, *hcloud.Response, error)
 	CreateNetwork(ctx context.Context, name string, ipRange string, labels map[string]string) (*hcloud.Network, *hcloud.Response, error)
 	UpdateNetworkLabels(ctx context.Context, network *hcloud.Network, labels map[string]string) (*hcloud.Network, *hcloud.Response, error)
-	UpdateNetworkCidr(ctx context.Context, network *hcloud.Network, cidr string) (*hcloud.Network, *hcloud.Response, error)
+	UpdateNetworkCidr(ctx context.Context, network *hcloud.Network, cidr string) (*hcloud.Action, *hcloud.Response, error)
 	DeleteNetwork(ctx context.Context, network *hcloud.Network) (*hcloud.Response, error)
 	ListNetworks(ctx context.Context) ([]*hcloud.Network, error)
+
+	// Subnet operations
+	AddNetworkSubnet(ctx context.Context, network *hcloud.Network, subnet hcloud.NetworkSubnet) (*hcloud.Network, *hcloud.Response, error)
+	DeleteNetworkSubnet(ctx context.Context, network *hcloud.Network, subnet hcloud.NetworkSubnet) (*hcloud.Network, *hcloud.Response, error)
+	ListNetworkServers(ctx context.Context, network *hcloud.Network) ([]*hcloud.Server, error)
+
+	// Route operations
+	AddNetworkRoute(ctx context.Context, network *hcloud.Network, route hcloud.NetworkRoute) (*hcloud.Network, *hcloud.Response, error)
+	DeleteNetworkRoute(ctx context.Context, network *hcloud.Network, route hcloud.NetworkRoute) (*hcloud.Network, *hcloud.Response, error)
+
+	// Action operations
+	GetAction(ctx context.Context, id int64) (*hcloud.Action, *hcloud.Response, error)
+
+	// Token operations
+	VerifyToken(ctx context.Context) (*hcloud.Response, error)
 }
 
 type hcloudNetworkAdapter struct {
 	client *hcloud.Client
 }
 
-// NewClient creates a new HCloud client with the provided token
-func NewNetworkClient(token string) *hcloudNetworkAdapter {
-	client := hcloud.NewClient(hcloud.WithToken(token))
-	return &hcloudNetworkAdapter{
+// NewClient creates a new HCloud client with the provided token. Additional options, such as an
+// endpoint override or poll interval, are passed on to the underlying hcloud-go client. Requests
+// pass the DefaultRateLimiter and every call is recorded in the Hetzner Cloud API metrics.
+func NewNetworkClient(token string, opts ...hcloud.ClientOption) NetworkClient {
+	client := newRateLimitedClient(token, opts...)
+	return InstrumentNetworkClient(&hcloudNetworkAdapter{
 		client: client,
-	}
+	})
 }
 
 // GetNetworkById retrieves a network by ID
@@ -63,8 +80,9 @@ func (a *hcloudNetworkAdapter) UpdateNetworkLabels(ctx context.Context, network
 	return a.client.Network.Update(ctx, network, opts)
 }
 
-// UpdateNetworkCidr updates the CIDR of an existing network
-func (a *hcloudNetworkAdapter) UpdateNetworkCidr(ctx context.Context, network *hcloud.Network, cidr string) (*hcloud.Network, *hcloud.Response, error) {
+// UpdateNetworkCidr starts changing the CIDR of an existing network and returns the pending action
+// without waiting for it, the action can be followed with GetAction
+func (a *hcloudNetworkAdapter) UpdateNetworkCidr(ctx context.Context, network *hcloud.Network, cidr string) (*hcloud.Action, *hcloud.Response, error) {
 	_, parsedCidr, err := net.ParseCIDR(cidr)
 	if err != nil {
 		return nil, nil, err
@@ -72,29 +90,104 @@ func (a *hcloudNetworkAdapter) UpdateNetworkCidr(ctx context.Context, network *h
 	opts := hcloud.NetworkChangeIPRangeOpts{
 		IPRange: parsedCidr,
 	}
-	action, resp, err := a.client.Network.ChangeIPRange(ctx, network, opts)
+	return a.client.Network.ChangeIPRange(ctx, network, opts)
+}
+
+// DeleteNetwork deletes a network
+func (a *hcloudNetworkAdapter) DeleteNetwork(ctx context.Context, network *hcloud.Network) (*hcloud.Response, error) {
+	return a.client.Network.Delete(ctx, network)
+}
+
+// ListNetworks lists all networks
+func (a *hcloudNetworkAdapter) ListNetworks(ctx context.Context) ([]*hcloud.Network, error) {
+	return a.client.Network.All(ctx)
+}
+
+// VerifyToken checks that the API token is accepted with a single request for one network
+func (a *hcloudNetworkAdapter) VerifyToken(ctx context.Context) (*hcloud.Response, error) {
+	_, response, err := a.client.Network.List(ctx, hcloud.NetworkListOpts{ListOpts: hcloud.ListOpts{Page: 1, PerPage: 1}})
+	return response, err
+}
+
+// AddNetworkSubnet adds a subnet to an existing network
+func (a *hcloudNetworkAdapter) AddNetworkSubnet(ctx context.Context, network *hcloud.Network, subnet hcloud.NetworkSubnet) (*hcloud.Network, *hcloud.Response, error) {
+	opts := hcloud.NetworkAddSubnetOpts{
+		Subnet: subnet,
+	}
+	action, resp, err := a.client.Network.AddSubnet(ctx, network, opts)
 	if err != nil {
 		return nil, resp, err
 	}
-	// Wait for the action to complete
-	err = a.client.Action.WaitFor(ctx, action)
+	return a.waitAndRefresh(ctx, network, action, resp)
+}
+
+// DeleteNetworkSubnet removes a subnet from an existing network
+func (a *hcloudNetworkAdapter) DeleteNetworkSubnet(ctx context.Context, network *hcloud.Network, subnet hcloud.NetworkSubnet) (*hcloud.Network, *hcloud.Response, error) {
+	opts := hcloud.NetworkDeleteSubnetOpts{
+		Subnet: subnet,
+	}
+	action, resp, err := a.client.Network.DeleteSubnet(ctx, network, opts)
 	if err != nil {
 		return nil, resp, err
 	}
-	// Retrieve the updated network
-	updatedNetwork, resp, err := a.client.Network.GetByID(ctx, network.ID)
+	return a.waitAndRefresh(ctx, network, action, resp)
+}
+
+// ListNetworkServers retrieves the servers attached to a network including their private network addresses
+func (a *hcloudNetworkAdapter) ListNetworkServers(ctx context.Context, network *hcloud.Network) ([]*hcloud.Server, error) {
+	servers := make([]*hcloud.Server, 0, len(network.Servers))
+	for _, attached := range network.Servers {
+		server, _, err := a.client.Server.GetByID(ctx, attached.ID)
+		if err != nil {
+			return nil, err
+		}
+		if server != nil {
+			servers = append(servers, server)
+		}
+	}
+	return servers, nil
+}
+
+// AddNetworkRoute adds a static route to an existing network
+func (a *hcloudNetworkAdapter) AddNetworkRoute(ctx context.Context, network *hcloud.Network, route hcloud.NetworkRoute) (*hcloud.Network, *hcloud.Response, error) {
+	opts := hcloud.NetworkAddRouteOpts{
+		Route: route,
+	}
+	action, resp, err := a.client.Network.AddRoute(ctx, network, opts)
 	if err != nil {
 		return nil, resp, err
 	}
-	return updatedNetwork, resp, nil
+	return a.waitAndRefresh(ctx, network, action, resp)
 }
 
-// DeleteNetwork deletes a network
-func (a *hcloudNetworkAdapter) DeleteNetwork(ctx context.Context, network *hcloud.Network) (*hcloud.Response, error) {
-	return a.client.Network.Delete(ctx, network)
+// DeleteNetworkRoute removes a static route from an existing network
+func (a *hcloudNetworkAdapter) DeleteNetworkRoute(ctx context.Context, network *hcloud.Network, route hcloud.NetworkRoute) (*hcloud.Network, *hcloud.Response, error) {
+	opts := hcloud.NetworkDeleteRouteOpts{
+		Route: route,
+	}
+	action, resp, err := a.client.Network.DeleteRoute(ctx, network, opts)
+	if err != nil {
+		return nil, resp, err
+	}
+	return a.waitAndRefresh(ctx, network, action, resp)
 }
 
-// ListNetworks lists all networks
-func (a *hcloudNetworkAdapter) ListNetworks(ctx context.Context) ([]*hcloud.Network, error) {
-	return a.client.Network.All(ctx)
+// GetAction retrieves an action by ID to follow its progress
+func (a *hcloudNetworkAdapter) GetAction(ctx context.Context, id int64) (*hcloud.Action, *hcloud.Response, error) {
+	return a.client.Action.GetByID(ctx, id)
+}
+
+// waitAndRefresh waits for a network action to complete and retrieves the updated network
+func (a *hcloudNetworkAdapter) waitAndRefresh(ctx context.Context, network *hcloud.Network, action *hcloud.Action, resp *hcloud.Response) (*hcloud.Network, *hcloud.Response, error) {
+	// Wait for the action to complete
+	err := a.client.Action.WaitFor(ctx, action)
+	if err != nil {
+		return nil, resp, err
+	}
+	// Retrieve the updated network
+	updatedNetwork, resp, err := a.client.Network.GetByID(ctx, network.ID)
+	if err != nil {
+		return nil, resp, err
+	}
+	return updatedNetwork, resp, nil
 }
@@ -12,9 +12,16 @@ type MockNetworkClient struct {
 	GetNetworkByNameFunc    func(ctx context.Context, name string) (*hcloud.Network, *hcloud.Response, error)
 	CreateNetworkFunc       func(ctx context.Context, name string, ipRange string, labels map[string]string) (*hcloud.Network, *hcloud.Response, error)
 	UpdateNetworkLabelsFunc func(ctx context.Context, network *hcloud.Network, labels map[string]string) (*hcloud.Network, *hcloud.Response, error)
-	UpdateNetworkCidrFunc   func(ctx context.Context, network *hcloud.Network, cidr string) (*hcloud.Network, *hcloud.Response, error)
+	UpdateNetworkCidrFunc   func(ctx context.Context, network *hcloud.Network, cidr string) (*hcloud.Action, *hcloud.Response, error)
 	DeleteNetworkFunc       func(ctx context.Context, network *hcloud.Network) (*hcloud.Response, error)
 	ListNetworksFunc        func(ctx context.Context) ([]*hcloud.Network, error)
+	VerifyTokenFunc         func(ctx context.Context) (*hcloud.Response, error)
+	AddNetworkSubnetFunc    func(ctx context.Context, network *hcloud.Network, subnet hcloud.NetworkSubnet) (*hcloud.Network, *hcloud.Response, error)
+	DeleteNetworkSubnetFunc func(ctx context.Context, network *hcloud.Network, subnet hcloud.NetworkSubnet) (*hcloud.Network, *hcloud.Response, error)
+	ListNetworkServersFunc  func(ctx context.Context, network *hcloud.Network) ([]*hcloud.Server, error)
+	AddNetworkRouteFunc     func(ctx context.Context, network *hcloud.Network, route hcloud.NetworkRoute) (*hcloud.Network, *hcloud.Response, error)
+	DeleteNetworkRouteFunc  func(ctx context.Context, network *hcloud.Network, route hcloud.NetworkRoute) (*hcloud.Network, *hcloud.Response, error)
+	GetActionFunc           func(ctx context.Context, id int64) (*hcloud.Action, *hcloud.Response, error)
 }
 
 // GetNetworkById calls the mocked GetNetworkFunc
@@ -50,7 +57,7 @@ func (m *MockNetworkClient) UpdateNetworkLabels(ctx context.Context, network *hc
 }
 
 // UpdateNetworkCidr calls the mocked UpdateNetworkCidrFunc
-func (m *MockNetworkClient) UpdateNetworkCidr(ctx context.Context, network *hcloud.Network, cidr string) (*hcloud.Network, *hcloud.Response, error) {
+func (m *MockNetworkClient) UpdateNetworkCidr(ctx context.Context, network *hcloud.Network, cidr string) (*hcloud.Action, *hcloud.Response, error) {
 	if m.UpdateNetworkCidrFunc != nil {
 		return m.UpdateNetworkCidrFunc(ctx, network, cidr)
 	}
@@ -72,3 +79,59 @@ func (m *MockNetworkClient) ListNetworks(ctx context.Context) ([]*hcloud.Network
 	}
 	return nil, nil
 }
+
+// VerifyToken calls the mocked VerifyTokenFunc
+func (m *MockNetworkClient) VerifyToken(ctx context.Context) (*hcloud.Response, error) {
+	if m.VerifyTokenFunc != nil {
+		return m.VerifyTokenFunc(ctx)
+	}
+	return nil, nil
+}
+
+// AddNetworkSubnet calls the mocked AddNetworkSubnetFunc
+func (m *MockNetworkClient) AddNetworkSubnet(ctx context.Context, network *hcloud.Network, subnet hcloud.NetworkSubnet) (*hcloud.Network, *hcloud.Response, error) {
+	if m.AddNetworkSubnetFunc != nil {
+		return m.AddNetworkSubnetFunc(ctx, network, subnet)
+	}
+	return nil, nil, nil
+}
+
+// DeleteNetworkSubnet calls the mocked DeleteNetworkSubnetFunc
+func (m *MockNetworkClient) DeleteNetworkSubnet(ctx context.Context, network *hcloud.Network, subnet hcloud.NetworkSubnet) (*hcloud.Network, *hcloud.Response, error) {
+	if m.DeleteNetworkSubnetFunc != nil {
+		return m.DeleteNetworkSubnetFunc(ctx, network, subnet)
+	}
+	return nil, nil, nil
+}
+
+// ListNetworkServers calls the mocked ListNetworkServersFunc
+func (m *MockNetworkClient) ListNetworkServers(ctx context.Context, network *hcloud.Network) ([]*hcloud.Server, error) {
+	if m.ListNetworkServersFunc != nil {
+		return m.ListNetworkServersFunc(ctx, network)
+	}
+	return nil, nil
+}
+
+// AddNetworkRoute calls the mocked AddNetworkRouteFunc
+func (m *MockNetworkClient) AddNetworkRoute(ctx context.Context, network *hcloud.Network, route hcloud.NetworkRoute) (*hcloud.Network, *hcloud.Response, error) {
+	if m.AddNetworkRouteFunc != nil {
+		return m.AddNetworkRouteFunc(ctx, network, route)
+	}
+	return nil, nil, nil
+}
+
+// DeleteNetworkRoute calls the mocked DeleteNetworkRouteFunc
+func (m *MockNetworkClient) DeleteNetworkRoute(ctx context.Context, network *hcloud.Network, route hcloud.NetworkRoute) (*hcloud.Network, *hcloud.Response, error) {
+	if m.DeleteNetworkRouteFunc != nil {
+		return m.DeleteNetworkRouteFunc(ctx, network, route)
+	}
+	return nil, nil, nil
+}
+
+// GetAction calls the mocked GetActionFunc
+func (m *MockNetworkClient) GetAction(ctx context.Context, id int64) (*hcloud.Action, *hcloud.Response, error) {
+	if m.GetActionFunc != nil {
+		return m.GetActionFunc(ctx, id)
+	}
+	return nil, nil, nil
+}
@@ -131,14 +131,11 @@ var _ = Describe("NetworkManager", func() {
 	Describe("UpdateNetworkCidr", func() {
 		When("valid update options are provided", func() {
 			BeforeEach(func() {
-				_, expectedCidr, err := net.ParseCIDR("10.0.0.0/8")
-				Expect(err).NotTo(HaveOccurred())
-
-				mockNetworkClient.UpdateNetworkCidrFunc = func(ctx context.Context, network *hcloud.Network, cidr string) (*hcloud.Network, *hcloud.Response, error) {
-					return &hcloud.Network{
-						ID:      network.ID,
-						Name:    network.Name,
-						IPRange: expectedCidr,
+				mockNetworkClient.UpdateNetworkCidrFunc = func(ctx context.Context, network *hcloud.Network, cidr string) (*hcloud.Action, *hcloud.Response, error) {
+					return &hcloud.Action{
+						ID:      77,
+						Status:  hcloud.ActionStatusRunning,
+						Command: "change_ip_range",
 					}, nil, nil
 				}
 			})
@@ -152,16 +149,17 @@ var _ = Describe("NetworkManager", func() {
 				Name:    "update-cidr-network",
 				IPRange: oldIpRange,
 			}
-			It("should update network cidr", func() {
-				updatedNetwork, _, err := nc.UpdateNetworkCidr(context.Background(), network, newIpRange.String())
+			It("should return the pending action", func() {
+				action, _, err := nc.UpdateNetworkCidr(context.Background(), network, newIpRange.String())
 				Expect(err).NotTo(HaveOccurred())
-				Expect(updatedNetwork.IPRange).To(Equal(newIpRange))
+				Expect(action.ID).To(Equal(int64(77)))
+				Expect(action.Status).To(Equal(hcloud.ActionStatusRunning))
 			})
 		})
 
 		When("API returns an error", func() {
 			BeforeEach(func() {
-				mockNetworkClient.UpdateNetworkCidrFunc = func(ctx context.Context, network *hcloud.Network, cidr string) (*hcloud.Network, *hcloud.Response, error) {
+				mockNetworkClient.UpdateNetworkCidrFunc = func(ctx context.Context, network *hcloud.Network, cidr string) (*hcloud.Action, *hcloud.Response, error) {
 					return nil, nil, errors.New("update failed")
 				}
 			})
@@ -184,6 +182,105 @@ var _ = Describe("NetworkManager", func() {
 		})
 	})
 
+	Describe("AddNetworkSubnet", func() {
+		When("the subnet is valid", func() {
+			BeforeEach(func() {
+				mockNetworkClient.AddNetworkSubnetFunc = func(ctx context.Context, network *hcloud.Network, subnet hcloud.NetworkSubnet) (*hcloud.Network, *hcloud.Response, error) {
+					network.Subnets = append(network.Subnets, subnet)
+					return network, nil, nil
+				}
+			})
+
+			It("should add the subnet to the network", func() {
+				_, ipRange, err := net.ParseCIDR("10.1.0.0/16")
+				Expect(err).NotTo(HaveOccurred())
+				network := &hcloud.Network{ID: 123, Name: "subnet-network"}
+				subnet := hcloud.NetworkSubnet{
+					Type:        hcloud.NetworkSubnetTypeCloud,
+					NetworkZone: hcloud.NetworkZoneEUCentral,
+					IPRange:     ipRange,
+				}
+
+				updatedNetwork, _, err := nc.AddNetworkSubnet(context.Background(), network, subnet)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(updatedNetwork.Subnets).To(HaveLen(1))
+				Expect(updatedNetwork.Subnets[0].IPRange).To(Equal(ipRange))
+			})
+		})
+
+		When("API returns an error", func() {
+			BeforeEach(func() {
+				mockNetworkClient.AddNetworkSubnetFunc = func(ctx context.Context, network *hcloud.Network, subnet hcloud.NetworkSubnet) (*hcloud.Network, *hcloud.Response, error) {
+					return nil, nil, errors.New("add subnet failed")
+				}
+			})
+
+			It("should propagate the error", func() {
+				_, _, err := nc.AddNetworkSubnet(context.Background(), &hcloud.Network{ID: 123}, hcloud.NetworkSubnet{})
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("add subnet failed"))
+			})
+		})
+	})
+
+	Describe("DeleteNetworkSubnet", func() {
+		When("API returns an error", func() {
+			BeforeEach(func() {
+				mockNetworkClient.DeleteNetworkSubnetFunc = func(ctx context.Context, network *hcloud.Network, subnet hcloud.NetworkSubnet) (*hcloud.Network, *hcloud.Response, error) {
+					return nil, nil, errors.New("delete subnet failed")
+				}
+			})
+
+			It("should propagate the error", func() {
+				_, _, err := nc.DeleteNetworkSubnet(context.Background(), &hcloud.Network{ID: 123}, hcloud.NetworkSubnet{})
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("delete subnet failed"))
+			})
+		})
+	})
+
+	Describe("AddNetworkRoute", func() {
+		When("the route is valid", func() {
+			BeforeEach(func() {
+				mockNetworkClient.AddNetworkRouteFunc = func(ctx context.Context, network *hcloud.Network, route hcloud.NetworkRoute) (*hcloud.Network, *hcloud.Response, error) {
+					network.Routes = append(network.Routes, route)
+					return network, nil, nil
+				}
+			})
+
+			It("should add the route to the network", func() {
+				_, destination, err := net.ParseCIDR("0.0.0.0/0")
+				Expect(err).NotTo(HaveOccurred())
+				network := &hcloud.Network{ID: 123, Name: "route-network"}
+				route := hcloud.NetworkRoute{
+					Destination: destination,
+					Gateway:     net.ParseIP("10.0.0.2"),
+				}
+
+				updatedNetwork, _, err := nc.AddNetworkRoute(context.Background(), network, route)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(updatedNetwork.Routes).To(HaveLen(1))
+				Expect(updatedNetwork.Routes[0].Gateway.String()).To(Equal("10.0.0.2"))
+			})
+		})
+	})
+
+	Describe("DeleteNetworkRoute", func() {
+		When("API returns an error", func() {
+			BeforeEach(func() {
+				mockNetworkClient.DeleteNetworkRouteFunc = func(ctx context.Context, network *hcloud.Network, route hcloud.NetworkRoute) (*hcloud.Network, *hcloud.Response, error) {
+					return nil, nil, errors.New("delete route failed")
+				}
+			})
+
+			It("should propagate the error", func() {
+				_, _, err := nc.DeleteNetworkRoute(context.Background(), &hcloud.Network{ID: 123}, hcloud.NetworkRoute{})
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("delete route failed"))
+			})
+		})
+	})
+
 	Describe("DeleteNetwork", func() {
 		When("network exists", func() {
 			BeforeEach(func() {
@@ -0,0 +1,193 @@
+package hcloud
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+const (
+	// defaultRateLimit is the number of requests per hour a Hetzner Cloud project may make
+	defaultRateLimit = 3600
+	// defaultMaxWait is the longest a request is delayed before it fails with a RateLimitError
+	defaultMaxWait = 5 * time.Second
+	// rateLimitRetryAfter is used when the API rejected a request without rate limit headers
+	rateLimitRetryAfter = 10 * time.Second
+)
+
+// DefaultRateLimiter is shared by all clients created by this package
+var DefaultRateLimiter = NewRateLimiter()
+
+// RateLimitError is returned for requests that are not sent because the rate limit of the
+// Hetzner Cloud project is exhausted
+type RateLimitError struct {
+	// Reset is the time at which the next request is allowed
+	Reset time.Time
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("hetzner cloud rate limit exhausted until %s", e.Reset.Format(time.RFC3339))
+}
+
+// RateLimitReset reports whether err was caused by an exhausted rate limit and returns the time at
+// which the next request is allowed. Both requests held back by the RateLimiter and requests
+// rejected by the API with rate_limit_exceeded are recognised.
+func RateLimitReset(err error) (time.Time, bool) {
+	var rateLimitErr *RateLimitError
+	if errors.As(err, &rateLimitErr) {
+		return rateLimitErr.Reset, true
+	}
+	var apiErr hcloud.Error
+	if !errors.As(err, &apiErr) || apiErr.Code != hcloud.ErrorCodeRateLimitExceeded {
+		return time.Time{}, false
+	}
+	if resp := apiErr.Response(); resp != nil && resp.Meta.Ratelimit.Limit > 0 && !resp.Meta.Ratelimit.Reset.IsZero() {
+		// The reset header is the time at which the limit is refilled completely, a single
+		// request is allowed once the first request of the limit has been refilled
+		ratelimit := resp.Meta.Ratelimit
+		refill := time.Hour / time.Duration(ratelimit.Limit)
+		reset := ratelimit.Reset.Add(-time.Duration(ratelimit.Limit-1) * refill)
+		if reset.After(time.Now()) {
+			return reset, true
+		}
+	}
+	return time.Now().Add(rateLimitRetryAfter), true
+}
+
+// RateLimiter is a token bucket per Hetzner Cloud project. Buckets refill at the rate of the
+// project limit and follow the RateLimit-Limit and RateLimit-Remaining headers of every response,
+// so requests are delayed before the limit is exhausted instead of being rejected by the API.
+type RateLimiter struct {
+	// MaxWait is the longest a request is delayed, requests that would have to wait longer
+	// fail with a RateLimitError
+	MaxWait time.Duration
+
+	mu      sync.Mutex
+	buckets map[string]*rateLimitBucket
+	now     func() time.Time
+}
+
+type rateLimitBucket struct {
+	limit   float64
+	tokens  float64
+	updated time.Time
+}
+
+// NewRateLimiter creates a RateLimiter assuming the default project limit until the API reports otherwise
+func NewRateLimiter() *RateLimiter {
+	return &RateLimiter{
+		MaxWait: defaultMaxWait,
+		buckets: make(map[string]*rateLimitBucket),
+		now:     time.Now,
+	}
+}
+
+// Transport wraps the RoundTripper so that requests pass the RateLimiter. Buckets are kept per
+// Authorization header, which identifies the Hetzner Cloud project of the request.
+func (l *RateLimiter) Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &rateLimitTransport{limiter: l, base: base}
+}
+
+// reserve takes a token from the bucket and returns how long the request has to wait for it
+func (l *RateLimiter) reserve(key string) (time.Duration, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	bucket := l.bucket(key, now)
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		return 0, nil
+	}
+
+	wait := time.Duration((1 - bucket.tokens) / bucket.rate() * float64(time.Second))
+	if wait > l.MaxWait {
+		return 0, &RateLimitError{Reset: now.Add(wait)}
+	}
+	bucket.tokens--
+	return wait, nil
+}
+
+// observe synchronises the bucket and the rate limit gauges with the rate limit headers of a response
+func (l *RateLimiter) observe(key string, resp *http.Response) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	bucket := l.bucket(key, l.now())
+	if limit, err := strconv.Atoi(resp.Header.Get("RateLimit-Limit")); err == nil && limit > 0 {
+		bucket.limit = float64(limit)
+		rateLimitLimit.WithLabelValues(projectLabel(key)).Set(float64(limit))
+	}
+	if remaining, err := strconv.Atoi(resp.Header.Get("RateLimit-Remaining")); err == nil {
+		bucket.tokens = float64(remaining)
+		rateLimitRemaining.WithLabelValues(projectLabel(key)).Set(float64(remaining))
+	}
+	if resp.StatusCode == http.StatusTooManyRequests && bucket.tokens > 0 {
+		bucket.tokens = 0
+	}
+}
+
+// bucket returns the refilled bucket for the key, the caller must hold the lock
+func (l *RateLimiter) bucket(key string, now time.Time) *rateLimitBucket {
+	bucket, ok := l.buckets[key]
+	if !ok {
+		bucket = &rateLimitBucket{limit: defaultRateLimit, tokens: defaultRateLimit, updated: now}
+		l.buckets[key] = bucket
+		return bucket
+	}
+	if elapsed := now.Sub(bucket.updated); elapsed > 0 {
+		bucket.tokens = min(bucket.limit, bucket.tokens+elapsed.Seconds()*bucket.rate())
+		bucket.updated = now
+	}
+	return bucket
+}
+
+// rate returns the number of tokens refilled per second
+func (b *rateLimitBucket) rate() float64 {
+	return b.limit / time.Hour.Seconds()
+}
+
+type rateLimitTransport struct {
+	limiter *RateLimiter
+	base    http.RoundTripper
+}
+
+// RoundTrip delays the request until the bucket of its project has a token left
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	key := req.Header.Get("Authorization")
+	wait, err := t.limiter.reserve(key)
+	if err != nil {
+		return nil, err
+	}
+	if wait > 0 {
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if resp != nil {
+		t.limiter.observe(key, resp)
+	}
+	return resp, err
+}
+
+// newRateLimitedClient builds a hcloud-go client for the token whose requests pass the DefaultRateLimiter
+func newRateLimitedClient(token string, opts ...hcloud.ClientOption) *hcloud.Client {
+	return hcloud.NewClient(append([]hcloud.ClientOption{
+		hcloud.WithToken(token),
+		hcloud.WithHTTPClient(&http.Client{Transport: DefaultRateLimiter.Transport(nil)}),
+	}, opts...)...)
+}
@@ -0,0 +1,119 @@
+package hcloud
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"time"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RateLimiter", func() {
+	var limiter *RateLimiter
+	var server *httptest.Server
+	var now time.Time
+	var remaining int
+	var reject bool
+	var requests int
+
+	BeforeEach(func() {
+		now = time.Unix(1700000000, 0)
+		remaining = 3600
+		reject = false
+		requests = 0
+		limiter = NewRateLimiter()
+		limiter.MaxWait = 0
+		limiter.now = func() time.Time { return now }
+
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			w.Header().Set("RateLimit-Limit", "3600")
+			w.Header().Set("RateLimit-Remaining", strconv.Itoa(remaining))
+			w.Header().Set("RateLimit-Reset", strconv.FormatInt(now.Add(time.Duration(3600-remaining)*time.Second).Unix(), 10))
+			w.Header().Set("Content-Type", "application/json")
+			if reject {
+				w.WriteHeader(http.StatusTooManyRequests)
+				_, _ = w.Write([]byte(`{"error":{"code":"rate_limit_exceeded","message":"limit of 3600 requests per hour reached"}}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"networks":[],"meta":{"pagination":{"page":1,"per_page":50,"previous_page":null,"next_page":null,"last_page":1,"total_entries":0}}}`))
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	newClient := func(token string) *hcloud.Client {
+		return hcloud.NewClient(
+			hcloud.WithToken(token),
+			hcloud.WithEndpoint(server.URL),
+			hcloud.WithHTTPClient(&http.Client{Transport: limiter.Transport(nil)}),
+			hcloud.WithRetryOpts(hcloud.RetryOpts{BackoffFunc: hcloud.ConstantBackoff(0), MaxRetries: 0}),
+		)
+	}
+
+	When("the project has requests left", func() {
+		It("should send the request", func() {
+			_, err := newClient("token-a").Network.All(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(requests).To(Equal(1))
+		})
+	})
+
+	When("the API reports that the limit is exhausted", func() {
+		BeforeEach(func() {
+			remaining = 0
+			_, err := newClient("token-a").Network.All(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(requests).To(Equal(1))
+		})
+
+		It("should hold back requests until a request was refilled", func() {
+			_, err := newClient("token-a").Network.All(context.Background())
+			Expect(err).To(HaveOccurred())
+			Expect(requests).To(Equal(1))
+
+			reset, ok := RateLimitReset(err)
+			Expect(ok).To(BeTrue())
+			Expect(reset).To(Equal(now.Add(time.Second)))
+
+			By("waiting for the bucket to refill")
+			now = now.Add(time.Second)
+			remaining = 1
+			_, err = newClient("token-a").Network.All(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(requests).To(Equal(2))
+		})
+
+		It("should not hold back requests of other projects", func() {
+			_, err := newClient("token-b").Network.All(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(requests).To(Equal(2))
+		})
+	})
+
+	When("the API rejects a request", func() {
+		It("should report when the next request is allowed", func() {
+			remaining = 0
+			reject = true
+			_, err := newClient("token-a").Network.All(context.Background())
+			Expect(hcloud.IsError(err, hcloud.ErrorCodeRateLimitExceeded)).To(BeTrue())
+
+			reset, ok := RateLimitReset(err)
+			Expect(ok).To(BeTrue())
+			Expect(reset).To(BeTemporally("~", time.Now().Add(rateLimitRetryAfter), time.Second))
+		})
+	})
+
+	Describe("RateLimitReset", func() {
+		It("should ignore other errors", func() {
+			_, ok := RateLimitReset(hcloud.Error{Code: hcloud.ErrorCodeNotFound})
+			Expect(ok).To(BeFalse())
+		})
+	})
+})
@@ -0,0 +1,159 @@
+package hcloud
+
+import (
+	"context"
+	"net"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+// ServerClient is an interface for managing servers through the Hetzner Cloud API
+type ServerClient interface {
+	// Server operations
+	GetServerById(ctx context.Context, id int64) (*hcloud.Server, *hcloud.Response, error)
+	GetServerByName(ctx context.Context, name string) (*hcloud.Server, *hcloud.Response, error)
+	CreateServer(ctx context.Context, opts hcloud.ServerCreateOpts) (*hcloud.ServerCreateResult, *hcloud.Response, error)
+	UpdateServerLabels(ctx context.Context, server *hcloud.Server, labels map[string]string) (*hcloud.Server, *hcloud.Response, error)
+	DeleteServer(ctx context.Context, server *hcloud.Server) (*hcloud.Action, *hcloud.Response, error)
+	ListServers(ctx context.Context) ([]*hcloud.Server, error)
+
+	// Network operations
+	AttachServerToNetwork(ctx context.Context, server *hcloud.Server, network *hcloud.Network, ip net.IP) (*hcloud.Server, *hcloud.Response, error)
+	DetachServerFromNetwork(ctx context.Context, server *hcloud.Server, network *hcloud.Network) (*hcloud.Server, *hcloud.Response, error)
+
+	// Firewall operations
+	ApplyServerFirewall(ctx context.Context, server *hcloud.Server, firewall *hcloud.Firewall) (*hcloud.Server, *hcloud.Response, error)
+	RemoveServerFirewall(ctx context.Context, server *hcloud.Server, firewall *hcloud.Firewall) (*hcloud.Server, *hcloud.Response, error)
+
+	// SSH key operations
+	GetSSHKeyByName(ctx context.Context, name string) (*hcloud.SSHKey, *hcloud.Response, error)
+
+	// Action operations
+	GetAction(ctx context.Context, id int64) (*hcloud.Action, *hcloud.Response, error)
+}
+
+type hcloudServerAdapter struct {
+	client *hcloud.Client
+}
+
+// NewServerClient creates a new ServerClient with the provided token. Additional options are
+// passed on to the underlying hcloud-go client. Requests pass the DefaultRateLimiter and every call
+// is recorded in the Hetzner Cloud API metrics.
+func NewServerClient(token string, opts ...hcloud.ClientOption) ServerClient {
+	client := newRateLimitedClient(token, opts...)
+	return InstrumentServerClient(&hcloudServerAdapter{
+		client: client,
+	})
+}
+
+// GetServerById retrieves a server by ID
+func (a *hcloudServerAdapter) GetServerById(ctx context.Context, id int64) (*hcloud.Server, *hcloud.Response, error) {
+	return a.client.Server.GetByID(ctx, id)
+}
+
+// GetServerByName retrieves a server by name
+func (a *hcloudServerAdapter) GetServerByName(ctx context.Context, name string) (*hcloud.Server, *hcloud.Response, error) {
+	return a.client.Server.GetByName(ctx, name)
+}
+
+// CreateServer creates a new server without waiting for it to start. The result holds the create
+// action and the actions started after it, such as starting the server, to be followed with GetAction.
+func (a *hcloudServerAdapter) CreateServer(ctx context.Context, opts hcloud.ServerCreateOpts) (*hcloud.ServerCreateResult, *hcloud.Response, error) {
+	result, resp, err := a.client.Server.Create(ctx, opts)
+	if err != nil {
+		return nil, resp, err
+	}
+	return &result, resp, nil
+}
+
+// UpdateServerLabels replaces the labels of an existing server
+func (a *hcloudServerAdapter) UpdateServerLabels(ctx context.Context, server *hcloud.Server, labels map[string]string) (*hcloud.Server, *hcloud.Response, error) {
+	opts := hcloud.ServerUpdateOpts{
+		Labels: labels,
+	}
+	return a.client.Server.Update(ctx, server, opts)
+}
+
+// DeleteServer deletes a server and returns the delete action without waiting for it
+func (a *hcloudServerAdapter) DeleteServer(ctx context.Context, server *hcloud.Server) (*hcloud.Action, *hcloud.Response, error) {
+	result, resp, err := a.client.Server.DeleteWithResult(ctx, server)
+	if err != nil {
+		return nil, resp, err
+	}
+	return result.Action, resp, nil
+}
+
+// ListServers lists all servers
+func (a *hcloudServerAdapter) ListServers(ctx context.Context) ([]*hcloud.Server, error) {
+	return a.client.Server.All(ctx)
+}
+
+// AttachServerToNetwork attaches a server to a network, with the given private IP or one picked by
+// Hetzner Cloud when ip is nil, and returns the refreshed server
+func (a *hcloudServerAdapter) AttachServerToNetwork(ctx context.Context, server *hcloud.Server, network *hcloud.Network, ip net.IP) (*hcloud.Server, *hcloud.Response, error) {
+	opts := hcloud.ServerAttachToNetworkOpts{
+		Network: network,
+		IP:      ip,
+	}
+	action, resp, err := a.client.Server.AttachToNetwork(ctx, server, opts)
+	if err != nil {
+		return nil, resp, err
+	}
+	return a.waitAndRefresh(ctx, server, []*hcloud.Action{action}, resp)
+}
+
+// DetachServerFromNetwork detaches a server from a network and returns the refreshed server
+func (a *hcloudServerAdapter) DetachServerFromNetwork(ctx context.Context, server *hcloud.Server, network *hcloud.Network) (*hcloud.Server, *hcloud.Response, error) {
+	opts := hcloud.ServerDetachFromNetworkOpts{
+		Network: network,
+	}
+	action, resp, err := a.client.Server.DetachFromNetwork(ctx, server, opts)
+	if err != nil {
+		return nil, resp, err
+	}
+	return a.waitAndRefresh(ctx, server, []*hcloud.Action{action}, resp)
+}
+
+// ApplyServerFirewall applies a firewall to a server and returns the refreshed server
+func (a *hcloudServerAdapter) ApplyServerFirewall(ctx context.Context, server *hcloud.Server, firewall *hcloud.Firewall) (*hcloud.Server, *hcloud.Response, error) {
+	actions, resp, err := a.client.Firewall.ApplyResources(ctx, firewall, []hcloud.FirewallResource{serverResource(server)})
+	if err != nil {
+		return nil, resp, err
+	}
+	return a.waitAndRefresh(ctx, server, actions, resp)
+}
+
+// RemoveServerFirewall removes a firewall from a server and returns the refreshed server
+func (a *hcloudServerAdapter) RemoveServerFirewall(ctx context.Context, server *hcloud.Server, firewall *hcloud.Firewall) (*hcloud.Server, *hcloud.Response, error) {
+	actions, resp, err := a.client.Firewall.RemoveResources(ctx, firewall, []hcloud.FirewallResource{serverResource(server)})
+	if err != nil {
+		return nil, resp, err
+	}
+	return a.waitAndRefresh(ctx, server, actions, resp)
+}
+
+// GetSSHKeyByName retrieves an SSH key by name
+func (a *hcloudServerAdapter) GetSSHKeyByName(ctx context.Context, name string) (*hcloud.SSHKey, *hcloud.Response, error) {
+	return a.client.SSHKey.GetByName(ctx, name)
+}
+
+// GetAction retrieves an action by ID to follow its progress
+func (a *hcloudServerAdapter) GetAction(ctx context.Context, id int64) (*hcloud.Action, *hcloud.Response, error) {
+	return a.client.Action.GetByID(ctx, id)
+}
+
+// waitAndRefresh waits for the actions to complete and returns the refreshed server
+func (a *hcloudServerAdapter) waitAndRefresh(ctx context.Context, server *hcloud.Server, actions []*hcloud.Action, resp *hcloud.Response) (*hcloud.Server, *hcloud.Response, error) {
+	if err := a.client.Action.WaitFor(ctx, actions...); err != nil {
+		return nil, resp, err
+	}
+	return a.client.Server.GetByID(ctx, server.ID)
+}
+
+// serverResource returns the firewall resource of a server
+func serverResource(server *hcloud.Server) hcloud.FirewallResource {
+	return hcloud.FirewallResource{
+		Type:   hcloud.FirewallResourceTypeServer,
+		Server: &hcloud.FirewallResourceServer{ID: server.ID},
+	}
+}
@@ -0,0 +1,120 @@
+package hcloud
+
+import (
+	"context"
+	"net"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+// MockServerClient is a mock implementation of the ServerClient interface for testing
+type MockServerClient struct {
+	GetServerByIdFunc           func(ctx context.Context, id int64) (*hcloud.Server, *hcloud.Response, error)
+	GetServerByNameFunc         func(ctx context.Context, name string) (*hcloud.Server, *hcloud.Response, error)
+	CreateServerFunc            func(ctx context.Context, opts hcloud.ServerCreateOpts) (*hcloud.ServerCreateResult, *hcloud.Response, error)
+	UpdateServerLabelsFunc      func(ctx context.Context, server *hcloud.Server, labels map[string]string) (*hcloud.Server, *hcloud.Response, error)
+	DeleteServerFunc            func(ctx context.Context, server *hcloud.Server) (*hcloud.Action, *hcloud.Response, error)
+	ListServersFunc             func(ctx context.Context) ([]*hcloud.Server, error)
+	AttachServerToNetworkFunc   func(ctx context.Context, server *hcloud.Server, network *hcloud.Network, ip net.IP) (*hcloud.Server, *hcloud.Response, error)
+	DetachServerFromNetworkFunc func(ctx context.Context, server *hcloud.Server, network *hcloud.Network) (*hcloud.Server, *hcloud.Response, error)
+	ApplyServerFirewallFunc     func(ctx context.Context, server *hcloud.Server, firewall *hcloud.Firewall) (*hcloud.Server, *hcloud.Response, error)
+	RemoveServerFirewallFunc    func(ctx context.Context, server *hcloud.Server, firewall *hcloud.Firewall) (*hcloud.Server, *hcloud.Response, error)
+	GetSSHKeyByNameFunc         func(ctx context.Context, name string) (*hcloud.SSHKey, *hcloud.Response, error)
+	GetActionFunc               func(ctx context.Context, id int64) (*hcloud.Action, *hcloud.Response, error)
+}
+
+// GetServerById calls the mocked GetServerByIdFunc
+func (m *MockServerClient) GetServerById(ctx context.Context, id int64) (*hcloud.Server, *hcloud.Response, error) {
+	if m.GetServerByIdFunc != nil {
+		return m.GetServerByIdFunc(ctx, id)
+	}
+	return nil, nil, nil
+}
+
+// GetServerByName calls the mocked GetServerByNameFunc
+func (m *MockServerClient) GetServerByName(ctx context.Context, name string) (*hcloud.Server, *hcloud.Response, error) {
+	if m.GetServerByNameFunc != nil {
+		return m.GetServerByNameFunc(ctx, name)
+	}
+	return nil, nil, nil
+}
+
+// CreateServer calls the mocked CreateServerFunc
+func (m *MockServerClient) CreateServer(ctx context.Context, opts hcloud.ServerCreateOpts) (*hcloud.ServerCreateResult, *hcloud.Response, error) {
+	if m.CreateServerFunc != nil {
+		return m.CreateServerFunc(ctx, opts)
+	}
+	return nil, nil, nil
+}
+
+// UpdateServerLabels calls the mocked UpdateServerLabelsFunc
+func (m *MockServerClient) UpdateServerLabels(ctx context.Context, server *hcloud.Server, labels map[string]string) (*hcloud.Server, *hcloud.Response, error) {
+	if m.UpdateServerLabelsFunc != nil {
+		return m.UpdateServerLabelsFunc(ctx, server, labels)
+	}
+	return nil, nil, nil
+}
+
+// DeleteServer calls the mocked DeleteServerFunc
+func (m *MockServerClient) DeleteServer(ctx context.Context, server *hcloud.Server) (*hcloud.Action, *hcloud.Response, error) {
+	if m.DeleteServerFunc != nil {
+		return m.DeleteServerFunc(ctx, server)
+	}
+	return nil, nil, nil
+}
+
+// ListServers calls the mocked ListServersFunc
+func (m *MockServerClient) ListServers(ctx context.Context) ([]*hcloud.Server, error) {
+	if m.ListServersFunc != nil {
+		return m.ListServersFunc(ctx)
+	}
+	return nil, nil
+}
+
+// AttachServerToNetwork calls the mocked AttachServerToNetworkFunc
+func (m *MockServerClient) AttachServerToNetwork(ctx context.Context, server *hcloud.Server, network *hcloud.Network, ip net.IP) (*hcloud.Server, *hcloud.Response, error) {
+	if m.AttachServerToNetworkFunc != nil {
+		return m.AttachServerToNetworkFunc(ctx, server, network, ip)
+	}
+	return nil, nil, nil
+}
+
+// DetachServerFromNetwork calls the mocked DetachServerFromNetworkFunc
+func (m *MockServerClient) DetachServerFromNetwork(ctx context.Context, server *hcloud.Server, network *hcloud.Network) (*hcloud.Server, *hcloud.Response, error) {
+	if m.DetachServerFromNetworkFunc != nil {
+		return m.DetachServerFromNetworkFunc(ctx, server, network)
+	}
+	return nil, nil, nil
+}
+
+// ApplyServerFirewall calls the mocked ApplyServerFirewallFunc
+func (m *MockServerClient) ApplyServerFirewall(ctx context.Context, server *hcloud.Server, firewall *hcloud.Firewall) (*hcloud.Server, *hcloud.Response, error) {
+	if m.ApplyServerFirewallFunc != nil {
+		return m.ApplyServerFirewallFunc(ctx, server, firewall)
+	}
+	return nil, nil, nil
+}
+
+// RemoveServerFirewall calls the mocked RemoveServerFirewallFunc
+func (m *MockServerClient) RemoveServerFirewall(ctx context.Context, server *hcloud.Server, firewall *hcloud.Firewall) (*hcloud.Server, *hcloud.Response, error) {
+	if m.RemoveServerFirewallFunc != nil {
+		return m.RemoveServerFirewallFunc(ctx, server, firewall)
+	}
+	return nil, nil, nil
+}
+
+// GetSSHKeyByName calls the mocked GetSSHKeyByNameFunc
+func (m *MockServerClient) GetSSHKeyByName(ctx context.Context, name string) (*hcloud.SSHKey, *hcloud.Response, error) {
+	if m.GetSSHKeyByNameFunc != nil {
+		return m.GetSSHKeyByNameFunc(ctx, name)
+	}
+	return nil, nil, nil
+}
+
+// GetAction calls the mocked GetActionFunc
+func (m *MockServerClient) GetAction(ctx context.Context, id int64) (*hcloud.Action, *hcloud.Response, error) {
+	if m.GetActionFunc != nil {
+		return m.GetActionFunc(ctx, id)
+	}
+	return nil, nil, nil
+}
@@ -0,0 +1,85 @@
+package hcloud
+
+import (
+	"context"
+	"errors"
+	"net"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ServerManager", func() {
+
+	var mockServerClient *MockServerClient
+	var sc ServerClient
+
+	BeforeEach(func() {
+		mockServerClient = &MockServerClient{}
+		sc = ServerClient(mockServerClient)
+	})
+
+	Describe("GetServerByName", func() {
+		When("server exists", func() {
+			BeforeEach(func() {
+				mockServerClient.GetServerByNameFunc = func(ctx context.Context, name string) (*hcloud.Server, *hcloud.Response, error) {
+					return &hcloud.Server{ID: 123, Name: name}, nil, nil
+				}
+			})
+
+			It("should retrieve server by name", func() {
+				server, _, err := sc.GetServerByName(context.Background(), "test-server")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(server).NotTo(BeNil())
+				Expect(server.ID).To(Equal(int64(123)))
+			})
+		})
+
+		When("server does not exist", func() {
+			It("should return nil without error", func() {
+				server, _, err := sc.GetServerByName(context.Background(), "test-server")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(server).To(BeNil())
+			})
+		})
+	})
+
+	Describe("CreateServer", func() {
+		When("the server is created", func() {
+			BeforeEach(func() {
+				mockServerClient.CreateServerFunc = func(ctx context.Context, opts hcloud.ServerCreateOpts) (*hcloud.ServerCreateResult, *hcloud.Response, error) {
+					return &hcloud.ServerCreateResult{
+						Server:      &hcloud.Server{ID: 456, Name: opts.Name},
+						Action:      &hcloud.Action{ID: 1, Status: hcloud.ActionStatusRunning},
+						NextActions: []*hcloud.Action{{ID: 2, Status: hcloud.ActionStatusRunning}},
+					}, nil, nil
+				}
+			})
+
+			It("should return the server with the actions still running", func() {
+				result, _, err := sc.CreateServer(context.Background(), hcloud.ServerCreateOpts{Name: "test-server"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Server.ID).To(Equal(int64(456)))
+				Expect(result.Action.ID).To(Equal(int64(1)))
+				Expect(result.NextActions).To(HaveLen(1))
+			})
+		})
+	})
+
+	Describe("AttachServerToNetwork", func() {
+		When("the private IP is taken", func() {
+			BeforeEach(func() {
+				mockServerClient.AttachServerToNetworkFunc = func(ctx context.Context, server *hcloud.Server, network *hcloud.Network, ip net.IP) (*hcloud.Server, *hcloud.Response, error) {
+					return nil, nil, errors.New("ip not available")
+				}
+			})
+
+			It("should return the error", func() {
+				server, _, err := sc.AttachServerToNetwork(context.Background(), &hcloud.Server{ID: 123}, &hcloud.Network{ID: 7}, net.ParseIP("10.0.1.5"))
+				Expect(err).To(MatchError("ip not available"))
+				Expect(server).To(BeNil())
+			})
+		})
+	})
+})
@@ -0,0 +1,131 @@
+package hcloud
+
+import (
+	"context"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+// ServerActionClient is an interface for day-2 actions on existing servers through the Hetzner
+// Cloud API. Like UpdateNetworkCidr, the actions are returned once started instead of being waited
+// for, so that callers can follow their progress with GetAction.
+type ServerActionClient interface {
+	// Server operations
+	GetServerById(ctx context.Context, id int64) (*hcloud.Server, *hcloud.Response, error)
+	GetServerByName(ctx context.Context, name string) (*hcloud.Server, *hcloud.Response, error)
+
+	// Power operations
+	PowerOnServer(ctx context.Context, server *hcloud.Server) (*hcloud.Action, *hcloud.Response, error)
+	PowerOffServer(ctx context.Context, server *hcloud.Server) (*hcloud.Action, *hcloud.Response, error)
+	ShutdownServer(ctx context.Context, server *hcloud.Server) (*hcloud.Action, *hcloud.Response, error)
+	RebootServer(ctx context.Context, server *hcloud.Server) (*hcloud.Action, *hcloud.Response, error)
+	ResetServer(ctx context.Context, server *hcloud.Server) (*hcloud.Action, *hcloud.Response, error)
+
+	// Maintenance operations
+	EnableServerRescue(ctx context.Context, server *hcloud.Server, opts hcloud.ServerEnableRescueOpts) (*hcloud.Action, *hcloud.Response, error)
+	RebuildServer(ctx context.Context, server *hcloud.Server, image *hcloud.Image) (*hcloud.Action, *hcloud.Response, error)
+	ChangeServerType(ctx context.Context, server *hcloud.Server, opts hcloud.ServerChangeTypeOpts) (*hcloud.Action, *hcloud.Response, error)
+	CreateServerImage(ctx context.Context, server *hcloud.Server, opts hcloud.ServerCreateImageOpts) (*hcloud.ServerCreateImageResult, *hcloud.Response, error)
+
+	// SSH key operations
+	GetSSHKeyByName(ctx context.Context, name string) (*hcloud.SSHKey, *hcloud.Response, error)
+
+	// Action operations
+	GetAction(ctx context.Context, id int64) (*hcloud.Action, *hcloud.Response, error)
+}
+
+type hcloudServerActionAdapter struct {
+	client *hcloud.Client
+}
+
+// NewServerActionClient creates a new ServerActionClient with the provided token. Additional
+// options are passed on to the underlying hcloud-go client. Requests pass the DefaultRateLimiter
+// and every call is recorded in the Hetzner Cloud API metrics.
+func NewServerActionClient(token string, opts ...hcloud.ClientOption) ServerActionClient {
+	client := newRateLimitedClient(token, opts...)
+	return InstrumentServerActionClient(&hcloudServerActionAdapter{
+		client: client,
+	})
+}
+
+// GetServerById retrieves a server by ID
+func (a *hcloudServerActionAdapter) GetServerById(ctx context.Context, id int64) (*hcloud.Server, *hcloud.Response, error) {
+	return a.client.Server.GetByID(ctx, id)
+}
+
+// GetServerByName retrieves a server by name
+func (a *hcloudServerActionAdapter) GetServerByName(ctx context.Context, name string) (*hcloud.Server, *hcloud.Response, error) {
+	return a.client.Server.GetByName(ctx, name)
+}
+
+// PowerOnServer starts a server
+func (a *hcloudServerActionAdapter) PowerOnServer(ctx context.Context, server *hcloud.Server) (*hcloud.Action, *hcloud.Response, error) {
+	return a.client.Server.Poweron(ctx, server)
+}
+
+// PowerOffServer cuts the power of a server
+func (a *hcloudServerActionAdapter) PowerOffServer(ctx context.Context, server *hcloud.Server) (*hcloud.Action, *hcloud.Response, error) {
+	return a.client.Server.Poweroff(ctx, server)
+}
+
+// ShutdownServer sends an ACPI shutdown request to a server. The action completes once the request
+// was sent, not once the server is off.
+func (a *hcloudServerActionAdapter) ShutdownServer(ctx context.Context, server *hcloud.Server) (*hcloud.Action, *hcloud.Response, error) {
+	return a.client.Server.Shutdown(ctx, server)
+}
+
+// RebootServer sends an ACPI reboot request to a server
+func (a *hcloudServerActionAdapter) RebootServer(ctx context.Context, server *hcloud.Server) (*hcloud.Action, *hcloud.Response, error) {
+	return a.client.Server.Reboot(ctx, server)
+}
+
+// ResetServer cuts the power of a server and starts it again
+func (a *hcloudServerActionAdapter) ResetServer(ctx context.Context, server *hcloud.Server) (*hcloud.Action, *hcloud.Response, error) {
+	return a.client.Server.Reset(ctx, server)
+}
+
+// EnableServerRescue enables the rescue system of a server for its next boot
+func (a *hcloudServerActionAdapter) EnableServerRescue(ctx context.Context, server *hcloud.Server, opts hcloud.ServerEnableRescueOpts) (*hcloud.Action, *hcloud.Response, error) {
+	result, resp, err := a.client.Server.EnableRescue(ctx, server, opts)
+	if err != nil {
+		return nil, resp, err
+	}
+	return result.Action, resp, nil
+}
+
+// RebuildServer reinstalls a server from an image, selected by ID or by name
+func (a *hcloudServerActionAdapter) RebuildServer(ctx context.Context, server *hcloud.Server, image *hcloud.Image) (*hcloud.Action, *hcloud.Response, error) {
+	opts := hcloud.ServerRebuildOpts{
+		Image: image,
+	}
+	result, resp, err := a.client.Server.RebuildWithResult(ctx, server, opts)
+	if err != nil {
+		return nil, resp, err
+	}
+	return result.Action, resp, nil
+}
+
+// ChangeServerType changes the server type of a server, which has to be off
+func (a *hcloudServerActionAdapter) ChangeServerType(ctx context.Context, server *hcloud.Server, opts hcloud.ServerChangeTypeOpts) (*hcloud.Action, *hcloud.Response, error) {
+	return a.client.Server.ChangeType(ctx, server, opts)
+}
+
+// CreateServerImage creates an image of the disk of a server. The result holds the image and the
+// action creating it.
+func (a *hcloudServerActionAdapter) CreateServerImage(ctx context.Context, server *hcloud.Server, opts hcloud.ServerCreateImageOpts) (*hcloud.ServerCreateImageResult, *hcloud.Response, error) {
+	result, resp, err := a.client.Server.CreateImage(ctx, server, &opts)
+	if err != nil {
+		return nil, resp, err
+	}
+	return &result, resp, nil
+}
+
+// GetSSHKeyByName retrieves an SSH key by name
+func (a *hcloudServerActionAdapter) GetSSHKeyByName(ctx context.Context, name string) (*hcloud.SSHKey, *hcloud.Response, error) {
+	return a.client.SSHKey.GetByName(ctx, name)
+}
+
+// GetAction retrieves an action by ID to follow its progress
+func (a *hcloudServerActionAdapter) GetAction(ctx context.Context, id int64) (*hcloud.Action, *hcloud.Response, error) {
+	return a.client.Action.GetByID(ctx, id)
+}
@@ -0,0 +1,128 @@
+package hcloud
+
+import (
+	"context"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+// MockServerActionClient is a mock implementation of the ServerActionClient interface for testing
+type MockServerActionClient struct {
+	GetServerByIdFunc      func(ctx context.Context, id int64) (*hcloud.Server, *hcloud.Response, error)
+	GetServerByNameFunc    func(ctx context.Context, name string) (*hcloud.Server, *hcloud.Response, error)
+	PowerOnServerFunc      func(ctx context.Context, server *hcloud.Server) (*hcloud.Action, *hcloud.Response, error)
+	PowerOffServerFunc     func(ctx context.Context, server *hcloud.Server) (*hcloud.Action, *hcloud.Response, error)
+	ShutdownServerFunc     func(ctx context.Context, server *hcloud.Server) (*hcloud.Action, *hcloud.Response, error)
+	RebootServerFunc       func(ctx context.Context, server *hcloud.Server) (*hcloud.Action, *hcloud.Response, error)
+	ResetServerFunc        func(ctx context.Context, server *hcloud.Server) (*hcloud.Action, *hcloud.Response, error)
+	EnableServerRescueFunc func(ctx context.Context, server *hcloud.Server, opts hcloud.ServerEnableRescueOpts) (*hcloud.Action, *hcloud.Response, error)
+	RebuildServerFunc      func(ctx context.Context, server *hcloud.Server, image *hcloud.Image) (*hcloud.Action, *hcloud.Response, error)
+	ChangeServerTypeFunc   func(ctx context.Context, server *hcloud.Server, opts hcloud.ServerChangeTypeOpts) (*hcloud.Action, *hcloud.Response, error)
+	CreateServerImageFunc  func(ctx context.Context, server *hcloud.Server, opts hcloud.ServerCreateImageOpts) (*hcloud.ServerCreateImageResult, *hcloud.Response, error)
+	GetSSHKeyByNameFunc    func(ctx context.Context, name string) (*hcloud.SSHKey, *hcloud.Response, error)
+	GetActionFunc          func(ctx context.Context, id int64) (*hcloud.Action, *hcloud.Response, error)
+}
+
+// GetServerById calls the mocked GetServerByIdFunc
+func (m *MockServerActionClient) GetServerById(ctx context.Context, id int64) (*hcloud.Server, *hcloud.Response, error) {
+	if m.GetServerByIdFunc != nil {
+		return m.GetServerByIdFunc(ctx, id)
+	}
+	return nil, nil, nil
+}
+
+// GetServerByName calls the mocked GetServerByNameFunc
+func (m *MockServerActionClient) GetServerByName(ctx context.Context, name string) (*hcloud.Server, *hcloud.Response, error) {
+	if m.GetServerByNameFunc != nil {
+		return m.GetServerByNameFunc(ctx, name)
+	}
+	return nil, nil, nil
+}
+
+// PowerOnServer calls the mocked PowerOnServerFunc
+func (m *MockServerActionClient) PowerOnServer(ctx context.Context, server *hcloud.Server) (*hcloud.Action, *hcloud.Response, error) {
+	if m.PowerOnServerFunc != nil {
+		return m.PowerOnServerFunc(ctx, server)
+	}
+	return nil, nil, nil
+}
+
+// PowerOffServer calls the mocked PowerOffServerFunc
+func (m *MockServerActionClient) PowerOffServer(ctx context.Context, server *hcloud.Server) (*hcloud.Action, *hcloud.Response, error) {
+	if m.PowerOffServerFunc != nil {
+		return m.PowerOffServerFunc(ctx, server)
+	}
+	return nil, nil, nil
+}
+
+// ShutdownServer calls the mocked ShutdownServerFunc
+func (m *MockServerActionClient) ShutdownServer(ctx context.Context, server *hcloud.Server) (*hcloud.Action, *hcloud.Response, error) {
+	if m.ShutdownServerFunc != nil {
+		return m.ShutdownServerFunc(ctx, server)
+	}
+	return nil, nil, nil
+}
+
+// RebootServer calls the mocked RebootServerFunc
+func (m *MockServerActionClient) RebootServer(ctx context.Context, server *hcloud.Server) (*hcloud.Action, *hcloud.Response, error) {
+	if m.RebootServerFunc != nil {
+		return m.RebootServerFunc(ctx, server)
+	}
+	return nil, nil, nil
+}
+
+// ResetServer calls the mocked ResetServerFunc
+func (m *MockServerActionClient) ResetServer(ctx context.Context, server *hcloud.Server) (*hcloud.Action, *hcloud.Response, error) {
+	if m.ResetServerFunc != nil {
+		return m.ResetServerFunc(ctx, server)
+	}
+	return nil, nil, nil
+}
+
+// EnableServerRescue calls the mocked EnableServerRescueFunc
+func (m *MockServerActionClient) EnableServerRescue(ctx context.Context, server *hcloud.Server, opts hcloud.ServerEnableRescueOpts) (*hcloud.Action, *hcloud.Response, error) {
+	if m.EnableServerRescueFunc != nil {
+		return m.EnableServerRescueFunc(ctx, server, opts)
+	}
+	return nil, nil, nil
+}
+
+// RebuildServer calls the mocked RebuildServerFunc
+func (m *MockServerActionClient) RebuildServer(ctx context.Context, server *hcloud.Server, image *hcloud.Image) (*hcloud.Action, *hcloud.Response, error) {
+	if m.RebuildServerFunc != nil {
+		return m.RebuildServerFunc(ctx, server, image)
+	}
+	return nil, nil, nil
+}
+
+// ChangeServerType calls the mocked ChangeServerTypeFunc
+func (m *MockServerActionClient) ChangeServerType(ctx context.Context, server *hcloud.Server, opts hcloud.ServerChangeTypeOpts) (*hcloud.Action, *hcloud.Response, error) {
+	if m.ChangeServerTypeFunc != nil {
+		return m.ChangeServerTypeFunc(ctx, server, opts)
+	}
+	return nil, nil, nil
+}
+
+// CreateServerImage calls the mocked CreateServerImageFunc
+func (m *MockServerActionClient) CreateServerImage(ctx context.Context, server *hcloud.Server, opts hcloud.ServerCreateImageOpts) (*hcloud.ServerCreateImageResult, *hcloud.Response, error) {
+	if m.CreateServerImageFunc != nil {
+		return m.CreateServerImageFunc(ctx, server, opts)
+	}
+	return nil, nil, nil
+}
+
+// GetSSHKeyByName calls the mocked GetSSHKeyByNameFunc
+func (m *MockServerActionClient) GetSSHKeyByName(ctx context.Context, name string) (*hcloud.SSHKey, *hcloud.Response, error) {
+	if m.GetSSHKeyByNameFunc != nil {
+		return m.GetSSHKeyByNameFunc(ctx, name)
+	}
+	return nil, nil, nil
+}
+
+// GetAction calls the mocked GetActionFunc
+func (m *MockServerActionClient) GetAction(ctx context.Context, id int64) (*hcloud.Action, *hcloud.Response, error) {
+	if m.GetActionFunc != nil {
+		return m.GetActionFunc(ctx, id)
+	}
+	return nil, nil, nil
+}
@@ -0,0 +1,71 @@
+package hcloud
+
+import (
+	"context"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+	"github.com/hetznercloud/hcloud-go/v2/hcloud/schema"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ServerActionManager", func() {
+
+	var mockServerActionClient *MockServerActionClient
+	var sac ServerActionClient
+
+	BeforeEach(func() {
+		mockServerActionClient = &MockServerActionClient{}
+		sac = ServerActionClient(mockServerActionClient)
+	})
+
+	Describe("ChangeServerType", func() {
+		When("the server is still running", func() {
+			BeforeEach(func() {
+				mockServerActionClient.ChangeServerTypeFunc = func(ctx context.Context, server *hcloud.Server, opts hcloud.ServerChangeTypeOpts) (*hcloud.Action, *hcloud.Response, error) {
+					return nil, nil, hcloud.ErrorFromSchema(schema.Error{Code: string(hcloud.ErrorCodeServerNotStopped), Message: "server must be stopped"})
+				}
+			})
+
+			It("should return the API error", func() {
+				action, _, err := sac.ChangeServerType(context.Background(), &hcloud.Server{ID: 123}, hcloud.ServerChangeTypeOpts{ServerType: &hcloud.ServerType{Name: "cx32"}})
+				Expect(hcloud.IsError(err, hcloud.ErrorCodeServerNotStopped)).To(BeTrue())
+				Expect(action).To(BeNil())
+			})
+		})
+	})
+
+	Describe("CreateServerImage", func() {
+		When("the snapshot is started", func() {
+			BeforeEach(func() {
+				mockServerActionClient.CreateServerImageFunc = func(ctx context.Context, server *hcloud.Server, opts hcloud.ServerCreateImageOpts) (*hcloud.ServerCreateImageResult, *hcloud.Response, error) {
+					return &hcloud.ServerCreateImageResult{
+						Image:  &hcloud.Image{ID: 789, Type: opts.Type, Description: *opts.Description},
+						Action: &hcloud.Action{ID: 1, Command: "create_image", Status: hcloud.ActionStatusRunning},
+					}, nil, nil
+				}
+			})
+
+			It("should return the image with the action still running", func() {
+				result, _, err := sac.CreateServerImage(context.Background(), &hcloud.Server{ID: 123}, hcloud.ServerCreateImageOpts{
+					Type:        hcloud.ImageTypeSnapshot,
+					Description: hcloud.Ptr("before upgrade"),
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Image.ID).To(Equal(int64(789)))
+				Expect(result.Image.Description).To(Equal("before upgrade"))
+				Expect(result.Action.Status).To(Equal(hcloud.ActionStatusRunning))
+			})
+		})
+	})
+
+	Describe("PowerOnServer", func() {
+		When("the call is not mocked", func() {
+			It("should return no action", func() {
+				action, _, err := sac.PowerOnServer(context.Background(), &hcloud.Server{ID: 123})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(action).To(BeNil())
+			})
+		})
+	})
+})
@@ -0,0 +1,104 @@
+package hcloud
+
+import (
+	"context"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+// VolumeClient is an interface for managing volumes through the Hetzner Cloud API. Resizing,
+// attaching and detaching return the started action instead of waiting for it, so that callers
+// can follow its progress with GetAction.
+type VolumeClient interface {
+	// Volume operations
+	GetVolumeById(ctx context.Context, id int64) (*hcloud.Volume, *hcloud.Response, error)
+	GetVolumeByName(ctx context.Context, name string) (*hcloud.Volume, *hcloud.Response, error)
+	CreateVolume(ctx context.Context, opts hcloud.VolumeCreateOpts) (*hcloud.VolumeCreateResult, *hcloud.Response, error)
+	UpdateVolumeLabels(ctx context.Context, volume *hcloud.Volume, labels map[string]string) (*hcloud.Volume, *hcloud.Response, error)
+	ResizeVolume(ctx context.Context, volume *hcloud.Volume, size int) (*hcloud.Action, *hcloud.Response, error)
+	DeleteVolume(ctx context.Context, volume *hcloud.Volume) (*hcloud.Response, error)
+	ListVolumes(ctx context.Context) ([]*hcloud.Volume, error)
+
+	// Server operations
+	AttachVolume(ctx context.Context, volume *hcloud.Volume, server *hcloud.Server, automount bool) (*hcloud.Action, *hcloud.Response, error)
+	DetachVolume(ctx context.Context, volume *hcloud.Volume) (*hcloud.Action, *hcloud.Response, error)
+
+	// Action operations
+	GetAction(ctx context.Context, id int64) (*hcloud.Action, *hcloud.Response, error)
+}
+
+type hcloudVolumeAdapter struct {
+	client *hcloud.Client
+}
+
+// NewVolumeClient creates a new VolumeClient with the provided token. Additional options are
+// passed on to the underlying hcloud-go client. Requests pass the DefaultRateLimiter and every call
+// is recorded in the Hetzner Cloud API metrics.
+func NewVolumeClient(token string, opts ...hcloud.ClientOption) VolumeClient {
+	client := newRateLimitedClient(token, opts...)
+	return InstrumentVolumeClient(&hcloudVolumeAdapter{
+		client: client,
+	})
+}
+
+// GetVolumeById retrieves a volume by ID
+func (a *hcloudVolumeAdapter) GetVolumeById(ctx context.Context, id int64) (*hcloud.Volume, *hcloud.Response, error) {
+	return a.client.Volume.GetByID(ctx, id)
+}
+
+// GetVolumeByName retrieves a volume by name
+func (a *hcloudVolumeAdapter) GetVolumeByName(ctx context.Context, name string) (*hcloud.Volume, *hcloud.Response, error) {
+	return a.client.Volume.GetByName(ctx, name)
+}
+
+// CreateVolume creates a new volume without waiting for it to become available. The result holds
+// the create action and the actions started after it, such as attaching the volume to its server.
+func (a *hcloudVolumeAdapter) CreateVolume(ctx context.Context, opts hcloud.VolumeCreateOpts) (*hcloud.VolumeCreateResult, *hcloud.Response, error) {
+	result, resp, err := a.client.Volume.Create(ctx, opts)
+	if err != nil {
+		return nil, resp, err
+	}
+	return &result, resp, nil
+}
+
+// UpdateVolumeLabels replaces the labels of an existing volume
+func (a *hcloudVolumeAdapter) UpdateVolumeLabels(ctx context.Context, volume *hcloud.Volume, labels map[string]string) (*hcloud.Volume, *hcloud.Response, error) {
+	opts := hcloud.VolumeUpdateOpts{
+		Labels: labels,
+	}
+	return a.client.Volume.Update(ctx, volume, opts)
+}
+
+// ResizeVolume grows a volume to size GB, Hetzner Cloud rejects a size below the current one
+func (a *hcloudVolumeAdapter) ResizeVolume(ctx context.Context, volume *hcloud.Volume, size int) (*hcloud.Action, *hcloud.Response, error) {
+	return a.client.Volume.Resize(ctx, volume, size)
+}
+
+// DeleteVolume deletes a volume, which has to be detached first
+func (a *hcloudVolumeAdapter) DeleteVolume(ctx context.Context, volume *hcloud.Volume) (*hcloud.Response, error) {
+	return a.client.Volume.Delete(ctx, volume)
+}
+
+// ListVolumes lists all volumes
+func (a *hcloudVolumeAdapter) ListVolumes(ctx context.Context) ([]*hcloud.Volume, error) {
+	return a.client.Volume.All(ctx)
+}
+
+// AttachVolume attaches a volume to a server in the same location, automount mounts it on the server
+func (a *hcloudVolumeAdapter) AttachVolume(ctx context.Context, volume *hcloud.Volume, server *hcloud.Server, automount bool) (*hcloud.Action, *hcloud.Response, error) {
+	opts := hcloud.VolumeAttachOpts{
+		Server:    server,
+		Automount: hcloud.Ptr(automount),
+	}
+	return a.client.Volume.AttachWithOpts(ctx, volume, opts)
+}
+
+// DetachVolume detaches a volume from the server it is attached to
+func (a *hcloudVolumeAdapter) DetachVolume(ctx context.Context, volume *hcloud.Volume) (*hcloud.Action, *hcloud.Response, error) {
+	return a.client.Volume.Detach(ctx, volume)
+}
+
+// GetAction retrieves an action by ID to follow its progress
+func (a *hcloudVolumeAdapter) GetAction(ctx context.Context, id int64) (*hcloud.Action, *hcloud.Response, error) {
+	return a.client.Action.GetByID(ctx, id)
+}
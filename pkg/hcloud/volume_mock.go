@@ -0,0 +1,101 @@
+package hcloud
+
+import (
+	"context"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+// MockVolumeClient is a mock implementation of the VolumeClient interface for testing
+type MockVolumeClient struct {
+	GetVolumeByIdFunc      func(ctx context.Context, id int64) (*hcloud.Volume, *hcloud.Response, error)
+	GetVolumeByNameFunc    func(ctx context.Context, name string) (*hcloud.Volume, *hcloud.Response, error)
+	CreateVolumeFunc       func(ctx context.Context, opts hcloud.VolumeCreateOpts) (*hcloud.VolumeCreateResult, *hcloud.Response, error)
+	UpdateVolumeLabelsFunc func(ctx context.Context, volume *hcloud.Volume, labels map[string]string) (*hcloud.Volume, *hcloud.Response, error)
+	ResizeVolumeFunc       func(ctx context.Context, volume *hcloud.Volume, size int) (*hcloud.Action, *hcloud.Response, error)
+	DeleteVolumeFunc       func(ctx context.Context, volume *hcloud.Volume) (*hcloud.Response, error)
+	ListVolumesFunc        func(ctx context.Context) ([]*hcloud.Volume, error)
+	AttachVolumeFunc       func(ctx context.Context, volume *hcloud.Volume, server *hcloud.Server, automount bool) (*hcloud.Action, *hcloud.Response, error)
+	DetachVolumeFunc       func(ctx context.Context, volume *hcloud.Volume) (*hcloud.Action, *hcloud.Response, error)
+	GetActionFunc          func(ctx context.Context, id int64) (*hcloud.Action, *hcloud.Response, error)
+}
+
+// GetVolumeById calls the mocked GetVolumeByIdFunc
+func (m *MockVolumeClient) GetVolumeById(ctx context.Context, id int64) (*hcloud.Volume, *hcloud.Response, error) {
+	if m.GetVolumeByIdFunc != nil {
+		return m.GetVolumeByIdFunc(ctx, id)
+	}
+	return nil, nil, nil
+}
+
+// GetVolumeByName calls the mocked GetVolumeByNameFunc
+func (m *MockVolumeClient) GetVolumeByName(ctx context.Context, name string) (*hcloud.Volume, *hcloud.Response, error) {
+	if m.GetVolumeByNameFunc != nil {
+		return m.GetVolumeByNameFunc(ctx, name)
+	}
+	return nil, nil, nil
+}
+
+// CreateVolume calls the mocked CreateVolumeFunc
+func (m *MockVolumeClient) CreateVolume(ctx context.Context, opts hcloud.VolumeCreateOpts) (*hcloud.VolumeCreateResult, *hcloud.Response, error) {
+	if m.CreateVolumeFunc != nil {
+		return m.CreateVolumeFunc(ctx, opts)
+	}
+	return nil, nil, nil
+}
+
+// UpdateVolumeLabels calls the mocked UpdateVolumeLabelsFunc
+func (m *MockVolumeClient) UpdateVolumeLabels(ctx context.Context, volume *hcloud.Volume, labels map[string]string) (*hcloud.Volume, *hcloud.Response, error) {
+	if m.UpdateVolumeLabelsFunc != nil {
+		return m.UpdateVolumeLabelsFunc(ctx, volume, labels)
+	}
+	return nil, nil, nil
+}
+
+// ResizeVolume calls the mocked ResizeVolumeFunc
+func (m *MockVolumeClient) ResizeVolume(ctx context.Context, volume *hcloud.Volume, size int) (*hcloud.Action, *hcloud.Response, error) {
+	if m.ResizeVolumeFunc != nil {
+		return m.ResizeVolumeFunc(ctx, volume, size)
+	}
+	return nil, nil, nil
+}
+
+// DeleteVolume calls the mocked DeleteVolumeFunc
+func (m *MockVolumeClient) DeleteVolume(ctx context.Context, volume *hcloud.Volume) (*hcloud.Response, error) {
+	if m.DeleteVolumeFunc != nil {
+		return m.DeleteVolumeFunc(ctx, volume)
+	}
+	return nil, nil
+}
+
+// ListVolumes calls the mocked ListVolumesFunc
+func (m *MockVolumeClient) ListVolumes(ctx context.Context) ([]*hcloud.Volume, error) {
+	if m.ListVolumesFunc != nil {
+		return m.ListVolumesFunc(ctx)
+	}
+	return nil, nil
+}
+
+// AttachVolume calls the mocked AttachVolumeFunc
+func (m *MockVolumeClient) AttachVolume(ctx context.Context, volume *hcloud.Volume, server *hcloud.Server, automount bool) (*hcloud.Action, *hcloud.Response, error) {
+	if m.AttachVolumeFunc != nil {
+		return m.AttachVolumeFunc(ctx, volume, server, automount)
+	}
+	return nil, nil, nil
+}
+
+// DetachVolume calls the mocked DetachVolumeFunc
+func (m *MockVolumeClient) DetachVolume(ctx context.Context, volume *hcloud.Volume) (*hcloud.Action, *hcloud.Response, error) {
+	if m.DetachVolumeFunc != nil {
+		return m.DetachVolumeFunc(ctx, volume)
+	}
+	return nil, nil, nil
+}
+
+// GetAction calls the mocked GetActionFunc
+func (m *MockVolumeClient) GetAction(ctx context.Context, id int64) (*hcloud.Action, *hcloud.Response, error) {
+	if m.GetActionFunc != nil {
+		return m.GetActionFunc(ctx, id)
+	}
+	return nil, nil, nil
+}
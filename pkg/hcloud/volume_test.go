@@ -0,0 +1,76 @@
+package hcloud
+
+import (
+	"context"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+	"github.com/hetznercloud/hcloud-go/v2/hcloud/schema"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("VolumeManager", func() {
+
+	var mockVolumeClient *MockVolumeClient
+	var vc VolumeClient
+
+	BeforeEach(func() {
+		mockVolumeClient = &MockVolumeClient{}
+		vc = VolumeClient(mockVolumeClient)
+	})
+
+	Describe("CreateVolume", func() {
+		When("the volume is created attached to a server", func() {
+			BeforeEach(func() {
+				mockVolumeClient.CreateVolumeFunc = func(ctx context.Context, opts hcloud.VolumeCreateOpts) (*hcloud.VolumeCreateResult, *hcloud.Response, error) {
+					return &hcloud.VolumeCreateResult{
+						Volume:      &hcloud.Volume{ID: 456, Name: opts.Name, Size: opts.Size, Server: opts.Server, Format: opts.Format},
+						Action:      &hcloud.Action{ID: 1, Command: "create_volume", Status: hcloud.ActionStatusRunning},
+						NextActions: []*hcloud.Action{{ID: 2, Command: "attach_volume", Status: hcloud.ActionStatusRunning}},
+					}, nil, nil
+				}
+			})
+
+			It("should return the volume with the create and attach actions", func() {
+				result, _, err := vc.CreateVolume(context.Background(), hcloud.VolumeCreateOpts{
+					Name:      "data",
+					Size:      20,
+					Server:    &hcloud.Server{ID: 123},
+					Automount: hcloud.Ptr(true),
+					Format:    hcloud.Ptr(hcloud.VolumeFormatExt4),
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Volume.ID).To(Equal(int64(456)))
+				Expect(result.Volume.Server.ID).To(Equal(int64(123)))
+				Expect(result.NextActions).To(HaveLen(1))
+				Expect(result.NextActions[0].Command).To(Equal("attach_volume"))
+			})
+		})
+	})
+
+	Describe("ResizeVolume", func() {
+		When("the volume would shrink", func() {
+			BeforeEach(func() {
+				mockVolumeClient.ResizeVolumeFunc = func(ctx context.Context, volume *hcloud.Volume, size int) (*hcloud.Action, *hcloud.Response, error) {
+					return nil, nil, hcloud.ErrorFromSchema(schema.Error{Code: string(hcloud.ErrorCodeInvalidInput), Message: "size must be greater than current size"})
+				}
+			})
+
+			It("should return the API error", func() {
+				action, _, err := vc.ResizeVolume(context.Background(), &hcloud.Volume{ID: 456, Size: 50}, 20)
+				Expect(hcloud.IsError(err, hcloud.ErrorCodeInvalidInput)).To(BeTrue())
+				Expect(action).To(BeNil())
+			})
+		})
+	})
+
+	Describe("DetachVolume", func() {
+		When("the call is not mocked", func() {
+			It("should return no action", func() {
+				action, _, err := vc.DetachVolume(context.Background(), &hcloud.Volume{ID: 456})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(action).To(BeNil())
+			})
+		})
+	})
+})
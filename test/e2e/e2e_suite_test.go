@@ -43,6 +43,10 @@ var (
 	// projectImage is the name of the image which will be build and loaded
 	// with the code source changes to be tested.
 	projectImage = "example.com/hcrm:v0.0.1"
+
+	// fakeHcloudImage is the name of the fake Hetzner Cloud API image which will be build and
+	// loaded, the manager is pointed to it instead of the real API.
+	fakeHcloudImage = "example.com/fake-hcloud:v0.0.1"
 )
 
 // TestE2E runs the end-to-end (e2e) test suite for the project. These tests execute in an isolated,
@@ -67,6 +71,15 @@ var _ = BeforeSuite(func() {
 	err = utils.LoadImageToKindClusterWithName(projectImage)
 	ExpectWithOffset(1, err).NotTo(HaveOccurred(), "Failed to load the manager(Operator) image into Kind")
 
+	By("building the fake Hetzner Cloud API image")
+	cmd = exec.Command("make", "docker-build-fake-hcloud", fmt.Sprintf("FAKE_HCLOUD_IMG=%s", fakeHcloudImage))
+	_, err = utils.Run(cmd)
+	ExpectWithOffset(1, err).NotTo(HaveOccurred(), "Failed to build the fake Hetzner Cloud API image")
+
+	By("loading the fake Hetzner Cloud API image on Kind")
+	err = utils.LoadImageToKindClusterWithName(fakeHcloudImage)
+	ExpectWithOffset(1, err).NotTo(HaveOccurred(), "Failed to load the fake Hetzner Cloud API image into Kind")
+
 	// The tests-e2e are intended to run on a temporary cluster that is created and destroyed for testing.
 	// To prevent errors when tests run in environments with CertManager already installed,
 	// we check for its presence before execution.